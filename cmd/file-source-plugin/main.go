@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// file-source-plugin is the reference implementation of a trust-manager source
+// plugin. It serves the PEM files below its root directory, such as those of
+// a mounted ConfigMap, as the certificates of plugin sources:
+//
+//	file-source-plugin [-socket /run/trust-manager/plugins/file.sock] [-root /certs]
+//
+// Bundles select a file with the "path" parameter of their source:
+//
+//	sources:
+//	- plugin:
+//	    name: file
+//	    parameters:
+//	      path: corporate/root-ca.pem
+//
+// The plugin reports itself as serving while its root directory exists.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/debug"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cert-manager/trust-manager/pkg/sourceplugin"
+	sourcepluginv1 "github.com/cert-manager/trust-manager/pkg/sourceplugin/api/v1"
+)
+
+// pluginName is the name of the plugin, which Bundles reference.
+const pluginName = "file"
+
+// maxFileSize limits the size of the files served.
+const maxFileSize = 4 << 20
+
+func main() {
+	stderrLogger := log.New(os.Stderr, "", log.LstdFlags)
+
+	socket := flag.String("socket", sourceplugin.SocketPath("/run/trust-manager/plugins", pluginName), "Path of the unix socket to serve on.")
+	root := flag.String("root", "/certs", "Directory holding the PEM files which are served.")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	p := &plugin{root: *root}
+	if err := sourceplugin.Serve(ctx, *socket, p, p.healthy); err != nil {
+		stderrLogger.Printf("failed to serve source plugin: %s", err.Error())
+		os.Exit(1)
+	}
+}
+
+// plugin serves the PEM files below its root directory.
+type plugin struct {
+	sourcepluginv1.UnimplementedSourcePluginServer
+
+	root string
+}
+
+func (p *plugin) GetInfo(context.Context, *sourcepluginv1.GetInfoRequest) (*sourcepluginv1.GetInfoResponse, error) {
+	version := "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version
+	}
+	return &sourcepluginv1.GetInfoResponse{Name: pluginName, Version: version}, nil
+}
+
+func (p *plugin) FetchCertificates(_ context.Context, req *sourcepluginv1.FetchCertificatesRequest) (*sourcepluginv1.FetchCertificatesResponse, error) {
+	path := req.Parameters["path"]
+	if path == "" {
+		return nil, status.Error(codes.InvalidArgument, `the "path" parameter is required`)
+	}
+	if !filepath.IsLocal(path) {
+		return nil, status.Errorf(codes.InvalidArgument, "path %q must be relative to the root directory, without ..", path)
+	}
+
+	data, err := readFile(filepath.Join(p.root, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, status.Errorf(codes.NotFound, "file %q not found", path)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read file %q: %s", path, err)
+	}
+
+	return &sourcepluginv1.FetchCertificatesResponse{Certificates: string(data)}, nil
+}
+
+// healthy returns an error if the root directory doesn't exist.
+func (p *plugin) healthy(context.Context) error {
+	info, err := os.Stat(p.root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", p.root)
+	}
+	return nil
+}
+
+// readFile reads the file at the path, failing if it exceeds maxFileSize.
+func readFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxFileSize)
+	}
+	return os.ReadFile(path)
+}
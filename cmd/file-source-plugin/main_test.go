@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sourcepluginv1 "github.com/cert-manager/trust-manager/pkg/sourceplugin/api/v1"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_plugin(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "corporate"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "corporate", "root-ca.pem"), []byte(dummy.TestCertificate1), 0o600))
+
+	p := &plugin{root: root}
+	fetch := func(parameters map[string]string) (string, codes.Code) {
+		resp, err := p.FetchCertificates(context.Background(), &sourcepluginv1.FetchCertificatesRequest{Parameters: parameters})
+		return resp.GetCertificates(), status.Code(err)
+	}
+
+	certificates, code := fetch(map[string]string{"path": "corporate/root-ca.pem"})
+	assert.Equal(t, codes.OK, code)
+	assert.Equal(t, dummy.TestCertificate1, certificates)
+
+	_, code = fetch(nil)
+	assert.Equal(t, codes.InvalidArgument, code)
+
+	_, code = fetch(map[string]string{"path": "../root-ca.pem"})
+	assert.Equal(t, codes.InvalidArgument, code)
+
+	_, code = fetch(map[string]string{"path": "/etc/ssl/certs/ca-certificates.crt"})
+	assert.Equal(t, codes.InvalidArgument, code)
+
+	_, code = fetch(map[string]string{"path": "missing.pem"})
+	assert.Equal(t, codes.NotFound, code)
+
+	assert.NoError(t, p.healthy(context.Background()))
+	assert.Error(t, (&plugin{root: filepath.Join(root, "missing")}).healthy(context.Background()))
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// inspect-trust-package prints the certificates of a default CA package, or
+// the certificates which differ between two packages, so that updates of the
+// package can be reviewed before they're rolled out.
+//
+//	inspect-trust-package [-output table|csv|json|yaml] <package.json>
+//	inspect-trust-package [-output table|csv|json|yaml] -diff <old.json> <new.json>
+//
+// A package path of "-" reads the package from stdin.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/trust-manager/pkg/fspkg"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// Changes of a certificate between two packages.
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+)
+
+// certificate describes a certificate of a package.
+type certificate struct {
+	Subject     string    `json:"subject"`
+	NotAfter    time.Time `json:"notAfter"`
+	Fingerprint string    `json:"fingerprint"`
+
+	// Change is how the certificate changed between two packages, if diffing.
+	Change string `json:"change,omitempty"`
+}
+
+func main() {
+	stderrLogger := log.New(os.Stderr, "", log.LstdFlags)
+
+	output := flag.String("output", "table", "Output format: table, csv, json or yaml.")
+	diff := flag.Bool("diff", false, "Print the certificates added and removed between the two given packages.")
+	flag.Parse()
+
+	certificates, err := run(flag.Args(), *diff)
+	if err != nil {
+		stderrLogger.Printf("failed to inspect trust package: %s", err.Error())
+		os.Exit(1)
+	}
+
+	if err := write(os.Stdout, *output, certificates, *diff); err != nil {
+		stderrLogger.Printf("failed to write certificates: %s", err.Error())
+		os.Exit(1)
+	}
+}
+
+// run returns the certificates of the package at the given path, or the
+// certificates which differ between the packages at the given paths.
+func run(paths []string, diff bool) ([]certificate, error) {
+	if !diff {
+		if len(paths) != 1 {
+			return nil, errors.New("expected the path of one package")
+		}
+		pkg, err := loadPackage(paths[0])
+		if err != nil {
+			return nil, err
+		}
+		return packageCertificates(pkg)
+	}
+
+	if len(paths) != 2 {
+		return nil, errors.New("expected the paths of two packages to diff")
+	}
+	oldPkg, err := loadPackage(paths[0])
+	if err != nil {
+		return nil, err
+	}
+	newPkg, err := loadPackage(paths[1])
+	if err != nil {
+		return nil, err
+	}
+	return diffPackages(oldPkg, newPkg)
+}
+
+func loadPackage(path string) (fspkg.Package, error) {
+	if path == "-" {
+		return fspkg.LoadPackage(os.Stdin)
+	}
+	return fspkg.LoadPackageFromFile(path)
+}
+
+// packageCertificates returns the certificates of the package, sorted by
+// subject. Expired certificates are included.
+func packageCertificates(pkg fspkg.Package) ([]certificate, error) {
+	certPool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
+	if err := certPool.AddCertsFromPEM([]byte(pkg.Bundle)); err != nil {
+		return nil, fmt.Errorf("package %q has an invalid bundle: %w", pkg.StringID(), err)
+	}
+
+	var certificates []certificate
+	for info := range certPool.All() {
+		certificates = append(certificates, certificate{
+			Subject:     info.Subject,
+			NotAfter:    info.NotAfter.UTC(),
+			Fingerprint: info.Fingerprint,
+		})
+	}
+
+	slices.SortStableFunc(certificates, func(a, b certificate) int {
+		return strings.Compare(a.Subject, b.Subject)
+	})
+	return certificates, nil
+}
+
+// diffPackages returns the certificates of newPkg which aren't in oldPkg as
+// added, followed by those of oldPkg which aren't in newPkg as removed.
+func diffPackages(oldPkg, newPkg fspkg.Package) ([]certificate, error) {
+	oldCertificates, err := packageCertificates(oldPkg)
+	if err != nil {
+		return nil, err
+	}
+	newCertificates, err := packageCertificates(newPkg)
+	if err != nil {
+		return nil, err
+	}
+
+	contains := func(certificates []certificate, fingerprint string) bool {
+		return slices.ContainsFunc(certificates, func(c certificate) bool { return c.Fingerprint == fingerprint })
+	}
+
+	var changed []certificate
+	for _, c := range newCertificates {
+		if !contains(oldCertificates, c.Fingerprint) {
+			c.Change = changeAdded
+			changed = append(changed, c)
+		}
+	}
+	for _, c := range oldCertificates {
+		if !contains(newCertificates, c.Fingerprint) {
+			c.Change = changeRemoved
+			changed = append(changed, c)
+		}
+	}
+	return changed, nil
+}
+
+// write writes the certificates in the given output format.
+func write(out io.Writer, output string, certificates []certificate, diff bool) error {
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(emptyIfNil(certificates))
+
+	case "yaml":
+		data, err := yaml.Marshal(emptyIfNil(certificates))
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+
+	case "csv":
+		w := csv.NewWriter(out)
+		header := []string{"subject", "notAfter", "fingerprint"}
+		if diff {
+			header = append([]string{"change"}, header...)
+		}
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		for _, c := range certificates {
+			record := []string{c.Subject, c.NotAfter.Format(time.RFC3339), c.Fingerprint}
+			if diff {
+				record = append([]string{c.Change}, record...)
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+
+	case "table":
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		if diff {
+			fmt.Fprint(w, "CHANGE\t")
+		}
+		fmt.Fprintln(w, "SUBJECT\tNOT AFTER\tFINGERPRINT")
+		for _, c := range certificates {
+			if diff {
+				fmt.Fprintf(w, "%s\t", c.Change)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", c.Subject, c.NotAfter.Format(time.DateOnly), c.Fingerprint)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unsupported output format %q: must be one of table, csv, json or yaml", output)
+	}
+}
+
+// emptyIfNil returns an empty list rather than nil, so that no certificates
+// are encoded as an empty list rather than null.
+func emptyIfNil(certificates []certificate) []certificate {
+	if certificates == nil {
+		return []certificate{}
+	}
+	return certificates
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/trust-manager/pkg/fspkg"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_diffPackages(t *testing.T) {
+	oldPkg := fspkg.Package{Name: "test", Version: "1", Bundle: dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)}
+	newPkg := fspkg.Package{Name: "test", Version: "2", Bundle: dummy.JoinCerts(dummy.TestCertificate2, dummy.TestCertificate3)}
+
+	oldCertificates, err := packageCertificates(oldPkg)
+	require.NoError(t, err)
+	require.Len(t, oldCertificates, 2)
+	newCertificates, err := packageCertificates(newPkg)
+	require.NoError(t, err)
+	require.Len(t, newCertificates, 2)
+
+	changed, err := diffPackages(oldPkg, newPkg)
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+
+	assert.Equal(t, changeAdded, changed[0].Change)
+	assert.NotContains(t, fingerprints(oldCertificates), changed[0].Fingerprint)
+	assert.Contains(t, fingerprints(newCertificates), changed[0].Fingerprint)
+
+	assert.Equal(t, changeRemoved, changed[1].Change)
+	assert.Contains(t, fingerprints(oldCertificates), changed[1].Fingerprint)
+	assert.NotContains(t, fingerprints(newCertificates), changed[1].Fingerprint)
+
+	changed, err = diffPackages(oldPkg, oldPkg)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func Test_write(t *testing.T) {
+	pkg := fspkg.Package{Name: "test", Version: "1", Bundle: dummy.TestCertificate1}
+	certificates, err := packageCertificates(pkg)
+	require.NoError(t, err)
+	c := certificates[0]
+
+	for _, output := range []string{"table", "csv", "json", "yaml"} {
+		t.Run(output, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, write(&out, output, certificates, false))
+			assert.Contains(t, out.String(), c.Fingerprint)
+			assert.Contains(t, out.String(), c.NotAfter.Format("2006-01-02"))
+		})
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, write(&out, "csv", []certificate{{Subject: c.Subject, NotAfter: c.NotAfter, Fingerprint: c.Fingerprint, Change: changeAdded}}, true))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, "change,subject,notAfter,fingerprint", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], changeAdded+","))
+
+	out.Reset()
+	require.NoError(t, write(&out, "json", nil, true))
+	assert.Equal(t, "[]\n", out.String())
+
+	assert.EqualError(t, write(&out, "xml", certificates, false), `unsupported output format "xml": must be one of table, csv, json or yaml`)
+}
+
+func fingerprints(certificates []certificate) []string {
+	var fingerprints []string
+	for _, c := range certificates {
+		fingerprints = append(fingerprints, c.Fingerprint)
+	}
+	return fingerprints
+}
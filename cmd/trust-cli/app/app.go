@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package app implements trust-cli, which inspects the Bundles of a cluster
+// from outside it. Bundles are rendered with the packages the controller
+// uses, from the current state of their sources, using the credentials of
+// a kubeconfig.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle"
+
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+const helpOutput = `trust-cli inspects the Bundles of trust-manager in a cluster
+
+It shows the status of Bundles, compares their targets with the bundle their
+sources currently produce, and exports that bundle in any supported format.`
+
+// options are the options shared by every command.
+type options struct {
+	kubeConfigFlags *genericclioptions.ConfigFlags
+
+	// trustNamespace is the namespace trust-manager reads sources from.
+	trustNamespace string
+
+	// defaultPackageLocation is the path of the default CA package, which
+	// Bundles using default CAs are rendered with.
+	defaultPackageLocation string
+
+	// defaultPackageLayers are the paths of packages layered on top of the
+	// default CA package, in order.
+	defaultPackageLayers []string
+}
+
+// NewCommand returns the root command of trust-cli.
+func NewCommand() *cobra.Command {
+	opts := &options{
+		kubeConfigFlags: genericclioptions.NewConfigFlags(true),
+	}
+
+	cmd := &cobra.Command{
+		Use:           "trust-cli",
+		Short:         helpOutput,
+		Long:          helpOutput,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	opts.kubeConfigFlags.AddFlags(cmd.PersistentFlags())
+	cmd.PersistentFlags().StringVar(&opts.trustNamespace,
+		"trust-namespace", "cert-manager",
+		"Namespace trust-manager sources trust bundles from.")
+	cmd.PersistentFlags().StringVar(&opts.defaultPackageLocation,
+		"default-package-location", "",
+		"Path to a JSON or PEM file containing the default certificate package, used to render Bundles which use default CAs.")
+	cmd.PersistentFlags().StringSliceVar(&opts.defaultPackageLayers,
+		"default-package-layer", nil,
+		"Path to a JSON or PEM file containing a package which is layered on top of the default certificate package. May be repeated; layers are applied in order.")
+
+	cmd.AddCommand(
+		newStatusCommand(opts),
+		newDiffCommand(opts),
+		newExportCommand(opts),
+	)
+
+	return cmd
+}
+
+// client returns a client of the cluster of the kubeconfig.
+func (o *options) client() (client.Client, error) {
+	restConfig, err := o.kubeConfigFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes rest config: %w", err)
+	}
+
+	cl, err := client.New(restConfig, client.Options{Scheme: trustapi.GlobalScheme})
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %w", err)
+	}
+	return cl, nil
+}
+
+// renderer returns a Renderer of Bundles reading sources with the client.
+func (o *options) renderer(cl client.Client) (*bundle.Renderer, error) {
+	return bundle.NewRenderer(cl, bundle.Options{
+		Namespace:              o.trustNamespace,
+		DefaultPackageLocation: o.defaultPackageLocation,
+		DefaultPackageLayers:   o.defaultPackageLayers,
+		// Whether sources may select objects in other Namespaces or
+		// cert-manager Certificates is left to the controller, which rejects
+		// such Bundles if it's disabled.
+		SourceNamespaceSelectorsEnabled: true,
+		CertificateSourcesEnabled:       true,
+	})
+}
+
+// getBundle returns the named Bundle.
+func getBundle(ctx context.Context, cl client.Client, name string) (*trustapi.Bundle, error) {
+	var bundleObj trustapi.Bundle
+	if err := cl.Get(ctx, client.ObjectKey{Name: name}, &bundleObj); err != nil {
+		return nil, fmt.Errorf("failed to get Bundle %q: %w", name, err)
+	}
+	return &bundleObj, nil
+}
+
+// errDiffFound is returned by the diff command if a target differs from the
+// rendered bundle, so that the command exits with a non-zero code.
+var errDiffFound = errors.New("targets differ from the rendered bundle")
+
+// errPreviewFailed is returned by the diff command if a previewed Bundle
+// would be rejected on admission or fail to sync.
+var errPreviewFailed = errors.New("bundles would be rejected or fail to sync")
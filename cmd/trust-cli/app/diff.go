@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+func newDiffCommand(opts *options) *cobra.Command {
+	var filename string
+
+	cmd := &cobra.Command{
+		Use:   "diff (<bundle> | -f <file>)",
+		Short: "Compare the targets of a Bundle with the bundle its sources currently produce",
+		Long: `Compare the targets of a Bundle in a namespace with the bundle its sources
+currently produce. Certificates which would be added to a target are prefixed
+with '+', and those which would be removed with '-'. Exits with a non-zero code
+if any target differs.
+
+With -f, the Bundles of a manifest file are previewed before they're applied,
+such as in the pull requests of a GitOps repository. Each Bundle is validated
+as the trust-manager webhook would on admission, and the target ConfigMaps and
+Secrets which syncing it would create, update or delete are listed by
+namespace, along with their keys. Nothing is written to the cluster. Exits with
+a non-zero code if any target would change, or any Bundle would be rejected or
+fail to sync.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if filename != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := opts.client()
+			if err != nil {
+				return err
+			}
+			renderer, err := opts.renderer(cl)
+			if err != nil {
+				return err
+			}
+
+			if filename != "" {
+				bundles, err := readBundlesFile(cmd.InOrStdin(), filename)
+				if err != nil {
+					return err
+				}
+				return previewBundles(cmd.Context(), cmd.OutOrStdout(), cl, renderer, bundles)
+			}
+
+			namespace, _, err := opts.kubeConfigFlags.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return fmt.Errorf("failed to get namespace: %w", err)
+			}
+
+			bundleObj, err := getBundle(cmd.Context(), cl, args[0])
+			if err != nil {
+				return err
+			}
+
+			rendered, err := renderer.Render(cmd.Context(), bundleObj)
+			if err != nil {
+				return fmt.Errorf("failed to render Bundle %q: %w", bundleObj.Name, err)
+			}
+
+			return diffTargets(cmd.Context(), cmd.OutOrStdout(), cl, bundleObj, namespace, rendered)
+		},
+	}
+
+	cmd.Flags().StringVarP(&filename, "filename", "f", "",
+		"Manifest file of the Bundles to preview, or '-' to read it from stdin. Documents which aren't Bundles are ignored.")
+
+	return cmd
+}
+
+// diffTargets writes the difference between each target of the Bundle in the
+// namespace and the rendered PEM bundle.
+func diffTargets(ctx context.Context, out io.Writer, cl client.Client, bundleObj *trustapi.Bundle, namespace, rendered string) error {
+	var namespaceObj corev1.Namespace
+	if err := cl.Get(ctx, client.ObjectKey{Name: namespace}, &namespaceObj); err != nil {
+		return fmt.Errorf("failed to get namespace %q: %w", namespace, err)
+	}
+
+	differs := false
+	for _, t := range bundleObj.Spec.AllTargets() {
+		var (
+			kind string
+			key  string
+			obj  client.Object
+		)
+		switch {
+		case t.ConfigMap != nil:
+			kind, key, obj = "ConfigMap", t.ConfigMap.Key, &corev1.ConfigMap{}
+		case t.Secret != nil:
+			if t.Secret.Immutable {
+				fmt.Fprintf(out, "Secret %s/%s: skipped, as immutable target Secrets are named after their content\n", namespace, bundleObj.Name)
+				continue
+			}
+			kind, key, obj = "Secret", t.Secret.Key, &corev1.Secret{}
+		default:
+			continue
+		}
+		key = overriddenKey(t, &namespaceObj, key)
+
+		name := fmt.Sprintf("%s %s/%s key %q", kind, namespace, bundleObj.Name, key)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: bundleObj.Name}, obj); apierrors.IsNotFound(err) {
+			fmt.Fprintf(out, "%s: not found\n", name)
+			differs = true
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get %s: %w", name, err)
+		}
+
+		var live string
+		switch obj := obj.(type) {
+		case *corev1.ConfigMap:
+			live = obj.Data[key]
+		case *corev1.Secret:
+			live = string(obj.Data[key])
+		}
+
+		added, removed, err := diffCertificates(live, rendered)
+		if err != nil {
+			return fmt.Errorf("failed to compare %s: %w", name, err)
+		}
+		if len(added) == 0 && len(removed) == 0 {
+			fmt.Fprintf(out, "%s: up to date\n", name)
+			continue
+		}
+
+		differs = true
+		fmt.Fprintf(out, "%s:\n", name)
+		for _, cert := range added {
+			fmt.Fprintf(out, "+ %s (%s)\n", cert.Subject, cert.Fingerprint)
+		}
+		for _, cert := range removed {
+			fmt.Fprintf(out, "- %s (%s)\n", cert.Subject, cert.Fingerprint)
+		}
+	}
+
+	if differs {
+		return errDiffFound
+	}
+	return nil
+}
+
+// overriddenKey returns the key the target is written to in the namespace,
+// taking key overrides into account.
+func overriddenKey(t trustapi.BundleTarget, namespace *corev1.Namespace, key string) string {
+	for _, override := range t.KeyOverrides {
+		selector, err := metav1.LabelSelectorAsSelector(&override.NamespaceSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(namespace.Labels)) {
+			return override.Key
+		}
+	}
+	return key
+}
+
+// diffCertificates returns the certificates of the rendered bundle which
+// aren't in the live bundle, and those of the live bundle which aren't in the
+// rendered one.
+func diffCertificates(live, rendered string) (added, removed []util.CertificateInfo, err error) {
+	livePool, renderedPool := util.NewCertPool(), util.NewCertPool()
+	// A live bundle holding no certificates differs from the rendered bundle
+	// by all of its certificates.
+	if live != "" {
+		if err := livePool.AddCertsFromPEM([]byte(live)); err != nil {
+			return nil, nil, fmt.Errorf("invalid target bundle: %w", err)
+		}
+	}
+	if err := renderedPool.AddCertsFromPEM([]byte(rendered)); err != nil {
+		return nil, nil, fmt.Errorf("invalid rendered bundle: %w", err)
+	}
+
+	liveFingerprints := map[string]bool{}
+	for cert := range livePool.All() {
+		liveFingerprints[cert.Fingerprint] = true
+	}
+	renderedFingerprints := map[string]bool{}
+	for cert := range renderedPool.All() {
+		renderedFingerprints[cert.Fingerprint] = true
+		if !liveFingerprints[cert.Fingerprint] {
+			added = append(added, cert)
+		}
+	}
+	for cert := range livePool.All() {
+		if !renderedFingerprints[cert.Fingerprint] {
+			removed = append(removed, cert)
+		}
+	}
+
+	return added, removed, nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_diffTargets(t *testing.T) {
+	bundleObj := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+		Spec: trustapi.BundleSpec{
+			Targets: []trustapi.BundleTarget{{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				KeyOverrides: []trustapi.KeyOverride{{
+					NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "java"}},
+					Key:               "ca.crt",
+				}},
+			}},
+		},
+	}
+	rendered := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)
+
+	tests := map[string]struct {
+		objects   []client.Object
+		expOutput string
+		expDiff   bool
+	}{
+		"target is up to date": {
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bundle"},
+					Data:       map[string]string{"trust.pem": dummy.JoinCerts(dummy.TestCertificate2, dummy.TestCertificate1)},
+				},
+			},
+			expOutput: "ConfigMap ns/bundle key \"trust.pem\": up to date\n",
+		},
+		"target differs at the overridden key": {
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{"team": "java"}}},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bundle"},
+					Data:       map[string]string{"ca.crt": dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate3)},
+				},
+			},
+			expOutput: "ConfigMap ns/bundle key \"ca.crt\":\n" +
+				"+ " + certificateSummary(t, dummy.TestCertificate2) + "\n" +
+				"- " + certificateSummary(t, dummy.TestCertificate3) + "\n",
+			expDiff: true,
+		},
+		"target doesn't exist": {
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+			},
+			expOutput: "ConfigMap ns/bundle key \"trust.pem\": not found\n",
+			expDiff:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).WithObjects(test.objects...).Build()
+
+			var out bytes.Buffer
+			err := diffTargets(context.Background(), &out, cl, bundleObj, "ns", rendered)
+			if test.expDiff {
+				assert.ErrorIs(t, err, errDiffFound)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expOutput, out.String())
+		})
+	}
+}
+
+func Test_readBundles(t *testing.T) {
+	bundles, err := readBundles(strings.NewReader(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-bundle
+---
+apiVersion: trust.cert-manager.io/v1alpha1
+kind: Bundle
+metadata:
+  name: alpha
+spec:
+  sources:
+  - useDefaultCAs: true
+  target:
+    configMap:
+      key: trust.pem
+---
+apiVersion: trust.cert-manager.io/v1beta1
+kind: Bundle
+metadata:
+  name: beta
+spec:
+  sources:
+  - useDefaultCAs: true
+  targets:
+  - configMap:
+      key: ca.crt
+`))
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+	assert.Equal(t, "alpha", bundles[0].Name)
+	assert.Equal(t, "v1alpha1", bundles[0].version)
+	assert.Equal(t, "trust.pem", bundles[0].Spec.Target.ConfigMap.Key)
+	assert.Equal(t, "beta", bundles[1].Name)
+	assert.Equal(t, "v1beta1", bundles[1].version)
+	require.Len(t, bundles[1].Spec.AllTargets(), 1)
+	assert.Equal(t, "ca.crt", bundles[1].Spec.AllTargets()[0].ConfigMap.Key)
+
+	_, err = readBundles(strings.NewReader(`apiVersion: trust.cert-manager.io/v1alpha1
+kind: Bundle
+metadata:
+  name: typo
+spec:
+  sorces: []
+`))
+	assert.Error(t, err)
+}
+
+func Test_previewBundles(t *testing.T) {
+	newBundle := func(name, inLine string) []manifestBundle {
+		return []manifestBundle{{
+			Bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: &inLine}},
+					Target:  trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+				},
+			},
+			version: "v1alpha1",
+		}}
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-2"}},
+		).
+		Build()
+	renderer, err := bundle.NewRenderer(cl, bundle.Options{Namespace: "cert-manager"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = previewBundles(context.Background(), &out, cl, renderer, newBundle("valid", dummy.TestCertificate1))
+	assert.ErrorIs(t, err, errDiffFound)
+	assert.Equal(t, `Bundle valid:
+  warning: spec.target: spec.target is deprecated and not served in trust.cert-manager.io/v1beta1; move the target to spec.targets
+  namespace ns-1:
+    + ConfigMap valid (keys: trust.pem)
+  namespace ns-2:
+    + ConfigMap valid (keys: trust.pem)
+  0 of 2 targets up to date
+`, out.String())
+
+	out.Reset()
+	err = previewBundles(context.Background(), &out, cl, renderer, newBundle("invalid", "not a certificate"))
+	assert.ErrorIs(t, err, errPreviewFailed)
+	assert.Contains(t, out.String(), "Bundle invalid:\n  rejected: ")
+}
+
+func Test_printStatus(t *testing.T) {
+	bundleObj := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Generation: 2},
+		Status: trustapi.BundleStatus{
+			TargetCount:       3,
+			SyncedTargetCount: 2,
+			Conditions: []trustapi.BundleCondition{{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "SyncConfigMapTargetFailed",
+				Message:            "Failed to sync bundle to ns",
+				ObservedGeneration: 2,
+			}},
+		},
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, printStatus(&out, bundleObj))
+	assert.Equal(t, `Bundle:      bundle
+Generation:  2
+Targets:     2 synced of 3
+
+TYPE    STATUS  REASON                     GENERATION  MESSAGE
+Synced  False   SyncConfigMapTargetFailed  2           Failed to sync bundle to ns
+`, out.String())
+}
+
+// certificateSummary returns the subject and fingerprint of the certificate,
+// as written by the diff command.
+func certificateSummary(t *testing.T, certPEM string) string {
+	t.Helper()
+
+	added, _, err := diffCertificates("", certPEM)
+	if err != nil || len(added) != 1 {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return added[0].Subject + " (" + added[0].Fingerprint + ")"
+}
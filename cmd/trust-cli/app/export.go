@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cert-manager/trust-manager/pkg/bundle"
+)
+
+func newExportCommand(opts *options) *cobra.Command {
+	var (
+		format     string
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <bundle>",
+		Short: "Render a Bundle from the current state of its sources, and write it in the given format",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var renderFormat bundle.Format
+			switch format {
+			case "pem":
+				renderFormat = bundle.FormatPEM
+			case "jks":
+				renderFormat = bundle.FormatJKS
+			case "pkcs12", "p12":
+				renderFormat = bundle.FormatPKCS12
+			default:
+				return fmt.Errorf(`unsupported format %q: must be one of "pem", "jks" or "pkcs12"`, format)
+			}
+
+			cl, err := opts.client()
+			if err != nil {
+				return err
+			}
+			renderer, err := opts.renderer(cl)
+			if err != nil {
+				return err
+			}
+
+			bundleObj, err := getBundle(cmd.Context(), cl, args[0])
+			if err != nil {
+				return err
+			}
+
+			content, err := renderer.RenderFormat(cmd.Context(), bundleObj, renderFormat)
+			if err != nil {
+				return fmt.Errorf("failed to render Bundle %q: %w", bundleObj.Name, err)
+			}
+
+			if outputFile == "" {
+				_, err = cmd.OutOrStdout().Write(content)
+				return err
+			}
+			return os.WriteFile(outputFile, content, 0o600)
+		},
+	}
+
+	cmd.Flags().StringVar(&format,
+		"format", "pem",
+		"Format to write the bundle in: pem, jks or pkcs12. Truststores use the passwords of the Bundle's targets, or the defaults.")
+	cmd.Flags().StringVar(&outputFile,
+		"output-file", "",
+		"File to write the bundle to. Written to stdout if empty.")
+
+	return cmd
+}
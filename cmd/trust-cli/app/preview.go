@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	trustv1beta1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1beta1"
+	"github.com/cert-manager/trust-manager/pkg/bundle"
+	"github.com/cert-manager/trust-manager/pkg/webhook"
+)
+
+// manifestBundle is a Bundle read from a manifest, converted to v1alpha1.
+type manifestBundle struct {
+	*trustapi.Bundle
+
+	// version is the API version the Bundle was written in.
+	version string
+}
+
+// readBundlesFile reads the Bundles of the manifest file, or of stdin if the
+// filename is "-".
+func readBundlesFile(stdin io.Reader, filename string) ([]manifestBundle, error) {
+	if filename == "-" {
+		return readBundles(stdin)
+	}
+
+	file, err := os.Open(filename) // #nosec G304 -- the file is chosen by the user running the command
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	bundles, err := readBundles(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	return bundles, nil
+}
+
+// readBundles decodes the Bundles of a multi-document YAML or JSON manifest,
+// in either API version, ignoring other documents.
+func readBundles(r io.Reader) ([]manifestBundle, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+
+	var bundles []manifestBundle
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		gv, err := schema.ParseGroupVersion(typeMeta.APIVersion)
+		if err != nil || gv.Group != trustapi.SchemeGroupVersion.Group || typeMeta.Kind != "Bundle" {
+			continue
+		}
+
+		bundleObj := &trustapi.Bundle{}
+		switch gv.Version {
+		case trustapi.SchemeGroupVersion.Version:
+			if err := yaml.UnmarshalStrict(doc, bundleObj); err != nil {
+				return nil, fmt.Errorf("failed to decode Bundle: %w", err)
+			}
+		case trustv1beta1.SchemeGroupVersion.Version:
+			var v1beta1Bundle trustv1beta1.Bundle
+			if err := yaml.UnmarshalStrict(doc, &v1beta1Bundle); err != nil {
+				return nil, fmt.Errorf("failed to decode Bundle: %w", err)
+			}
+			if err := v1beta1Bundle.ConvertTo(bundleObj); err != nil {
+				return nil, fmt.Errorf("failed to convert Bundle %q: %w", v1beta1Bundle.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported Bundle API version %q", typeMeta.APIVersion)
+		}
+		bundles = append(bundles, manifestBundle{Bundle: bundleObj, version: gv.Version})
+	}
+
+	return bundles, nil
+}
+
+// previewBundles validates each Bundle as the webhook would, as an update of
+// the Bundle of the same name if one exists, and writes the changes syncing
+// it would make to its targets.
+func previewBundles(ctx context.Context, out io.Writer, cl client.Client, renderer *bundle.Renderer, bundles []manifestBundle) error {
+	var differs, failed bool
+	for _, manifest := range bundles {
+		bundleObj := manifest.Bundle
+		fmt.Fprintf(out, "Bundle %s:\n", bundleObj.Name)
+
+		var live *trustapi.Bundle
+		var liveObj trustapi.Bundle
+		if err := cl.Get(ctx, client.ObjectKey{Name: bundleObj.Name}, &liveObj); err == nil {
+			live = &liveObj
+			// Targets belong to the Bundle by its UID.
+			bundleObj.UID = live.UID
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Bundle %q: %w", bundleObj.Name, err)
+		}
+
+		// Options of the controller which can't be known here, such as whether
+		// signing is enabled, are left to the cluster.
+		webhookOpts := webhook.Options{Log: logr.Discard(), SigningEnabled: true, SourceNamespaceSelectorsEnabled: true, CertificateSourcesEnabled: true}
+		warnings, err := webhook.Validate(ctx, webhookOpts, cl, manifest.version, bundleObj, live)
+		for _, warning := range warnings {
+			fmt.Fprintf(out, "  warning: %s\n", warning)
+		}
+		if err != nil {
+			fmt.Fprintf(out, "  rejected: %s\n", err)
+			failed = true
+			continue
+		}
+
+		changes, err := renderer.Plan(ctx, bundleObj)
+		if err != nil {
+			fmt.Fprintf(out, "  sync would fail: %s\n", err)
+			failed = true
+			continue
+		}
+		if printPlan(out, changes) {
+			differs = true
+		}
+	}
+
+	switch {
+	case failed:
+		return errPreviewFailed
+	case differs:
+		return errDiffFound
+	}
+	return nil
+}
+
+// printPlan writes the changes to targets by namespace, and returns true if
+// any target would change.
+func printPlan(out io.Writer, changes []bundle.TargetChange) bool {
+	var (
+		namespace string
+		upToDate  int
+	)
+	for _, change := range changes {
+		if change.Action == bundle.TargetActionNone {
+			upToDate++
+			continue
+		}
+
+		if change.Namespace != namespace {
+			namespace = change.Namespace
+			fmt.Fprintf(out, "  namespace %s:\n", namespace)
+		}
+
+		switch change.Action {
+		case bundle.TargetActionCreate:
+			fmt.Fprintf(out, "    + %s %s (keys: %s)\n", change.Kind, change.Name, strings.Join(change.AddedKeys, ", "))
+		case bundle.TargetActionDelete:
+			fmt.Fprintf(out, "    - %s %s\n", change.Kind, change.Name)
+		case bundle.TargetActionUpdate:
+			var keys []string
+			for _, k := range []struct {
+				name string
+				keys []string
+			}{{"added", change.AddedKeys}, {"changed", change.ChangedKeys}, {"removed", change.RemovedKeys}} {
+				if len(k.keys) > 0 {
+					keys = append(keys, k.name+": "+strings.Join(k.keys, ", "))
+				}
+			}
+			fmt.Fprintf(out, "    ~ %s %s (%s)\n", change.Kind, change.Name, strings.Join(keys, "; "))
+		}
+	}
+
+	fmt.Fprintf(out, "  %d of %d targets up to date\n", upToDate, len(changes))
+	return upToDate != len(changes)
+}
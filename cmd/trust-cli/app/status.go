@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func newStatusCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <bundle>",
+		Short: "Show the status of a Bundle, as last reported by trust-manager",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			bundleObj, err := getBundle(cmd.Context(), cl, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printStatus(cmd.OutOrStdout(), bundleObj)
+		},
+	}
+}
+
+// printStatus writes a summary of the status of the Bundle.
+func printStatus(out io.Writer, bundleObj *trustapi.Bundle) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Bundle:\t%s\n", bundleObj.Name)
+	fmt.Fprintf(w, "Generation:\t%d\n", bundleObj.Generation)
+	fmt.Fprintf(w, "Targets:\t%d synced of %d\n", bundleObj.Status.SyncedTargetCount, bundleObj.Status.TargetCount)
+	if lastSync := bundleObj.Status.LastSyncTime; lastSync != nil {
+		fmt.Fprintf(w, "Last synced:\t%s\n", lastSync.UTC().Format(time.RFC3339))
+	}
+	if version := bundleObj.Status.DefaultCAPackageVersion; version != nil && *version != "" {
+		fmt.Fprintf(w, "Default CA package:\t%s\n", *version)
+	}
+	if filtered := bundleObj.Status.FilteredCertificates; filtered != nil {
+		fmt.Fprintf(w, "Filtered certificates:\t%d\n", filtered.Count)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TYPE\tSTATUS\tREASON\tGENERATION\tMESSAGE")
+	for _, condition := range bundleObj.Status.Conditions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", condition.Type, condition.Status, condition.Reason, condition.ObservedGeneration, condition.Message)
+	}
+
+	return w.Flush()
+}
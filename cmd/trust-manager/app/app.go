@@ -17,13 +17,20 @@ limitations under the License.
 package app
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -37,6 +44,12 @@ import (
 	"github.com/cert-manager/trust-manager/cmd/trust-manager/app/options"
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
 	"github.com/cert-manager/trust-manager/pkg/bundle"
+	"github.com/cert-manager/trust-manager/pkg/bundleserver"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+	"github.com/cert-manager/trust-manager/pkg/hub"
+	"github.com/cert-manager/trust-manager/pkg/integration"
+	"github.com/cert-manager/trust-manager/pkg/permissions"
 	"github.com/cert-manager/trust-manager/pkg/webhook"
 )
 
@@ -70,10 +83,80 @@ func NewCommand() *cobra.Command {
 
 			ctrl.SetLogger(mlog)
 
+			mlog.Info("cloud source providers", "providers", cloudsource.Registered())
+
 			eventBroadcaster := record.NewBroadcaster()
 			eventBroadcaster.StartLogging(func(format string, args ...any) { mlog.V(3).Info(fmt.Sprintf(format, args...)) })
 			eventBroadcaster.StartRecordingToSink(&clientv1.EventSinkImpl{Interface: cl.CoreV1().Events("")})
 
+			// The webhook server is only started by the manager once a webhook
+			// is registered, so it's left unconfigured when disabled.
+			var (
+				webhookCertWatcher *webhook.CertificateWatcher
+				webhookServer      ctrlwebhook.Server
+			)
+			if opts.Webhook.Enabled {
+				webhookCertWatcher, err = webhook.NewCertificateWatcher(opts.Logr.WithName("webhook"), opts.Webhook.CertDir, opts.Webhook.CertReloadInterval)
+				if err != nil {
+					return err
+				}
+
+				webhookServer = ctrlwebhook.NewServer(ctrlwebhook.Options{
+					Port: opts.Webhook.Port,
+					Host: opts.Webhook.Host,
+					TLSOpts: []func(*tls.Config){
+						func(config *tls.Config) {
+							config.GetCertificate = webhookCertWatcher.GetCertificate
+						},
+					},
+				})
+			}
+
+			// Sources are read from the trust namespace, or from any namespace
+			// if sources may select objects in other namespaces.
+			sourceNamespaces := map[string]cache.Config{opts.Bundle.Namespace: {}}
+			if opts.Bundle.SourceNamespaceSelectorsEnabled {
+				if len(opts.Bundle.WatchNamespaces) == 0 {
+					sourceNamespaces = map[string]cache.Config{cache.AllNamespaces: {}}
+				}
+				for _, namespace := range opts.Bundle.WatchNamespaces {
+					sourceNamespaces[namespace] = cache.Config{}
+				}
+			}
+
+			// The Secrets of ClusterIssuers, and the CA Certificates of
+			// SelfSigned ClusterIssuers, are read from the cluster resource
+			// namespace.
+			issuerNamespaces := map[string]cache.Config{opts.Bundle.Namespace: {}, opts.Bundle.ClusterResourceNamespace: {}}
+			secretNamespaces := maps.Clone(sourceNamespaces)
+			if _, ok := secretNamespaces[cache.AllNamespaces]; !ok {
+				secretNamespaces[opts.Bundle.ClusterResourceNamespace] = cache.Config{}
+			}
+
+			cacheByObject := map[client.Object]cache.ByObject{
+				&trustapi.Bundle{}:  {},
+				&corev1.Namespace{}: {},
+				&corev1.ConfigMap{}: {
+					// Only cache full ConfigMaps in the source namespaces.
+					// Target ConfigMaps have a dedicated cache
+					Namespaces: sourceNamespaces,
+				},
+				&corev1.Secret{}: {
+					// Only cache full Secrets in the source and issuer
+					// namespaces. Target Secrets have a dedicated cache
+					Namespaces: secretNamespaces,
+				},
+			}
+			if opts.Bundle.CertificateSourcesEnabled {
+				// Only cache the metadata of cert-manager Certificates in the
+				// trust and cluster resource namespaces.
+				certificate := &metav1.PartialObjectMetadata{}
+				certificate.SetGroupVersionKind(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"})
+				cacheByObject[certificate] = cache.ByObject{
+					Namespaces: issuerNamespaces,
+				}
+			}
+
 			mgr, err := ctrl.NewManager(opts.RestConfig, ctrl.Options{
 				Scheme:                        trustapi.GlobalScheme,
 				EventBroadcaster:              eventBroadcaster,
@@ -82,48 +165,39 @@ func NewCommand() *cobra.Command {
 				LeaderElectionReleaseOnCancel: true,
 				LeaseDuration:                 &opts.LeaseDuration,
 				RenewDeadline:                 &opts.RenewDeadline,
+				RetryPeriod:                   &opts.RetryPeriod,
 				ReadinessEndpointName:         opts.ReadyzPath,
 				HealthProbeBindAddress:        fmt.Sprintf("0.0.0.0:%d", opts.ReadyzPort),
-				WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
-					Port:    opts.Webhook.Port,
-					Host:    opts.Webhook.Host,
-					CertDir: opts.Webhook.CertDir,
-				}),
+				WebhookServer:                 webhookServer,
 				Metrics: server.Options{
 					BindAddress: fmt.Sprintf("0.0.0.0:%d", opts.MetricsPort),
 				},
 				Logger: mlog,
 				Cache: cache.Options{
 					ReaderFailOnMissingInformer: true,
-					ByObject: map[client.Object]cache.ByObject{
-						&trustapi.Bundle{}:  {},
-						&corev1.Namespace{}: {},
-						&corev1.ConfigMap{}: {
-							// Only cache full ConfigMaps in the "watched" namespace.
-							// Target ConfigMaps have a dedicated cache
-							Namespaces: map[string]cache.Config{
-								opts.Bundle.Namespace: {},
-							},
-						},
-						&corev1.Secret{}: {
-							// Only cache full Secrets in the "watched" namespace.
-							// Target Secrets have a dedicated cache
-							Namespaces: map[string]cache.Config{
-								opts.Bundle.Namespace: {},
-							},
-						},
-					},
+					ByObject:                    cacheByObject,
 				},
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create manager: %w", err)
 			}
 
+			// In namespaced mode, trust-manager may only list and watch targets
+			// in the watched namespaces.
+			var targetNamespaces map[string]cache.Config
+			if len(opts.Bundle.WatchNamespaces) > 0 {
+				targetNamespaces = make(map[string]cache.Config, len(opts.Bundle.WatchNamespaces))
+				for _, namespace := range opts.Bundle.WatchNamespaces {
+					targetNamespaces[namespace] = cache.Config{}
+				}
+			}
+
 			targetCache, err := cache.New(mgr.GetConfig(), cache.Options{
 				HTTPClient:                  mgr.GetHTTPClient(),
 				Scheme:                      mgr.GetScheme(),
 				Mapper:                      mgr.GetRESTMapper(),
 				ReaderFailOnMissingInformer: true,
+				DefaultNamespaces:           targetNamespaces,
 				DefaultLabelSelector: func() labels.Selector {
 					targetRequirement, err := labels.NewRequirement(trustapi.BundleLabelKey, selection.Exists, nil)
 					if err != nil {
@@ -141,6 +215,18 @@ func NewCommand() *cobra.Command {
 				return fmt.Errorf("failed to add target cache to manager: %w", err)
 			}
 
+			if webhookCertWatcher != nil {
+				if err := mgr.Add(webhookCertWatcher); err != nil {
+					return fmt.Errorf("failed to add webhook certificate watcher to manager: %w", err)
+				}
+			}
+
+			if configReloader := opts.NewConfigReloader(); configReloader != nil {
+				if err := mgr.Add(configReloader); err != nil {
+					return fmt.Errorf("failed to add config reloader to manager: %w", err)
+				}
+			}
+
 			// Add readiness check that the manager's informers have been synced.
 			if err := mgr.AddReadyzCheck("informers_synced", func(req *http.Request) error {
 				if mgr.GetCache().WaitForCacheSync(req.Context()) {
@@ -151,16 +237,131 @@ func NewCommand() *cobra.Command {
 				return fmt.Errorf("failed to add readiness check: %w", err)
 			}
 
-			ctx := ctrl.SetupSignalHandler()
+			// Check that trust-manager holds the RBAC permissions it needs, so
+			// that a drifted ClusterRole is reported on startup rather than as
+			// failures to sync some targets.
+			if opts.PermissionsCheckInterval > 0 {
+				required := bundle.RequiredPermissions(opts.Bundle)
+				if opts.BundleServer.Serve {
+					required = append(required, permissions.Permission{Verb: "create", Group: "authentication.k8s.io", Resource: "tokenreviews"})
+				}
+				if opts.HubAgent.URL != "" {
+					for _, verb := range []string{"create", "update", "delete"} {
+						required = append(required, permissions.Permission{Verb: verb, Group: trustapi.SchemeGroupVersion.Group, Resource: "bundles"})
+					}
+				}
+				required = append(required, integration.RequiredPermissions(opts.Integration, opts.Bundle.WatchNamespaces)...)
+
+				checker := permissions.NewChecker(mgr.GetClient(), permissions.Options{
+					Log:         opts.Logr.WithName("permissions"),
+					Permissions: required,
+					Interval:    opts.PermissionsCheckInterval,
+					Recorder:    mgr.GetEventRecorderFor("trust-manager"),
+					Pod:         permissions.PodReference(os.Getenv("POD_NAMESPACE"), os.Getenv("POD_NAME")),
+				})
+				if err := mgr.Add(checker); err != nil {
+					return fmt.Errorf("failed to add permissions checker to manager: %w", err)
+				}
+				if err := mgr.AddReadyzCheck("permissions", checker.Check); err != nil {
+					return fmt.Errorf("failed to add readiness check: %w", err)
+				}
+			}
+
+			// When asked to stop, report the webhook as unready for the
+			// shutdown delay before stopping, so that admission requests aren't
+			// sent to this replica once its webhook server has stopped. With a
+			// failure policy of Fail, those requests would be rejected.
+			ctx, shuttingDown := delayShutdown(ctrl.SetupSignalHandler(), opts.Webhook.ShutdownDelay)
 
 			// Add Bundle controller to manager.
 			if err := bundle.AddBundleController(ctx, mgr, opts.Bundle, targetCache); err != nil {
 				return fmt.Errorf("failed to register Bundle controller: %w", err)
 			}
 
-			// Register webhook handlers with manager.
-			if err := webhook.Register(mgr, webhook.Options{Log: opts.Logr.WithName("webhook")}); err != nil {
-				return fmt.Errorf("failed to register webhook: %w", err)
+			if opts.Integration.Enabled() {
+				if err := integration.AddControllers(mgr, opts.Integration); err != nil {
+					return fmt.Errorf("failed to register integration controllers: %w", err)
+				}
+			}
+
+			if opts.Webhook.Enabled {
+				webhookOpts := webhook.Options{
+					Log:  opts.Logr.WithName("webhook"),
+					Host: opts.Webhook.Host,
+					Port: opts.Webhook.Port,
+
+					RequireCABasicConstraints: opts.Bundle.RequireCABasicConstraints,
+					SigningEnabled:            opts.Bundle.SigningKeySecret != "",
+					MaxBundleSizeBytes:        opts.Bundle.MaxBundleSizeBytes,
+					MaxCertificates:           opts.Bundle.MaxCertificates,
+
+					SourceNamespaceSelectorsEnabled: opts.Bundle.SourceNamespaceSelectorsEnabled,
+					CertificateSourcesEnabled:       opts.Bundle.CertificateSourcesEnabled,
+
+					CertificateWatcher: webhookCertWatcher,
+					ShuttingDown:       shuttingDown,
+				}
+				if opts.Webhook.RenderEnabled {
+					renderer, err := bundle.NewRenderer(mgr.GetClient(), opts.Bundle)
+					if err != nil {
+						return fmt.Errorf("failed to create Bundle renderer: %w", err)
+					}
+					webhookOpts.Renderer = renderer
+				}
+
+				// Register webhook handlers with manager.
+				if err := webhook.Register(mgr, webhookOpts); err != nil {
+					return fmt.Errorf("failed to register webhook: %w", err)
+				}
+			} else {
+				mlog.Info("webhook is disabled; Bundles are only validated by the CEL rules of the CRD")
+			}
+
+			if opts.BundleServer.Address != "" || opts.BundleServer.Serve {
+				renderer, err := bundle.NewRenderer(mgr.GetClient(), opts.Bundle)
+				if err != nil {
+					return fmt.Errorf("failed to create Bundle renderer: %w", err)
+				}
+
+				if opts.BundleServer.Address != "" {
+					if err := bundleserver.Register(mgr, bundleserver.Options{
+						Log:      opts.Logr.WithName("bundle-server"),
+						Address:  opts.BundleServer.Address,
+						Renderer: renderer,
+					}); err != nil {
+						return fmt.Errorf("failed to register bundle server: %w", err)
+					}
+				}
+
+				if opts.BundleServer.Serve {
+					if err := bundleserver.Register(mgr, bundleserver.Options{
+						Log:          opts.Logr.WithName("bundle-server-https"),
+						Address:      opts.BundleServer.ServeAddress,
+						Renderer:     renderer,
+						CertDir:      opts.BundleServer.ServeCertDir,
+						Authenticate: true,
+						Exports:      true,
+					}); err != nil {
+						return fmt.Errorf("failed to register authenticated bundle server: %w", err)
+					}
+				}
+			}
+
+			if opts.HubAgent.URL != "" {
+				httpClient, err := httpclient.New(opts.Bundle.HTTPClient)
+				if err != nil {
+					return fmt.Errorf("failed to create hub HTTP client: %w", err)
+				}
+
+				if err := hub.RegisterAgent(mgr, hub.AgentOptions{
+					Log:        opts.Logr.WithName("hub-agent"),
+					URL:        opts.HubAgent.URL,
+					TokenFile:  opts.HubAgent.TokenFile,
+					Interval:   opts.HubAgent.Interval,
+					HTTPClient: httpClient,
+				}); err != nil {
+					return fmt.Errorf("failed to register hub agent: %w", err)
+				}
 			}
 
 			// Start all runnables and controller
@@ -170,5 +371,26 @@ func NewCommand() *cobra.Command {
 
 	opts = opts.Prepare(cmd)
 
+	cmd.AddCommand(newSelfcheckCommand())
+
 	return cmd
 }
+
+// delayShutdown returns a context which is cancelled the given delay after
+// the parent is, and a channel which is closed as soon as the parent is.
+func delayShutdown(parent context.Context, delay time.Duration) (context.Context, <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	shuttingDown := make(chan struct{})
+
+	go func() {
+		<-parent.Done()
+		close(shuttingDown)
+
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+		cancel()
+	}()
+
+	return ctx, shuttingDown
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// configAPIVersion and configKind identify the format of the config
+	// file, so that it can evolve without breaking existing files.
+	configAPIVersion = "config.trust.cert-manager.io/v1alpha1"
+	configKind       = "TrustManagerConfiguration"
+)
+
+// tunableFlags are the flags whose changes in the config file are applied
+// when it's reloaded. Changes to other flags only apply on restart.
+var tunableFlags = sets.New(
+	"log-level",
+	"filter-expired-certificates",
+	"target-sync-concurrency",
+	"exclude-namespaces",
+	"exclude-namespace-selector",
+)
+
+// configKey returns the field of the config file which sets the flag with the
+// given name, which is the name in camelCase, such as "logLevel" for
+// "log-level".
+func configKey(flag string) string {
+	words := strings.Split(flag, "-")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// changedFlags returns the names of the flags which were set.
+func changedFlags(fs *pflag.FlagSet) sets.Set[string] {
+	changed := sets.New[string]()
+	fs.Visit(func(f *pflag.Flag) {
+		changed.Insert(f.Name)
+	})
+	return changed
+}
+
+// applyConfig sets the flags of fs from the fields of the config file data,
+// other than the flags in skip, which were given on the command line and
+// override the file.
+func applyConfig(fs *pflag.FlagSet, data []byte, skip sets.Set[string]) error {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	var config map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&config); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if config["apiVersion"] != configAPIVersion || config["kind"] != configKind {
+		return fmt.Errorf("config file must have apiVersion %q and kind %q", configAPIVersion, configKind)
+	}
+	delete(config, "apiVersion")
+	delete(config, "kind")
+
+	flags := make(map[string]*pflag.Flag)
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Name != "config" && f.Name != "help" {
+			flags[configKey(f.Name)] = f
+		}
+	})
+
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		f, ok := flags[key]
+		if !ok {
+			return fmt.Errorf("unknown config file field %q", key)
+		}
+		if skip.Has(f.Name) {
+			continue
+		}
+		if err := setFlag(fs, f, config[key]); err != nil {
+			return fmt.Errorf("invalid config file field %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// setFlag sets the flag to the value of a config file field. Lists replace
+// the values of flags which take a list.
+func setFlag(fs *pflag.FlagSet, f *pflag.Flag, value any) error {
+	list, isList := value.([]any)
+	if !isList {
+		s, err := configScalar(value)
+		if err != nil {
+			return err
+		}
+		return fs.Set(f.Name, s)
+	}
+
+	sliceValue, ok := f.Value.(pflag.SliceValue)
+	if !ok {
+		return errors.New("must not be a list")
+	}
+
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		s, err := configScalar(item)
+		if err != nil {
+			return err
+		}
+		values = append(values, s)
+	}
+	if err := sliceValue.Replace(values); err != nil {
+		return err
+	}
+	f.Changed = true
+	return nil
+}
+
+// configScalar returns the flag value of a scalar config file field.
+func configScalar(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		return "", errors.New("must be a string, number, boolean or list of them")
+	}
+}
+
+// copyFlag sets the value of dst to that of src.
+func copyFlag(dst, src *pflag.Flag) error {
+	if srcSlice, ok := src.Value.(pflag.SliceValue); ok {
+		return dst.Value.(pflag.SliceValue).Replace(srcSlice.GetSlice())
+	}
+	return dst.Value.Set(src.Value.String())
+}
+
+// loadConfig sets the flags which weren't given on the command line from
+// the config file.
+func (o *Options) loadConfig() error {
+	data, err := os.ReadFile(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read --config: %w", err)
+	}
+
+	o.cliFlags = changedFlags(o.flags)
+	if err := applyConfig(o.flags, data, o.cliFlags); err != nil {
+		return fmt.Errorf("invalid --config %q: %w", o.configFile, err)
+	}
+	o.configData = data
+
+	return nil
+}
+
+// reloadConfig applies the tunable options of the config file data, in the
+// same way as on startup. Returns the names of the other flags whose values
+// differ from those trust-manager was started with.
+func (o *Options) reloadConfig(data []byte) ([]string, error) {
+	reloaded := New()
+	reloaded.addFlags(&cobra.Command{})
+	if err := applyConfig(reloaded.flags, data, o.cliFlags); err != nil {
+		return nil, err
+	}
+	for name := range o.cliFlags {
+		if err := copyFlag(reloaded.flags.Lookup(name), o.flags.Lookup(name)); err != nil {
+			return nil, fmt.Errorf("failed to apply --%s: %w", name, err)
+		}
+	}
+	if err := reloaded.completeTunables(); err != nil {
+		return nil, err
+	}
+
+	var restartRequired []string
+	reloaded.flags.VisitAll(func(f *pflag.Flag) {
+		if tunableFlags.Has(f.Name) {
+			return
+		}
+		if current := o.flags.Lookup(f.Name); current != nil && current.Value.String() != f.Value.String() {
+			restartRequired = append(restartRequired, f.Name)
+		}
+	})
+
+	o.log.dynamic.set(reloaded.log.levels)
+	if tunables := reloaded.Bundle.TunableOptions; !reflect.DeepEqual(tunables, o.Bundle.Tunables.Load()) {
+		o.Bundle.Tunables.Store(tunables)
+	}
+
+	return restartRequired, nil
+}
+
+// ConfigReloader re-reads the config file at an interval, and applies changes
+// to the log levels and the tunable options of the Bundle controller. Changes
+// to other options are logged, as they only apply on restart.
+type ConfigReloader struct {
+	log  logr.Logger
+	opts *Options
+	data []byte
+}
+
+// NewConfigReloader returns a ConfigReloader for the config file of the
+// Options, or nil if there is none, or reloading is disabled.
+func (o *Options) NewConfigReloader() *ConfigReloader {
+	if o.configFile == "" || o.configReloadInterval <= 0 {
+		return nil
+	}
+
+	return &ConfigReloader{
+		log:  o.Logr.WithName("config"),
+		opts: o,
+		data: o.configData,
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that the
+// config file is reloaded by every replica.
+func (*ConfigReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reloads the config file until the context is cancelled.
+func (r *ConfigReloader) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(context.Context) {
+		r.reload()
+	}, r.opts.configReloadInterval)
+	return nil
+}
+
+// reload applies the config file if its contents changed. An invalid file is
+// reported once, and the options in effect are kept.
+func (r *ConfigReloader) reload() {
+	data, err := os.ReadFile(r.opts.configFile)
+	if err != nil {
+		r.log.Error(err, "failed to read config file", "path", r.opts.configFile)
+		return
+	}
+	if bytes.Equal(data, r.data) {
+		return
+	}
+	r.data = data
+
+	restartRequired, err := r.opts.reloadConfig(data)
+	if err != nil {
+		r.log.Error(err, "ignoring invalid config file", "path", r.opts.configFile)
+		return
+	}
+
+	r.log.Info("reloaded config file", "path", r.opts.configFile)
+	if len(restartRequired) > 0 {
+		r.log.Info("config file changes options which only apply on restart", "flags", restartRequired)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/trust-manager/pkg/bundle"
+)
+
+const testConfigHeader = `apiVersion: config.trust.cert-manager.io/v1alpha1
+kind: TrustManagerConfiguration
+`
+
+// newTestOptions returns Options parsed from the given command line, with
+// the given config file.
+func newTestOptions(t *testing.T, config string, args ...string) (*Options, error) {
+	configFile := filepath.Join(t.TempDir(), "trust-manager.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0600))
+
+	o := New().Prepare(&cobra.Command{})
+	require.NoError(t, o.flags.Parse(append(args, "--config", configFile)))
+	if err := o.loadConfig(); err != nil {
+		return nil, err
+	}
+	if err := o.completeTunables(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func Test_configKey(t *testing.T) {
+	assert.Equal(t, "logLevel", configKey("log-level"))
+	assert.Equal(t, "filterExpiredCertificates", configKey("filter-expired-certificates"))
+	assert.Equal(t, "kubeconfig", configKey("kubeconfig"))
+}
+
+func Test_loadConfig(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		args   []string
+
+		expErr     string
+		expOptions func(t *testing.T, o *Options)
+	}{
+		"fields set their flags": {
+			config: testConfigHeader + `
+targetSyncConcurrency: 4
+filterExpiredCertificates: true
+logLevel: 2,bundle=4
+excludeNamespaces: [kube-system, "vendor-*"]
+excludeNamespaceSelector: excluded=true
+webhookShutdownDelay: 5s
+`,
+			expOptions: func(t *testing.T, o *Options) {
+				assert.Equal(t, 4, o.Bundle.TargetSyncConcurrency)
+				assert.True(t, o.Bundle.FilterExpiredCerts)
+				assert.Equal(t, logLevels{level: 2, named: map[string]int{"bundle": 4}}, o.log.levels)
+				assert.Equal(t, []string{"kube-system", "vendor-*"}, o.Bundle.ExcludeNamespaces)
+				assert.Equal(t, "excluded=true", o.Bundle.ExcludeNamespaceSelector.String())
+				assert.Equal(t, 5*time.Second, o.Webhook.ShutdownDelay)
+			},
+		},
+		"flags given on the command line override the file": {
+			config: testConfigHeader + `
+targetSyncConcurrency: 4
+excludeNamespaces: [kube-system]
+`,
+			args: []string{"--target-sync-concurrency=2", "--exclude-namespaces=other"},
+			expOptions: func(t *testing.T, o *Options) {
+				assert.Equal(t, 2, o.Bundle.TargetSyncConcurrency)
+				assert.Equal(t, []string{"other"}, o.Bundle.ExcludeNamespaces)
+			},
+		},
+		"unset fields keep their defaults": {
+			config: testConfigHeader,
+			expOptions: func(t *testing.T, o *Options) {
+				assert.Equal(t, 1, o.Bundle.TargetSyncConcurrency)
+				assert.Equal(t, "cert-manager", o.Bundle.Namespace)
+			},
+		},
+		"a missing kind is an error": {
+			config: "apiVersion: config.trust.cert-manager.io/v1alpha1\n",
+			expErr: `config file must have apiVersion "config.trust.cert-manager.io/v1alpha1" and kind "TrustManagerConfiguration"`,
+		},
+		"an unknown field is an error": {
+			config: testConfigHeader + "targetSyncConcurency: 4\n",
+			expErr: `unknown config file field "targetSyncConcurency"`,
+		},
+		"the config file can't be set by itself": {
+			config: testConfigHeader + "config: other.yaml\n",
+			expErr: `unknown config file field "config"`,
+		},
+		"an invalid value is an error": {
+			config: testConfigHeader + "targetSyncConcurrency: many\n",
+			expErr: `invalid config file field "targetSyncConcurrency"`,
+		},
+		"a list for a flag which doesn't take one is an error": {
+			config: testConfigHeader + "trustNamespace: [a, b]\n",
+			expErr: `invalid config file field "trustNamespace": must not be a list`,
+		},
+		"an invalid tunable is an error": {
+			config: testConfigHeader + "excludeNamespaceSelector: '!!'\n",
+			expErr: "invalid --exclude-namespace-selector",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o, err := newTestOptions(t, test.config, test.args...)
+			if test.expErr != "" {
+				assert.ErrorContains(t, err, test.expErr)
+				return
+			}
+
+			require.NoError(t, err)
+			test.expOptions(t, o)
+		})
+	}
+}
+
+func Test_reloadConfig(t *testing.T) {
+	o, err := newTestOptions(t, testConfigHeader+`
+targetSyncConcurrency: 4
+trustNamespace: trust
+`, "--filter-expired-certificates=true")
+	require.NoError(t, err)
+	o.log.dynamic = newDynamicLevels(o.log.levels)
+	o.Bundle.Tunables = bundle.NewTunables(o.Bundle.TunableOptions)
+
+	restartRequired, err := o.reloadConfig([]byte(testConfigHeader + `
+targetSyncConcurrency: 8
+filterExpiredCertificates: false
+logLevel: 3
+excludeNamespaces: [kube-system]
+trustNamespace: other
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trust-namespace"}, restartRequired)
+
+	tunables := o.Bundle.Tunables.Load()
+	assert.Equal(t, 8, tunables.TargetSyncConcurrency)
+	assert.True(t, tunables.FilterExpiredCerts, "flags given on the command line still override the file")
+	assert.Equal(t, []string{"kube-system"}, tunables.ExcludeNamespaces)
+	assert.Equal(t, 3, o.log.dynamic.levels.Load().level)
+
+	// An invalid file leaves the options in effect unchanged.
+	_, err = o.reloadConfig([]byte(testConfigHeader + "excludeNamespaces: ['[']\n"))
+	assert.Error(t, err)
+	assert.Equal(t, tunables, o.Bundle.Tunables.Load())
+}
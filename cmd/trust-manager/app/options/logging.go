@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+)
+
+// logLevels are the verbosity of logging, by default and for the loggers of
+// given names. The level of a named logger applies to the loggers derived
+// from it, unless they are named themselves.
+type logLevels struct {
+	level int
+	named map[string]int
+}
+
+// String is used both by fmt.Print and by Cobra in help text
+func (l *logLevels) String() string {
+	levels := []string{strconv.Itoa(l.level)}
+	for _, name := range slices.Sorted(maps.Keys(l.named)) {
+		levels = append(levels, fmt.Sprintf("%s=%d", name, l.named[name]))
+	}
+	return strings.Join(levels, ",")
+}
+
+// Set parses a comma-separated list of a default level and levels of named
+// loggers, such as "1,bundle=4,webhook=0".
+func (l *logLevels) Set(v string) error {
+	levels := logLevels{level: l.level}
+	for _, item := range strings.Split(v, ",") {
+		name, value, named := strings.Cut(strings.TrimSpace(item), "=")
+		level, err := strconv.Atoi(value)
+		if !named {
+			level, err = strconv.Atoi(name)
+		}
+		if err != nil || level < 0 {
+			return fmt.Errorf("invalid level in %q: must be a non-negative integer", item)
+		}
+
+		if !named {
+			levels.level = level
+			continue
+		}
+		if name == "" {
+			return fmt.Errorf("invalid level in %q: logger name must not be empty", item)
+		}
+		if levels.named == nil {
+			levels.named = map[string]int{}
+		}
+		levels.named[name] = level
+	}
+
+	*l = levels
+	return nil
+}
+
+// Type is only used in help text
+func (l *logLevels) Type() string {
+	return "level"
+}
+
+// max returns the highest level of any logger.
+func (l *logLevels) max() int {
+	level := l.level
+	for _, named := range l.named {
+		level = max(level, named)
+	}
+	return level
+}
+
+// dynamicLevels holds the log levels in effect, which may be replaced while
+// loggers are in use.
+type dynamicLevels struct {
+	levels atomic.Pointer[logLevels]
+
+	// handler is the level of the slog handler which loggers write to, which
+	// is the highest level of any logger.
+	handler slog.LevelVar
+}
+
+// newDynamicLevels returns dynamicLevels holding the given levels.
+func newDynamicLevels(levels logLevels) *dynamicLevels {
+	d := new(dynamicLevels)
+	d.set(levels)
+	return d
+}
+
+// set replaces the levels in effect.
+func (d *dynamicLevels) set(levels logLevels) {
+	// To avoid a breaking change in application configuration, we negate the
+	// (configured) logr verbosity level to get the corresponding slog level.
+	d.handler.Set(slog.Level(-levels.max()))
+	d.levels.Store(&levels)
+}
+
+// leveledSink is a logr.LogSink which drops Info logs above the level of its
+// logger, so that the verbosity of each logger can differ. Error logs are
+// always passed on. The level is looked up on each log, so that changes to
+// the levels apply to existing loggers.
+type leveledSink struct {
+	logr.LogSink
+	levels *dynamicLevels
+	// names are the names of the logger, outermost first.
+	names []string
+}
+
+// newLeveledLogger returns a logger which writes to the sink of base, at the
+// verbosity given by levels. base must be enabled at the maximum level.
+func newLeveledLogger(base logr.Logger, levels *dynamicLevels) logr.Logger {
+	return logr.New(&leveledSink{
+		LogSink: base.GetSink(),
+		levels:  levels,
+	})
+}
+
+// level returns the level of the logger, which is that of its innermost name
+// which has a level.
+func (s *leveledSink) level() int {
+	levels := s.levels.levels.Load()
+	for i := len(s.names) - 1; i >= 0; i-- {
+		if level, ok := levels.named[s.names[i]]; ok {
+			return level
+		}
+	}
+	return levels.level
+}
+
+func (s *leveledSink) Enabled(level int) bool {
+	return level <= s.level() && s.LogSink.Enabled(level)
+}
+
+func (s *leveledSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &leveledSink{LogSink: s.LogSink.WithValues(keysAndValues...), levels: s.levels, names: s.names}
+}
+
+func (s *leveledSink) WithName(name string) logr.LogSink {
+	names := append(slices.Clip(s.names), name)
+	return &leveledSink{LogSink: s.LogSink.WithName(name), levels: s.levels, names: names}
+}
+
+// WithCallDepth implements logr.CallDepthLogSink, so that the caller of the
+// logger is reported rather than this sink.
+func (s *leveledSink) WithCallDepth(depth int) logr.LogSink {
+	sink := s.LogSink
+	if withCallDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = withCallDepth.WithCallDepth(depth)
+	}
+	return &leveledSink{LogSink: sink, levels: s.levels, names: s.names}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_logLevels(t *testing.T) {
+	tests := map[string]struct {
+		value     string
+		expLevels logLevels
+		expErr    bool
+	}{
+		"a default level": {
+			value:     "3",
+			expLevels: logLevels{level: 3},
+		},
+		"a default level and named levels": {
+			value:     "2, bundle=4,webhook=0",
+			expLevels: logLevels{level: 2, named: map[string]int{"bundle": 4, "webhook": 0}},
+		},
+		"only named levels keep the default level": {
+			value:     "bundle=4",
+			expLevels: logLevels{level: 1, named: map[string]int{"bundle": 4}},
+		},
+		"an invalid level": {
+			value:  "bundle=high",
+			expErr: true,
+		},
+		"a negative level": {
+			value:  "-1",
+			expErr: true,
+		},
+		"an empty name": {
+			value:  "=2",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			levels := logLevels{level: 1}
+			err := levels.Set(test.value)
+			if test.expErr {
+				assert.Error(t, err)
+				assert.Equal(t, logLevels{level: 1}, levels)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expLevels, levels)
+		})
+	}
+}
+
+func Test_newLeveledLogger(t *testing.T) {
+	var logged []string
+	base := funcr.New(func(prefix, _ string) {
+		logged = append(logged, prefix)
+	}, funcr.Options{Verbosity: 5})
+
+	levels := logLevels{level: 1, named: map[string]int{"bundle": 4, "webhook": 0}}
+	assert.Equal(t, "1,bundle=4,webhook=0", levels.String())
+	assert.Equal(t, 4, levels.max())
+
+	dynamic := newDynamicLevels(levels)
+	assert.Equal(t, slog.Level(-4), dynamic.handler.Level())
+
+	log := newLeveledLogger(base, dynamic).WithName("trust")
+	bundleLog := log.WithName("bundle")
+
+	log.V(1).Info("")
+	log.V(2).Info("")
+	bundleLog.WithValues("bundle", "a").V(4).Info("")
+	bundleLog.WithName("target").V(4).Info("")
+	log.WithName("webhook").V(1).Info("")
+	log.WithName("webhook").Error(nil, "")
+
+	assert.Equal(t, []string{"trust", "trust/bundle", "trust/bundle/target", "trust/webhook"}, logged)
+
+	// Changed levels apply to existing loggers.
+	logged = nil
+	dynamic.set(logLevels{level: 2})
+	assert.Equal(t, slog.Level(-2), dynamic.handler.Level())
+
+	log.V(2).Info("")
+	bundleLog.V(2).Info("")
+	bundleLog.V(4).Info("")
+
+	assert.Equal(t, []string{"trust", "trust/bundle"}, logged)
+}
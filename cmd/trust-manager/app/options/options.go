@@ -20,18 +20,28 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog/v2"
 
+	"github.com/cert-manager/trust-manager/pkg/audit"
 	"github.com/cert-manager/trust-manager/pkg/bundle"
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+	"github.com/cert-manager/trust-manager/pkg/integration"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -40,6 +50,20 @@ import (
 type Options struct {
 	kubeConfigFlags *genericclioptions.ConfigFlags
 
+	// flags are the flags of the command, which the config file sets.
+	flags *pflag.FlagSet
+
+	// configFile is the path of the config file, if any.
+	configFile string
+	// configReloadInterval is the interval at which the config file is
+	// re-read. Zero disables reloading.
+	configReloadInterval time.Duration
+	// configData is the content of the config file on startup.
+	configData []byte
+	// cliFlags are the names of the flags given on the command line, which
+	// override the config file.
+	cliFlags sets.Set[string]
+
 	// ReadyzPort if the port used to expose Prometheus metrics.
 	ReadyzPort int
 	// ReadyzPath if the HTTP path used to expose Prometheus metrics.
@@ -49,6 +73,10 @@ type Options struct {
 	// path '/metrics'.
 	MetricsPort int
 
+	// PermissionsCheckInterval is how often the RBAC permissions of
+	// trust-manager are checked. Zero disables the check.
+	PermissionsCheckInterval time.Duration
+
 	// Logr is the shared base logger.
 	Logr logr.Logger
 
@@ -62,19 +90,41 @@ type Options struct {
 	// Bundle are options specific to the Bundle controller.
 	Bundle bundle.Options
 
+	// BundleServer are options specific to the bundle server.
+	BundleServer BundleServer
+
+	// HubAgent are options specific to pulling Bundles from a hub cluster.
+	HubAgent HubAgent
+
+	// Integration are options specific to the integrations which point the
+	// CA references of other objects at Bundle targets.
+	Integration integration.Options
+
 	// log are options controlling logging
 	log logOptions
 
+	// excludeNamespaceSelector is the label selector of Namespaces which
+	// targets are never written to, parsed into Bundle options.
+	excludeNamespaceSelector string
+
 	// Leader election lease duration
 	LeaseDuration time.Duration
 
 	// Leader election lease renew duration
 	RenewDeadline time.Duration
+
+	// Leader election retry period, the interval between attempts to acquire
+	// or renew the lease
+	RetryPeriod time.Duration
 }
 
 type logOptions struct {
 	format logFormat
-	level  int
+	levels logLevels
+
+	// dynamic holds the levels in effect, which change when the config file
+	// is reloaded.
+	dynamic *dynamicLevels
 }
 
 const (
@@ -110,9 +160,55 @@ func (e *logFormat) Type() string {
 
 // Webhook holds options specific to running the trust Webhook service.
 type Webhook struct {
+	// Enabled controls if the webhook server is run. When disabled, Bundles
+	// are only validated by the CEL rules of the CRD, and only the v1alpha1
+	// version of the API can be used, as no conversion webhook is served.
+	Enabled bool
+
 	Host    string
 	Port    int
 	CertDir string
+
+	// CertReloadInterval is the interval at which the serving certificate is
+	// re-read, in addition to when its files are changed.
+	CertReloadInterval time.Duration
+
+	// RenderEnabled controls if the Bundle render endpoint is served.
+	RenderEnabled bool
+
+	// ShutdownDelay is how long the webhook is reported as unready before
+	// trust-manager stops when asked to shut down.
+	ShutdownDelay time.Duration
+}
+
+// BundleServer holds options specific to serving Bundle content over HTTP.
+type BundleServer struct {
+	// Address is the address to serve Bundles on. Empty disables the server.
+	Address string
+
+	// Serve enables serving Bundles over HTTPS to clients authenticated using
+	// a Kubernetes TokenReview.
+	Serve bool
+
+	// ServeAddress is the address to serve authenticated HTTPS on.
+	ServeAddress string
+
+	// ServeCertDir is the directory holding the serving certificate and key
+	// for the authenticated HTTPS server.
+	ServeCertDir string
+}
+
+// HubAgent holds options specific to pulling Bundles from a hub cluster.
+type HubAgent struct {
+	// URL is the base URL of the authenticated bundle server of the hub.
+	// Empty disables the agent.
+	URL string
+
+	// TokenFile is the path of the bearer token presented to the hub.
+	TokenFile string
+
+	// Interval is the interval at which Bundles are pulled from the hub.
+	Interval time.Duration
 }
 
 // New constructs a new Options.
@@ -129,10 +225,17 @@ func (o *Options) Prepare(cmd *cobra.Command) *Options {
 // Complete will populate the remaining Options from the CLI flags. Must be run
 // before consuming Options.
 func (o *Options) Complete() error {
+	if o.configFile != "" {
+		if err := o.loadConfig(); err != nil {
+			return err
+		}
+	}
+
+	o.log.dynamic = newDynamicLevels(o.log.levels)
 	opts := &slog.HandlerOptions{
-		// To avoid a breaking change in application configuration,
-		// we negate the (configured) logr verbosity level to get the corresponding slog level
-		Level: slog.Level(-o.log.level),
+		// The handler logs at the highest level of any logger, and the levels
+		// of each logger are applied by the leveled logger.
+		Level: &o.log.dynamic.handler,
 	}
 	var handler slog.Handler = slog.NewTextHandler(os.Stdout, opts)
 	if o.log.format == logFormatJSON {
@@ -141,10 +244,23 @@ func (o *Options) Complete() error {
 
 	slog.SetDefault(slog.New(handler))
 
-	log := logr.FromSlogHandler(handler)
+	log := newLeveledLogger(logr.FromSlogHandler(handler), o.log.dynamic)
 	klog.SetLogger(log)
 	o.Logr = log.WithName("trust")
 
+	// Leader election timings are otherwise only validated once the manager
+	// has started.
+	if o.RenewDeadline >= o.LeaseDuration {
+		return fmt.Errorf("--leader-election-renew-deadline (%s) must be less than --leader-election-lease-duration (%s)", o.RenewDeadline, o.LeaseDuration)
+	}
+	if float64(o.RenewDeadline) <= leaderelection.JitterFactor*float64(o.RetryPeriod) {
+		return fmt.Errorf("--leader-election-renew-deadline (%s) must be greater than %.1f times --leader-election-retry-period (%s)", o.RenewDeadline, leaderelection.JitterFactor, o.RetryPeriod)
+	}
+
+	if o.PermissionsCheckInterval < 0 {
+		return fmt.Errorf("--permissions-check-interval (%s) must not be negative", o.PermissionsCheckInterval)
+	}
+
 	var err error
 	o.RestConfig, err = o.kubeConfigFlags.ToRESTConfig()
 	if err != nil {
@@ -152,6 +268,80 @@ func (o *Options) Complete() error {
 	}
 
 	o.Bundle.Log = o.Logr.WithName("bundle")
+	o.Integration.Log = o.Logr.WithName("integration")
+
+	if err := o.completeTunables(); err != nil {
+		return err
+	}
+	if o.configFile != "" {
+		o.Bundle.Tunables = bundle.NewTunables(o.Bundle.TunableOptions)
+	}
+
+	for _, namespace := range o.Bundle.WatchNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid --watch-namespaces namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+
+	if o.Bundle.ClusterResourceNamespace == "" {
+		o.Bundle.ClusterResourceNamespace = o.Bundle.Namespace
+	} else if errs := validation.IsDNS1123Label(o.Bundle.ClusterResourceNamespace); len(errs) > 0 {
+		return fmt.Errorf("invalid --cert-manager-cluster-resource-namespace %q: %s", o.Bundle.ClusterResourceNamespace, strings.Join(errs, ", "))
+	}
+
+	if !o.Webhook.Enabled && o.Webhook.RenderEnabled {
+		return errors.New("--webhook-render-enabled requires --enable-webhook")
+	}
+
+	if o.Integration.IngressNginx && !o.Bundle.SecretTargetsEnabled {
+		return errors.New("--ingress-nginx-integration requires --secret-targets-enabled")
+	}
+
+	if o.HubAgent.URL != "" {
+		hubURL, err := url.Parse(o.HubAgent.URL)
+		if err != nil {
+			return fmt.Errorf("invalid --hub-url: %w", err)
+		}
+		if hubURL.Scheme != "https" {
+			return fmt.Errorf("--hub-url %q must use https", o.HubAgent.URL)
+		}
+		if o.HubAgent.TokenFile == "" {
+			return errors.New("--hub-url requires --hub-token-file")
+		}
+		if o.HubAgent.Interval <= 0 {
+			return fmt.Errorf("--hub-sync-interval (%s) must be positive", o.HubAgent.Interval)
+		}
+	}
+
+	// Outbound connections are only made by some sources, so the client is
+	// built here to report misconfigurations on startup.
+	if _, err := httpclient.New(o.Bundle.HTTPClient); err != nil {
+		return fmt.Errorf("invalid outbound HTTP client options: %w", err)
+	}
+
+	if err := o.Bundle.Audit.Validate(); err != nil {
+		return fmt.Errorf("invalid --audit-sink or --audit-webhook-url: %w", err)
+	}
+
+	return nil
+}
+
+// completeTunables validates the options which may change when the config
+// file is reloaded, and populates the Bundle options from them.
+func (o *Options) completeTunables() error {
+	for _, pattern := range o.Bundle.ExcludeNamespaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --exclude-namespaces pattern %q: %w", pattern, err)
+		}
+	}
+
+	if o.excludeNamespaceSelector != "" {
+		selector, err := labels.Parse(o.excludeNamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("invalid --exclude-namespace-selector: %w", err)
+		}
+		o.Bundle.ExcludeNamespaceSelector = selector
+	}
 
 	return nil
 }
@@ -164,6 +354,11 @@ func (o *Options) addFlags(cmd *cobra.Command) {
 	o.addBundleFlags(nfs.FlagSet("Bundle"))
 	o.addLoggingFlags(nfs.FlagSet("Logging"))
 	o.addWebhookFlags(nfs.FlagSet("Webhook"))
+	o.addOutboundFlags(nfs.FlagSet("Outbound"))
+	o.addAuditFlags(nfs.FlagSet("Audit"))
+	o.addBundleServerFlags(nfs.FlagSet("Bundle Server"))
+	o.addHubAgentFlags(nfs.FlagSet("Hub Agent"))
+	o.addIntegrationFlags(nfs.FlagSet("Integrations"))
 	o.kubeConfigFlags = genericclioptions.NewConfigFlags(true)
 	o.kubeConfigFlags.AddFlags(nfs.FlagSet("Kubernetes"))
 
@@ -183,9 +378,22 @@ func (o *Options) addFlags(cmd *cobra.Command) {
 	for _, f := range nfs.FlagSets {
 		fs.AddFlagSet(f)
 	}
+	o.flags = fs
 }
 
 func (o *Options) addAppFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.configFile,
+		"config", "",
+		"Path to a YAML config file with 'apiVersion: "+configAPIVersion+"' and 'kind: "+configKind+"', "+
+			"which sets any other flag by its name in camelCase, such as 'targetSyncConcurrency: 4'. "+
+			"Flags given on the command line override the file.")
+
+	fs.DurationVar(&o.configReloadInterval,
+		"config-reload-interval", 10*time.Second,
+		"Interval at which --config is re-read. Changes to its log-level, filter-expired-certificates, target-sync-concurrency, "+
+			"exclude-namespaces and exclude-namespace-selector apply without a restart; changes to other options are logged, "+
+			"and apply on restart. Set to 0 to disable reloading.")
+
 	fs.IntVar(&o.ReadyzPort,
 		"readiness-probe-port", 6060,
 		"Port to expose the readiness probe.")
@@ -202,9 +410,18 @@ func (o *Options) addAppFlags(fs *pflag.FlagSet) {
 		"leader-election-renew-deadline", time.Second*10,
 		"Lease renew deadline for leader election")
 
+	fs.DurationVar(&o.RetryPeriod,
+		"leader-election-retry-period", time.Second*2,
+		"Interval between attempts to acquire or renew the lease for leader election")
+
 	fs.IntVar(&o.MetricsPort,
 		"metrics-port", 9402,
 		"Port to expose Prometheus metrics on 0.0.0.0 on path '/metrics'.")
+
+	fs.DurationVar(&o.PermissionsCheckInterval,
+		"permissions-check-interval", 5*time.Minute,
+		"Interval at which trust-manager checks that it holds the RBAC permissions it needs, "+
+			"failing its readiness probe while any are missing. Set to 0 to disable the check.")
 }
 
 func (o *Options) addBundleFlags(fs *pflag.FlagSet) {
@@ -214,15 +431,126 @@ func (o *Options) addBundleFlags(fs *pflag.FlagSet) {
 
 	fs.StringVar(&o.Bundle.DefaultPackageLocation,
 		"default-package-location", "",
-		"Path to a JSON file containing the default certificate package. If set, must be a valid package.")
+		"Path to a JSON or PEM file containing the default certificate package. If set, must be a valid package.")
+	fs.StringSliceVar(&o.Bundle.DefaultPackageLayers,
+		"default-package-layer", nil,
+		"Path to a JSON or PEM file containing a package which is layered on top of the default certificate package, "+
+			"adding certificates to it or removing them from it. May be repeated; layers are applied in order.")
+	fs.StringVar(&o.Bundle.DefaultPackageVerificationKey,
+		"default-package-verification-key", "",
+		"Path to a PEM-encoded Ed25519 public key. If set, the default certificate package and its layers "+
+			"must each have a detached, base64-encoded signature made with the private key, at the path of the package "+
+			"with a \".sig\" suffix, or the controller refuses to start.")
+	fs.DurationVar(&o.Bundle.DefaultPackageMaxAge,
+		"default-package-max-age", 0,
+		"Age after which the default certificate package is considered stale, and Bundles using default CAs get a "+
+			"DefaultPackageStale condition. Only packages which specify their build date can be stale. Zero disables the condition.")
 
 	fs.BoolVar(&o.Bundle.SecretTargetsEnabled,
 		"secret-targets-enabled", false,
 		"Controls if secret targets are enabled in the Bundle API.")
+	fs.BoolVar(&o.Bundle.SourceNamespaceSelectorsEnabled,
+		"source-namespace-selectors-enabled", false,
+		"Allow ConfigMap and Secret sources with a label selector to set a namespaceSelector, selecting objects in the "+
+			"namespaces it matches rather than only in the trust namespace. ConfigMaps and Secrets are then cached in every "+
+			"namespace, or every watched namespace in namespaced mode, which requires permission to read them there.")
 
 	fs.BoolVar(&o.Bundle.FilterExpiredCerts,
 		"filter-expired-certificates", false,
-		"Filter expired certificates from the bundle.")
+		"Filter expired certificates from the bundle. Bundles may override this with spec.filters.expired.")
+	fs.BoolVar(&o.Bundle.PEMTrailingNewline,
+		"pem-trailing-newline", false,
+		"End the PEM bundles written to targets with a newline, for consumers which concatenate bundles or expect POSIX text files. "+
+			"Changing this changes the hash of every bundle, so all targets are updated.")
+	fs.BoolVar(&o.Bundle.RequireCABasicConstraints,
+		"require-ca-basic-constraints", false,
+		"Filter certificates which aren't CA certificates from bundles, and reject InLine sources containing them. "+
+			"Can be overridden per Bundle with spec.requireCABasicConstraints.")
+	fs.BoolVar(&o.Bundle.SyncSummaryConfigMaps,
+		"sync-summary-configmaps", false,
+		"Write a summary of the last sync of each Bundle to a ConfigMap named 'trust-manager-sync-summary-<bundle>' in the trust namespace.")
+	fs.BoolVar(&o.Bundle.RolloutWorkloads,
+		"rollout-workloads", false,
+		"Roll out Deployments and StatefulSets which mount a target ConfigMap or Secret when its bundle changes, "+
+			"by setting a checksum annotation on their pod templates. Requires permission to list and patch them.")
+	fs.DurationVar(&o.Bundle.ConsumerReportInterval,
+		"consumer-report-interval", 0,
+		"Interval at which to count the pods mounting the targets of each Bundle, exported as the "+
+			"trust_manager_bundle_consumer_pods metric. Requires permission to list pods. Set to 0 to disable.")
+	fs.BoolVar(&o.Bundle.RemoteClustersEnabled,
+		"remote-clusters-enabled", false,
+		"Propagate Bundles to the remote clusters registered by Secrets in the trust namespace labelled with "+
+			"'trust.cert-manager.io/remote-cluster: \"true\"', which hold a kubeconfig under the 'kubeconfig' key.")
+	fs.DurationVar(&o.Bundle.EventAggregationWindow,
+		"event-aggregation-window", time.Minute,
+		"Window in which repeated Warning Events for a Bundle with the same reason are aggregated into a single Event. "+
+			"Set to 0 to disable aggregation.")
+	fs.Float32Var(&o.Bundle.MaxEventsPerSecond,
+		"max-events-per-second", 0,
+		"Maximum rate at which Events are emitted for Bundles. Set to 0 for no limit.")
+	fs.BoolVar(&o.Bundle.TargetEvents,
+		"target-events", false,
+		"Record an Event on each target ConfigMap and Secret when it is created, updated or deleted, "+
+			"so that changes can be traced from the namespace of the target. Subject to --max-events-per-second separately from Bundle Events.")
+
+	fs.DurationVar(&o.Bundle.RequeueInterval,
+		"requeue-interval", 0,
+		"Interval at which Bundles are re-synced, even if no events for their sources or targets are received. "+
+			"Bundles may override this with spec.refreshInterval. Set to 0 to disable periodic re-syncs.")
+
+	fs.DurationVar(&o.Bundle.StatusUpdateInterval,
+		"status-update-interval", 10*time.Second,
+		"Minimum interval between status updates of a Bundle which only report progress, that is its target counts, "+
+			"rollout and last sync time, during a large sync or rollout. Updates which change anything else are applied immediately. "+
+			"Set to 0 to update on every sync.")
+
+	fs.IntVar(&o.Bundle.TargetSyncConcurrency,
+		"target-sync-concurrency", 1,
+		"Number of targets of a Bundle which are synced in parallel. Increase on clusters with many namespaces to reduce sync latency.")
+
+	fs.StringSliceVar(&o.Bundle.ExcludeNamespaces,
+		"exclude-namespaces", nil,
+		"Comma-separated glob patterns of namespace names which targets are never written to, "+
+			"even if the namespaceSelector of a Bundle matches them. For example 'kube-system,vendor-*'.")
+
+	fs.StringVar(&o.excludeNamespaceSelector,
+		"exclude-namespace-selector", "",
+		"Label selector of namespaces which targets are never written to, "+
+			"even if the namespaceSelector of a Bundle matches them.")
+
+	fs.StringSliceVar(&o.Bundle.WatchNamespaces,
+		"watch-namespaces", nil,
+		"Comma-separated list of namespaces which targets are restricted to. If set, trust-manager only "+
+			"needs permissions for ConfigMaps and Secrets in these namespaces. All namespaces if empty.")
+
+	fs.IntVar(&o.Bundle.MaxBundleSizeBytes,
+		"max-bundle-size-bytes", 0,
+		"Maximum size in bytes of the PEM bundle built from the sources of a Bundle. Larger Bundles are rejected "+
+			"at admission if their inLine sources alone exceed it, and otherwise fail policy and aren't synced. Set to 0 for no limit.")
+
+	fs.IntVar(&o.Bundle.MaxCertificates,
+		"max-certificates", 0,
+		"Maximum number of certificates in the bundle built from the sources of a Bundle. Bundles with more are rejected "+
+			"at admission if their inLine sources alone exceed it, and otherwise fail policy and aren't synced. Set to 0 for no limit.")
+
+	fs.BoolVar(&o.Bundle.ForceTargetApply,
+		"force-target-apply", false,
+		"Apply every Bundle target once after startup, even if its hash annotation shows it to be up to date. "+
+			"Use to repair targets whose data was changed without updating the annotation.")
+
+	fs.StringVar(&o.Bundle.SigningKeySecret,
+		"signing-key-secret", "",
+		"Name of the Secret in the trust Namespace holding the Ed25519 key used to sign Bundles "+
+			"which request a detached signature. Bundles can't request signatures if empty.")
+
+	fs.StringVar(&o.Bundle.SigningKeySecretKey,
+		"signing-key-secret-key", "tls.key",
+		"Key in the signing key Secret holding the PEM-encoded PKCS#8 Ed25519 private key.")
+
+	fs.StringVar(&o.Bundle.SourcePluginsDir,
+		"source-plugins-dir", "",
+		"Directory holding the unix sockets of source plugins, named '<plugin>.sock', which provide the certificates of "+
+			"the plugin sources of Bundles over the SourcePlugin gRPC API. Plugin sources are unavailable if empty.")
 }
 
 func (o *Options) addLoggingFlags(fs *pflag.FlagSet) {
@@ -230,12 +558,18 @@ func (o *Options) addLoggingFlags(fs *pflag.FlagSet) {
 		"log-format",
 		"Log format (text or json)")
 
-	fs.IntVarP(&o.log.level,
-		"log-level", "v", 1,
-		"Log level (1-5).")
+	o.log.levels = logLevels{level: 1}
+	fs.VarP(&o.log.levels,
+		"log-level", "v",
+		"Log level (1-5), optionally followed by the levels of named loggers which override it, "+
+			"such as '1,bundle=4,webhook=0'. Loggers include 'bundle', 'webhook', 'bundle-server' and 'manager'.")
 }
 
 func (o *Options) addWebhookFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Webhook.Enabled,
+		"enable-webhook", true,
+		"Run the webhook server, which validates Bundles and converts them between API versions. "+
+			"When disabled, Bundles are only validated by the CEL rules of the CRD.")
 	fs.StringVar(&o.Webhook.Host,
 		"webhook-host", "0.0.0.0",
 		"Host to serve webhook.")
@@ -247,4 +581,110 @@ func (o *Options) addWebhookFlags(fs *pflag.FlagSet) {
 		"Directory where the Webhook certificate and private key are located. "+
 			"Certificate and private key must be named 'tls.crt' and 'tls.key' "+
 			"respectively.")
+	fs.DurationVar(&o.Webhook.CertReloadInterval,
+		"webhook-certificate-reload-interval", 10*time.Second,
+		"Interval at which the Webhook certificate and private key are re-read, in addition "+
+			"to when their files change, so that rotated certificates are served without a restart.")
+	fs.BoolVar(&o.Webhook.RenderEnabled,
+		"webhook-render-enabled", false,
+		"Serve the /render endpoint on the webhook server, which returns the PEM bundle "+
+			"a Bundle manifest would produce from the current sources.")
+	fs.DurationVar(&o.Webhook.ShutdownDelay,
+		"webhook-shutdown-delay", 0,
+		"Time for which the webhook is reported as unready before trust-manager stops when asked to shut down, "+
+			"so that the API server stops sending admission requests to this replica before its webhook server stops. "+
+			"Should exceed the time taken for the readiness probe to fail and the webhook Service endpoints to update.")
+}
+
+func (o *Options) addOutboundFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Bundle.HTTPClient.CAFile,
+		"outbound-ca-file", "",
+		"Path to a PEM bundle of CAs which servers of outbound connections, such as remote sources, are verified against "+
+			"in addition to the system roots. Proxies are configured with the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.")
+	fs.StringVar(&o.Bundle.HTTPClient.CertFile,
+		"outbound-client-certificate-file", "",
+		"Path to a PEM certificate presented to servers of outbound connections which require client authentication. "+
+			"Requires --outbound-client-key-file.")
+	fs.StringVar(&o.Bundle.HTTPClient.KeyFile,
+		"outbound-client-key-file", "",
+		"Path to the PEM private key of --outbound-client-certificate-file.")
+	fs.DurationVar(&o.Bundle.HTTPClient.Timeout,
+		"outbound-timeout", 30*time.Second,
+		"Timeout of each outbound request. Set to 0 for no timeout.")
+	fs.StringVar(&o.Bundle.SPIFFEFederationTokenFile,
+		"spiffe-federation-token-file", "",
+		"Path to a projected ServiceAccount token which SPIFFE federation sources with presentServiceAccountToken "+
+			"present to their bundle endpoints as a bearer token. The file is read on each fetch, so that rotated tokens are used.")
+}
+
+func (o *Options) addAuditFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Bundle.Audit.Sink,
+		"audit-sink", audit.SinkNone,
+		"Where to write an audit entry for every create, update and delete of a Bundle target, as JSON: "+
+			"'stdout', or 'webhook' to post each entry to --audit-webhook-url. Auditing is disabled if empty.")
+	fs.StringVar(&o.Bundle.Audit.WebhookURL,
+		"audit-webhook-url", "",
+		"HTTP or HTTPS URL audit entries are posted to with the webhook sink. "+
+			"The outbound connection options apply to it.")
+}
+
+func (o *Options) addBundleServerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.BundleServer.Address,
+		"bundle-server-address", "",
+		"Address to serve the current bundle of each Bundle on, at '/bundles/<name>[.pem|.jks|.p12]', "+
+			"for consumers such as a CSI driver. Disabled if empty.")
+
+	fs.BoolVar(&o.BundleServer.Serve,
+		"serve-bundles", false,
+		"If true, serve the current bundle of each Bundle over HTTPS at '/bundles/<name>[.pem|.jks|.p12]', "+
+			"to clients presenting a bearer token which is authenticated using a Kubernetes TokenReview. "+
+			"Bundles annotated with 'trust.cert-manager.io/hub-export: \"true\"' are also served to the agents of spoke clusters at '/hub/bundles'.")
+
+	fs.StringVar(&o.BundleServer.ServeAddress,
+		"serve-bundles-address", "0.0.0.0:6090",
+		"Address to serve authenticated HTTPS on, when --serve-bundles is enabled.")
+
+	fs.StringVar(&o.BundleServer.ServeCertDir,
+		"serve-bundles-certificate-dir", "/tls-bundles",
+		"Directory where the serving certificate and key for --serve-bundles are located, "+
+			"as 'tls.crt' and 'tls.key'.")
+}
+
+func (o *Options) addHubAgentFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.HubAgent.URL,
+		"hub-url", "",
+		"Base HTTPS URL of the authenticated bundle server of a hub cluster, to pull the Bundles it exports from "+
+			"and apply them as local Bundles labelled with 'trust.cert-manager.io/hub-managed: \"true\"'. "+
+			"The hub is verified using --outbound-ca-file. Disabled if empty.")
+
+	fs.StringVar(&o.HubAgent.TokenFile,
+		"hub-token-file", "",
+		"Path to a bearer token presented to the hub, which must be authenticated by a TokenReview in the hub cluster. "+
+			"The file is read on each sync, so that rotated tokens are used.")
+
+	fs.DurationVar(&o.HubAgent.Interval,
+		"hub-sync-interval", time.Minute,
+		"Interval at which Bundles are pulled from the hub, when --hub-url is set.")
+}
+
+func (o *Options) addIntegrationFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Integration.BackendTLSPolicy,
+		"backend-tls-policy-integration", false,
+		"If true, point the caCertificateRefs of Gateway API BackendTLSPolicies annotated with "+
+			"'trust.cert-manager.io/ca-bundle: <bundle>' at the target ConfigMap of the Bundle, "+
+			"which must write to the 'ca.crt' key. Requires the Gateway API CRDs to be installed.")
+	fs.BoolVar(&o.Integration.IngressNginx,
+		"ingress-nginx-integration", false,
+		"If true, point the 'nginx.ingress.kubernetes.io/proxy-ssl-secret' annotation of Ingresses annotated with "+
+			"'trust.cert-manager.io/ca-bundle: <bundle>' at the target Secret of the Bundle, "+
+			"which must write to the 'ca.crt' key. Requires --secret-targets-enabled.")
+	fs.BoolVar(&o.Bundle.CertificateSourcesEnabled,
+		"cert-manager-certificate-integration", false,
+		"If true, allow Bundle sources to select cert-manager Certificates in the trust namespace by label, "+
+			"including the 'ca.crt' of their Secrets in the bundle as they are rotated. "+
+			"Requires the cert-manager CRDs to be installed.")
+	fs.StringVar(&o.Bundle.ClusterResourceNamespace,
+		"cert-manager-cluster-resource-namespace", "",
+		"Namespace cert-manager reads the Secrets of ClusterIssuers from, as set by its --cluster-resource-namespace flag. "+
+			"ClusterIssuer sources read their CA certificate from this namespace. Defaults to the trust namespace.")
 }
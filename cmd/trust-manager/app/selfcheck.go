@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cliflag "k8s.io/component-base/cli/flag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/selfcheck"
+)
+
+const selfcheckHelpOutput = `Check that trust-manager syncs Bundles in the cluster
+
+A temporary Bundle of a generated CA certificate is created, with a ConfigMap
+target holding PEM, JKS and PKCS#12 bundles. Once it has been synced to a test
+Namespace, the truststores are decoded and compared with the certificate, and
+the objects created by the check are deleted. The command fails if the Bundle
+isn't synced correctly within the timeout, so it can run as a post-install
+hook or from a runbook.`
+
+// newSelfcheckCommand returns the command which checks that a running
+// trust-manager syncs Bundles.
+func newSelfcheckCommand() *cobra.Command {
+	var (
+		kubeConfigFlags = genericclioptions.NewConfigFlags(true)
+		opts            = selfcheck.Options{PollInterval: time.Second}
+	)
+
+	cmd := &cobra.Command{
+		Use:           "selfcheck",
+		Short:         "Check that trust-manager syncs Bundles in the cluster",
+		Long:          selfcheckHelpOutput,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Log = logr.FromSlogHandler(slog.NewTextHandler(cmd.ErrOrStderr(), nil)).WithName("selfcheck")
+
+			restConfig, err := kubeConfigFlags.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("failed to build kubernetes rest config: %w", err)
+			}
+
+			cl, err := client.New(restConfig, client.Options{Scheme: trustapi.GlobalScheme})
+			if err != nil {
+				return fmt.Errorf("error creating kubernetes client: %w", err)
+			}
+
+			// Objects are still deleted when interrupted, as the check
+			// cleans up with a context of its own.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if err := selfcheck.Run(ctx, cl, opts); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "trust-manager synced the test Bundle")
+			return nil
+		},
+	}
+
+	var nfs cliflag.NamedFlagSets
+
+	fs := nfs.FlagSet("Selfcheck")
+	fs.StringVar(&opts.Namespace,
+		"namespace", "",
+		"Existing Namespace to sync the test Bundle to. If empty, a temporary Namespace is created, which "+
+			"requires trust-manager to watch all Namespaces.")
+	fs.DurationVar(&opts.Timeout,
+		"timeout", 2*time.Minute,
+		"Time the test Bundle may take to sync.")
+	kubeConfigFlags.AddFlags(nfs.FlagSet("Kubernetes"))
+
+	// The root command prints the flags of the controller, so this command
+	// prints its own.
+	usageFmt := "Usage:\n  %s\n"
+	cmd.SetUsageFunc(func(cmd *cobra.Command) error {
+		fmt.Fprintf(cmd.OutOrStderr(), usageFmt, cmd.UseLine())
+		cliflag.PrintSections(cmd.OutOrStderr(), nfs, 0)
+		return nil
+	})
+	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n\n"+usageFmt, cmd.Long, cmd.UseLine())
+		cliflag.PrintSections(cmd.OutOrStdout(), nfs, 0)
+	})
+
+	for _, f := range nfs.FlagSets {
+		cmd.Flags().AddFlagSet(f)
+	}
+
+	return cmd
+}
@@ -29,6 +29,18 @@ app.kubernetes.io/managed-by: {{ .Release.Service }}
 {{- end }}
 {{- end -}}
 
+{{/*
+The CA which signs the webhook certificate when app.webhook.tls.helmCert.enabled
+is true. genCA returns a new CA on every call, so it's generated once and kept
+on the root context, where every template which trusts the webhook can read it
+from .trustManagerWebhookCA.
+*/}}
+{{- define "trust-manager.webhookCA" -}}
+{{- if not (hasKey $ "trustManagerWebhookCA") -}}
+{{- $_ := set $ "trustManagerWebhookCA" (genCA (printf "*.%s.svc" .Release.Namespace ) 9125) -}}
+{{- end -}}
+{{- end -}}
+
 {{/*
 Util function for generating the image URL based on the provided options.
 IMPORTANT: This function is standarized across all charts in the cert-manager GH organization.
@@ -64,4 +76,72 @@ Create the name of the service account to use
 {{- else -}}
     {{ default "default" .Values.serviceAccount.name }}
 {{- end -}}
-{{- end -}}
\ No newline at end of file
+{{- end -}}
+{{/*
+RBAC rules for writing Bundle targets. These are granted across the cluster,
+or only in app.watchNamespaces when trust-manager runs in namespaced mode.
+*/}}
+{{- define "trust-manager.targetRules" -}}
+- apiGroups:
+  - ""
+  resources:
+  - "configmaps"
+  verbs: ["get", "list", "create", "patch", "watch", "delete"]
+{{- if .Values.secretTargets.enabled }}
+{{- if .Values.secretTargets.authorizedSecretsAll }}
+- apiGroups:
+  - ""
+  resources:
+  - "secrets"
+  verbs: ["get", "list", "create", "patch", "watch", "delete"]
+{{- else if .Values.secretTargets.authorizedSecrets }}
+- apiGroups:
+  - ""
+  resources:
+  - "secrets"
+  verbs: ["get", "list", "watch"]
+- apiGroups:
+  - ""
+  resources:
+  - "secrets"
+  verbs: ["create", "patch", "delete"]
+  resourceNames: {{ .Values.secretTargets.authorizedSecrets | toYaml | nindent 2 }}
+{{- end }}
+{{- end }}
+{{- if .Values.sourceNamespaceSelectors.enabled }}
+- apiGroups:
+  - ""
+  resources:
+  - "secrets"
+  verbs: ["get", "list", "watch"]
+{{- end }}
+{{- if .Values.rolloutWorkloads.enabled }}
+- apiGroups:
+  - "apps"
+  resources:
+  - "deployments"
+  - "statefulsets"
+  verbs: ["list", "patch"]
+{{- end }}
+{{- if .Values.consumerReport.enabled }}
+- apiGroups:
+  - ""
+  resources:
+  - "pods"
+  verbs: ["list"]
+{{- end }}
+{{- if .Values.integrations.backendTLSPolicy.enabled }}
+- apiGroups:
+  - "gateway.networking.k8s.io"
+  resources:
+  - "backendtlspolicies"
+  verbs: ["get", "list", "watch", "patch"]
+{{- end }}
+{{- if .Values.integrations.ingressNginx.enabled }}
+- apiGroups:
+  - "networking.k8s.io"
+  resources:
+  - "ingresses"
+  verbs: ["get", "list", "watch", "patch"]
+{{- end }}
+{{- end -}}
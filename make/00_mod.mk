@@ -25,6 +25,10 @@ build_names := manager package_debian
 
 go_manager_main_dir := ./cmd/trust-manager
 go_manager_mod_dir := .
+# The build tags of the optional cloud source providers compiled into the
+# manager, such as "gcpcas,azurekeyvault".
+cloud_source_tags ?=
+go_manager_flags := -tags=$(cloud_source_tags)
 go_manager_ldflags := -X $(repo_name)/internal/version.AppVersion=$(VERSION) -X $(repo_name)/internal/version.GitCommit=$(GITCOMMIT)
 oci_manager_base_image_flavor := static
 oci_manager_image_name := quay.io/jetstack/trust-manager
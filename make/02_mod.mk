@@ -33,6 +33,7 @@ smoke:
 	$(MAKE) test-smoke
 
 include make/debian-trust-package.mk
+include make/trust-cli.mk
 
 .PHONY: release
 ## Publish all release artifacts (image + helm chart)
@@ -59,3 +60,20 @@ release-debian-trust-package:
 	@echo "RELEASE_OCI_PACKAGE_DEBIAN_TAG=$(oci_package_debian_image_tag)" >> "$(GITHUB_OUTPUT)"
 
 	@echo "Release complete!"
+
+# The Bundle CRD is served in several versions, which are converted by the
+# trust-manager webhook. controller-gen doesn't generate the conversion
+# configuration, so the CRD templates of the chart use local headers and
+# footers which add it.
+crd_template_header := make/config/crd/crd.template.header.yaml
+crd_template_footer := make/config/crd/crd.template.footer.yaml
+
+# Versions of the Bundle CRD other than the storage version are only served
+# while the conversion webhook is enabled. Without it, the API server doesn't
+# convert between versions, and fields such as spec.target, which v1beta1
+# doesn't have, would be pruned from Bundles read or written through them.
+.PHONY: generate-crd-served-versions
+generate-crd-served-versions: | generate-crds
+	$(sed_inplace) -e '/served: true/{N;s/served: true\(\n *storage: false\)/served: {{ .Values.app.webhook.enabled }}\1/;}' $(helm_chart_source_dir)/templates/crd-trust.cert-manager.io_bundles.yaml
+
+shared_generate_targets += generate-crd-served-versions
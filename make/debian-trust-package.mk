@@ -20,6 +20,9 @@ $(debian_package_layer)/debian-package $(bin_dir)/bin:
 $(bin_dir)/bin/validate-trust-package: cmd/validate-trust-package/*.go pkg/fspkg/*.go | $(NEEDS_GO) $(bin_dir)/bin
 	$(GO) build -o $@ ./cmd/validate-trust-package
 
+$(bin_dir)/bin/inspect-trust-package: cmd/inspect-trust-package/*.go pkg/fspkg/*.go | $(NEEDS_GO) $(bin_dir)/bin
+	$(GO) build -o $@ ./cmd/inspect-trust-package
+
 $(debian_package_json): | $(bin_dir)/bin/validate-trust-package $(debian_package_layer)/debian-package
 	BIN_VALIDATE_TRUST_PACKAGE=$(bin_dir)/bin/validate-trust-package \
 		./make/debian-trust-package-fetch.sh exact $(DEBIAN_BUNDLE_SOURCE_IMAGE) $@ $(DEBIAN_BUNDLE_VERSION)
@@ -36,12 +39,15 @@ ifeq ($(HOST_OS),darwin)
 endif
 
 .PHONY: upgrade-debian-trust-package-version
-upgrade-debian-trust-package-version: | $(bin_dir)/bin/validate-trust-package $(bin_dir)/scratch
+upgrade-debian-trust-package-version: | $(bin_dir)/bin/validate-trust-package $(bin_dir)/bin/inspect-trust-package $(debian_package_json) $(bin_dir)/scratch
 	$(eval temp_out := $(bin_dir)/scratch/debian-trust-package.temp.json)
 	rm -rf $(temp_out)
 
 	BIN_VALIDATE_TRUST_PACKAGE=$(bin_dir)/bin/validate-trust-package \
 		./make/debian-trust-package-fetch.sh latest $(DEBIAN_BUNDLE_SOURCE_IMAGE) $(temp_out) $(DEBIAN_BUNDLE_VERSION)
 
+	@echo "Certificates changed since the current package:"
+	$(bin_dir)/bin/inspect-trust-package -diff $(debian_package_json) $(temp_out)
+
 	latest_version=$$(jq -r '.version' $(temp_out)); \
 		$(sed_inplace) "s/DEBIAN_BUNDLE_VERSION := .*/DEBIAN_BUNDLE_VERSION := $$latest_version/" make/00_debian_version.mk
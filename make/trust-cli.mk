@@ -0,0 +1,22 @@
+# Copyright 2026 The cert-manager Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+$(bin_dir)/bin/trust-cli: cmd/trust-cli/*.go cmd/trust-cli/app/*.go pkg/bundle/*.go | $(NEEDS_GO) $(bin_dir)/bin
+	$(GO) build -o $@ ./cmd/trust-cli
+
+.PHONY: build-trust-cli
+## Build trust-cli, which shows the status of Bundles in a cluster, compares
+## their targets with their sources and exports them in any format.
+## @category Development
+build-trust-cli: $(bin_dir)/bin/trust-cli
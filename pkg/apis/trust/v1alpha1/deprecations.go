@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Deprecation is a field of Bundles, or a value of one, which is deprecated
+// or whose default is about to change. Clients submitting Bundles which use
+// it are warned.
+// +kubebuilder:object:generate=false
+type Deprecation struct {
+	// Versions are the API versions of Bundle which the deprecation applies
+	// to, or all versions if empty.
+	Versions []string
+
+	// Message explains the deprecation, and what to do instead.
+	Message string
+
+	// Uses returns the paths at which the Bundle uses the deprecated field or
+	// value.
+	Uses func(bundle *Bundle) []*field.Path
+}
+
+// Deprecations is the registry of deprecated fields and values of Bundles.
+var Deprecations = []Deprecation{
+	{
+		Versions: []string{"v1alpha1"},
+		Message:  "spec.target is deprecated and not served in trust.cert-manager.io/v1beta1; move the target to spec.targets",
+		Uses: func(bundle *Bundle) []*field.Path {
+			if bundle.Spec.Target.ConfigMap == nil && bundle.Spec.Target.Secret == nil {
+				return nil
+			}
+			return []*field.Path{field.NewPath("spec", "target")}
+		},
+	},
+	{
+		Message: fmt.Sprintf("the default JKS password %q is deprecated; JKS truststores will be written without a password by default in a future release, like PKCS#12 truststores", DefaultJKSPassword),
+		Uses: func(bundle *Bundle) []*field.Path {
+			var paths []*field.Path
+			visitAdditionalFormats(bundle, func(formats *AdditionalFormats, path *field.Path) {
+				if formats.JKS != nil && (formats.JKS.Password == nil || *formats.JKS.Password == DefaultJKSPassword) {
+					paths = append(paths, path.Child("jks", "password"))
+				}
+			})
+			return paths
+		},
+	},
+}
+
+// DeprecationWarnings returns a warning for each use of a deprecated field or
+// value by the Bundle, which was submitted in the given API version. If the
+// version is empty, deprecations of every version apply.
+func DeprecationWarnings(bundle *Bundle, version string) []string {
+	var warnings []string
+	for _, deprecation := range Deprecations {
+		if version != "" && len(deprecation.Versions) > 0 && !slices.Contains(deprecation.Versions, version) {
+			continue
+		}
+
+		for _, path := range deprecation.Uses(bundle) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", path, deprecation.Message))
+		}
+	}
+	return warnings
+}
+
+// visitAdditionalFormats calls fn with each set of additional formats of the
+// Bundle's targets, along with their path.
+func visitAdditionalFormats(bundle *Bundle, fn func(formats *AdditionalFormats, path *field.Path)) {
+	visit := func(t *BundleTarget, path *field.Path) {
+		if t.AdditionalFormats != nil {
+			fn(t.AdditionalFormats, path.Child("additionalFormats"))
+		}
+		if t.ConfigMap != nil && t.ConfigMap.AdditionalFormats != nil {
+			fn(t.ConfigMap.AdditionalFormats, path.Child("configMap", "additionalFormats"))
+		}
+		if t.Secret != nil && t.Secret.AdditionalFormats != nil {
+			fn(t.Secret.AdditionalFormats, path.Child("secret", "additionalFormats"))
+		}
+	}
+
+	path := field.NewPath("spec")
+	visit(&bundle.Spec.Target, path.Child("target"))
+	for i := range bundle.Spec.Targets {
+		visit(&bundle.Spec.Targets[i], path.Child("targets").Index(i))
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	const (
+		targetWarning = "spec.target: spec.target is deprecated and not served in trust.cert-manager.io/v1beta1; move the target to spec.targets"
+		jksWarning    = `: the default JKS password "changeit" is deprecated; JKS truststores will be written without a password by default in a future release, like PKCS#12 truststores`
+	)
+
+	tests := map[string]struct {
+		spec    BundleSpec
+		version string
+		exp     []string
+	}{
+		"targets without deprecated fields": {
+			spec: BundleSpec{
+				Targets: []BundleTarget{{
+					ConfigMap:         &ConfigMapTarget{KeySelector: KeySelector{Key: "trust.pem"}},
+					AdditionalFormats: &AdditionalFormats{JKS: &JKS{KeySelector: KeySelector{Key: "trust.jks"}, Password: ptr.To("secret")}},
+				}},
+			},
+			version: "v1alpha1",
+		},
+		"target submitted as v1alpha1": {
+			spec:    BundleSpec{Target: BundleTarget{ConfigMap: &ConfigMapTarget{KeySelector: KeySelector{Key: "trust.pem"}}}},
+			version: "v1alpha1",
+			exp:     []string{targetWarning},
+		},
+		"target converted from v1beta1": {
+			spec:    BundleSpec{Target: BundleTarget{ConfigMap: &ConfigMapTarget{KeySelector: KeySelector{Key: "trust.pem"}}}},
+			version: "v1beta1",
+		},
+		"default JKS passwords": {
+			spec: BundleSpec{
+				Targets: []BundleTarget{{
+					ConfigMap:         &ConfigMapTarget{KeySelector: KeySelector{Key: "trust.pem"}},
+					AdditionalFormats: &AdditionalFormats{JKS: &JKS{KeySelector: KeySelector{Key: "trust.jks"}, Password: ptr.To(DefaultJKSPassword)}},
+					Secret: &SecretTarget{
+						KeySelector:       KeySelector{Key: "trust.pem"},
+						AdditionalFormats: &AdditionalFormats{JKS: &JKS{KeySelector: KeySelector{Key: "trust.jks"}}},
+					},
+				}},
+			},
+			version: "v1beta1",
+			exp: []string{
+				"spec.targets[0].additionalFormats.jks.password" + jksWarning,
+				"spec.targets[0].secret.additionalFormats.jks.password" + jksWarning,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, DeprecationWarnings(&Bundle{Spec: test.spec}, test.version))
+		})
+	}
+}
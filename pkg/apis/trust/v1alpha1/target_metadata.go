@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TargetMetadataVariables are the variables which the values of target labels
+// and annotations can reference.
+// +kubebuilder:object:generate=false
+type TargetMetadataVariables struct {
+	BundleName     string
+	BundleHash     string
+	PackageVersion string
+	SyncTimestamp  string
+}
+
+// Render returns the labels and annotations of the metadata, with the
+// templates of their values executed against the variables.
+func (m *TargetMetadata) Render(vars TargetMetadataVariables) (labels, annotations map[string]string, err error) {
+	if m == nil {
+		return nil, nil, nil
+	}
+
+	render := func(values map[string]string) (map[string]string, error) {
+		if values == nil {
+			return nil, nil
+		}
+
+		rendered := make(map[string]string, len(values))
+		for key, value := range values {
+			value, err := RenderTargetMetadataValue(value, vars)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			rendered[key] = value
+		}
+		return rendered, nil
+	}
+
+	if labels, err = render(m.Labels); err != nil {
+		return nil, nil, fmt.Errorf("failed to render label %w", err)
+	}
+	if annotations, err = render(m.Annotations); err != nil {
+		return nil, nil, fmt.Errorf("failed to render annotation %w", err)
+	}
+	return labels, annotations, nil
+}
+
+// RenderTargetMetadataValue executes the value of a target label or
+// annotation as a template against the variables.
+func RenderTargetMetadataValue(value string, vars TargetMetadataVariables) (string, error) {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetMetadataRender(t *testing.T) {
+	vars := TargetMetadataVariables{
+		BundleName:     "my-bundle",
+		BundleHash:     "abc123",
+		PackageVersion: "cert-manager-debian-1",
+		SyncTimestamp:  "2026-01-02T03:04:05Z",
+	}
+
+	metadata := &TargetMetadata{
+		Labels: map[string]string{"team": "platform", "bundle": "{{ .BundleName }}"},
+		Annotations: map[string]string{
+			"hash":    "{{ .BundleHash }}",
+			"details": "{{ .PackageVersion }}@{{ .SyncTimestamp }}",
+		},
+	}
+	labels, annotations, err := metadata.Render(vars)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform", "bundle": "my-bundle"}, labels)
+	assert.Equal(t, map[string]string{"hash": "abc123", "details": "cert-manager-debian-1@2026-01-02T03:04:05Z"}, annotations)
+
+	labels, annotations, err = (*TargetMetadata)(nil).Render(vars)
+	assert.NoError(t, err)
+	assert.Nil(t, labels)
+	assert.Nil(t, annotations)
+
+	_, _, err = (&TargetMetadata{Labels: map[string]string{"bundle": "{{ .Bundle }}"}}).Render(vars)
+	assert.ErrorContains(t, err, "failed to render label bundle: ")
+
+	_, _, err = (&TargetMetadata{Annotations: map[string]string{"hash": "{{ .BundleHash"}}).Render(vars)
+	assert.ErrorContains(t, err, "failed to render annotation hash: ")
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// AllTargets returns every target of the Bundle: spec.target, if it defines a
+// ConfigMap or Secret, followed by spec.targets.
+func (spec *BundleSpec) AllTargets() []BundleTarget {
+	targets := make([]BundleTarget, 0, len(spec.Targets)+1)
+	if spec.Target.ConfigMap != nil || spec.Target.Secret != nil {
+		targets = append(targets, spec.Target)
+	}
+	return append(targets, spec.Targets...)
+}
+
+// ConfigMapFormats returns the additional formats written to the target
+// ConfigMap: those of the ConfigMap target if set, or else those of the
+// target.
+func (t *BundleTarget) ConfigMapFormats() *AdditionalFormats {
+	if t.ConfigMap != nil && t.ConfigMap.AdditionalFormats != nil {
+		return t.ConfigMap.AdditionalFormats
+	}
+	return t.AdditionalFormats
+}
+
+// SecretFormats returns the additional formats written to the target Secret:
+// those of the Secret target if set, or else those of the target.
+func (t *BundleTarget) SecretFormats() *AdditionalFormats {
+	if t.Secret != nil && t.Secret.AdditionalFormats != nil {
+		return t.Secret.AdditionalFormats
+	}
+	return t.AdditionalFormats
+}
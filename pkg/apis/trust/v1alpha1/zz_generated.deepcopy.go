@@ -25,6 +25,26 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSPrivateCASource) DeepCopyInto(out *AWSPrivateCASource) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateCASource.
+func (in *AWSPrivateCASource) DeepCopy() *AWSPrivateCASource {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSPrivateCASource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalFormats) DeepCopyInto(out *AdditionalFormats) {
 	*out = *in
@@ -38,6 +58,16 @@ func (in *AdditionalFormats) DeepCopyInto(out *AdditionalFormats) {
 		*out = new(PKCS12)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(SPIFFE)
+		**out = **in
+	}
+	if in.Certdata != nil {
+		in, out := &in.Certdata, &out.Certdata
+		*out = new(Certdata)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalFormats.
@@ -50,6 +80,41 @@ func (in *AdditionalFormats) DeepCopy() *AdditionalFormats {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalFormatsTarget) DeepCopyInto(out *AdditionalFormatsTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalFormatsTarget.
+func (in *AdditionalFormatsTarget) DeepCopy() *AdditionalFormatsTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalFormatsTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultSource) DeepCopyInto(out *AzureKeyVaultSource) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultSource.
+func (in *AzureKeyVaultSource) DeepCopy() *AzureKeyVaultSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bundle) DeepCopyInto(out *Bundle) {
 	*out = *in
@@ -77,6 +142,21 @@ func (in *Bundle) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleCertificate) DeepCopyInto(out *BundleCertificate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleCertificate.
+func (in *BundleCertificate) DeepCopy() *BundleCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleCondition) DeepCopyInto(out *BundleCondition) {
 	*out = *in
@@ -93,6 +173,62 @@ func (in *BundleCondition) DeepCopy() *BundleCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleContentChange) DeepCopyInto(out *BundleContentChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Added != nil {
+		in, out := &in.Added, &out.Added
+		*out = make([]BundleCertificate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]BundleCertificate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleContentChange.
+func (in *BundleContentChange) DeepCopy() *BundleContentChange {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleContentChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleFilters) DeepCopyInto(out *BundleFilters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleFilters.
+func (in *BundleFilters) DeepCopy() *BundleFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleHistory) DeepCopyInto(out *BundleHistory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleHistory.
+func (in *BundleHistory) DeepCopy() *BundleHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleList) DeepCopyInto(out *BundleList) {
 	*out = *in
@@ -125,6 +261,72 @@ func (in *BundleList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleManifest) DeepCopyInto(out *BundleManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleManifest.
+func (in *BundleManifest) DeepCopy() *BundleManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleRolloutStatus) DeepCopyInto(out *BundleRolloutStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.PromotionTime != nil {
+		in, out := &in.PromotionTime, &out.PromotionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleRolloutStatus.
+func (in *BundleRolloutStatus) DeepCopy() *BundleRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleSignature) DeepCopyInto(out *BundleSignature) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSignature.
+func (in *BundleSignature) DeepCopy() *BundleSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleSnapshot) DeepCopyInto(out *BundleSnapshot) {
+	*out = *in
+	in.PublishTime.DeepCopyInto(&out.PublishTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSnapshot.
+func (in *BundleSnapshot) DeepCopy() *BundleSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleSource) DeepCopyInto(out *BundleSource) {
 	*out = *in
@@ -148,6 +350,51 @@ func (in *BundleSource) DeepCopyInto(out *BundleSource) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UseInClusterCA != nil {
+		in, out := &in.UseInClusterCA, &out.UseInClusterCA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(IssuerReference)
+		**out = **in
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificateSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SPIFFEFederation != nil {
+		in, out := &in.SPIFFEFederation, &out.SPIFFEFederation
+		*out = new(SPIFFEFederationSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(AWSPrivateCASource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCPCASPool != nil {
+		in, out := &in.GCPCASPool, &out.GCPCASPool
+		*out = new(GCPCASPoolSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(AzureKeyVaultSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]CertificateUsage, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSource.
@@ -171,6 +418,33 @@ func (in *BundleSpec) DeepCopyInto(out *BundleSpec) {
 		}
 	}
 	in.Target.DeepCopyInto(&out.Target)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]BundleTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequireCABasicConstraints != nil {
+		in, out := &in.RequireCABasicConstraints, &out.RequireCABasicConstraints
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(BundleFilters)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(BundleHistory)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSpec.
@@ -198,6 +472,51 @@ func (in *BundleStatus) DeepCopyInto(out *BundleStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FilteredCertificates != nil {
+		in, out := &in.FilteredCertificates, &out.FilteredCertificates
+		*out = new(FilteredCertificates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OptedOutNamespaces != nil {
+		in, out := &in.OptedOutNamespaces, &out.OptedOutNamespaces
+		*out = new(OptedOutNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(BundleRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]BundleSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastContentChange != nil {
+		in, out := &in.LastContentChange, &out.LastContentChange
+		*out = new(BundleContentChange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KeyTransitions != nil {
+		in, out := &in.KeyTransitions, &out.KeyTransitions
+		*out = make([]KeyTransitionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleStatus.
@@ -215,24 +534,66 @@ func (in *BundleTarget) DeepCopyInto(out *BundleTarget) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(KeySelector)
-		**out = **in
+		*out = new(ConfigMapTarget)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(KeySelector)
-		**out = **in
+		*out = new(SecretTarget)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AdditionalFormats != nil {
 		in, out := &in.AdditionalFormats, &out.AdditionalFormats
 		*out = new(AdditionalFormats)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalFormatsTarget != nil {
+		in, out := &in.AdditionalFormatsTarget, &out.AdditionalFormatsTarget
+		*out = new(AdditionalFormatsTarget)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(BundleSignature)
+		**out = **in
+	}
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(BundleManifest)
+		**out = **in
+	}
+	if in.UsageKeys != nil {
+		in, out := &in.UsageKeys, &out.UsageKeys
+		*out = make([]UsageKey, len(*in))
+		copy(*out, *in)
+	}
 	if in.NamespaceSelector != nil {
 		in, out := &in.NamespaceSelector, &out.NamespaceSelector
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KeyOverrides != nil {
+		in, out := &in.KeyOverrides, &out.KeyOverrides
+		*out = make([]KeyOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KeyTransition != nil {
+		in, out := &in.KeyTransition, &out.KeyTransition
+		*out = new(KeyTransition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(TargetMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleTarget.
@@ -245,6 +606,179 @@ func (in *BundleTarget) DeepCopy() *BundleTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRollout) DeepCopyInto(out *CanaryRollout) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRollout.
+func (in *CanaryRollout) DeepCopy() *CanaryRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Certdata) DeepCopyInto(out *Certdata) {
+	*out = *in
+	out.KeySelector = in.KeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certdata.
+func (in *Certdata) DeepCopy() *Certdata {
+	if in == nil {
+		return nil
+	}
+	out := new(Certdata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSource) DeepCopyInto(out *CertificateSource) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSource.
+func (in *CertificateSource) DeepCopy() *CertificateSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapTarget) DeepCopyInto(out *ConfigMapTarget) {
+	*out = *in
+	out.KeySelector = in.KeySelector
+	if in.AdditionalFormats != nil {
+		in, out := &in.AdditionalFormats, &out.AdditionalFormats
+		*out = new(AdditionalFormats)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalKeys != nil {
+		in, out := &in.AdditionalKeys, &out.AdditionalKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapTarget.
+func (in *ConfigMapTarget) DeepCopy() *ConfigMapTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilteredCertificate) DeepCopyInto(out *FilteredCertificate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilteredCertificate.
+func (in *FilteredCertificate) DeepCopy() *FilteredCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(FilteredCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilteredCertificates) DeepCopyInto(out *FilteredCertificates) {
+	*out = *in
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]FilteredCertificate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilteredCertificates.
+func (in *FilteredCertificates) DeepCopy() *FilteredCertificates {
+	if in == nil {
+		return nil
+	}
+	out := new(FilteredCertificates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCASPoolSource) DeepCopyInto(out *GCPCASPoolSource) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCASPoolSource.
+func (in *GCPCASPoolSource) DeepCopy() *GCPCASPoolSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCASPoolSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerReference) DeepCopyInto(out *IssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerReference.
+func (in *IssuerReference) DeepCopy() *IssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JKS) DeepCopyInto(out *JKS) {
 	*out = *in
@@ -266,6 +800,22 @@ func (in *JKS) DeepCopy() *JKS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyOverride) DeepCopyInto(out *KeyOverride) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyOverride.
+func (in *KeyOverride) DeepCopy() *KeyOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeySelector) DeepCopyInto(out *KeySelector) {
 	*out = *in
@@ -281,6 +831,65 @@ func (in *KeySelector) DeepCopy() *KeySelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyTransition) DeepCopyInto(out *KeyTransition) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyTransition.
+func (in *KeyTransition) DeepCopy() *KeyTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyTransitionStatus) DeepCopyInto(out *KeyTransitionStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyTransitionStatus.
+func (in *KeyTransitionStatus) DeepCopy() *KeyTransitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyTransitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptedOutNamespaces) DeepCopyInto(out *OptedOutNamespaces) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptedOutNamespaces.
+func (in *OptedOutNamespaces) DeepCopy() *OptedOutNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(OptedOutNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PKCS12) DeepCopyInto(out *PKCS12) {
 	*out = *in
@@ -302,6 +911,159 @@ func (in *PKCS12) DeepCopy() *PKCS12 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSource) DeepCopyInto(out *PluginSource) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSource.
+func (in *PluginSource) DeepCopy() *PluginSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProgressiveRollout) DeepCopyInto(out *ProgressiveRollout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProgressiveRollout.
+func (in *ProgressiveRollout) DeepCopy() *ProgressiveRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ProgressiveRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterStatus.
+func (in *RemoteClusterStatus) DeepCopy() *RemoteClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.Progressive != nil {
+		in, out := &in.Progressive, &out.Progressive
+		*out = new(ProgressiveRollout)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryRollout)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFE) DeepCopyInto(out *SPIFFE) {
+	*out = *in
+	out.KeySelector = in.KeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFE.
+func (in *SPIFFE) DeepCopy() *SPIFFE {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFE)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEFederationSource) DeepCopyInto(out *SPIFFEFederationSource) {
+	*out = *in
+	if in.BootstrapBundle != nil {
+		in, out := &in.BootstrapBundle, &out.BootstrapBundle
+		*out = new(ConfigMapKeyReference)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEFederationSource.
+func (in *SPIFFEFederationSource) DeepCopy() *SPIFFEFederationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEFederationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretTarget) DeepCopyInto(out *SecretTarget) {
+	*out = *in
+	out.KeySelector = in.KeySelector
+	if in.AdditionalFormats != nil {
+		in, out := &in.AdditionalFormats, &out.AdditionalFormats
+		*out = new(AdditionalFormats)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalKeys != nil {
+		in, out := &in.AdditionalKeys, &out.AdditionalKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTarget.
+func (in *SecretTarget) DeepCopy() *SecretTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceObjectKeySelector) DeepCopyInto(out *SourceObjectKeySelector) {
 	*out = *in
@@ -310,6 +1072,11 @@ func (in *SourceObjectKeySelector) DeepCopyInto(out *SourceObjectKeySelector) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceObjectKeySelector.
@@ -321,3 +1088,47 @@ func (in *SourceObjectKeySelector) DeepCopy() *SourceObjectKeySelector {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetMetadata) DeepCopyInto(out *TargetMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetMetadata.
+func (in *TargetMetadata) DeepCopy() *TargetMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageKey) DeepCopyInto(out *UsageKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageKey.
+func (in *UsageKey) DeepCopy() *UsageKey {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageKey)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+var _ conversion.Convertible = &Bundle{}
+
+// targetConversionAnnotationKey is set on v1beta1 Bundles converted from
+// v1alpha1 Bundles which use spec.target, whose first target then came from
+// spec.target rather than spec.targets. This keeps conversions lossless.
+const targetConversionAnnotationKey = "trust.cert-manager.io/v1alpha1-target"
+
+// targetFieldsConversionAnnotationKey is set on v1beta1 Bundles converted from
+// v1alpha1 Bundles whose spec.target sets fields, but neither a ConfigMap nor
+// a Secret. Such a spec.target isn't a target, so it's kept in the annotation
+// as JSON rather than in spec.targets.
+const targetFieldsConversionAnnotationKey = "trust.cert-manager.io/v1alpha1-target-fields"
+
+// ConvertTo converts this Bundle to the hub version, v1alpha1.
+func (src *Bundle) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.Bundle)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertJSON(src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertJSON(src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+
+	// spec.targets is split back into spec.target and spec.targets if the
+	// Bundle was converted from a v1alpha1 Bundle using spec.target.
+	if _, ok := src.Annotations[targetConversionAnnotationKey]; ok && len(dst.Spec.Targets) > 0 {
+		dst.Spec.Target, dst.Spec.Targets = dst.Spec.Targets[0], dst.Spec.Targets[1:]
+		if len(dst.Spec.Targets) == 0 {
+			dst.Spec.Targets = nil
+		}
+
+		dst.Annotations = withoutAnnotation(src.Annotations, targetConversionAnnotationKey)
+	} else if fields, ok := src.Annotations[targetFieldsConversionAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(fields), &dst.Spec.Target); err != nil {
+			return fmt.Errorf("failed to convert target: %w", err)
+		}
+
+		dst.Annotations = withoutAnnotation(src.Annotations, targetFieldsConversionAnnotationKey)
+	}
+
+	return nil
+}
+
+// withAnnotation returns a copy of the annotations with the given key set.
+func withAnnotation(annotations map[string]string, key, value string) map[string]string {
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	return annotations
+}
+
+// withoutAnnotation returns a copy of the annotations without the given key,
+// or nil if no other annotations are left.
+func withoutAnnotation(annotations map[string]string, key string) map[string]string {
+	annotations = maps.Clone(annotations)
+	delete(annotations, key)
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+// ConvertFrom converts from the hub version, v1alpha1, to this Bundle.
+func (dst *Bundle) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.Bundle)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertJSON(src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertJSON(src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+
+	// spec.target and spec.targets are merged into spec.targets.
+	dst.Spec.Targets = nil
+	if err := convertJSON(src.Spec.Targets, &dst.Spec.Targets); err != nil {
+		return fmt.Errorf("failed to convert targets: %w", err)
+	}
+	// As in AllTargets, spec.target is only a target if it defines a
+	// ConfigMap or Secret. Any other fields it sets are kept in an annotation,
+	// as a target without either isn't valid in v1beta1.
+	switch {
+	case src.Spec.Target.ConfigMap != nil || src.Spec.Target.Secret != nil:
+		var target BundleTarget
+		if err := convertJSON(src.Spec.Target, &target); err != nil {
+			return fmt.Errorf("failed to convert target: %w", err)
+		}
+		dst.Spec.Targets = append([]BundleTarget{target}, dst.Spec.Targets...)
+
+		dst.Annotations = withAnnotation(src.Annotations, targetConversionAnnotationKey, "true")
+	case !reflect.DeepEqual(src.Spec.Target, v1alpha1.BundleTarget{}):
+		fields, err := json.Marshal(src.Spec.Target)
+		if err != nil {
+			return fmt.Errorf("failed to convert target: %w", err)
+		}
+
+		dst.Annotations = withAnnotation(src.Annotations, targetFieldsConversionAnnotationKey, string(fields))
+	}
+
+	return nil
+}
+
+// convertJSON converts between the types of the two versions by way of their
+// JSON encoding. Apart from spec.target, the schemas of v1alpha1 and v1beta1
+// are the same; fields which diverge between the versions need to be
+// converted explicitly, after the remainder of the object is converted.
+func convertJSON(src, dst any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
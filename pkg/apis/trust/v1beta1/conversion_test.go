@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func TestConversionRoundTrip(t *testing.T) {
+	hub := &v1alpha1.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Labels: map[string]string{"a": "b"}, Generation: 2},
+		Spec: v1alpha1.BundleSpec{
+			Sources: []v1alpha1.BundleSource{
+				{ConfigMap: &v1alpha1.SourceObjectKeySelector{Name: "cm", Key: "ca.crt"}},
+				{InLine: ptr.To("inline")},
+				{UseDefaultCAs: ptr.To(true)},
+			},
+			Target: v1alpha1.BundleTarget{
+				ConfigMap: &v1alpha1.ConfigMapTarget{KeySelector: v1alpha1.KeySelector{Key: "trust.pem"}},
+				AdditionalFormats: &v1alpha1.AdditionalFormats{
+					JKS: &v1alpha1.JKS{KeySelector: v1alpha1.KeySelector{Key: "trust.jks"}, Password: ptr.To("changeit")},
+				},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				MergeStrategy:     v1alpha1.MergeStrategyUnion,
+			},
+			Targets: []v1alpha1.BundleTarget{
+				{Secret: &v1alpha1.SecretTarget{KeySelector: v1alpha1.KeySelector{Key: "ca.crt"}}},
+			},
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+		},
+		Status: v1alpha1.BundleStatus{
+			Conditions: []v1alpha1.BundleCondition{{
+				Type:               v1alpha1.BundleConditionSynced,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Unix(1767225600, 0)),
+				Reason:             "Synced",
+				ObservedGeneration: 2,
+			}},
+			TargetCount: 3,
+		},
+	}
+
+	var spoke Bundle
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+	require.Len(t, spoke.Spec.Targets, 2)
+	assert.Equal(t, "trust.pem", spoke.Spec.Targets[0].ConfigMap.Key)
+	assert.Equal(t, MergeStrategyUnion, spoke.Spec.Targets[0].MergeStrategy)
+	assert.Equal(t, "ca.crt", spoke.Spec.Targets[1].Secret.Key)
+
+	var roundTripped v1alpha1.Bundle
+	require.NoError(t, spoke.ConvertTo(&roundTripped))
+	assert.Equal(t, hub, &roundTripped)
+}
+
+func TestConversionRoundTripTargetWithoutConfigMapOrSecret(t *testing.T) {
+	hub := &v1alpha1.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+		Spec: v1alpha1.BundleSpec{
+			Sources: []v1alpha1.BundleSource{{UseDefaultCAs: ptr.To(true)}},
+			Target: v1alpha1.BundleTarget{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+			Targets: []v1alpha1.BundleTarget{
+				{ConfigMap: &v1alpha1.ConfigMapTarget{KeySelector: v1alpha1.KeySelector{Key: "trust.pem"}}},
+				{Secret: &v1alpha1.SecretTarget{KeySelector: v1alpha1.KeySelector{Key: "ca.crt"}}},
+			},
+		},
+	}
+
+	var spoke Bundle
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+	// spec.target isn't a target, so it mustn't be served as one.
+	require.Len(t, spoke.Spec.Targets, 2)
+	assert.Equal(t, "trust.pem", spoke.Spec.Targets[0].ConfigMap.Key)
+	assert.Equal(t, "ca.crt", spoke.Spec.Targets[1].Secret.Key)
+	assert.NotContains(t, spoke.Annotations, targetConversionAnnotationKey)
+	assert.Contains(t, spoke.Annotations, targetFieldsConversionAnnotationKey)
+
+	var roundTripped v1alpha1.Bundle
+	require.NoError(t, spoke.ConvertTo(&roundTripped))
+	assert.Equal(t, hub, &roundTripped)
+}
+
+func TestConversionRoundTripFromSpoke(t *testing.T) {
+	spoke := &Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+		Spec: BundleSpec{
+			Sources: []BundleSource{{UseDefaultCAs: ptr.To(true)}},
+			Targets: []BundleTarget{
+				{ConfigMap: &ConfigMapTarget{KeySelector: KeySelector{Key: "trust.pem"}}},
+				{Secret: &SecretTarget{KeySelector: KeySelector{Key: "ca.crt"}}},
+			},
+		},
+	}
+
+	var hub v1alpha1.Bundle
+	require.NoError(t, spoke.ConvertTo(&hub))
+	assert.Equal(t, v1alpha1.BundleTarget{}, hub.Spec.Target)
+	assert.Len(t, hub.Spec.Targets, 2)
+
+	var roundTripped Bundle
+	require.NoError(t, roundTripped.ConvertFrom(&hub))
+	assert.Equal(t, spoke, &roundTripped)
+}
+
+func TestIsConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	ok, err := conversion.IsConvertible(scheme, &v1alpha1.Bundle{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
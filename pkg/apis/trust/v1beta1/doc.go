@@ -0,0 +1,23 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta1 contains the v1beta1 version of the trust-manager API.
+// Bundles are stored as v1alpha1, and converted to and from v1beta1 by the
+// conversion webhook of trust-manager, so v1beta1 is only served while the
+// webhook is enabled.
+// +kubebuilder:object:generate=true
+// +groupName=trust.cert-manager.io
+package v1beta1
@@ -0,0 +1,1427 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="ConfigMap Target",type="string",JSONPath=".spec.targets[*].configMap.key",description="Bundle ConfigMap Target Key"
+// +kubebuilder:printcolumn:name="Secret Target",type="string",JSONPath=".spec.targets[*].secret.key",description="Bundle Secret Target Key"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=`.status.conditions[?(@.type == "Synced")].status`,description="Bundle has been synced"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=`.status.conditions[?(@.type == "Synced")].reason`,description="Reason Bundle has Synced status"
+// +kubebuilder:printcolumn:name="Targets",type="integer",JSONPath=".status.targetCount",description="Number of targets the Bundle is synced to"
+// +kubebuilder:printcolumn:name="Synced Targets",type="integer",JSONPath=".status.syncedTargetCount",description="Number of targets successfully synced",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Time the Bundle was last synced",priority=1
+// +kubebuilder:printcolumn:name="DefaultCAVersion",type="string",JSONPath=".status.defaultCAVersion",description="Version of the default CA package the Bundle uses"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Timestamp Bundle was created"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=tb
+// +genclient
+// +genclient:nonNamespaced
+
+type Bundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the Bundle resource.
+	Spec BundleSpec `json:"spec"`
+
+	// Status of the Bundle. This is set and managed automatically.
+	// +optional
+	Status BundleStatus `json:"status"`
+}
+
+// +kubebuilder:object:root=true
+type BundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Bundle `json:"items"`
+}
+
+// BundleSpec defines the desired state of a Bundle.
+// +kubebuilder:validation:XValidation:rule="self.sources.exists(s, !(has(s.useDefaultCAs) && !s.useDefaultCAs) && !(has(s.useInClusterCA) && !s.useInClusterCA))",message="must define at least one source"
+// +kubebuilder:validation:XValidation:rule="self.sources.filter(s, has(s.useDefaultCAs)).size() <= 1",message="must request default CAs either once or not at all"
+// +kubebuilder:validation:XValidation:rule="self.sources.filter(s, has(s.useInClusterCA)).size() <= 1",message="must request the in-cluster CA either once or not at all"
+// +kubebuilder:validation:XValidation:rule="self.targets.all(t, has(t.configMap) || has(t.secret))",message="each of targets must define a configMap or secret target"
+// +kubebuilder:validation:XValidation:rule="self.targets.filter(t, has(t.configMap)).size() <= 1",message="configMap targets may only be defined once"
+// +kubebuilder:validation:XValidation:rule="self.targets.filter(t, has(t.secret)).size() <= 1",message="secret targets may only be defined once"
+type BundleSpec struct {
+	// Sources is a set of references to data whose data will sync to the target.
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Sources []BundleSource `json:"sources"`
+
+	// Targets are the target locations to sync source data to, each with its
+	// own keys, formats and namespace selector. This allows, for example, a
+	// ConfigMap target for pods and a Secret target in fewer Namespaces for an
+	// ingress controller. ConfigMap and Secret targets may each only be
+	// defined once.
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	Targets []BundleTarget `json:"targets"`
+
+	// Paused, when true, stops trust-manager from syncing this Bundle's targets.
+	// Existing targets are left untouched, and the Bundle reports a `Paused`
+	// condition until it is unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// RequireCABasicConstraints, if set, overrides the
+	// "--require-ca-basic-constraints" setting of the trust-manager controller
+	// for this Bundle. When enabled, certificates which aren't CA certificates
+	// are filtered from the sources, and InLine sources containing them are
+	// rejected.
+	// +optional
+	RequireCABasicConstraints *bool `json:"requireCABasicConstraints,omitempty"`
+
+	// Filters configures how certificates in the sources are filtered.
+	// +optional
+	Filters *BundleFilters `json:"filters,omitempty"`
+
+	// RefreshInterval, if set, overrides the "--requeue-interval" setting of
+	// the trust-manager controller for this Bundle. The Bundle is re-synced
+	// at this interval even if no events for its sources or targets are
+	// received. A zero interval disables periodic re-syncs.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// History, if set, keeps the last bundles published to the targets as
+	// snapshots, which the Bundle can be rolled back to. Snapshots are kept
+	// in Secrets in the trust Namespace, and listed in the status of the
+	// Bundle.
+	// +optional
+	History *BundleHistory `json:"history,omitempty"`
+
+	// RollbackTo, if set, is the hash of a snapshot in the history of the
+	// Bundle, which is published to the targets instead of the bundle built
+	// from the sources. This allows a bad change to a source to be reverted
+	// quickly, without reconstructing the previous sources. The
+	// "trust.cert-manager.io/rollback-to" annotation has the same effect.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{64}$`
+	RollbackTo string `json:"rollbackTo,omitempty"`
+}
+
+// BundleHistory configures the snapshots kept of a Bundle.
+type BundleHistory struct {
+	// Limit is the number of snapshots kept. The oldest snapshots are
+	// removed when a new bundle is published.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Limit int32 `json:"limit"`
+}
+
+// BundleFilters configures how certificates in the sources of a Bundle are
+// filtered.
+type BundleFilters struct {
+	// Expired is the policy applied to expired certificates in the sources.
+	// With `Remove`, they are dropped from the bundle. With `Keep`, they are
+	// kept. With `Fail`, the Bundle fails to sync while any source contains
+	// one. Defaults to the "--filter-expired-certificates" setting of the
+	// trust-manager controller: `Remove` if enabled, `Keep` otherwise.
+	// +optional
+	Expired ExpiredCertificatePolicy `json:"expired,omitempty"`
+
+	// Deduplication is how duplicate certificates in the sources are
+	// detected. With `Fingerprint`, byte-identical certificates are removed.
+	// With `SPKI`, certificates with the same public key are removed, which
+	// includes cross-signed variants of a CA. With `SubjectSerial`,
+	// certificates with the same subject and serial number are removed.
+	// With `None`, every certificate in the sources is kept. The first
+	// certificate of duplicates is kept. Defaults to `Fingerprint`.
+	// +optional
+	Deduplication DeduplicationStrategy `json:"deduplication,omitempty"`
+
+	// Invalid is the policy applied to malformed PEM blocks in the sources,
+	// such as blocks which aren't certificates or certificates which can't be
+	// parsed. With `Fail`, the Bundle fails to sync while any source contains
+	// one. With `Skip`, they are dropped from the bundle, and listed in the
+	// InvalidCertificatesSkipped condition. Sources holding private keys
+	// always fail the Bundle. Defaults to `Fail`.
+	// +optional
+	Invalid InvalidCertificatePolicy `json:"invalid,omitempty"`
+}
+
+// ExpiredCertificatePolicy is the policy applied to expired certificates in
+// the sources of a Bundle.
+// +kubebuilder:validation:Enum=Remove;Keep;Fail
+type ExpiredCertificatePolicy string
+
+const (
+	// ExpiredCertificatePolicyRemove drops expired certificates from the
+	// bundle.
+	ExpiredCertificatePolicyRemove ExpiredCertificatePolicy = "Remove"
+
+	// ExpiredCertificatePolicyKeep keeps expired certificates in the bundle.
+	ExpiredCertificatePolicyKeep ExpiredCertificatePolicy = "Keep"
+
+	// ExpiredCertificatePolicyFail fails to sync the Bundle if its sources
+	// contain an expired certificate.
+	ExpiredCertificatePolicyFail ExpiredCertificatePolicy = "Fail"
+)
+
+// DeduplicationStrategy is how duplicate certificates in the sources of a
+// Bundle are detected.
+// +kubebuilder:validation:Enum=Fingerprint;SPKI;SubjectSerial;None
+type DeduplicationStrategy string
+
+const (
+	// DeduplicationStrategyFingerprint removes byte-identical certificates.
+	DeduplicationStrategyFingerprint DeduplicationStrategy = "Fingerprint"
+
+	// DeduplicationStrategySPKI removes certificates with the same public key.
+	DeduplicationStrategySPKI DeduplicationStrategy = "SPKI"
+
+	// DeduplicationStrategySubjectSerial removes certificates with the same
+	// subject and serial number.
+	DeduplicationStrategySubjectSerial DeduplicationStrategy = "SubjectSerial"
+
+	// DeduplicationStrategyNone keeps every certificate.
+	DeduplicationStrategyNone DeduplicationStrategy = "None"
+)
+
+// InvalidCertificatePolicy is the policy applied to malformed PEM blocks in
+// the sources of a Bundle.
+// +kubebuilder:validation:Enum=Fail;Skip
+type InvalidCertificatePolicy string
+
+const (
+	// InvalidCertificatePolicyFail fails to sync the Bundle if its sources
+	// contain a malformed PEM block.
+	InvalidCertificatePolicyFail InvalidCertificatePolicy = "Fail"
+
+	// InvalidCertificatePolicySkip drops malformed PEM blocks from the bundle.
+	InvalidCertificatePolicySkip InvalidCertificatePolicy = "Skip"
+)
+
+// BundleSource is the set of sources whose data will be appended and synced to
+// the BundleTarget in all Namespaces.
+// +structType=atomic
+// +kubebuilder:validation:XValidation:rule="[has(self.configMap), has(self.secret), has(self.inLine), has(self.useDefaultCAs), has(self.useInClusterCA), has(self.issuerRef), has(self.certificates), has(self.spiffeFederation), has(self.awsPrivateCA), has(self.gcpCASPool), has(self.azureKeyVault), has(self.plugin)].exists_one(x, x)",message="must define exactly one source type for each item"
+type BundleSource struct {
+	// ConfigMap is a reference (by name) to a ConfigMap's `data` key(s), or to a
+	// list of ConfigMap's `data` key(s) using label selector, in the trust Namespace.
+	// +optional
+	ConfigMap *SourceObjectKeySelector `json:"configMap,omitempty"`
+
+	// Secret is a reference (by name) to a Secret's `data` key(s), or to a
+	// list of Secret's `data` key(s) using label selector, in the trust Namespace.
+	// +optional
+	Secret *SourceObjectKeySelector `json:"secret,omitempty"`
+
+	// InLine is a simple string to append as the source data.
+	// +optional
+	InLine *string `json:"inLine,omitempty"`
+
+	// UseDefaultCAs, when true, requests the default CA bundle to be used as a source.
+	// Default CAs are available if trust-manager was installed via Helm
+	// or was otherwise set up to include a package-injecting init container by using the
+	// "--default-package-location" flag when starting the trust-manager controller.
+	// If default CAs were not configured at start-up, any request to use the default
+	// CAs will fail.
+	// The version of the default CA package which is used for a Bundle is stored in the
+	// defaultCAPackageVersion field of the Bundle's status field.
+	// +optional
+	UseDefaultCAs *bool `json:"useDefaultCAs,omitempty"`
+
+	// UseInClusterCA, when true, requests the CA of the cluster's API server
+	// to be used as a source. Kubernetes publishes it to the "ca.crt" key of
+	// the "kube-root-ca.crt" ConfigMap in every Namespace, from which it is
+	// read in the trust Namespace.
+	// +optional
+	UseInClusterCA *bool `json:"useInClusterCA,omitempty"`
+
+	// IssuerRef is a reference to a cert-manager Issuer in the trust Namespace,
+	// or to a ClusterIssuer, whose CA certificate is used as the source data.
+	// The CA certificate is read from the Secret of a CA issuer, or from the
+	// Secrets of the CA Certificates issued by a SelfSigned issuer, so the
+	// Bundle follows the issuer as its CA is rotated. The Secrets of a
+	// ClusterIssuer are read from cert-manager's cluster resource Namespace,
+	// set with "--cert-manager-cluster-resource-namespace". SelfSigned issuers
+	// require trust-manager to be started with
+	// "--cert-manager-certificate-integration".
+	// +optional
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+
+	// Certificates selects cert-manager Certificates in the trust Namespace by
+	// label, and uses the CA certificate in the "ca.crt" key of the Secret of
+	// each as source data, so that the CAs issued by cert-manager, such as
+	// intermediate CAs, are trusted as they are rotated. Certificates whose
+	// Secret holds no CA certificate yet are skipped. Requires trust-manager
+	// to be started with "--cert-manager-certificate-integration".
+	// +optional
+	Certificates *CertificateSource `json:"certificates,omitempty"`
+
+	// SPIFFEFederation fetches the trust bundle of another SPIFFE trust domain
+	// from its bundle endpoint, making trust-manager the federation client of
+	// the cluster. The bundle is fetched again as its refresh hint suggests,
+	// and its X.509 authorities are used as the source data.
+	// +optional
+	SPIFFEFederation *SPIFFEFederationSource `json:"spiffeFederation,omitempty"`
+
+	// AWSPrivateCA fetches the certificate chain of an AWS Private CA, so
+	// that certificates it issues, such as through ACM, are trusted. The
+	// chain is fetched again at the refresh interval. trust-manager
+	// authenticates to AWS with the web identity token of its
+	// ServiceAccount (IRSA), or with the static credentials of its
+	// environment.
+	// +optional
+	AWSPrivateCA *AWSPrivateCASource `json:"awsPrivateCA,omitempty"`
+
+	// GCPCASPool fetches the CA certificates of a Google Cloud Certificate
+	// Authority Service CA pool, so that certificates issued from the pool
+	// are trusted. The certificates are fetched again at the refresh
+	// interval. trust-manager authenticates to Google Cloud with the service
+	// account of the metadata server, as provided by GKE Workload Identity
+	// Federation. Only available in builds of trust-manager with the
+	// "gcpcas" build tag.
+	// +optional
+	GCPCASPool *GCPCASPoolSource `json:"gcpCASPool,omitempty"`
+
+	// AzureKeyVault fetches a certificate stored in Azure Key Vault, such as
+	// the certificate of a CA. The latest version of the certificate is
+	// fetched again at the refresh interval. trust-manager authenticates to
+	// Azure with Microsoft Entra Workload ID. Only available in builds of
+	// trust-manager with the "azurekeyvault" build tag.
+	// +optional
+	AzureKeyVault *AzureKeyVaultSource `json:"azureKeyVault,omitempty"`
+
+	// Plugin fetches the certificates of a source plugin, so that sources
+	// such as the inventories of corporate PKIs can be provided without
+	// changes to trust-manager. Plugins run alongside trust-manager and serve
+	// the SourcePlugin gRPC API on a unix socket in its plugin directory.
+	// The certificates are fetched again at the refresh interval, and those
+	// fetched last are used while the plugin fails.
+	// +optional
+	Plugin *PluginSource `json:"plugin,omitempty"`
+
+	// Usages are the usages which the certificates of the source are trusted
+	// for. They are recorded in the manifest, and select the certificates
+	// written to the usageKeys of the targets. Sources without usages are
+	// trusted for every usage.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=2
+	Usages []CertificateUsage `json:"usages,omitempty"`
+}
+
+// CertificateUsage is a usage which the certificates of a source are trusted
+// for.
+// +kubebuilder:validation:Enum=serverAuth;clientAuth
+type CertificateUsage string
+
+const (
+	// CertificateUsageServerAuth trusts the certificates to authenticate
+	// servers.
+	CertificateUsageServerAuth CertificateUsage = "serverAuth"
+
+	// CertificateUsageClientAuth trusts the certificates to authenticate
+	// clients.
+	CertificateUsageClientAuth CertificateUsage = "clientAuth"
+)
+
+// SPIFFEBundleEndpointProfile is the profile of a SPIFFE bundle endpoint,
+// which determines how the endpoint is authenticated.
+type SPIFFEBundleEndpointProfile string
+
+const (
+	// SPIFFEBundleEndpointProfileHTTPSWeb authenticates the endpoint with
+	// Web PKI, as any other HTTPS server.
+	SPIFFEBundleEndpointProfileHTTPSWeb SPIFFEBundleEndpointProfile = "https_web"
+
+	// SPIFFEBundleEndpointProfileHTTPSSPIFFE authenticates the endpoint by
+	// its X.509-SVID, against the bundle of the endpoint's trust domain.
+	SPIFFEBundleEndpointProfileHTTPSSPIFFE SPIFFEBundleEndpointProfile = "https_spiffe"
+)
+
+// SPIFFEFederationSource is a SPIFFE bundle endpoint which the trust bundle of
+// a trust domain is fetched from.
+// +kubebuilder:validation:XValidation:rule="has(self.profile) && self.profile == 'https_spiffe' ? has(self.endpointSPIFFEID) && has(self.bootstrapBundle) : !has(self.endpointSPIFFEID) && !has(self.bootstrapBundle)",message="endpointSPIFFEID and bootstrapBundle must be set if and only if the profile is https_spiffe"
+type SPIFFEFederationSource struct {
+	// TrustDomain is the name of the trust domain whose bundle is fetched,
+	// such as "example.org".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	TrustDomain string `json:"trustDomain"`
+
+	// EndpointURL is the HTTPS URL of the bundle endpoint of the trust domain.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://`
+	EndpointURL string `json:"endpointURL"`
+
+	// Profile is the profile of the bundle endpoint, either "https_web" or
+	// "https_spiffe". Defaults to "https_web".
+	// +optional
+	// +kubebuilder:validation:Enum=https_web;https_spiffe
+	Profile SPIFFEBundleEndpointProfile `json:"profile,omitempty"`
+
+	// EndpointSPIFFEID is the SPIFFE ID which the bundle endpoint presents
+	// in its X.509-SVID. Required for the https_spiffe profile.
+	// +optional
+	// +kubebuilder:validation:MaxLength=2048
+	EndpointSPIFFEID string `json:"endpointSPIFFEID,omitempty"`
+
+	// BootstrapBundle is a ConfigMap key in the trust Namespace holding the
+	// PEM X.509 authorities of the trust domain of the endpoint SPIFFE ID,
+	// which the endpoint is authenticated against. Once fetched, the bundle
+	// of the trust domain is also used if the endpoint belongs to it, so that
+	// the endpoint can rotate its authorities. Required for the https_spiffe
+	// profile.
+	// +optional
+	BootstrapBundle *ConfigMapKeyReference `json:"bootstrapBundle,omitempty"`
+
+	// RefreshInterval is how often the bundle is fetched. Defaults to the
+	// refresh hint of the fetched bundle, or 5 minutes if it has none.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// PresentServiceAccountToken, when true, presents the projected
+	// ServiceAccount token which trust-manager was started with to the
+	// endpoint as a bearer token, for endpoints which only serve
+	// authenticated clients. trust-manager must be started with
+	// "--spiffe-federation-token-file".
+	// +optional
+	PresentServiceAccountToken bool `json:"presentServiceAccountToken,omitempty"`
+}
+
+// AWSPrivateCASource is an AWS Private CA whose certificate chain is used as
+// the source data.
+type AWSPrivateCASource struct {
+	// ARN is the Amazon Resource Name of the certificate authority, such as
+	// "arn:aws:acm-pca:us-east-1:111122223333:certificate-authority/11223344-1234-1122-2233-112233445566".
+	// The CA is fetched from the region of the ARN.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:acm-pca:[a-z0-9-]+:[0-9]{12}:certificate-authority/[0-9a-f-]+$`
+	ARN string `json:"arn"`
+
+	// RefreshInterval is how often the certificate chain is fetched.
+	// Defaults to 1 hour.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// GCPCASPoolSource is a Google Cloud Certificate Authority Service CA pool
+// whose CA certificates are used as the source data.
+type GCPCASPoolSource struct {
+	// Pool is the resource name of the CA pool, such as
+	// "projects/my-project/locations/us-central1/caPools/my-pool".
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^projects/[^/]+/locations/[^/]+/caPools/[^/]+$`
+	Pool string `json:"pool"`
+
+	// RefreshInterval is how often the CA certificates are fetched.
+	// Defaults to 1 hour.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// AzureKeyVaultSource is a certificate in Azure Key Vault which is used as
+// the source data.
+type AzureKeyVaultSource struct {
+	// VaultURL is the URL of the key vault, such as
+	// "https://my-vault.vault.azure.net".
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://[^/?#]+/?$`
+	VaultURL string `json:"vaultURL"`
+
+	// CertificateName is the name of the certificate in the key vault.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=127
+	// +kubebuilder:validation:Pattern=`^[0-9a-zA-Z-]+$`
+	CertificateName string `json:"certificateName"`
+
+	// RefreshInterval is how often the certificate is fetched.
+	// Defaults to 1 hour.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// PluginSource is a source whose certificates are provided by a source
+// plugin.
+type PluginSource struct {
+	// Name is the name of the plugin, which serves on the socket
+	// "<name>.sock" in the plugin directory of trust-manager.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Parameters are passed to the plugin to select the certificates of the
+	// source. Their meaning depends on the plugin.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=32
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// RefreshInterval is how often the certificates are fetched.
+	// Defaults to 1 hour.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// ConfigMapKeyReference is a reference to a key of a ConfigMap in the trust
+// Namespace.
+type ConfigMapKeyReference struct {
+	// Name is the name of the ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the entry in the ConfigMap's `data` field.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
+// IssuerReference is a reference to a cert-manager Issuer or ClusterIssuer.
+type IssuerReference struct {
+	// Name is the name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, either "Issuer" or "ClusterIssuer".
+	// Defaults to "Issuer".
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// CertificateSource selects cert-manager Certificates.
+type CertificateSource struct {
+	// Selector is the label selector of the Certificates.
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// BundleTarget is the target resource that the Bundle will sync all source
+// data to.
+// +kubebuilder:validation:XValidation:rule=`!has(self.additionalFormats) || ![has(self.additionalFormats.jks) ? self.additionalFormats.jks.key : "", has(self.additionalFormats.pkcs12) ? self.additionalFormats.pkcs12.key : "", has(self.additionalFormats.spiffe) ? self.additionalFormats.spiffe.key : "", has(self.additionalFormats.certdata) ? self.additionalFormats.certdata.key : ""].exists(k, k != "" && ((has(self.configMap) && k == self.configMap.key) || (has(self.secret) && k == self.secret.key)))`,message="additionalFormats keys must differ from the target keys"
+// +kubebuilder:validation:XValidation:rule="!has(self.additionalFormatsTarget) || has(self.additionalFormats) || (has(self.configMap) && has(self.configMap.additionalFormats)) || (has(self.secret) && has(self.secret.additionalFormats))",message="additionalFormats must be defined when additionalFormatsTarget is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.secret) || !has(self.secret.type) || self.secret.type != 'kubernetes.io/tls' || (!has(self.keyOverrides) && !has(self.additionalFormatsTarget))",message="keyOverrides and additionalFormatsTarget are not supported with kubernetes.io/tls Secret targets"
+type BundleTarget struct {
+	// ConfigMap is the target ConfigMap in Namespaces that all Bundle source
+	// data will be synced to.
+	// +optional
+	ConfigMap *ConfigMapTarget `json:"configMap,omitempty"`
+
+	// Secret is the target Secret that all Bundle source data will be synced to.
+	// Using Secrets as targets is only supported if enabled at trust-manager startup.
+	// By default, trust-manager has no permissions for writing to secrets and can only read secrets in the trust namespace.
+	// +optional
+	Secret *SecretTarget `json:"secret,omitempty"`
+
+	// AdditionalFormats specifies any additional formats to write to the target.
+	// They apply to both the ConfigMap and Secret targets, unless overridden
+	// by the additionalFormats of either.
+	// +optional
+	AdditionalFormats *AdditionalFormats `json:"additionalFormats,omitempty"`
+
+	// AdditionalFormatsTarget, if set, writes the additional formats to a
+	// separate target object of the same kind in each Namespace, instead of
+	// alongside the PEM bundle. This keeps each target under the 1MiB size
+	// limit of ConfigMaps and Secrets when the bundle is large.
+	// +optional
+	AdditionalFormatsTarget *AdditionalFormatsTarget `json:"additionalFormatsTarget,omitempty"`
+
+	// Signature, if set, writes a detached Ed25519 signature of the PEM bundle
+	// alongside it in each target, so that consumers can verify that the
+	// bundle wasn't modified outside of trust-manager. Requires trust-manager
+	// to be started with a signing key.
+	// +optional
+	Signature *BundleSignature `json:"signature,omitempty"`
+
+	// Manifest, if set, writes a JSON manifest alongside the PEM bundle in
+	// each target, which describes each certificate in the bundle: its
+	// SHA-256 fingerprint, subject and expiry, and the sources which
+	// contributed it.
+	// +optional
+	Manifest *BundleManifest `json:"manifest,omitempty"`
+
+	// UsageKeys write the certificates trusted for a usage to further keys of
+	// the target ConfigMap and Secret alongside the PEM bundle, such as
+	// "server-roots.pem" and "client-roots.pem", for proxies which separate
+	// client and server trust.
+	// +optional
+	// +listType=map
+	// +listMapKey=usage
+	// +kubebuilder:validation:MaxItems=2
+	UsageKeys []UsageKey `json:"usageKeys,omitempty"`
+
+	// NamespaceSelector will, if set, only sync the target resource in
+	// Namespaces which match the selector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// KeyOverrides override the key which the PEM bundle is written to in the
+	// target ConfigMap and Secret, in Namespaces matching their selectors. The
+	// first matching override applies, and other Namespaces use the key of
+	// the target. For example, the bundle can be written to "ca.crt" in Istio
+	// Namespaces and to "trust.pem" elsewhere.
+	// +optional
+	// +listType=atomic
+	KeyOverrides []KeyOverride `json:"keyOverrides,omitempty"`
+
+	// DeletionPolicy controls what happens to the targets when the Bundle is
+	// deleted. If unset, targets are garbage collected along with the Bundle.
+	// With `Delete`, trust-manager deletes the targets in all Namespaces before
+	// the Bundle is deleted, and the deletion of the Bundle waits until none
+	// remain. With `Retain`, trust-manager removes its owner reference, labels
+	// and managed fields from each target before the Bundle is deleted,
+	// leaving the data in place.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Ownership controls whether the targets are owned by the Bundle. If
+	// unset or `Owned`, each target has an owner reference to the Bundle. With
+	// `Unowned`, targets are only associated with the Bundle by the
+	// trust.cert-manager.io/bundle label, for tooling which doesn't cope with
+	// owner references to cluster-scoped objects. As they aren't garbage
+	// collected, trust-manager deletes unowned targets itself when the Bundle
+	// is deleted, unless the deletion policy is `Retain`.
+	// +optional
+	Ownership TargetOwnership `json:"ownership,omitempty"`
+
+	// KeyTransition, if set, keeps writing the PEM bundle to the previous key
+	// of the target ConfigMap and Secret for a while after their key changes,
+	// so that consumers can move to the new key without a gap. It must be set
+	// before the key is changed, as trust-manager only records the keys of
+	// targets with a key transition.
+	// +optional
+	KeyTransition *KeyTransition `json:"keyTransition,omitempty"`
+
+	// AdoptExisting, when true, allows trust-manager to take over existing
+	// target ConfigMaps and Secrets which were not created by trust-manager.
+	// When false (the default), such targets are left untouched and the Bundle
+	// reports a sync failure for them.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// RolloutStrategy controls how quickly a changed bundle is written to the
+	// target Namespaces. By default, all Namespaces are updated immediately.
+	// +optional
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// Metadata sets labels and annotations on the target ConfigMaps and
+	// Secrets. Their values may reference variables of the bundle as Go
+	// templates, such as `{{ .BundleHash }}`, so that consumers can be
+	// reloaded when the bundle changes.
+	// +optional
+	Metadata *TargetMetadata `json:"metadata,omitempty"`
+
+	// MergeStrategy controls how the PEM bundle is written to a target key
+	// which other field managers also write certificates to. With `Replace`
+	// (the default), trust-manager overwrites the key with its bundle. With
+	// `Union`, certificates written to the key by other field managers are
+	// kept alongside the bundle. Additional formats only ever hold the
+	// certificates of the Bundle.
+	// +optional
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+}
+
+// TargetMetadata holds the labels and annotations which are set on targets.
+// Values may use the following template variables:
+//   - `{{ .BundleName }}`: the name of the Bundle.
+//   - `{{ .BundleHash }}`: the hash of the bundle, which changes along with its
+//     data.
+//   - `{{ .PackageVersion }}`: the version of the default CA package, if the
+//     Bundle uses it.
+//   - `{{ .SyncTimestamp }}`: the RFC 3339 time at which the target was last
+//     written.
+type TargetMetadata struct {
+	// Labels are set on each target.
+	// +optional
+	// +mapType=granular
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are set on each target.
+	// +optional
+	// +mapType=granular
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// KeyOverride overrides the key which the PEM bundle is written to in the
+// targets in some Namespaces.
+type KeyOverride struct {
+	// NamespaceSelector selects the Namespaces which the override applies to.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// Key is the key which the PEM bundle is written to in the selected
+	// Namespaces.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// ConfigMapTarget is the target ConfigMap that all Bundle source data will be
+// synced to.
+// +kubebuilder:validation:XValidation:rule="!has(self.additionalKeys) || !(self.key in self.additionalKeys)",message="additionalKeys must not contain the target key"
+// +kubebuilder:validation:XValidation:rule=`!has(self.additionalFormats) || ![has(self.additionalFormats.jks) ? self.additionalFormats.jks.key : "", has(self.additionalFormats.pkcs12) ? self.additionalFormats.pkcs12.key : "", has(self.additionalFormats.spiffe) ? self.additionalFormats.spiffe.key : "", has(self.additionalFormats.certdata) ? self.additionalFormats.certdata.key : ""].exists(k, k == self.key)`,message="additionalFormats keys must differ from the target key"
+type ConfigMapTarget struct {
+	KeySelector `json:",inline"`
+
+	// AdditionalFormats, if set, specifies the additional formats to write to
+	// the target ConfigMap in place of the additionalFormats of the target.
+	// Set it to an empty object to write only the PEM bundle to the ConfigMap.
+	// +optional
+	AdditionalFormats *AdditionalFormats `json:"additionalFormats,omitempty"`
+
+	// AdditionalKeys are further keys of the target ConfigMap which the PEM
+	// bundle is written to, for consumers which expect it under another name.
+	// Key overrides and the Union merge strategy only apply to the key.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=253
+	AdditionalKeys []string `json:"additionalKeys,omitempty"`
+}
+
+// SecretTarget is the target Secret that all Bundle source data will be
+// synced to.
+// +kubebuilder:validation:XValidation:rule="!has(self.additionalKeys) || !(self.key in self.additionalKeys)",message="additionalKeys must not contain the target key"
+// +kubebuilder:validation:XValidation:rule=`!has(self.additionalFormats) || ![has(self.additionalFormats.jks) ? self.additionalFormats.jks.key : "", has(self.additionalFormats.pkcs12) ? self.additionalFormats.pkcs12.key : "", has(self.additionalFormats.spiffe) ? self.additionalFormats.spiffe.key : "", has(self.additionalFormats.certdata) ? self.additionalFormats.certdata.key : ""].exists(k, k == self.key)`,message="additionalFormats keys must differ from the target key"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'kubernetes.io/tls' || self.key == 'ca.crt'",message="kubernetes.io/tls Secret targets must write the bundle to the ca.crt key"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'kubernetes.io/tls' || !has(self.additionalKeys) || !self.additionalKeys.exists(k, k == 'tls.crt' || k == 'tls.key')",message="additionalKeys of kubernetes.io/tls Secret targets must not contain tls.crt or tls.key"
+type SecretTarget struct {
+	KeySelector `json:",inline"`
+
+	// AdditionalFormats, if set, specifies the additional formats to write to
+	// the target Secret in place of the additionalFormats of the target.
+	// Set it to an empty object to write only the PEM bundle to the Secret.
+	// +optional
+	AdditionalFormats *AdditionalFormats `json:"additionalFormats,omitempty"`
+
+	// AdditionalKeys are further keys of the target Secret which the PEM
+	// bundle is written to, for consumers which expect it under another name,
+	// such as `root-cert.pem` in the `cacerts` Secret layout of Istio. Key
+	// overrides and the Union merge strategy only apply to the key.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=253
+	AdditionalKeys []string `json:"additionalKeys,omitempty"`
+
+	// Immutable, when true, makes trust-manager create immutable target
+	// Secrets. As immutable Secrets can't be updated, each version of the
+	// bundle is written to a new Secret named after the Bundle with a suffix
+	// derived from the bundle content, and Secrets for older versions are
+	// deleted. The name of the current Secret is published in the
+	// "trust.cert-manager.io/secret-target" annotation of the Bundle.
+	// +optional
+	Immutable bool `json:"immutable,omitempty"`
+
+	// Type is the type of the target Secret. Defaults to Opaque.
+	// Secrets of type kubernetes.io/tls can be referenced by APIs which
+	// only accept TLS Secrets, such as some ingress controllers and Gateway
+	// implementations. They must hold the bundle under the ca.crt key, and
+	// get empty tls.crt and tls.key entries as the API server requires them.
+	// As the type of a Secret can't be changed, existing target Secrets of
+	// another type are recreated.
+	// +optional
+	// +kubebuilder:validation:Enum=Opaque;kubernetes.io/tls
+	Type corev1.SecretType `json:"type,omitempty"`
+}
+
+// BundleSignature configures the detached signature written to the targets of
+// a Bundle.
+type BundleSignature struct {
+	// Key is the key in the target that the base64-encoded signature is
+	// written to. Defaults to the key of the PEM bundle in the target with a
+	// ".sig" suffix, e.g. "trust.pem.sig".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// BundleManifest configures the manifest written to the targets of a Bundle.
+type BundleManifest struct {
+	// Key is the key in the target that the manifest is written to. Defaults
+	// to "trust-manifest.json".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// UsageKey is a key of the targets of a Bundle which the certificates trusted
+// for a usage are written to.
+type UsageKey struct {
+	// Usage is the usage which the certificates written to the key are
+	// trusted for.
+	Usage CertificateUsage `json:"usage"`
+
+	// Key is the key in the target that the PEM-encoded certificates are
+	// written to.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
+// DeletionPolicy is the policy applied to the targets of a Bundle when the
+// Bundle is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the targets along with the Bundle.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyRetain leaves the targets in place when the Bundle is
+	// deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// KeyTransition controls how long the PEM bundle is still written to the
+// previous key of a target after its key changes.
+type KeyTransition struct {
+	// Duration is how long the previous key is written to after the key
+	// changes. If unset, it's written to until the
+	// trust.cert-manager.io/key-transition annotation, which trust-manager
+	// sets on the Bundle when the key changes, is removed. Removing the
+	// annotation ends the transition early either way.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// TargetOwnership controls whether the targets of a Bundle are owned by it.
+// +kubebuilder:validation:Enum=Owned;Unowned
+type TargetOwnership string
+
+const (
+	// TargetOwnershipOwned sets an owner reference to the Bundle on its
+	// targets, so that they are garbage collected with it.
+	TargetOwnershipOwned TargetOwnership = "Owned"
+
+	// TargetOwnershipUnowned associates the targets with the Bundle only by
+	// label.
+	TargetOwnershipUnowned TargetOwnership = "Unowned"
+)
+
+// MergeStrategy is the strategy used to write the PEM bundle to a target key
+// which other field managers also write to.
+// +kubebuilder:validation:Enum=Replace;Union
+type MergeStrategy string
+
+const (
+	// MergeStrategyReplace overwrites the target key with the bundle.
+	MergeStrategyReplace MergeStrategy = "Replace"
+
+	// MergeStrategyUnion writes the union of the bundle and the certificates
+	// written to the target key by other field managers.
+	MergeStrategyUnion MergeStrategy = "Union"
+)
+
+// RolloutStrategyType is the type of rollout strategy of a target.
+// +kubebuilder:validation:Enum=Immediate;Progressive
+type RolloutStrategyType string
+
+const (
+	// RolloutStrategyImmediate writes a changed bundle to all target
+	// Namespaces at once.
+	RolloutStrategyImmediate RolloutStrategyType = "Immediate"
+
+	// RolloutStrategyProgressive writes a changed bundle to a number of target
+	// Namespaces each minute, so that a bad change can be caught before it
+	// reaches every Namespace.
+	RolloutStrategyProgressive RolloutStrategyType = "Progressive"
+)
+
+// RolloutStrategy controls how a changed bundle is written to the target
+// Namespaces.
+// +kubebuilder:validation:XValidation:rule="(has(self.type) && self.type == 'Progressive') == has(self.progressive)",message="progressive must be set if and only if the type is Progressive"
+type RolloutStrategy struct {
+	// Type is the type of rollout, either `Immediate` (the default) or
+	// `Progressive`.
+	// +optional
+	Type RolloutStrategyType `json:"type,omitempty"`
+
+	// Progressive configures the Progressive rollout strategy. It must be set
+	// if the type is `Progressive`.
+	// +optional
+	Progressive *ProgressiveRollout `json:"progressive,omitempty"`
+
+	// Canary, if set, writes a changed bundle to the canary Namespaces first.
+	// The bundle is only rolled out to the other Namespaces, following the
+	// type of the strategy, once it has soaked in the canary Namespaces or
+	// the rollout has been approved.
+	// +optional
+	Canary *CanaryRollout `json:"canary,omitempty"`
+}
+
+// CanaryRollout selects the Namespaces which a changed bundle is written to
+// first, and when it is promoted to the other Namespaces.
+// +kubebuilder:validation:XValidation:rule="has(self.namespaceSelector) || has(self.namespaces)",message="one of namespaceSelector or namespaces must be set"
+type CanaryRollout struct {
+	// NamespaceSelector selects the canary Namespaces by their labels.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Namespaces lists canary Namespaces by name, in addition to those
+	// selected by the namespaceSelector.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// SoakDuration is how long a changed bundle stays in the canary
+	// Namespaces before it is promoted to the others. If unset, the bundle
+	// is only promoted by setting the "trust.cert-manager.io/approve-rollout"
+	// annotation of the Bundle to the bundle hash in status.rollout, which
+	// also promotes it before the soak duration has passed.
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+}
+
+// ProgressiveRollout configures the Progressive rollout strategy.
+type ProgressiveRollout struct {
+	// NamespacesPerMinute is the number of Namespaces whose targets are
+	// updated each minute after the bundle changes. Namespaces whose turn
+	// hasn't come yet keep the previous bundle.
+	// +kubebuilder:validation:Minimum=1
+	NamespacesPerMinute int32 `json:"namespacesPerMinute"`
+
+	// OrderLabel is the key of a Namespace label which orders the rollout.
+	// Namespaces are updated in ascending order of the label's value, and
+	// those without the label last. Namespaces with the same value are
+	// updated in order of their names.
+	// +optional
+	OrderLabel string `json:"orderLabel,omitempty"`
+}
+
+// AdditionalFormatsTarget is the target object that additional formats are
+// written to.
+type AdditionalFormatsTarget struct {
+	// Name is the name of the target object in each Namespace. It must differ
+	// from the name of the Bundle.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+// AdditionalFormats specifies any additional formats to write to the target
+// +kubebuilder:validation:XValidation:rule="(!has(self.jks) || !has(self.pkcs12) || self.jks.key != self.pkcs12.key) && (!has(self.jks) || !has(self.spiffe) || self.jks.key != self.spiffe.key) && (!has(self.pkcs12) || !has(self.spiffe) || self.pkcs12.key != self.spiffe.key) && (!has(self.jks) || !has(self.certdata) || self.jks.key != self.certdata.key) && (!has(self.pkcs12) || !has(self.certdata) || self.pkcs12.key != self.certdata.key) && (!has(self.spiffe) || !has(self.certdata) || self.spiffe.key != self.certdata.key)",message="additional formats must be written to distinct keys"
+type AdditionalFormats struct {
+	// JKS requests a JKS-formatted binary trust bundle to be written to the target.
+	// The bundle has "changeit" as the default password.
+	// For more information refer to this link https://cert-manager.io/docs/faq/#keystore-passwords
+	// +optional
+	JKS *JKS `json:"jks,omitempty"`
+	// PKCS12 requests a PKCS12-formatted binary trust bundle to be written to the target.
+	// The bundle is by default created without a password.
+	// +optional
+	PKCS12 *PKCS12 `json:"pkcs12,omitempty"`
+	// SPIFFE requests a SPIFFE trust bundle document to be written to the target.
+	// The document is a JWK Set with one x5c entry per CA certificate, keyed by
+	// the configured trust domain.
+	// CA certificates whose public key can't be represented as a JWK, such as
+	// ECDSA keys on the P-224 curve, are left out of the document.
+	// For more information refer to this link https://github.com/spiffe/spiffe/blob/main/standards/SPIFFE_Trust_Domain_and_Bundle.md
+	// +optional
+	SPIFFE *SPIFFE `json:"spiffe,omitempty"`
+	// Certdata requests a listing of the trust bundle in the certdata.txt format
+	// of Mozilla's NSS to be written to the target, from which NSS databases and
+	// OS CA packages can be generated. Every certificate is trusted as a CA for
+	// server authentication and email protection.
+	// For more information refer to this link https://firefox-source-docs.mozilla.org/security/nss/runbooks/rootstore.html
+	// +optional
+	Certdata *Certdata `json:"certdata,omitempty"`
+}
+
+// JKS specifies additional target JKS files
+// +structType=atomic
+type JKS struct {
+	KeySelector `json:",inline"`
+
+	// Password for JKS trust store
+	//+optional
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=128
+	//+kubebuilder:default=changeit
+	Password *string `json:"password"`
+}
+
+// PKCS12 specifies additional target PKCS#12 files
+// +structType=atomic
+type PKCS12 struct {
+	KeySelector `json:",inline"`
+
+	// Password for PKCS12 trust store
+	//+optional
+	//+kubebuilder:validation:MaxLength=128
+	//+kubebuilder:default=""
+	Password *string `json:"password,omitempty"`
+
+	// Deterministic, when true, derives the salts of a password-protected
+	// PKCS12 trust store from its certificates and password rather than
+	// generating them randomly, so that the same certificates always encode
+	// to the same bytes. Without it, every encoding of the trust store
+	// differs, so targets change whenever they're rewritten even if their
+	// certificates don't, e.g. after a restart of trust-manager.
+	// The trust store only holds public certificates and its password only
+	// protects its integrity, but note that two trust stores with the same
+	// certificates and password can be recognised as such, and that the
+	// salts no longer make precomputed attacks on the password harder.
+	// Password-less trust stores are always deterministic.
+	// +optional
+	Deterministic bool `json:"deterministic,omitempty"`
+}
+
+// SPIFFE specifies additional target SPIFFE trust bundle files
+// +structType=atomic
+type SPIFFE struct {
+	KeySelector `json:",inline"`
+
+	// TrustDomain is the SPIFFE trust domain which the bundle is published for,
+	// e.g. "example.org".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	TrustDomain string `json:"trustDomain"`
+}
+
+// Certdata specifies additional target NSS certdata.txt files
+// +structType=atomic
+type Certdata struct {
+	KeySelector `json:",inline"`
+}
+
+// SourceObjectKeySelector is a reference to a source object and its `data` key(s)
+// in the trust Namespace, or to the objects selected in other Namespaces.
+// +structType=atomic
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.selector)",message="exactly one of name or selector must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.namespaceSelector) || has(self.selector)",message="namespaceSelector may only be set along with selector"
+// +kubebuilder:validation:XValidation:rule="has(self.key) != (has(self.includeAllKeys) && self.includeAllKeys)",message="exactly one of key or includeAllKeys must be set"
+type SourceObjectKeySelector struct {
+	// Name is the name of the source object in the trust Namespace.
+	// This field must be left empty when `selector` is set
+	//+optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// Selector is the label selector to use to fetch a list of objects. Must not be set
+	// when `Name` is set.
+	//+optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// NamespaceSelector selects the Namespaces in which objects matching
+	// `selector` are fetched, instead of the trust Namespace. This lets teams
+	// contribute CAs from their own Namespaces, limited to the Namespaces
+	// selected. Requires trust-manager to be started with
+	// "--source-namespace-selectors-enabled". May only be set along with
+	// `selector`.
+	//+optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Key of the entry in the object's `data` field to be used.
+	// The key may be a glob pattern, such as "*.crt" or "*", in which case
+	// every matching entry which contains PEM-encoded certificates is used.
+	//+optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
+
+	// IncludeAllKeys is a flag to include all keys in the object's `data` field to be used. False by default.
+	// This field must not be true when `Key` is set.
+	//+optional
+	IncludeAllKeys bool `json:"includeAllKeys,omitempty"`
+
+	// Optional, when true, allows the source object (or the referenced key) to
+	// be missing. Missing optional sources are skipped and listed in the
+	// Bundle's `SourcesSkipped` condition, rather than failing the sync.
+	//+optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// KeySelector is a reference to a key for some map data object.
+type KeySelector struct {
+	// Key is the key of the entry in the object's `data` field to be used.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
+// BundleStatus defines the observed state of the Bundle.
+type BundleStatus struct {
+	// List of status conditions to indicate the status of the Bundle.
+	// Known condition types are `Bundle`.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []BundleCondition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the .metadata.generation of the Bundle which
+	// trust-manager last reconciled. The status is out of date with respect
+	// to the current spec of the Bundle while it's lower than the generation.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DefaultCAPackageVersion, if set and non-empty, indicates the version information
+	// which was retrieved when the set of default CAs was requested in the bundle
+	// source. This should only be set if useDefaultCAs was set to "true" on a source,
+	// and will be the same for the same version of a bundle with identical certificates.
+	// +optional
+	DefaultCAPackageVersion *string `json:"defaultCAVersion,omitempty"`
+
+	// TargetCount is the number of target ConfigMaps and Secrets which the
+	// Bundle was last synced to.
+	// +optional
+	TargetCount int32 `json:"targetCount,omitempty"`
+
+	// SyncedTargetCount is the number of targets which were successfully
+	// synced in the last sync of the Bundle.
+	// +optional
+	SyncedTargetCount int32 `json:"syncedTargetCount,omitempty"`
+
+	// LastSyncTime is the time at which the Bundle was last successfully
+	// synced to all of its targets, following a change.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// FilteredCertificates summarises the certificates which were removed
+	// from the sources of the Bundle when it was last built, either as
+	// duplicates or by a filter. It is unset if no certificates were removed.
+	// +optional
+	FilteredCertificates *FilteredCertificates `json:"filteredCertificates,omitempty"`
+
+	// OptedOutNamespaces summarises the Namespaces selected by the targets of
+	// the Bundle which opted out of them with the
+	// `trust.cert-manager.io/exclude: "true"` annotation. It is unset if no
+	// selected Namespace opted out.
+	// +optional
+	OptedOutNamespaces *OptedOutNamespaces `json:"optedOutNamespaces,omitempty"`
+
+	// Rollout reports the progress of the rollout of the bundle to targets
+	// with the Progressive rollout strategy or canary Namespaces. It is unset
+	// if no target uses them.
+	// +optional
+	Rollout *BundleRolloutStatus `json:"rollout,omitempty"`
+
+	// History lists the snapshots kept of the Bundle, newest first, for
+	// Bundles with a history.
+	// +optional
+	// +listType=atomic
+	History []BundleSnapshot `json:"history,omitempty"`
+
+	// LastContentChange describes how the certificates of the bundle changed
+	// when it last changed, as seen by the running trust-manager. It is unset
+	// until the bundle changes.
+	// +optional
+	LastContentChange *BundleContentChange `json:"lastContentChange,omitempty"`
+
+	// RemoteClusters reports the sync of the Bundle to each remote cluster
+	// it's propagated to, if propagation to remote clusters is enabled in
+	// trust-manager.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	RemoteClusters []RemoteClusterStatus `json:"remoteClusters,omitempty"`
+
+	// KeyTransitions reports the key of each kind of target with a key
+	// transition, along with the previous key which the PEM bundle is still
+	// written to while a transition is in progress.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	KeyTransitions []KeyTransitionStatus `json:"keyTransitions,omitempty"`
+}
+
+// BundleSnapshot is a bundle which was published to the targets of a Bundle.
+type BundleSnapshot struct {
+	// Hash is the SHA-256 hash of the PEM bundle, which identifies the
+	// snapshot to roll back to.
+	Hash string `json:"hash"`
+
+	// PublishTime is the time at which the bundle was last published.
+	PublishTime metav1.Time `json:"publishTime"`
+}
+
+// BundleRolloutPhase is the phase of the rollout of a bundle.
+type BundleRolloutPhase string
+
+const (
+	// BundleRolloutPhaseCanary means the bundle is only written to the canary
+	// Namespaces, and waits to be promoted.
+	BundleRolloutPhaseCanary BundleRolloutPhase = "Canary"
+
+	// BundleRolloutPhaseProgressing means the bundle is being rolled out to
+	// the target Namespaces.
+	BundleRolloutPhaseProgressing BundleRolloutPhase = "Progressing"
+
+	// BundleRolloutPhaseComplete means the bundle has been rolled out to all
+	// target Namespaces.
+	BundleRolloutPhaseComplete BundleRolloutPhase = "Complete"
+)
+
+// BundleRolloutStatus reports the progress of a progressive or canary
+// rollout of a bundle.
+type BundleRolloutStatus struct {
+	// BundleHash is the hash of the bundle being rolled out. The rollout
+	// starts over when it changes.
+	BundleHash string `json:"bundleHash"`
+
+	// StartTime is the time at which the rollout of the bundle started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Phase is the phase of the rollout: `Canary` while the bundle is only
+	// written to the canary Namespaces, `Progressing` while it is rolled out
+	// to the other Namespaces, and `Complete` once it has reached them all.
+	Phase BundleRolloutPhase `json:"phase"`
+
+	// PromotionTime is the time at which the bundle was promoted from the
+	// canary Namespaces to the others.
+	// +optional
+	PromotionTime *metav1.Time `json:"promotionTime,omitempty"`
+
+	// UpdatedNamespaces is the number of target Namespaces which the bundle
+	// has been rolled out to, summed over the targets with the Progressive
+	// rollout strategy or canary Namespaces.
+	UpdatedNamespaces int32 `json:"updatedNamespaces"`
+
+	// TotalNamespaces is the number of target Namespaces which the bundle is
+	// rolled out to, summed over the targets with the Progressive rollout
+	// strategy or canary Namespaces.
+	TotalNamespaces int32 `json:"totalNamespaces"`
+}
+
+// FilteredCertificates summarises the certificates removed from the sources
+// of a Bundle.
+type FilteredCertificates struct {
+	// Count is the number of certificates which were removed.
+	Count int32 `json:"count"`
+
+	// Samples lists up to 5 of the certificates which were removed.
+	// +listType=atomic
+	// +optional
+	Samples []FilteredCertificate `json:"samples,omitempty"`
+}
+
+// OptedOutNamespaces summarises the Namespaces which opted out of the targets
+// of a Bundle.
+type OptedOutNamespaces struct {
+	// Count is the number of Namespaces which opted out.
+	Count int32 `json:"count"`
+
+	// Names lists the names of up to 10 of the Namespaces which opted out,
+	// in alphabetical order.
+	// +listType=atomic
+	// +optional
+	Names []string `json:"names,omitempty"`
+}
+
+// FilteredCertificate describes a certificate removed from the sources of a
+// Bundle.
+type FilteredCertificate struct {
+	// Subject is the subject of the certificate.
+	Subject string `json:"subject"`
+
+	// Fingerprint is the hex-encoded SHA-256 fingerprint of the certificate.
+	Fingerprint string `json:"fingerprint"`
+
+	// Reason is why the certificate was removed, one of `Duplicate`,
+	// `Expired` or `NotCA`.
+	Reason string `json:"reason"`
+}
+
+// BundleContentChange describes the certificates which were added to and
+// removed from a bundle when it changed.
+type BundleContentChange struct {
+	// Time is the time at which the changed bundle was synced to the targets.
+	Time metav1.Time `json:"time"`
+
+	// Hash is the SHA-256 hash of the changed PEM bundle.
+	Hash string `json:"hash"`
+
+	// AddedCount is the number of certificates which were added.
+	AddedCount int32 `json:"addedCount"`
+
+	// Added lists up to 10 of the certificates which were added.
+	// +listType=atomic
+	// +optional
+	Added []BundleCertificate `json:"added,omitempty"`
+
+	// RemovedCount is the number of certificates which were removed.
+	RemovedCount int32 `json:"removedCount"`
+
+	// Removed lists up to 10 of the certificates which were removed.
+	// +listType=atomic
+	// +optional
+	Removed []BundleCertificate `json:"removed,omitempty"`
+}
+
+// BundleCertificate identifies a certificate of a bundle.
+type BundleCertificate struct {
+	// Subject is the subject of the certificate.
+	Subject string `json:"subject"`
+
+	// Fingerprint is the hex-encoded SHA-256 fingerprint of the certificate.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// KeyTransitionStatus reports the key transition of the targets of a kind.
+type KeyTransitionStatus struct {
+	// Kind is the kind of the targets, either ConfigMap or Secret.
+	Kind string `json:"kind"`
+
+	// Key is the key which the PEM bundle is written to.
+	Key string `json:"key"`
+
+	// PreviousKey is the key which the PEM bundle was written to before the
+	// key last changed. It's set while the transition is in progress.
+	// +optional
+	PreviousKey string `json:"previousKey,omitempty"`
+
+	// StartTime is the time at which the transition started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// RemoteClusterStatus reports the sync of a Bundle to a remote cluster.
+type RemoteClusterStatus struct {
+	// Name is the name of the Secret in the trust Namespace which registers
+	// the remote cluster.
+	Name string `json:"name"`
+
+	// Synced is true if the Bundle was synced to all of its targets in the
+	// remote cluster.
+	Synced bool `json:"synced"`
+
+	// TargetCount is the number of targets which the Bundle was last synced
+	// to in the remote cluster.
+	// +optional
+	TargetCount int32 `json:"targetCount,omitempty"`
+
+	// Message describes why the Bundle failed to sync to the remote cluster,
+	// if it did.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastSyncTime is the time at which the Bundle was last successfully
+	// synced to the remote cluster, following a change.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// BundleCondition contains condition information for a Bundle.
+type BundleCondition struct {
+	// Type of the condition, known values are (`Synced`, `Paused`, `SourcesSkipped`).
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+	// +kubebuilder:validation:MaxLength=316
+	Type string `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Reason is a brief machine-readable explanation for the condition's last
+	// transition.
+	// The value should be a CamelCase string.
+	// This field may not be empty.
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`
+	Reason string `json:"reason"`
+
+	// Message is a human-readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32768
+	Message string `json:"message,omitempty"`
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	// For instance, if .metadata.generation is currently 12, but the
+	// .status.condition[x].observedGeneration is 9, the condition is out of date
+	// with respect to the current state of the Bundle.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+const (
+	// DefaultJKSPassword is the default password that Java uses; it's a Java convention to use this exact password.
+	// Since we're not storing anything secret in the JKS files we generate, this password is not a meaningful security measure
+	// but seems often to be expected by applications consuming JKS files
+	DefaultJKSPassword = "changeit"
+	// DefaultPKCS12Password is the empty string, that will create a password-less PKCS12 truststore.
+	// Password-less PKCS is the new default Java truststore from Java 18.
+	// By password-less, it means the certificates are not encrypted, and it contains no MacData for integrity check.
+	DefaultPKCS12Password = ""
+
+	// BundleConditionSynced indicates that the Bundle has successfully synced
+	// all source bundle data to the Bundle target in all Namespaces.
+	BundleConditionSynced string = "Synced"
+
+	// BundleConditionReady mirrors the Synced condition, under the condition
+	// type which tools such as Argo CD and Flux assess the health of resources
+	// by.
+	BundleConditionReady string = "Ready"
+
+	// BundleConditionPaused indicates that the Bundle is paused, and that its
+	// targets are not being synced.
+	BundleConditionPaused string = "Paused"
+
+	// BundleConditionSourcesSkipped indicates that one or more optional
+	// sources of the Bundle were not found, and were skipped.
+	BundleConditionSourcesSkipped string = "SourcesSkipped"
+
+	// BundleConditionConflict indicates that other Bundles write to some of
+	// the targets of the Bundle. Each such target is synced by only one of
+	// the Bundles.
+	BundleConditionConflict string = "Conflict"
+
+	// BundleConditionInconsistentCertificates indicates that the bundle holds
+	// certificates which some clients, such as Java clients of JKS
+	// truststores, can't tell apart: cross-signed duplicates of the same CA,
+	// or CAs with the same subject and key identifier but different keys.
+	BundleConditionInconsistentCertificates string = "InconsistentCertificates"
+
+	// BundleConditionDefaultPackageStale indicates that the Bundle uses the
+	// default CAs, and that the default CA package was built longer ago than
+	// the "--default-package-max-age" setting of the trust-manager controller.
+	BundleConditionDefaultPackageStale string = "DefaultPackageStale"
+
+	// BundleConditionCloudSourcesFailing indicates that the API of the cloud
+	// provider of one or more sources of the Bundle failed, such as the AWS
+	// Private CA API. The certificates fetched last from such sources are
+	// used until the API succeeds again.
+	BundleConditionCloudSourcesFailing string = "CloudSourcesFailing"
+
+	// BundleConditionInvalidCertificatesSkipped indicates that malformed PEM
+	// blocks in the sources of the Bundle were skipped, as its invalid
+	// certificate policy is Skip. The blocks are listed in its message.
+	BundleConditionInvalidCertificatesSkipped string = "InvalidCertificatesSkipped"
+
+	// BundleConditionResynced indicates that all of the targets of the Bundle
+	// were applied again, as requested by the value of the
+	// "trust.cert-manager.io/resync" annotation in its message.
+	BundleConditionResynced string = "Resynced"
+
+	// BundleConditionBlocked indicates that the Bundle isn't synced because
+	// its sources hold data which must never be distributed, such as private
+	// keys. Its targets keep the bundle they were last synced with.
+	BundleConditionBlocked string = "Blocked"
+
+	// BundleConditionDeleting indicates that the Bundle is being deleted, and
+	// that trust-manager is deleting its targets as its deletion policy is
+	// Delete. The targets which remain are counted in its message.
+	BundleConditionDeleting string = "Deleting"
+)
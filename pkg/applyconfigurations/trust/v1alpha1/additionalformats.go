@@ -18,14 +18,16 @@ limitations under the License.
 
 package v1alpha1
 
-// AdditionalFormatsApplyConfiguration represents an declarative configuration of the AdditionalFormats type for use
+// AdditionalFormatsApplyConfiguration represents a declarative configuration of the AdditionalFormats type for use
 // with apply.
 type AdditionalFormatsApplyConfiguration struct {
-	JKS    *JKSApplyConfiguration    `json:"jks,omitempty"`
-	PKCS12 *PKCS12ApplyConfiguration `json:"pkcs12,omitempty"`
+	JKS      *JKSApplyConfiguration      `json:"jks,omitempty"`
+	PKCS12   *PKCS12ApplyConfiguration   `json:"pkcs12,omitempty"`
+	SPIFFE   *SPIFFEApplyConfiguration   `json:"spiffe,omitempty"`
+	Certdata *CertdataApplyConfiguration `json:"certdata,omitempty"`
 }
 
-// AdditionalFormatsApplyConfiguration constructs an declarative configuration of the AdditionalFormats type for use with
+// AdditionalFormatsApplyConfiguration constructs a declarative configuration of the AdditionalFormats type for use with
 // apply.
 func AdditionalFormats() *AdditionalFormatsApplyConfiguration {
 	return &AdditionalFormatsApplyConfiguration{}
@@ -46,3 +48,19 @@ func (b *AdditionalFormatsApplyConfiguration) WithPKCS12(value *PKCS12ApplyConfi
 	b.PKCS12 = value
 	return b
 }
+
+// WithSPIFFE sets the SPIFFE field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SPIFFE field is set to the value of the last call.
+func (b *AdditionalFormatsApplyConfiguration) WithSPIFFE(value *SPIFFEApplyConfiguration) *AdditionalFormatsApplyConfiguration {
+	b.SPIFFE = value
+	return b
+}
+
+// WithCertdata sets the Certdata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certdata field is set to the value of the last call.
+func (b *AdditionalFormatsApplyConfiguration) WithCertdata(value *CertdataApplyConfiguration) *AdditionalFormatsApplyConfiguration {
+	b.Certdata = value
+	return b
+}
@@ -24,7 +24,7 @@ import (
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// BundleApplyConfiguration represents an declarative configuration of the Bundle type for use
+// BundleApplyConfiguration represents a declarative configuration of the Bundle type for use
 // with apply.
 type BundleApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
@@ -33,7 +33,7 @@ type BundleApplyConfiguration struct {
 	Status                           *BundleStatusApplyConfiguration `json:"status,omitempty"`
 }
 
-// Bundle constructs an declarative configuration of the Bundle type for use with
+// Bundle constructs a declarative configuration of the Bundle type for use with
 // apply.
 func Bundle(name string) *BundleApplyConfiguration {
 	b := &BundleApplyConfiguration{}
@@ -216,3 +216,9 @@ func (b *BundleApplyConfiguration) WithStatus(value *BundleStatusApplyConfigurat
 	b.Status = value
 	return b
 }
+
+// GetName retrieves the value of the Name field in the declarative configuration.
+func (b *BundleApplyConfiguration) GetName() *string {
+	b.ensureObjectMetaApplyConfigurationExists()
+	return b.Name
+}
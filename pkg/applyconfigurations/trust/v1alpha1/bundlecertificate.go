@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// BundleCertificateApplyConfiguration represents a declarative configuration of the BundleCertificate type for use
+// with apply.
+type BundleCertificateApplyConfiguration struct {
+	Subject     *string `json:"subject,omitempty"`
+	Fingerprint *string `json:"fingerprint,omitempty"`
+}
+
+// BundleCertificateApplyConfiguration constructs a declarative configuration of the BundleCertificate type for use with
+// apply.
+func BundleCertificate() *BundleCertificateApplyConfiguration {
+	return &BundleCertificateApplyConfiguration{}
+}
+
+// WithSubject sets the Subject field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Subject field is set to the value of the last call.
+func (b *BundleCertificateApplyConfiguration) WithSubject(value string) *BundleCertificateApplyConfiguration {
+	b.Subject = &value
+	return b
+}
+
+// WithFingerprint sets the Fingerprint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Fingerprint field is set to the value of the last call.
+func (b *BundleCertificateApplyConfiguration) WithFingerprint(value string) *BundleCertificateApplyConfiguration {
+	b.Fingerprint = &value
+	return b
+}
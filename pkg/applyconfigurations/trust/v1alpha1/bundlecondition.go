@@ -22,7 +22,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// BundleConditionApplyConfiguration represents an declarative configuration of the BundleCondition type for use
+// BundleConditionApplyConfiguration represents a declarative configuration of the BundleCondition type for use
 // with apply.
 type BundleConditionApplyConfiguration struct {
 	Type               *string             `json:"type,omitempty"`
@@ -33,7 +33,7 @@ type BundleConditionApplyConfiguration struct {
 	ObservedGeneration *int64              `json:"observedGeneration,omitempty"`
 }
 
-// BundleConditionApplyConfiguration constructs an declarative configuration of the BundleCondition type for use with
+// BundleConditionApplyConfiguration constructs a declarative configuration of the BundleCondition type for use with
 // apply.
 func BundleCondition() *BundleConditionApplyConfiguration {
 	return &BundleConditionApplyConfiguration{}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BundleContentChangeApplyConfiguration represents a declarative configuration of the BundleContentChange type for use
+// with apply.
+type BundleContentChangeApplyConfiguration struct {
+	Time         *v1.Time                              `json:"time,omitempty"`
+	Hash         *string                               `json:"hash,omitempty"`
+	AddedCount   *int32                                `json:"addedCount,omitempty"`
+	Added        []BundleCertificateApplyConfiguration `json:"added,omitempty"`
+	RemovedCount *int32                                `json:"removedCount,omitempty"`
+	Removed      []BundleCertificateApplyConfiguration `json:"removed,omitempty"`
+}
+
+// BundleContentChangeApplyConfiguration constructs a declarative configuration of the BundleContentChange type for use with
+// apply.
+func BundleContentChange() *BundleContentChangeApplyConfiguration {
+	return &BundleContentChangeApplyConfiguration{}
+}
+
+// WithTime sets the Time field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Time field is set to the value of the last call.
+func (b *BundleContentChangeApplyConfiguration) WithTime(value v1.Time) *BundleContentChangeApplyConfiguration {
+	b.Time = &value
+	return b
+}
+
+// WithHash sets the Hash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hash field is set to the value of the last call.
+func (b *BundleContentChangeApplyConfiguration) WithHash(value string) *BundleContentChangeApplyConfiguration {
+	b.Hash = &value
+	return b
+}
+
+// WithAddedCount sets the AddedCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AddedCount field is set to the value of the last call.
+func (b *BundleContentChangeApplyConfiguration) WithAddedCount(value int32) *BundleContentChangeApplyConfiguration {
+	b.AddedCount = &value
+	return b
+}
+
+// WithAdded adds the given value to the Added field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Added field.
+func (b *BundleContentChangeApplyConfiguration) WithAdded(values ...*BundleCertificateApplyConfiguration) *BundleContentChangeApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdded")
+		}
+		b.Added = append(b.Added, *values[i])
+	}
+	return b
+}
+
+// WithRemovedCount sets the RemovedCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RemovedCount field is set to the value of the last call.
+func (b *BundleContentChangeApplyConfiguration) WithRemovedCount(value int32) *BundleContentChangeApplyConfiguration {
+	b.RemovedCount = &value
+	return b
+}
+
+// WithRemoved adds the given value to the Removed field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Removed field.
+func (b *BundleContentChangeApplyConfiguration) WithRemoved(values ...*BundleCertificateApplyConfiguration) *BundleContentChangeApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRemoved")
+		}
+		b.Removed = append(b.Removed, *values[i])
+	}
+	return b
+}
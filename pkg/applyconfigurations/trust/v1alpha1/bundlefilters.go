@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// BundleFiltersApplyConfiguration represents a declarative configuration of the BundleFilters type for use
+// with apply.
+type BundleFiltersApplyConfiguration struct {
+	Expired       *v1alpha1.ExpiredCertificatePolicy `json:"expired,omitempty"`
+	Deduplication *v1alpha1.DeduplicationStrategy    `json:"deduplication,omitempty"`
+	Invalid       *v1alpha1.InvalidCertificatePolicy `json:"invalid,omitempty"`
+}
+
+// BundleFiltersApplyConfiguration constructs a declarative configuration of the BundleFilters type for use with
+// apply.
+func BundleFilters() *BundleFiltersApplyConfiguration {
+	return &BundleFiltersApplyConfiguration{}
+}
+
+// WithExpired sets the Expired field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expired field is set to the value of the last call.
+func (b *BundleFiltersApplyConfiguration) WithExpired(value v1alpha1.ExpiredCertificatePolicy) *BundleFiltersApplyConfiguration {
+	b.Expired = &value
+	return b
+}
+
+// WithDeduplication sets the Deduplication field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deduplication field is set to the value of the last call.
+func (b *BundleFiltersApplyConfiguration) WithDeduplication(value v1alpha1.DeduplicationStrategy) *BundleFiltersApplyConfiguration {
+	b.Deduplication = &value
+	return b
+}
+
+// WithInvalid sets the Invalid field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Invalid field is set to the value of the last call.
+func (b *BundleFiltersApplyConfiguration) WithInvalid(value v1alpha1.InvalidCertificatePolicy) *BundleFiltersApplyConfiguration {
+	b.Invalid = &value
+	return b
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BundleRolloutStatusApplyConfiguration represents a declarative configuration of the BundleRolloutStatus type for use
+// with apply.
+type BundleRolloutStatusApplyConfiguration struct {
+	BundleHash        *string                      `json:"bundleHash,omitempty"`
+	StartTime         *v1.Time                     `json:"startTime,omitempty"`
+	Phase             *v1alpha1.BundleRolloutPhase `json:"phase,omitempty"`
+	PromotionTime     *v1.Time                     `json:"promotionTime,omitempty"`
+	UpdatedNamespaces *int32                       `json:"updatedNamespaces,omitempty"`
+	TotalNamespaces   *int32                       `json:"totalNamespaces,omitempty"`
+}
+
+// BundleRolloutStatusApplyConfiguration constructs a declarative configuration of the BundleRolloutStatus type for use with
+// apply.
+func BundleRolloutStatus() *BundleRolloutStatusApplyConfiguration {
+	return &BundleRolloutStatusApplyConfiguration{}
+}
+
+// WithBundleHash sets the BundleHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BundleHash field is set to the value of the last call.
+func (b *BundleRolloutStatusApplyConfiguration) WithBundleHash(value string) *BundleRolloutStatusApplyConfiguration {
+	b.BundleHash = &value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *BundleRolloutStatusApplyConfiguration) WithStartTime(value v1.Time) *BundleRolloutStatusApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *BundleRolloutStatusApplyConfiguration) WithPhase(value v1alpha1.BundleRolloutPhase) *BundleRolloutStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithPromotionTime sets the PromotionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PromotionTime field is set to the value of the last call.
+func (b *BundleRolloutStatusApplyConfiguration) WithPromotionTime(value v1.Time) *BundleRolloutStatusApplyConfiguration {
+	b.PromotionTime = &value
+	return b
+}
+
+// WithUpdatedNamespaces sets the UpdatedNamespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdatedNamespaces field is set to the value of the last call.
+func (b *BundleRolloutStatusApplyConfiguration) WithUpdatedNamespaces(value int32) *BundleRolloutStatusApplyConfiguration {
+	b.UpdatedNamespaces = &value
+	return b
+}
+
+// WithTotalNamespaces sets the TotalNamespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TotalNamespaces field is set to the value of the last call.
+func (b *BundleRolloutStatusApplyConfiguration) WithTotalNamespaces(value int32) *BundleRolloutStatusApplyConfiguration {
+	b.TotalNamespaces = &value
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BundleSnapshotApplyConfiguration represents a declarative configuration of the BundleSnapshot type for use
+// with apply.
+type BundleSnapshotApplyConfiguration struct {
+	Hash        *string  `json:"hash,omitempty"`
+	PublishTime *v1.Time `json:"publishTime,omitempty"`
+}
+
+// BundleSnapshotApplyConfiguration constructs a declarative configuration of the BundleSnapshot type for use with
+// apply.
+func BundleSnapshot() *BundleSnapshotApplyConfiguration {
+	return &BundleSnapshotApplyConfiguration{}
+}
+
+// WithHash sets the Hash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hash field is set to the value of the last call.
+func (b *BundleSnapshotApplyConfiguration) WithHash(value string) *BundleSnapshotApplyConfiguration {
+	b.Hash = &value
+	return b
+}
+
+// WithPublishTime sets the PublishTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PublishTime field is set to the value of the last call.
+func (b *BundleSnapshotApplyConfiguration) WithPublishTime(value v1.Time) *BundleSnapshotApplyConfiguration {
+	b.PublishTime = &value
+	return b
+}
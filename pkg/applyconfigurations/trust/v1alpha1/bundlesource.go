@@ -18,16 +18,29 @@ limitations under the License.
 
 package v1alpha1
 
-// BundleSourceApplyConfiguration represents an declarative configuration of the BundleSource type for use
+import (
+	trustv1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// BundleSourceApplyConfiguration represents a declarative configuration of the BundleSource type for use
 // with apply.
 type BundleSourceApplyConfiguration struct {
-	ConfigMap     *SourceObjectKeySelectorApplyConfiguration `json:"configMap,omitempty"`
-	Secret        *SourceObjectKeySelectorApplyConfiguration `json:"secret,omitempty"`
-	InLine        *string                                    `json:"inLine,omitempty"`
-	UseDefaultCAs *bool                                      `json:"useDefaultCAs,omitempty"`
+	ConfigMap        *SourceObjectKeySelectorApplyConfiguration `json:"configMap,omitempty"`
+	Secret           *SourceObjectKeySelectorApplyConfiguration `json:"secret,omitempty"`
+	InLine           *string                                    `json:"inLine,omitempty"`
+	UseDefaultCAs    *bool                                      `json:"useDefaultCAs,omitempty"`
+	UseInClusterCA   *bool                                      `json:"useInClusterCA,omitempty"`
+	IssuerRef        *IssuerReferenceApplyConfiguration         `json:"issuerRef,omitempty"`
+	Certificates     *CertificateSourceApplyConfiguration       `json:"certificates,omitempty"`
+	SPIFFEFederation *SPIFFEFederationSourceApplyConfiguration  `json:"spiffeFederation,omitempty"`
+	AWSPrivateCA     *AWSPrivateCASourceApplyConfiguration      `json:"awsPrivateCA,omitempty"`
+	GCPCASPool       *GCPCASPoolSourceApplyConfiguration        `json:"gcpCASPool,omitempty"`
+	AzureKeyVault    *AzureKeyVaultSourceApplyConfiguration     `json:"azureKeyVault,omitempty"`
+	Plugin           *PluginSourceApplyConfiguration            `json:"plugin,omitempty"`
+	Usages           []trustv1alpha1.CertificateUsage           `json:"usages,omitempty"`
 }
 
-// BundleSourceApplyConfiguration constructs an declarative configuration of the BundleSource type for use with
+// BundleSourceApplyConfiguration constructs a declarative configuration of the BundleSource type for use with
 // apply.
 func BundleSource() *BundleSourceApplyConfiguration {
 	return &BundleSourceApplyConfiguration{}
@@ -64,3 +77,77 @@ func (b *BundleSourceApplyConfiguration) WithUseDefaultCAs(value bool) *BundleSo
 	b.UseDefaultCAs = &value
 	return b
 }
+
+// WithUseInClusterCA sets the UseInClusterCA field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UseInClusterCA field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithUseInClusterCA(value bool) *BundleSourceApplyConfiguration {
+	b.UseInClusterCA = &value
+	return b
+}
+
+// WithIssuerRef sets the IssuerRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuerRef field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithIssuerRef(value *IssuerReferenceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.IssuerRef = value
+	return b
+}
+
+// WithCertificates sets the Certificates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certificates field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithCertificates(value *CertificateSourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.Certificates = value
+	return b
+}
+
+// WithSPIFFEFederation sets the SPIFFEFederation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SPIFFEFederation field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithSPIFFEFederation(value *SPIFFEFederationSourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.SPIFFEFederation = value
+	return b
+}
+
+// WithAWSPrivateCA sets the AWSPrivateCA field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AWSPrivateCA field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithAWSPrivateCA(value *AWSPrivateCASourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.AWSPrivateCA = value
+	return b
+}
+
+// WithGCPCASPool sets the GCPCASPool field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GCPCASPool field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithGCPCASPool(value *GCPCASPoolSourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.GCPCASPool = value
+	return b
+}
+
+// WithAzureKeyVault sets the AzureKeyVault field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AzureKeyVault field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithAzureKeyVault(value *AzureKeyVaultSourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.AzureKeyVault = value
+	return b
+}
+
+// WithPlugin sets the Plugin field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Plugin field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithPlugin(value *PluginSourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.Plugin = value
+	return b
+}
+
+// WithUsages adds the given value to the Usages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Usages field.
+func (b *BundleSourceApplyConfiguration) WithUsages(values ...trustv1alpha1.CertificateUsage) *BundleSourceApplyConfiguration {
+	for i := range values {
+		b.Usages = append(b.Usages, values[i])
+	}
+	return b
+}
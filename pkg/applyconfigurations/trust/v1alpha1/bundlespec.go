@@ -18,14 +18,25 @@ limitations under the License.
 
 package v1alpha1
 
-// BundleSpecApplyConfiguration represents an declarative configuration of the BundleSpec type for use
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BundleSpecApplyConfiguration represents a declarative configuration of the BundleSpec type for use
 // with apply.
 type BundleSpecApplyConfiguration struct {
-	Sources []BundleSourceApplyConfiguration `json:"sources,omitempty"`
-	Target  *BundleTargetApplyConfiguration  `json:"target,omitempty"`
+	Sources                   []BundleSourceApplyConfiguration `json:"sources,omitempty"`
+	Target                    *BundleTargetApplyConfiguration  `json:"target,omitempty"`
+	Targets                   []BundleTargetApplyConfiguration `json:"targets,omitempty"`
+	Paused                    *bool                            `json:"paused,omitempty"`
+	RequireCABasicConstraints *bool                            `json:"requireCABasicConstraints,omitempty"`
+	Filters                   *BundleFiltersApplyConfiguration `json:"filters,omitempty"`
+	RefreshInterval           *v1.Duration                     `json:"refreshInterval,omitempty"`
+	History                   *BundleHistoryApplyConfiguration `json:"history,omitempty"`
+	RollbackTo                *string                          `json:"rollbackTo,omitempty"`
 }
 
-// BundleSpecApplyConfiguration constructs an declarative configuration of the BundleSpec type for use with
+// BundleSpecApplyConfiguration constructs a declarative configuration of the BundleSpec type for use with
 // apply.
 func BundleSpec() *BundleSpecApplyConfiguration {
 	return &BundleSpecApplyConfiguration{}
@@ -51,3 +62,64 @@ func (b *BundleSpecApplyConfiguration) WithTarget(value *BundleTargetApplyConfig
 	b.Target = value
 	return b
 }
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *BundleSpecApplyConfiguration) WithTargets(values ...*BundleTargetApplyConfiguration) *BundleSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTargets")
+		}
+		b.Targets = append(b.Targets, *values[i])
+	}
+	return b
+}
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *BundleSpecApplyConfiguration) WithPaused(value bool) *BundleSpecApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
+// WithRequireCABasicConstraints sets the RequireCABasicConstraints field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequireCABasicConstraints field is set to the value of the last call.
+func (b *BundleSpecApplyConfiguration) WithRequireCABasicConstraints(value bool) *BundleSpecApplyConfiguration {
+	b.RequireCABasicConstraints = &value
+	return b
+}
+
+// WithFilters sets the Filters field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Filters field is set to the value of the last call.
+func (b *BundleSpecApplyConfiguration) WithFilters(value *BundleFiltersApplyConfiguration) *BundleSpecApplyConfiguration {
+	b.Filters = value
+	return b
+}
+
+// WithRefreshInterval sets the RefreshInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RefreshInterval field is set to the value of the last call.
+func (b *BundleSpecApplyConfiguration) WithRefreshInterval(value v1.Duration) *BundleSpecApplyConfiguration {
+	b.RefreshInterval = &value
+	return b
+}
+
+// WithHistory sets the History field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the History field is set to the value of the last call.
+func (b *BundleSpecApplyConfiguration) WithHistory(value *BundleHistoryApplyConfiguration) *BundleSpecApplyConfiguration {
+	b.History = value
+	return b
+}
+
+// WithRollbackTo sets the RollbackTo field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RollbackTo field is set to the value of the last call.
+func (b *BundleSpecApplyConfiguration) WithRollbackTo(value string) *BundleSpecApplyConfiguration {
+	b.RollbackTo = &value
+	return b
+}
@@ -18,14 +18,29 @@ limitations under the License.
 
 package v1alpha1
 
-// BundleStatusApplyConfiguration represents an declarative configuration of the BundleStatus type for use
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BundleStatusApplyConfiguration represents a declarative configuration of the BundleStatus type for use
 // with apply.
 type BundleStatusApplyConfiguration struct {
-	Conditions              []BundleConditionApplyConfiguration `json:"conditions,omitempty"`
-	DefaultCAPackageVersion *string                             `json:"defaultCAVersion,omitempty"`
+	Conditions              []BundleConditionApplyConfiguration     `json:"conditions,omitempty"`
+	ObservedGeneration      *int64                                  `json:"observedGeneration,omitempty"`
+	DefaultCAPackageVersion *string                                 `json:"defaultCAVersion,omitempty"`
+	TargetCount             *int32                                  `json:"targetCount,omitempty"`
+	SyncedTargetCount       *int32                                  `json:"syncedTargetCount,omitempty"`
+	LastSyncTime            *v1.Time                                `json:"lastSyncTime,omitempty"`
+	FilteredCertificates    *FilteredCertificatesApplyConfiguration `json:"filteredCertificates,omitempty"`
+	OptedOutNamespaces      *OptedOutNamespacesApplyConfiguration   `json:"optedOutNamespaces,omitempty"`
+	Rollout                 *BundleRolloutStatusApplyConfiguration  `json:"rollout,omitempty"`
+	History                 []BundleSnapshotApplyConfiguration      `json:"history,omitempty"`
+	LastContentChange       *BundleContentChangeApplyConfiguration  `json:"lastContentChange,omitempty"`
+	RemoteClusters          []RemoteClusterStatusApplyConfiguration `json:"remoteClusters,omitempty"`
+	KeyTransitions          []KeyTransitionStatusApplyConfiguration `json:"keyTransitions,omitempty"`
 }
 
-// BundleStatusApplyConfiguration constructs an declarative configuration of the BundleStatus type for use with
+// BundleStatusApplyConfiguration constructs a declarative configuration of the BundleStatus type for use with
 // apply.
 func BundleStatus() *BundleStatusApplyConfiguration {
 	return &BundleStatusApplyConfiguration{}
@@ -44,6 +59,14 @@ func (b *BundleStatusApplyConfiguration) WithConditions(values ...*BundleConditi
 	return b
 }
 
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithObservedGeneration(value int64) *BundleStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
 // WithDefaultCAPackageVersion sets the DefaultCAPackageVersion field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DefaultCAPackageVersion field is set to the value of the last call.
@@ -51,3 +74,98 @@ func (b *BundleStatusApplyConfiguration) WithDefaultCAPackageVersion(value strin
 	b.DefaultCAPackageVersion = &value
 	return b
 }
+
+// WithTargetCount sets the TargetCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetCount field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithTargetCount(value int32) *BundleStatusApplyConfiguration {
+	b.TargetCount = &value
+	return b
+}
+
+// WithSyncedTargetCount sets the SyncedTargetCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SyncedTargetCount field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithSyncedTargetCount(value int32) *BundleStatusApplyConfiguration {
+	b.SyncedTargetCount = &value
+	return b
+}
+
+// WithLastSyncTime sets the LastSyncTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSyncTime field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithLastSyncTime(value v1.Time) *BundleStatusApplyConfiguration {
+	b.LastSyncTime = &value
+	return b
+}
+
+// WithFilteredCertificates sets the FilteredCertificates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FilteredCertificates field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithFilteredCertificates(value *FilteredCertificatesApplyConfiguration) *BundleStatusApplyConfiguration {
+	b.FilteredCertificates = value
+	return b
+}
+
+// WithOptedOutNamespaces sets the OptedOutNamespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OptedOutNamespaces field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithOptedOutNamespaces(value *OptedOutNamespacesApplyConfiguration) *BundleStatusApplyConfiguration {
+	b.OptedOutNamespaces = value
+	return b
+}
+
+// WithRollout sets the Rollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollout field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithRollout(value *BundleRolloutStatusApplyConfiguration) *BundleStatusApplyConfiguration {
+	b.Rollout = value
+	return b
+}
+
+// WithHistory adds the given value to the History field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the History field.
+func (b *BundleStatusApplyConfiguration) WithHistory(values ...*BundleSnapshotApplyConfiguration) *BundleStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHistory")
+		}
+		b.History = append(b.History, *values[i])
+	}
+	return b
+}
+
+// WithLastContentChange sets the LastContentChange field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastContentChange field is set to the value of the last call.
+func (b *BundleStatusApplyConfiguration) WithLastContentChange(value *BundleContentChangeApplyConfiguration) *BundleStatusApplyConfiguration {
+	b.LastContentChange = value
+	return b
+}
+
+// WithRemoteClusters adds the given value to the RemoteClusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RemoteClusters field.
+func (b *BundleStatusApplyConfiguration) WithRemoteClusters(values ...*RemoteClusterStatusApplyConfiguration) *BundleStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRemoteClusters")
+		}
+		b.RemoteClusters = append(b.RemoteClusters, *values[i])
+	}
+	return b
+}
+
+// WithKeyTransitions adds the given value to the KeyTransitions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the KeyTransitions field.
+func (b *BundleStatusApplyConfiguration) WithKeyTransitions(values ...*KeyTransitionStatusApplyConfiguration) *BundleStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithKeyTransitions")
+		}
+		b.KeyTransitions = append(b.KeyTransitions, *values[i])
+	}
+	return b
+}
@@ -18,16 +18,33 @@ limitations under the License.
 
 package v1alpha1
 
-// BundleTargetApplyConfiguration represents an declarative configuration of the BundleTarget type for use
+import (
+	trustv1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// BundleTargetApplyConfiguration represents a declarative configuration of the BundleTarget type for use
 // with apply.
 type BundleTargetApplyConfiguration struct {
-	ConfigMap         *KeySelectorApplyConfiguration       `json:"configMap,omitempty"`
-	Secret            *KeySelectorApplyConfiguration       `json:"secret,omitempty"`
-	AdditionalFormats *AdditionalFormatsApplyConfiguration `json:"additionalFormats,omitempty"`
-	NamespaceSelector *NamespaceSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	ConfigMap               *ConfigMapTargetApplyConfiguration         `json:"configMap,omitempty"`
+	Secret                  *SecretTargetApplyConfiguration            `json:"secret,omitempty"`
+	AdditionalFormats       *AdditionalFormatsApplyConfiguration       `json:"additionalFormats,omitempty"`
+	AdditionalFormatsTarget *AdditionalFormatsTargetApplyConfiguration `json:"additionalFormatsTarget,omitempty"`
+	Signature               *BundleSignatureApplyConfiguration         `json:"signature,omitempty"`
+	Manifest                *BundleManifestApplyConfiguration          `json:"manifest,omitempty"`
+	UsageKeys               []UsageKeyApplyConfiguration               `json:"usageKeys,omitempty"`
+	NamespaceSelector       *v1.LabelSelectorApplyConfiguration        `json:"namespaceSelector,omitempty"`
+	KeyOverrides            []KeyOverrideApplyConfiguration            `json:"keyOverrides,omitempty"`
+	DeletionPolicy          *trustv1alpha1.DeletionPolicy              `json:"deletionPolicy,omitempty"`
+	Ownership               *trustv1alpha1.TargetOwnership             `json:"ownership,omitempty"`
+	KeyTransition           *KeyTransitionApplyConfiguration           `json:"keyTransition,omitempty"`
+	AdoptExisting           *bool                                      `json:"adoptExisting,omitempty"`
+	RolloutStrategy         *RolloutStrategyApplyConfiguration         `json:"rolloutStrategy,omitempty"`
+	Metadata                *TargetMetadataApplyConfiguration          `json:"metadata,omitempty"`
+	MergeStrategy           *trustv1alpha1.MergeStrategy               `json:"mergeStrategy,omitempty"`
 }
 
-// BundleTargetApplyConfiguration constructs an declarative configuration of the BundleTarget type for use with
+// BundleTargetApplyConfiguration constructs a declarative configuration of the BundleTarget type for use with
 // apply.
 func BundleTarget() *BundleTargetApplyConfiguration {
 	return &BundleTargetApplyConfiguration{}
@@ -36,7 +53,7 @@ func BundleTarget() *BundleTargetApplyConfiguration {
 // WithConfigMap sets the ConfigMap field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ConfigMap field is set to the value of the last call.
-func (b *BundleTargetApplyConfiguration) WithConfigMap(value *KeySelectorApplyConfiguration) *BundleTargetApplyConfiguration {
+func (b *BundleTargetApplyConfiguration) WithConfigMap(value *ConfigMapTargetApplyConfiguration) *BundleTargetApplyConfiguration {
 	b.ConfigMap = value
 	return b
 }
@@ -44,7 +61,7 @@ func (b *BundleTargetApplyConfiguration) WithConfigMap(value *KeySelectorApplyCo
 // WithSecret sets the Secret field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Secret field is set to the value of the last call.
-func (b *BundleTargetApplyConfiguration) WithSecret(value *KeySelectorApplyConfiguration) *BundleTargetApplyConfiguration {
+func (b *BundleTargetApplyConfiguration) WithSecret(value *SecretTargetApplyConfiguration) *BundleTargetApplyConfiguration {
 	b.Secret = value
 	return b
 }
@@ -57,10 +74,116 @@ func (b *BundleTargetApplyConfiguration) WithAdditionalFormats(value *Additional
 	return b
 }
 
+// WithAdditionalFormatsTarget sets the AdditionalFormatsTarget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdditionalFormatsTarget field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithAdditionalFormatsTarget(value *AdditionalFormatsTargetApplyConfiguration) *BundleTargetApplyConfiguration {
+	b.AdditionalFormatsTarget = value
+	return b
+}
+
+// WithSignature sets the Signature field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Signature field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithSignature(value *BundleSignatureApplyConfiguration) *BundleTargetApplyConfiguration {
+	b.Signature = value
+	return b
+}
+
+// WithManifest sets the Manifest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Manifest field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithManifest(value *BundleManifestApplyConfiguration) *BundleTargetApplyConfiguration {
+	b.Manifest = value
+	return b
+}
+
+// WithUsageKeys adds the given value to the UsageKeys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UsageKeys field.
+func (b *BundleTargetApplyConfiguration) WithUsageKeys(values ...*UsageKeyApplyConfiguration) *BundleTargetApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUsageKeys")
+		}
+		b.UsageKeys = append(b.UsageKeys, *values[i])
+	}
+	return b
+}
+
 // WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NamespaceSelector field is set to the value of the last call.
-func (b *BundleTargetApplyConfiguration) WithNamespaceSelector(value *NamespaceSelectorApplyConfiguration) *BundleTargetApplyConfiguration {
+func (b *BundleTargetApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *BundleTargetApplyConfiguration {
 	b.NamespaceSelector = value
 	return b
 }
+
+// WithKeyOverrides adds the given value to the KeyOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the KeyOverrides field.
+func (b *BundleTargetApplyConfiguration) WithKeyOverrides(values ...*KeyOverrideApplyConfiguration) *BundleTargetApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithKeyOverrides")
+		}
+		b.KeyOverrides = append(b.KeyOverrides, *values[i])
+	}
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithDeletionPolicy(value trustv1alpha1.DeletionPolicy) *BundleTargetApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
+
+// WithOwnership sets the Ownership field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ownership field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithOwnership(value trustv1alpha1.TargetOwnership) *BundleTargetApplyConfiguration {
+	b.Ownership = &value
+	return b
+}
+
+// WithKeyTransition sets the KeyTransition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeyTransition field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithKeyTransition(value *KeyTransitionApplyConfiguration) *BundleTargetApplyConfiguration {
+	b.KeyTransition = value
+	return b
+}
+
+// WithAdoptExisting sets the AdoptExisting field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdoptExisting field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithAdoptExisting(value bool) *BundleTargetApplyConfiguration {
+	b.AdoptExisting = &value
+	return b
+}
+
+// WithRolloutStrategy sets the RolloutStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RolloutStrategy field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithRolloutStrategy(value *RolloutStrategyApplyConfiguration) *BundleTargetApplyConfiguration {
+	b.RolloutStrategy = value
+	return b
+}
+
+// WithMetadata sets the Metadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Metadata field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithMetadata(value *TargetMetadataApplyConfiguration) *BundleTargetApplyConfiguration {
+	b.Metadata = value
+	return b
+}
+
+// WithMergeStrategy sets the MergeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MergeStrategy field is set to the value of the last call.
+func (b *BundleTargetApplyConfiguration) WithMergeStrategy(value trustv1alpha1.MergeStrategy) *BundleTargetApplyConfiguration {
+	b.MergeStrategy = &value
+	return b
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// CanaryRolloutApplyConfiguration represents a declarative configuration of the CanaryRollout type for use
+// with apply.
+type CanaryRolloutApplyConfiguration struct {
+	NamespaceSelector *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	Namespaces        []string                            `json:"namespaces,omitempty"`
+	SoakDuration      *metav1.Duration                    `json:"soakDuration,omitempty"`
+}
+
+// CanaryRolloutApplyConfiguration constructs a declarative configuration of the CanaryRollout type for use with
+// apply.
+func CanaryRollout() *CanaryRolloutApplyConfiguration {
+	return &CanaryRolloutApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *CanaryRolloutApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *CanaryRolloutApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *CanaryRolloutApplyConfiguration) WithNamespaces(values ...string) *CanaryRolloutApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
+
+// WithSoakDuration sets the SoakDuration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SoakDuration field is set to the value of the last call.
+func (b *CanaryRolloutApplyConfiguration) WithSoakDuration(value metav1.Duration) *CanaryRolloutApplyConfiguration {
+	b.SoakDuration = &value
+	return b
+}
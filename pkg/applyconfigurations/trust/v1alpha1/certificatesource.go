@@ -0,0 +1,43 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// CertificateSourceApplyConfiguration represents a declarative configuration of the CertificateSource type for use
+// with apply.
+type CertificateSourceApplyConfiguration struct {
+	Selector *v1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+}
+
+// CertificateSourceApplyConfiguration constructs a declarative configuration of the CertificateSource type for use with
+// apply.
+func CertificateSource() *CertificateSourceApplyConfiguration {
+	return &CertificateSourceApplyConfiguration{}
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *CertificateSourceApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *CertificateSourceApplyConfiguration {
+	b.Selector = value
+	return b
+}
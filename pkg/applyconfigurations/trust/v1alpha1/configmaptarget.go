@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ConfigMapTargetApplyConfiguration represents a declarative configuration of the ConfigMapTarget type for use
+// with apply.
+type ConfigMapTargetApplyConfiguration struct {
+	KeySelectorApplyConfiguration `json:",inline"`
+	AdditionalFormats             *AdditionalFormatsApplyConfiguration `json:"additionalFormats,omitempty"`
+	AdditionalKeys                []string                             `json:"additionalKeys,omitempty"`
+}
+
+// ConfigMapTargetApplyConfiguration constructs a declarative configuration of the ConfigMapTarget type for use with
+// apply.
+func ConfigMapTarget() *ConfigMapTargetApplyConfiguration {
+	return &ConfigMapTargetApplyConfiguration{}
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *ConfigMapTargetApplyConfiguration) WithKey(value string) *ConfigMapTargetApplyConfiguration {
+	b.Key = &value
+	return b
+}
+
+// WithAdditionalFormats sets the AdditionalFormats field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdditionalFormats field is set to the value of the last call.
+func (b *ConfigMapTargetApplyConfiguration) WithAdditionalFormats(value *AdditionalFormatsApplyConfiguration) *ConfigMapTargetApplyConfiguration {
+	b.AdditionalFormats = value
+	return b
+}
+
+// WithAdditionalKeys adds the given value to the AdditionalKeys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalKeys field.
+func (b *ConfigMapTargetApplyConfiguration) WithAdditionalKeys(values ...string) *ConfigMapTargetApplyConfiguration {
+	for i := range values {
+		b.AdditionalKeys = append(b.AdditionalKeys, values[i])
+	}
+	return b
+}
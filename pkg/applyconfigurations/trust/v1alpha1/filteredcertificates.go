@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FilteredCertificatesApplyConfiguration represents a declarative configuration of the FilteredCertificates type for use
+// with apply.
+type FilteredCertificatesApplyConfiguration struct {
+	Count   *int32                                  `json:"count,omitempty"`
+	Samples []FilteredCertificateApplyConfiguration `json:"samples,omitempty"`
+}
+
+// FilteredCertificatesApplyConfiguration constructs a declarative configuration of the FilteredCertificates type for use with
+// apply.
+func FilteredCertificates() *FilteredCertificatesApplyConfiguration {
+	return &FilteredCertificatesApplyConfiguration{}
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *FilteredCertificatesApplyConfiguration) WithCount(value int32) *FilteredCertificatesApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithSamples adds the given value to the Samples field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Samples field.
+func (b *FilteredCertificatesApplyConfiguration) WithSamples(values ...*FilteredCertificateApplyConfiguration) *FilteredCertificatesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSamples")
+		}
+		b.Samples = append(b.Samples, *values[i])
+	}
+	return b
+}
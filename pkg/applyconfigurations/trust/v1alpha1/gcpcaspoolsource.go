@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GCPCASPoolSourceApplyConfiguration represents a declarative configuration of the GCPCASPoolSource type for use
+// with apply.
+type GCPCASPoolSourceApplyConfiguration struct {
+	Pool            *string      `json:"pool,omitempty"`
+	RefreshInterval *v1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// GCPCASPoolSourceApplyConfiguration constructs a declarative configuration of the GCPCASPoolSource type for use with
+// apply.
+func GCPCASPoolSource() *GCPCASPoolSourceApplyConfiguration {
+	return &GCPCASPoolSourceApplyConfiguration{}
+}
+
+// WithPool sets the Pool field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pool field is set to the value of the last call.
+func (b *GCPCASPoolSourceApplyConfiguration) WithPool(value string) *GCPCASPoolSourceApplyConfiguration {
+	b.Pool = &value
+	return b
+}
+
+// WithRefreshInterval sets the RefreshInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RefreshInterval field is set to the value of the last call.
+func (b *GCPCASPoolSourceApplyConfiguration) WithRefreshInterval(value v1.Duration) *GCPCASPoolSourceApplyConfiguration {
+	b.RefreshInterval = &value
+	return b
+}
@@ -18,14 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
-// JKSApplyConfiguration represents an declarative configuration of the JKS type for use
+// JKSApplyConfiguration represents a declarative configuration of the JKS type for use
 // with apply.
 type JKSApplyConfiguration struct {
 	KeySelectorApplyConfiguration `json:",inline"`
 	Password                      *string `json:"password,omitempty"`
 }
 
-// JKSApplyConfiguration constructs an declarative configuration of the JKS type for use with
+// JKSApplyConfiguration constructs a declarative configuration of the JKS type for use with
 // apply.
 func JKS() *JKSApplyConfiguration {
 	return &JKSApplyConfiguration{}
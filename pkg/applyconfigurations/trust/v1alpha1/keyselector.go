@@ -18,13 +18,13 @@ limitations under the License.
 
 package v1alpha1
 
-// KeySelectorApplyConfiguration represents an declarative configuration of the KeySelector type for use
+// KeySelectorApplyConfiguration represents a declarative configuration of the KeySelector type for use
 // with apply.
 type KeySelectorApplyConfiguration struct {
 	Key *string `json:"key,omitempty"`
 }
 
-// KeySelectorApplyConfiguration constructs an declarative configuration of the KeySelector type for use with
+// KeySelectorApplyConfiguration constructs a declarative configuration of the KeySelector type for use with
 // apply.
 func KeySelector() *KeySelectorApplyConfiguration {
 	return &KeySelectorApplyConfiguration{}
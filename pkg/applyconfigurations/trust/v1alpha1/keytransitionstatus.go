@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KeyTransitionStatusApplyConfiguration represents a declarative configuration of the KeyTransitionStatus type for use
+// with apply.
+type KeyTransitionStatusApplyConfiguration struct {
+	Kind        *string  `json:"kind,omitempty"`
+	Key         *string  `json:"key,omitempty"`
+	PreviousKey *string  `json:"previousKey,omitempty"`
+	StartTime   *v1.Time `json:"startTime,omitempty"`
+}
+
+// KeyTransitionStatusApplyConfiguration constructs a declarative configuration of the KeyTransitionStatus type for use with
+// apply.
+func KeyTransitionStatus() *KeyTransitionStatusApplyConfiguration {
+	return &KeyTransitionStatusApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *KeyTransitionStatusApplyConfiguration) WithKind(value string) *KeyTransitionStatusApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *KeyTransitionStatusApplyConfiguration) WithKey(value string) *KeyTransitionStatusApplyConfiguration {
+	b.Key = &value
+	return b
+}
+
+// WithPreviousKey sets the PreviousKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreviousKey field is set to the value of the last call.
+func (b *KeyTransitionStatusApplyConfiguration) WithPreviousKey(value string) *KeyTransitionStatusApplyConfiguration {
+	b.PreviousKey = &value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *KeyTransitionStatusApplyConfiguration) WithStartTime(value v1.Time) *KeyTransitionStatusApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// OptedOutNamespacesApplyConfiguration represents a declarative configuration of the OptedOutNamespaces type for use
+// with apply.
+type OptedOutNamespacesApplyConfiguration struct {
+	Count *int32   `json:"count,omitempty"`
+	Names []string `json:"names,omitempty"`
+}
+
+// OptedOutNamespacesApplyConfiguration constructs a declarative configuration of the OptedOutNamespaces type for use with
+// apply.
+func OptedOutNamespaces() *OptedOutNamespacesApplyConfiguration {
+	return &OptedOutNamespacesApplyConfiguration{}
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *OptedOutNamespacesApplyConfiguration) WithCount(value int32) *OptedOutNamespacesApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithNames adds the given value to the Names field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Names field.
+func (b *OptedOutNamespacesApplyConfiguration) WithNames(values ...string) *OptedOutNamespacesApplyConfiguration {
+	for i := range values {
+		b.Names = append(b.Names, values[i])
+	}
+	return b
+}
@@ -18,14 +18,15 @@ limitations under the License.
 
 package v1alpha1
 
-// PKCS12ApplyConfiguration represents an declarative configuration of the PKCS12 type for use
+// PKCS12ApplyConfiguration represents a declarative configuration of the PKCS12 type for use
 // with apply.
 type PKCS12ApplyConfiguration struct {
 	KeySelectorApplyConfiguration `json:",inline"`
 	Password                      *string `json:"password,omitempty"`
+	Deterministic                 *bool   `json:"deterministic,omitempty"`
 }
 
-// PKCS12ApplyConfiguration constructs an declarative configuration of the PKCS12 type for use with
+// PKCS12ApplyConfiguration constructs a declarative configuration of the PKCS12 type for use with
 // apply.
 func PKCS12() *PKCS12ApplyConfiguration {
 	return &PKCS12ApplyConfiguration{}
@@ -46,3 +47,11 @@ func (b *PKCS12ApplyConfiguration) WithPassword(value string) *PKCS12ApplyConfig
 	b.Password = &value
 	return b
 }
+
+// WithDeterministic sets the Deterministic field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deterministic field is set to the value of the last call.
+func (b *PKCS12ApplyConfiguration) WithDeterministic(value bool) *PKCS12ApplyConfiguration {
+	b.Deterministic = &value
+	return b
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PluginSourceApplyConfiguration represents a declarative configuration of the PluginSource type for use
+// with apply.
+type PluginSourceApplyConfiguration struct {
+	Name            *string           `json:"name,omitempty"`
+	Parameters      map[string]string `json:"parameters,omitempty"`
+	RefreshInterval *v1.Duration      `json:"refreshInterval,omitempty"`
+}
+
+// PluginSourceApplyConfiguration constructs a declarative configuration of the PluginSource type for use with
+// apply.
+func PluginSource() *PluginSourceApplyConfiguration {
+	return &PluginSourceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PluginSourceApplyConfiguration) WithName(value string) *PluginSourceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithParameters puts the entries into the Parameters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Parameters field,
+// overwriting an existing map entries in Parameters field with the same key.
+func (b *PluginSourceApplyConfiguration) WithParameters(entries map[string]string) *PluginSourceApplyConfiguration {
+	if b.Parameters == nil && len(entries) > 0 {
+		b.Parameters = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Parameters[k] = v
+	}
+	return b
+}
+
+// WithRefreshInterval sets the RefreshInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RefreshInterval field is set to the value of the last call.
+func (b *PluginSourceApplyConfiguration) WithRefreshInterval(value v1.Duration) *PluginSourceApplyConfiguration {
+	b.RefreshInterval = &value
+	return b
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteClusterStatusApplyConfiguration represents a declarative configuration of the RemoteClusterStatus type for use
+// with apply.
+type RemoteClusterStatusApplyConfiguration struct {
+	Name         *string  `json:"name,omitempty"`
+	Synced       *bool    `json:"synced,omitempty"`
+	TargetCount  *int32   `json:"targetCount,omitempty"`
+	Message      *string  `json:"message,omitempty"`
+	LastSyncTime *v1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// RemoteClusterStatusApplyConfiguration constructs a declarative configuration of the RemoteClusterStatus type for use with
+// apply.
+func RemoteClusterStatus() *RemoteClusterStatusApplyConfiguration {
+	return &RemoteClusterStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *RemoteClusterStatusApplyConfiguration) WithName(value string) *RemoteClusterStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSynced sets the Synced field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Synced field is set to the value of the last call.
+func (b *RemoteClusterStatusApplyConfiguration) WithSynced(value bool) *RemoteClusterStatusApplyConfiguration {
+	b.Synced = &value
+	return b
+}
+
+// WithTargetCount sets the TargetCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetCount field is set to the value of the last call.
+func (b *RemoteClusterStatusApplyConfiguration) WithTargetCount(value int32) *RemoteClusterStatusApplyConfiguration {
+	b.TargetCount = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *RemoteClusterStatusApplyConfiguration) WithMessage(value string) *RemoteClusterStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastSyncTime sets the LastSyncTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSyncTime field is set to the value of the last call.
+func (b *RemoteClusterStatusApplyConfiguration) WithLastSyncTime(value v1.Time) *RemoteClusterStatusApplyConfiguration {
+	b.LastSyncTime = &value
+	return b
+}
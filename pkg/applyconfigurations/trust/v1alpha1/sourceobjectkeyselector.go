@@ -19,18 +19,21 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// SourceObjectKeySelectorApplyConfiguration represents an declarative configuration of the SourceObjectKeySelector type for use
+// SourceObjectKeySelectorApplyConfiguration represents a declarative configuration of the SourceObjectKeySelector type for use
 // with apply.
 type SourceObjectKeySelectorApplyConfiguration struct {
-	Name                          *string           `json:"name,omitempty"`
-	Selector                      *v1.LabelSelector `json:"selector,omitempty"`
-	KeySelectorApplyConfiguration `json:",inline"`
+	Name              *string                             `json:"name,omitempty"`
+	Selector          *v1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+	NamespaceSelector *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	Key               *string                             `json:"key,omitempty"`
+	IncludeAllKeys    *bool                               `json:"includeAllKeys,omitempty"`
+	Optional          *bool                               `json:"optional,omitempty"`
 }
 
-// SourceObjectKeySelectorApplyConfiguration constructs an declarative configuration of the SourceObjectKeySelector type for use with
+// SourceObjectKeySelectorApplyConfiguration constructs a declarative configuration of the SourceObjectKeySelector type for use with
 // apply.
 func SourceObjectKeySelector() *SourceObjectKeySelectorApplyConfiguration {
 	return &SourceObjectKeySelectorApplyConfiguration{}
@@ -47,8 +50,16 @@ func (b *SourceObjectKeySelectorApplyConfiguration) WithName(value string) *Sour
 // WithSelector sets the Selector field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Selector field is set to the value of the last call.
-func (b *SourceObjectKeySelectorApplyConfiguration) WithSelector(value v1.LabelSelector) *SourceObjectKeySelectorApplyConfiguration {
-	b.Selector = &value
+func (b *SourceObjectKeySelectorApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *SourceObjectKeySelectorApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *SourceObjectKeySelectorApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *SourceObjectKeySelectorApplyConfiguration {
+	b.NamespaceSelector = value
 	return b
 }
 
@@ -59,3 +70,19 @@ func (b *SourceObjectKeySelectorApplyConfiguration) WithKey(value string) *Sourc
 	b.Key = &value
 	return b
 }
+
+// WithIncludeAllKeys sets the IncludeAllKeys field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IncludeAllKeys field is set to the value of the last call.
+func (b *SourceObjectKeySelectorApplyConfiguration) WithIncludeAllKeys(value bool) *SourceObjectKeySelectorApplyConfiguration {
+	b.IncludeAllKeys = &value
+	return b
+}
+
+// WithOptional sets the Optional field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Optional field is set to the value of the last call.
+func (b *SourceObjectKeySelectorApplyConfiguration) WithOptional(value bool) *SourceObjectKeySelectorApplyConfiguration {
+	b.Optional = &value
+	return b
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SPIFFEFederationSourceApplyConfiguration represents a declarative configuration of the SPIFFEFederationSource type for use
+// with apply.
+type SPIFFEFederationSourceApplyConfiguration struct {
+	TrustDomain                *string                                  `json:"trustDomain,omitempty"`
+	EndpointURL                *string                                  `json:"endpointURL,omitempty"`
+	Profile                    *v1alpha1.SPIFFEBundleEndpointProfile    `json:"profile,omitempty"`
+	EndpointSPIFFEID           *string                                  `json:"endpointSPIFFEID,omitempty"`
+	BootstrapBundle            *ConfigMapKeyReferenceApplyConfiguration `json:"bootstrapBundle,omitempty"`
+	RefreshInterval            *v1.Duration                             `json:"refreshInterval,omitempty"`
+	PresentServiceAccountToken *bool                                    `json:"presentServiceAccountToken,omitempty"`
+}
+
+// SPIFFEFederationSourceApplyConfiguration constructs a declarative configuration of the SPIFFEFederationSource type for use with
+// apply.
+func SPIFFEFederationSource() *SPIFFEFederationSourceApplyConfiguration {
+	return &SPIFFEFederationSourceApplyConfiguration{}
+}
+
+// WithTrustDomain sets the TrustDomain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TrustDomain field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithTrustDomain(value string) *SPIFFEFederationSourceApplyConfiguration {
+	b.TrustDomain = &value
+	return b
+}
+
+// WithEndpointURL sets the EndpointURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndpointURL field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithEndpointURL(value string) *SPIFFEFederationSourceApplyConfiguration {
+	b.EndpointURL = &value
+	return b
+}
+
+// WithProfile sets the Profile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Profile field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithProfile(value v1alpha1.SPIFFEBundleEndpointProfile) *SPIFFEFederationSourceApplyConfiguration {
+	b.Profile = &value
+	return b
+}
+
+// WithEndpointSPIFFEID sets the EndpointSPIFFEID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndpointSPIFFEID field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithEndpointSPIFFEID(value string) *SPIFFEFederationSourceApplyConfiguration {
+	b.EndpointSPIFFEID = &value
+	return b
+}
+
+// WithBootstrapBundle sets the BootstrapBundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BootstrapBundle field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithBootstrapBundle(value *ConfigMapKeyReferenceApplyConfiguration) *SPIFFEFederationSourceApplyConfiguration {
+	b.BootstrapBundle = value
+	return b
+}
+
+// WithRefreshInterval sets the RefreshInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RefreshInterval field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithRefreshInterval(value v1.Duration) *SPIFFEFederationSourceApplyConfiguration {
+	b.RefreshInterval = &value
+	return b
+}
+
+// WithPresentServiceAccountToken sets the PresentServiceAccountToken field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PresentServiceAccountToken field is set to the value of the last call.
+func (b *SPIFFEFederationSourceApplyConfiguration) WithPresentServiceAccountToken(value bool) *SPIFFEFederationSourceApplyConfiguration {
+	b.PresentServiceAccountToken = &value
+	return b
+}
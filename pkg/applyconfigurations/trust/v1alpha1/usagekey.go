@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// UsageKeyApplyConfiguration represents a declarative configuration of the UsageKey type for use
+// with apply.
+type UsageKeyApplyConfiguration struct {
+	Usage *v1alpha1.CertificateUsage `json:"usage,omitempty"`
+	Key   *string                    `json:"key,omitempty"`
+}
+
+// UsageKeyApplyConfiguration constructs a declarative configuration of the UsageKey type for use with
+// apply.
+func UsageKey() *UsageKeyApplyConfiguration {
+	return &UsageKeyApplyConfiguration{}
+}
+
+// WithUsage sets the Usage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Usage field is set to the value of the last call.
+func (b *UsageKeyApplyConfiguration) WithUsage(value v1alpha1.CertificateUsage) *UsageKeyApplyConfiguration {
+	b.Usage = &value
+	return b
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *UsageKeyApplyConfiguration) WithKey(value string) *UsageKeyApplyConfiguration {
+	b.Key = &value
+	return b
+}
@@ -20,8 +20,11 @@ package applyconfigurations
 
 import (
 	v1alpha1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	internal "github.com/cert-manager/trust-manager/pkg/applyconfigurations/internal"
 	trustv1alpha1 "github.com/cert-manager/trust-manager/pkg/applyconfigurations/trust/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	testing "k8s.io/client-go/testing"
 )
 
 // ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
@@ -31,10 +34,32 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=trust.cert-manager.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithKind("AdditionalFormats"):
 		return &trustv1alpha1.AdditionalFormatsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AdditionalFormatsTarget"):
+		return &trustv1alpha1.AdditionalFormatsTargetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AWSPrivateCASource"):
+		return &trustv1alpha1.AWSPrivateCASourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AzureKeyVaultSource"):
+		return &trustv1alpha1.AzureKeyVaultSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Bundle"):
 		return &trustv1alpha1.BundleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleCertificate"):
+		return &trustv1alpha1.BundleCertificateApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BundleCondition"):
 		return &trustv1alpha1.BundleConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleContentChange"):
+		return &trustv1alpha1.BundleContentChangeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleFilters"):
+		return &trustv1alpha1.BundleFiltersApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleHistory"):
+		return &trustv1alpha1.BundleHistoryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleManifest"):
+		return &trustv1alpha1.BundleManifestApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleRolloutStatus"):
+		return &trustv1alpha1.BundleRolloutStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleSignature"):
+		return &trustv1alpha1.BundleSignatureApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleSnapshot"):
+		return &trustv1alpha1.BundleSnapshotApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BundleSource"):
 		return &trustv1alpha1.BundleSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BundleSpec"):
@@ -43,17 +68,63 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &trustv1alpha1.BundleStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BundleTarget"):
 		return &trustv1alpha1.BundleTargetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CanaryRollout"):
+		return &trustv1alpha1.CanaryRolloutApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Certdata"):
+		return &trustv1alpha1.CertdataApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CertificateSource"):
+		return &trustv1alpha1.CertificateSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapKeyReference"):
+		return &trustv1alpha1.ConfigMapKeyReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapTarget"):
+		return &trustv1alpha1.ConfigMapTargetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FilteredCertificate"):
+		return &trustv1alpha1.FilteredCertificateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FilteredCertificates"):
+		return &trustv1alpha1.FilteredCertificatesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GCPCASPoolSource"):
+		return &trustv1alpha1.GCPCASPoolSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IssuerReference"):
+		return &trustv1alpha1.IssuerReferenceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("JKS"):
 		return &trustv1alpha1.JKSApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KeyOverride"):
+		return &trustv1alpha1.KeyOverrideApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KeySelector"):
 		return &trustv1alpha1.KeySelectorApplyConfiguration{}
-	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceSelector"):
-		return &trustv1alpha1.NamespaceSelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KeyTransition"):
+		return &trustv1alpha1.KeyTransitionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KeyTransitionStatus"):
+		return &trustv1alpha1.KeyTransitionStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OptedOutNamespaces"):
+		return &trustv1alpha1.OptedOutNamespacesApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PKCS12"):
 		return &trustv1alpha1.PKCS12ApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PluginSource"):
+		return &trustv1alpha1.PluginSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ProgressiveRollout"):
+		return &trustv1alpha1.ProgressiveRolloutApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RemoteClusterStatus"):
+		return &trustv1alpha1.RemoteClusterStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RolloutStrategy"):
+		return &trustv1alpha1.RolloutStrategyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SecretTarget"):
+		return &trustv1alpha1.SecretTargetApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("SourceObjectKeySelector"):
 		return &trustv1alpha1.SourceObjectKeySelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SPIFFE"):
+		return &trustv1alpha1.SPIFFEApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SPIFFEFederationSource"):
+		return &trustv1alpha1.SPIFFEFederationSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TargetMetadata"):
+		return &trustv1alpha1.TargetMetadataApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UsageKey"):
+		return &trustv1alpha1.UsageKeyApplyConfiguration{}
 
 	}
 	return nil
 }
+
+func NewTypeConverter(scheme *runtime.Scheme) *testing.TypeConverter {
+	return &testing.TypeConverter{Scheme: scheme, TypeResolver: internal.Parser()}
+}
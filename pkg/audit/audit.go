@@ -0,0 +1,257 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every change trust-manager makes to the targets of
+// Bundles as a structured JSON entry, written to stdout or posted to a
+// webhook, so that changes to trust anchors can be audited.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+)
+
+// The sinks audit entries can be written to.
+const (
+	// SinkNone disables auditing.
+	SinkNone = ""
+
+	// SinkStdout writes each entry to stdout as a line of JSON.
+	SinkStdout = "stdout"
+
+	// SinkWebhook posts each entry as JSON to a webhook.
+	SinkWebhook = "webhook"
+)
+
+// webhookTimeout limits the time taken to post an entry to the webhook, if
+// the HTTP client has no shorter timeout.
+const webhookTimeout = 10 * time.Second
+
+// Action is the change made to a target.
+type Action string
+
+// The changes made to targets.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Entry records a change made to a target of a Bundle.
+type Entry struct {
+	// Audit is always "target", so that entries can be told apart from the
+	// logs of trust-manager when both are written to stdout.
+	Audit string `json:"audit"`
+
+	// Time is the time at which the change was made.
+	Time time.Time `json:"time"`
+
+	// Action is the change made to the target.
+	Action Action `json:"action"`
+
+	// Bundle is the name of the Bundle the target belongs to.
+	Bundle string `json:"bundle"`
+
+	// Cluster is the name of the remote cluster the target is in, or empty
+	// for targets in the local cluster.
+	Cluster string `json:"cluster,omitempty"`
+
+	// Kind, Namespace and Name identify the target object.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// OldHash is the hash of the bundle the target held before the change,
+	// and NewHash the hash of the bundle it holds after it. Either is empty
+	// if the target held no bundle.
+	OldHash string `json:"oldHash,omitempty"`
+	NewHash string `json:"newHash,omitempty"`
+
+	// Version is the version of trust-manager which made the change.
+	Version string `json:"version"`
+}
+
+// Options configure the audit log.
+type Options struct {
+	// Sink is where entries are written: SinkNone, SinkStdout or SinkWebhook.
+	Sink string
+
+	// WebhookURL is the HTTP or HTTPS URL entries are posted to by the
+	// webhook sink.
+	WebhookURL string
+}
+
+// Validate returns an error if the options are inconsistent.
+func (o Options) Validate() error {
+	switch o.Sink {
+	case SinkNone, SinkStdout:
+		if o.WebhookURL != "" {
+			return errors.New("a webhook URL can only be set for the webhook sink")
+		}
+	case SinkWebhook:
+		u, err := url.Parse(o.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook URL: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook URL %q must be an absolute HTTP or HTTPS URL", o.WebhookURL)
+		}
+	default:
+		return fmt.Errorf("unknown sink %q, must be one of %q, %q or %q", o.Sink, SinkNone, SinkStdout, SinkWebhook)
+	}
+	return nil
+}
+
+// Log writes audit entries to its sink. A nil Log writes nothing, so that
+// callers needn't check whether auditing is enabled.
+type Log struct {
+	log     logr.Logger
+	clock   clock.PassiveClock
+	version string
+	cluster string
+
+	write func(ctx context.Context, data []byte) error
+}
+
+// New returns a Log writing to the sink configured by the options, or nil if
+// auditing is disabled. The webhook is posted to with an HTTP client built
+// from httpOpts. Entries which can't be written are logged to log.
+func New(opts Options, httpOpts httpclient.Options, log logr.Logger) (*Log, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch opts.Sink {
+	case SinkStdout:
+		return NewWriterLog(os.Stdout, log), nil
+	case SinkWebhook:
+		client, err := httpclient.New(httpOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook client: %w", err)
+		}
+		return newLog(newWebhookSink(client, opts.WebhookURL), log), nil
+	}
+	return nil, nil
+}
+
+// NewWriterLog returns a Log writing each entry to w as a line of JSON.
+func NewWriterLog(w io.Writer, log logr.Logger) *Log {
+	return newLog(newWriterSink(w), log)
+}
+
+func newLog(write func(context.Context, []byte) error, log logr.Logger) *Log {
+	return &Log{
+		log:     log,
+		clock:   clock.RealClock{},
+		version: version(),
+		write:   write,
+	}
+}
+
+// WithCluster returns a Log which records entries for targets in the named
+// remote cluster, writing to the same sink.
+func (l *Log) WithCluster(name string) *Log {
+	if l == nil {
+		return nil
+	}
+	clusterLog := *l
+	clusterLog.cluster = name
+	return &clusterLog
+}
+
+// Record writes an entry for a change made to a target, filling in the time,
+// cluster and version. Failures are logged rather than returned, as the
+// change has already been made.
+func (l *Log) Record(entry Entry) {
+	if l == nil {
+		return
+	}
+
+	entry.Audit = "target"
+	entry.Time = l.clock.Now().UTC()
+	entry.Cluster = l.cluster
+	entry.Version = l.version
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		l.log.Error(err, "failed to encode audit entry")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	if err := l.write(ctx, data); err != nil {
+		l.log.Error(err, "failed to write audit entry", "entry", string(data))
+	}
+}
+
+// newWriterSink returns a sink writing each entry to w as a line of JSON.
+func newWriterSink(w io.Writer) func(context.Context, []byte) error {
+	var mu sync.Mutex
+	return func(_ context.Context, data []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		_, err := w.Write(append(data, '\n'))
+		return err
+	}
+}
+
+// newWebhookSink returns a sink posting each entry to the endpoint, which must
+// respond with a 2xx status.
+func newWebhookSink(client *http.Client, endpoint string) func(context.Context, []byte) error {
+	return func(ctx context.Context, data []byte) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to post to webhook: %w", err)
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook responded with status %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// version returns the module version trust-manager was built from.
+func version() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	assert.NoError(t, Options{}.Validate())
+	assert.NoError(t, Options{Sink: SinkStdout}.Validate())
+	assert.NoError(t, Options{Sink: SinkWebhook, WebhookURL: "https://audit.example.com/trust-manager"}.Validate())
+	assert.EqualError(t, Options{Sink: "file"}.Validate(), `unknown sink "file", must be one of "", "stdout" or "webhook"`)
+	assert.EqualError(t, Options{Sink: SinkStdout, WebhookURL: "https://audit.example.com"}.Validate(), "a webhook URL can only be set for the webhook sink")
+	assert.EqualError(t, Options{Sink: SinkWebhook}.Validate(), `webhook URL "" must be an absolute HTTP or HTTPS URL`)
+	assert.EqualError(t, Options{Sink: SinkWebhook, WebhookURL: "ftp://audit.example.com"}.Validate(), `webhook URL "ftp://audit.example.com" must be an absolute HTTP or HTTPS URL`)
+}
+
+func TestLogRecord(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	log := NewWriterLog(&out, logr.Discard())
+	log.clock = fakeclock.NewFakePassiveClock(now)
+	log.version = "v0.0.0-test"
+
+	log.Record(Entry{Action: ActionUpdate, Bundle: "bundle", Kind: "ConfigMap", Namespace: "default", Name: "bundle", OldHash: "old", NewHash: "new"})
+	log.WithCluster("remote").Record(Entry{Action: ActionDelete, Bundle: "bundle", Kind: "Secret", Namespace: "default", Name: "bundle", OldHash: "old"})
+
+	assert.Equal(t,
+		`{"audit":"target","time":"2026-01-01T00:00:00Z","action":"update","bundle":"bundle","kind":"ConfigMap","namespace":"default","name":"bundle","oldHash":"old","newHash":"new","version":"v0.0.0-test"}`+"\n"+
+			`{"audit":"target","time":"2026-01-01T00:00:00Z","action":"delete","bundle":"bundle","cluster":"remote","kind":"Secret","namespace":"default","name":"bundle","oldHash":"old","version":"v0.0.0-test"}`+"\n",
+		out.String())
+
+	// A nil Log records nothing.
+	var disabled *Log
+	disabled.WithCluster("remote").Record(Entry{Action: ActionCreate})
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []Entry
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var entry Entry
+		require.NoError(t, json.Unmarshal(body, &entry))
+		received = append(received, entry)
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	log, err := New(Options{Sink: SinkWebhook, WebhookURL: server.URL}, httpclient.Options{}, logr.Discard())
+	require.NoError(t, err)
+
+	log.Record(Entry{Action: ActionCreate, Bundle: "bundle", Kind: "ConfigMap", Namespace: "default", Name: "bundle", NewHash: "new"})
+	require.Len(t, received, 1)
+	assert.Equal(t, ActionCreate, received[0].Action)
+	assert.Equal(t, "new", received[0].NewHash)
+	assert.Equal(t, "target", received[0].Audit)
+
+	// Entries the webhook rejects are reported as failed.
+	status = http.StatusServiceUnavailable
+	write := newWebhookSink(server.Client(), server.URL)
+	assert.EqualError(t, write(context.Background(), []byte(`{}`)), "webhook responded with status 503 Service Unavailable")
+
+	// Auditing is disabled without a sink.
+	log, err = New(Options{}, httpclient.Options{}, logr.Discard())
+	require.NoError(t, err)
+	assert.Nil(t, log)
+}
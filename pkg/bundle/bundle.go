@@ -21,24 +21,33 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/csaupgrade"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
 	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
 	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/pkg/federation"
 	"github.com/cert-manager/trust-manager/pkg/fspkg"
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+	"github.com/cert-manager/trust-manager/pkg/util"
 )
 
 // Options hold options for the Bundle controller.
@@ -55,11 +64,172 @@ type Options struct {
 	// certificate package in a `Bundle` resource will cause that Bundle to error.
 	DefaultPackageLocation string
 
+	// DefaultPackageLayers are the locations on the filesystem of packages which are
+	// layered on top of the default package, in order, such as an addendum of internal
+	// CAs. Each layer may add certificates to, and remove certificates from, the layers
+	// below it. Requires DefaultPackageLocation to be set.
+	DefaultPackageLayers []string
+
+	// DefaultPackageVerificationKey is the location on the filesystem of a PEM-encoded
+	// Ed25519 public key. If set, the default package and each of its layers must have
+	// a detached signature made with its private key, or they fail to load.
+	DefaultPackageVerificationKey string
+
+	// DefaultPackageMaxAge is the age after which the default package is
+	// considered stale, and Bundles using default CAs are warned about it. Only
+	// packages which specify their build date can be stale. Zero disables the
+	// warning.
+	DefaultPackageMaxAge time.Duration
+
+	// TunableOptions are the options which may be changed while the
+	// controller is running, through Tunables.
+	TunableOptions
+
+	// Tunables, if set, holds the current TunableOptions, which are then
+	// used instead of those embedded in Options.
+	Tunables *Tunables
+
 	// SecretTargetsEnabled controls if secret targets are enabled in the Bundle API.
 	SecretTargetsEnabled bool
 
-	// FilterExpiredCerts controls if expired certificates are filtered from the bundle.
+	// PEMTrailingNewline controls if the PEM bundles written to targets end
+	// with a newline.
+	PEMTrailingNewline bool
+
+	// RequireCABasicConstraints controls if certificates which aren't CA
+	// certificates are filtered from the bundle. Bundles may override this.
+	RequireCABasicConstraints bool
+
+	// SyncSummaryConfigMaps controls if a summary of the last sync of each Bundle
+	// is written to a ConfigMap in the trust Namespace, in addition to an Event.
+	SyncSummaryConfigMaps bool
+
+	// EventAggregationWindow is the window in which repeated Warning Events for
+	// a Bundle with the same reason are aggregated into a single Event. Zero
+	// disables aggregation.
+	EventAggregationWindow time.Duration
+
+	// MaxEventsPerSecond caps the rate at which Events are emitted for Bundles.
+	// Zero means unlimited.
+	MaxEventsPerSecond float32
+
+	// TargetEvents controls if Events are recorded on targets as they are
+	// created, updated and deleted, so that changes can be traced from the
+	// Namespace of a target. They are capped by MaxEventsPerSecond
+	// separately from the Events of Bundles.
+	TargetEvents bool
+
+	// RequeueInterval is the interval at which Bundles are re-synced, even if
+	// no events for their sources or targets are received. Bundles may
+	// override it. Zero disables periodic re-syncs.
+	RequeueInterval time.Duration
+
+	// StatusUpdateInterval is the minimum interval between status updates of
+	// a Bundle which only report progress: its target counts, rollout and
+	// last sync time. Updates which change anything else are applied
+	// immediately. Zero disables throttling.
+	StatusUpdateInterval time.Duration
+
+	// ForceTargetApply, if true, applies every target once after startup,
+	// even if it already holds the current bundle. Afterwards, targets are
+	// only applied when they or their Bundle change.
+	ForceTargetApply bool
+
+	// RemoteClustersEnabled, if true, propagates Bundles to the remote
+	// clusters registered by Secrets in the trust Namespace labelled with
+	// trust.cert-manager.io/remote-cluster, syncing their targets to the
+	// Namespaces of each remote cluster as well.
+	RemoteClustersEnabled bool
+
+	// RolloutWorkloads, if true, rolls out Deployments and StatefulSets which
+	// mount a target when its bundle changes, by setting a checksum
+	// annotation on their pod templates.
+	RolloutWorkloads bool
+
+	// ConsumerReportInterval, if non-zero, is the interval at which the pods
+	// mounting the targets of each Bundle are counted and exported as a
+	// metric. Requires permission to list pods.
+	ConsumerReportInterval time.Duration
+
+	// SourceNamespaceSelectorsEnabled, if true, lets ConfigMap and Secret
+	// sources with a label selector select objects in the Namespaces matching
+	// their namespace selector, rather than only in the trust Namespace.
+	// Requires permission to read ConfigMaps and Secrets in these Namespaces.
+	SourceNamespaceSelectorsEnabled bool
+
+	// CertificateSourcesEnabled, if true, lets Certificates sources select
+	// cert-manager Certificates in the trust Namespace, which are watched so
+	// that Bundles follow them. Requires the cert-manager CRDs to be installed
+	// and permission to list and watch Certificates.
+	CertificateSourcesEnabled bool
+
+	// ClusterResourceNamespace is the Namespace cert-manager reads the Secrets
+	// of ClusterIssuers from, as set by its --cluster-resource-namespace flag.
+	// Defaults to the trust Namespace if empty. Requires permission to read
+	// Secrets in this Namespace.
+	ClusterResourceNamespace string
+
+	// WatchNamespaces, if set, restricts targets to the listed Namespaces.
+	// trust-manager then only needs permissions for ConfigMaps and Secrets in
+	// these Namespaces, rather than across the cluster.
+	WatchNamespaces []string
+
+	// SigningKeySecret is the name of the Secret in the trust Namespace which
+	// holds the Ed25519 key Bundles requesting a signature are signed with.
+	// Bundles can't be signed if empty.
+	SigningKeySecret string
+
+	// SigningKeySecretKey is the key in the signing key Secret which holds the
+	// PEM-encoded PKCS#8 private key.
+	SigningKeySecretKey string
+
+	// MaxBundleSizeBytes, if positive, is the maximum size of the PEM bundle
+	// built from the sources of a Bundle. Larger Bundles fail policy, and
+	// aren't synced to their targets.
+	MaxBundleSizeBytes int
+
+	// MaxCertificates, if positive, is the maximum number of certificates in
+	// the bundle built from the sources of a Bundle. Bundles with more fail
+	// policy, and aren't synced to their targets.
+	MaxCertificates int
+
+	// HTTPClient configures the HTTP clients which remote sources are fetched
+	// with, including the CAs servers are verified against and the client
+	// certificate presented to them.
+	HTTPClient httpclient.Options
+
+	// Audit configures the audit log, which records every change made to
+	// targets. Auditing is disabled if no sink is set.
+	Audit audit.Options
+
+	// SPIFFEFederationTokenFile is the path of a projected ServiceAccount
+	// token, which SPIFFE federation sources may present to their bundle
+	// endpoints.
+	SPIFFEFederationTokenFile string
+
+	// SourcePluginsDir is the directory holding the sockets of source
+	// plugins. Plugin sources are unavailable if it's empty.
+	SourcePluginsDir string
+}
+
+// TunableOptions holds the options of the Bundle controller which may be
+// changed while it is running.
+type TunableOptions struct {
+	// FilterExpiredCerts controls if expired certificates are filtered from the
+	// bundle. Bundles may override this.
 	FilterExpiredCerts bool
+
+	// TargetSyncConcurrency is the number of targets of a Bundle which are
+	// synced in parallel. Values below 1 sync targets one at a time.
+	TargetSyncConcurrency int
+
+	// ExcludeNamespaces holds glob patterns of Namespace names which targets
+	// are never written to, whatever the namespaceSelector of a Bundle.
+	ExcludeNamespaces []string
+
+	// ExcludeNamespaceSelector, if set, selects Namespaces which targets are
+	// never written to, whatever the namespaceSelector of a Bundle.
+	ExcludeNamespaceSelector labels.Selector
 }
 
 // bundle is a controller-runtime controller. Implements the actual controller
@@ -68,6 +238,10 @@ type bundle struct {
 	// a cache-backed Kubernetes client
 	client client.Client
 
+	// apiReader is an uncached reader, used to list the workloads which
+	// mount targets. client is used if nil.
+	apiReader client.Reader
+
 	// defaultPackage holds the loaded 'default' certificate package, if one was specified
 	// at startup.
 	defaultPackage *fspkg.Package
@@ -82,6 +256,25 @@ type bundle struct {
 	Options
 
 	targetReconciler *target.Reconciler
+
+	// encodingCache holds the additional formats encoded for Bundles, so
+	// that they aren't encoded again on every reconcile.
+	encodingCache *target.EncodingCache
+	// federation fetches and caches the bundles of SPIFFE federation
+	// sources.
+	federation *federation.Fetcher
+	// cloud fetches and caches the certificates of cloud sources, such as
+	// AWS Private CAs.
+	cloud *cloudsource.Fetcher
+	// contentTracker remembers the certificates last synced for Bundles, so
+	// that changes to them can be described.
+	contentTracker *contentTracker
+	// remoteClusters holds the clients of the remote clusters which Bundles
+	// are propagated to. It is nil unless propagation is enabled.
+	remoteClusters *remoteClusters
+	// statusThrottle debounces the status updates of Bundles. If nil, every
+	// status update is applied.
+	statusThrottle *statusThrottle
 }
 
 // Reconcile is the top level function for reconciling over synced Bundles.
@@ -89,22 +282,74 @@ type bundle struct {
 // related resource event to that bundle occurs.
 func (b *bundle) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	result, statusPatch, resultErr := b.reconcileBundle(ctx, req)
-	if statusPatch != nil {
-		con, patch, err := ssa_client.GenerateBundleStatusPatch(req.Name, statusPatch)
-		if err != nil {
-			err = fmt.Errorf("failed to generate bundle status patch: %w", err)
-			return ctrl.Result{}, utilerrors.NewAggregate([]error{resultErr, err})
-		}
+	if statusPatch == nil {
+		return result, resultErr
+	}
 
-		if err := b.client.Status().Patch(ctx, con, patch, ssa_client.FieldManager, client.ForceOwnership); err != nil {
-			err = fmt.Errorf("failed to apply bundle status patch: %w", err)
-			return ctrl.Result{}, utilerrors.NewAggregate([]error{resultErr, err})
+	// Status updates which only report progress are deferred, and the
+	// Bundle requeued to apply them once they're due.
+	if wait := b.statusThrottle.admit(req.Name, statusPatch); wait > 0 {
+		if result.RequeueAfter == 0 || wait < result.RequeueAfter {
+			result.RequeueAfter = wait
 		}
+		return result, resultErr
+	}
+
+	if err := b.applyBundleStatus(ctx, req.Name, statusPatch); err != nil {
+		b.statusThrottle.forget(req.Name)
+		return ctrl.Result{}, utilerrors.NewAggregate([]error{resultErr, err})
 	}
+	b.statusThrottle.record(req.Name, statusPatch)
 
 	return result, resultErr
 }
 
+// applyBundleStatus applies the status of the Bundle. The apply is forced,
+// so it doesn't conflict with other field managers, but it's re-applied if
+// the API server still reports a conflict.
+func (b *bundle) applyBundleStatus(ctx context.Context, name string, status *trustapi.BundleStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		con, patch, err := ssa_client.GenerateBundleStatusPatch(name, status)
+		if err != nil {
+			return fmt.Errorf("failed to generate bundle status patch: %w", err)
+		}
+
+		if err := b.client.Status().Patch(ctx, con, patch, ssa_client.StatusFieldManager, client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to apply bundle status patch: %w", err)
+		}
+		return nil
+	})
+}
+
+// migrateBundleStatus migrates the ownership of the Bundle status fields to
+// the status field manager, retrying with the latest Bundle on conflicts.
+// Failing to migrate doesn't fail the reconcile, as the status is applied
+// with forced ownership either way; only fields which were set by older
+// versions of trust-manager may then be left behind.
+func (b *bundle) migrateBundleStatus(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		didMigrate, err := ssa_client.MigrateBundleStatusToApply(ctx, b.client, bundle)
+		if apierrors.IsConflict(err) {
+			// Retry with the latest managed fields.
+			if getErr := b.client.Get(ctx, client.ObjectKeyFromObject(bundle), bundle); getErr != nil {
+				return getErr
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if didMigrate {
+			log.V(2).Info("migrated bundle status to the status field manager")
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "failed to migrate bundle status, re-applying it")
+	}
+}
+
 func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result ctrl.Result, statusPatch *trustapi.BundleStatus, returnedErr error) {
 	log := b.Log.WithValues("bundle", req.NamespacedName.Name)
 	log.V(2).Info("syncing bundle")
@@ -113,6 +358,11 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 	err := b.client.Get(ctx, req.NamespacedName, &bundle)
 	if apierrors.IsNotFound(err) {
 		log.V(2).Info("bundle no longer exists, ignoring")
+		b.encodingCache.Forget(req.NamespacedName.Name)
+		b.contentTracker.forget(req.NamespacedName.Name)
+		if events, ok := b.recorder.(*eventAggregator); ok {
+			events.forget(req.NamespacedName.Name)
+		}
 		return ctrl.Result{}, nil, nil
 	}
 
@@ -122,20 +372,123 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 	}
 
 	// MIGRATION: If we are upgrading from a version of trust-manager that did use Update to set
-	// the Bundle status, we need to ensure that we do remove the old status fields in case we apply.
-	if didMigrate, err := ssa_client.MigrateToApply(ctx, b.client, &bundle, csaupgrade.Subresource("status")); err != nil {
-		log.Error(err, "failed to migrate bundle status")
-		return ctrl.Result{}, nil, fmt.Errorf("failed to migrate bundle status: %w", err)
-	} else if didMigrate {
-		log.V(2).Info("migrated bundle status from CSA to SSA")
-	}
+	// the Bundle status, or applied it with the target field manager, we need to ensure that we
+	// do remove the old status fields in case we apply.
+	b.migrateBundleStatus(ctx, log, &bundle)
 
 	// Initialize patch with current status field values, except conditions.
 	// This is done to ensure information is not lost in patch if exiting early.
 	statusPatch = &trustapi.BundleStatus{
+		ObservedGeneration:      bundle.Generation,
 		DefaultCAPackageVersion: bundle.Status.DefaultCAPackageVersion,
+		TargetCount:             bundle.Status.TargetCount,
+		SyncedTargetCount:       bundle.Status.SyncedTargetCount,
+		LastSyncTime:            bundle.Status.LastSyncTime,
+		FilteredCertificates:    bundle.Status.FilteredCertificates,
+		OptedOutNamespaces:      bundle.Status.OptedOutNamespaces,
+		Rollout:                 bundle.Status.Rollout,
+		History:                 bundle.Status.History,
+		LastContentChange:       bundle.Status.LastContentChange,
+		RemoteClusters:          bundle.Status.RemoteClusters,
+		KeyTransitions:          bundle.Status.KeyTransitions,
+	}
+
+	if err := b.reconcileRemoteTargetsFinalizer(ctx, log, &bundle); err != nil {
+		log.Error(err, "failed to reconcile remote targets finalizer")
+		return ctrl.Result{}, nil, err
+	}
+
+	if remaining, err := b.reconcileDeleteTargetsFinalizer(ctx, log, &bundle, statusPatch); err != nil {
+		log.Error(err, "failed to delete bundle targets")
+		return ctrl.Result{}, nil, err
+	} else if remaining > 0 {
+		return ctrl.Result{RequeueAfter: deleteTargetsRequeueInterval}, statusPatch, nil
+	}
+
+	if deleting, err := b.reconcileDeletionPolicy(ctx, log, &bundle); err != nil {
+		log.Error(err, "failed to apply bundle deletion policy")
+		return ctrl.Result{}, nil, err
+	} else if deleting {
+		return ctrl.Result{}, nil, nil
+	}
+
+	// Re-sync the Bundle periodically, unless it's already being requeued.
+	defer func() {
+		if returnedErr == nil && result.IsZero() {
+			result.RequeueAfter = b.refreshInterval(&bundle)
+		}
+	}()
+
+	// A resync which was handled is remembered on all return paths, so that
+	// it isn't handled again.
+	resyncedConditionChanged := retainResyncedCondition(&bundle, statusPatch)
+
+	if bundleIsPaused(&bundle) {
+		log.V(2).Info("bundle is paused, skipping sync of targets")
+
+		// Retain the last known conditions while the Bundle is paused, as the
+		// status is applied in full. The Resynced condition has been retained
+		// above, if it's still current.
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type != trustapi.BundleConditionResynced && cond.Type != trustapi.BundleConditionPaused {
+				statusPatch.Conditions = append(statusPatch.Conditions, cond)
+			}
+		}
+
+		pausedCondition := trustapi.BundleCondition{
+			Type:               trustapi.BundleConditionPaused,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Paused",
+			Message:            "Bundle is paused; targets are not being synced",
+			ObservedGeneration: bundle.Generation,
+		}
+
+		if bundleHasCondition(bundle.Status.Conditions, pausedCondition) && bundleStatusObserved(&bundle) {
+			return ctrl.Result{}, nil, nil
+		}
+
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			pausedCondition,
+		)
+
+		b.recorder.Eventf(&bundle, corev1.EventTypeNormal, "Paused", "Bundle is paused; targets are not being synced")
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// Detect if we have a bundle with sources in other Namespaces but the
+	// feature is disabled.
+	if !b.Options.SourceNamespaceSelectorsEnabled && len(sourceNamespaceSelectors(&bundle)) > 0 {
+		log.Error(errSourceNamespaceSelectorsDisabled, "bundle has sources with a namespace selector but the feature is disabled")
+		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SourceNamespaceSelectorsDisabled", "Bundle has sources with a namespace selector but the feature is disabled")
+
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "SourceNamespaceSelectorsDisabled",
+				Message:            "Bundle has sources with a namespace selector but the feature is disabled",
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// The additional formats of each target are encoded once the sources are
+	// resolved, as they may differ between targets. Bundles which are rolled
+	// back are built from their snapshot instead of their sources.
+	var resolvedBundle bundleData
+	rollbackTo := bundleRollbackTo(&bundle)
+	if rollbackTo != "" {
+		resolvedBundle, err = b.buildSnapshotBundle(ctx, &bundle, rollbackTo)
+	} else {
+		resolvedBundle, err = b.buildSourceBundle(ctx, bundle.Spec.Sources, nil, b.requireCABasicConstraints(&bundle), b.expiredCertificatePolicy(&bundle), deduplicationStrategy(&bundle), invalidCertificatePolicy(&bundle))
 	}
-	resolvedBundle, err := b.buildSourceBundle(ctx, bundle.Spec.Sources, bundle.Spec.Target.AdditionalFormats)
 
 	// If any source is not found, update the Bundle status to an unready state.
 	if errors.As(err, &notFoundError{}) {
@@ -157,14 +510,138 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 		return ctrl.Result{}, statusPatch, nil
 	}
 
+	// If a source contains an expired certificate and the Bundle doesn't
+	// permit them, update the Bundle status to an unready state.
+	if errors.As(err, &expiredCertificateError{}) {
+		log.Error(err, "bundle source contains an expired certificate")
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ExpiredCertificate",
+				Message:            "Bundle source contains an expired certificate: " + err.Error(),
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "ExpiredCertificate", "Bundle source contains an expired certificate: %s", err)
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// If a source holds a private key, block the Bundle rather than risk
+	// distributing the key to every target Namespace.
+	if errors.As(err, &privateKeyError{}) {
+		log.Error(err, "bundle source holds a private key; refusing to sync the bundle")
+		b.setBlockedCondition(&bundle, statusPatch, "PrivateKeyInSource", "Refusing to sync a bundle whose sources hold a private key: "+err.Error())
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// If a source needs a cloud source provider which isn't compiled into
+	// this build of trust-manager, update the Bundle status to an unready
+	// state. Retrying won't help until trust-manager is replaced.
+	if errors.As(err, &cloudsource.UnavailableError{}) {
+		log.Error(err, "cloud source provider is not available")
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "SourceUnavailable",
+				Message:            "Bundle source is not available: " + err.Error(),
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SourceUnavailable", "Bundle source is not available: %s", err)
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// If the API of a cloud source failed and no data was fetched from it
+	// before, update the Bundle status to an unready state, and retry once
+	// the source is due to be fetched again.
+	if errors.As(err, &cloudSourceError{}) {
+		log.Error(err, "failed to fetch cloud source")
+		b.setCloudSourcesFailingCondition(&bundle, statusPatch, []string{err.Error()})
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "CloudAPIError",
+				Message:            "Failed to fetch cloud source: " + err.Error(),
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		return ctrl.Result{RequeueAfter: b.refreshInterval(&bundle)}, statusPatch, nil
+	}
+
 	if err != nil {
 		log.Error(err, "failed to build source bundle")
 		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SourceBuildError", "Failed to build bundle sources: %s", err)
 		return ctrl.Result{}, nil, fmt.Errorf("failed to build bundle source: %w", err)
 	}
 
+	// Bundles exceeding the size and certificate count policies aren't synced,
+	// to protect etcd and the consumers of their targets.
+	if err := b.checkPolicy(resolvedBundle); err != nil {
+		log.Error(err, "bundle fails policy")
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "FailedPolicy",
+				Message:            "Bundle fails policy: " + err.Error(),
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "FailedPolicy", "Bundle fails policy: %s", err)
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// Bundles pinned to a hash are only synced while they resolve to the
+	// pinned bundle, as a safety lock against unexpected changes.
+	if err := checkPinnedHash(&bundle, snapshotHash(resolvedBundle.Data.Data)); err != nil {
+		log.Error(err, "bundle differs from the pinned hash")
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             "PinnedHashMismatch",
+				Message:            "Bundle doesn't match its pinned hash: " + err.Error(),
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "PinnedHashMismatch", "Bundle doesn't match its pinned hash: %s", err)
+
+		return ctrl.Result{}, statusPatch, nil
+	}
+
+	// Report any optional sources which were skipped. The condition is added to
+	// the status patch here so that it's retained on all later return paths.
+	skippedSourcesChanged := b.setSkippedSourcesCondition(&bundle, statusPatch, resolvedBundle.skippedSources)
+	filteredCertificatesChanged := b.setFilteredCertificatesStatus(&bundle, statusPatch, resolvedBundle.filtered)
+	invalidCertificatesChanged := b.setInvalidCertificatesSkippedCondition(&bundle, statusPatch, resolvedBundle.invalidBlocks)
+	inconsistentCertificatesChanged := b.setInconsistentCertificatesCondition(&bundle, statusPatch, findCertificateInconsistencies(resolvedBundle.pool))
+	defaultPackageStaleChanged := b.setDefaultPackageStaleCondition(&bundle, statusPatch)
+	cloudSourcesFailingChanged := b.setCloudSourcesFailingCondition(&bundle, statusPatch, resolvedBundle.cloudSourceFailures)
+
 	// Detect if we have a bundle with Secret targets but the feature is disabled.
-	if !b.Options.SecretTargetsEnabled && bundle.Spec.Target.Secret != nil {
+	if !b.Options.SecretTargetsEnabled && anyTarget(&bundle, func(t trustapi.BundleTarget) bool { return t.Secret != nil }) {
 
 		log.Error(err, "bundle has Secret targets but the feature is disabled")
 		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SecretTargetsDisabled", "Bundle has Secret targets but the feature is disabled")
@@ -184,24 +661,129 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 		return ctrl.Result{}, statusPatch, nil
 	}
 
-	targetResources := map[target.Resource]bool{}
+	if anyTarget(&bundle, func(t trustapi.BundleTarget) bool { return t.Signature != nil }) {
+		if err := b.sign(ctx, &resolvedBundle.Data); err != nil {
+			log.Error(err, "failed to sign bundle")
+			b.setBundleCondition(
+				bundle.Status.Conditions,
+				&statusPatch.Conditions,
+				trustapi.BundleCondition{
+					Type:               trustapi.BundleConditionSynced,
+					Status:             metav1.ConditionFalse,
+					Reason:             "SigningFailed",
+					Message:            "Failed to sign bundle: " + err.Error(),
+					ObservedGeneration: bundle.Generation,
+				},
+			)
 
-	namespaceSelector, err := b.bundleTargetNamespaceSelector(&bundle)
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SigningFailed", "Failed to sign bundle: %s", err)
+
+			return ctrl.Result{}, statusPatch, nil
+		}
+	}
+
+	if anyTarget(&bundle, func(t trustapi.BundleTarget) bool { return t.Manifest != nil }) {
+		resolvedBundle.Manifest, err = buildManifest(resolvedBundle.pool, resolvedBundle.provenance)
+		if err != nil {
+			log.Error(err, "failed to build bundle manifest")
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SourceBuildError", "Failed to build bundle manifest: %s", err)
+			return ctrl.Result{}, nil, fmt.Errorf("failed to build bundle manifest: %w", err)
+		}
+	}
+
+	if anyTarget(&bundle, func(t trustapi.BundleTarget) bool { return len(t.UsageKeys) > 0 }) {
+		resolvedBundle.UsageData = buildUsageData(resolvedBundle.pool, resolvedBundle.provenance, b.PEMTrailingNewline)
+	}
+
+	// A pending resync applies all targets, even those which are up to date.
+	resync, resyncPending := pendingResync(&bundle)
+	resolvedBundle.Data.ForceApply = resyncPending
+
+	// The previous keys of targets whose key changed are still written to
+	// until their transition ends.
+	keyTransitions, keyTransitionRequeue, err := b.reconcileKeyTransitions(ctx, &bundle)
 	if err != nil {
-		b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "NamespaceSelectorError", "Failed to build namespace match labels selector: %s", err)
-		return ctrl.Result{}, nil, fmt.Errorf("failed to build NamespaceSelector: %w", err)
+		log.Error(err, "failed to reconcile key transitions")
+		return ctrl.Result{}, nil, err
+	}
+	keyTransitionsChanged := !apiequality.Semantic.DeepEqual(bundle.Status.KeyTransitions, keyTransitions)
+	statusPatch.KeyTransitions = keyTransitions
+
+	targetResources := map[target.Resource]bool{}
+	resolvedTargets := map[target.Resource]*resolvedTarget{}
+
+	var targets []*resolvedTarget
+	defer b.encodingCache.Commit(bundle.Name)
+	for _, targetBundle := range targetBundles(&bundle) {
+		withPreviousKeys(targetBundle, keyTransitions)
+		t := &resolvedTarget{bundle: targetBundle, data: resolvedBundle.Data}
+		if err := b.encodingCache.Populate(&t.data, bundle.Name, resolvedBundle.pool, targetBundle.Spec.Target.AdditionalFormats); err != nil {
+			log.Error(err, "failed to encode additional formats")
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "SourceBuildError", "Failed to build bundle sources: %s", err)
+			return ctrl.Result{}, nil, fmt.Errorf("failed to build bundle source: %w", err)
+		}
+
+		t.namespaceSelector, err = b.bundleTargetNamespaceSelector(targetBundle)
+		if err != nil {
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "NamespaceSelectorError", "Failed to build namespace match labels selector: %s", err)
+			return ctrl.Result{}, nil, fmt.Errorf("failed to build NamespaceSelector: %w", err)
+		}
+
+		if err := t.resolveKeyOverrides(); err != nil {
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "NamespaceSelectorError", "Failed to build key override namespace selector: %s", err)
+			return ctrl.Result{}, nil, err
+		}
+
+		targets = append(targets, t)
+	}
+
+	// Targets can't exceed the size limit of ConfigMaps and Secrets.
+	for _, t := range targets {
+		if err := target.ValidateSize(t.bundle, t.data); err != nil {
+			log.Error(err, "bundle is too large for its targets")
+			b.setBundleCondition(
+				bundle.Status.Conditions,
+				&statusPatch.Conditions,
+				trustapi.BundleCondition{
+					Type:               trustapi.BundleConditionSynced,
+					Status:             metav1.ConditionFalse,
+					Reason:             "TargetTooLarge",
+					Message:            "Bundle is too large to be synced: " + err.Error(),
+					ObservedGeneration: bundle.Generation,
+				},
+			)
+
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "TargetTooLarge", "Bundle is too large to be synced: %s", err)
+
+			return ctrl.Result{}, statusPatch, nil
+		}
 	}
 
-	// Find all desired targetResources.
-	{
+	// Immutable target Secrets are named after the data they hold.
+	secretTargetName := bundle.Name
+	for _, t := range targets {
+		if secret := t.bundle.Spec.Target.Secret; secret != nil && secret.Immutable {
+			secretTargetName = target.ImmutableSecretName(t.bundle, t.data)
+		}
+	}
+
+	// Find all desired targetResources. Targets whose turn in a progressive
+	// rollout hasn't come yet are left as they are.
+	rollout := b.newRolloutPlan(&bundle, target.TrustBundleHash([]byte(resolvedBundle.Data.Data), targetFormats(targets)...))
+	deferredResources := sets.New[target.Resource]()
+	optedOutNamespaces := sets.New[string]()
+	for _, t := range targets {
+		bundleTarget := t.bundle.Spec.Target
+
 		var namespaceList corev1.NamespaceList
 		if err := b.client.List(ctx, &namespaceList, &client.ListOptions{
-			LabelSelector: namespaceSelector,
+			LabelSelector: t.namespaceSelector,
 		}); err != nil {
 			log.Error(err, "failed to list namespaces")
 			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "NamespaceListError", "Failed to list namespaces: %s", err)
 			return ctrl.Result{}, nil, fmt.Errorf("failed to list Namespaces: %w", err)
 		}
+		var namespaces []corev1.Namespace
 		for _, namespace := range namespaceList.Items {
 			namespaceLog := log.WithValues("namespace", namespace.Name)
 
@@ -211,16 +793,50 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 				continue
 			}
 
-			namespacedName := types.NamespacedName{
-				Name:      bundle.Name,
-				Namespace: namespace.Name,
+			// In namespaced mode, trust-manager has no access to targets
+			// outside the watched Namespaces.
+			if !b.namespaceWatched(namespace.Name) {
+				namespaceLog.V(2).Info("skipping sync for namespace as it is not watched")
+				continue
 			}
 
-			if bundle.Spec.Target.Secret != nil {
-				targetResources[target.Resource{Kind: target.KindSecret, NamespacedName: namespacedName}] = true
+			// Don't reconcile target for Namespaces excluded by the operator,
+			// whatever the Bundle selects.
+			if b.namespaceExcluded(&namespace) {
+				namespaceLog.V(2).Info("skipping sync for namespace as it is excluded")
+				continue
 			}
-			if bundle.Spec.Target.ConfigMap != nil {
-				targetResources[target.Resource{Kind: target.KindConfigMap, NamespacedName: namespacedName}] = true
+
+			// Namespaces may opt out of all targets. Their existing targets
+			// are removed below, as they are no longer desired.
+			if namespaceOptedOut(&namespace) {
+				namespaceLog.V(2).Info("skipping sync for namespace as it opted out")
+				optedOutNamespaces.Insert(namespace.Name)
+				continue
+			}
+
+			namespaces = append(namespaces, namespace)
+		}
+
+		// A changed bundle may only be rolled out to some Namespaces so far.
+		deferredNamespaces, err := rollout.deferred(bundleTarget.RolloutStrategy, namespaces)
+		if err != nil {
+			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, "NamespaceSelectorError", "Failed to build canary namespace selector: %s", err)
+			return ctrl.Result{}, nil, err
+		}
+
+		for _, namespace := range namespaces {
+			resources := namespaceTargetResources(bundleTarget, bundle.Name, secretTargetName, namespace.Name)
+			if deferredNamespaces.Has(namespace.Name) {
+				log.V(2).Info("deferring sync for namespace until its turn in the rollout", "namespace", namespace.Name)
+				deferredResources.Insert(resources...)
+				continue
+			}
+
+			namespaceTarget := t.forNamespace(&namespace)
+			for _, resource := range resources {
+				targetResources[resource] = true
+				resolvedTargets[resource] = namespaceTarget
 			}
 		}
 	}
@@ -264,58 +880,256 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 				continue
 			}
 
+			if deferredResources.Has(key) {
+				targetLog.V(2).Info("skipping sync for target as it is deferred by the rollout")
+				continue
+			}
+
 			// Don't reconcile target for targets that are being deleted.
 			if t.GetDeletionTimestamp() != nil {
 				targetLog.V(2).WithValues("deletionTimestamp", t.GetDeletionTimestamp()).Info("skipping sync for target as it is being deleted")
 				continue
 			}
 
-			if !metav1.IsControlledBy(&t, &bundle) /* #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010 */ {
+			if !targetControlledBy(&t, &bundle, kind) /* #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010 */ {
 				targetLog.V(2).Info("skipping sync for target as it is not controlled by bundle")
 				continue
 			}
 
+			// Keep the immutable Secret which the Bundle still points consumers to.
+			// It's removed once the annotation has moved to the new Secret.
+			if kind == target.KindSecret && t.Name == bundle.GetAnnotations()[trustapi.BundleSecretTargetAnnotationKey] {
+				targetLog.V(2).Info("skipping removal of target as it is still referenced by the bundle")
+				continue
+			}
+
+			namespace, err := b.getNamespace(ctx, t.Namespace)
+			if err != nil {
+				log.Error(err, "failed to get namespace", "namespace", t.Namespace)
+				return ctrl.Result{}, nil, fmt.Errorf("failed to get Namespace %q: %w", t.Namespace, err)
+			}
+
+			// Targets in Namespaces which are going away are removed along
+			// with them; patching them would only fail.
+			if namespaceIsGone(namespace) {
+				targetLog.V(2).Info("skipping removal of target as its namespace is terminating")
+				continue
+			}
+
+			// trust-manager never writes to excluded Namespaces.
+			if b.namespaceExcluded(namespace) {
+				targetLog.V(2).Info("skipping removal of target as its namespace is excluded")
+				continue
+			}
+
 			targetResources[key] = false
 		}
 	}
 
-	var needsUpdate bool
+	// Targets which other Bundles write to as well are synced by only one of
+	// the Bundles, rather than having them overwrite each other.
+	conflicts, err := b.targetConflicts(ctx, &bundle)
+	if err != nil {
+		log.Error(err, "failed to check for target conflicts")
+		return ctrl.Result{}, nil, err
+	}
+	for t := range targetResources {
+		if otherBundle, ok := yieldsTarget(conflicts, t); ok {
+			log.V(2).Info("skipping sync of target as another bundle writes to it", "target", t, "otherBundle", otherBundle)
+			delete(targetResources, t)
+		}
+	}
+	conflictsChanged := b.setConflictCondition(&bundle, statusPatch, conflicts)
+	optedOutNamespacesChanged := setOptedOutNamespacesStatus(&bundle, statusPatch, optedOutNamespaces)
+
+	var (
+		needsUpdate   bool
+		failedTarget  *target.Resource
+		failedErr     error
+		fanOutStart   = b.clock.Now()
+		namespaceSync = map[string]namespaceSyncResult{}
+		failures      = targetFailures{}
 
-	for t, shouldExist := range targetResources {
+		targetCount, syncedTargetCount int32
+	)
+
+	// Targets are synced in parallel, up to the configured concurrency. The
+	// results are gathered under the lock.
+	var mu sync.Mutex
+	syncTargets(b.tunables().TargetSyncConcurrency, targetResources, func(t target.Resource, shouldExist bool) {
 		targetLog := log.WithValues("target", t)
-		synced, err := b.targetReconciler.Sync(ctx, t, &bundle, resolvedBundle.Data, targetLog, shouldExist)
+
+		// Targets which should no longer exist are cleaned up on behalf of
+		// the whole Bundle.
+		syncBundle, syncData := &bundle, resolvedBundle.Data
+		if resolved, ok := resolvedTargets[t]; ok {
+			syncBundle, syncData = resolved.bundle, resolved.data
+		} else if ownership := targetOwnership(&bundle, t.Kind); ownership != bundle.Spec.Target.Ownership {
+			// Removed targets keep the ownership of the target which wrote them.
+			syncBundle = bundle.DeepCopy()
+			syncBundle.Spec.Target.Ownership = ownership
+		}
+
+		synced, err := b.targetReconciler.Sync(ctx, t, syncBundle, syncData, targetLog, shouldExist)
+
+		// Workloads mounting a target which changed are rolled out to pick
+		// up the new bundle. Failures are logged, and don't fail the sync.
+		if err == nil && synced && shouldExist && b.Options.RolloutWorkloads {
+			if err := b.rolloutWorkloads(ctx, targetLog, t); err != nil {
+				targetLog.Error(err, "failed to roll out workloads mounting target")
+			}
+		}
+
+		// The Namespace started terminating after it was listed. Its targets
+		// are removed along with it, and the Bundle is reconciled again when
+		// the Namespace is deleted or recreated.
+		if apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+			targetLog.V(2).Info("skipping sync for target as its namespace is terminating")
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result := namespaceSync[t.Namespace]
+
+		if shouldExist {
+			targetCount++
+		}
+
+		if err == nil && shouldExist {
+			syncedTargetCount++
+		}
 		if err != nil {
 			targetLog.Error(err, "failed sync bundle to target namespace")
-			b.recorder.Eventf(&bundle, corev1.EventTypeWarning, fmt.Sprintf("Sync%sTargetFailed", t.Kind), "Failed to sync target %s in Namespace %q: %s", t.Kind, t.Namespace, err)
-
-			b.setBundleCondition(
-				bundle.Status.Conditions,
-				&statusPatch.Conditions,
-				trustapi.BundleCondition{
-					Type:               trustapi.BundleConditionSynced,
-					Status:             metav1.ConditionFalse,
-					Reason:             fmt.Sprintf("Sync%sTargetFailed", t.Kind),
-					Message:            fmt.Sprintf("Failed to sync bundle %s to namespace %q: %s", t.Kind, t.Namespace, err),
-					ObservedGeneration: bundle.Generation,
-				},
-			)
+			failures.add(t, err)
 
-			return ctrl.Result{Requeue: true}, statusPatch, nil
+			// Carry on syncing the remaining targets, so the summary covers the whole fan-out.
+			if failedTarget == nil {
+				failedTarget, failedErr = &t, err
+			}
+			result.failed = true
 		}
 
 		if synced {
 			// We need to update if any target is synced.
 			needsUpdate = true
+			result.changed = true
+		}
+
+		namespaceSync[t.Namespace] = result
+	})
+
+	// Emit a single Event per kind of target failure, rather than one per target.
+	failures.record(b.recorder, &bundle)
+
+	summary := newSyncSummary(fanOutStart, b.clock.Now(), target.TrustBundleHash([]byte(resolvedBundle.Data.Data), targetFormats(targets)...), namespaceSync)
+	publishSummary := func() {
+		// Only fan-outs which changed or failed to change something are worth a record.
+		if summary.namespacesChanged == 0 && summary.namespacesFailed == 0 {
+			return
+		}
+		if err := b.publishSyncSummary(ctx, &bundle, summary); err != nil {
+			log.Error(err, "failed to publish sync summary")
+		}
+	}
+
+	if statusPatch.TargetCount != targetCount || statusPatch.SyncedTargetCount != syncedTargetCount {
+		statusPatch.TargetCount, statusPatch.SyncedTargetCount = targetCount, syncedTargetCount
+		needsUpdate = true
+	}
+
+	if rolloutStatus := rollout.rolloutStatus(); !apiequality.Semantic.DeepEqual(bundle.Status.Rollout, rolloutStatus) {
+		statusPatch.Rollout = rolloutStatus
+		needsUpdate = true
+	}
+
+	if failedTarget != nil {
+		b.setBundleCondition(
+			bundle.Status.Conditions,
+			&statusPatch.Conditions,
+			trustapi.BundleCondition{
+				Type:               trustapi.BundleConditionSynced,
+				Status:             metav1.ConditionFalse,
+				Reason:             fmt.Sprintf("Sync%sTargetFailed", failedTarget.Kind),
+				Message:            fmt.Sprintf("Failed to sync bundle %s to namespace %q: %s", failedTarget.Kind, failedTarget.Namespace, failedErr),
+				ObservedGeneration: bundle.Generation,
+			},
+		)
+
+		publishSummary()
+
+		return ctrl.Result{Requeue: true}, statusPatch, nil
+	}
+
+	// Namespaces deferred by the rollout don't have the current Secret yet.
+	if err := b.reconcileSecretTargetAnnotation(ctx, &bundle, secretTargetName, deferredResources.Len() == 0); err != nil {
+		log.Error(err, "failed to update secret target annotation")
+		return ctrl.Result{}, nil, err
+	}
+
+	// Bundles are propagated to remote clusters once they are synced locally.
+	if b.remoteClusters != nil {
+		remoteStatus, err := b.propagateToRemoteClusters(ctx, log, &bundle, targets, secretTargetName, resolvedBundle.Data)
+		if err != nil {
+			log.Error(err, "failed to propagate bundle to remote clusters")
+			return ctrl.Result{}, nil, err
+		}
+		if !apiequality.Semantic.DeepEqual(bundle.Status.RemoteClusters, remoteStatus) {
+			statusPatch.RemoteClusters = remoteStatus
+			needsUpdate = true
+		}
+	} else if bundle.Status.RemoteClusters != nil {
+		statusPatch.RemoteClusters = nil
+		needsUpdate = true
+	}
+
+	if b.setContentChangeStatus(&bundle, statusPatch, resolvedBundle.pool, snapshotHash(resolvedBundle.Data.Data)) {
+		needsUpdate = true
+	}
+
+	if resyncPending {
+		b.setResyncedCondition(&bundle, statusPatch, resync)
+		needsUpdate = true
+	}
+
+	// Snapshots are only recorded of bundles built from the sources.
+	if rollbackTo == "" {
+		history, err := b.recordSnapshot(ctx, &bundle, resolvedBundle.Data)
+		if err != nil {
+			log.Error(err, "failed to record bundle snapshot")
+			return ctrl.Result{}, nil, err
+		}
+		if !apiequality.Semantic.DeepEqual(bundle.Status.History, history) {
+			statusPatch.History = history
+			needsUpdate = true
 		}
 	}
 
-	if b.setBundleStatusDefaultCAVersion(statusPatch, resolvedBundle.defaultCAPackageStringID) {
+	if b.setBundleStatusDefaultCAVersion(statusPatch, resolvedBundle.PackageVersion) {
 		needsUpdate = true
 	}
 
+	if skippedSourcesChanged || filteredCertificatesChanged || invalidCertificatesChanged || inconsistentCertificatesChanged || defaultPackageStaleChanged || cloudSourcesFailingChanged || conflictsChanged || optedOutNamespacesChanged || resyncedConditionChanged || keyTransitionsChanged {
+		needsUpdate = true
+	}
+
+	// Remove the Paused condition of a Bundle which has been unpaused, and the
+	// Blocked condition of a Bundle which is no longer blocked.
+	for _, cond := range bundle.Status.Conditions {
+		if cond.Type == trustapi.BundleConditionPaused || cond.Type == trustapi.BundleConditionBlocked {
+			needsUpdate = true
+		}
+	}
+
 	message := "Successfully synced Bundle to all namespaces"
-	if !namespaceSelector.Empty() {
-		message = fmt.Sprintf("Successfully synced Bundle to namespaces that match this label selector: %s", namespaceSelector)
+	if len(targets) > 1 {
+		message = fmt.Sprintf("Successfully synced Bundle to its %d targets", len(targets))
+	} else if len(targets) == 1 && !targets[0].namespaceSelector.Empty() {
+		message = fmt.Sprintf("Successfully synced Bundle to namespaces that match this label selector: %s", targets[0].namespaceSelector)
+	}
+	if rollbackTo != "" {
+		message = fmt.Sprintf("%s; rolled back to snapshot %s", message, rollbackTo)
 	}
 
 	syncedCondition := trustapi.BundleCondition{
@@ -326,12 +1140,21 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 		ObservedGeneration: bundle.Generation,
 	}
 
-	if !needsUpdate && bundleHasCondition(bundle.Status.Conditions, syncedCondition) {
-		return ctrl.Result{}, nil, nil
+	// Bundles being rolled out progressively are synced again when the next
+	// Namespaces are due, and Bundles in a key transition when it ends.
+	result = ctrl.Result{RequeueAfter: rollout.requeueAfter}
+	if keyTransitionRequeue > 0 && (result.RequeueAfter == 0 || keyTransitionRequeue < result.RequeueAfter) {
+		result.RequeueAfter = keyTransitionRequeue
+	}
+
+	if !needsUpdate && bundleHasCondition(bundle.Status.Conditions, syncedCondition) && bundleStatusObserved(&bundle) {
+		return result, nil, nil
 	}
 
 	log.V(2).Info("successfully synced bundle")
 
+	statusPatch.LastSyncTime = &metav1.Time{Time: b.clock.Now()}
+
 	b.setBundleCondition(
 		bundle.Status.Conditions,
 		&statusPatch.Conditions,
@@ -340,7 +1163,224 @@ func (b *bundle) reconcileBundle(ctx context.Context, req ctrl.Request) (result
 
 	b.recorder.Eventf(&bundle, corev1.EventTypeNormal, "Synced", message)
 
-	return ctrl.Result{}, statusPatch, nil
+	publishSummary()
+
+	return result, statusPatch, nil
+}
+
+// namespaceTargetResources returns the resources which the Bundle target
+// writes to in the named Namespace.
+func namespaceTargetResources(bundleTarget trustapi.BundleTarget, bundleName, secretTargetName, namespace string) []target.Resource {
+	var resources []target.Resource
+	if bundleTarget.Secret != nil {
+		resources = append(resources, target.Resource{Kind: target.KindSecret, NamespacedName: types.NamespacedName{
+			Name:      secretTargetName,
+			Namespace: namespace,
+		}})
+	}
+	if bundleTarget.ConfigMap != nil {
+		resources = append(resources, target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{
+			Name:      bundleName,
+			Namespace: namespace,
+		}})
+	}
+
+	// Additional formats may be written to a separate target of each kind.
+	if formatsTarget := bundleTarget.AdditionalFormatsTarget; formatsTarget != nil {
+		formatsName := types.NamespacedName{Name: formatsTarget.Name, Namespace: namespace}
+		if bundleTarget.Secret != nil {
+			resources = append(resources, target.Resource{Kind: target.KindSecret, NamespacedName: formatsName})
+		}
+		if bundleTarget.ConfigMap != nil {
+			resources = append(resources, target.Resource{Kind: target.KindConfigMap, NamespacedName: formatsName})
+		}
+	}
+
+	return resources
+}
+
+// bundleIsPaused returns true if syncing of the Bundle's targets has been paused,
+// either through the spec or the paused annotation.
+func bundleIsPaused(bundle *trustapi.Bundle) bool {
+	return bundle.Spec.Paused || bundle.GetAnnotations()[trustapi.BundlePausedAnnotationKey] == "true"
+}
+
+// setBlockedCondition adds the Blocked condition to the status patch, and
+// marks the Bundle as not synced. As a Bundle is only blocked for data which
+// must never be distributed, a Warning Event is recorded whenever the
+// condition changes, for security tooling watching Events.
+func (b *bundle) setBlockedCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, reason, message string) {
+	blockedCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionBlocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	if !bundleHasCondition(bundle.Status.Conditions, blockedCondition) {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, reason, message)
+	}
+
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, blockedCondition)
+	b.setBundleCondition(
+		bundle.Status.Conditions,
+		&statusPatch.Conditions,
+		trustapi.BundleCondition{
+			Type:               trustapi.BundleConditionSynced,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Blocked",
+			Message:            message,
+			ObservedGeneration: bundle.Generation,
+		},
+	)
+}
+
+// setSkippedSourcesCondition adds the SourcesSkipped condition to the status
+// patch if any optional sources were skipped, emitting an event when the set of
+// skipped sources changes. Returns true if the condition was added, changed or
+// needs to be removed.
+func (b *bundle) setSkippedSourcesCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, skippedSources []string) bool {
+	if len(skippedSources) == 0 {
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type == trustapi.BundleConditionSourcesSkipped {
+				return true
+			}
+		}
+		return false
+	}
+
+	message := "Skipped optional sources which were not found: " + strings.Join(skippedSources, ", ")
+	skippedCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionSourcesSkipped,
+		Status:             metav1.ConditionTrue,
+		Reason:             "OptionalSourcesNotFound",
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	changed := !bundleHasCondition(bundle.Status.Conditions, skippedCondition)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, skippedCondition)
+	if changed {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, "SourcesSkipped", message)
+	}
+
+	return changed
+}
+
+// maxInvalidBlockSamples is the number of skipped PEM blocks described in the
+// InvalidCertificatesSkipped condition.
+const maxInvalidBlockSamples = 5
+
+// setInvalidCertificatesSkippedCondition adds the InvalidCertificatesSkipped
+// condition to the status patch if any malformed PEM blocks were skipped in
+// the sources, emitting an event when they change. Returns true if the
+// condition was added, changed or needs to be removed.
+func (b *bundle) setInvalidCertificatesSkippedCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, invalidBlocks []string) bool {
+	if len(invalidBlocks) == 0 {
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type == trustapi.BundleConditionInvalidCertificatesSkipped {
+				return true
+			}
+		}
+		return false
+	}
+
+	descriptions := make([]string, 0, maxInvalidBlockSamples)
+	descriptions = append(descriptions, invalidBlocks[:min(len(invalidBlocks), maxInvalidBlockSamples)]...)
+	if more := len(invalidBlocks) - len(descriptions); more > 0 {
+		descriptions = append(descriptions, fmt.Sprintf("and %d more", more))
+	}
+
+	message := "Skipped malformed PEM blocks in sources: " + strings.Join(descriptions, "; ")
+	skippedCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionInvalidCertificatesSkipped,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MalformedPEMBlocks",
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	changed := !bundleHasCondition(bundle.Status.Conditions, skippedCondition)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, skippedCondition)
+	if changed {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, "InvalidCertificatesSkipped", message)
+	}
+
+	return changed
+}
+
+// refreshInterval returns the interval at which the Bundle should be re-synced.
+func (b *bundle) refreshInterval(bundle *trustapi.Bundle) time.Duration {
+	interval := b.Options.RequeueInterval
+	if bundle.Spec.RefreshInterval != nil {
+		interval = bundle.Spec.RefreshInterval.Duration
+	}
+
+	// Bundles are synced again once the bundle of a SPIFFE federation
+	// source is due to be fetched again.
+	if refreshIn := b.spiffeFederationRefreshIn(bundle); refreshIn > 0 && refreshIn < interval {
+		interval = refreshIn
+	}
+
+	// Likewise once the certificates of a cloud source are due to be
+	// fetched again, or retried after a failure.
+	if refreshIn := b.cloudSourcesRefreshIn(bundle); refreshIn > 0 && refreshIn < interval {
+		interval = refreshIn
+	}
+
+	return interval
+}
+
+// expiredCertificatePolicy returns the policy for expired certificates in the
+// sources of the Bundle.
+func (b *bundle) expiredCertificatePolicy(bundle *trustapi.Bundle) trustapi.ExpiredCertificatePolicy {
+	if bundle.Spec.Filters != nil && bundle.Spec.Filters.Expired != "" {
+		return bundle.Spec.Filters.Expired
+	}
+
+	if b.tunables().FilterExpiredCerts {
+		return trustapi.ExpiredCertificatePolicyRemove
+	}
+	return trustapi.ExpiredCertificatePolicyKeep
+}
+
+// deduplicationStrategy returns how duplicate certificates in the sources of
+// the Bundle are detected.
+func deduplicationStrategy(bundle *trustapi.Bundle) util.Deduplication {
+	if bundle.Spec.Filters == nil {
+		return util.DeduplicateFingerprint
+	}
+
+	switch bundle.Spec.Filters.Deduplication {
+	case trustapi.DeduplicationStrategySPKI:
+		return util.DeduplicateSPKI
+	case trustapi.DeduplicationStrategySubjectSerial:
+		return util.DeduplicateSubjectSerial
+	case trustapi.DeduplicationStrategyNone:
+		return util.DeduplicateNone
+	default:
+		return util.DeduplicateFingerprint
+	}
+}
+
+// invalidCertificatePolicy returns the policy for malformed PEM blocks in the
+// sources of the Bundle.
+func invalidCertificatePolicy(bundle *trustapi.Bundle) trustapi.InvalidCertificatePolicy {
+	if bundle.Spec.Filters != nil && bundle.Spec.Filters.Invalid != "" {
+		return bundle.Spec.Filters.Invalid
+	}
+	return trustapi.InvalidCertificatePolicyFail
+}
+
+// requireCABasicConstraints returns true if certificates which aren't CA
+// certificates should be filtered from the Bundle.
+func (b *bundle) requireCABasicConstraints(bundle *trustapi.Bundle) bool {
+	if bundle.Spec.RequireCABasicConstraints != nil {
+		return *bundle.Spec.RequireCABasicConstraints
+	}
+
+	return b.Options.RequireCABasicConstraints
 }
 
 func (b *bundle) bundleTargetNamespaceSelector(bundleObj *trustapi.Bundle) (labels.Selector, error) {
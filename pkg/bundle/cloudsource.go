@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/pkg/sourceplugin"
+)
+
+// cloudSourceError is returned when the API of the cloud provider of a source
+// fails. If it's returned along with source data, the data is the data
+// fetched last, which is used until the API succeeds again.
+type cloudSourceError struct{ error }
+
+func (e cloudSourceError) Unwrap() error {
+	return e.error
+}
+
+// cloudSourceBundle returns the certificates of the cloud source.
+func (b *bundle) cloudSourceBundle(ctx context.Context, source cloudsource.Source) (string, error) {
+	if b.cloud == nil {
+		return "", errors.New("cloud sources are not available")
+	}
+
+	certificates, err := b.cloud.Fetch(ctx, source)
+	if err != nil && !errors.As(err, &cloudsource.UnavailableError{}) {
+		return certificates, cloudSourceError{err}
+	}
+
+	return certificates, err
+}
+
+// cloudSourcesRefreshIn returns the time until the first of the cloud sources
+// of the Bundle is due to be fetched again, or zero if the Bundle has no such
+// sources.
+func (b *bundle) cloudSourcesRefreshIn(bundle *trustapi.Bundle) time.Duration {
+	if b.cloud == nil {
+		return 0
+	}
+
+	var refreshIn time.Duration
+	for _, source := range bundle.Spec.Sources {
+		cloud, ok := cloudSource(source)
+		if !ok {
+			continue
+		}
+		next := b.cloud.RefreshIn(cloud)
+		if next > 0 && (refreshIn == 0 || next < refreshIn) {
+			refreshIn = next
+		}
+	}
+
+	return refreshIn
+}
+
+// cloudSource returns the cloud source of the Bundle source, and false if it
+// isn't a cloud source. Plugin sources are fetched like cloud sources.
+func cloudSource(source trustapi.BundleSource) (cloudsource.Source, bool) {
+	var (
+		cloud           cloudsource.Source
+		refreshInterval *metav1.Duration
+	)
+
+	switch {
+	case source.AWSPrivateCA != nil:
+		cloud = cloudsource.Source{Provider: cloudsource.ProviderAWSPrivateCA, Ref: source.AWSPrivateCA.ARN}
+		refreshInterval = source.AWSPrivateCA.RefreshInterval
+
+	case source.GCPCASPool != nil:
+		cloud = cloudsource.Source{Provider: cloudsource.ProviderGCPCASPool, Ref: source.GCPCASPool.Pool}
+		refreshInterval = source.GCPCASPool.RefreshInterval
+
+	case source.AzureKeyVault != nil:
+		cloud = cloudsource.Source{Provider: cloudsource.ProviderAzureKeyVault, Ref: cloudsource.AzureKeyVaultRef(source.AzureKeyVault.VaultURL, source.AzureKeyVault.CertificateName)}
+		refreshInterval = source.AzureKeyVault.RefreshInterval
+
+	case source.Plugin != nil:
+		cloud = cloudsource.Source{Provider: cloudsource.ProviderPlugin, Ref: sourceplugin.Ref(source.Plugin.Name, source.Plugin.Parameters)}
+		refreshInterval = source.Plugin.RefreshInterval
+
+	default:
+		return cloudsource.Source{}, false
+	}
+
+	if refreshInterval != nil {
+		cloud.RefreshInterval = refreshInterval.Duration
+	}
+	return cloud, true
+}
+
+// setCloudSourcesFailingCondition adds the CloudSourcesFailing condition to
+// the status patch if the API of the cloud provider of any source failed,
+// emitting an event when the failures change. Returns true if the condition
+// was added, changed or needs to be removed.
+func (b *bundle) setCloudSourcesFailingCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, failures []string) bool {
+	if len(failures) == 0 {
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type == trustapi.BundleConditionCloudSourcesFailing {
+				return true
+			}
+		}
+		return false
+	}
+
+	message := "Failed to refresh cloud sources, using the certificates fetched last: " + strings.Join(failures, "; ")
+	failingCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionCloudSourcesFailing,
+		Status:             metav1.ConditionTrue,
+		Reason:             "CloudAPIError",
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	changed := !bundleHasCondition(bundle.Status.Conditions, failingCondition)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, failingCondition)
+	if changed {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, "CloudAPIError", message)
+	}
+
+	return changed
+}
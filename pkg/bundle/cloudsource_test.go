@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_setCloudSourcesFailingCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	b := &bundle{recorder: recorder, clock: fakeclock.NewFakeClock(time.Now())}
+
+	failures := []string{`failed to get certificate of AWS Private CA "arn": AccessDeniedException: not authorized`}
+	bundle := &trustapi.Bundle{}
+	statusPatch := &trustapi.BundleStatus{}
+	assert.True(t, b.setCloudSourcesFailingCondition(bundle, statusPatch, failures))
+	require.Len(t, statusPatch.Conditions, 1)
+	assert.Equal(t, trustapi.BundleConditionCloudSourcesFailing, statusPatch.Conditions[0].Type)
+	assert.Equal(t, "CloudAPIError", statusPatch.Conditions[0].Reason)
+	assert.Equal(t, []string{`Warning CloudAPIError Failed to refresh cloud sources, using the certificates fetched last: ` + failures[0]}, drainEvents(recorder))
+
+	// An unchanged condition is not reported again.
+	bundle.Status.Conditions = statusPatch.Conditions
+	assert.False(t, b.setCloudSourcesFailingCondition(bundle, &trustapi.BundleStatus{}, failures))
+	assert.Empty(t, drainEvents(recorder))
+
+	// The condition is removed once the sources are fetched again.
+	assert.True(t, b.setCloudSourcesFailingCondition(bundle, &trustapi.BundleStatus{}, nil))
+	assert.False(t, b.setCloudSourcesFailingCondition(&trustapi.Bundle{}, &trustapi.BundleStatus{}, nil))
+}
+
+func Test_cloudSource(t *testing.T) {
+	tests := map[string]struct {
+		source   trustapi.BundleSource
+		expCloud cloudsource.Source
+		expOK    bool
+	}{
+		"awsPrivateCA": {
+			source: trustapi.BundleSource{AWSPrivateCA: &trustapi.AWSPrivateCASource{
+				ARN:             "arn:aws:acm-pca:eu-west-1:111122223333:certificate-authority/abc",
+				RefreshInterval: &metav1.Duration{Duration: time.Minute},
+			}},
+			expCloud: cloudsource.Source{Provider: cloudsource.ProviderAWSPrivateCA, Ref: "arn:aws:acm-pca:eu-west-1:111122223333:certificate-authority/abc", RefreshInterval: time.Minute},
+			expOK:    true,
+		},
+		"gcpCASPool": {
+			source:   trustapi.BundleSource{GCPCASPool: &trustapi.GCPCASPoolSource{Pool: "projects/p/locations/l/caPools/pool"}},
+			expCloud: cloudsource.Source{Provider: cloudsource.ProviderGCPCASPool, Ref: "projects/p/locations/l/caPools/pool"},
+			expOK:    true,
+		},
+		"azureKeyVault": {
+			source:   trustapi.BundleSource{AzureKeyVault: &trustapi.AzureKeyVaultSource{VaultURL: "https://my-vault.vault.azure.net/", CertificateName: "my-ca"}},
+			expCloud: cloudsource.Source{Provider: cloudsource.ProviderAzureKeyVault, Ref: "https://my-vault.vault.azure.net/certificates/my-ca"},
+			expOK:    true,
+		},
+		"plugin": {
+			source: trustapi.BundleSource{Plugin: &trustapi.PluginSource{
+				Name:            "inventory",
+				Parameters:      map[string]string{"ca": "issuing-ca", "env": "prod"},
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			}},
+			expCloud: cloudsource.Source{Provider: cloudsource.ProviderPlugin, Ref: "inventory?ca=issuing-ca&env=prod", RefreshInterval: time.Hour},
+			expOK:    true,
+		},
+		"not a cloud source": {
+			source: trustapi.BundleSource{InLine: ptr.To(dummy.TestCertificate1)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cloud, ok := cloudSource(test.source)
+			assert.Equal(t, test.expOK, ok)
+			assert.Equal(t, test.expCloud, cloud)
+		})
+	}
+}
+
+func Test_cloudSourceBundle_unavailable(t *testing.T) {
+	fetcher, err := cloudsource.NewFetcher(httpclient.Options{}, fakeclock.NewFakeClock(time.Now()))
+	require.NoError(t, err)
+	b := &bundle{cloud: fetcher}
+
+	// Sources whose provider isn't compiled in don't fail as cloud API
+	// errors, which would be retried.
+	_, err = b.cloudSourceBundle(context.Background(), cloudsource.Source{Provider: cloudsource.ProviderGCPCASPool, Ref: "projects/p/locations/l/caPools/pool"})
+	assert.ErrorAs(t, err, &cloudsource.UnavailableError{})
+	assert.NotErrorAs(t, err, &cloudSourceError{})
+}
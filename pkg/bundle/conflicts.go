@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// targetName identifies the target resources of a kind with a name, in any
+// Namespace.
+type targetName struct {
+	kind target.Kind
+	name string
+}
+
+// targetConflict is a target resource which another Bundle writes to as well.
+type targetConflict struct {
+	targetName
+
+	// bundle is the name of the other Bundle.
+	bundle string
+
+	// yielded is true if the target is left to the other Bundle.
+	yielded bool
+}
+
+// bundleTargetNames returns the names of the target resources the Bundle
+// writes to. Immutable Secret targets are named after their data, so only
+// their mutable name is considered.
+func bundleTargetNames(bundle *trustapi.Bundle) sets.Set[targetName] {
+	names := sets.New[targetName]()
+	for _, t := range bundle.Spec.AllTargets() {
+		var kinds []target.Kind
+		if t.ConfigMap != nil {
+			kinds = append(kinds, target.KindConfigMap)
+		}
+		if t.Secret != nil {
+			kinds = append(kinds, target.KindSecret)
+		}
+
+		for _, kind := range kinds {
+			names.Insert(targetName{kind: kind, name: bundle.Name})
+			if t.AdditionalFormatsTarget != nil {
+				names.Insert(targetName{kind: kind, name: t.AdditionalFormatsTarget.Name})
+			}
+		}
+	}
+	return names
+}
+
+// bundleYields returns true if the Bundle leaves the targets it shares with
+// the other Bundle to it. Targets are kept by the Bundle created first, or by
+// the Bundle whose name sorts first if both were created at the same time, so
+// that both Bundles agree on which of them syncs the targets.
+func bundleYields(bundle, other *trustapi.Bundle) bool {
+	if !bundle.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return other.CreationTimestamp.Before(&bundle.CreationTimestamp)
+	}
+	return other.Name < bundle.Name
+}
+
+// findTargetConflicts returns the targets the Bundle shares with any of the
+// other Bundles, sorted by target and Bundle.
+func findTargetConflicts(bundle *trustapi.Bundle, others []trustapi.Bundle) []targetConflict {
+	names := bundleTargetNames(bundle)
+
+	var conflicts []targetConflict
+	for i := range others {
+		other := &others[i]
+		if other.Name == bundle.Name {
+			continue
+		}
+
+		for name := range names.Intersection(bundleTargetNames(other)) {
+			conflicts = append(conflicts, targetConflict{
+				targetName: name,
+				bundle:     other.Name,
+				yielded:    bundleYields(bundle, other),
+			})
+		}
+	}
+
+	slices.SortFunc(conflicts, func(a, b targetConflict) int {
+		return cmp.Or(
+			cmp.Compare(a.kind, b.kind),
+			cmp.Compare(a.name, b.name),
+			cmp.Compare(a.bundle, b.bundle),
+		)
+	})
+
+	return conflicts
+}
+
+// targetConflicts returns the targets the Bundle shares with other Bundles.
+func (b *bundle) targetConflicts(ctx context.Context, bundle *trustapi.Bundle) ([]targetConflict, error) {
+	var bundleList trustapi.BundleList
+	if err := b.client.List(ctx, &bundleList); err != nil {
+		return nil, fmt.Errorf("failed to list Bundles: %w", err)
+	}
+
+	return findTargetConflicts(bundle, bundleList.Items), nil
+}
+
+// yieldsTarget returns the name of the Bundle the target resource is left to,
+// if any.
+func yieldsTarget(conflicts []targetConflict, resource target.Resource) (string, bool) {
+	for _, conflict := range conflicts {
+		if conflict.yielded && conflict.kind == resource.Kind && conflict.name == resource.Name {
+			return conflict.bundle, true
+		}
+	}
+	return "", false
+}
+
+// setConflictCondition adds the Conflict condition to the status patch if the
+// Bundle shares targets with other Bundles, emitting an event when the
+// conflicts change. Returns true if the condition was added, changed or needs
+// to be removed.
+func (b *bundle) setConflictCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, conflicts []targetConflict) bool {
+	if len(conflicts) == 0 {
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type == trustapi.BundleConditionConflict {
+				return true
+			}
+		}
+		return false
+	}
+
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		description := fmt.Sprintf("%s %q with Bundle %q", conflict.kind, conflict.name, conflict.bundle)
+		if conflict.yielded {
+			description += " (synced by the other Bundle)"
+		}
+		descriptions = append(descriptions, description)
+	}
+
+	message := "Targets are also written by other Bundles: " + strings.Join(descriptions, ", ")
+	conflictCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionConflict,
+		Status:             metav1.ConditionTrue,
+		Reason:             "TargetConflict",
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	changed := !bundleHasCondition(bundle.Status.Conditions, conflictCondition)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, conflictCondition)
+	if changed {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, "TargetConflict", message)
+	}
+
+	return changed
+}
+
+// conflictEventHandler returns an event handler which reconciles the Bundles
+// sharing targets with a Bundle when it's created, deleted or changes its
+// targets, so that every Bundle of a conflict is marked, and unmarked once the
+// conflict is resolved.
+func (b *bundle) conflictEventHandler() handler.EventHandler {
+	enqueue := func(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], objs ...client.Object) {
+		for _, obj := range objs {
+			changed, ok := obj.(*trustapi.Bundle)
+			if !ok {
+				continue
+			}
+
+			for _, conflict := range findTargetConflicts(changed, b.mustBundleList(ctx).Items) {
+				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: conflict.bundle}})
+			}
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+	}
+}
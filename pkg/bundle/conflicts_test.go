@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+func Test_findTargetConflicts(t *testing.T) {
+	created := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	newBundle := func(name string, created metav1.Time, formatsTarget string) trustapi.Bundle {
+		bundleObj := trustapi.Bundle{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: created},
+			Spec: trustapi.BundleSpec{
+				Target: trustapi.BundleTarget{
+					ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				},
+			},
+		}
+		if formatsTarget != "" {
+			bundleObj.Spec.Target.AdditionalFormats = &trustapi.AdditionalFormats{
+				JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.jks"}},
+			}
+			bundleObj.Spec.Target.AdditionalFormatsTarget = &trustapi.AdditionalFormatsTarget{Name: formatsTarget}
+		}
+		return bundleObj
+	}
+
+	older := newBundle("b", created, "formats")
+	newer := newBundle("a", metav1.NewTime(created.Add(time.Minute)), "formats")
+	named := newBundle("formats", created, "")
+	unrelated := newBundle("c", created, "other-formats")
+	all := []trustapi.Bundle{older, newer, named, unrelated}
+
+	formatsConfigMap := targetName{kind: target.KindConfigMap, name: "formats"}
+
+	// The newer Bundle leaves the shared target to the older one, and to the
+	// Bundle it's named after.
+	assert.Equal(t, []targetConflict{
+		{targetName: formatsConfigMap, bundle: "b", yielded: true},
+		{targetName: formatsConfigMap, bundle: "formats", yielded: true},
+	}, findTargetConflicts(&newer, all))
+
+	// Bundles created at the same time leave it to the Bundle whose name sorts first.
+	assert.Equal(t, []targetConflict{
+		{targetName: formatsConfigMap, bundle: "a", yielded: false},
+		{targetName: formatsConfigMap, bundle: "formats", yielded: false},
+	}, findTargetConflicts(&older, all))
+
+	assert.Equal(t, []targetConflict{
+		{targetName: formatsConfigMap, bundle: "a", yielded: false},
+		{targetName: formatsConfigMap, bundle: "b", yielded: true},
+	}, findTargetConflicts(&named, all))
+
+	assert.Empty(t, findTargetConflicts(&unrelated, all))
+
+	resource := target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns", Name: "formats"}}
+	otherBundle, ok := yieldsTarget(findTargetConflicts(&newer, all), resource)
+	assert.True(t, ok)
+	assert.Equal(t, "b", otherBundle)
+
+	_, ok = yieldsTarget(findTargetConflicts(&older, all), resource)
+	assert.False(t, ok)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// maxInconsistencySamples is the number of inconsistencies described in the
+// InconsistentCertificates condition.
+const maxInconsistencySamples = 5
+
+const (
+	// inconsistencyCrossSigned is reported for certificates with the same
+	// subject and key, such as a self-signed root and a cross-signed version
+	// of it.
+	inconsistencyCrossSigned = "CrossSigned"
+
+	// inconsistencyKeyMismatch is reported for certificates with the same
+	// subject and subject key identifier, but different keys.
+	inconsistencyKeyMismatch = "KeyMismatch"
+)
+
+// certificateInconsistency is a set of certificates in a bundle which clients
+// may confuse with each other.
+type certificateInconsistency struct {
+	reason       string
+	subject      string
+	fingerprints []string
+}
+
+func (i certificateInconsistency) String() string {
+	switch i.reason {
+	case inconsistencyCrossSigned:
+		return fmt.Sprintf("%q is cross-signed (%s)", i.subject, strings.Join(i.fingerprints, ", "))
+	default:
+		return fmt.Sprintf("%q has the same key identifier for different keys (%s)", i.subject, strings.Join(i.fingerprints, ", "))
+	}
+}
+
+// findCertificateInconsistencies returns the certificates in the pool which
+// share a subject, and either share a key, being cross-signed duplicates, or
+// share a subject key identifier but not a key. Some clients, notably Java
+// clients of JKS truststores, index CAs by subject and key identifier, and
+// fail to verify chains when such certificates are mixed.
+func findCertificateInconsistencies(pool *util.CertPool) []certificateInconsistency {
+	bySubject := map[string][]*x509.Certificate{}
+	seen := map[string]struct{}{}
+	for _, certificate := range pool.Certificates() {
+		// Byte-identical copies are kept if deduplication is disabled, and
+		// aren't inconsistent.
+		if _, ok := seen[string(certificate.Raw)]; ok {
+			continue
+		}
+		seen[string(certificate.Raw)] = struct{}{}
+		bySubject[string(certificate.RawSubject)] = append(bySubject[string(certificate.RawSubject)], certificate)
+	}
+
+	var inconsistencies []certificateInconsistency
+	for _, certificates := range bySubject {
+		if len(certificates) < 2 {
+			continue
+		}
+
+		byKey := map[string][]*x509.Certificate{}
+		byKeyID := map[string][]*x509.Certificate{}
+		for _, certificate := range certificates {
+			byKey[string(certificate.RawSubjectPublicKeyInfo)] = append(byKey[string(certificate.RawSubjectPublicKeyInfo)], certificate)
+			if len(certificate.SubjectKeyId) > 0 {
+				byKeyID[string(certificate.SubjectKeyId)] = append(byKeyID[string(certificate.SubjectKeyId)], certificate)
+			}
+		}
+
+		for _, group := range byKey {
+			if len(group) > 1 {
+				inconsistencies = append(inconsistencies, newCertificateInconsistency(inconsistencyCrossSigned, group))
+			}
+		}
+		for _, group := range byKeyID {
+			if slices.ContainsFunc(group, func(certificate *x509.Certificate) bool {
+				return !bytes.Equal(certificate.RawSubjectPublicKeyInfo, group[0].RawSubjectPublicKeyInfo)
+			}) {
+				inconsistencies = append(inconsistencies, newCertificateInconsistency(inconsistencyKeyMismatch, group))
+			}
+		}
+	}
+
+	slices.SortFunc(inconsistencies, func(a, b certificateInconsistency) int {
+		return cmp.Or(cmp.Compare(a.subject, b.subject), cmp.Compare(a.reason, b.reason), slices.Compare(a.fingerprints, b.fingerprints))
+	})
+
+	return inconsistencies
+}
+
+func newCertificateInconsistency(reason string, certificates []*x509.Certificate) certificateInconsistency {
+	inconsistency := certificateInconsistency{reason: reason, subject: certificates[0].Subject.String()}
+	for _, certificate := range certificates {
+		hash := sha256.Sum256(certificate.Raw)
+		inconsistency.fingerprints = append(inconsistency.fingerprints, hex.EncodeToString(hash[:]))
+	}
+	slices.Sort(inconsistency.fingerprints)
+	return inconsistency
+}
+
+// setInconsistentCertificatesCondition adds the InconsistentCertificates
+// condition to the status patch if the bundle holds inconsistent certificates,
+// emitting an event when they change. Returns true if the condition was added,
+// changed or needs to be removed.
+func (b *bundle) setInconsistentCertificatesCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, inconsistencies []certificateInconsistency) bool {
+	if len(inconsistencies) == 0 {
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type == trustapi.BundleConditionInconsistentCertificates {
+				return true
+			}
+		}
+		return false
+	}
+
+	descriptions := make([]string, 0, maxInconsistencySamples)
+	for _, inconsistency := range inconsistencies[:min(len(inconsistencies), maxInconsistencySamples)] {
+		descriptions = append(descriptions, inconsistency.String())
+	}
+	if more := len(inconsistencies) - len(descriptions); more > 0 {
+		descriptions = append(descriptions, fmt.Sprintf("and %d more", more))
+	}
+
+	message := "Bundle holds certificates which clients may confuse: " + strings.Join(descriptions, "; ")
+	inconsistentCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionInconsistentCertificates,
+		Status:             metav1.ConditionTrue,
+		Reason:             inconsistencies[0].reason,
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	changed := !bundleHasCondition(bundle.Status.Conditions, inconsistentCondition)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, inconsistentCondition)
+	if changed {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, "InconsistentCertificates", message)
+	}
+
+	return changed
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+func Test_findCertificateInconsistencies(t *testing.T) {
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		return key
+	}
+	newCertificate := func(subject string, keyID []byte, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			Subject:               pkix.Name{CommonName: subject},
+			SubjectKeyId:          keyID,
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+		require.NoError(t, err)
+		certificate, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return certificate
+	}
+
+	rootKey, otherRootKey, impostorKey := newKey(), newKey(), newKey()
+	root := newCertificate("root", nil, rootKey, nil, nil)
+	otherRoot := newCertificate("other-root", nil, otherRootKey, nil, nil)
+	crossSignedRoot := newCertificate("root", nil, rootKey, otherRoot, otherRootKey)
+	keyIDRoot := newCertificate("key-id-root", []byte("key-id"), rootKey, nil, nil)
+	impostorRoot := newCertificate("key-id-root", []byte("key-id"), impostorKey, nil, nil)
+
+	tests := map[string]struct {
+		certificates []*x509.Certificate
+		exp          []certificateInconsistency
+	}{
+		"certificates with different subjects are consistent": {
+			certificates: []*x509.Certificate{root, otherRoot},
+		},
+		"cross-signed duplicates are reported": {
+			certificates: []*x509.Certificate{root, otherRoot, crossSignedRoot},
+			exp: []certificateInconsistency{
+				newCertificateInconsistency(inconsistencyCrossSigned, []*x509.Certificate{root, crossSignedRoot}),
+			},
+		},
+		"certificates with the same subject key identifier but different keys are reported": {
+			certificates: []*x509.Certificate{keyIDRoot, impostorRoot},
+			exp: []certificateInconsistency{
+				newCertificateInconsistency(inconsistencyKeyMismatch, []*x509.Certificate{keyIDRoot, impostorRoot}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var bundlePEM []byte
+			for _, certificate := range test.certificates {
+				bundlePEM = append(bundlePEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})...)
+			}
+
+			pool := util.NewCertPool()
+			require.NoError(t, pool.AddCertsFromPEM(bundlePEM))
+
+			assert.Equal(t, test.exp, findCertificateInconsistencies(pool))
+		})
+	}
+}
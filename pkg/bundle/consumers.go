@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/pkg/metrics"
+)
+
+// consumerPodsPageSize is the number of pods listed at a time, so that
+// reports on large clusters don't hold every pod in memory at once.
+const consumerPodsPageSize = 500
+
+// consumerReporter periodically counts the pods which mount the targets of
+// each Bundle, through ConfigMap, Secret or projected volumes, and exports
+// the counts as a metric. This shows how many workloads a change to a Bundle
+// would reach before it's made.
+//
+// Pods are listed from the API server a page at a time rather than cached,
+// as caching every pod in the cluster would cost far more memory than
+// trust-manager otherwise uses.
+type consumerReporter struct {
+	log logr.Logger
+
+	// client reads Bundles, and targetCache the metadata of their targets.
+	client      client.Reader
+	targetCache client.Reader
+	// apiReader lists pods.
+	apiReader client.Reader
+
+	interval             time.Duration
+	namespaces           []string
+	secretTargetsEnabled bool
+}
+
+// newConsumerReporter returns a consumerReporter for the Bundle controller.
+func newConsumerReporter(b *bundle, targetCache client.Reader) *consumerReporter {
+	namespaces := b.Options.WatchNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	return &consumerReporter{
+		log:                  b.Options.Log.WithName("consumers"),
+		client:               b.client,
+		targetCache:          targetCache,
+		apiReader:            b.apiReader,
+		interval:             b.Options.ConsumerReportInterval,
+		namespaces:           namespaces,
+		secretTargetsEnabled: b.Options.SecretTargetsEnabled,
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the
+// leader reports, so that replicas don't each list every pod.
+func (*consumerReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable, reporting the consumers of Bundles at
+// the interval until the context is cancelled.
+func (r *consumerReporter) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		consumers, err := r.countConsumers(ctx)
+		if err != nil {
+			r.log.Error(err, "failed to report the consumers of Bundles")
+			return
+		}
+
+		metrics.SetBundleConsumers(consumers)
+		r.log.V(2).Info("reported the consumers of Bundles", "consumers", consumers)
+	}, r.interval)
+	return nil
+}
+
+// countConsumers returns the number of pods which mount a target of each
+// Bundle. Pods which have completed aren't counted, and a pod mounting
+// several targets of a Bundle is counted once.
+func (r *consumerReporter) countConsumers(ctx context.Context) (map[string]int, error) {
+	var bundles trustapi.BundleList
+	if err := r.client.List(ctx, &bundles); err != nil {
+		return nil, fmt.Errorf("failed to list Bundles: %w", err)
+	}
+
+	consumers := make(map[string]int, len(bundles.Items))
+	for _, bundle := range bundles.Items {
+		consumers[bundle.Name] = 0
+	}
+
+	targets, err := r.listTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range r.namespaces {
+		var pods corev1.PodList
+		for {
+			if err := r.apiReader.List(ctx, &pods, client.InNamespace(namespace), client.Limit(consumerPodsPageSize), client.Continue(pods.Continue)); err != nil {
+				return nil, fmt.Errorf("failed to list pods: %w", err)
+			}
+
+			for i := range pods.Items {
+				pod := &pods.Items[i]
+				if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+					continue
+				}
+
+				mountedBundles := sets.New[string]()
+				for resource := range mountedResources(pod.Namespace, &pod.Spec) {
+					if bundle, ok := targets[resource]; ok {
+						mountedBundles.Insert(bundle)
+					}
+				}
+				for bundle := range mountedBundles {
+					consumers[bundle]++
+				}
+			}
+
+			if pods.Continue == "" {
+				break
+			}
+		}
+	}
+
+	return consumers, nil
+}
+
+// listTargets returns the name of the Bundle owning each target.
+func (r *consumerReporter) listTargets(ctx context.Context) (map[target.Resource]string, error) {
+	kinds := []target.Kind{target.KindConfigMap}
+	if r.secretTargetsEnabled {
+		kinds = append(kinds, target.KindSecret)
+	}
+
+	targets := make(map[target.Resource]string)
+	for _, kind := range kinds {
+		list := &metav1.PartialObjectMetadataList{
+			TypeMeta: metav1.TypeMeta{Kind: string(kind) + "List", APIVersion: "v1"},
+		}
+		if err := r.targetCache.List(ctx, list, client.HasLabels{trustapi.BundleLabelKey}); err != nil {
+			return nil, fmt.Errorf("failed to list %s targets: %w", kind, err)
+		}
+
+		for _, obj := range list.Items {
+			resource := target.Resource{Kind: kind, NamespacedName: types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}}
+			targets[resource] = obj.Labels[trustapi.BundleLabelKey]
+		}
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func Test_countConsumers(t *testing.T) {
+	configMapVolume := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+		}}
+	}
+	secretVolume := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: name},
+		}}
+	}
+	pod := func(namespace, name string, phase corev1.PodPhase, volumes ...corev1.Volume) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       corev1.PodSpec{Volumes: volumes},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	targetMeta := func(namespace, name, bundle string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{trustapi.BundleLabelKey: bundle}}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(
+			&trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "bundle-a"}},
+			&trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "bundle-b"}},
+			&trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "unused"}},
+			&corev1.ConfigMap{ObjectMeta: targetMeta("ns-1", "bundle-a", "bundle-a")},
+			&corev1.ConfigMap{ObjectMeta: targetMeta("ns-2", "bundle-a", "bundle-a")},
+			&corev1.ConfigMap{ObjectMeta: targetMeta("ns-1", "bundle-b", "bundle-b")},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "not-a-target", Namespace: "ns-1"}},
+			&corev1.Secret{ObjectMeta: targetMeta("ns-1", "bundle-a", "bundle-a")},
+
+			pod("ns-1", "running", corev1.PodRunning, configMapVolume("bundle-a")),
+			// A pod mounting several targets of a Bundle is counted once.
+			pod("ns-1", "both", corev1.PodPending, configMapVolume("bundle-a"), secretVolume("bundle-a"), configMapVolume("bundle-b")),
+			pod("ns-2", "other-namespace", corev1.PodRunning, configMapVolume("bundle-a")),
+			pod("ns-2", "missing-target", corev1.PodRunning, configMapVolume("bundle-b")),
+			pod("ns-1", "completed", corev1.PodSucceeded, configMapVolume("bundle-b")),
+			pod("ns-1", "secret-only", corev1.PodRunning, secretVolume("bundle-a")),
+			pod("ns-1", "unrelated", corev1.PodRunning, configMapVolume("not-a-target")),
+		).
+		Build()
+
+	tests := map[string]struct {
+		namespaces           []string
+		secretTargetsEnabled bool
+
+		expConsumers map[string]int
+	}{
+		"all namespaces": {
+			namespaces:   []string{metav1.NamespaceAll},
+			expConsumers: map[string]int{"bundle-a": 3, "bundle-b": 1, "unused": 0},
+		},
+		"secret targets enabled": {
+			namespaces:           []string{metav1.NamespaceAll},
+			secretTargetsEnabled: true,
+			expConsumers:         map[string]int{"bundle-a": 4, "bundle-b": 1, "unused": 0},
+		},
+		"watched namespaces": {
+			namespaces:   []string{"ns-1"},
+			expConsumers: map[string]int{"bundle-a": 2, "bundle-b": 1, "unused": 0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			log, ctx := ktesting.NewTestContext(t)
+			r := &consumerReporter{
+				log:                  log,
+				client:               fakeClient,
+				targetCache:          fakeClient,
+				apiReader:            fakeClient,
+				namespaces:           test.namespaces,
+				secretTargetsEnabled: test.secretTargetsEnabled,
+			}
+
+			consumers, err := r.countConsumers(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, test.expConsumers, consumers)
+		})
+	}
+}
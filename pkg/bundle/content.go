@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+const (
+	// maxContentChangeCertificates is the number of added and removed
+	// certificates listed in the Bundle status.
+	maxContentChangeCertificates = 10
+
+	// maxContentChangeEventCertificates is the number of added and removed
+	// certificates listed in the Event recorded when the bundle changes, which
+	// is kept short to be readable in `kubectl describe`.
+	maxContentChangeEventCertificates = 5
+)
+
+// contentTracker remembers the certificates which were last synced to the
+// targets of each Bundle, so that changes to them can be described. It only
+// knows of the bundles synced since trust-manager started. A nil
+// contentTracker remembers nothing.
+type contentTracker struct {
+	mu sync.Mutex
+
+	// certificates maps the name of a Bundle to the subjects of its
+	// certificates, by fingerprint.
+	certificates map[string]map[string]string
+}
+
+func newContentTracker() *contentTracker {
+	return &contentTracker{certificates: make(map[string]map[string]string)}
+}
+
+// update records the certificates of the pool as those synced to the targets
+// of the named Bundle. It returns the certificates which were added and
+// removed since they were last recorded, and false if they were never
+// recorded.
+func (t *contentTracker) update(bundleName string, pool *util.CertPool) (added, removed []trustapi.BundleCertificate, known bool) {
+	if t == nil {
+		return nil, nil, false
+	}
+
+	current := make(map[string]string)
+	for info := range pool.All() {
+		current[info.Fingerprint] = info.Subject
+	}
+
+	t.mu.Lock()
+	previous, known := t.certificates[bundleName]
+	t.certificates[bundleName] = current
+	t.mu.Unlock()
+
+	if !known {
+		return nil, nil, false
+	}
+
+	return certificatesNotIn(current, previous), certificatesNotIn(previous, current), true
+}
+
+// forget drops the certificates recorded for the named Bundle, which no
+// longer exists.
+func (t *contentTracker) forget(bundleName string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.certificates, bundleName)
+}
+
+// certificatesNotIn returns the certificates of a which aren't in b, sorted
+// by subject and then fingerprint so that they're listed stably.
+func certificatesNotIn(a, b map[string]string) []trustapi.BundleCertificate {
+	var certificates []trustapi.BundleCertificate
+	for fingerprint, subject := range a {
+		if _, ok := b[fingerprint]; !ok {
+			certificates = append(certificates, trustapi.BundleCertificate{Subject: subject, Fingerprint: fingerprint})
+		}
+	}
+
+	slices.SortFunc(certificates, func(x, y trustapi.BundleCertificate) int {
+		return cmp.Or(cmp.Compare(x.Subject, y.Subject), cmp.Compare(x.Fingerprint, y.Fingerprint))
+	})
+	return certificates
+}
+
+// setContentChangeStatus describes the certificates which were added to and
+// removed from the bundle since it was last synced, in an Event and the status
+// patch. Returns true if the description changed.
+func (b *bundle) setContentChangeStatus(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, pool *util.CertPool, hash string) bool {
+	added, removed, known := b.contentTracker.update(bundle.Name, pool)
+	if !known || (len(added) == 0 && len(removed) == 0) {
+		return false
+	}
+
+	statusPatch.LastContentChange = &trustapi.BundleContentChange{
+		Time:         metav1.NewTime(b.clock.Now()),
+		Hash:         hash,
+		AddedCount:   int32(len(added)), // #nosec G115 -- bounded by the size of the sources
+		Added:        added[:min(len(added), maxContentChangeCertificates)],
+		RemovedCount: int32(len(removed)), // #nosec G115 -- bounded by the size of the sources
+		Removed:      removed[:min(len(removed), maxContentChangeCertificates)],
+	}
+
+	b.recorder.Eventf(bundle, corev1.EventTypeNormal, "ContentChanged", "Bundle changed to %s: %s", hash, describeContentChange(added, removed))
+
+	return true
+}
+
+// describeContentChange returns a description of the added and removed
+// certificates, suitable for an Event message.
+func describeContentChange(added, removed []trustapi.BundleCertificate) string {
+	var parts []string
+	if len(added) > 0 {
+		parts = append(parts, "added "+describeCertificates(added))
+	}
+	if len(removed) > 0 {
+		parts = append(parts, "removed "+describeCertificates(removed))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeCertificates(certificates []trustapi.BundleCertificate) string {
+	noun := "certificates"
+	if len(certificates) == 1 {
+		noun = "certificate"
+	}
+
+	var listed []string
+	for _, cert := range certificates[:min(len(certificates), maxContentChangeEventCertificates)] {
+		listed = append(listed, fmt.Sprintf("%q (%s)", cert.Subject, cert.Fingerprint[:min(len(cert.Fingerprint), 16)]))
+	}
+	if more := len(certificates) - len(listed); more > 0 {
+		listed = append(listed, fmt.Sprintf("and %d more", more))
+	}
+
+	return fmt.Sprintf("%d %s: %s", len(certificates), noun, strings.Join(listed, ", "))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_setContentChangeStatus(t *testing.T) {
+	pool := func(certs ...string) *util.CertPool {
+		pool := util.NewCertPool()
+		require.NoError(t, pool.AddCertsFromPEM([]byte(dummy.JoinCerts(certs...))))
+		return pool
+	}
+	describe := func(certPEM string) trustapi.BundleCertificate {
+		block, _ := pem.Decode([]byte(certPEM))
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		hash := sha256.Sum256(block.Bytes)
+		return trustapi.BundleCertificate{Subject: cert.Subject.String(), Fingerprint: hex.EncodeToString(hash[:])}
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := record.NewFakeRecorder(10)
+	b := &bundle{recorder: recorder, clock: fakeclock.NewFakeClock(now), contentTracker: newContentTracker()}
+	bundle := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "test-bundle"}}
+
+	// The first bundle synced isn't described, as its previous content isn't known.
+	statusPatch := &trustapi.BundleStatus{}
+	assert.False(t, b.setContentChangeStatus(bundle, statusPatch, pool(dummy.TestCertificate1, dummy.TestCertificate2), "hash-1"))
+	assert.Nil(t, statusPatch.LastContentChange)
+	assert.Empty(t, drainEvents(recorder))
+
+	// An unchanged bundle isn't described again.
+	assert.False(t, b.setContentChangeStatus(bundle, statusPatch, pool(dummy.TestCertificate2, dummy.TestCertificate1), "hash-1"))
+	assert.Empty(t, drainEvents(recorder))
+
+	statusPatch = &trustapi.BundleStatus{}
+	assert.True(t, b.setContentChangeStatus(bundle, statusPatch, pool(dummy.TestCertificate2, dummy.TestCertificate3), "hash-2"))
+	added, removed := describe(dummy.TestCertificate3), describe(dummy.TestCertificate1)
+	assert.Equal(t, &trustapi.BundleContentChange{
+		Time:         metav1.NewTime(now),
+		Hash:         "hash-2",
+		AddedCount:   1,
+		Added:        []trustapi.BundleCertificate{added},
+		RemovedCount: 1,
+		Removed:      []trustapi.BundleCertificate{removed},
+	}, statusPatch.LastContentChange)
+	assert.Equal(t, []string{fmt.Sprintf("Normal ContentChanged Bundle changed to hash-2: added 1 certificate: %q (%s); removed 1 certificate: %q (%s)",
+		added.Subject, added.Fingerprint[:16], removed.Subject, removed.Fingerprint[:16])}, drainEvents(recorder))
+
+	// A Bundle which was deleted and recreated isn't described.
+	b.contentTracker.forget(bundle.Name)
+	assert.False(t, b.setContentChangeStatus(bundle, &trustapi.BundleStatus{}, pool(dummy.TestCertificate1), "hash-3"))
+	assert.Empty(t, drainEvents(recorder))
+}
+
+func Test_describeCertificates(t *testing.T) {
+	var certificates []trustapi.BundleCertificate
+	for i := range 7 {
+		certificates = append(certificates, trustapi.BundleCertificate{Subject: fmt.Sprintf("CN=cert-%d", i), Fingerprint: strings.Repeat(fmt.Sprint(i), 64)})
+	}
+
+	assert.Equal(t,
+		`7 certificates: "CN=cert-0" (0000000000000000), "CN=cert-1" (1111111111111111), "CN=cert-2" (2222222222222222), "CN=cert-3" (3333333333333333), "CN=cert-4" (4444444444444444), and 2 more`,
+		describeCertificates(certificates))
+}
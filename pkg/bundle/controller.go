@@ -18,14 +18,18 @@ package bundle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -37,10 +41,136 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
 	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/pkg/federation"
 	"github.com/cert-manager/trust-manager/pkg/fspkg"
+	"github.com/cert-manager/trust-manager/pkg/sourceplugin"
 )
 
+// Reconciler reconciles Bundles, building the trust bundle from the sources of
+// each Bundle and syncing it to its targets. It allows other controllers to
+// embed trust-manager's Bundle reconciliation; AddBundleController registers a
+// Reconciler with the watches trust-manager uses.
+type Reconciler struct {
+	bundle *bundle
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// NewReconciler returns a Reconciler which reads and writes Bundles and their
+// sources and targets using the client of the Manager. Existing targets are
+// listed from targetCache, which only needs to hold the metadata of ConfigMaps
+// and Secrets labelled with trust.cert-manager.io/bundle.
+func NewReconciler(mgr manager.Manager, opts Options, targetCache cache.Cache) (*Reconciler, error) {
+	b := &bundle{
+		client:    mgr.GetClient(),
+		apiReader: mgr.GetAPIReader(),
+		recorder:  newEventAggregator(mgr.GetEventRecorderFor("bundles"), clock.RealClock{}, opts.EventAggregationWindow, opts.MaxEventsPerSecond),
+		clock:     clock.RealClock{},
+		Options:   opts,
+		targetReconciler: &target.Reconciler{
+			Client:     mgr.GetClient(),
+			Cache:      targetCache,
+			APIReader:  mgr.GetAPIReader(),
+			ForceApply: opts.ForceTargetApply,
+		},
+		encodingCache:  target.NewEncodingCache(),
+		contentTracker: newContentTracker(),
+		statusThrottle: newStatusThrottle(clock.RealClock{}, opts.StatusUpdateInterval),
+	}
+
+	auditLog, err := audit.New(opts.Audit, opts.HTTPClient, opts.Log.WithName("audit"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit log: %w", err)
+	}
+	b.targetReconciler.Audit = auditLog
+
+	if opts.RemoteClustersEnabled {
+		b.remoteClusters = newRemoteClusters()
+		b.remoteClusters.audit = auditLog
+	}
+
+	if opts.TargetEvents {
+		b.targetReconciler.Recorder = newEventAggregator(mgr.GetEventRecorderFor("bundles"), clock.RealClock{}, 0, opts.MaxEventsPerSecond)
+	}
+
+	fetcher, err := federation.NewFetcher(opts.HTTPClient, opts.SPIFFEFederationTokenFile, b.clock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPIFFE federation client: %w", err)
+	}
+	b.federation = fetcher
+
+	cloud, err := cloudsource.NewFetcher(opts.HTTPClient, b.clock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud source clients: %w", err)
+	}
+	b.cloud = cloud
+
+	if opts.SourcePluginsDir != "" {
+		plugins, err := sourceplugin.NewProvider(opts.SourcePluginsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create source plugin client: %w", err)
+		}
+		b.cloud.SetProvider(cloudsource.ProviderPlugin, plugins)
+	}
+
+	pkg, err := loadDefaultPackage(b.Options)
+	if err != nil {
+		return nil, err
+	}
+	if pkg != nil {
+		b.defaultPackage = pkg
+		recordDefaultPackageMetrics(pkg)
+
+		b.Options.Log.Info("successfully loaded default package from filesystem", "path", b.Options.DefaultPackageLocation, "layers", b.Options.DefaultPackageLayers)
+	}
+
+	return &Reconciler{bundle: b}, nil
+}
+
+// loadDefaultPackage loads the default package from the filesystem, layered
+// with any packages on top of it, or returns nil if no location is set.
+func loadDefaultPackage(opts Options) (*fspkg.Package, error) {
+	if opts.DefaultPackageLocation == "" {
+		if len(opts.DefaultPackageLayers) > 0 {
+			return nil, errors.New("default package location must be set when default package layers are set")
+		}
+		return nil, nil
+	}
+
+	var options []fspkg.LoadOption
+	if opts.DefaultPackageVerificationKey != "" {
+		key, err := fspkg.LoadVerificationKeyFromFile(opts.DefaultPackageVerificationKey)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, fspkg.WithVerificationKey(key))
+	}
+
+	var (
+		pkg fspkg.Package
+		err error
+	)
+	if len(opts.DefaultPackageLayers) == 0 {
+		pkg, err = fspkg.LoadPackageFromFile(opts.DefaultPackageLocation, options...)
+	} else {
+		pkg, err = fspkg.LoadLayeredPackageFromFiles(append([]string{opts.DefaultPackageLocation}, opts.DefaultPackageLayers...), options...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("must load default package successfully when default package location is set: %w", err)
+	}
+
+	return &pkg, nil
+}
+
+// Reconcile syncs the Bundle named in the request to its targets, and updates
+// its status.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.bundle.Reconcile(ctx, req)
+}
+
 // AddBundleController will register the Bundle controller with the
 // controller-runtime Manager.
 // The Bundle controller will reconcile Bundles on Bundle events, as well as
@@ -52,26 +182,16 @@ func AddBundleController(
 	opts Options,
 	targetCache cache.Cache,
 ) error {
-	b := &bundle{
-		client:   mgr.GetClient(),
-		recorder: mgr.GetEventRecorderFor("bundles"),
-		clock:    clock.RealClock{},
-		Options:  opts,
-		targetReconciler: &target.Reconciler{
-			Client: mgr.GetClient(),
-			Cache:  targetCache,
-		},
+	r, err := NewReconciler(mgr, opts, targetCache)
+	if err != nil {
+		return err
 	}
+	b := r.bundle
 
-	if b.Options.DefaultPackageLocation != "" {
-		pkg, err := fspkg.LoadPackageFromFile(b.Options.DefaultPackageLocation)
-		if err != nil {
-			return fmt.Errorf("must load default package successfully when default package location is set: %w", err)
-		}
-
-		b.defaultPackage = &pkg
-
-		b.Options.Log.Info("successfully loaded default package from filesystem", "path", b.Options.DefaultPackageLocation)
+	// Index Bundles by the Namespace labels which their targets depend on, so
+	// that Namespace events only reconcile the Bundles they may affect.
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &trustapi.Bundle{}, namespaceLabelsIndex, indexNamespaceLabels); err != nil {
+		return fmt.Errorf("failed to index Bundles by namespace labels: %w", err)
 	}
 
 	// Only reconcile config maps that match the well known name
@@ -81,87 +201,192 @@ func AddBundleController(
 		////// Targets //////
 
 		// Reconcile a Bundle on events against a ConfigMap that it
-		// owns. Only cache ConfigMap metadata.
+		// controls. Only cache ConfigMap metadata.
 		WatchesRawSource(
 			source.Kind(
 				targetCache,
 				&metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}},
-				handler.TypedEnqueueRequestForOwner[*metav1.PartialObjectMetadata](
-					mgr.GetScheme(),
-					mgr.GetRESTMapper(),
-					&trustapi.Bundle{},
-					handler.OnlyControllerOwner(),
-				),
+				targetEventHandler(),
 			),
 		)
 
 	if opts.SecretTargetsEnabled {
 		// Reconcile a Bundle on events against a Secret that it
-		// owns. Only cache Secret metadata.
+		// controls. Only cache Secret metadata.
 		controller.WatchesRawSource(
 			source.Kind(
 				targetCache,
 				&metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}},
-				handler.TypedEnqueueRequestForOwner[*metav1.PartialObjectMetadata](
-					mgr.GetScheme(),
-					mgr.GetRESTMapper(),
-					&trustapi.Bundle{},
-					handler.OnlyControllerOwner(),
-				),
+				targetEventHandler(),
 			),
 		)
 	}
 
+	if opts.Tunables != nil {
+		// Reconcile all Bundles when the tunable options change, so that
+		// they take effect.
+		controller.WatchesRawSource(source.Channel(opts.Tunables.changed, b.enqueueRequestsFromBundleFunc(
+			func(client.Object, trustapi.Bundle) bool {
+				return true
+			}),
+		))
+	}
+
 	////// Sources //////
 
 	// Reconcile trust.cert-manager.io Bundles
 	controller.Watches(&trustapi.Bundle{}, &handler.EnqueueRequestForObject{}).
 
-		// Watch all Namespaces. Cache whole Namespaces to include Phase Status.
-		// Reconcile all Bundles on a Namespace change.
-		Watches(&corev1.Namespace{}, b.enqueueRequestsFromBundleFunc(
-			func(obj client.Object, bundle trustapi.Bundle) bool {
-				namespaceSelector, err := b.bundleTargetNamespaceSelector(&bundle)
-				if err != nil {
-					// We have an invalid selector, so we can skip this Bundle.
-					return false
-				}
+		// Reconcile the Bundles sharing targets with a Bundle when it changes
+		// its targets, so that both sides of a conflict are marked.
+		Watches(&trustapi.Bundle{}, b.conflictEventHandler(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 
-				return namespaceSelector.Matches(labels.Set(obj.GetLabels()))
-			})).
+		// Watch all Namespaces. Cache whole Namespaces to include Phase Status.
+		// Reconcile the Bundles selecting a Namespace when it is created,
+		// deleted, starts terminating or changes its labels.
+		Watches(&corev1.Namespace{}, b.namespaceEventHandler(), builder.WithPredicates(namespacePredicate())).
 
-		// Watch ConfigMaps in trust Namespace.
+		// Watch ConfigMaps in trust Namespace, or in any Namespace if sources
+		// may select ConfigMaps in other Namespaces.
 		// Reconcile Bundles who reference a modified source ConfigMap.
 		Watches(&corev1.ConfigMap{}, b.enqueueRequestsFromBundleFunc(
 			func(obj client.Object, bundle trustapi.Bundle) bool {
 				for _, s := range bundle.Spec.Sources {
-					if sourceSelectsObject(s.ConfigMap, obj) {
+					if sourceSelectsObject(s.ConfigMap, b.Options.Namespace, obj) {
+						return true
+					}
+					if obj.GetNamespace() != b.Options.Namespace {
+						continue
+					}
+					if ptr.Deref(s.UseInClusterCA, false) && obj.GetName() == kubeRootCAConfigMapName {
+						return true
+					}
+					if s.SPIFFEFederation != nil && s.SPIFFEFederation.BootstrapBundle != nil && obj.GetName() == s.SPIFFEFederation.BootstrapBundle.Name {
 						return true
 					}
 				}
 				return false
-			}), builder.WithPredicates(inNamespacePredicate(b.Options.Namespace))).
-
-		// Watch Secrets in trust Namespace.
-		// Reconcile Bundles who reference a modified source Secret.
+			}), builder.WithPredicates(b.sourcePredicate())).
+
+		// Watch Secrets in trust Namespace, or in any Namespace if sources
+		// may select Secrets in other Namespaces.
+		// Reconcile Bundles who reference a modified source Secret, or are
+		// signed with a modified signing key Secret. Bundles with issuer or
+		// Certificates sources are always reconciled, as the Secret of an
+		// issuer or Certificate is only known once it is read. All Bundles are
+		// reconciled when a Secret registering a remote cluster changes.
 		Watches(&corev1.Secret{}, b.enqueueRequestsFromBundleFunc(
+			func(obj client.Object, bundle trustapi.Bundle) bool {
+				if obj.GetNamespace() != b.Options.Namespace {
+					for _, s := range bundle.Spec.Sources {
+						if sourceSelectsObject(s.Secret, b.Options.Namespace, obj) {
+							return true
+						}
+						if s.IssuerRef != nil && obj.GetNamespace() == b.issuerNamespace(s.IssuerRef.Kind) {
+							return true
+						}
+					}
+					return false
+				}
+				if b.remoteClusters != nil && obj.GetLabels()[trustapi.RemoteClusterLabelKey] == "true" {
+					return true
+				}
+				signed := anyTarget(&bundle, func(t trustapi.BundleTarget) bool { return t.Signature != nil })
+				if signed && obj.GetName() == b.Options.SigningKeySecret {
+					return true
+				}
+				for _, s := range bundle.Spec.Sources {
+					if sourceSelectsObject(s.Secret, b.Options.Namespace, obj) || s.IssuerRef != nil || s.Certificates != nil {
+						return true
+					}
+				}
+				return false
+			}), builder.WithPredicates(predicate.Or(b.sourcePredicate(), inNamespacePredicate(b.issuerNamespace("ClusterIssuer")))))
+
+	if opts.CertificateSourcesEnabled {
+		// Reconcile the Bundles selecting a cert-manager Certificate in the
+		// trust Namespace when it changes, including when it stops being
+		// selected. Bundles with issuer sources are always reconciled, as the
+		// CA Certificates of a SelfSigned issuer are only known once they are
+		// read. Only cache Certificate metadata.
+		certificate := &metav1.PartialObjectMetadata{}
+		certificate.SetGroupVersionKind(certificateGVK)
+		controller.WatchesMetadata(certificate, b.enqueueRequestsFromBundleFunc(
 			func(obj client.Object, bundle trustapi.Bundle) bool {
 				for _, s := range bundle.Spec.Sources {
-					if sourceSelectsObject(s.Secret, obj) {
+					if s.IssuerRef != nil && obj.GetNamespace() == b.issuerNamespace(s.IssuerRef.Kind) {
+						return true
+					}
+					if obj.GetNamespace() != b.Options.Namespace {
+						continue
+					}
+					if s.Certificates != nil && labelsMatchSelector(obj.GetLabels(), &s.Certificates.Selector) {
 						return true
 					}
 				}
 				return false
-			}), builder.WithPredicates(inNamespacePredicate(b.Options.Namespace)))
+			}), builder.WithPredicates(predicate.Or(inNamespacePredicate(b.Options.Namespace), inNamespacePredicate(b.issuerNamespace("ClusterIssuer")))))
+	}
+
+	// Reconcile the Bundles referring to a cert-manager Issuer or
+	// ClusterIssuer when it changes, if the cert-manager CRDs are installed.
+	// Only cache issuer metadata.
+	for _, kind := range []string{"Issuer", "ClusterIssuer"} {
+		gvk := issuerGVK.GroupVersion().WithKind(kind)
+		if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); meta.IsNoMatchError(err) {
+			b.Log.Info("not watching cert-manager issuers as their CRD isn't installed", "kind", kind)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to look up the cert-manager %s kind: %w", kind, err)
+		}
+
+		issuer := &metav1.PartialObjectMetadata{}
+		issuer.SetGroupVersionKind(gvk)
+		controller.WatchesMetadata(issuer, b.enqueueRequestsFromBundleFunc(
+			func(obj client.Object, bundle trustapi.Bundle) bool {
+				for _, s := range bundle.Spec.Sources {
+					if s.IssuerRef != nil && issuerRefMatches(s.IssuerRef, kind, b.Options.Namespace, obj) {
+						return true
+					}
+				}
+				return false
+			}))
+	}
 
 	// Complete controller.
-	if err := controller.Complete(b); err != nil {
+	if err := controller.Complete(r); err != nil {
 		return fmt.Errorf("failed to create Bundle controller: %s", err)
 	}
 
+	if opts.ConsumerReportInterval > 0 {
+		if err := mgr.Add(newConsumerReporter(b, targetCache)); err != nil {
+			return fmt.Errorf("failed to add Bundle consumer reporter: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// targetEventHandler reconciles the Bundle controlling a target on its
+// events: the Bundle owning it or, for unowned targets, the Bundle named by
+// its label.
+func targetEventHandler() handler.TypedEventHandler[*metav1.PartialObjectMetadata, reconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, obj *metav1.PartialObjectMetadata) []reconcile.Request {
+		if ref := metav1.GetControllerOf(obj); ref != nil {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil || gv.Group != trustapi.SchemeGroupVersion.Group || ref.Kind != trustapi.BundleKind {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ref.Name}}}
+		}
+
+		if name := obj.GetLabels()[trustapi.BundleLabelKey]; name != "" {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+		}
+		return nil
+	})
+}
+
 // enqueueRequestsFromBundleFunc returns an event handler for watching Bundle dependants.
 // It will invoke the provided function for all Bundles and trigger a Bundle reconcile if the
 // functions returns true.
@@ -186,11 +411,11 @@ func (b *bundle) enqueueRequestsFromBundleFunc(fn func(obj client.Object, bundle
 	)
 }
 
-// mustBundleList will return a BundleList of all Bundles in the cluster. If an
-// error occurs, will exit error the program.
-func (b *bundle) mustBundleList(ctx context.Context) *trustapi.BundleList {
+// mustBundleList will return a BundleList of all Bundles in the cluster
+// matching the list options. If an error occurs, will exit error the program.
+func (b *bundle) mustBundleList(ctx context.Context, opts ...client.ListOption) *trustapi.BundleList {
 	var bundleList trustapi.BundleList
-	if err := b.client.List(ctx, &bundleList); err != nil {
+	if err := b.client.List(ctx, &bundleList, opts...); err != nil {
 		b.Log.Error(err, "failed to list all Bundles, exiting error")
 		os.Exit(-1)
 	}
@@ -205,12 +430,41 @@ func inNamespacePredicate(namespace string) predicate.Predicate {
 	})
 }
 
-// sourceSelectsObject returns true if source selector selects obj and false otherwise
-func sourceSelectsObject(selector *trustapi.SourceObjectKeySelector, obj client.Object) bool {
+// sourcePredicate creates an event filter predicate for the ConfigMaps and
+// Secrets which may be sources: those in the trust Namespace, or in any
+// Namespace if sources may select objects in other Namespaces.
+func (b *bundle) sourcePredicate() predicate.Predicate {
+	if b.Options.SourceNamespaceSelectorsEnabled {
+		return predicate.NewPredicateFuncs(func(client.Object) bool { return true })
+	}
+	return inNamespacePredicate(b.Options.Namespace)
+}
+
+// issuerRefMatches returns true if ref refers to obj, an issuer of the kind.
+// Issuers are only referred to in the trust Namespace.
+func issuerRefMatches(ref *trustapi.IssuerReference, kind, trustNamespace string, obj client.Object) bool {
+	refKind := ref.Kind
+	if refKind == "" {
+		refKind = "Issuer"
+	}
+	if refKind != kind || ref.Name != obj.GetName() {
+		return false
+	}
+	return kind == "ClusterIssuer" || obj.GetNamespace() == trustNamespace
+}
+
+// sourceSelectsObject returns true if source selector selects obj and false otherwise.
+// Objects outside the trust Namespace are only selected by the label selector
+// of sources with a namespace selector.
+func sourceSelectsObject(selector *trustapi.SourceObjectKeySelector, trustNamespace string, obj client.Object) bool {
 	if selector == nil {
 		return false
 	}
 
+	if obj.GetNamespace() != trustNamespace {
+		return selector.NamespaceSelector != nil && labelsMatchSelector(obj.GetLabels(), selector.Selector)
+	}
+
 	if labelsMatchSelector(obj.GetLabels(), selector.Selector) {
 		return true
 	}
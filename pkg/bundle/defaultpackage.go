@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/fspkg"
+	"github.com/cert-manager/trust-manager/pkg/metrics"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// recordDefaultPackageMetrics exports the build time of the default package,
+// and the latest expiry of its certificates.
+func recordDefaultPackageMetrics(pkg *fspkg.Package) {
+	buildTime, _ := pkg.BuildTime()
+
+	var newestNotAfter time.Time
+	certPool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
+	if err := certPool.AddCertsFromPEM([]byte(pkg.Bundle)); err == nil {
+		for _, certificate := range certPool.Certificates() {
+			if certificate.NotAfter.After(newestNotAfter) {
+				newestNotAfter = certificate.NotAfter
+			}
+		}
+	}
+
+	metrics.SetDefaultPackage(buildTime, newestNotAfter)
+}
+
+// usesDefaultCAs returns true if the Bundle has a source of default CAs.
+func usesDefaultCAs(bundle *trustapi.Bundle) bool {
+	for _, source := range bundle.Spec.Sources {
+		if source.UseDefaultCAs != nil && *source.UseDefaultCAs {
+			return true
+		}
+	}
+	return false
+}
+
+// setDefaultPackageStaleCondition adds the DefaultPackageStale condition to
+// the status patch if the Bundle uses default CAs and the default package is
+// older than the maximum age, emitting an event when it's added. Returns true
+// if the condition was added, changed or needs to be removed.
+func (b *bundle) setDefaultPackageStaleCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus) bool {
+	var (
+		buildTime time.Time
+		stale     bool
+	)
+	if b.defaultPackage != nil && b.Options.DefaultPackageMaxAge > 0 && usesDefaultCAs(bundle) {
+		var ok bool
+		buildTime, ok = b.defaultPackage.BuildTime()
+		stale = ok && b.clock.Since(buildTime) > b.Options.DefaultPackageMaxAge
+	}
+
+	if !stale {
+		for _, cond := range bundle.Status.Conditions {
+			if cond.Type == trustapi.BundleConditionDefaultPackageStale {
+				return true
+			}
+		}
+		return false
+	}
+
+	message := fmt.Sprintf("Default CA package %q was built at %s, more than %s ago; update the default package image to pick up changes to the default CAs",
+		b.defaultPackage.StringID(), buildTime.UTC().Format(time.RFC3339), b.Options.DefaultPackageMaxAge)
+	staleCondition := trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionDefaultPackageStale,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DefaultPackageStale",
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	}
+
+	changed := !bundleHasCondition(bundle.Status.Conditions, staleCondition)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, staleCondition)
+	if changed {
+		b.recorder.Eventf(bundle, corev1.EventTypeWarning, "DefaultPackageStale", message)
+	}
+
+	return changed
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/fspkg"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_setDefaultPackageStaleCondition(t *testing.T) {
+	built := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := record.NewFakeRecorder(10)
+	b := &bundle{
+		recorder:       recorder,
+		clock:          fakeclock.NewFakeClock(built.Add(100 * 24 * time.Hour)),
+		defaultPackage: &fspkg.Package{Name: "debian", Version: "1", Bundle: dummy.TestCertificate1, BuildDate: built.Format(time.RFC3339)},
+		Options:        Options{DefaultPackageMaxAge: 90 * 24 * time.Hour},
+	}
+
+	usingDefaultCAs := &trustapi.Bundle{Spec: trustapi.BundleSpec{Sources: []trustapi.BundleSource{{UseDefaultCAs: ptr.To(true)}}}}
+	statusPatch := &trustapi.BundleStatus{}
+	assert.True(t, b.setDefaultPackageStaleCondition(usingDefaultCAs, statusPatch))
+	require.Len(t, statusPatch.Conditions, 1)
+	assert.Equal(t, trustapi.BundleConditionDefaultPackageStale, statusPatch.Conditions[0].Type)
+	assert.Len(t, drainEvents(recorder), 1)
+
+	// An unchanged condition is not reported again.
+	usingDefaultCAs.Status.Conditions = statusPatch.Conditions
+	assert.False(t, b.setDefaultPackageStaleCondition(usingDefaultCAs, &trustapi.BundleStatus{Conditions: statusPatch.Conditions}))
+	assert.Empty(t, drainEvents(recorder))
+
+	// The condition is removed once the package is recent enough.
+	b.Options.DefaultPackageMaxAge = 120 * 24 * time.Hour
+	assert.True(t, b.setDefaultPackageStaleCondition(usingDefaultCAs, &trustapi.BundleStatus{}))
+	b.Options.DefaultPackageMaxAge = 90 * 24 * time.Hour
+
+	// Bundles which don't use default CAs aren't warned.
+	notUsingDefaultCAs := &trustapi.Bundle{Spec: trustapi.BundleSpec{Sources: []trustapi.BundleSource{{UseDefaultCAs: ptr.To(false)}}}}
+	assert.False(t, b.setDefaultPackageStaleCondition(notUsingDefaultCAs, &trustapi.BundleStatus{}))
+
+	// Packages without a build date are never stale.
+	b.defaultPackage = &fspkg.Package{Name: "debian", Version: "1", Bundle: dummy.TestCertificate1}
+	assert.False(t, b.setDefaultPackageStaleCondition(&trustapi.Bundle{Spec: usingDefaultCAs.Spec}, &trustapi.BundleStatus{}))
+	assert.Empty(t, drainEvents(recorder))
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// reconcileDeletionPolicy ensures the retain finalizer is present on the Bundle
+// only if its targets should be retained when the Bundle is deleted. If the
+// Bundle is being deleted, its targets are released before the finalizer is
+// removed.
+// Returns true if the Bundle is being deleted, in which case the targets must
+// not be synced.
+func (b *bundle) reconcileDeletionPolicy(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle) (bool, error) {
+	retain := bundle.Spec.Target.DeletionPolicy == trustapi.DeletionPolicyRetain ||
+		anyTarget(bundle, func(t trustapi.BundleTarget) bool { return t.DeletionPolicy == trustapi.DeletionPolicyRetain })
+	hasFinalizer := controllerutil.ContainsFinalizer(bundle, trustapi.BundleRetainTargetsFinalizer)
+
+	if bundle.GetDeletionTimestamp() == nil {
+		if retain == hasFinalizer {
+			return false, nil
+		}
+
+		patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if retain {
+			controllerutil.AddFinalizer(bundle, trustapi.BundleRetainTargetsFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(bundle, trustapi.BundleRetainTargetsFinalizer)
+		}
+
+		if err := b.client.Patch(ctx, bundle, patch); err != nil {
+			return false, fmt.Errorf("failed to update bundle finalizers: %w", err)
+		}
+
+		return false, nil
+	}
+
+	if !hasFinalizer {
+		log.V(2).Info("bundle is being deleted, skipping sync of targets")
+		return true, nil
+	}
+
+	if retain {
+		released, err := b.releaseTargets(ctx, log, bundle)
+		if err != nil {
+			b.recorder.Eventf(bundle, corev1.EventTypeWarning, "ReleaseTargetsFailed", "Failed to release targets: %s", err)
+			return true, err
+		}
+
+		b.recorder.Eventf(bundle, corev1.EventTypeNormal, "TargetsRetained", "Released %d targets, which will be retained after the Bundle is deleted", released)
+	}
+
+	patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(bundle, trustapi.BundleRetainTargetsFinalizer)
+	if err := b.client.Patch(ctx, bundle, patch); err != nil {
+		return true, fmt.Errorf("failed to remove bundle finalizer: %w", err)
+	}
+
+	return true, nil
+}
+
+// releaseTargets releases all targets controlled by the Bundle whose kind has
+// the Retain deletion policy, so that they are not garbage collected with it.
+// Returns the number of released targets.
+func (b *bundle) releaseTargets(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle) (int, error) {
+	targetKinds := []target.Kind{target.KindConfigMap}
+	if b.Options.SecretTargetsEnabled {
+		targetKinds = append(targetKinds, target.KindSecret)
+	}
+
+	var released int
+	for _, kind := range targetKinds {
+		if deletionPolicy(bundle, kind) != trustapi.DeletionPolicyRetain {
+			continue
+		}
+
+		targetList := &metav1.PartialObjectMetadataList{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       string(kind),
+			},
+		}
+		if err := b.targetReconciler.Cache.List(ctx, targetList, &client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(map[string]string{
+				trustapi.BundleLabelKey: bundle.Name,
+			}),
+		}); err != nil {
+			return released, fmt.Errorf("failed to list %ss: %w", kind, err)
+		}
+
+		for _, t := range targetList.Items {
+			if !targetControlledBy(&t, bundle, kind) {
+				continue
+			}
+
+			key := target.Resource{
+				Kind: kind,
+				NamespacedName: types.NamespacedName{
+					Name:      t.Name,
+					Namespace: t.Namespace,
+				},
+			}
+
+			if err := b.targetReconciler.Release(ctx, key, bundle); err != nil {
+				return released, err
+			}
+
+			log.V(2).Info("released target", "target", key)
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// deleteTargetsRequeueInterval is how often a Bundle being deleted is
+// requeued while its targets remain, in case the deletion of a target isn't
+// observed.
+const deleteTargetsRequeueInterval = 5 * time.Second
+
+// reconcileDeleteTargetsFinalizer ensures the delete finalizer is present on
+// the Bundle only if trust-manager deletes its targets itself: if its deletion
+// policy is explicitly Delete, or its targets are unowned. If the Bundle is
+// being deleted, its targets are deleted in all Namespaces, and the finalizer
+// is only removed once none remain, so that the deletion of targets doesn't
+// rely on garbage collection.
+// Returns the number of targets which remain to be deleted, in which case the
+// Deleting condition is added to the status patch.
+func (b *bundle) reconcileDeleteTargetsFinalizer(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus) (int, error) {
+	deleteTargets := deletesTargets(bundle, target.KindConfigMap) || deletesTargets(bundle, target.KindSecret)
+	hasFinalizer := controllerutil.ContainsFinalizer(bundle, trustapi.BundleDeleteTargetsFinalizer)
+
+	if bundle.GetDeletionTimestamp() == nil {
+		if deleteTargets == hasFinalizer {
+			return 0, nil
+		}
+
+		patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if deleteTargets {
+			controllerutil.AddFinalizer(bundle, trustapi.BundleDeleteTargetsFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(bundle, trustapi.BundleDeleteTargetsFinalizer)
+		}
+
+		if err := b.client.Patch(ctx, bundle, patch); err != nil {
+			return 0, fmt.Errorf("failed to update bundle finalizers: %w", err)
+		}
+
+		return 0, nil
+	}
+
+	if !hasFinalizer {
+		return 0, nil
+	}
+
+	if deleteTargets {
+		remaining, err := b.deleteTargets(ctx, log, bundle)
+		if err != nil {
+			b.recorder.Eventf(bundle, corev1.EventTypeWarning, "DeleteTargetsFailed", "Failed to delete targets: %s", err)
+			return 0, err
+		}
+
+		if remaining > 0 {
+			message := fmt.Sprintf("Waiting for %d targets to be deleted", remaining)
+			b.setBundleCondition(
+				bundle.Status.Conditions,
+				&statusPatch.Conditions,
+				trustapi.BundleCondition{
+					Type:               trustapi.BundleConditionDeleting,
+					Status:             metav1.ConditionTrue,
+					Reason:             "DeletingTargets",
+					Message:            message,
+					ObservedGeneration: bundle.Generation,
+				},
+			)
+			log.V(2).Info("waiting for targets to be deleted", "remaining", remaining)
+			return remaining, nil
+		}
+
+		b.recorder.Eventf(bundle, corev1.EventTypeNormal, "TargetsDeleted", "Deleted all targets of the Bundle")
+	}
+
+	patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(bundle, trustapi.BundleDeleteTargetsFinalizer)
+	if err := b.client.Patch(ctx, bundle, patch); err != nil {
+		return 0, fmt.Errorf("failed to remove bundle finalizer: %w", err)
+	}
+
+	return 0, nil
+}
+
+// deleteTargets deletes all targets controlled by the Bundle whose kind is
+// deleted along with it, in all Namespaces.
+// Returns the number of targets which still exist, including those whose
+// deletion was only just requested.
+func (b *bundle) deleteTargets(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle) (int, error) {
+	targetKinds := []target.Kind{target.KindConfigMap}
+	if b.Options.SecretTargetsEnabled {
+		targetKinds = append(targetKinds, target.KindSecret)
+	}
+
+	var remaining int
+	for _, kind := range targetKinds {
+		if !deletesTargets(bundle, kind) {
+			continue
+		}
+
+		targetList := &metav1.PartialObjectMetadataList{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       string(kind),
+			},
+		}
+		if err := b.targetReconciler.Cache.List(ctx, targetList, &client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(map[string]string{
+				trustapi.BundleLabelKey: bundle.Name,
+			}),
+		}); err != nil {
+			return remaining, fmt.Errorf("failed to list %ss: %w", kind, err)
+		}
+
+		for _, t := range targetList.Items {
+			if !targetControlledBy(&t, bundle, kind) {
+				continue
+			}
+
+			if t.GetDeletionTimestamp() == nil {
+				obj := &metav1.PartialObjectMetadata{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: string(kind)},
+					ObjectMeta: metav1.ObjectMeta{Namespace: t.Namespace, Name: t.Name},
+				}
+				if err := b.client.Delete(ctx, obj, client.Preconditions{UID: &t.UID}); apierrors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return remaining, fmt.Errorf("failed to delete %s %s/%s: %w", kind, t.Namespace, t.Name, err)
+				}
+
+				log.V(2).Info("deleted target", "kind", kind, "namespace", t.Namespace, "name", t.Name)
+				b.targetReconciler.Audit.Record(audit.Entry{
+					Action:    audit.ActionDelete,
+					Bundle:    bundle.Name,
+					Kind:      string(kind),
+					Namespace: t.Namespace,
+					Name:      t.Name,
+					OldHash:   t.GetAnnotations()[trustapi.BundleHashAnnotationKey],
+				})
+			}
+
+			remaining++
+		}
+	}
+
+	return remaining, nil
+}
+
+// deletesTargets returns true if trust-manager deletes the targets of the
+// given kind when the Bundle is deleted: if their deletion policy is Delete,
+// or if they are unowned, and so aren't garbage collected, unless they are
+// retained.
+func deletesTargets(bundle *trustapi.Bundle, kind target.Kind) bool {
+	policy := deletionPolicy(bundle, kind)
+	return policy == trustapi.DeletionPolicyDelete ||
+		(policy != trustapi.DeletionPolicyRetain && targetOwnership(bundle, kind) == trustapi.TargetOwnershipUnowned)
+}
+
+// deletionPolicy returns the deletion policy of the targets of the given kind:
+// the policy of the Bundle target which writes them, or of spec.target if none
+// does.
+func deletionPolicy(bundle *trustapi.Bundle, kind target.Kind) trustapi.DeletionPolicy {
+	if t := targetFor(bundle, kind); t != nil {
+		return t.DeletionPolicy
+	}
+	return bundle.Spec.Target.DeletionPolicy
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+func Test_reconcileDeletionPolicy(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	deletionTimestamp := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	targetConfigMap := func(bundle *trustapi.Bundle) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bundleName,
+				Namespace: "ns-1",
+				Labels: map[string]string{
+					trustapi.BundleLabelKey: bundleName,
+					"app":                   "foo",
+				},
+				Annotations: map[string]string{
+					trustapi.BundleHashAnnotationKey: "hash",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion:         trustapi.SchemeGroupVersion.String(),
+					Kind:               trustapi.BundleKind,
+					Name:               bundle.Name,
+					UID:                bundle.UID,
+					Controller:         ptr.To(true),
+					BlockOwnerDeletion: ptr.To(true),
+				}},
+				ManagedFields: ssa_client.ManagedFieldEntries(nil, []string{"target-key"}),
+			},
+			Data: map[string]string{"target-key": "data"},
+		}
+	}
+
+	tests := map[string]struct {
+		bundle *trustapi.Bundle
+
+		expDeleting     bool
+		expFinalizer    bool
+		expTargetOwned  bool
+		expBundleExists bool
+	}{
+		"if deletion policy is Retain, add finalizer": {
+			bundle:          gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyRetain)),
+			expFinalizer:    true,
+			expTargetOwned:  true,
+			expBundleExists: true,
+		},
+		"if deletion policy is Delete, remove finalizer": {
+			bundle: gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyDelete), func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRetainTargetsFinalizer}
+			}),
+			expFinalizer:    false,
+			expTargetOwned:  true,
+			expBundleExists: true,
+		},
+		"if Bundle with Retain policy is deleted, release targets and remove finalizer": {
+			bundle: gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyRetain), func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRetainTargetsFinalizer}
+				b.DeletionTimestamp = &deletionTimestamp
+			}),
+			expDeleting:     true,
+			expTargetOwned:  false,
+			expBundleExists: false,
+		},
+		"if Bundle with Delete policy is deleted, leave targets to be garbage collected": {
+			bundle: gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{"other-finalizer"}
+				b.DeletionTimestamp = &deletionTimestamp
+			}),
+			expDeleting:     true,
+			expTargetOwned:  true,
+			expBundleExists: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.bundle.UID = "bundle-uid"
+			configMap := targetConfigMap(test.bundle)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(test.bundle, configMap).
+				Build()
+
+			log, ctx := ktesting.NewTestContext(t)
+			b := &bundle{
+				client:   fakeClient,
+				recorder: record.NewFakeRecorder(1),
+				Options:  Options{Log: log},
+				targetReconciler: &target.Reconciler{
+					Client: fakeClient,
+					Cache:  fakeClient,
+				},
+			}
+
+			var bundle trustapi.Bundle
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+
+			deleting, err := b.reconcileDeletionPolicy(ctx, log, &bundle)
+			require.NoError(t, err)
+			assert.Equal(t, test.expDeleting, deleting)
+
+			err = fakeClient.Get(ctx, types.NamespacedName{Name: bundleName}, &bundle)
+			if test.expBundleExists {
+				require.NoError(t, err)
+				assert.Equal(t, test.expFinalizer, controllerutil.ContainsFinalizer(&bundle, trustapi.BundleRetainTargetsFinalizer))
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected bundle to be deleted, got %v", err)
+			}
+
+			var gotConfigMap corev1.ConfigMap
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), &gotConfigMap))
+			assert.Equal(t, test.expTargetOwned, metav1.IsControlledBy(&gotConfigMap, test.bundle))
+			assert.Equal(t, map[string]string{"target-key": "data"}, gotConfigMap.Data)
+			if !test.expTargetOwned {
+				assert.Equal(t, map[string]string{"app": "foo"}, gotConfigMap.Labels)
+				assert.Empty(t, gotConfigMap.Annotations)
+			}
+		})
+	}
+}
+
+func Test_reconcileDeleteTargetsFinalizer(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	deletionTimestamp := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	targetConfigMap := func(bundle *trustapi.Bundle, namespace string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bundleName,
+				Namespace: namespace,
+				Labels:    map[string]string{trustapi.BundleLabelKey: bundleName},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: trustapi.SchemeGroupVersion.String(),
+					Kind:       trustapi.BundleKind,
+					Name:       bundle.Name,
+					UID:        bundle.UID,
+					Controller: ptr.To(true),
+				}},
+			},
+			Data: map[string]string{"target-key": "data"},
+		}
+	}
+
+	tests := map[string]struct {
+		bundle    *trustapi.Bundle
+		noTargets bool
+
+		expRemaining    int
+		expFinalizer    bool
+		expTargets      int
+		expBundleExists bool
+	}{
+		"if deletion policy is explicitly Delete, add finalizer": {
+			bundle:          gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyDelete)),
+			expFinalizer:    true,
+			expTargets:      2,
+			expBundleExists: true,
+		},
+		"if targets are unowned, add finalizer": {
+			bundle: gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+				b.Spec.Target.Ownership = trustapi.TargetOwnershipUnowned
+			}),
+			expFinalizer:    true,
+			expTargets:      2,
+			expBundleExists: true,
+		},
+		"if unowned targets are retained, remove finalizer": {
+			bundle: gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyRetain), func(b *trustapi.Bundle) {
+				b.Spec.Target.Ownership = trustapi.TargetOwnershipUnowned
+				b.Finalizers = []string{trustapi.BundleDeleteTargetsFinalizer}
+			}),
+			expFinalizer:    false,
+			expTargets:      2,
+			expBundleExists: true,
+		},
+		"if deletion policy is unset, remove finalizer": {
+			bundle: gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleDeleteTargetsFinalizer}
+			}),
+			expFinalizer:    false,
+			expTargets:      2,
+			expBundleExists: true,
+		},
+		"if Bundle with Delete policy is deleted, delete targets and keep finalizer while they remain": {
+			bundle: gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyDelete), func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleDeleteTargetsFinalizer}
+				b.DeletionTimestamp = &deletionTimestamp
+			}),
+			expRemaining:    2,
+			expFinalizer:    true,
+			expTargets:      0,
+			expBundleExists: true,
+		},
+		"if Bundle with Delete policy is deleted and no targets remain, remove finalizer": {
+			bundle: gen.Bundle(bundleName, gen.SetBundleTargetDeletionPolicy(trustapi.DeletionPolicyDelete), func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleDeleteTargetsFinalizer}
+				b.DeletionTimestamp = &deletionTimestamp
+			}),
+			noTargets:       true,
+			expBundleExists: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.bundle.UID = "bundle-uid"
+			objs := []client.Object{test.bundle}
+			if !test.noTargets {
+				objs = append(objs,
+					targetConfigMap(test.bundle, "ns-1"),
+					targetConfigMap(test.bundle, "ns-2"),
+					// Targets of other Bundles are never deleted.
+					targetConfigMap(&trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: bundleName, UID: "other-uid"}}, "ns-3"),
+				)
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(objs...).
+				Build()
+
+			log, ctx := ktesting.NewTestContext(t)
+			b := &bundle{
+				client:   fakeClient,
+				recorder: record.NewFakeRecorder(1),
+				clock:    fakeclock.NewFakeClock(deletionTimestamp.Time),
+				Options:  Options{Log: log},
+				targetReconciler: &target.Reconciler{
+					Client: fakeClient,
+					Cache:  fakeClient,
+				},
+			}
+
+			var bundle trustapi.Bundle
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+
+			statusPatch := &trustapi.BundleStatus{}
+			remaining, err := b.reconcileDeleteTargetsFinalizer(ctx, log, &bundle, statusPatch)
+			require.NoError(t, err)
+			assert.Equal(t, test.expRemaining, remaining)
+			if test.expRemaining > 0 {
+				require.Len(t, statusPatch.Conditions, 1)
+				assert.Equal(t, trustapi.BundleConditionDeleting, statusPatch.Conditions[0].Type)
+				assert.Equal(t, "Waiting for 2 targets to be deleted", statusPatch.Conditions[0].Message)
+			} else {
+				assert.Empty(t, statusPatch.Conditions)
+			}
+
+			err = fakeClient.Get(ctx, types.NamespacedName{Name: bundleName}, &bundle)
+			if test.expBundleExists {
+				require.NoError(t, err)
+				assert.Equal(t, test.expFinalizer, controllerutil.ContainsFinalizer(&bundle, trustapi.BundleDeleteTargetsFinalizer))
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected bundle to be deleted, got %v", err)
+			}
+
+			if !test.noTargets {
+				var configMaps corev1.ConfigMapList
+				require.NoError(t, fakeClient.List(ctx, &configMaps))
+				var owned int
+				for _, cm := range configMaps.Items {
+					if metav1.IsControlledBy(&cm, test.bundle) {
+						owned++
+					}
+				}
+				assert.Equal(t, test.expTargets, owned)
+				assert.Len(t, configMaps.Items, test.expTargets+1)
+			}
+		})
+	}
+}
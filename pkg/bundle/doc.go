@@ -0,0 +1,28 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle implements the reconciliation of trust-manager Bundles.
+//
+// Besides AddBundleController, which runs the controller used by
+// trust-manager, the package can be used by other controllers which need to
+// build trust bundles:
+//
+//   - Reconciler, returned by NewReconciler, syncs Bundles to their targets
+//     and can be registered with a controller of its own.
+//   - Renderer, returned by NewRenderer, resolves the sources of a Bundle
+//     without writing to any targets. Its CertPool can be encoded with the
+//     encoders of the truststore package.
+package bundle
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// eventAggregator is an EventRecorder which protects the API server from
+// floods of Warning Events, such as when a Bundle fails to sync to thousands
+// of Namespaces.
+// Warning Events are bucketed per object and reason: only the first Event of
+// each bucket is emitted, and the number of Events suppressed is appended to
+// the first Event of the next bucket. All Events are additionally subject to
+// an optional rate limit.
+type eventAggregator struct {
+	recorder record.EventRecorder
+	clock    clock.PassiveClock
+
+	// window is the length of each bucket. Zero disables aggregation.
+	window time.Duration
+
+	// limiter caps the rate at which Events are emitted. Nil means unlimited.
+	limiter flowcontrol.PassiveRateLimiter
+
+	mu      sync.Mutex
+	buckets map[eventKey]*eventBucket
+}
+
+type eventKey struct {
+	uid    types.UID
+	reason string
+}
+
+type eventBucket struct {
+	// object is the object the Events of the bucket were recorded on.
+	object     types.NamespacedName
+	start      time.Time
+	suppressed int
+}
+
+var _ record.EventRecorder = &eventAggregator{}
+
+func newEventAggregator(recorder record.EventRecorder, clock clock.PassiveClock, window time.Duration, maxPerSecond float32) *eventAggregator {
+	a := &eventAggregator{
+		recorder: recorder,
+		clock:    clock,
+		window:   window,
+		buckets:  make(map[eventKey]*eventBucket),
+	}
+
+	if maxPerSecond > 0 {
+		burst := max(int(maxPerSecond), 1)
+		a.limiter = flowcontrol.NewTokenBucketPassiveRateLimiterWithClock(maxPerSecond, burst, clock)
+	}
+
+	return a
+}
+
+// Event implements record.EventRecorder.
+func (a *eventAggregator) Event(object runtime.Object, eventtype, reason, message string) {
+	message, ok := a.admit(object, eventtype, reason, message)
+	if !ok {
+		return
+	}
+	a.recorder.Event(object, eventtype, reason, message)
+}
+
+// Eventf implements record.EventRecorder.
+func (a *eventAggregator) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	a.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf implements record.EventRecorder.
+func (a *eventAggregator) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message, ok := a.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+	if !ok {
+		return
+	}
+	a.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// admit returns whether the Event should be emitted, and the message to emit
+// it with.
+func (a *eventAggregator) admit(object runtime.Object, eventtype, reason, message string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock.Now()
+
+	if a.window > 0 && eventtype == corev1.EventTypeWarning {
+		if accessor, err := meta.Accessor(object); err == nil {
+			a.prune(now)
+
+			key := eventKey{uid: accessor.GetUID(), reason: reason}
+			if bucket, ok := a.buckets[key]; ok {
+				if now.Before(bucket.start.Add(a.window)) {
+					bucket.suppressed++
+					return "", false
+				}
+				if bucket.suppressed > 0 {
+					message = fmt.Sprintf("%s (%d similar events suppressed)", message, bucket.suppressed)
+				}
+			}
+			a.buckets[key] = &eventBucket{
+				object: types.NamespacedName{Namespace: accessor.GetNamespace(), Name: accessor.GetName()},
+				start:  now,
+			}
+		}
+	}
+
+	if a.limiter != nil && !a.limiter.TryAccept() {
+		return "", false
+	}
+
+	return message, true
+}
+
+// prune forgets expired buckets which have nothing to report. Buckets with
+// suppressed Events are kept, so the count is reported on the next Event.
+func (a *eventAggregator) prune(now time.Time) {
+	for key, bucket := range a.buckets {
+		if bucket.suppressed == 0 && !now.Before(bucket.start.Add(a.window)) {
+			delete(a.buckets, key)
+		}
+	}
+}
+
+// forget drops the buckets of the Bundle, which was deleted, so that the
+// Events suppressed for it aren't kept forever.
+func (a *eventAggregator) forget(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for key, bucket := range a.buckets {
+		if bucket.object == (types.NamespacedName{Name: name}) {
+			delete(a.buckets, key)
+		}
+	}
+}
+
+// targetFailures collects the failures of a single fan-out of a Bundle, so
+// that they can be reported as one Event per reason.
+type targetFailures map[string]*targetFailure
+
+type targetFailure struct {
+	kind       target.Kind
+	count      int
+	firstNS    string
+	firstError error
+}
+
+func (f targetFailures) add(t target.Resource, err error) {
+	reason := fmt.Sprintf("Sync%sTargetFailed", t.Kind)
+	if failure, ok := f[reason]; ok {
+		failure.count++
+		return
+	}
+	f[reason] = &targetFailure{kind: t.Kind, count: 1, firstNS: t.Namespace, firstError: err}
+}
+
+// record emits a Warning Event on the Bundle for each reason.
+func (f targetFailures) record(recorder record.EventRecorder, bundle *trustapi.Bundle) {
+	for _, reason := range slices.Sorted(maps.Keys(f)) {
+		failure := f[reason]
+		if failure.count == 1 {
+			recorder.Eventf(bundle, corev1.EventTypeWarning, reason, "Failed to sync target %s in Namespace %q: %s", failure.kind, failure.firstNS, failure.firstError)
+			continue
+		}
+		recorder.Eventf(bundle, corev1.EventTypeWarning, reason, "Failed to sync target %s in %d namespaces (first error: Namespace %q: %s)", failure.kind, failure.count, failure.firstNS, failure.firstError)
+	}
+}
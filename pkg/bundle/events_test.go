@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func Test_eventAggregator(t *testing.T) {
+	bundleA := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "a", UID: "a"}}
+	bundleB := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "b", UID: "b"}}
+
+	recorder := record.NewFakeRecorder(20)
+	clock := fakeclock.NewFakeClock(time.Now())
+	aggregator := newEventAggregator(recorder, clock, time.Minute, 0)
+
+	aggregator.Event(bundleA, corev1.EventTypeWarning, "Failed", "first")
+	aggregator.Event(bundleA, corev1.EventTypeWarning, "Failed", "second")
+	aggregator.Event(bundleA, corev1.EventTypeWarning, "Failed", "third")
+	aggregator.Event(bundleA, corev1.EventTypeWarning, "OtherReason", "other")
+	aggregator.Event(bundleB, corev1.EventTypeWarning, "Failed", "bundle b")
+	aggregator.Event(bundleA, corev1.EventTypeNormal, "Synced", "synced")
+	aggregator.Event(bundleA, corev1.EventTypeNormal, "Synced", "synced")
+
+	assert.Equal(t, []string{
+		"Warning Failed first",
+		"Warning OtherReason other",
+		"Warning Failed bundle b",
+		"Normal Synced synced",
+		"Normal Synced synced",
+	}, drainEvents(recorder))
+
+	// The next bucket reports the Events suppressed in the previous one.
+	clock.Step(time.Minute)
+	aggregator.Eventf(bundleA, corev1.EventTypeWarning, "Failed", "fourth %d", 4)
+	aggregator.Event(bundleB, corev1.EventTypeWarning, "Failed", "bundle b again")
+
+	assert.Equal(t, []string{
+		"Warning Failed fourth 4 (2 similar events suppressed)",
+		"Warning Failed bundle b again",
+	}, drainEvents(recorder))
+
+	// Expired buckets with nothing to report are forgotten.
+	clock.Step(time.Minute)
+	aggregator.Event(bundleA, corev1.EventTypeWarning, "OtherReason", "other")
+	assert.Equal(t, map[eventKey]*eventBucket{
+		{uid: types.UID("a"), reason: "OtherReason"}: {object: types.NamespacedName{Name: "a"}, start: clock.Now()},
+	}, aggregator.buckets)
+}
+
+func Test_eventAggregator_forget(t *testing.T) {
+	bundleA := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "a", UID: "a"}}
+	bundleB := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "b", UID: "b"}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "a", UID: "cm"}}
+
+	recorder := record.NewFakeRecorder(20)
+	clock := fakeclock.NewFakeClock(time.Now())
+	aggregator := newEventAggregator(recorder, clock, time.Minute, 0)
+
+	for range 2 {
+		aggregator.Event(bundleA, corev1.EventTypeWarning, "Failed", "failed")
+		aggregator.Event(bundleB, corev1.EventTypeWarning, "Failed", "failed")
+		aggregator.Event(configMap, corev1.EventTypeWarning, "Failed", "failed")
+	}
+
+	// Buckets with suppressed Events outlive the window until the Bundle is
+	// found to be deleted.
+	clock.Step(time.Hour)
+	b := &bundle{
+		client:         fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).Build(),
+		recorder:       aggregator,
+		encodingCache:  target.NewEncodingCache(),
+		contentTracker: newContentTracker(),
+	}
+	_, _, err := b.reconcileBundle(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[eventKey]*eventBucket{
+		{uid: types.UID("b"), reason: "Failed"}:  {object: types.NamespacedName{Name: "b"}, start: clock.Now().Add(-time.Hour), suppressed: 1},
+		{uid: types.UID("cm"), reason: "Failed"}: {object: types.NamespacedName{Namespace: "team-a", Name: "a"}, start: clock.Now().Add(-time.Hour), suppressed: 1},
+	}, aggregator.buckets)
+}
+
+func Test_eventAggregator_rateLimit(t *testing.T) {
+	bundle := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "a", UID: "a"}}
+
+	recorder := record.NewFakeRecorder(20)
+	clock := fakeclock.NewFakeClock(time.Now())
+	aggregator := newEventAggregator(recorder, clock, 0, 2)
+
+	for range 5 {
+		aggregator.Event(bundle, corev1.EventTypeWarning, "Failed", "failed")
+	}
+	assert.Len(t, drainEvents(recorder), 2)
+
+	clock.Step(time.Second)
+	for range 5 {
+		aggregator.Event(bundle, corev1.EventTypeNormal, "Synced", "synced")
+	}
+	assert.Len(t, drainEvents(recorder), 2)
+}
+
+func Test_targetFailures(t *testing.T) {
+	bundle := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	recorder := record.NewFakeRecorder(20)
+
+	failures := targetFailures{}
+	failures.add(target.Resource{Kind: target.KindSecret, NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "a"}}, errors.New("forbidden"))
+	failures.add(target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "a"}}, errors.New("boom"))
+	failures.add(target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns-2", Name: "a"}}, errors.New("boom"))
+	failures.add(target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns-3", Name: "a"}}, errors.New("boom"))
+	failures.record(recorder, bundle)
+
+	assert.Equal(t, []string{
+		`Warning SyncConfigMapTargetFailed Failed to sync target ConfigMap in 3 namespaces (first error: Namespace "ns-1": boom)`,
+		`Warning SyncSecretTargetFailed Failed to sync target Secret in Namespace "ns-1": forbidden`,
+	}, drainEvents(recorder))
+}
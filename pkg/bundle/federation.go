@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/federation"
+)
+
+// spiffeFederationBundle returns the X.509 authorities of the trust domain of
+// the source, fetched from its bundle endpoint. If the bundle can't be
+// refreshed, the bundle fetched last is used until fetching succeeds again.
+func (b *bundle) spiffeFederationBundle(ctx context.Context, source *trustapi.SPIFFEFederationSource) (string, error) {
+	if b.federation == nil {
+		return "", errors.New("SPIFFE federation is not available")
+	}
+
+	endpoint := spiffeFederationEndpoint(source)
+	if ref := source.BootstrapBundle; ref != nil {
+		bootstrapPEM, err := b.configMapBundle(ctx, &trustapi.SourceObjectKeySelector{Name: ref.Name, Key: ref.Key})
+		if err != nil {
+			return "", fmt.Errorf("failed to read bootstrap bundle of trust domain %q: %w", source.TrustDomain, err)
+		}
+		endpoint.BootstrapPEM = bootstrapPEM
+	}
+
+	fetched, err := b.federation.Fetch(ctx, endpoint)
+	if fetched == nil {
+		return "", err
+	}
+	if err != nil {
+		b.Log.Error(err, "failed to refresh SPIFFE bundle, using the bundle fetched last", "trustDomain", source.TrustDomain)
+	}
+
+	return fetched.PEM(), nil
+}
+
+// spiffeFederationRefreshIn returns the time until the first of the SPIFFE
+// federation sources of the Bundle is due to be fetched again, or zero if the
+// Bundle has no such sources.
+func (b *bundle) spiffeFederationRefreshIn(bundle *trustapi.Bundle) time.Duration {
+	if b.federation == nil {
+		return 0
+	}
+
+	var refreshIn time.Duration
+	for _, source := range bundle.Spec.Sources {
+		if source.SPIFFEFederation == nil {
+			continue
+		}
+		next := b.federation.RefreshIn(spiffeFederationEndpoint(source.SPIFFEFederation))
+		if next > 0 && (refreshIn == 0 || next < refreshIn) {
+			refreshIn = next
+		}
+	}
+
+	return refreshIn
+}
+
+// spiffeFederationEndpoint returns the bundle endpoint of the source, without
+// its bootstrap bundle.
+func spiffeFederationEndpoint(source *trustapi.SPIFFEFederationSource) federation.Endpoint {
+	endpoint := federation.Endpoint{
+		TrustDomain:  source.TrustDomain,
+		URL:          source.EndpointURL,
+		Profile:      federation.ProfileHTTPSWeb,
+		SPIFFEID:     source.EndpointSPIFFEID,
+		PresentToken: source.PresentServiceAccountToken,
+	}
+	if source.Profile == trustapi.SPIFFEBundleEndpointProfileHTTPSSPIFFE {
+		endpoint.Profile = federation.ProfileHTTPSSPIFFE
+	}
+	if source.RefreshInterval != nil {
+		endpoint.RefreshInterval = source.RefreshInterval.Duration
+	}
+
+	return endpoint
+}
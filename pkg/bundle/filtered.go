@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// maxFilteredCertificateSamples is the number of removed certificates listed
+// in the Bundle status.
+const maxFilteredCertificateSamples = 5
+
+// setFilteredCertificatesStatus sets the summary of the certificates removed
+// from the sources in the status patch, emitting an event when it changes.
+// Returns true if the summary changed.
+func (b *bundle) setFilteredCertificatesStatus(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, filtered []util.FilteredCertificate) bool {
+	summary := summarizeFilteredCertificates(filtered)
+	statusPatch.FilteredCertificates = summary
+
+	if apiequality.Semantic.DeepEqual(bundle.Status.FilteredCertificates, summary) {
+		return false
+	}
+
+	if summary != nil {
+		reasons := map[string]int{}
+		for _, cert := range filtered {
+			reasons[cert.Reason]++
+		}
+		var counts []string
+		for _, reason := range slices.Sorted(maps.Keys(reasons)) {
+			counts = append(counts, fmt.Sprintf("%d %s", reasons[reason], reason))
+		}
+
+		b.recorder.Eventf(bundle, corev1.EventTypeNormal, "CertificatesFiltered", "Removed %d certificates from the bundle sources (%s)", summary.Count, strings.Join(counts, ", "))
+	}
+
+	return true
+}
+
+// summarizeFilteredCertificates returns the status summary of the removed
+// certificates, or nil if none were removed. Samples are sorted so that the
+// summary is stable across reconciles.
+func summarizeFilteredCertificates(filtered []util.FilteredCertificate) *trustapi.FilteredCertificates {
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	sorted := slices.SortedFunc(slices.Values(filtered), func(a, b util.FilteredCertificate) int {
+		return cmp.Or(cmp.Compare(a.Reason, b.Reason), cmp.Compare(a.Fingerprint, b.Fingerprint))
+	})
+
+	summary := &trustapi.FilteredCertificates{Count: int32(len(filtered))} // #nosec G115 -- bounded by the size of the sources
+	for _, cert := range sorted[:min(len(sorted), maxFilteredCertificateSamples)] {
+		summary.Samples = append(summary.Samples, trustapi.FilteredCertificate{
+			Subject:     cert.Subject,
+			Fingerprint: cert.Fingerprint,
+			Reason:      cert.Reason,
+		})
+	}
+
+	return summary
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+func Test_setFilteredCertificatesStatus(t *testing.T) {
+	var filtered []util.FilteredCertificate
+	for i := range 6 {
+		filtered = append(filtered, util.FilteredCertificate{
+			Subject:     fmt.Sprintf("CN=cert-%d", i),
+			Fingerprint: fmt.Sprintf("%064d", 6-i),
+			Reason:      util.FilterReasonDuplicate,
+		})
+	}
+	filtered = append(filtered, util.FilteredCertificate{Subject: "CN=expired", Fingerprint: fmt.Sprintf("%064d", 9), Reason: util.FilterReasonExpired})
+
+	recorder := record.NewFakeRecorder(10)
+	b := &bundle{recorder: recorder}
+
+	bundle := &trustapi.Bundle{}
+	statusPatch := &trustapi.BundleStatus{}
+	assert.True(t, b.setFilteredCertificatesStatus(bundle, statusPatch, filtered))
+	assert.Equal(t, int32(7), statusPatch.FilteredCertificates.Count)
+	assert.Equal(t, []trustapi.FilteredCertificate{
+		{Subject: "CN=cert-5", Fingerprint: fmt.Sprintf("%064d", 1), Reason: "Duplicate"},
+		{Subject: "CN=cert-4", Fingerprint: fmt.Sprintf("%064d", 2), Reason: "Duplicate"},
+		{Subject: "CN=cert-3", Fingerprint: fmt.Sprintf("%064d", 3), Reason: "Duplicate"},
+		{Subject: "CN=cert-2", Fingerprint: fmt.Sprintf("%064d", 4), Reason: "Duplicate"},
+		{Subject: "CN=cert-1", Fingerprint: fmt.Sprintf("%064d", 5), Reason: "Duplicate"},
+	}, statusPatch.FilteredCertificates.Samples)
+	assert.Equal(t, []string{"Normal CertificatesFiltered Removed 7 certificates from the bundle sources (6 Duplicate, 1 Expired)"}, drainEvents(recorder))
+
+	// An unchanged summary is not reported again.
+	bundle.Status.FilteredCertificates = statusPatch.FilteredCertificates
+	assert.False(t, b.setFilteredCertificatesStatus(bundle, &trustapi.BundleStatus{}, filtered))
+	assert.Empty(t, drainEvents(recorder))
+
+	// A summary which is no longer needed is removed.
+	statusPatch = &trustapi.BundleStatus{}
+	assert.True(t, b.setFilteredCertificatesStatus(bundle, statusPatch, nil))
+	assert.Nil(t, statusPatch.FilteredCertificates)
+	assert.Empty(t, drainEvents(recorder))
+}
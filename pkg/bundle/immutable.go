@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// reconcileSecretTargetAnnotation ensures the secret target annotation of the
+// Bundle holds the name of the current immutable target Secret, or is absent
+// if the Bundle has no immutable Secret target.
+// The annotation is only pointed at a new Secret once rolledOut is true: the
+// Secret exists in every Namespace, and none are still deferred by a
+// progressive or canary rollout. Consumers following it then never find a
+// missing Secret.
+func (b *bundle) reconcileSecretTargetAnnotation(ctx context.Context, bundle *trustapi.Bundle, secretName string, rolledOut bool) error {
+	secretTarget := targetFor(bundle, target.KindSecret)
+	immutable := secretTarget != nil && secretTarget.Secret.Immutable
+	if immutable && !rolledOut {
+		return nil
+	}
+
+	current, ok := bundle.GetAnnotations()[trustapi.BundleSecretTargetAnnotationKey]
+	if immutable == ok && (!immutable || current == secretName) {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	annotations := bundle.GetAnnotations()
+	if immutable {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[trustapi.BundleSecretTargetAnnotationKey] = secretName
+	} else {
+		delete(annotations, trustapi.BundleSecretTargetAnnotationKey)
+	}
+	bundle.SetAnnotations(annotations)
+
+	if err := b.client.Patch(ctx, bundle, patch); err != nil {
+		return fmt.Errorf("failed to update bundle secret target annotation: %w", err)
+	}
+
+	return nil
+}
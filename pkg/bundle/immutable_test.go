@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/test/dummy"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+func Test_reconcileSecretTargetAnnotation(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	immutableSecret := gen.SetBundleTargetSecret(trustapi.SecretTarget{
+		KeySelector: trustapi.KeySelector{Key: "trust.pem"},
+		Immutable:   true,
+	})
+	withAnnotation := func(value string) gen.BundleModifier {
+		return func(b *trustapi.Bundle) {
+			b.Annotations = map[string]string{trustapi.BundleSecretTargetAnnotationKey: value}
+		}
+	}
+
+	tests := map[string]struct {
+		bundle     *trustapi.Bundle
+		secretName string
+		deferred   bool
+
+		expAnnotations map[string]string
+	}{
+		"if Secret target is immutable, point the annotation at the current Secret": {
+			bundle:         gen.Bundle(bundleName, immutableSecret),
+			secretName:     bundleName + "-0123456789",
+			expAnnotations: map[string]string{trustapi.BundleSecretTargetAnnotationKey: bundleName + "-0123456789"},
+		},
+		"if the current Secret changed, update the annotation": {
+			bundle:         gen.Bundle(bundleName, immutableSecret, withAnnotation(bundleName+"-0123456789")),
+			secretName:     bundleName + "-abcdef0123",
+			expAnnotations: map[string]string{trustapi.BundleSecretTargetAnnotationKey: bundleName + "-abcdef0123"},
+		},
+		"if Namespaces are deferred by the rollout, keep the annotation": {
+			bundle:         gen.Bundle(bundleName, immutableSecret, withAnnotation(bundleName+"-0123456789")),
+			secretName:     bundleName + "-abcdef0123",
+			deferred:       true,
+			expAnnotations: map[string]string{trustapi.BundleSecretTargetAnnotationKey: bundleName + "-0123456789"},
+		},
+		"if Secret target is not immutable, remove the annotation": {
+			bundle: gen.Bundle(bundleName, withAnnotation(bundleName+"-0123456789"), gen.SetBundleTargetSecret(trustapi.SecretTarget{
+				KeySelector: trustapi.KeySelector{Key: "trust.pem"},
+			})),
+			secretName:     bundleName,
+			expAnnotations: nil,
+		},
+		"if Bundle has no Secret target, do nothing": {
+			bundle:         gen.Bundle(bundleName),
+			secretName:     bundleName,
+			expAnnotations: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(test.bundle).
+				Build()
+
+			log, ctx := ktesting.NewTestContext(t)
+			b := &bundle{
+				client:  fakeClient,
+				Options: Options{Log: log},
+			}
+
+			var bundle trustapi.Bundle
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+			require.NoError(t, b.reconcileSecretTargetAnnotation(ctx, &bundle, test.secretName, !test.deferred))
+
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+			assert.Equal(t, test.expAnnotations, bundle.Annotations)
+		})
+	}
+}
+
+func Test_reconcileBundle_immutableSecretProgressiveRollout(t *testing.T) {
+	const (
+		trustNamespace = "trust-namespace"
+		bundleName     = "test-bundle"
+	)
+
+	bundleObj := gen.Bundle(bundleName,
+		gen.SetBundleTargetSecret(trustapi.SecretTarget{
+			KeySelector: trustapi.KeySelector{Key: "trust.pem"},
+			Immutable:   true,
+		}),
+		func(b *trustapi.Bundle) {
+			b.Spec.Sources = []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}}
+			b.Spec.Target.RolloutStrategy = &trustapi.RolloutStrategy{
+				Type:        trustapi.RolloutStrategyProgressive,
+				Progressive: &trustapi.ProgressiveRollout{NamespacesPerMinute: 1},
+			}
+		},
+	)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(
+			bundleObj,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: trustNamespace}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}},
+		).
+		WithStatusSubresource(bundleObj).
+		Build()
+
+	clock := fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	log, ctx := ktesting.NewTestContext(t)
+	b := &bundle{
+		client:   fakeClient,
+		recorder: record.NewFakeRecorder(100),
+		clock:    clock,
+		Options: Options{
+			Log:                  log,
+			Namespace:            trustNamespace,
+			SecretTargetsEnabled: true,
+			TunableOptions:       TunableOptions{TargetSyncConcurrency: 1},
+		},
+		targetReconciler: &target.Reconciler{
+			Client:                 fakeClient,
+			Cache:                  fakeClient,
+			PatchResourceOverwrite: func(context.Context, interface{}) error { return nil },
+		},
+	}
+
+	reconcile := func() *trustapi.Bundle {
+		_, statusPatch, err := b.reconcileBundle(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: bundleName}})
+		require.NoError(t, err)
+
+		var got trustapi.Bundle
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: bundleName}, &got))
+		if statusPatch != nil {
+			got.Status.Rollout = statusPatch.Rollout
+			require.NoError(t, fakeClient.Status().Update(ctx, &got))
+		}
+		return &got
+	}
+
+	// Only the first Namespace has been admitted, so the Secret doesn't exist
+	// in every Namespace yet.
+	got := reconcile()
+	require.NotNil(t, got.Status.Rollout)
+	assert.Equal(t, trustapi.BundleRolloutPhaseProgressing, got.Status.Rollout.Phase)
+	assert.NotContains(t, got.Annotations, trustapi.BundleSecretTargetAnnotationKey)
+
+	// Once every Namespace is admitted, the annotation points at the Secret.
+	clock.Step(time.Minute)
+	got = reconcile()
+	assert.Equal(t, trustapi.BundleRolloutPhaseComplete, got.Status.Rollout.Phase)
+	assert.Regexp(t, "^"+bundleName+"-[0-9a-f]+$", got.Annotations[trustapi.BundleSecretTargetAnnotationKey])
+}
@@ -19,10 +19,13 @@ package ssa_client
 import (
 	"context"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/csaupgrade"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
 )
 
 const (
@@ -48,3 +51,31 @@ func MigrateToApply(ctx context.Context, c client.Client, obj client.Object, opt
 	// No work to be done - already upgraded
 	return false, nil
 }
+
+// MIGRATION: MigrateBundleStatusToApply migrates the ownership of the Bundle
+// status fields to StatusFieldManager, from the Update operations of older
+// versions of trust-manager and from the Apply operations of FieldManager,
+// which applied the status before it had a field manager of its own. This is
+// required to ensure that applying the status also removes the fields which
+// were set before. Conflicts are returned as such, so that the migration can
+// be retried with the latest Bundle.
+func MigrateBundleStatusToApply(ctx context.Context, c client.Client, bundle *trustapi.Bundle) (bool, error) {
+	// The status fields applied by FieldManager are merged into
+	// StatusFieldManager like those of an Update operation.
+	obj := bundle.DeepCopy()
+	for i, entry := range obj.ManagedFields {
+		if entry.Manager == string(FieldManager) && entry.Operation == metav1.ManagedFieldsOperationApply && entry.Subresource == "status" {
+			obj.ManagedFields[i].Operation = metav1.ManagedFieldsOperationUpdate
+		}
+	}
+
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(obj, sets.New(string(FieldManager), crRegressionFieldManager), string(StatusFieldManager), csaupgrade.Subresource("status"))
+	if err != nil {
+		return false, err
+	}
+	if patch != nil {
+		return true, c.Patch(ctx, bundle, client.RawPatch(types.JSONPatchType, patch))
+	}
+	// No work to be done - already upgraded
+	return false, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssa_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func Test_MigrateBundleStatusToApply(t *testing.T) {
+	entry := func(manager string, operation metav1.ManagedFieldsOperationType, subresource, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:     manager,
+			Operation:   operation,
+			APIVersion:  trustapi.SchemeGroupVersion.Identifier(),
+			Subresource: subresource,
+			FieldsType:  "FieldsV1",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	tests := map[string]struct {
+		managedFields    []metav1.ManagedFieldsEntry
+		expMigrated      bool
+		expManagedFields []metav1.ManagedFieldsEntry
+	}{
+		"status applied by the target field manager is moved to the status field manager": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", metav1.ManagedFieldsOperationUpdate, "", `{"f:spec":{"f:sources":{}}}`),
+				entry("trust-manager", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:targetCount":{}}}`),
+			},
+			expMigrated: true,
+			expManagedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", metav1.ManagedFieldsOperationUpdate, "", `{"f:spec":{"f:sources":{}}}`),
+				entry("trust-manager-status", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:targetCount":{}}}`),
+			},
+		},
+		"status set by updates and applies of conflicting managers is merged into the status field manager": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("trust-manager-status", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:lastSyncTime":{}}}`),
+				entry("trust-manager", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:targetCount":{}}}`),
+				entry("Go-http-client", metav1.ManagedFieldsOperationUpdate, "status", `{"f:status":{"f:conditions":{}}}`),
+				entry("other-controller", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:rollout":{}}}`),
+			},
+			expMigrated: true,
+			expManagedFields: []metav1.ManagedFieldsEntry{
+				entry("trust-manager-status", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:conditions":{},"f:lastSyncTime":{},"f:targetCount":{}}}`),
+				entry("other-controller", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:rollout":{}}}`),
+			},
+		},
+		"targets applied by the target field manager are left alone": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("trust-manager", metav1.ManagedFieldsOperationApply, "", `{"f:metadata":{"f:finalizers":{}}}`),
+				entry("trust-manager-status", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:targetCount":{}}}`),
+			},
+			expMigrated: false,
+			expManagedFields: []metav1.ManagedFieldsEntry{
+				entry("trust-manager", metav1.ManagedFieldsOperationApply, "", `{"f:metadata":{"f:finalizers":{}}}`),
+				entry("trust-manager-status", metav1.ManagedFieldsOperationApply, "status", `{"f:status":{"f:targetCount":{}}}`),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle := &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle", ManagedFields: test.managedFields},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(bundle).
+				Build()
+
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(bundle), bundle))
+
+			migrated, err := MigrateBundleStatusToApply(context.TODO(), fakeClient, bundle)
+			require.NoError(t, err)
+			assert.Equal(t, test.expMigrated, migrated)
+
+			var got trustapi.Bundle
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(bundle), &got))
+			assert.Equal(t, test.expManagedFields, got.ManagedFields)
+		})
+	}
+}
+
+func Test_MigrateBundleStatusToApply_conflict(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-bundle",
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:     "trust-manager",
+				Operation:   metav1.ManagedFieldsOperationApply,
+				APIVersion:  trustapi.SchemeGroupVersion.Identifier(),
+				Subresource: "status",
+				FieldsType:  "FieldsV1",
+				FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:targetCount":{}}}`)},
+			}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(bundle).
+		Build()
+
+	// The migration is based on a stale Bundle, which must not overwrite the
+	// managed fields of the latest one.
+	var latest trustapi.Bundle
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(bundle), &latest))
+	stale := latest.DeepCopy()
+	latest.Labels = map[string]string{"foo": "bar"}
+	require.NoError(t, fakeClient.Update(context.TODO(), &latest))
+
+	_, err := MigrateBundleStatusToApply(context.TODO(), fakeClient, stale)
+	assert.True(t, apierrors.IsConflict(err), "expected a conflict, got %v", err)
+
+}
@@ -25,6 +25,16 @@ import (
 
 const (
 	FieldManager = client.FieldOwner("trust-manager")
+
+	// StatusFieldManager is the field manager which applies the Bundle
+	// status, so that its ownership is kept apart from the targets and other
+	// objects which FieldManager applies.
+	StatusFieldManager = client.FieldOwner("trust-manager-status")
+
+	// SyncSummaryFieldManager is the field manager which applies the sync
+	// summary ConfigMaps of Bundles, so that they never take over the fields
+	// of a target of the same name.
+	SyncSummaryFieldManager = client.FieldOwner("trust-manager-sync-summary")
 )
 
 type ApplyPatch struct {
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// appliedCache remembers the content last written to, or found up to date in,
+// each target, along with the resource version of the target at the time.
+// While a target keeps that resource version nothing else has changed it, so
+// if its content is still the same, the target can be skipped without
+// inspecting its managed fields.
+// The zero value is an empty cache.
+type appliedCache struct {
+	mu      sync.Mutex
+	entries map[Resource]appliedContent
+}
+
+// appliedContent identifies the content of a target.
+type appliedContent struct {
+	resourceVersion string
+	bundleUID       string
+	bundleHash      string
+	metadataHash    string
+	keys            string
+	ownership       trustapi.TargetOwnership
+}
+
+func newAppliedContent(resourceVersion string, bundle *trustapi.Bundle, bundleHash, metadataHash string, keys sets.Set[string]) appliedContent {
+	return appliedContent{
+		resourceVersion: resourceVersion,
+		bundleUID:       string(bundle.UID),
+		bundleHash:      bundleHash,
+		metadataHash:    metadataHash,
+		keys:            strings.Join(sets.List(keys), ","),
+		ownership:       bundle.Spec.Target.Ownership,
+	}
+}
+
+// upToDate returns true if the target was last found holding the given
+// content, at its current resource version, and still holds the hash
+// annotations of the content.
+func (c *appliedCache) upToDate(target Resource, obj *metav1.PartialObjectMetadata, content appliedContent) bool {
+	if obj.GetAnnotations()[trustapi.BundleHashAnnotationKey] != content.bundleHash ||
+		obj.GetAnnotations()[trustapi.BundleMetadataHashAnnotationKey] != content.metadataHash {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	applied, ok := c.entries[target]
+	return ok && applied == content
+}
+
+// has returns true if the content of the target is known.
+func (c *appliedCache) has(target Resource) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[target]
+	return ok
+}
+
+// record records the content of the target.
+func (c *appliedCache) record(target Resource, content appliedContent) {
+	if content.resourceVersion == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[Resource]appliedContent)
+	}
+	c.entries[target] = content
+}
+
+// forget drops the content of a target which was removed.
+func (c *appliedCache) forget(target Resource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, target)
+}
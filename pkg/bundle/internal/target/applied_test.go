@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+func Test_shouldApply(t *testing.T) {
+	const (
+		key        = "trust.pem"
+		bundleHash = "hash"
+	)
+
+	bundle := &trustapi.Bundle{
+		TypeMeta:   metav1.TypeMeta{APIVersion: trustapi.SchemeGroupVersion.String(), Kind: trustapi.BundleKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", UID: "uid"},
+	}
+	target := Resource{Kind: KindSecret, NamespacedName: types.NamespacedName{Namespace: "ns", Name: "bundle"}}
+	upToDate := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "bundle",
+			Namespace:       "ns",
+			ResourceVersion: "1",
+			Labels:          map[string]string{trustapi.BundleLabelKey: bundle.Name},
+			Annotations:     map[string]string{trustapi.BundleHashAnnotationKey: bundleHash},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: trustapi.SchemeGroupVersion.String(),
+				Kind:       trustapi.BundleKind,
+				Name:       bundle.Name,
+				UID:        bundle.UID,
+				Controller: ptr.To(true),
+			}},
+			ManagedFields: ssa_client.ManagedFieldEntries([]string{key}, nil),
+		},
+	}
+	keys := sets.New(key)
+	content := newAppliedContent(upToDate.ResourceVersion, bundle, bundleHash, "", keys)
+
+	_, ctx := ktesting.NewTestContext(t)
+	log := ktesting.NewLogger(t, ktesting.NewConfig())
+
+	t.Run("an up to date target is skipped and remembered", func(t *testing.T) {
+		r := &Reconciler{}
+		apply, err := r.shouldApply(ctx, target, log, upToDate, bundle, content, keys, false)
+		require.NoError(t, err)
+		assert.False(t, apply)
+		assert.True(t, r.applied.upToDate(target, upToDate, content))
+	})
+
+	t.Run("a forced apply applies a target once", func(t *testing.T) {
+		r := &Reconciler{ForceApply: true}
+		apply, err := r.shouldApply(ctx, target, log, upToDate, bundle, content, keys, false)
+		require.NoError(t, err)
+		assert.True(t, apply)
+
+		r.applied.record(target, content)
+		apply, err = r.shouldApply(ctx, target, log, upToDate, bundle, content, keys, false)
+		require.NoError(t, err)
+		assert.False(t, apply)
+	})
+
+	t.Run("a target changed since it was remembered is inspected again", func(t *testing.T) {
+		r := &Reconciler{}
+		r.applied.record(target, content)
+
+		changed := upToDate.DeepCopy()
+		changed.ResourceVersion = "2"
+		changed.Labels = nil
+		apply, err := r.shouldApply(ctx, target, log, changed, bundle, newAppliedContent(changed.ResourceVersion, bundle, bundleHash, "", keys), keys, false)
+		require.NoError(t, err)
+		assert.True(t, apply)
+	})
+
+	t.Run("a changed bundle is applied", func(t *testing.T) {
+		r := &Reconciler{}
+		r.applied.record(target, content)
+
+		apply, err := r.shouldApply(ctx, target, log, upToDate, bundle, newAppliedContent(upToDate.ResourceVersion, bundle, "new-hash", "", keys), keys, false)
+		require.NoError(t, err)
+		assert.True(t, apply)
+	})
+
+	t.Run("a resync applies a remembered target again", func(t *testing.T) {
+		r := &Reconciler{}
+		r.applied.record(target, content)
+
+		apply, err := r.shouldApply(ctx, target, log, upToDate, bundle, content, keys, true)
+		require.NoError(t, err)
+		assert.True(t, apply)
+	})
+
+	t.Run("a forced apply applies a removed target again", func(t *testing.T) {
+		r := &Reconciler{ForceApply: true}
+		r.applied.record(target, content)
+		r.applied.forget(target)
+
+		apply, err := r.shouldApply(ctx, target, log, upToDate, bundle, content, keys, false)
+		require.NoError(t, err)
+		assert.True(t, apply)
+	})
+}
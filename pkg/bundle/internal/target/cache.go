@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/metrics"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// EncodingCache caches the additional formats encoded for Bundles, keyed by
+// the content of the bundle and the format options. Encoding JKS and PKCS#12
+// truststores is expensive, and a Bundle is reconciled on every change to
+// its targets or Namespaces, usually without any change to its sources.
+//
+// Entries are held for as long as a Bundle uses them: those which a Bundle
+// stops using, because its sources or formats changed, are dropped when the
+// Bundle's reconcile is committed, and all of its entries are dropped when
+// the Bundle is forgotten.
+// A nil EncodingCache is valid and doesn't cache anything.
+type EncodingCache struct {
+	mu sync.Mutex
+
+	// entries holds the encoded additional formats by cache key.
+	entries map[string]*cacheEntry
+
+	// held holds the keys of the entries used by each Bundle as of its last
+	// committed reconcile.
+	held map[string]sets.Set[string]
+
+	// pending holds the keys of the entries used by each Bundle since its
+	// last committed reconcile.
+	pending map[string]sets.Set[string]
+}
+
+type cacheEntry struct {
+	binaryData map[string][]byte
+
+	// refs is the number of Bundles holding the entry.
+	refs int
+}
+
+// NewEncodingCache returns an empty EncodingCache.
+func NewEncodingCache() *EncodingCache {
+	return &EncodingCache{
+		entries: make(map[string]*cacheEntry),
+		held:    make(map[string]sets.Set[string]),
+		pending: make(map[string]sets.Set[string]),
+	}
+}
+
+// Populate populates data from the pool like Data.Populate, reusing the
+// additional formats which were previously encoded for the same certificates
+// and formats.
+func (c *EncodingCache) Populate(data *Data, bundleName string, pool *util.CertPool, formats *trustapi.AdditionalFormats) error {
+	if c == nil || formats == nil {
+		return data.Populate(pool, formats)
+	}
+
+	data.Data = pool.PEM()
+
+	key, err := cacheKey(data.Data, formats)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok {
+		metrics.RecordEncodingCacheLookup(metrics.CacheResultHit)
+	} else {
+		metrics.RecordEncodingCacheLookup(metrics.CacheResultMiss)
+
+		if err := data.Populate(pool, formats); err != nil {
+			return err
+		}
+		entry = &cacheEntry{binaryData: data.BinaryData}
+	}
+	data.BinaryData = maps.Clone(entry.binaryData)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists {
+		c.entries[key] = entry
+	}
+	if c.pending[bundleName] == nil {
+		c.pending[bundleName] = sets.New[string]()
+	}
+	c.pending[bundleName].Insert(key)
+	c.updateEntriesMetric()
+
+	return nil
+}
+
+// Commit records the entries used by the named Bundle since its last commit
+// as the entries it holds, and drops entries which no Bundle holds anymore.
+func (c *EncodingCache) Commit(bundleName string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.replaceHeld(bundleName, c.pending[bundleName])
+	delete(c.pending, bundleName)
+}
+
+// Forget drops the entries held by the named Bundle, which no longer exists.
+func (c *EncodingCache) Forget(bundleName string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.replaceHeld(bundleName, nil)
+	delete(c.pending, bundleName)
+}
+
+// replaceHeld replaces the keys held by the named Bundle. Must be called with
+// the lock held.
+func (c *EncodingCache) replaceHeld(bundleName string, keys sets.Set[string]) {
+	previous := c.held[bundleName]
+
+	for key := range keys.Difference(previous) {
+		if entry, ok := c.entries[key]; ok {
+			entry.refs++
+		}
+	}
+
+	for key := range previous.Difference(keys) {
+		if entry, ok := c.entries[key]; ok {
+			entry.refs--
+		}
+	}
+
+	// Entries which were encoded but never committed, for example as a later
+	// target of the Bundle failed, are dropped along with released ones.
+	for key, entry := range c.entries {
+		if entry.refs <= 0 && !c.isPending(key) {
+			delete(c.entries, key)
+		}
+	}
+
+	if keys.Len() == 0 {
+		delete(c.held, bundleName)
+	} else {
+		c.held[bundleName] = keys
+	}
+
+	c.updateEntriesMetric()
+}
+
+// isPending returns true if a reconcile in progress uses the entry. Must be
+// called with the lock held.
+func (c *EncodingCache) isPending(key string) bool {
+	for _, keys := range c.pending {
+		if keys.Has(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateEntriesMetric must be called with the lock held.
+func (c *EncodingCache) updateEntriesMetric() {
+	metrics.SetEncodingCacheEntries(len(c.entries))
+}
+
+// cacheKey returns the key of the additional formats encoded from the given
+// PEM bundle with the given options.
+func cacheKey(bundlePEM string, formats *trustapi.AdditionalFormats) (string, error) {
+	options, err := json.Marshal(formats)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal additional formats: %w", err)
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write([]byte(bundlePEM))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write(options)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func TestEncodingCache(t *testing.T) {
+	formats := &trustapi.AdditionalFormats{
+		PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: "trust.p12"}, Password: ptr.To("")},
+	}
+	pool := certPool(t, dummy.TestCertificate1)
+	changedPool := certPool(t, dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2))
+
+	cache := NewEncodingCache()
+
+	var first Data
+	require.NoError(t, cache.Populate(&first, "bundle-a", pool, formats))
+	cache.Commit("bundle-a")
+	assert.Len(t, cache.entries, 1)
+
+	// An unchanged bundle reuses the encoded formats.
+	var second Data
+	require.NoError(t, cache.Populate(&second, "bundle-a", pool, formats))
+	cache.Commit("bundle-a")
+	assert.Equal(t, first, second)
+	assert.Len(t, cache.entries, 1)
+
+	// Another Bundle with the same content shares the entry.
+	var other Data
+	require.NoError(t, cache.Populate(&other, "bundle-b", pool, formats))
+	cache.Commit("bundle-b")
+	assert.Equal(t, first, other)
+	assert.Len(t, cache.entries, 1)
+
+	// A changed source invalidates the entry once no Bundle holds it.
+	var changed Data
+	require.NoError(t, cache.Populate(&changed, "bundle-a", changedPool, formats))
+	cache.Commit("bundle-a")
+	assert.NotEqual(t, first.BinaryData, changed.BinaryData)
+	assert.Len(t, cache.entries, 2)
+
+	cache.Forget("bundle-b")
+	assert.Len(t, cache.entries, 1)
+
+	cache.Forget("bundle-a")
+	assert.Empty(t, cache.entries)
+	assert.Empty(t, cache.held)
+}
+
+func TestEncodingCacheNil(t *testing.T) {
+	formats := &trustapi.AdditionalFormats{
+		JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.jks"}, Password: ptr.To(trustapi.DefaultJKSPassword)},
+	}
+
+	var cache *EncodingCache
+	var data Data
+	require.NoError(t, cache.Populate(&data, "bundle", certPool(t, dummy.TestCertificate1), formats))
+	assert.Contains(t, data.BinaryData, "trust.jks")
+
+	cache.Commit("bundle")
+	cache.Forget("bundle")
+}
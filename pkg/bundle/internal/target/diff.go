@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"bytes"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+// Change is the change which syncing a target would make to it.
+type Change struct {
+	// Create is true if the target doesn't exist and would be created, and
+	// Delete if it would be deleted.
+	Create, Delete bool
+
+	// Added, Changed and Removed are the keys of the target which would be
+	// added, changed and removed, sorted.
+	Added, Changed, Removed []string
+}
+
+// Empty returns true if syncing the target wouldn't change it.
+func (c Change) Empty() bool {
+	return !c.Create && !c.Delete && len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// Diff returns the change which syncing the target with the resolved bundle
+// would make to the data of obj, the current ConfigMap or Secret of the
+// target, or nil if it doesn't exist. Only keys are compared; the metadata of
+// targets is ignored.
+//
+// Keys derived from the PEM bundle, such as additional formats and
+// signatures, can't always be reproduced: PKCS#12 encodings are salted, and
+// signatures need the signing key. They are reported as changed whenever the
+// PEM bundle is, and resolvedBundle needn't hold a signature. A separate
+// additional formats target of a signed bundle is only reported unchanged if
+// resolvedBundle holds the signature.
+func Diff(target Resource, obj client.Object, bundle *trustapi.Bundle, resolvedBundle Data, shouldExist bool) (Change, error) {
+	var (
+		live       = map[string][]byte{}
+		fieldNames []string
+		objMeta    metav1.ObjectMeta
+	)
+	switch obj := obj.(type) {
+	case nil:
+	case *corev1.ConfigMap:
+		for key, value := range obj.Data {
+			live[key] = []byte(value)
+		}
+		for key, value := range obj.BinaryData {
+			live[key] = value
+		}
+		fieldNames, objMeta = []string{"data", "binaryData"}, obj.ObjectMeta
+	case *corev1.Secret:
+		live = obj.Data
+		fieldNames, objMeta = []string{"data"}, obj.ObjectMeta
+	default:
+		return Change{}, fmt.Errorf("don't know how to diff target of type %T", obj)
+	}
+
+	if obj == nil {
+		if !shouldExist {
+			return Change{}, nil
+		}
+		expected, _ := expectedContent(target, bundle, resolvedBundle)
+		return Change{Create: true, Added: sets.List(sets.KeySet(expected))}, nil
+	}
+
+	managed, err := listManagedProperties(&metav1.PartialObjectMetadata{ObjectMeta: objMeta}, ssa_client.FieldManager, fieldNames...)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to list managed keys of %s %s: %w", target.Kind, target.NamespacedName, err)
+	}
+
+	if !shouldExist {
+		// Immutable Secrets of previous bundles are deleted outright, and
+		// other targets once they hold no other keys.
+		removed := managed.Intersection(sets.KeySet(live))
+		change := Change{Delete: isImmutableSecretName(target.Name, bundle) || removed.Len() == len(live)}
+		if removed.Len() > 0 {
+			change.Removed = sets.List(removed)
+		}
+		return change, nil
+	}
+
+	expected, derived := expectedContent(target, bundle, resolvedBundle)
+
+	var pemKey string
+	switch target.Kind {
+	case KindConfigMap:
+		pemKey = bundle.Spec.Target.ConfigMap.Key
+	case KindSecret:
+		pemKey = bundle.Spec.Target.Secret.Key
+	}
+	// A separate additional formats target doesn't hold the PEM bundle, so
+	// whether it changed is told by the hash of the bundle instead.
+	pemChanged := !bytes.Equal(live[pemKey], expected[pemKey])
+	if _, ok := expected[pemKey]; !ok {
+		pemChanged = objMeta.Annotations[trustapi.BundleHashAnnotationKey] != resolvedBundle.hash(bundle.Spec.Target.AdditionalFormats)
+	}
+
+	var change Change
+	for _, key := range sets.List(sets.KeySet(expected)) {
+		value, ok := live[key]
+		switch {
+		case !ok:
+			change.Added = append(change.Added, key)
+		case derived.Has(key):
+			if pemChanged {
+				change.Changed = append(change.Changed, key)
+			}
+		case !bytes.Equal(value, expected[key]):
+			change.Changed = append(change.Changed, key)
+		}
+	}
+	if removed := managed.Intersection(sets.KeySet(live)).Difference(sets.KeySet(expected)); removed.Len() > 0 {
+		change.Removed = sets.List(removed)
+	}
+
+	return change, nil
+}
+
+// expectedContent returns the content which the target should hold, as bytes,
+// along with the keys derived from the PEM bundle which may not be
+// reproducible. The signature key of a signed bundle is included even if
+// resolvedBundle holds no signature.
+func expectedContent(target Resource, bundle *trustapi.Bundle, resolvedBundle Data) (map[string][]byte, sets.Set[string]) {
+	data, binData := Content(target, bundle, resolvedBundle)
+
+	expected := make(map[string][]byte, len(data)+len(binData))
+	derived := sets.KeySet(binData)
+	for key, value := range data {
+		expected[key] = []byte(value)
+	}
+	for key, value := range binData {
+		expected[key] = value
+	}
+
+	formatsTarget := bundle.Spec.Target.AdditionalFormatsTarget
+	if signature := bundle.Spec.Target.Signature; signature != nil && (formatsTarget == nil || target.Name != formatsTarget.Name) {
+		var key string
+		switch target.Kind {
+		case KindConfigMap:
+			key = bundle.Spec.Target.ConfigMap.Key
+		case KindSecret:
+			key = bundle.Spec.Target.Secret.Key
+		}
+		signatureKey := SignatureKey(signature, key)
+		if _, ok := expected[signatureKey]; !ok {
+			expected[signatureKey] = nil
+		}
+		derived.Insert(signatureKey)
+	}
+
+	return expected, derived
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+func Test_Diff(t *testing.T) {
+	const key = "trust.pem"
+
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+		Spec: trustapi.BundleSpec{Target: trustapi.BundleTarget{
+			ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
+			AdditionalFormats: &trustapi.AdditionalFormats{JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "bundle.jks"}, Password: ptr.To(trustapi.DefaultJKSPassword)}},
+		}},
+	}
+	resource := Resource{Kind: KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns", Name: "bundle"}}
+	data := Data{Data: "new", BinaryData: map[string][]byte{"bundle.jks": []byte("jks")}}
+
+	configMap := func(pem string, managed []string, data map[string]string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bundle", ManagedFields: ssa_client.ManagedFieldEntries(managed, []string{"bundle.jks"})},
+			Data:       map[string]string{key: pem},
+			BinaryData: map[string][]byte{"bundle.jks": []byte("old jks")},
+		}
+		for k, v := range data {
+			cm.Data[k] = v
+		}
+		return cm
+	}
+
+	tests := map[string]struct {
+		obj         client.Object
+		shouldExist bool
+
+		expChange Change
+	}{
+		"target doesn't exist": {
+			shouldExist: true,
+			expChange:   Change{Create: true, Added: []string{"bundle.jks", key}},
+		},
+		"target doesn't exist and shouldn't": {},
+		"target is up to date": {
+			obj:         configMap("new", []string{key}, nil),
+			shouldExist: true,
+		},
+		"PEM bundle changed": {
+			obj:         configMap("old", []string{key}, nil),
+			shouldExist: true,
+			expChange:   Change{Changed: []string{"bundle.jks", key}},
+		},
+		"managed key is removed, unmanaged key is kept": {
+			obj:         configMap("new", []string{key, "old.pem"}, map[string]string{"old.pem": "old", "other": "other"}),
+			shouldExist: true,
+			expChange:   Change{Removed: []string{"old.pem"}},
+		},
+		"target which shouldn't exist is deleted": {
+			obj:       configMap("old", []string{key}, nil),
+			expChange: Change{Delete: true, Removed: []string{"bundle.jks", key}},
+		},
+		"target with unmanaged keys which shouldn't exist is only emptied of managed keys": {
+			obj:       configMap("old", []string{key}, map[string]string{"other": "other"}),
+			expChange: Change{Removed: []string{"bundle.jks", key}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			change, err := Diff(resource, test.obj, bundle, data, test.shouldExist)
+			require.NoError(t, err)
+			assert.Equal(t, test.expChange, change)
+		})
+	}
+}
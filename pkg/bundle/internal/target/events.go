@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+// The reasons of the Events recorded on targets.
+const (
+	ReasonTargetCreated     = "Created"
+	ReasonTargetUpdated     = "Updated"
+	ReasonTargetKeysRemoved = "KeysRemoved"
+	ReasonTargetDeleted     = "Deleted"
+)
+
+// recordApplied records that the target was created or changed by applying
+// the bundle. Updates describe which keys were added and removed, and whether
+// the bundle changed. Applies which didn't change the target are not
+// recorded.
+func (r *Reconciler) recordApplied(target Resource, before *metav1.PartialObjectMetadata, uid types.UID, resourceVersion string, bundle *trustapi.Bundle, bundleHash string, keys sets.Set[string]) {
+	if before.ResourceVersion == resourceVersion {
+		return
+	}
+
+	action := audit.ActionUpdate
+	if before.ResourceVersion == "" {
+		action = audit.ActionCreate
+	}
+	r.recordAudit(action, target, before, bundle, bundleHash)
+
+	if r.Recorder == nil {
+		return
+	}
+
+	if before.ResourceVersion == "" {
+		r.recordEvent(target, uid, ReasonTargetCreated,
+			fmt.Sprintf("Created by trust-manager to hold the bundle of Bundle %q", bundle.Name))
+		return
+	}
+
+	var changes []string
+	if before.GetAnnotations()[trustapi.BundleHashAnnotationKey] != bundleHash {
+		changes = append(changes, "the bundle changed")
+	}
+
+	fieldNames := []string{"data"}
+	if target.Kind == KindConfigMap {
+		fieldNames = append(fieldNames, "binaryData")
+	}
+	if previousKeys, err := listManagedProperties(before, ssa_client.FieldManager, fieldNames...); err == nil {
+		if added := keys.Difference(previousKeys); added.Len() > 0 {
+			changes = append(changes, fmt.Sprintf("added keys %s", strings.Join(sets.List(added), ", ")))
+		}
+		if removed := previousKeys.Difference(keys); removed.Len() > 0 {
+			changes = append(changes, fmt.Sprintf("removed keys %s", strings.Join(sets.List(removed), ", ")))
+		}
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "the metadata changed")
+	}
+
+	r.recordEvent(target, uid, ReasonTargetUpdated,
+		fmt.Sprintf("Updated by trust-manager from Bundle %q: %s", bundle.Name, strings.Join(changes, "; ")))
+}
+
+// recordKeysRemoved records that the keys of the Bundle were removed from the
+// target, which is left in place as it holds other data.
+func (r *Reconciler) recordKeysRemoved(target Resource, before *metav1.PartialObjectMetadata, resourceVersion string, bundle *trustapi.Bundle) {
+	if before.ResourceVersion == resourceVersion {
+		return
+	}
+	r.recordAudit(audit.ActionUpdate, target, before, bundle, "")
+	r.recordEvent(target, before.UID, ReasonTargetKeysRemoved,
+		fmt.Sprintf("trust-manager removed the keys of Bundle %q, as the Bundle no longer targets this Namespace", bundle.Name))
+}
+
+// recordDeleted records that the target was deleted.
+func (r *Reconciler) recordDeleted(target Resource, before *metav1.PartialObjectMetadata, bundle *trustapi.Bundle) {
+	r.recordAudit(audit.ActionDelete, target, before, bundle, "")
+	r.recordEvent(target, before.UID, ReasonTargetDeleted,
+		fmt.Sprintf("Deleted by trust-manager, as Bundle %q no longer targets this Namespace", bundle.Name))
+}
+
+// recordEvent records a Normal Event on the target, if Events are recorded on
+// targets. Events are recorded in the Namespace of the target, so that they
+// can be seen by those without access to Bundles.
+func (r *Reconciler) recordEvent(target Resource, uid types.UID, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+
+	obj := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{Kind: string(target.Kind), APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.Name,
+			Namespace: target.Namespace,
+			UID:       uid,
+		},
+	}
+	r.Recorder.Event(obj, corev1.EventTypeNormal, reason, message)
+}
+
+// recordAudit records the change made to the target in the audit log, along
+// with the hash of the bundle it held before and after the change.
+func (r *Reconciler) recordAudit(action audit.Action, target Resource, before *metav1.PartialObjectMetadata, bundle *trustapi.Bundle, bundleHash string) {
+	r.Audit.Record(audit.Entry{
+		Action:    action,
+		Bundle:    bundle.Name,
+		Kind:      string(target.Kind),
+		Namespace: target.Namespace,
+		Name:      target.Name,
+		OldHash:   before.GetAnnotations()[trustapi.BundleHashAnnotationKey],
+		NewHash:   bundleHash,
+	})
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+func Test_recordApplied(t *testing.T) {
+	bundle := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: bundleName}}
+	target := Resource{Kind: KindConfigMap, NamespacedName: types.NamespacedName{Name: bundleName, Namespace: "test-namespace"}}
+
+	existing := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			ResourceVersion: "1",
+			Annotations:     map[string]string{trustapi.BundleHashAnnotationKey: "old-hash"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:   string(ssa_client.FieldManager),
+				Operation: metav1.ManagedFieldsOperationApply,
+				FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:trust.pem":{}},"f:binaryData":{"f:trust.jks":{}}}`)},
+			}},
+		},
+	}
+
+	tests := map[string]struct {
+		before          *metav1.PartialObjectMetadata
+		resourceVersion string
+		bundleHash      string
+		keys            sets.Set[string]
+		expEvents       []string
+		expAudit        []string
+	}{
+		"created target": {
+			before:          &metav1.PartialObjectMetadata{},
+			resourceVersion: "1",
+			keys:            sets.New(key),
+			expEvents:       []string{`Normal Created Created by trust-manager to hold the bundle of Bundle "test-bundle"`},
+			expAudit:        []string{"create  "},
+		},
+		"target with a new bundle and keys": {
+			before:          existing,
+			resourceVersion: "2",
+			bundleHash:      "new-hash",
+			keys:            sets.New(key, pkcs12Key),
+			expEvents:       []string{`Normal Updated Updated by trust-manager from Bundle "test-bundle": the bundle changed; added keys trust.p12; removed keys trust.jks`},
+			expAudit:        []string{"update old-hash new-hash"},
+		},
+		"target with new metadata": {
+			before:          existing,
+			resourceVersion: "2",
+			bundleHash:      "old-hash",
+			keys:            sets.New(key, jksKey),
+			expEvents:       []string{`Normal Updated Updated by trust-manager from Bundle "test-bundle": the metadata changed`},
+			expAudit:        []string{"update old-hash old-hash"},
+		},
+		"unchanged target": {
+			before:          existing,
+			resourceVersion: "1",
+			bundleHash:      "new-hash",
+			keys:            sets.New(key),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			var auditOut bytes.Buffer
+			r := &Reconciler{Recorder: recorder, Audit: audit.NewWriterLog(&auditOut, logr.Discard())}
+
+			r.recordApplied(target, test.before, "uid", test.resourceVersion, bundle, test.bundleHash, test.keys)
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, test.expEvents, events)
+
+			var entries []string
+			decoder := json.NewDecoder(&auditOut)
+			for decoder.More() {
+				var entry audit.Entry
+				require.NoError(t, decoder.Decode(&entry))
+				entries = append(entries, fmt.Sprintf("%s %s %s", entry.Action, entry.OldHash, entry.NewHash))
+			}
+			assert.Equal(t, test.expAudit, entries)
+		})
+	}
+}
+
+func Test_syncSecretTarget_deletedEvent(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName, UID: "bundle-uid"},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: trustapi.TLSSecretTargetKey}, Type: corev1.SecretTypeTLS},
+			},
+		},
+	}
+
+	// A kubernetes.io/tls Secret created for the Bundle, which can't lose
+	// its tls.crt and tls.key entries.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundleName,
+			Namespace: "test-namespace",
+			UID:       "secret-uid",
+			Labels:    map[string]string{trustapi.BundleLabelKey: bundleName},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: trustapi.SchemeGroupVersion.String(),
+				Kind:       trustapi.BundleKind,
+				Name:       bundleName,
+				UID:        bundle.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(secret).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fakeClient,
+		Cache:    fakeClient,
+		Recorder: recorder,
+		PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
+			return apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("Secret").GroupKind(), bundleName, nil)
+		},
+	}
+
+	log, ctx := ktesting.NewTestContext(t)
+	synced, err := r.Sync(ctx, Resource{
+		Kind:           KindSecret,
+		NamespacedName: types.NamespacedName{Name: bundleName, Namespace: "test-namespace"},
+	}, bundle, Data{Data: data}, log, false)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+
+	close(recorder.Events)
+	var events []string
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	assert.Equal(t, []string{`Normal Deleted Deleted by trust-manager, as Bundle "test-bundle" no longer targets this Namespace`}, events)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/structured-merge-diff/fieldpath"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// mergeExisting returns the union of the given PEM bundle and the certificates
+// which other field managers wrote to the key of the target, along with the
+// fingerprints of the merged certificates.
+// Certificates are only taken from the current value of the key if another
+// field manager owns it, or if they were merged in a previous sync; this way,
+// certificates which are removed from the Bundle sources are also removed
+// from the target.
+func (r *Reconciler) mergeExisting(ctx context.Context, target Resource, targetObj *metav1.PartialObjectMetadata, key string, bundlePEM string) (string, []string, error) {
+	current, err := r.currentValue(ctx, target, key)
+	if err != nil {
+		return "", nil, err
+	}
+	if strings.TrimSpace(current) == "" {
+		return bundlePEM, nil, nil
+	}
+
+	existing := util.NewCertPool()
+	if err := existing.AddCertsFromPEM([]byte(current)); err != nil {
+		return "", nil, fmt.Errorf("failed to parse existing data of %s %s key %q: %w", target.Kind, target.NamespacedName, key, err)
+	}
+
+	// The merged bundle ends with a newline if the bundle does.
+	merged := util.NewCertPool(util.WithTrailingNewline(strings.HasSuffix(bundlePEM, "\n")))
+	if bundlePEM != "" {
+		if err := merged.AddCertsFromPEM([]byte(bundlePEM)); err != nil {
+			return "", nil, fmt.Errorf("failed to parse bundle: %w", err)
+		}
+	}
+	own := sets.New[string]()
+	for cert := range merged.All() {
+		own.Insert(cert.Fingerprint)
+	}
+
+	ownedByOthers, err := keyManagedByOthers(targetObj, key)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list managed properties: %w", err)
+	}
+	previouslyMerged := sets.New(strings.Split(targetObj.GetAnnotations()[trustapi.BundleMergedCertificatesAnnotationKey], ",")...)
+
+	var fingerprints []string
+	for cert := range existing.All() {
+		if own.Has(cert.Fingerprint) || (!ownedByOthers && !previouslyMerged.Has(cert.Fingerprint)) {
+			continue
+		}
+		if err := merged.AddCertsFromPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate.Raw})); err != nil {
+			return "", nil, fmt.Errorf("failed to merge existing certificate: %w", err)
+		}
+		fingerprints = append(fingerprints, cert.Fingerprint)
+	}
+	slices.Sort(fingerprints)
+
+	return merged.PEM(), fingerprints, nil
+}
+
+// currentValue returns the current value of the key in the target. Targets
+// are only cached as metadata, so the full object is read from the API server.
+func (r *Reconciler) currentValue(ctx context.Context, target Resource, key string) (string, error) {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	switch target.Kind {
+	case KindConfigMap:
+		var configMap corev1.ConfigMap
+		if err := reader.Get(ctx, target.NamespacedName, &configMap); err != nil {
+			return "", fmt.Errorf("failed to get %s %s: %w", target.Kind, target.NamespacedName, err)
+		}
+		return configMap.Data[key], nil
+	case KindSecret:
+		var secret corev1.Secret
+		if err := reader.Get(ctx, target.NamespacedName, &secret); err != nil {
+			return "", fmt.Errorf("failed to get %s %s: %w", target.Kind, target.NamespacedName, err)
+		}
+		return string(secret.Data[key]), nil
+	default:
+		return "", fmt.Errorf("don't know how to read target of kind: %s", target.Kind)
+	}
+}
+
+// keyManagedByOthers returns true if a field manager other than trust-manager
+// owns the given data key of the target.
+func keyManagedByOthers(obj *metav1.PartialObjectMetadata, key string) (bool, error) {
+	path := fieldpath.MakePathOrDie("data", key)
+
+	for _, managedField := range obj.GetManagedFields() {
+		if managedField.Manager == string(ssa_client.FieldManager) || managedField.FieldsV1 == nil {
+			continue
+		}
+
+		var fieldset fieldpath.Set
+		if err := fieldset.FromJSON(bytes.NewReader(managedField.FieldsV1.Raw)); err != nil {
+			return false, err
+		}
+		if fieldset.Has(path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_mergeExisting(t *testing.T) {
+	const key = "trust.pem"
+
+	legacyOperator := metav1.ManagedFieldsEntry{
+		Manager:   "legacy-operator",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:trust.pem":{}}}`)},
+	}
+
+	tests := map[string]struct {
+		current       string
+		managedFields []metav1.ManagedFieldsEntry
+		annotations   map[string]string
+
+		expCerts        []string
+		expFingerprints []string
+	}{
+		"an empty key holds only the bundle": {
+			current:       "",
+			managedFields: []metav1.ManagedFieldsEntry{legacyOperator},
+			expCerts:      []string{dummy.TestCertificate1},
+		},
+		"certificates written by another field manager are kept": {
+			current:         dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2),
+			managedFields:   []metav1.ManagedFieldsEntry{legacyOperator},
+			expCerts:        []string{dummy.TestCertificate1, dummy.TestCertificate2},
+			expFingerprints: []string{certFingerprint(t, dummy.TestCertificate2)},
+		},
+		"certificates merged in a previous sync are kept": {
+			current:         dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2, dummy.TestCertificate3),
+			managedFields:   ssa_client.ManagedFieldEntries([]string{key}, nil),
+			annotations:     map[string]string{trustapi.BundleMergedCertificatesAnnotationKey: certFingerprint(t, dummy.TestCertificate2)},
+			expCerts:        []string{dummy.TestCertificate1, dummy.TestCertificate2},
+			expFingerprints: []string{certFingerprint(t, dummy.TestCertificate2)},
+		},
+		"certificates removed from the bundle are dropped when trust-manager owns the key": {
+			current:       dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate3),
+			managedFields: ssa_client.ManagedFieldEntries([]string{key}, nil),
+			expCerts:      []string{dummy.TestCertificate1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "bundle",
+					Namespace:     "ns",
+					Annotations:   test.annotations,
+					ManagedFields: test.managedFields,
+				},
+				Data: map[string]string{key: test.current},
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+			targetObj := &metav1.PartialObjectMetadata{ObjectMeta: configMap.ObjectMeta}
+			target := Resource{Kind: KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns", Name: "bundle"}}
+
+			_, ctx := ktesting.NewTestContext(t)
+			r := &Reconciler{Client: fakeClient, APIReader: fakeClient}
+			merged, fingerprints, err := r.mergeExisting(ctx, target, targetObj, key, dummy.TestCertificate1)
+			require.NoError(t, err)
+
+			var expFingerprints []string
+			for _, cert := range test.expCerts {
+				expFingerprints = append(expFingerprints, certFingerprint(t, cert))
+			}
+			var gotFingerprints []string
+			for cert := range certPool(t, merged).All() {
+				gotFingerprints = append(gotFingerprints, cert.Fingerprint)
+			}
+			assert.ElementsMatch(t, expFingerprints, gotFingerprints)
+			assert.Equal(t, test.expFingerprints, fingerprints)
+		})
+	}
+}
+
+func certFingerprint(t *testing.T, cert string) string {
+	for info := range certPool(t, cert).All() {
+		return info.Fingerprint
+	}
+	t.Fatal("no certificate found")
+	return ""
+}
+
+func certPool(t *testing.T, pemData string) *util.CertPool {
+	pool := util.NewCertPool()
+	require.NoError(t, pool.AddCertsFromPEM([]byte(pemData)))
+	return pool
+}
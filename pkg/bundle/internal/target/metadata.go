@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package target
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// MetadataHash returns the hash of the target metadata of the Bundle, and of
+// the variables which only change the rendered metadata, not the bundle
+// itself. It's empty if the Bundle has no target metadata.
+func MetadataHash(metadata *trustapi.TargetMetadata, packageVersion string) string {
+	if metadata == nil {
+		return ""
+	}
+
+	hash := sha256.New()
+
+	// Maps are encoded with sorted keys, so the encoding is stable.
+	_ = json.NewEncoder(hash).Encode(metadata)
+	_, _ = hash.Write([]byte(packageVersion))
+
+	hashValue := [32]byte{}
+	hash.Sum(hashValue[:0])
+
+	return hex.EncodeToString(hashValue[:])
+}
+
+// renderMetadata returns the labels and annotations which the target metadata
+// of the Bundle sets on its targets, including the metadata hash annotation.
+func (r *Reconciler) renderMetadata(bundle *trustapi.Bundle, resolvedBundle Data, bundleHash, metadataHash string) (map[string]string, map[string]string, error) {
+	metadata := bundle.Spec.Target.Metadata
+	if metadata == nil {
+		return nil, nil, nil
+	}
+
+	labels, annotations, err := metadata.Render(trustapi.TargetMetadataVariables{
+		BundleName:     bundle.Name,
+		BundleHash:     bundleHash,
+		PackageVersion: resolvedBundle.PackageVersion,
+		SyncTimestamp:  r.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid target metadata: %w", err)
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[trustapi.BundleMetadataHashAnnotationKey] = metadataHash
+
+	return labels, annotations, nil
+}
+
+func (r *Reconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
@@ -23,7 +23,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -32,15 +36,20 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	coreapplyconfig "k8s.io/client-go/applyconfigurations/core/v1"
 	metav1applyconfig "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/structured-merge-diff/fieldpath"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
 	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
-	"github.com/cert-manager/trust-manager/pkg/bundle/internal/truststore"
+	"github.com/cert-manager/trust-manager/pkg/metrics"
+	"github.com/cert-manager/trust-manager/pkg/truststore"
 	"github.com/cert-manager/trust-manager/pkg/util"
 )
 
@@ -52,9 +61,42 @@ type Reconciler struct {
 	// resources that are used as targets for Bundles.
 	Cache client.Reader
 
+	// APIReader is an uncached reader, used to read the current data of
+	// targets of Bundles with the Union merge strategy, the type of Secret
+	// targets which failed to apply, and targets missing from the cache as
+	// they have no bundle label. Client is used if nil.
+	APIReader client.Reader
+
 	// PatchResourceOverwrite allows use to override the patchResource function
 	// it is used for testing purposes
 	PatchResourceOverwrite func(ctx context.Context, obj interface{}) error
+
+	// ForceApply, if true, applies each target the first time it is synced,
+	// even if its hash annotation and managed fields show it to be up to
+	// date. Targets are only skipped once they have been applied since
+	// startup and haven't changed since.
+	ForceApply bool
+
+	// Clock is the clock which sync timestamps in target metadata are taken
+	// from. The real clock is used if nil.
+	Clock clock.PassiveClock
+
+	// Recorder, if set, records Events on targets as they are created,
+	// updated and deleted, so that changes can be traced from the Namespace
+	// of the target.
+	Recorder record.EventRecorder
+
+	// Audit, if set, records every change made to targets to the audit log.
+	Audit *audit.Log
+
+	// Unowned, if true, writes targets without an owner reference to their
+	// Bundle, which is needed for targets in remote clusters where the
+	// Bundle doesn't exist. Such targets are known by their bundle label.
+	Unowned bool
+
+	// applied remembers the content of targets which were synced, so that
+	// unchanged targets can be skipped cheaply.
+	applied appliedCache
 }
 
 // Sync syncs the given data to the target resource.
@@ -67,7 +109,18 @@ func (r *Reconciler) Sync(
 	resolvedBundle Data,
 	log logr.Logger,
 	shouldExist bool,
-) (bool, error) {
+) (synced bool, err error) {
+	defer func() {
+		switch {
+		case err != nil:
+			metrics.RecordTargetPatch(string(target.Kind), metrics.PatchResultFailed)
+		case synced:
+			metrics.RecordTargetPatch(string(target.Kind), metrics.PatchResultApplied)
+		default:
+			metrics.RecordTargetPatch(string(target.Kind), metrics.PatchResultSkipped)
+		}
+	}()
+
 	switch target.Kind {
 	case KindConfigMap:
 		return r.syncConfigMap(ctx, target, bundle, resolvedBundle, log, shouldExist)
@@ -102,17 +155,40 @@ func (r *Reconciler) syncConfigMap(
 		return false, nil
 	}
 
+	// The cache only holds targets with the bundle label, so targets created
+	// by hand are read from the API server before they are taken over.
+	if apierrors.IsNotFound(err) {
+		reader := r.APIReader
+		if reader == nil {
+			reader = r.Client
+		}
+
+		err = reader.Get(ctx, target.NamespacedName, targetObj)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get %s %s: %w", target.Kind, target.NamespacedName, err)
+		}
+	}
+
 	// If the resource exists, but should not, delete it.
 	if !apierrors.IsNotFound(err) && !shouldExist {
+		r.applied.forget(target)
+
 		// Apply empty patch to remove the key(s).
-		patch := prepareTargetPatch(coreapplyconfig.ConfigMap(target.Name, target.Namespace), *bundle)
+		patch := prepareTargetPatch(coreapplyconfig.ConfigMap(target.Name, target.Namespace), *bundle, r.owned(bundle))
 		configMap, err := r.patchConfigMap(ctx, patch)
 		if err != nil {
 			return false, fmt.Errorf("failed to patch %s %s: %w", target.Kind, target.NamespacedName, err)
 		}
 		// If the ConfigMap is empty, delete it.
 		if configMap != nil && len(configMap.Data) == 0 && len(configMap.BinaryData) == 0 {
-			return true, r.Client.Delete(ctx, configMap)
+			if err := r.Client.Delete(ctx, configMap); err != nil {
+				return true, err
+			}
+			r.recordDeleted(target, targetObj, bundle)
+			return true, nil
+		}
+		if configMap != nil {
+			r.recordKeysRemoved(target, targetObj, configMap.ResourceVersion, bundle)
 		}
 		return true, nil
 	}
@@ -122,34 +198,67 @@ func (r *Reconciler) syncConfigMap(
 		return false, errors.New("target not defined")
 	}
 
+	if !apierrors.IsNotFound(err) && !isManagedTarget(targetObj, bundle) {
+		if !bundleTarget.AdoptExisting {
+			return false, fmt.Errorf("%s %s already exists and is not managed by trust-manager; set spec.target.adoptExisting to take it over", target.Kind, target.NamespacedName)
+		}
+		log.Info(fmt.Sprintf("adopting existing %s which is not managed by trust-manager", target.Kind))
+	}
+
 	// Generated PKCS #12 is not deterministic - best we can do here is update if the pem cert has
 	// changed (hence not checking if PKCS #12 matches)
-	bundleHash := TrustBundleHash([]byte(resolvedBundle.Data), bundle.Spec.Target.AdditionalFormats)
-	data := map[string]string{
-		bundleTarget.ConfigMap.Key: resolvedBundle.Data,
-	}
-	binData := resolvedBundle.BinaryData
+	bundleHash := resolvedBundle.hash(bundle.Spec.Target.AdditionalFormats)
+	data, binData := Content(target, bundle, resolvedBundle)
+
+	expectedKeys := sets.KeySet(data).Union(sets.KeySet(binData))
+	metadataHash := MetadataHash(bundle.Spec.Target.Metadata, resolvedBundle.PackageVersion)
+	content := newAppliedContent(targetObj.ResourceVersion, bundle, bundleHash, metadataHash, expectedKeys)
 
 	// If the resource exists, check if it is up-to-date.
 	if !apierrors.IsNotFound(err) {
-		// Exit early if no update is needed
-		if exit, err := r.needsUpdate(ctx, target.Kind, log, targetObj, bundle, bundleHash); err != nil {
+		if exit, err := r.shouldApply(ctx, target, log, targetObj, bundle, content, expectedKeys, resolvedBundle.ForceApply); err != nil {
 			return false, err
 		} else if !exit {
 			return false, nil
 		}
 	}
 
-	patch := prepareTargetPatch(coreapplyconfig.ConfigMap(target.Name, target.Namespace), *bundle).
-		WithAnnotations(map[string]string{
-			trustapi.BundleHashAnnotationKey: bundleHash,
-		}).
+	metadataLabels, metadataAnnotations, err := r.renderMetadata(bundle, resolvedBundle, bundleHash, metadataHash)
+	if err != nil {
+		return false, err
+	}
+
+	annotations := map[string]string{
+		trustapi.BundleHashAnnotationKey: bundleHash,
+	}
+	if key := bundleTarget.ConfigMap.Key; bundleTarget.MergeStrategy == trustapi.MergeStrategyUnion && !apierrors.IsNotFound(err) {
+		if _, ok := data[key]; ok {
+			merged, fingerprints, err := r.mergeExisting(ctx, target, targetObj, key, data[key])
+			if err != nil {
+				return false, err
+			}
+			data[key] = merged
+			if len(fingerprints) > 0 {
+				annotations[trustapi.BundleMergedCertificatesAnnotationKey] = strings.Join(fingerprints, ",")
+			}
+		}
+	}
+
+	patch := prepareTargetPatch(coreapplyconfig.ConfigMap(target.Name, target.Namespace).WithLabels(metadataLabels).WithAnnotations(metadataAnnotations), *bundle, r.owned(bundle)).
+		WithAnnotations(annotations).
 		WithData(data).
 		WithBinaryData(binData)
 
-	if _, err = r.patchConfigMap(ctx, patch); err != nil {
+	configMap, err := r.patchConfigMap(ctx, patch)
+	if err != nil {
 		return false, fmt.Errorf("failed to patch %s %s: %w", target.Kind, target.NamespacedName, err)
 	}
+	if configMap != nil {
+		content.resourceVersion = configMap.ResourceVersion
+		r.applied.record(target, content)
+		logAppliedPatch(log, target.Kind, targetObj, configMap.ResourceVersion, bundleHash, expectedKeys)
+		r.recordApplied(target, targetObj, configMap.UID, configMap.ResourceVersion, bundle, bundleHash, expectedKeys)
+	}
 
 	log.V(2).Info(fmt.Sprintf("synced bundle to namespace for target %s", target.Kind))
 
@@ -180,17 +289,51 @@ func (r *Reconciler) syncSecret(
 		return false, nil
 	}
 
+	// The cache only holds targets with the bundle label, so targets created
+	// by hand are read from the API server before they are taken over.
+	if apierrors.IsNotFound(err) {
+		reader := r.APIReader
+		if reader == nil {
+			reader = r.Client
+		}
+
+		err = reader.Get(ctx, target.NamespacedName, targetObj)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get %s %s: %w", target.Kind, target.NamespacedName, err)
+		}
+	}
+
 	// If the resource exists, but should not, delete it.
 	if !apierrors.IsNotFound(err) && !shouldExist {
+		r.applied.forget(target)
+
+		// Immutable Secrets from previous versions of the bundle can't be
+		// patched, and are owned entirely by trust-manager.
+		if isImmutableSecretName(target.Name, bundle) {
+			return true, r.deleteSecret(ctx, target, targetObj, bundle)
+		}
+
 		// Apply empty patch to remove the key(s).
-		patch := prepareTargetPatch(coreapplyconfig.Secret(target.Name, target.Namespace), *bundle)
+		patch := prepareTargetPatch(coreapplyconfig.Secret(target.Name, target.Namespace), *bundle, r.owned(bundle))
 		secret, err := r.patchSecret(ctx, patch)
+		if apierrors.IsInvalid(err) && r.controlledBy(targetObj, bundle) {
+			// kubernetes.io/tls Secrets can't lose their tls.crt and tls.key
+			// entries, so Secrets created for the Bundle are deleted outright.
+			return true, r.deleteSecret(ctx, target, targetObj, bundle)
+		}
 		if err != nil {
 			return false, fmt.Errorf("failed to patch %s %s: %w", target.Kind, target.NamespacedName, err)
 		}
 		// If the Secret is empty, delete it.
 		if secret != nil && len(secret.Data) == 0 {
-			return true, r.Client.Delete(ctx, secret)
+			if err := r.Client.Delete(ctx, secret); err != nil {
+				return true, err
+			}
+			r.recordDeleted(target, targetObj, bundle)
+			return true, nil
+		}
+		if secret != nil {
+			r.recordKeysRemoved(target, targetObj, secret.ResourceVersion, bundle)
 		}
 		return true, nil
 	}
@@ -200,42 +343,156 @@ func (r *Reconciler) syncSecret(
 		return false, errors.New("target not defined")
 	}
 
+	if !apierrors.IsNotFound(err) && !isManagedTarget(targetObj, bundle) {
+		if !bundleTarget.AdoptExisting {
+			return false, fmt.Errorf("%s %s already exists and is not managed by trust-manager; set spec.target.adoptExisting to take it over", target.Kind, target.NamespacedName)
+		}
+		log.Info(fmt.Sprintf("adopting existing %s which is not managed by trust-manager", target.Kind))
+	}
+
 	// Generated PKCS #12 is not deterministic - best we can do here is update if the pem cert has
 	// changed (hence not checking if PKCS #12 matches)
-	bundleHash := TrustBundleHash([]byte(resolvedBundle.Data), bundle.Spec.Target.AdditionalFormats)
-	data := map[string][]byte{
-		bundleTarget.Secret.Key: []byte(resolvedBundle.Data),
+	bundleHash := resolvedBundle.hash(bundle.Spec.Target.AdditionalFormats)
+	stringData, binData := Content(target, bundle, resolvedBundle)
+	data := make(map[string][]byte, len(stringData)+len(binData))
+	for k, v := range stringData {
+		data[k] = []byte(v)
 	}
+	maps.Copy(data, binData)
 
-	for k, v := range resolvedBundle.BinaryData {
-		data[k] = v
-	}
+	expectedKeys := sets.KeySet(data)
+	metadataHash := MetadataHash(bundle.Spec.Target.Metadata, resolvedBundle.PackageVersion)
+	content := newAppliedContent(targetObj.ResourceVersion, bundle, bundleHash, metadataHash, expectedKeys)
 
 	// If the resource exists, check if it is up-to-date.
 	if !apierrors.IsNotFound(err) {
-		// Exit early if no update is needed
-		if exit, err := r.needsUpdate(ctx, target.Kind, log, targetObj, bundle, bundleHash); err != nil {
+		if exit, err := r.shouldApply(ctx, target, log, targetObj, bundle, content, expectedKeys, resolvedBundle.ForceApply); err != nil {
 			return false, err
 		} else if !exit {
 			return false, nil
 		}
 	}
 
-	patch := prepareTargetPatch(coreapplyconfig.Secret(target.Name, target.Namespace), *bundle).
-		WithAnnotations(map[string]string{
-			trustapi.BundleHashAnnotationKey: bundleHash,
-		}).
+	metadataLabels, metadataAnnotations, err := r.renderMetadata(bundle, resolvedBundle, bundleHash, metadataHash)
+	if err != nil {
+		return false, err
+	}
+
+	annotations := map[string]string{
+		trustapi.BundleHashAnnotationKey: bundleHash,
+	}
+	if key := bundleTarget.Secret.Key; bundleTarget.MergeStrategy == trustapi.MergeStrategyUnion && !apierrors.IsNotFound(err) {
+		if _, ok := data[key]; ok {
+			merged, fingerprints, err := r.mergeExisting(ctx, target, targetObj, key, string(data[key]))
+			if err != nil {
+				return false, err
+			}
+			data[key] = []byte(merged)
+			if len(fingerprints) > 0 {
+				annotations[trustapi.BundleMergedCertificatesAnnotationKey] = strings.Join(fingerprints, ",")
+			}
+		}
+	}
+
+	patch := prepareTargetPatch(coreapplyconfig.Secret(target.Name, target.Namespace).WithLabels(metadataLabels).WithAnnotations(metadataAnnotations), *bundle, r.owned(bundle)).
+		WithAnnotations(annotations).
 		WithData(data)
+	if bundleTarget.Secret.Immutable {
+		patch = patch.WithImmutable(true)
+	}
+	if secretType := bundleTarget.Secret.Type; secretType != "" {
+		patch = patch.WithType(secretType)
+	}
 
-	if _, err = r.patchSecret(ctx, patch); err != nil {
+	secret, err := r.patchSecret(ctx, patch)
+	if apierrors.IsInvalid(err) && targetObj.ResourceVersion != "" {
+		secret, err = r.recreateSecretOfOtherType(ctx, target, patch, err)
+	}
+	if err != nil {
 		return false, fmt.Errorf("failed to patch %s %s: %w", target.Kind, target.NamespacedName, err)
 	}
+	if secret != nil {
+		content.resourceVersion = secret.ResourceVersion
+		r.applied.record(target, content)
+		logAppliedPatch(log, target.Kind, targetObj, secret.ResourceVersion, bundleHash, expectedKeys)
+		r.recordApplied(target, targetObj, secret.UID, secret.ResourceVersion, bundle, bundleHash, expectedKeys)
+	}
 
 	log.V(2).Info(fmt.Sprintf("synced bundle to namespace for target %s", target.Kind))
 
 	return true, nil
 }
 
+// Release removes the given Bundle's ownership of the target resource, leaving
+// its data in place. The owner reference, Bundle label and hash annotations are
+// removed, along with trust-manager's managed fields, so that the resource is
+// not garbage collected with the Bundle.
+func (r *Reconciler) Release(ctx context.Context, target Resource, bundle *trustapi.Bundle) error {
+	targetObj := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       string(target.Kind),
+			APIVersion: "v1",
+		},
+	}
+	if err := r.Cache.Get(ctx, target.NamespacedName, targetObj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	patch := client.MergeFromWithOptions(targetObj.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	var ownerRefs []metav1.OwnerReference
+	for _, ref := range targetObj.GetOwnerReferences() {
+		if ref.UID != bundle.GetUID() {
+			ownerRefs = append(ownerRefs, ref)
+		}
+	}
+	targetObj.SetOwnerReferences(ownerRefs)
+
+	labels := targetObj.GetLabels()
+	delete(labels, trustapi.BundleLabelKey)
+	targetObj.SetLabels(labels)
+
+	annotations := targetObj.GetAnnotations()
+	delete(annotations, trustapi.BundleHashAnnotationKey)
+	delete(annotations, trustapi.BundleMetadataHashAnnotationKey)
+	targetObj.SetAnnotations(annotations)
+
+	var managedFields []metav1.ManagedFieldsEntry
+	for _, entry := range targetObj.GetManagedFields() {
+		if entry.Manager != string(ssa_client.FieldManager) {
+			managedFields = append(managedFields, entry)
+		}
+	}
+	if len(managedFields) == 0 {
+		// An empty list leaves managed fields untouched; a single empty entry
+		// clears them.
+		managedFields = []metav1.ManagedFieldsEntry{{}}
+	}
+	targetObj.SetManagedFields(managedFields)
+
+	if err := r.Client.Patch(ctx, targetObj, patch); err != nil {
+		return fmt.Errorf("failed to release %s %s: %w", target.Kind, target.NamespacedName, err)
+	}
+
+	return nil
+}
+
+// isManagedTarget returns true if the target resource was created by
+// trust-manager for the given Bundle, rather than by some other party.
+func isManagedTarget(obj *metav1.PartialObjectMetadata, bundle *trustapi.Bundle) bool {
+	if metav1.IsControlledBy(obj, bundle) || obj.GetLabels()[trustapi.BundleLabelKey] == bundle.Name {
+		return true
+	}
+
+	for _, managedField := range obj.GetManagedFields() {
+		if managedField.Manager == string(ssa_client.FieldManager) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Kind string
 
 const (
@@ -243,9 +500,44 @@ const (
 	KindSecret    Kind = "Secret"
 )
 
-func (r *Reconciler) needsUpdate(ctx context.Context, kind Kind, log logr.Logger, obj *metav1.PartialObjectMetadata, bundle *trustapi.Bundle, bundleHash string) (bool, error) {
+// shouldApply returns true if the existing target object needs to be applied.
+// Targets which haven't changed since they were last synced are skipped
+// without inspecting their managed fields, unless force is true.
+func (r *Reconciler) shouldApply(ctx context.Context, target Resource, log logr.Logger, obj *metav1.PartialObjectMetadata, bundle *trustapi.Bundle, content appliedContent, expectedKeys sets.Set[string], force bool) (bool, error) {
+	if force {
+		return true, nil
+	}
+
+	if r.applied.upToDate(target, obj, content) {
+		return false, nil
+	}
+
+	if r.ForceApply && !r.applied.has(target) {
+		return true, nil
+	}
+
+	needsUpdate, err := r.needsUpdate(ctx, target.Kind, log, obj, bundle, content.bundleHash, content.metadataHash, expectedKeys)
+	if err != nil {
+		return false, err
+	}
+	if !needsUpdate {
+		r.applied.record(target, content)
+	}
+	return needsUpdate, nil
+}
+
+// needsUpdate returns true if the target object isn't owned by the Bundle,
+// holds data or metadata for a different bundle, or doesn't hold exactly the
+// expected keys.
+func (r *Reconciler) needsUpdate(ctx context.Context, kind Kind, log logr.Logger, obj *metav1.PartialObjectMetadata, bundle *trustapi.Bundle, bundleHash, metadataHash string, expectedProperties sets.Set[string]) (bool, error) {
 	needsUpdate := false
-	if !metav1.IsControlledBy(obj, bundle) {
+	if !r.controlledBy(obj, bundle) {
+		needsUpdate = true
+	}
+
+	// Unowned targets which are still owned by the Bundle are applied, to
+	// remove the owner reference.
+	if !r.owned(bundle) && metav1.IsControlledBy(obj, bundle) {
 		needsUpdate = true
 	}
 
@@ -257,15 +549,16 @@ func (r *Reconciler) needsUpdate(ctx context.Context, kind Kind, log logr.Logger
 		needsUpdate = true
 	}
 
+	if obj.GetAnnotations()[trustapi.BundleMetadataHashAnnotationKey] != metadataHash {
+		needsUpdate = true
+	}
+
 	{
-		var key string
 		var targetFieldNames []string
 		switch kind {
 		case KindConfigMap:
-			key = bundle.Spec.Target.ConfigMap.Key
 			targetFieldNames = []string{"data", "binaryData"}
 		case KindSecret:
-			key = bundle.Spec.Target.Secret.Key
 			targetFieldNames = []string{"data"}
 		default:
 			return false, fmt.Errorf("unknown targetType: %s", kind)
@@ -275,13 +568,6 @@ func (r *Reconciler) needsUpdate(ctx context.Context, kind Kind, log logr.Logger
 		if err != nil {
 			return false, fmt.Errorf("failed to list managed properties: %w", err)
 		}
-		expectedProperties := sets.New[string](key)
-		if bundle.Spec.Target.AdditionalFormats != nil && bundle.Spec.Target.AdditionalFormats.JKS != nil {
-			expectedProperties.Insert(bundle.Spec.Target.AdditionalFormats.JKS.Key)
-		}
-		if bundle.Spec.Target.AdditionalFormats != nil && bundle.Spec.Target.AdditionalFormats.PKCS12 != nil {
-			expectedProperties.Insert(bundle.Spec.Target.AdditionalFormats.PKCS12.Key)
-		}
 		if !properties.Equal(expectedProperties) {
 			needsUpdate = true
 		}
@@ -301,6 +587,34 @@ func (r *Reconciler) needsUpdate(ctx context.Context, kind Kind, log logr.Logger
 	return needsUpdate, nil
 }
 
+// logAppliedPatch logs a summary of the changes made by a patch to a target
+// at debug level, so that targets which are patched over and over can be told
+// apart from targets whose content actually changes.
+func logAppliedPatch(log logr.Logger, kind Kind, before *metav1.PartialObjectMetadata, resourceVersion, bundleHash string, keys sets.Set[string]) {
+	log = log.V(4)
+	if !log.Enabled() {
+		return
+	}
+
+	fieldNames := []string{"data"}
+	if kind == KindConfigMap {
+		fieldNames = append(fieldNames, "binaryData")
+	}
+	previousKeys, err := listManagedProperties(before, ssa_client.FieldManager, fieldNames...)
+	if err != nil {
+		log.Error(err, "failed to list managed properties of target")
+		return
+	}
+
+	log.Info("applied patch to target",
+		"addedKeys", sets.List(keys.Difference(previousKeys)),
+		"removedKeys", sets.List(previousKeys.Difference(keys)),
+		"bundleHashChanged", before.GetAnnotations()[trustapi.BundleHashAnnotationKey] != bundleHash,
+		"resourceVersion", resourceVersion,
+		"changed", before.ResourceVersion != resourceVersion,
+	)
+}
+
 func listManagedProperties(configmap *metav1.PartialObjectMetadata, fieldManager client.FieldOwner, fieldNames ...string) (sets.Set[string], error) {
 	properties := sets.New[string]()
 
@@ -355,6 +669,7 @@ func (r *Reconciler) patchConfigMap(ctx context.Context, applyConfig *coreapplyc
 		return nil, err
 	}
 
+	defer observeApply(KindConfigMap, time.Now())
 	return obj, r.Client.Patch(ctx, obj, ssa_client.ApplyPatch{Patch: encodedPatch}, ssa_client.FieldManager, client.ForceOwnership)
 }
 
@@ -380,9 +695,56 @@ func (r *Reconciler) patchSecret(ctx context.Context, applyConfig *coreapplyconf
 		return nil, err
 	}
 
+	defer observeApply(KindSecret, time.Now())
 	return obj, r.Client.Patch(ctx, obj, ssa_client.ApplyPatch{Patch: encodedPatch}, ssa_client.FieldManager, client.ForceOwnership)
 }
 
+// deleteSecret deletes the target Secret outright, rather than removing the
+// keys of the Bundle from it.
+func (r *Reconciler) deleteSecret(ctx context.Context, target Resource, targetObj *metav1.PartialObjectMetadata, bundle *trustapi.Bundle) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: target.Name, Namespace: target.Namespace}}
+	if err := r.Client.Delete(ctx, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.recordDeleted(target, targetObj, bundle)
+	return nil
+}
+
+// recreateSecretOfOtherType deletes the target Secret and applies the patch
+// again if the Secret has another type than the patch, as the type of a
+// Secret can't be changed. Otherwise, patchErr is returned.
+func (r *Reconciler) recreateSecretOfOtherType(ctx context.Context, target Resource, applyConfig *coreapplyconfig.SecretApplyConfiguration, patchErr error) (*corev1.Secret, error) {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	var existing corev1.Secret
+	if err := reader.Get(ctx, target.NamespacedName, &existing); err != nil {
+		return nil, patchErr
+	}
+
+	secretType := corev1.SecretTypeOpaque
+	if applyConfig.Type != nil {
+		secretType = *applyConfig.Type
+	}
+	if existing.Type == secretType {
+		return nil, patchErr
+	}
+
+	if err := r.Client.Delete(ctx, &existing, client.Preconditions{UID: &existing.UID}); client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to delete %s %s of type %s: %w", target.Kind, target.NamespacedName, existing.Type, err)
+	}
+
+	return r.patchSecret(ctx, applyConfig)
+}
+
+// observeApply records the latency of a patch to a target which started at
+// start.
+func observeApply(kind Kind, start time.Time) {
+	metrics.ObserveTargetApply(string(kind), time.Since(start))
+}
+
 type targetApplyConfiguration[T any] interface {
 	*coreapplyconfig.ConfigMapApplyConfiguration | *coreapplyconfig.SecretApplyConfiguration
 
@@ -390,11 +752,16 @@ type targetApplyConfiguration[T any] interface {
 	WithOwnerReferences(values ...*metav1applyconfig.OwnerReferenceApplyConfiguration) T
 }
 
-func prepareTargetPatch[T targetApplyConfiguration[T]](target T, bundle trustapi.Bundle) T {
-	return target.
+func prepareTargetPatch[T targetApplyConfiguration[T]](target T, bundle trustapi.Bundle, owned bool) T {
+	target = target.
 		WithLabels(map[string]string{
 			trustapi.BundleLabelKey: bundle.Name,
-		}).
+		})
+	if !owned {
+		return target
+	}
+
+	return target.
 		WithOwnerReferences(
 			metav1applyconfig.OwnerReference().
 				WithAPIVersion(trustapi.SchemeGroupVersion.String()).
@@ -406,6 +773,21 @@ func prepareTargetPatch[T targetApplyConfiguration[T]](target T, bundle trustapi
 		)
 }
 
+// owned returns true if the targets of the Bundle are written with an owner
+// reference to it.
+func (r *Reconciler) owned(bundle *trustapi.Bundle) bool {
+	return !r.Unowned && bundle.Spec.Target.Ownership != trustapi.TargetOwnershipUnowned
+}
+
+// controlledBy returns true if the target object is controlled by the Bundle:
+// owned by it or, for unowned targets, labelled with it.
+func (r *Reconciler) controlledBy(obj *metav1.PartialObjectMetadata, bundle *trustapi.Bundle) bool {
+	if !r.owned(bundle) {
+		return obj.GetLabels()[trustapi.BundleLabelKey] == bundle.Name
+	}
+	return metav1.IsControlledBy(obj, bundle)
+}
+
 type Resource struct {
 	Kind Kind
 	types.NamespacedName
@@ -414,6 +796,58 @@ type Resource struct {
 type Data struct {
 	Data       string
 	BinaryData map[string][]byte
+
+	// Signature is the base64-encoded detached signature of Data, if the
+	// Bundle is signed.
+	Signature string
+
+	// Manifest is the JSON manifest describing the certificates of Data and
+	// their sources, if a target of the Bundle has a manifest.
+	Manifest string
+
+	// UsageData holds the PEM-encoded certificates of Data which are trusted
+	// for each usage, if a target of the Bundle has usage keys.
+	UsageData map[trustapi.CertificateUsage]string
+
+	// PackageVersion identifies the default CA package which the bundle
+	// includes, if any.
+	PackageVersion string
+
+	// ForceApply, if true, applies the target even if it is up to date, as
+	// when a resync of the Bundle is requested.
+	ForceApply bool
+}
+
+// hash returns the TrustBundleHash of the content of the targets. The content
+// is hashed piece by piece rather than concatenated first, as the bundle can
+// be several megabytes and is hashed for every target.
+func (b *Data) hash(additionalFormats *trustapi.AdditionalFormats) string {
+	hash := &stringHasher{Hash: sha256.New()}
+
+	hash.writeString(b.Data)
+	hash.writeString(b.Signature)
+	hash.writeString(b.Manifest)
+	for _, usage := range slices.Sorted(maps.Keys(b.UsageData)) {
+		hash.writeString(string(usage))
+		hash.writeString(b.UsageData[usage])
+	}
+
+	return sumTrustBundleHash(hash.Hash, additionalFormats)
+}
+
+// stringHasher writes strings to a hash through a fixed buffer, rather than
+// converting each to a byte slice of its own.
+type stringHasher struct {
+	hash.Hash
+	buf [4096]byte
+}
+
+func (h *stringHasher) writeString(s string) {
+	for len(s) > 0 {
+		n := copy(h.buf[:], s)
+		_, _ = h.Write(h.buf[:n])
+		s = s[n:]
+	}
 }
 
 func (b *Data) Populate(pool *util.CertPool, formats *trustapi.AdditionalFormats) error {
@@ -431,26 +865,205 @@ func (b *Data) Populate(pool *util.CertPool, formats *trustapi.AdditionalFormats
 		}
 
 		if formats.PKCS12 != nil {
-			encoded, err := truststore.NewPKCS12Encoder(*formats.PKCS12.Password).Encode(pool)
+			encoder := truststore.NewPKCS12Encoder(*formats.PKCS12.Password)
+			if formats.PKCS12.Deterministic {
+				encoder = truststore.NewDeterministicPKCS12Encoder(*formats.PKCS12.Password)
+			}
+
+			encoded, err := encoder.Encode(pool)
 			if err != nil {
 				return fmt.Errorf("failed to encode PKCS12: %w", err)
 			}
 			b.BinaryData[formats.PKCS12.Key] = encoded
 		}
+
+		if formats.SPIFFE != nil {
+			encoded, err := truststore.NewSPIFFEEncoder(formats.SPIFFE.TrustDomain).Encode(pool)
+			if err != nil {
+				return fmt.Errorf("failed to encode SPIFFE bundle: %w", err)
+			}
+			b.BinaryData[formats.SPIFFE.Key] = encoded
+		}
+
+		if formats.Certdata != nil {
+			encoded, err := truststore.NewCertdataEncoder().Encode(pool)
+			if err != nil {
+				return fmt.Errorf("failed to encode certdata: %w", err)
+			}
+			b.BinaryData[formats.Certdata.Key] = encoded
+		}
+	}
+	return nil
+}
+
+// Content returns the data and binary data which the given target should
+// hold. If the Bundle writes its additional formats to a separate target, the
+// main target only holds the PEM bundle and the additional formats target only
+// holds the additional formats.
+func Content(target Resource, bundle *trustapi.Bundle, resolvedBundle Data) (map[string]string, map[string][]byte) {
+	formatsTarget := bundle.Spec.Target.AdditionalFormatsTarget
+	if formatsTarget != nil && target.Name == formatsTarget.Name {
+		return nil, resolvedBundle.BinaryData
+	}
+
+	var (
+		key            string
+		additionalKeys []string
+	)
+	switch target.Kind {
+	case KindConfigMap:
+		key, additionalKeys = bundle.Spec.Target.ConfigMap.Key, bundle.Spec.Target.ConfigMap.AdditionalKeys
+	case KindSecret:
+		key, additionalKeys = bundle.Spec.Target.Secret.Key, bundle.Spec.Target.Secret.AdditionalKeys
+	}
+	data := map[string]string{key: resolvedBundle.Data}
+	for _, additionalKey := range additionalKeys {
+		data[additionalKey] = resolvedBundle.Data
+	}
+	if signature := bundle.Spec.Target.Signature; signature != nil && resolvedBundle.Signature != "" {
+		data[SignatureKey(signature, key)] = resolvedBundle.Signature
+	}
+	if manifest := bundle.Spec.Target.Manifest; manifest != nil && resolvedBundle.Manifest != "" {
+		data[ManifestKey(manifest)] = resolvedBundle.Manifest
+	}
+	for _, usageKey := range bundle.Spec.Target.UsageKeys {
+		data[usageKey.Key] = resolvedBundle.UsageData[usageKey.Usage]
+	}
+	if target.Kind == KindSecret && bundle.Spec.Target.Secret.Type == corev1.SecretTypeTLS {
+		// The API server requires kubernetes.io/tls Secrets to hold a
+		// certificate and private key, which trust-manager has neither of.
+		data[corev1.TLSCertKey] = ""
+		data[corev1.TLSPrivateKeyKey] = ""
+	}
+
+	if formatsTarget != nil {
+		return data, nil
+	}
+	return data, resolvedBundle.BinaryData
+}
+
+// SignatureKey returns the key the signature is written to in a target where
+// the PEM bundle is written to bundleKey.
+func SignatureKey(signature *trustapi.BundleSignature, bundleKey string) string {
+	if signature.Key != "" {
+		return signature.Key
+	}
+	return bundleKey + ".sig"
+}
+
+// ManifestKey returns the key the manifest is written to in a target.
+func ManifestKey(manifest *trustapi.BundleManifest) string {
+	if manifest.Key != "" {
+		return manifest.Key
+	}
+	return trustapi.DefaultManifestKey
+}
+
+// ValidateSize returns an error if any of the Bundle's targets would exceed
+// the 1MiB size limit which the API server enforces on ConfigMaps and
+// Secrets.
+func ValidateSize(bundle *trustapi.Bundle, resolvedBundle Data) error {
+	var targets []Resource
+	for _, kind := range []Kind{KindConfigMap, KindSecret} {
+		if (kind == KindConfigMap && bundle.Spec.Target.ConfigMap == nil) || (kind == KindSecret && bundle.Spec.Target.Secret == nil) {
+			continue
+		}
+		targets = append(targets, Resource{Kind: kind, NamespacedName: types.NamespacedName{Name: bundle.Name}})
+		if formatsTarget := bundle.Spec.Target.AdditionalFormatsTarget; formatsTarget != nil {
+			targets = append(targets, Resource{Kind: kind, NamespacedName: types.NamespacedName{Name: formatsTarget.Name}})
+		}
 	}
+
+	for _, target := range targets {
+		data, binData := Content(target, bundle, resolvedBundle)
+
+		size := 0
+		for k, v := range data {
+			size += len(k) + len(v)
+		}
+		for k, v := range binData {
+			size += len(k) + len(v)
+		}
+
+		if size > corev1.MaxSecretSize {
+			msg := fmt.Sprintf("target %s %q would be %d bytes, which exceeds the limit of %d bytes", target.Kind, target.Name, size, corev1.MaxSecretSize)
+			if bundle.Spec.Target.AdditionalFormatsTarget == nil && len(binData) > 0 {
+				msg += "; set spec.target.additionalFormatsTarget to write additional formats to a separate target"
+			}
+			return errors.New(msg)
+		}
+	}
+
 	return nil
 }
 
-func TrustBundleHash(data []byte, additionalFormats *trustapi.AdditionalFormats) string {
+// ImmutableSecretName returns the name of the immutable target Secret for the
+// given bundle data. The name changes whenever the content of the Secret
+// would, including its keys.
+func ImmutableSecretName(bundle *trustapi.Bundle, resolvedBundle Data) string {
 	hash := sha256.New()
 
-	_, _ = hash.Write(data)
+	_, _ = hash.Write([]byte(resolvedBundle.hash(bundle.Spec.Target.AdditionalFormats)))
+	_, _ = hash.Write([]byte(bundle.Spec.Target.Secret.Key))
+	for _, key := range slices.Sorted(slices.Values(bundle.Spec.Target.Secret.AdditionalKeys)) {
+		_, _ = hash.Write([]byte(key))
+	}
+	for _, key := range slices.Sorted(maps.Keys(resolvedBundle.BinaryData)) {
+		_, _ = hash.Write([]byte(key))
+	}
 
-	if additionalFormats != nil && additionalFormats.JKS != nil && additionalFormats.JKS.Password != nil {
-		_, _ = hash.Write([]byte(*additionalFormats.JKS.Password))
+	suffix := hex.EncodeToString(hash.Sum(nil))[:immutableSecretSuffixLength]
+
+	// Leave room for the suffix within the limit on object names.
+	name := bundle.Name
+	if maxLen := validation.DNS1123SubdomainMaxLength - len(suffix) - 1; len(name) > maxLen {
+		name = name[:maxLen]
 	}
-	if additionalFormats != nil && additionalFormats.PKCS12 != nil && additionalFormats.PKCS12.Password != nil {
-		_, _ = hash.Write([]byte(*additionalFormats.PKCS12.Password))
+
+	return name + "-" + suffix
+}
+
+const immutableSecretSuffixLength = 10
+
+// isImmutableSecretName returns true if the name is that of an immutable target
+// Secret of the Bundle, for any version of its data.
+func isImmutableSecretName(name string, bundle *trustapi.Bundle) bool {
+	prefix, suffix, ok := cutLast(name, "-")
+	if !ok || len(suffix) != immutableSecretSuffixLength {
+		return false
+	}
+	// Long Bundle names are truncated to make room for the suffix.
+	truncated := len(prefix) == validation.DNS1123SubdomainMaxLength-immutableSecretSuffixLength-1 && strings.HasPrefix(bundle.Name, prefix)
+	if prefix != bundle.Name && !truncated {
+		return false
+	}
+	_, err := hex.DecodeString(suffix)
+	return err == nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// TrustBundleHash returns the hash of a trust bundle published with the given
+// additional formats. Passing the formats of several targets gives a hash which
+// changes when the formats of any of them do.
+func TrustBundleHash(data []byte, additionalFormats ...*trustapi.AdditionalFormats) string {
+	hash := sha256.New()
+
+	_, _ = hash.Write(data)
+
+	return sumTrustBundleHash(hash, additionalFormats...)
+}
+
+// sumTrustBundleHash adds the options of the additional formats to the hash
+// of the content of a trust bundle, and returns the TrustBundleHash.
+func sumTrustBundleHash(hash hash.Hash, additionalFormats ...*trustapi.AdditionalFormats) string {
+	for _, additionalFormats := range additionalFormats {
+		writeAdditionalFormats(hash, additionalFormats)
 	}
 
 	hashValue := [32]byte{}
@@ -458,3 +1071,24 @@ func TrustBundleHash(data []byte, additionalFormats *trustapi.AdditionalFormats)
 
 	return hex.EncodeToString(hashValue[:])
 }
+
+// writeAdditionalFormats writes the options of the additional formats to the
+// hash of a trust bundle.
+func writeAdditionalFormats(hash hash.Hash, additionalFormats *trustapi.AdditionalFormats) {
+	if additionalFormats != nil && additionalFormats.JKS != nil && additionalFormats.JKS.Password != nil {
+		_, _ = hash.Write([]byte(*additionalFormats.JKS.Password))
+	}
+	if additionalFormats != nil && additionalFormats.PKCS12 != nil && additionalFormats.PKCS12.Password != nil {
+		_, _ = hash.Write([]byte(*additionalFormats.PKCS12.Password))
+	}
+	// The hash is computed from the inputs of the binary formats rather than
+	// their encoded bytes, as a PKCS12 trust store with random salts encodes
+	// differently every time. Switching to deterministic encoding must still
+	// rewrite the targets, but leaves the hash of other targets unchanged.
+	if additionalFormats != nil && additionalFormats.PKCS12 != nil && additionalFormats.PKCS12.Deterministic {
+		_, _ = hash.Write([]byte("deterministic-pkcs12"))
+	}
+	if additionalFormats != nil && additionalFormats.SPIFFE != nil {
+		_, _ = hash.Write([]byte(additionalFormats.SPIFFE.TrustDomain))
+	}
+}
@@ -18,18 +18,25 @@ package target
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	coreapplyconfig "k8s.io/client-go/applyconfigurations/core/v1"
 	metav1applyconfig "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
@@ -62,6 +69,10 @@ func Test_syncConfigMapTarget(t *testing.T) {
 		withJKS bool
 		// Add PKCS12 to AdditionalFormats
 		withPKCS12 bool
+		// Allow adopting an existing configmap not managed by trust-manager
+		adoptExisting bool
+		// Expect the sync to fail.
+		expError bool
 		// Expect the configmap to exist at the end of the sync.
 		expExists bool
 		// Expect JKS to exist in the configmap at the end of the sync.
@@ -116,6 +127,35 @@ func Test_syncConfigMapTarget(t *testing.T) {
 			expOwnerReference: true,
 			expNeedsUpdate:    true,
 		},
+		"if object exists but is not managed by trust-manager, expect error": {
+			object: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bundleName,
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{key: "manual data"},
+			},
+			namespace:      corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
+			shouldExist:    true,
+			expError:       true,
+			expExists:      true,
+			expNeedsUpdate: false,
+		},
+		"if object exists but is not managed by trust-manager and adoptExisting is set, expect update": {
+			object: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bundleName,
+					Namespace: "test-namespace",
+				},
+				Data: map[string]string{key: "manual data"},
+			},
+			namespace:         corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
+			shouldExist:       true,
+			adoptExisting:     true,
+			expExists:         true,
+			expOwnerReference: true,
+			expNeedsUpdate:    true,
+		},
 		"if object exists with data but no owner, expect update": {
 			object: &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
@@ -566,7 +606,7 @@ func Test_syncConfigMapTarget(t *testing.T) {
 
 			r := &Reconciler{
 				Client: fakeClient,
-				Cache:  fakeClient,
+				Cache:  labelledCache{fakeClient},
 				PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
 					logMutex.Lock()
 					defer logMutex.Unlock()
@@ -578,8 +618,9 @@ func Test_syncConfigMapTarget(t *testing.T) {
 
 			spec := trustapi.BundleSpec{
 				Target: trustapi.BundleTarget{
-					ConfigMap:         &trustapi.KeySelector{Key: key},
+					ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
 					AdditionalFormats: &trustapi.AdditionalFormats{},
+					AdoptExisting:     test.adoptExisting,
 				},
 			}
 			resolvedBundle := Data{Data: data, BinaryData: make(map[string][]byte)}
@@ -608,7 +649,7 @@ func Test_syncConfigMapTarget(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: bundleName},
 				Spec:       spec,
 			}, resolvedBundle, log, test.shouldExist)
-			assert.NoError(t, err)
+			assert.Equal(t, test.expError, err != nil, "unexpected error: %v", err)
 
 			assert.Equalf(t, test.expNeedsUpdate, needsUpdate, "unexpected needsUpdate, exp=%t got=%t", test.expNeedsUpdate, needsUpdate)
 
@@ -674,6 +715,10 @@ func Test_syncSecretTarget(t *testing.T) {
 		withJKS bool
 		// Add PKCS12 to AdditionalFormats
 		withPKCS12 bool
+		// Allow adopting an existing secret not managed by trust-manager
+		adoptExisting bool
+		// Expect the sync to fail.
+		expError bool
 		// Expect the secret to exist at the end of the sync.
 		expExists bool
 		// Expect JKS to exist in the secret at the end of the sync.
@@ -712,6 +757,35 @@ func Test_syncSecretTarget(t *testing.T) {
 			expOwnerReference: true,
 			expNeedsUpdate:    true,
 		},
+		"if object exists but is not managed by trust-manager, expect error": {
+			object: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bundleName,
+					Namespace: "test-namespace",
+				},
+				Data: map[string][]byte{key: []byte("manual data")},
+			},
+			namespace:      corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
+			shouldExist:    true,
+			expError:       true,
+			expExists:      true,
+			expNeedsUpdate: false,
+		},
+		"if object exists but is not managed by trust-manager and adoptExisting is set, expect update": {
+			object: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bundleName,
+					Namespace: "test-namespace",
+				},
+				Data: map[string][]byte{key: []byte("manual data")},
+			},
+			namespace:         corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
+			shouldExist:       true,
+			adoptExisting:     true,
+			expExists:         true,
+			expOwnerReference: true,
+			expNeedsUpdate:    true,
+		},
 		"if object exists but without data or owner, expect update": {
 			object: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1178,7 +1252,7 @@ func Test_syncSecretTarget(t *testing.T) {
 
 			r := &Reconciler{
 				Client: fakeClient,
-				Cache:  fakeClient,
+				Cache:  labelledCache{fakeClient},
 				PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
 					logMutex.Lock()
 					defer logMutex.Unlock()
@@ -1190,8 +1264,9 @@ func Test_syncSecretTarget(t *testing.T) {
 
 			spec := trustapi.BundleSpec{
 				Target: trustapi.BundleTarget{
-					Secret:            &trustapi.KeySelector{Key: key},
+					Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: key}},
 					AdditionalFormats: &trustapi.AdditionalFormats{},
+					AdoptExisting:     test.adoptExisting,
 				},
 			}
 			resolvedBundle := Data{Data: data, BinaryData: make(map[string][]byte)}
@@ -1220,7 +1295,7 @@ func Test_syncSecretTarget(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: bundleName},
 				Spec:       spec,
 			}, resolvedBundle, log, test.shouldExist)
-			assert.NoError(t, err)
+			assert.Equal(t, test.expError, err != nil, "unexpected error: %v", err)
 
 			assert.Equalf(t, test.expNeedsUpdate, needsUpdate, "unexpected needsUpdate, exp=%t got=%t", test.expNeedsUpdate, needsUpdate)
 
@@ -1268,6 +1343,463 @@ func Test_syncSecretTarget(t *testing.T) {
 	}
 }
 
+func Test_syncSecretTarget_immutable(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: key}, Immutable: true},
+			},
+		},
+	}
+	resolvedBundle := Data{Data: data}
+	secretName := ImmutableSecretName(bundle, resolvedBundle)
+
+	oldSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundleName + "-0123456789",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{trustapi.BundleLabelKey: bundleName},
+		},
+		Immutable: ptr.To(true),
+		Data:      map[string][]byte{key: []byte("old")},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(oldSecret).
+		Build()
+
+	var resourcePatches []interface{}
+	r := &Reconciler{
+		Client: fakeClient,
+		Cache:  fakeClient,
+		PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
+			resourcePatches = append(resourcePatches, obj)
+			return nil
+		},
+	}
+
+	log, ctx := ktesting.NewTestContext(t)
+
+	synced, err := r.Sync(ctx, Resource{
+		Kind:           KindSecret,
+		NamespacedName: types.NamespacedName{Name: secretName, Namespace: "test-namespace"},
+	}, bundle, resolvedBundle, log, true)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+
+	if assert.Len(t, resourcePatches, 1) {
+		secret := resourcePatches[0].(*coreapplyconfig.SecretApplyConfiguration)
+		assert.Equal(t, secretName, *secret.Name)
+		assert.Equal(t, ptr.To(true), secret.Immutable)
+		assert.Equal(t, data, string(secret.Data[key]))
+	}
+
+	// Immutable Secrets for older versions of the bundle are deleted outright.
+	synced, err = r.Sync(ctx, Resource{
+		Kind:           KindSecret,
+		NamespacedName: types.NamespacedName{Name: oldSecret.Name, Namespace: oldSecret.Namespace},
+	}, bundle, resolvedBundle, log, false)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+	assert.Len(t, resourcePatches, 1)
+
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(oldSecret), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "expected old secret to be deleted, got: %v", err)
+}
+
+func Test_syncSecretTarget_tls(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: trustapi.TLSSecretTargetKey}, Type: corev1.SecretTypeTLS},
+			},
+		},
+	}
+	resolvedBundle := Data{Data: data}
+
+	// An Opaque Secret created for the Bundle before it changed the type.
+	opaqueSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundleName,
+			Namespace: "test-namespace",
+			Labels:    map[string]string{trustapi.BundleLabelKey: bundleName},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{trustapi.TLSSecretTargetKey: []byte(data)},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(opaqueSecret).
+		Build()
+
+	var resourcePatches []interface{}
+	r := &Reconciler{
+		Client:    fakeClient,
+		Cache:     fakeClient,
+		APIReader: fakeClient,
+		PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
+			resourcePatches = append(resourcePatches, obj)
+			if len(resourcePatches) == 1 {
+				return apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("Secret").GroupKind(), bundleName, nil)
+			}
+			return nil
+		},
+	}
+
+	log, ctx := ktesting.NewTestContext(t)
+
+	synced, err := r.Sync(ctx, Resource{
+		Kind:           KindSecret,
+		NamespacedName: types.NamespacedName{Name: bundleName, Namespace: "test-namespace"},
+	}, bundle, resolvedBundle, log, true)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+
+	// The Secret is recreated, as its type can't be changed.
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(opaqueSecret), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "expected opaque secret to be deleted, got: %v", err)
+
+	if assert.Len(t, resourcePatches, 2) {
+		secret := resourcePatches[1].(*coreapplyconfig.SecretApplyConfiguration)
+		assert.Equal(t, ptr.To(corev1.SecretTypeTLS), secret.Type)
+		assert.Equal(t, map[string][]byte{
+			trustapi.TLSSecretTargetKey: []byte(data),
+			corev1.TLSCertKey:           {},
+			corev1.TLSPrivateKeyKey:     {},
+		}, secret.Data)
+	}
+}
+
+func Test_syncConfigMapTarget_metadata(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
+				Metadata: &trustapi.TargetMetadata{
+					Labels: map[string]string{"app": "{{ .BundleName }}"},
+					Annotations: map[string]string{
+						"reloader/hash":    "{{ .BundleHash }}",
+						"reloader/package": "{{ .PackageVersion }}",
+						"reloader/synced":  "{{ .SyncTimestamp }}",
+					},
+				},
+			},
+		},
+	}
+	resolvedBundle := Data{Data: data, PackageVersion: "cert-manager-debian-1"}
+	bundleHash := TrustBundleHash([]byte(data), nil)
+	metadataHash := MetadataHash(bundle.Spec.Target.Metadata, resolvedBundle.PackageVersion)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).Build()
+
+	var resourcePatches []interface{}
+	r := &Reconciler{
+		Client: fakeClient,
+		Cache:  fakeClient,
+		Clock:  fakeclock.NewFakePassiveClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+		PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
+			resourcePatches = append(resourcePatches, obj)
+			return nil
+		},
+	}
+
+	log, ctx := ktesting.NewTestContext(t)
+
+	synced, err := r.Sync(ctx, Resource{
+		Kind:           KindConfigMap,
+		NamespacedName: types.NamespacedName{Name: bundleName, Namespace: "test-namespace"},
+	}, bundle, resolvedBundle, log, true)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+
+	if assert.Len(t, resourcePatches, 1) {
+		configMap := resourcePatches[0].(*coreapplyconfig.ConfigMapApplyConfiguration)
+		assert.Equal(t, map[string]string{
+			"app":                   bundleName,
+			trustapi.BundleLabelKey: bundleName,
+		}, configMap.Labels)
+		assert.Equal(t, map[string]string{
+			"reloader/hash":                          bundleHash,
+			"reloader/package":                       "cert-manager-debian-1",
+			"reloader/synced":                        "2026-01-02T03:04:05Z",
+			trustapi.BundleHashAnnotationKey:         bundleHash,
+			trustapi.BundleMetadataHashAnnotationKey: metadataHash,
+		}, configMap.Annotations)
+	}
+
+	// A target written before the metadata changed is updated.
+	outdated := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				trustapi.BundleHashAnnotationKey:         bundleHash,
+				trustapi.BundleMetadataHashAnnotationKey: "outdated",
+			},
+		},
+	}
+	needsUpdate, err := r.needsUpdate(ctx, KindConfigMap, log, outdated, bundle, bundleHash, metadataHash, nil)
+	assert.NoError(t, err)
+	assert.True(t, needsUpdate)
+}
+
+func Test_syncConfigMapTarget_unowned(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName, UID: "bundle-uid"},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
+				Ownership: trustapi.TargetOwnershipUnowned,
+			},
+		},
+	}
+	resolvedBundle := Data{Data: data}
+	bundleHash := TrustBundleHash([]byte(data), nil)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).Build()
+
+	var resourcePatches []interface{}
+	r := &Reconciler{
+		Client: fakeClient,
+		Cache:  fakeClient,
+		PatchResourceOverwrite: func(ctx context.Context, obj interface{}) error {
+			resourcePatches = append(resourcePatches, obj)
+			return nil
+		},
+	}
+
+	log, ctx := ktesting.NewTestContext(t)
+
+	synced, err := r.Sync(ctx, Resource{
+		Kind:           KindConfigMap,
+		NamespacedName: types.NamespacedName{Name: bundleName, Namespace: "test-namespace"},
+	}, bundle, resolvedBundle, log, true)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+
+	if assert.Len(t, resourcePatches, 1) {
+		configMap := resourcePatches[0].(*coreapplyconfig.ConfigMapApplyConfiguration)
+		assert.Empty(t, configMap.OwnerReferences)
+		assert.Equal(t, map[string]string{trustapi.BundleLabelKey: bundleName}, configMap.Labels)
+	}
+
+	target := func(ownerReferences ...metav1.OwnerReference) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:          map[string]string{trustapi.BundleLabelKey: bundleName},
+				Annotations:     map[string]string{trustapi.BundleHashAnnotationKey: bundleHash},
+				OwnerReferences: ownerReferences,
+				ManagedFields:   ssa_client.ManagedFieldEntries([]string{key}, nil),
+			},
+		}
+	}
+
+	// A labelled target is controlled by the Bundle without an owner
+	// reference.
+	needsUpdate, err := r.needsUpdate(ctx, KindConfigMap, log, target(), bundle, bundleHash, "", sets.New(key))
+	assert.NoError(t, err)
+	assert.False(t, needsUpdate)
+
+	// A target written while the Bundle owned its targets is updated, to
+	// remove the owner reference.
+	needsUpdate, err = r.needsUpdate(ctx, KindConfigMap, log, target(metav1.OwnerReference{
+		APIVersion: trustapi.SchemeGroupVersion.String(),
+		Kind:       trustapi.BundleKind,
+		Name:       bundleName,
+		UID:        bundle.UID,
+		Controller: ptr.To(true),
+	}), bundle, bundleHash, "", sets.New(key))
+	assert.NoError(t, err)
+	assert.True(t, needsUpdate)
+}
+
+func Test_ImmutableSecretName(t *testing.T) {
+	bundle := func(mods ...func(*trustapi.Bundle)) *trustapi.Bundle {
+		b := &trustapi.Bundle{
+			ObjectMeta: metav1.ObjectMeta{Name: bundleName},
+			Spec: trustapi.BundleSpec{
+				Target: trustapi.BundleTarget{
+					Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: key}, Immutable: true},
+				},
+			},
+		}
+		for _, mod := range mods {
+			mod(b)
+		}
+		return b
+	}
+
+	name := ImmutableSecretName(bundle(), Data{Data: data})
+	assert.Regexp(t, "^"+bundleName+"-[0-9a-f]{10}$", name)
+	assert.Equal(t, name, ImmutableSecretName(bundle(), Data{Data: data}))
+
+	assert.NotEqual(t, name, ImmutableSecretName(bundle(), Data{Data: dummy.TestCertificate2}))
+	assert.NotEqual(t, name, ImmutableSecretName(bundle(func(b *trustapi.Bundle) {
+		b.Spec.Target.Secret.Key = "other.pem"
+	}), Data{Data: data}))
+	assert.NotEqual(t, name, ImmutableSecretName(bundle(), Data{Data: data, BinaryData: map[string][]byte{jksKey: jksData}}))
+
+	long := ImmutableSecretName(bundle(func(b *trustapi.Bundle) {
+		b.Name = strings.Repeat("a", 253)
+	}), Data{Data: data})
+	assert.Len(t, long, 253)
+}
+
+func Test_Content(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
+				Secret:    &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "secret.pem"}},
+				AdditionalFormats: &trustapi.AdditionalFormats{
+					JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: jksKey}},
+				},
+			},
+		},
+	}
+	resolvedBundle := Data{Data: data, BinaryData: map[string][]byte{jksKey: jksData}}
+	configMap := Resource{Kind: KindConfigMap, NamespacedName: types.NamespacedName{Name: bundleName}}
+	secret := Resource{Kind: KindSecret, NamespacedName: types.NamespacedName{Name: bundleName}}
+	formats := Resource{Kind: KindConfigMap, NamespacedName: types.NamespacedName{Name: "formats"}}
+
+	gotData, gotBinData := Content(configMap, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{key: data}, gotData)
+	assert.Equal(t, map[string][]byte{jksKey: jksData}, gotBinData)
+
+	gotData, _ = Content(secret, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{"secret.pem": data}, gotData)
+
+	bundle.Spec.Target.AdditionalFormatsTarget = &trustapi.AdditionalFormatsTarget{Name: "formats"}
+
+	gotData, gotBinData = Content(configMap, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{key: data}, gotData)
+	assert.Empty(t, gotBinData)
+
+	gotData, gotBinData = Content(formats, bundle, resolvedBundle)
+	assert.Empty(t, gotData)
+	assert.Equal(t, map[string][]byte{jksKey: jksData}, gotBinData)
+
+	bundle.Spec.Target.Signature = &trustapi.BundleSignature{}
+	resolvedBundle.Signature = "c2lnbmF0dXJl"
+
+	gotData, _ = Content(configMap, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{key: data, key + ".sig": "c2lnbmF0dXJl"}, gotData)
+
+	gotData, _ = Content(formats, bundle, resolvedBundle)
+	assert.Empty(t, gotData)
+
+	bundle.Spec.Target.Signature.Key = "bundle.sig"
+
+	gotData, _ = Content(secret, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{"secret.pem": data, "bundle.sig": "c2lnbmF0dXJl"}, gotData)
+
+	// The PEM bundle is also written to additional keys, but not signed there.
+	bundle.Spec.Target.Secret.AdditionalKeys = []string{"root-cert.pem", "ca.crt"}
+
+	gotData, _ = Content(secret, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{"secret.pem": data, "root-cert.pem": data, "ca.crt": data, "bundle.sig": "c2lnbmF0dXJl"}, gotData)
+
+	bundle.Spec.Target.Manifest = &trustapi.BundleManifest{}
+	resolvedBundle.Manifest = `{"certificates":[]}`
+
+	gotData, _ = Content(configMap, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{key: data, "bundle.sig": "c2lnbmF0dXJl", trustapi.DefaultManifestKey: `{"certificates":[]}`}, gotData)
+
+	gotData, _ = Content(formats, bundle, resolvedBundle)
+	assert.Empty(t, gotData)
+
+	// The certificates trusted for each usage are written to the usage keys,
+	// even if there are none.
+	bundle.Spec.Target.Manifest = nil
+	bundle.Spec.Target.UsageKeys = []trustapi.UsageKey{
+		{Usage: trustapi.CertificateUsageServerAuth, Key: "server-roots.pem"},
+		{Usage: trustapi.CertificateUsageClientAuth, Key: "client-roots.pem"},
+	}
+	resolvedBundle.UsageData = map[trustapi.CertificateUsage]string{trustapi.CertificateUsageServerAuth: "server"}
+
+	gotData, _ = Content(configMap, bundle, resolvedBundle)
+	assert.Equal(t, map[string]string{key: data, "bundle.sig": "c2lnbmF0dXJl", "server-roots.pem": "server", "client-roots.pem": ""}, gotData)
+
+	gotData, _ = Content(formats, bundle, resolvedBundle)
+	assert.Empty(t, gotData)
+}
+
+func Test_ValidateSize(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: bundleName},
+		Spec: trustapi.BundleSpec{
+			Target: trustapi.BundleTarget{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
+				AdditionalFormats: &trustapi.AdditionalFormats{
+					JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: jksKey}},
+				},
+			},
+		},
+	}
+	half := strings.Repeat("a", corev1.MaxSecretSize/2)
+	resolvedBundle := Data{Data: half, BinaryData: map[string][]byte{jksKey: []byte(half)}}
+
+	assert.EqualError(t, ValidateSize(bundle, resolvedBundle), fmt.Sprintf(
+		`target ConfigMap "test-bundle" would be %d bytes, which exceeds the limit of %d bytes; set spec.target.additionalFormatsTarget to write additional formats to a separate target`,
+		corev1.MaxSecretSize+len(key)+len(jksKey), corev1.MaxSecretSize,
+	))
+
+	bundle.Spec.Target.AdditionalFormatsTarget = &trustapi.AdditionalFormatsTarget{Name: "formats"}
+	assert.NoError(t, ValidateSize(bundle, resolvedBundle))
+
+	resolvedBundle.Data = half + half
+	assert.EqualError(t, ValidateSize(bundle, resolvedBundle), fmt.Sprintf(
+		`target ConfigMap "test-bundle" would be %d bytes, which exceeds the limit of %d bytes`,
+		corev1.MaxSecretSize+len(key), corev1.MaxSecretSize,
+	))
+}
+
+func Test_isImmutableSecretName(t *testing.T) {
+	bundle := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: bundleName}}
+
+	assert.True(t, isImmutableSecretName(bundleName+"-0123456789", bundle))
+	assert.False(t, isImmutableSecretName(bundleName, bundle))
+	assert.False(t, isImmutableSecretName(bundleName+"-formats", bundle))
+	assert.False(t, isImmutableSecretName("test-0123456789", bundle))
+	assert.False(t, isImmutableSecretName(bundleName+"-012345678z", bundle))
+
+	bundle.Name = strings.Repeat("a", 253)
+	assert.True(t, isImmutableSecretName(strings.Repeat("a", 242)+"-0123456789", bundle))
+}
+
+func Test_DataHash(t *testing.T) {
+	formats := &trustapi.AdditionalFormats{PKCS12: &trustapi.PKCS12{Password: ptr.To("password")}}
+	data := Data{
+		Data:      strings.Repeat(dummy.TestCertificate1, 10),
+		Signature: "signature",
+		Manifest:  "manifest",
+		UsageData: map[trustapi.CertificateUsage]string{
+			trustapi.CertificateUsageServerAuth: dummy.TestCertificate2,
+			trustapi.CertificateUsageClientAuth: dummy.TestCertificate3,
+		},
+	}
+
+	// The hash annotation of existing targets must not change.
+	content := data.Data + data.Signature + data.Manifest +
+		string(trustapi.CertificateUsageClientAuth) + dummy.TestCertificate3 +
+		string(trustapi.CertificateUsageServerAuth) + dummy.TestCertificate2
+	assert.Equal(t, TrustBundleHash([]byte(content), formats), data.hash(formats))
+}
+
+// BenchmarkDataHash measures hashing a large bundle, which is done for every
+// target of a Bundle.
+func BenchmarkDataHash(b *testing.B) {
+	data := Data{Data: strings.Repeat(dummy.DefaultJoinedCerts(), 1000)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		_ = data.hash(nil)
+	}
+}
+
 func Test_TrustBundleHash(t *testing.T) {
 	type inputArgs struct {
 		data              []byte
@@ -1320,6 +1852,7 @@ func Test_TrustBundleHash(t *testing.T) {
 			},
 			mismatches: []inputArgs{
 				{data: []byte("data"), additionalFormats: &trustapi.AdditionalFormats{PKCS12: &trustapi.PKCS12{Password: ptr.To("wrong")}}},
+				{data: []byte("data"), additionalFormats: &trustapi.AdditionalFormats{PKCS12: &trustapi.PKCS12{Password: ptr.To("password"), Deterministic: true}}},
 			},
 		},
 	}
@@ -1341,3 +1874,19 @@ func Test_TrustBundleHash(t *testing.T) {
 		})
 	}
 }
+
+// labelledCache is a reader which, like the target cache of trust-manager,
+// only finds objects with the bundle label.
+type labelledCache struct {
+	client.Reader
+}
+
+func (c labelledCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Reader.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if _, ok := obj.GetLabels()[trustapi.BundleLabelKey]; !ok {
+		return apierrors.NewNotFound(corev1.Resource(strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)), key.Name)
+	}
+	return nil
+}
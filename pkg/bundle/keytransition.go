@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// reconcileKeyTransitions returns the key transition status of the targets of
+// the Bundle with a key transition, along with how long until the next
+// transition with a duration ends.
+//
+// The key of each kind of target with a key transition is recorded in the
+// status. When it changes, a transition starts, during which the PEM bundle is
+// still written to the previous key. The transition ends once its duration
+// has passed or the key transition annotation, which is set on the Bundle
+// while any transition is in progress, is removed.
+func (b *bundle) reconcileKeyTransitions(ctx context.Context, bundle *trustapi.Bundle) ([]trustapi.KeyTransitionStatus, time.Duration, error) {
+	now := b.clock.Now()
+	_, annotated := bundle.GetAnnotations()[trustapi.BundleKeyTransitionAnnotationKey]
+
+	var (
+		transitions  []trustapi.KeyTransitionStatus
+		previousKeys []string
+		requeueAfter time.Duration
+	)
+	for _, kind := range []target.Kind{target.KindConfigMap, target.KindSecret} {
+		t := targetFor(bundle, kind)
+		if t == nil || t.KeyTransition == nil {
+			continue
+		}
+
+		var key string
+		switch kind {
+		case target.KindConfigMap:
+			key = t.ConfigMap.Key
+		case target.KindSecret:
+			key = t.Secret.Key
+		}
+
+		current := trustapi.KeyTransitionStatus{Kind: string(kind), Key: key}
+		if last := keyTransitionStatus(bundle.Status.KeyTransitions, kind); last != nil {
+			switch {
+			case last.Key != key:
+				current.PreviousKey = last.Key
+				current.StartTime = &metav1.Time{Time: now}
+			case last.PreviousKey != "" && annotated:
+				current.PreviousKey = last.PreviousKey
+				current.StartTime = last.StartTime
+			}
+		}
+
+		if duration := t.KeyTransition.Duration; current.PreviousKey != "" && duration != nil && current.StartTime != nil {
+			remaining := current.StartTime.Add(duration.Duration).Sub(now)
+			switch {
+			case remaining <= 0:
+				current.PreviousKey, current.StartTime = "", nil
+			case requeueAfter == 0 || remaining < requeueAfter:
+				requeueAfter = remaining
+			}
+		}
+		if current.PreviousKey != "" {
+			previousKeys = append(previousKeys, current.PreviousKey)
+		}
+
+		transitions = append(transitions, current)
+	}
+
+	// The annotation is set before the status, so that a transition is never
+	// reported in progress without it.
+	if err := b.reconcileKeyTransitionAnnotation(ctx, bundle, previousKeys); err != nil {
+		return nil, 0, err
+	}
+
+	return transitions, requeueAfter, nil
+}
+
+// reconcileKeyTransitionAnnotation sets the key transition annotation of the
+// Bundle to the previous keys of the transitions in progress, or removes it
+// if there are none.
+func (b *bundle) reconcileKeyTransitionAnnotation(ctx context.Context, bundle *trustapi.Bundle, previousKeys []string) error {
+	slices.Sort(previousKeys)
+	value := strings.Join(previousKeys, ",")
+
+	current, ok := bundle.GetAnnotations()[trustapi.BundleKeyTransitionAnnotationKey]
+	if ok == (len(previousKeys) > 0) && (!ok || current == value) {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	annotations := bundle.GetAnnotations()
+	if len(previousKeys) > 0 {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[trustapi.BundleKeyTransitionAnnotationKey] = value
+	} else {
+		delete(annotations, trustapi.BundleKeyTransitionAnnotationKey)
+	}
+	bundle.SetAnnotations(annotations)
+
+	if err := b.client.Patch(ctx, bundle, patch); err != nil {
+		return fmt.Errorf("failed to update bundle key transition annotation: %w", err)
+	}
+
+	return nil
+}
+
+// keyTransitionStatus returns the key transition status of the targets of the
+// given kind, or nil if there is none.
+func keyTransitionStatus(transitions []trustapi.KeyTransitionStatus, kind target.Kind) *trustapi.KeyTransitionStatus {
+	for i := range transitions {
+		if transitions[i].Kind == string(kind) {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
+
+// withPreviousKeys adds the previous key of each transition in progress to
+// the additional keys of the target of the target Bundle, unless the target
+// already writes to it.
+func withPreviousKeys(targetBundle *trustapi.Bundle, transitions []trustapi.KeyTransitionStatus) {
+	t := &targetBundle.Spec.Target
+	for _, transition := range transitions {
+		if transition.PreviousKey == "" {
+			continue
+		}
+
+		var (
+			key            string
+			additionalKeys *[]string
+		)
+		switch {
+		case transition.Kind == string(target.KindConfigMap) && t.ConfigMap != nil:
+			key, additionalKeys = t.ConfigMap.Key, &t.ConfigMap.AdditionalKeys
+		case transition.Kind == string(target.KindSecret) && t.Secret != nil:
+			key, additionalKeys = t.Secret.Key, &t.Secret.AdditionalKeys
+		default:
+			continue
+		}
+
+		// Targets whose key differs from the transition's belong to another
+		// target of the Bundle.
+		if key != transition.Key || slices.Contains(*additionalKeys, transition.PreviousKey) || formatKeys(t.AdditionalFormats)[transition.PreviousKey] {
+			continue
+		}
+		*additionalKeys = append(*additionalKeys, transition.PreviousKey)
+	}
+}
+
+// formatKeys returns the keys which the additional formats are written to.
+func formatKeys(formats *trustapi.AdditionalFormats) map[string]bool {
+	keys := map[string]bool{}
+	if formats == nil {
+		return keys
+	}
+	if formats.JKS != nil {
+		keys[formats.JKS.Key] = true
+	}
+	if formats.PKCS12 != nil {
+		keys[formats.PKCS12.Key] = true
+	}
+	if formats.SPIFFE != nil {
+		keys[formats.SPIFFE.Key] = true
+	}
+	if formats.Certdata != nil {
+		keys[formats.Certdata.Key] = true
+	}
+	return keys
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+func Test_reconcileKeyTransitions(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(now.Add(-time.Minute))
+
+	configMapTarget := func(key string, duration *metav1.Duration) gen.BundleModifier {
+		return func(b *trustapi.Bundle) {
+			b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}}
+			b.Spec.Target.KeyTransition = &trustapi.KeyTransition{Duration: duration}
+		}
+	}
+	withStatus := func(transitions ...trustapi.KeyTransitionStatus) gen.BundleModifier {
+		return func(b *trustapi.Bundle) {
+			b.Status.KeyTransitions = transitions
+		}
+	}
+	withAnnotation := func(value string) gen.BundleModifier {
+		return func(b *trustapi.Bundle) {
+			b.Annotations = map[string]string{trustapi.BundleKeyTransitionAnnotationKey: value}
+		}
+	}
+
+	tests := map[string]struct {
+		bundle *trustapi.Bundle
+
+		expTransitions []trustapi.KeyTransitionStatus
+		expRequeue     time.Duration
+		expAnnotations map[string]string
+	}{
+		"if target has no key transition, record nothing": {
+			bundle: gen.Bundle(bundleName, configMapTarget("trust.pem", nil), func(b *trustapi.Bundle) {
+				b.Spec.Target.KeyTransition = nil
+			}),
+			expTransitions: nil,
+		},
+		"if key is unrecorded, record it": {
+			bundle:         gen.Bundle(bundleName, configMapTarget("trust.pem", nil)),
+			expTransitions: []trustapi.KeyTransitionStatus{{Kind: "ConfigMap", Key: "trust.pem"}},
+		},
+		"if key changed, start a transition and set the annotation": {
+			bundle: gen.Bundle(bundleName, configMapTarget("ca.crt", &metav1.Duration{Duration: time.Hour}),
+				withStatus(trustapi.KeyTransitionStatus{Kind: "ConfigMap", Key: "trust.pem"})),
+			expTransitions: []trustapi.KeyTransitionStatus{{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &metav1.Time{Time: now}}},
+			expRequeue:     time.Hour,
+			expAnnotations: map[string]string{trustapi.BundleKeyTransitionAnnotationKey: "trust.pem"},
+		},
+		"if transition is in progress, keep it until its duration has passed": {
+			bundle: gen.Bundle(bundleName, configMapTarget("ca.crt", &metav1.Duration{Duration: time.Hour}), withAnnotation("trust.pem"),
+				withStatus(trustapi.KeyTransitionStatus{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &started})),
+			expTransitions: []trustapi.KeyTransitionStatus{{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &started}},
+			expRequeue:     59 * time.Minute,
+			expAnnotations: map[string]string{trustapi.BundleKeyTransitionAnnotationKey: "trust.pem"},
+		},
+		"if transition has no duration, keep it while annotated": {
+			bundle: gen.Bundle(bundleName, configMapTarget("ca.crt", nil), withAnnotation("trust.pem"),
+				withStatus(trustapi.KeyTransitionStatus{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &started})),
+			expTransitions: []trustapi.KeyTransitionStatus{{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &started}},
+			expAnnotations: map[string]string{trustapi.BundleKeyTransitionAnnotationKey: "trust.pem"},
+		},
+		"if annotation was removed, end the transition": {
+			bundle: gen.Bundle(bundleName, configMapTarget("ca.crt", &metav1.Duration{Duration: time.Hour}),
+				withStatus(trustapi.KeyTransitionStatus{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &started})),
+			expTransitions: []trustapi.KeyTransitionStatus{{Kind: "ConfigMap", Key: "ca.crt"}},
+		},
+		"if duration has passed, end the transition and remove the annotation": {
+			bundle: gen.Bundle(bundleName, configMapTarget("ca.crt", &metav1.Duration{Duration: time.Minute}), withAnnotation("trust.pem"),
+				withStatus(trustapi.KeyTransitionStatus{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem", StartTime: &started})),
+			expTransitions: []trustapi.KeyTransitionStatus{{Kind: "ConfigMap", Key: "ca.crt"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(test.bundle).
+				Build()
+
+			log, ctx := ktesting.NewTestContext(t)
+			b := &bundle{
+				client:  fakeClient,
+				clock:   fakeclock.NewFakeClock(now),
+				Options: Options{Log: log},
+			}
+
+			transitions, requeue, err := b.reconcileKeyTransitions(ctx, test.bundle.DeepCopy())
+			require.NoError(t, err)
+			assert.Equal(t, test.expTransitions, transitions)
+			assert.Equal(t, test.expRequeue, requeue)
+
+			var bundle trustapi.Bundle
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+			assert.Equal(t, test.expAnnotations, bundle.Annotations)
+		})
+	}
+}
+
+func Test_withPreviousKeys(t *testing.T) {
+	transitions := []trustapi.KeyTransitionStatus{
+		{Kind: "ConfigMap", Key: "ca.crt", PreviousKey: "trust.pem"},
+		{Kind: "Secret", Key: "trust.pem"},
+	}
+
+	targetBundle := &trustapi.Bundle{Spec: trustapi.BundleSpec{Target: trustapi.BundleTarget{
+		ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}},
+		Secret:    &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+	}}}
+	withPreviousKeys(targetBundle, transitions)
+	assert.Equal(t, []string{"trust.pem"}, targetBundle.Spec.Target.ConfigMap.AdditionalKeys)
+	assert.Empty(t, targetBundle.Spec.Target.Secret.AdditionalKeys)
+
+	// The previous key isn't written twice, nor over an additional format.
+	targetBundle.Spec.Target.AdditionalFormats = &trustapi.AdditionalFormats{JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}}
+	targetBundle.Spec.Target.ConfigMap.AdditionalKeys = nil
+	withPreviousKeys(targetBundle, transitions)
+	assert.Empty(t, targetBundle.Spec.Target.ConfigMap.AdditionalKeys)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// manifest describes the certificates of a bundle and where they came from,
+// so that auditors can tell which source contributed a certificate.
+type manifest struct {
+	Certificates []manifestCertificate `json:"certificates"`
+}
+
+type manifestCertificate struct {
+	// Fingerprint is the hex-encoded SHA-256 fingerprint of the certificate.
+	Fingerprint string `json:"fingerprint"`
+	Subject     string `json:"subject"`
+	NotAfter    string `json:"notAfter"`
+
+	// Sources are the sources which contributed the certificate, in the
+	// order of the sources of the Bundle.
+	Sources []manifestSource `json:"sources"`
+
+	// Usages are the usages which the certificate is trusted for by its
+	// sources.
+	Usages []trustapi.CertificateUsage `json:"usages"`
+}
+
+// manifestSource identifies a source of a Bundle.
+type manifestSource struct {
+	// Index is the index of the source in the sources of the Bundle. It's
+	// unset for snapshots, which a rolled back Bundle is built from.
+	Index *int `json:"index,omitempty"`
+
+	// Kind is the kind of the source: ConfigMap, Secret, InLine, Issuer,
+	// ClusterIssuer, Certificates, SPIFFEFederation, AWSPrivateCA, GCPCASPool,
+	// AzureKeyVault, Plugin, InClusterCA, DefaultCAs or Snapshot. The Name of
+	// SPIFFEFederation sources is their trust domain, that of AWSPrivateCA
+	// sources the ARN of the CA, that of GCPCASPool sources the resource name
+	// of the CA pool, that of AzureKeyVault sources the URL of the
+	// certificate, and that of Plugin sources the name of the plugin and
+	// the parameters of the source. ConfigMap and Secret sources with a
+	// NamespaceSelector have no Namespace.
+	Kind              string `json:"kind"`
+	Namespace         string `json:"namespace,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Selector          string `json:"selector,omitempty"`
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+	Key               string `json:"key,omitempty"`
+
+	// Version is the version of the default CA package, for DefaultCAs
+	// sources.
+	Version string `json:"version,omitempty"`
+
+	// Usages are the usages of the source, if it's limited to some.
+	Usages []trustapi.CertificateUsage `json:"usages,omitempty"`
+}
+
+// manifestSourceOf returns the description of the source at the given index
+// of the sources of a Bundle.
+func (b *bundle) manifestSourceOf(index int, source trustapi.BundleSource) manifestSource {
+	ms := manifestSource{Index: &index, Usages: source.Usages}
+
+	objectSource := func(kind string, ref *trustapi.SourceObjectKeySelector) {
+		ms.Kind, ms.Namespace, ms.Name, ms.Key = kind, b.Namespace, ref.Name, ref.Key
+		if ref.Selector != nil {
+			ms.Selector = metav1.FormatLabelSelector(ref.Selector)
+		}
+		if ref.NamespaceSelector != nil {
+			ms.Namespace, ms.NamespaceSelector = "", metav1.FormatLabelSelector(ref.NamespaceSelector)
+		}
+	}
+
+	switch {
+	case source.ConfigMap != nil:
+		objectSource("ConfigMap", source.ConfigMap)
+	case source.Secret != nil:
+		objectSource("Secret", source.Secret)
+	case source.InLine != nil:
+		ms.Kind = "InLine"
+	case source.IssuerRef != nil:
+		ms.Kind, ms.Name = source.IssuerRef.Kind, source.IssuerRef.Name
+		if ms.Kind == "" {
+			ms.Kind = "Issuer"
+		}
+		if ms.Kind == "Issuer" {
+			ms.Namespace = b.Namespace
+		}
+	case source.Certificates != nil:
+		ms.Kind, ms.Namespace, ms.Key = "Certificates", b.Namespace, "ca.crt"
+		ms.Selector = metav1.FormatLabelSelector(&source.Certificates.Selector)
+	case source.SPIFFEFederation != nil:
+		ms.Kind, ms.Name = "SPIFFEFederation", source.SPIFFEFederation.TrustDomain
+	case source.AWSPrivateCA != nil, source.GCPCASPool != nil, source.AzureKeyVault != nil, source.Plugin != nil:
+		cloud, _ := cloudSource(source)
+		ms.Kind, ms.Name = cloud.Provider, cloud.Ref
+	case source.UseInClusterCA != nil:
+		ms.Kind, ms.Namespace, ms.Name, ms.Key = "InClusterCA", b.Namespace, kubeRootCAConfigMapName, kubeRootCAConfigMapKey
+	case source.UseDefaultCAs != nil:
+		ms.Kind = "DefaultCAs"
+		if b.defaultPackage != nil {
+			ms.Version = b.defaultPackage.StringID()
+		}
+	}
+
+	return ms
+}
+
+// addProvenance records the source as a contributor of each certificate in
+// its PEM data.
+func (d *bundleData) addProvenance(source manifestSource, sourceData []byte) {
+	if d.provenance == nil {
+		d.provenance = make(map[string][]manifestSource)
+	}
+
+	rest := util.NormalizePEM(sourceData)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return
+		}
+
+		hash := sha256.Sum256(block.Bytes)
+		fingerprint := hex.EncodeToString(hash[:])
+		d.provenance[fingerprint] = append(d.provenance[fingerprint], source)
+	}
+}
+
+// buildManifest returns the JSON manifest of the certificates in the pool,
+// with the sources recorded for each.
+func buildManifest(pool *util.CertPool, provenance map[string][]manifestSource) (string, error) {
+	m := manifest{Certificates: []manifestCertificate{}}
+	for _, certificate := range pool.Certificates() {
+		hash := sha256.Sum256(certificate.Raw)
+		fingerprint := hex.EncodeToString(hash[:])
+
+		m.Certificates = append(m.Certificates, manifestCertificate{
+			Fingerprint: fingerprint,
+			Subject:     certificate.Subject.String(),
+			NotAfter:    certificate.NotAfter.UTC().Format(time.RFC3339),
+			Sources:     provenance[fingerprint],
+			Usages:      certificateUsages(provenance[fingerprint]),
+		})
+	}
+
+	encoded, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// allCertificateUsages are the usages which certificates can be trusted for,
+// in the order they're listed in.
+var allCertificateUsages = []trustapi.CertificateUsage{
+	trustapi.CertificateUsageServerAuth,
+	trustapi.CertificateUsageClientAuth,
+}
+
+// certificateUsages returns the usages which a certificate contributed by the
+// given sources is trusted for: those of any of its sources, where a source
+// without usages trusts the certificate for every usage. Snapshots don't
+// record the usages of their sources, so certificates of a rolled back Bundle
+// are trusted for every usage.
+func certificateUsages(sources []manifestSource) []trustapi.CertificateUsage {
+	usages := sets.New[trustapi.CertificateUsage]()
+	for _, source := range sources {
+		if len(source.Usages) == 0 {
+			return allCertificateUsages
+		}
+		usages.Insert(source.Usages...)
+	}
+	if len(sources) == 0 {
+		return allCertificateUsages
+	}
+
+	var ordered []trustapi.CertificateUsage
+	for _, usage := range allCertificateUsages {
+		if usages.Has(usage) {
+			ordered = append(ordered, usage)
+		}
+	}
+	return ordered
+}
+
+// buildUsageData returns the PEM bundle of the certificates in the pool which
+// are trusted for each usage, in the order of the pool. Usages which no
+// certificate is trusted for get an empty bundle.
+func buildUsageData(pool *util.CertPool, provenance map[string][]manifestSource, trailingNewline bool) map[trustapi.CertificateUsage]string {
+	usagePools := make(map[trustapi.CertificateUsage]*bytes.Buffer, len(allCertificateUsages))
+	for _, usage := range allCertificateUsages {
+		usagePools[usage] = &bytes.Buffer{}
+	}
+
+	for _, certificate := range pool.Certificates() {
+		hash := sha256.Sum256(certificate.Raw)
+		fingerprint := hex.EncodeToString(hash[:])
+
+		for _, usage := range certificateUsages(provenance[fingerprint]) {
+			// Encoding to a buffer can't fail.
+			_ = pem.Encode(usagePools[usage], &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+		}
+	}
+
+	usageData := make(map[trustapi.CertificateUsage]string, len(usagePools))
+	for usage, buffer := range usagePools {
+		usageData[usage] = string(bytes.TrimSpace(buffer.Bytes()))
+		if trailingNewline && usageData[usage] != "" {
+			usageData[usage] += "\n"
+		}
+	}
+	return usageData
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_buildManifest(t *testing.T) {
+	const trustNamespace = "trust-namespace"
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: trustNamespace, Name: "roots"},
+			Data:       map[string]string{"ca.crt": dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)},
+		}).
+		Build()
+
+	log, ctx := ktesting.NewTestContext(t)
+	b := &bundle{
+		client:  fakeClient,
+		Options: Options{Log: log, Namespace: trustNamespace},
+	}
+
+	resolvedBundle, err := b.buildSourceBundle(ctx, []trustapi.BundleSource{
+		{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "roots", Key: "ca.crt"}},
+		{InLine: ptr.To(dummy.TestCertificate2)},
+	}, nil, false, trustapi.ExpiredCertificatePolicyKeep, util.DeduplicateFingerprint, trustapi.InvalidCertificatePolicyFail)
+	require.NoError(t, err)
+
+	encoded, err := buildManifest(resolvedBundle.pool, resolvedBundle.provenance)
+	require.NoError(t, err)
+
+	var m manifest
+	require.NoError(t, json.Unmarshal([]byte(encoded), &m))
+	require.Len(t, m.Certificates, 2)
+
+	configMapSource := manifestSource{Index: ptr.To(0), Kind: "ConfigMap", Namespace: trustNamespace, Name: "roots", Key: "ca.crt"}
+	inLineSource := manifestSource{Index: ptr.To(1), Kind: "InLine"}
+
+	fingerprint := func(certPEM string) string {
+		block, _ := pem.Decode([]byte(certPEM))
+		hash := sha256.Sum256(block.Bytes)
+		return hex.EncodeToString(hash[:])
+	}
+	sources := map[string][]manifestSource{}
+	for _, certificate := range m.Certificates {
+		sources[certificate.Fingerprint] = certificate.Sources
+	}
+
+	// A certificate found in several sources lists all of them.
+	assert.Equal(t, []manifestSource{configMapSource}, sources[fingerprint(dummy.TestCertificate1)])
+	assert.Equal(t, []manifestSource{configMapSource, inLineSource}, sources[fingerprint(dummy.TestCertificate2)])
+
+	// Sources without usages trust their certificates for every usage.
+	for _, certificate := range m.Certificates {
+		assert.Equal(t, allCertificateUsages, certificate.Usages)
+	}
+}
+
+func Test_buildUsageData(t *testing.T) {
+	log, ctx := ktesting.NewTestContext(t)
+	b := &bundle{
+		client:  fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).Build(),
+		Options: Options{Log: log, Namespace: "trust-namespace"},
+	}
+
+	// TestCertificate1 is trusted for server authentication only, and
+	// TestCertificate2 for client authentication by one source and for
+	// server authentication by another. TestCertificate3 is trusted for every
+	// usage by a source without usages.
+	resolvedBundle, err := b.buildSourceBundle(ctx, []trustapi.BundleSource{
+		{InLine: ptr.To(dummy.TestCertificate1), Usages: []trustapi.CertificateUsage{trustapi.CertificateUsageServerAuth}},
+		{InLine: ptr.To(dummy.TestCertificate2), Usages: []trustapi.CertificateUsage{trustapi.CertificateUsageClientAuth}},
+		{InLine: ptr.To(dummy.TestCertificate2), Usages: []trustapi.CertificateUsage{trustapi.CertificateUsageServerAuth}},
+		{InLine: ptr.To(dummy.TestCertificate3)},
+	}, nil, false, trustapi.ExpiredCertificatePolicyKeep, util.DeduplicateFingerprint, trustapi.InvalidCertificatePolicyFail)
+	require.NoError(t, err)
+
+	usageData := buildUsageData(resolvedBundle.pool, resolvedBundle.provenance, false)
+
+	certificates := func(bundlePEM string) []string {
+		pool := util.NewCertPool()
+		require.NoError(t, pool.AddCertsFromPEM([]byte(bundlePEM)))
+		return pool.PEMSplit()
+	}
+	assert.ElementsMatch(t,
+		certificates(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2, dummy.TestCertificate3)),
+		certificates(usageData[trustapi.CertificateUsageServerAuth]),
+	)
+	assert.ElementsMatch(t,
+		certificates(dummy.JoinCerts(dummy.TestCertificate2, dummy.TestCertificate3)),
+		certificates(usageData[trustapi.CertificateUsageClientAuth]),
+	)
+
+	encoded, err := buildManifest(resolvedBundle.pool, resolvedBundle.provenance)
+	require.NoError(t, err)
+
+	var m manifest
+	require.NoError(t, json.Unmarshal([]byte(encoded), &m))
+	usages := map[string][]trustapi.CertificateUsage{}
+	for _, certificate := range m.Certificates {
+		usages[certificate.Fingerprint] = certificate.Usages
+	}
+
+	fingerprint := func(certPEM string) string {
+		block, _ := pem.Decode([]byte(certPEM))
+		hash := sha256.Sum256(block.Bytes)
+		return hex.EncodeToString(hash[:])
+	}
+	assert.Equal(t, []trustapi.CertificateUsage{trustapi.CertificateUsageServerAuth}, usages[fingerprint(dummy.TestCertificate1)])
+	assert.Equal(t, allCertificateUsages, usages[fingerprint(dummy.TestCertificate2)])
+	assert.Equal(t, allCertificateUsages, usages[fingerprint(dummy.TestCertificate3)])
+}
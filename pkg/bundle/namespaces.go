@@ -0,0 +1,361 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"maps"
+	"path"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// namespacePredicate filters Namespace events down to those which can change
+// the targets of Bundles: Namespaces being created or deleted, starting to
+// terminate, changing their labels or opting in or out of targets. Other updates, such as the condition
+// updates of a terminating Namespace, are dropped.
+func namespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNamespace, ok := e.ObjectOld.(*corev1.Namespace)
+			if !ok {
+				return true
+			}
+			newNamespace, ok := e.ObjectNew.(*corev1.Namespace)
+			if !ok {
+				return true
+			}
+
+			return !labels.Equals(oldNamespace.Labels, newNamespace.Labels) ||
+				oldNamespace.Status.Phase != newNamespace.Status.Phase ||
+				namespaceOptedOut(oldNamespace) != namespaceOptedOut(newNamespace)
+		},
+	}
+}
+
+const (
+	// namespaceLabelsIndex is the name of the field index of Bundles by the
+	// keys of the Namespace labels which their targets depend on.
+	namespaceLabelsIndex = "namespaceLabels"
+
+	// anyNamespaceIndexValue is indexed for Bundles with a target which may
+	// select Namespaces without any of the indexed labels, such as a target
+	// without a namespace selector. It isn't a valid label key.
+	anyNamespaceIndexValue = "*"
+)
+
+// indexNamespaceLabels returns the keys of the Namespace labels which the
+// targets of the Bundle depend on: the keys used by their namespace
+// selectors, key overrides and rollout strategies, and by the namespace
+// selectors of its sources. A Namespace changing none of these labels can't
+// change the targets of the Bundle.
+func indexNamespaceLabels(obj client.Object) []string {
+	bundle, ok := obj.(*trustapi.Bundle)
+	if !ok {
+		return nil
+	}
+
+	keys := sets.New[string]()
+	for _, target := range bundle.Spec.AllTargets() {
+		if !insertSelectorKeys(keys, target.NamespaceSelector) {
+			keys.Insert(anyNamespaceIndexValue)
+		}
+		for _, override := range target.KeyOverrides {
+			insertSelectorKeys(keys, &override.NamespaceSelector)
+		}
+		if rollout := target.RolloutStrategy; rollout != nil {
+			if rollout.Canary != nil {
+				insertSelectorKeys(keys, rollout.Canary.NamespaceSelector)
+			}
+			if rollout.Progressive != nil && rollout.Progressive.OrderLabel != "" {
+				keys.Insert(rollout.Progressive.OrderLabel)
+			}
+		}
+	}
+	for _, selector := range sourceNamespaceSelectors(bundle) {
+		if !insertSelectorKeys(keys, selector) {
+			keys.Insert(anyNamespaceIndexValue)
+		}
+	}
+
+	return sets.List(keys)
+}
+
+// insertSelectorKeys inserts the label keys used by the selector into keys.
+// It returns true if the selector only matches Namespaces with at least one of
+// these labels.
+func insertSelectorKeys(keys sets.Set[string], selector *metav1.LabelSelector) bool {
+	if selector == nil {
+		return false
+	}
+
+	requiresLabel := false
+	for key := range selector.MatchLabels {
+		keys.Insert(key)
+		requiresLabel = true
+	}
+	for _, requirement := range selector.MatchExpressions {
+		keys.Insert(requirement.Key)
+		if requirement.Operator == metav1.LabelSelectorOpIn || requirement.Operator == metav1.LabelSelectorOpExists {
+			requiresLabel = true
+		}
+	}
+
+	return requiresLabel
+}
+
+// namespaceEventHandler returns an event handler which reconciles the Bundles
+// with a target or a source selecting the Namespace. On label changes, Bundles selecting
+// either the old or the new labels are reconciled, so that targets are removed
+// from Namespaces which no longer match.
+//
+// Bundles are looked up through the namespaceLabelsIndex, so that a Namespace
+// changing its labels only reconciles the Bundles depending on the changed
+// labels, rather than every Bundle selecting it.
+func (b *bundle) namespaceEventHandler() handler.EventHandler {
+	enqueue := func(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], keys sets.Set[string], objs ...client.Object) {
+		for _, bundle := range b.mustBundleListByNamespaceLabels(ctx, keys) {
+			if b.bundleSelectsAnyNamespace(&bundle, objs...) {
+				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: bundle.Name}})
+			}
+		}
+	}
+
+	// allKeys returns the index values of every Bundle which may select any of
+	// the Namespaces.
+	allKeys := func(objs ...client.Object) sets.Set[string] {
+		keys := sets.New(anyNamespaceIndexValue)
+		for _, obj := range objs {
+			keys.Insert(slices.Collect(maps.Keys(obj.GetLabels()))...)
+		}
+		return keys
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, allKeys(e.Object), e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			keys := changedLabelKeys(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+			if namespacePhaseChanged(e.ObjectOld, e.ObjectNew) || namespaceOptedOut(e.ObjectOld) != namespaceOptedOut(e.ObjectNew) || b.excludeSelectorUsesAny(keys) {
+				keys = allKeys(e.ObjectOld, e.ObjectNew)
+			}
+			enqueue(ctx, q, keys, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, allKeys(e.Object), e.Object)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, q, allKeys(e.Object), e.Object)
+		},
+	}
+}
+
+// mustBundleListByNamespaceLabels returns the Bundles indexed under any of the
+// keys, each only once. If an error occurs, will exit error the program.
+func (b *bundle) mustBundleListByNamespaceLabels(ctx context.Context, keys sets.Set[string]) []trustapi.Bundle {
+	seen := sets.New[string]()
+
+	var bundles []trustapi.Bundle
+	for _, key := range sets.List(keys) {
+		for _, bundle := range b.mustBundleList(ctx, client.MatchingFields{namespaceLabelsIndex: key}).Items {
+			if !seen.Has(bundle.Name) {
+				seen.Insert(bundle.Name)
+				bundles = append(bundles, bundle)
+			}
+		}
+	}
+
+	return bundles
+}
+
+// changedLabelKeys returns the keys of the labels which were added, removed or
+// changed.
+func changedLabelKeys(oldLabels, newLabels map[string]string) sets.Set[string] {
+	keys := sets.New[string]()
+	for key, value := range oldLabels {
+		if newValue, ok := newLabels[key]; !ok || newValue != value {
+			keys.Insert(key)
+		}
+	}
+	for key := range newLabels {
+		if _, ok := oldLabels[key]; !ok {
+			keys.Insert(key)
+		}
+	}
+
+	return keys
+}
+
+// namespacePhaseChanged returns true if the objects are Namespaces in
+// different phases.
+func namespacePhaseChanged(oldObj, newObj client.Object) bool {
+	oldNamespace, ok := oldObj.(*corev1.Namespace)
+	if !ok {
+		return false
+	}
+	newNamespace, ok := newObj.(*corev1.Namespace)
+	if !ok {
+		return false
+	}
+
+	return oldNamespace.Status.Phase != newNamespace.Status.Phase
+}
+
+// excludeSelectorUsesAny returns true if the operator's exclude namespace
+// selector depends on any of the label keys, in which case changing them may
+// change the targets of any Bundle.
+func (b *bundle) excludeSelectorUsesAny(keys sets.Set[string]) bool {
+	selector := b.tunables().ExcludeNamespaceSelector
+	if selector == nil {
+		return false
+	}
+
+	requirements, _ := selector.Requirements()
+	for _, requirement := range requirements {
+		if keys.Has(requirement.Key()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bundleSelectsAnyNamespace returns true if any target of the Bundle selects
+// any of the Namespaces, or any of its sources reads objects from them.
+func (b *bundle) bundleSelectsAnyNamespace(bundle *trustapi.Bundle, namespaces ...client.Object) bool {
+	for _, sourceSelector := range sourceNamespaceSelectors(bundle) {
+		for _, namespace := range namespaces {
+			if labelsMatchSelector(namespace.GetLabels(), sourceSelector) {
+				return true
+			}
+		}
+	}
+
+	for _, targetBundle := range targetBundles(bundle) {
+		selector, err := b.bundleTargetNamespaceSelector(targetBundle)
+		if err != nil {
+			// We have an invalid selector, so we can skip this target.
+			continue
+		}
+
+		for _, namespace := range namespaces {
+			if selector.Matches(labels.Set(namespace.GetLabels())) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getNamespace returns the named Namespace, or nil if it doesn't exist.
+func (b *bundle) getNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	var namespace corev1.Namespace
+	if err := b.client.Get(ctx, types.NamespacedName{Name: name}, &namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &namespace, nil
+}
+
+// namespaceIsGone returns true if the Namespace is terminating or no longer
+// exists, in which case its targets are removed along with it.
+func namespaceIsGone(namespace *corev1.Namespace) bool {
+	return namespace == nil || namespace.Status.Phase == corev1.NamespaceTerminating || namespace.DeletionTimestamp != nil
+}
+
+// namespaceExcluded returns true if the operator excluded the Namespace from
+// being written to, by name or by labels.
+func (b *bundle) namespaceExcluded(namespace *corev1.Namespace) bool {
+	tunables := b.tunables()
+	for _, pattern := range tunables.ExcludeNamespaces {
+		if matched, _ := path.Match(pattern, namespace.Name); matched {
+			return true
+		}
+	}
+
+	if selector := tunables.ExcludeNamespaceSelector; selector != nil && !selector.Empty() {
+		return selector.Matches(labels.Set(namespace.Labels))
+	}
+
+	return false
+}
+
+// namespaceOptedOut returns true if the Namespace opted out of the targets of
+// all Bundles with the exclude annotation.
+func namespaceOptedOut(namespace metav1.Object) bool {
+	return namespace.GetAnnotations()[trustapi.NamespaceExcludeAnnotationKey] == "true"
+}
+
+// maxOptedOutNamespaceNames is the number of opted out Namespaces listed in
+// the Bundle status.
+const maxOptedOutNamespaceNames = 10
+
+// setOptedOutNamespacesStatus sets the summary of the selected Namespaces
+// which opted out of the targets of the Bundle in the status patch. Returns
+// true if the summary changed.
+func setOptedOutNamespacesStatus(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, namespaces sets.Set[string]) bool {
+	var summary *trustapi.OptedOutNamespaces
+	if namespaces.Len() > 0 {
+		names := sets.List(namespaces)
+		summary = &trustapi.OptedOutNamespaces{
+			Count: int32(len(names)), // #nosec G115 -- bounded by the number of Namespaces
+			Names: names[:min(len(names), maxOptedOutNamespaceNames)],
+		}
+	}
+	statusPatch.OptedOutNamespaces = summary
+
+	return !apiequality.Semantic.DeepEqual(bundle.Status.OptedOutNamespaces, summary)
+}
+
+// namespaceWatched returns true if trust-manager may write targets to the
+// Namespace, which is any Namespace unless it runs in namespaced mode.
+func (b *bundle) namespaceWatched(name string) bool {
+	return len(b.Options.WatchNamespaces) == 0 || slices.Contains(b.Options.WatchNamespaces, name)
+}
+
+// sourceNamespaceSelectors returns the namespace selectors of the ConfigMap
+// and Secret sources of the Bundle.
+func sourceNamespaceSelectors(bundle *trustapi.Bundle) []*metav1.LabelSelector {
+	var selectors []*metav1.LabelSelector
+	for _, source := range bundle.Spec.Sources {
+		for _, ref := range []*trustapi.SourceObjectKeySelector{source.ConfigMap, source.Secret} {
+			if ref != nil && ref.NamespaceSelector != nil {
+				selectors = append(selectors, ref.NamespaceSelector)
+			}
+		}
+	}
+	return selectors
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func Test_namespacePredicate(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{"foo": "bar"}},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+	}
+
+	relabelled := namespace.DeepCopy()
+	relabelled.Labels["foo"] = "baz"
+
+	terminating := namespace.DeepCopy()
+	terminating.Status.Phase = corev1.NamespaceTerminating
+
+	annotated := namespace.DeepCopy()
+	annotated.Annotations = map[string]string{"foo": "bar"}
+
+	optedOut := namespace.DeepCopy()
+	optedOut.Annotations = map[string]string{trustapi.NamespaceExcludeAnnotationKey: "true"}
+
+	stillTerminating := terminating.DeepCopy()
+	stillTerminating.Status.Conditions = []corev1.NamespaceCondition{{Type: corev1.NamespaceDeletionContentFailure}}
+
+	p := namespacePredicate()
+	assert.True(t, p.Create(event.CreateEvent{Object: namespace}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: namespace}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: relabelled}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: terminating}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: annotated}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: annotated, ObjectNew: optedOut}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: optedOut, ObjectNew: namespace}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: terminating, ObjectNew: stillTerminating}))
+}
+
+func Test_bundleSelectsAnyNamespace(t *testing.T) {
+	bundleObj := &trustapi.Bundle{
+		Spec: trustapi.BundleSpec{
+			Targets: []trustapi.BundleTarget{
+				{
+					ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"configmap": "true"}},
+				},
+				{
+					Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"secret": "true"}},
+				},
+			},
+		},
+	}
+
+	namespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels}}
+	}
+
+	b := &bundle{}
+	assert.True(t, b.bundleSelectsAnyNamespace(bundleObj, namespace(map[string]string{"configmap": "true"})))
+	assert.True(t, b.bundleSelectsAnyNamespace(bundleObj, namespace(map[string]string{"secret": "true"})))
+	assert.False(t, b.bundleSelectsAnyNamespace(bundleObj, namespace(nil)))
+
+	// A Namespace which stopped matching is still selected through its old labels.
+	assert.True(t, b.bundleSelectsAnyNamespace(bundleObj, namespace(map[string]string{"secret": "true"}), namespace(nil)))
+}
+
+func Test_namespaceExcluded(t *testing.T) {
+	selector, err := labels.Parse("trust.cert-manager.io/excluded=true")
+	assert.NoError(t, err)
+
+	b := &bundle{Options: Options{TunableOptions: TunableOptions{
+		ExcludeNamespaces:        []string{"kube-system", "vendor-*"},
+		ExcludeNamespaceSelector: selector,
+	}}}
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	assert.True(t, b.namespaceExcluded(namespace("kube-system", nil)))
+	assert.True(t, b.namespaceExcluded(namespace("vendor-foo", nil)))
+	assert.True(t, b.namespaceExcluded(namespace("team-a", map[string]string{"trust.cert-manager.io/excluded": "true"})))
+	assert.False(t, b.namespaceExcluded(namespace("team-a", nil)))
+	assert.False(t, b.namespaceExcluded(namespace("kube-public", map[string]string{"trust.cert-manager.io/excluded": "false"})))
+
+	assert.False(t, (&bundle{}).namespaceExcluded(namespace("kube-system", nil)))
+}
+
+func Test_namespaceWatched(t *testing.T) {
+	assert.True(t, (&bundle{}).namespaceWatched("team-a"))
+
+	b := &bundle{Options: Options{WatchNamespaces: []string{"team-a", "team-b"}}}
+	assert.True(t, b.namespaceWatched("team-a"))
+	assert.True(t, b.namespaceWatched("team-b"))
+	assert.False(t, b.namespaceWatched("team-c"))
+}
+
+func Test_indexNamespaceLabels(t *testing.T) {
+	target := func(selector *metav1.LabelSelector) trustapi.BundleTarget {
+		return trustapi.BundleTarget{
+			ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+			NamespaceSelector: selector,
+		}
+	}
+	index := func(targets ...trustapi.BundleTarget) []string {
+		return indexNamespaceLabels(&trustapi.Bundle{Spec: trustapi.BundleSpec{Targets: targets}})
+	}
+
+	assert.Equal(t, []string{anyNamespaceIndexValue}, index(target(nil)))
+	assert.Equal(t, []string{anyNamespaceIndexValue}, index(target(&metav1.LabelSelector{})))
+	assert.Equal(t, []string{"team"}, index(target(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}})))
+	assert.Equal(t, []string{"team"}, index(target(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "team", Operator: metav1.LabelSelectorOpExists},
+	}})))
+	assert.Equal(t, []string{anyNamespaceIndexValue, "team"}, index(target(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "team", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}},
+	}})))
+
+	withDependencies := target(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}})
+	withDependencies.KeyOverrides = []trustapi.KeyOverride{{
+		NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"legacy": "true"}},
+		Key:               "ca.crt",
+	}}
+	withDependencies.RolloutStrategy = &trustapi.RolloutStrategy{
+		Type:        trustapi.RolloutStrategyProgressive,
+		Progressive: &trustapi.ProgressiveRollout{NamespacesPerMinute: 1, OrderLabel: "wave"},
+		Canary:      &trustapi.CanaryRollout{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}},
+	}
+	assert.Equal(t, []string{"canary", "legacy", "team", "wave"}, index(withDependencies))
+}
+
+func Test_changedLabelKeys(t *testing.T) {
+	assert.Equal(t, sets.New[string](), changedLabelKeys(map[string]string{"a": "1"}, map[string]string{"a": "1"}))
+	assert.Equal(t, sets.New("a", "b", "c"), changedLabelKeys(
+		map[string]string{"a": "1", "b": "1", "d": "1"},
+		map[string]string{"a": "2", "c": "1", "d": "1"},
+	))
+}
+
+func Test_namespaceEventHandler(t *testing.T) {
+	bundleObj := func(name string, selector *metav1.LabelSelector) *trustapi.Bundle {
+		return &trustapi.Bundle{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: trustapi.BundleSpec{
+				Target: trustapi.BundleTarget{
+					ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					NamespaceSelector: selector,
+				},
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(
+			bundleObj("everywhere", nil),
+			bundleObj("team-a", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}),
+			bundleObj("team-b", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}),
+			bundleObj("tier", &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}}),
+		).
+		WithIndex(&trustapi.Bundle{}, namespaceLabelsIndex, indexNamespaceLabels).
+		Build()
+
+	excluded, err := labels.Parse("excluded=true")
+	assert.NoError(t, err)
+	b := &bundle{client: fakeClient, Options: Options{TunableOptions: TunableOptions{ExcludeNamespaceSelector: excluded}}}
+
+	namespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		}
+	}
+
+	enqueued := func(fn func(context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request])) []string {
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+
+		fn(context.TODO(), q)
+
+		var names []string
+		for q.Len() > 0 {
+			req, _ := q.Get()
+			names = append(names, req.Name)
+		}
+		return sets.List(sets.New(names...))
+	}
+
+	h := b.namespaceEventHandler()
+	update := func(oldNamespace, newNamespace *corev1.Namespace) []string {
+		return enqueued(func(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			h.Update(ctx, event.UpdateEvent{ObjectOld: oldNamespace, ObjectNew: newNamespace}, q)
+		})
+	}
+
+	created := enqueued(func(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		h.Create(ctx, event.CreateEvent{Object: namespace(map[string]string{"team": "a"})}, q)
+	})
+	assert.Equal(t, []string{"everywhere", "team-a"}, created)
+
+	// Only the Bundles depending on the changed label are reconciled.
+	assert.Equal(t, []string{"team-a", "team-b"}, update(
+		namespace(map[string]string{"team": "a", "tier": "prod"}),
+		namespace(map[string]string{"team": "b", "tier": "prod"}),
+	))
+	assert.Empty(t, update(
+		namespace(map[string]string{"team": "a"}),
+		namespace(map[string]string{"team": "a", "unrelated": "true"}),
+	))
+
+	// Changes to labels of the exclude selector and phase changes may affect
+	// any Bundle selecting the Namespace.
+	assert.Equal(t, []string{"everywhere", "team-a"}, update(
+		namespace(map[string]string{"team": "a"}),
+		namespace(map[string]string{"team": "a", "excluded": "true"}),
+	))
+	terminating := namespace(map[string]string{"tier": "prod"})
+	terminating.Status.Phase = corev1.NamespaceTerminating
+	assert.Equal(t, []string{"everywhere", "tier"}, update(namespace(map[string]string{"tier": "prod"}), terminating))
+
+	// So does opting out of targets.
+	optedOut := namespace(map[string]string{"team": "b"})
+	optedOut.Annotations = map[string]string{trustapi.NamespaceExcludeAnnotationKey: "true"}
+	assert.Equal(t, []string{"everywhere", "team-b"}, update(namespace(map[string]string{"team": "b"}), optedOut))
+}
+
+func Test_setOptedOutNamespacesStatus(t *testing.T) {
+	bundleObj := &trustapi.Bundle{}
+	statusPatch := &trustapi.BundleStatus{}
+
+	assert.False(t, setOptedOutNamespacesStatus(bundleObj, statusPatch, sets.New[string]()))
+	assert.Nil(t, statusPatch.OptedOutNamespaces)
+
+	var names []string
+	for i := range 12 {
+		names = append(names, fmt.Sprintf("ns-%02d", i))
+	}
+	assert.True(t, setOptedOutNamespacesStatus(bundleObj, statusPatch, sets.New(names...)))
+	assert.Equal(t, &trustapi.OptedOutNamespaces{Count: 12, Names: names[:10]}, statusPatch.OptedOutNamespaces)
+
+	bundleObj.Status.OptedOutNamespaces = statusPatch.OptedOutNamespaces
+	assert.False(t, setOptedOutNamespacesStatus(bundleObj, statusPatch, sets.New(names...)))
+	assert.True(t, setOptedOutNamespacesStatus(bundleObj, statusPatch, sets.New[string]()))
+	assert.Nil(t, statusPatch.OptedOutNamespaces)
+}
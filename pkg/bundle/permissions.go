@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"github.com/cert-manager/trust-manager/pkg/apis/trust"
+	"github.com/cert-manager/trust-manager/pkg/permissions"
+)
+
+// RequiredPermissions returns the RBAC permissions which the Bundle controller
+// needs with the options, matching those granted by the Helm chart.
+//
+// Secret targets may be limited to Secrets with authorized names, so only the
+// permissions to read Secrets are required of them.
+func RequiredPermissions(opts Options) []permissions.Permission {
+	var required []permissions.Permission
+	add := func(namespace, group, resource, subresource string, verbs ...string) {
+		for _, verb := range verbs {
+			required = append(required, permissions.Permission{
+				Namespace:   namespace,
+				Verb:        verb,
+				Group:       group,
+				Resource:    resource,
+				Subresource: subresource,
+			})
+		}
+	}
+
+	add("", trust.GroupName, "bundles", "", "get", "list", "watch", "patch")
+	add("", trust.GroupName, "bundles", "status", "patch")
+	add("", trust.GroupName, "bundles", "finalizers", "update")
+	add("", "", "namespaces", "", "get", "list", "watch")
+	add("", "", "events", "", "create", "patch")
+	add("", "cert-manager.io", "issuers", "", "get", "list", "watch")
+	add("", "cert-manager.io", "clusterissuers", "", "get", "list", "watch")
+
+	// Sources and snapshots are read from and written to the trust Namespace.
+	add(opts.Namespace, "", "configmaps", "", "get", "list", "watch")
+	add(opts.Namespace, "", "secrets", "", "get", "list", "watch", "create", "patch", "delete")
+	if opts.CertificateSourcesEnabled {
+		add(opts.Namespace, "cert-manager.io", "certificates", "", "list", "watch")
+	}
+
+	// The Secrets of ClusterIssuers, and the CA Certificates of SelfSigned
+	// ClusterIssuers, are read from the cluster resource Namespace.
+	if namespace := opts.ClusterResourceNamespace; namespace != "" && namespace != opts.Namespace {
+		add(namespace, "", "secrets", "", "get", "list", "watch")
+		if opts.CertificateSourcesEnabled {
+			add(namespace, "cert-manager.io", "certificates", "", "list", "watch")
+		}
+	}
+
+	// In namespaced mode, targets are only written to the watched Namespaces.
+	targetNamespaces := opts.WatchNamespaces
+	if len(targetNamespaces) == 0 {
+		targetNamespaces = []string{""}
+	}
+	for _, namespace := range targetNamespaces {
+		add(namespace, "", "configmaps", "", "get", "list", "watch", "create", "patch", "delete")
+		// Secrets are read as targets, and as sources selected by the
+		// namespace selector of a source.
+		if opts.SecretTargetsEnabled || opts.SourceNamespaceSelectorsEnabled {
+			add(namespace, "", "secrets", "", "get", "list", "watch")
+		}
+		if opts.RolloutWorkloads {
+			add(namespace, "apps", "deployments", "", "list", "patch")
+			add(namespace, "apps", "statefulsets", "", "list", "patch")
+		}
+		if opts.ConsumerReportInterval > 0 {
+			add(namespace, "", "pods", "", "list")
+		}
+	}
+
+	return required
+}
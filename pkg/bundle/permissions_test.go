@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cert-manager/trust-manager/pkg/permissions"
+)
+
+func Test_RequiredPermissions(t *testing.T) {
+	has := func(required []permissions.Permission, namespace, verb, group, resource string) bool {
+		for _, p := range required {
+			if p.Namespace == namespace && p.Verb == verb && p.Group == group && p.Resource == resource {
+				return true
+			}
+		}
+		return false
+	}
+
+	required := RequiredPermissions(Options{Namespace: "cert-manager"})
+	assert.True(t, has(required, "", "patch", "trust.cert-manager.io", "bundles"))
+	assert.True(t, has(required, "", "patch", "", "configmaps"))
+	assert.True(t, has(required, "cert-manager", "delete", "", "secrets"))
+	assert.False(t, has(required, "", "get", "", "secrets"))
+	assert.False(t, has(required, "", "patch", "apps", "deployments"))
+	assert.False(t, has(required, "", "list", "", "pods"))
+
+	// In namespaced mode, targets are only required in the watched Namespaces.
+	required = RequiredPermissions(Options{
+		Namespace:            "cert-manager",
+		WatchNamespaces:      []string{"team-a", "team-b"},
+		SecretTargetsEnabled: true,
+		RolloutWorkloads:     true,
+
+		ConsumerReportInterval: time.Minute,
+	})
+	assert.False(t, has(required, "", "patch", "", "configmaps"))
+	assert.True(t, has(required, "team-a", "patch", "", "configmaps"))
+	assert.True(t, has(required, "team-b", "list", "", "secrets"))
+	assert.False(t, has(required, "team-b", "patch", "", "secrets"))
+	assert.True(t, has(required, "team-a", "patch", "apps", "statefulsets"))
+	assert.True(t, has(required, "team-b", "list", "", "pods"))
+
+	// Sources selecting Secrets in other Namespaces need to read them.
+	required = RequiredPermissions(Options{
+		Namespace:                       "cert-manager",
+		SourceNamespaceSelectorsEnabled: true,
+	})
+	assert.True(t, has(required, "", "watch", "", "secrets"))
+	assert.False(t, has(required, "", "patch", "", "secrets"))
+	assert.False(t, has(required, "cert-manager", "watch", "cert-manager.io", "certificates"))
+
+	// Certificates sources watch Certificates in the trust Namespace.
+	required = RequiredPermissions(Options{
+		Namespace:                 "cert-manager",
+		CertificateSourcesEnabled: true,
+	})
+	assert.True(t, has(required, "cert-manager", "watch", "cert-manager.io", "certificates"))
+	assert.False(t, has(required, "", "watch", "cert-manager.io", "certificates"))
+
+	// ClusterIssuer sources read from the cluster resource Namespace.
+	required = RequiredPermissions(Options{
+		Namespace:                 "trust",
+		ClusterResourceNamespace:  "cert-manager",
+		CertificateSourcesEnabled: true,
+	})
+	assert.True(t, has(required, "", "watch", "cert-manager.io", "clusterissuers"))
+	assert.True(t, has(required, "cert-manager", "watch", "", "secrets"))
+	assert.False(t, has(required, "cert-manager", "patch", "", "secrets"))
+	assert.True(t, has(required, "cert-manager", "list", "cert-manager.io", "certificates"))
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// TargetAction is what syncing a Bundle would do to one of its targets.
+type TargetAction string
+
+const (
+	TargetActionNone   TargetAction = "None"
+	TargetActionCreate TargetAction = "Create"
+	TargetActionUpdate TargetAction = "Update"
+	TargetActionDelete TargetAction = "Delete"
+)
+
+// TargetChange is the change which syncing a Bundle would make to one of its
+// targets.
+type TargetChange struct {
+	Action TargetAction
+
+	// Kind is the kind of the target, either ConfigMap or Secret.
+	Kind      string
+	Namespace string
+	Name      string
+
+	// AddedKeys, ChangedKeys and RemovedKeys are the keys of the target which
+	// would be added, changed and removed.
+	AddedKeys, ChangedKeys, RemovedKeys []string
+}
+
+// Plan returns the change which syncing the given Bundle would make to each
+// of its targets as they are now, without making any, sorted by Namespace.
+// The Bundle needn't exist; targets are only deleted on behalf of a Bundle
+// with the UID of the one they belong to. Paused Bundles change nothing.
+//
+// The plan covers the data of targets, as the bundle will be once it's fully
+// rolled out: Namespaces whose turn in a progressive rollout hasn't come yet,
+// target metadata, target conflicts, key transitions and remote clusters
+// aren't considered.
+// Additional formats and signatures are reported as changed whenever the PEM
+// bundle is, as they can't always be reproduced.
+func (r *Renderer) Plan(ctx context.Context, bundle *trustapi.Bundle) ([]TargetChange, error) {
+	b := r.bundle
+	if bundleIsPaused(bundle) {
+		return nil, nil
+	}
+
+	var (
+		resolvedBundle bundleData
+		err            error
+	)
+	if rollbackTo := bundleRollbackTo(bundle); rollbackTo != "" {
+		resolvedBundle, err = b.buildSnapshotBundle(ctx, bundle, rollbackTo)
+	} else {
+		resolvedBundle, err = b.buildSourceBundle(ctx, bundle.Spec.Sources, nil, b.requireCABasicConstraints(bundle), b.expiredCertificatePolicy(bundle), deduplicationStrategy(bundle), invalidCertificatePolicy(bundle))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build bundle source: %w", err)
+	}
+
+	if err := b.checkPolicy(resolvedBundle); err != nil {
+		return nil, fmt.Errorf("bundle fails policy: %w", err)
+	}
+	if err := checkPinnedHash(bundle, snapshotHash(resolvedBundle.Data.Data)); err != nil {
+		return nil, err
+	}
+
+	if anyTarget(bundle, func(t trustapi.BundleTarget) bool { return t.Manifest != nil }) {
+		resolvedBundle.Manifest, err = buildManifest(resolvedBundle.pool, resolvedBundle.provenance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build bundle manifest: %w", err)
+		}
+	}
+	if anyTarget(bundle, func(t trustapi.BundleTarget) bool { return len(t.UsageKeys) > 0 }) {
+		resolvedBundle.UsageData = buildUsageData(resolvedBundle.pool, resolvedBundle.provenance, b.PEMTrailingNewline)
+	}
+
+	var targets []*resolvedTarget
+	for _, targetBundle := range targetBundles(bundle) {
+		t := &resolvedTarget{bundle: targetBundle, data: resolvedBundle.Data}
+		if err := t.data.Populate(resolvedBundle.pool, targetBundle.Spec.Target.AdditionalFormats); err != nil {
+			return nil, fmt.Errorf("failed to encode additional formats: %w", err)
+		}
+		if err := target.ValidateSize(t.bundle, t.data); err != nil {
+			return nil, err
+		}
+
+		t.namespaceSelector, err = b.bundleTargetNamespaceSelector(targetBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build NamespaceSelector: %w", err)
+		}
+		if err := t.resolveKeyOverrides(); err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, t)
+	}
+
+	secretTargetName := bundle.Name
+	for _, t := range targets {
+		if secret := t.bundle.Spec.Target.Secret; secret != nil && secret.Immutable {
+			secretTargetName = target.ImmutableSecretName(t.bundle, t.data)
+		}
+	}
+
+	// Find the targets which should exist, as the controller does.
+	desired := map[target.Resource]*resolvedTarget{}
+	for _, t := range targets {
+		var namespaceList corev1.NamespaceList
+		if err := b.client.List(ctx, &namespaceList, &client.ListOptions{LabelSelector: t.namespaceSelector}); err != nil {
+			return nil, fmt.Errorf("failed to list Namespaces: %w", err)
+		}
+
+		for _, namespace := range namespaceList.Items {
+			if namespace.Status.Phase == corev1.NamespaceTerminating || !b.namespaceWatched(namespace.Name) ||
+				b.namespaceExcluded(&namespace) || namespaceOptedOut(&namespace) {
+				continue
+			}
+
+			namespaceTarget := t.forNamespace(&namespace)
+			for _, resource := range namespaceTargetResources(t.bundle.Spec.Target, bundle.Name, secretTargetName, namespace.Name) {
+				desired[resource] = namespaceTarget
+			}
+		}
+	}
+
+	var changes []TargetChange
+	plan := func(resource target.Resource, obj client.Object, t *resolvedTarget) error {
+		syncBundle, syncData := bundle, resolvedBundle.Data
+		if t != nil {
+			syncBundle, syncData = t.bundle, t.data
+		}
+
+		change, err := target.Diff(resource, obj, syncBundle, syncData, t != nil)
+		if err != nil {
+			return err
+		}
+
+		action := TargetActionNone
+		switch {
+		case change.Create:
+			action = TargetActionCreate
+		case change.Delete:
+			action = TargetActionDelete
+		case !change.Empty():
+			action = TargetActionUpdate
+		}
+		changes = append(changes, TargetChange{
+			Action:      action,
+			Kind:        string(resource.Kind),
+			Namespace:   resource.Namespace,
+			Name:        resource.Name,
+			AddedKeys:   change.Added,
+			ChangedKeys: change.Changed,
+			RemovedKeys: change.Removed,
+		})
+		return nil
+	}
+
+	for resource, t := range desired {
+		obj, err := b.getTargetObject(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		if err := plan(resource, obj, t); err != nil {
+			return nil, err
+		}
+	}
+
+	// Existing targets of the Bundle which are no longer desired are
+	// removed, unless they're being deleted, or their Namespace is.
+	for _, kind := range []target.Kind{target.KindConfigMap, target.KindSecret} {
+		if targetFor(bundle, kind) == nil {
+			continue
+		}
+
+		existing, err := b.listTargetObjects(ctx, kind, bundle.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range existing {
+			resource := target.Resource{Kind: kind, NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}}
+			if _, ok := desired[resource]; ok || !b.namespaceWatched(resource.Namespace) ||
+				obj.GetDeletionTimestamp() != nil || !targetControlledBy(obj, bundle, kind) {
+				continue
+			}
+			if kind == target.KindSecret && obj.GetName() == bundle.GetAnnotations()[trustapi.BundleSecretTargetAnnotationKey] {
+				continue
+			}
+
+			namespace, err := b.getNamespace(ctx, resource.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Namespace %q: %w", resource.Namespace, err)
+			}
+			if namespaceIsGone(namespace) || b.namespaceExcluded(namespace) {
+				continue
+			}
+
+			if err := plan(resource, obj, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	slices.SortFunc(changes, func(x, y TargetChange) int {
+		return cmp.Or(cmp.Compare(x.Namespace, y.Namespace), cmp.Compare(x.Kind, y.Kind), cmp.Compare(x.Name, y.Name))
+	})
+	return changes, nil
+}
+
+// getTargetObject returns the ConfigMap or Secret of the target, or nil if it
+// doesn't exist.
+func (b *bundle) getTargetObject(ctx context.Context, resource target.Resource) (client.Object, error) {
+	var obj client.Object = &corev1.ConfigMap{}
+	if resource.Kind == target.KindSecret {
+		obj = &corev1.Secret{}
+	}
+
+	if err := b.client.Get(ctx, resource.NamespacedName, obj); apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", resource.Kind, resource.NamespacedName, err)
+	}
+	return obj, nil
+}
+
+// listTargetObjects returns the ConfigMaps or Secrets labelled as targets of
+// the named Bundle.
+func (b *bundle) listTargetObjects(ctx context.Context, kind target.Kind, bundleName string) ([]client.Object, error) {
+	selector := client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(map[string]string{trustapi.BundleLabelKey: bundleName})}
+
+	var objs []client.Object
+	switch kind {
+	case target.KindConfigMap:
+		var list corev1.ConfigMapList
+		if err := b.client.List(ctx, &list, selector); err != nil {
+			return nil, fmt.Errorf("failed to list ConfigMaps: %w", err)
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case target.KindSecret:
+		var list corev1.SecretList
+		if err := b.client.List(ctx, &list, selector); err != nil {
+			return nil, fmt.Errorf("failed to list Secrets: %w", err)
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	}
+	return objs, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_Plan(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", UID: "bundle-uid"},
+		Spec: trustapi.BundleSpec{
+			Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+			Target: trustapi.BundleTarget{
+				ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "true"}},
+			},
+		},
+	}
+
+	namespace := func(name string, selected bool) *corev1.Namespace {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if selected {
+			ns.Labels = map[string]string{"trust": "true"}
+		}
+		return ns
+	}
+	targetConfigMap := func(namespace, pem string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            "bundle",
+				Labels:          map[string]string{trustapi.BundleLabelKey: "bundle"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "trust.cert-manager.io/v1alpha1", Kind: "Bundle", Name: "bundle", UID: "bundle-uid", Controller: ptr.To(true)}},
+				ManagedFields:   ssa_client.ManagedFieldEntries([]string{"trust.pem"}, nil),
+			},
+			Data: map[string]string{"trust.pem": pem},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme)
+	renderer, err := NewRenderer(fakeClient.Build(), Options{Namespace: "trust"})
+	require.NoError(t, err)
+	pem, err := renderer.Render(context.Background(), bundle)
+	require.NoError(t, err)
+
+	renderer, err = NewRenderer(fakeClient.
+		WithObjects(
+			namespace("new", true),
+			namespace("outdated", true),
+			namespace("up-to-date", true),
+			namespace("deselected", false),
+			targetConfigMap("outdated", dummy.TestCertificate2),
+			targetConfigMap("up-to-date", pem),
+			targetConfigMap("deselected", pem),
+		).
+		Build(), Options{Namespace: "trust"})
+	require.NoError(t, err)
+
+	changes, err := renderer.Plan(context.Background(), bundle)
+	require.NoError(t, err)
+	assert.Equal(t, []TargetChange{
+		{Action: TargetActionDelete, Kind: "ConfigMap", Namespace: "deselected", Name: "bundle", RemovedKeys: []string{"trust.pem"}},
+		{Action: TargetActionCreate, Kind: "ConfigMap", Namespace: "new", Name: "bundle", AddedKeys: []string{"trust.pem"}},
+		{Action: TargetActionUpdate, Kind: "ConfigMap", Namespace: "outdated", Name: "bundle", ChangedKeys: []string{"trust.pem"}},
+		{Action: TargetActionNone, Kind: "ConfigMap", Namespace: "up-to-date", Name: "bundle"},
+	}, changes)
+
+	// Targets of other Bundles, or of a Bundle of the same name which was
+	// deleted, aren't touched.
+	bundle.UID = "other-uid"
+	changes, err = renderer.Plan(context.Background(), bundle)
+	require.NoError(t, err)
+	assert.Len(t, changes, 3)
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import "fmt"
+
+// checkPolicy returns an error if the bundle built from the sources of a
+// Bundle exceeds the maximum size or number of certificates in Options.
+func (b *bundle) checkPolicy(resolvedBundle bundleData) error {
+	if limit := b.Options.MaxBundleSizeBytes; limit > 0 && len(resolvedBundle.Data.Data) > limit {
+		return fmt.Errorf("bundle is %d bytes, which exceeds the maximum of %d bytes", len(resolvedBundle.Data.Data), limit)
+	}
+
+	if limit := b.Options.MaxCertificates; limit > 0 && resolvedBundle.pool != nil && resolvedBundle.pool.Size() > limit {
+		return fmt.Errorf("bundle holds %d certificates, which exceeds the maximum of %d", resolvedBundle.pool.Size(), limit)
+	}
+
+	return nil
+}
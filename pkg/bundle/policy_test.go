@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_checkPolicy(t *testing.T) {
+	pool := util.NewCertPool()
+	require.NoError(t, pool.AddCertsFromPEM([]byte(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2))))
+	resolvedBundle := bundleData{Data: target.Data{Data: pool.PEM()}, pool: pool}
+	size := len(resolvedBundle.Data.Data)
+
+	tests := map[string]struct {
+		opts   Options
+		expErr string
+	}{
+		"no limits": {},
+		"within limits": {
+			opts: Options{MaxBundleSizeBytes: size, MaxCertificates: 2},
+		},
+		"too large": {
+			opts:   Options{MaxBundleSizeBytes: size - 1},
+			expErr: fmt.Sprintf("bundle is %d bytes, which exceeds the maximum of %d bytes", size, size-1),
+		},
+		"too many certificates": {
+			opts:   Options{MaxCertificates: 1},
+			expErr: "bundle holds 2 certificates, which exceeds the maximum of 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &bundle{Options: test.opts}
+			err := b.checkPolicy(resolvedBundle)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// rolloutPlan decides which target Namespaces a bundle is written to, for
+// targets with the Progressive rollout strategy or canary Namespaces. The
+// rollout of a bundle starts when its hash changes. Canary Namespaces are
+// updated first, and the other Namespaces once the bundle is promoted, at
+// once or by the configured number of Namespaces each minute.
+type rolloutPlan struct {
+	bundle     *trustapi.Bundle
+	bundleHash string
+	start, now time.Time
+
+	// status reports the progress of the rollout, or is nil if no target
+	// uses the Progressive rollout strategy or canary Namespaces.
+	status *trustapi.BundleRolloutStatus
+
+	// awaitingPromotion is true if the bundle is held back in the canary
+	// Namespaces of a target.
+	awaitingPromotion bool
+
+	// requeueAfter is the time until more Namespaces are admitted, or zero
+	// if the rollout is complete or waits for approval.
+	requeueAfter time.Duration
+}
+
+func (b *bundle) newRolloutPlan(bundle *trustapi.Bundle, bundleHash string) *rolloutPlan {
+	now := b.clock.Now()
+	start := now
+	if rollout := bundle.Status.Rollout; rollout != nil && rollout.BundleHash == bundleHash {
+		start = rollout.StartTime.Time
+	}
+	return &rolloutPlan{bundle: bundle, bundleHash: bundleHash, start: start, now: now}
+}
+
+// deferred returns the Namespaces, of those a target with the given rollout
+// strategy is written to, whose turn in the rollout hasn't come yet.
+func (p *rolloutPlan) deferred(strategy *trustapi.RolloutStrategy, namespaces []corev1.Namespace) (sets.Set[string], error) {
+	if strategy == nil {
+		return nil, nil
+	}
+	progressive := strategy.Progressive
+	if strategy.Type != trustapi.RolloutStrategyProgressive {
+		progressive = nil
+	}
+	if progressive == nil && strategy.Canary == nil {
+		return nil, nil
+	}
+
+	if p.status == nil {
+		p.status = &trustapi.BundleRolloutStatus{
+			BundleHash: p.bundleHash,
+			StartTime:  metav1.NewTime(p.start),
+		}
+	}
+	p.status.TotalNamespaces += int32(len(namespaces)) // #nosec G115 -- bounded by the number of Namespaces
+
+	// The other Namespaces are rolled out to from the time the bundle was
+	// promoted from the canary Namespaces.
+	rolloutStart := p.start
+	if canary := strategy.Canary; canary != nil {
+		canaries, others, err := splitCanaryNamespaces(canary, namespaces)
+		if err != nil {
+			return nil, err
+		}
+		p.status.UpdatedNamespaces += int32(len(canaries)) // #nosec G115 -- bounded by the number of Namespaces
+
+		promotionTime, promoted := p.promotion(canary)
+		if !promoted {
+			p.awaitingPromotion = true
+			deferred := sets.New[string]()
+			for _, namespace := range others {
+				deferred.Insert(namespace.Name)
+			}
+			return deferred, nil
+		}
+		rolloutStart, namespaces = promotionTime, others
+	}
+
+	total := int32(len(namespaces)) // #nosec G115 -- bounded by the number of Namespaces
+	if progressive == nil {
+		p.status.UpdatedNamespaces += total
+		return nil, nil
+	}
+
+	elapsedMinutes := int64(p.now.Sub(rolloutStart) / time.Minute)
+	admitted := int64(progressive.NamespacesPerMinute) * (elapsedMinutes + 1)
+	if admitted >= int64(total) {
+		p.status.UpdatedNamespaces += total
+		return nil, nil
+	}
+	p.status.UpdatedNamespaces += int32(admitted) // #nosec G115 -- less than the number of Namespaces
+
+	p.requeueBefore(rolloutStart.Add(time.Duration(elapsedMinutes+1) * time.Minute))
+
+	ordered := slices.Clone(namespaces)
+	slices.SortFunc(ordered, func(a, b corev1.Namespace) int {
+		return compareRolloutOrder(progressive.OrderLabel, &a, &b)
+	})
+
+	deferred := sets.New[string]()
+	for _, namespace := range ordered[admitted:] {
+		deferred.Insert(namespace.Name)
+	}
+	return deferred, nil
+}
+
+// promotion returns the time at which the bundle was promoted from the canary
+// Namespaces, and false if it hasn't been yet. The bundle is promoted once it
+// has soaked for the soak duration, or when the Bundle is annotated with its
+// hash.
+func (p *rolloutPlan) promotion(canary *trustapi.CanaryRollout) (time.Time, bool) {
+	if rollout := p.bundle.Status.Rollout; rollout != nil && rollout.BundleHash == p.bundleHash && rollout.PromotionTime != nil {
+		p.status.PromotionTime = rollout.PromotionTime
+		return rollout.PromotionTime.Time, true
+	}
+
+	promotionTime, promoted := time.Time{}, false
+	if soak := canary.SoakDuration; soak != nil {
+		promotionTime = p.start.Add(soak.Duration)
+		if p.now.Before(promotionTime) {
+			p.requeueBefore(promotionTime)
+		} else {
+			promoted = true
+		}
+	}
+	if !promoted && p.bundle.GetAnnotations()[trustapi.BundleApproveRolloutAnnotationKey] == p.bundleHash {
+		promotionTime, promoted = p.now, true
+	}
+
+	if promoted && (p.status.PromotionTime == nil || promotionTime.Before(p.status.PromotionTime.Time)) {
+		p.status.PromotionTime = &metav1.Time{Time: promotionTime}
+	}
+	return promotionTime, promoted
+}
+
+// rolloutStatus returns the status of the rollout, or nil if no target uses
+// the Progressive rollout strategy or canary Namespaces.
+func (p *rolloutPlan) rolloutStatus() *trustapi.BundleRolloutStatus {
+	if p.status == nil {
+		return nil
+	}
+
+	switch {
+	case p.awaitingPromotion:
+		p.status.Phase = trustapi.BundleRolloutPhaseCanary
+	case p.status.UpdatedNamespaces < p.status.TotalNamespaces:
+		p.status.Phase = trustapi.BundleRolloutPhaseProgressing
+	default:
+		p.status.Phase = trustapi.BundleRolloutPhaseComplete
+	}
+	return p.status
+}
+
+// requeueBefore makes sure the Bundle is synced again at the given time.
+func (p *rolloutPlan) requeueBefore(t time.Time) {
+	if next := t.Sub(p.now); p.requeueAfter == 0 || next < p.requeueAfter {
+		p.requeueAfter = next
+	}
+}
+
+// splitCanaryNamespaces splits the Namespaces into the canary Namespaces and
+// the others.
+func splitCanaryNamespaces(canary *trustapi.CanaryRollout, namespaces []corev1.Namespace) ([]corev1.Namespace, []corev1.Namespace, error) {
+	selector := labels.Nothing()
+	if canary.NamespaceSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(canary.NamespaceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse canary namespace selector: %w", err)
+		}
+	}
+
+	var canaries, others []corev1.Namespace
+	for _, namespace := range namespaces {
+		if slices.Contains(canary.Namespaces, namespace.Name) || selector.Matches(labels.Set(namespace.Labels)) {
+			canaries = append(canaries, namespace)
+		} else {
+			others = append(others, namespace)
+		}
+	}
+	return canaries, others, nil
+}
+
+// compareRolloutOrder orders Namespaces by the value of the order label, with
+// Namespaces without it last, and then by name.
+func compareRolloutOrder(orderLabel string, a, b *corev1.Namespace) int {
+	if orderLabel != "" {
+		aValue, aOK := a.Labels[orderLabel]
+		bValue, bOK := b.Labels[orderLabel]
+		switch {
+		case aOK && !bOK:
+			return -1
+		case !aOK && bOK:
+			return 1
+		case aValue != bValue:
+			return cmp.Compare(aValue, bValue)
+		}
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
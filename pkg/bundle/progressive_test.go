@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func Test_rolloutPlan(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	namespace := func(name, wave string) corev1.Namespace {
+		ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if wave != "" {
+			ns.Labels = map[string]string{"wave": wave}
+		}
+		return ns
+	}
+	namespaces := []corev1.Namespace{
+		namespace("a", ""),
+		namespace("b", "2"),
+		namespace("c", "1"),
+		namespace("d", "1"),
+		namespace("e", ""),
+	}
+	progressive := &trustapi.RolloutStrategy{
+		Type:        trustapi.RolloutStrategyProgressive,
+		Progressive: &trustapi.ProgressiveRollout{NamespacesPerMinute: 2, OrderLabel: "wave"},
+	}
+	rollingOut := func(start time.Time) *trustapi.Bundle {
+		return &trustapi.Bundle{Status: trustapi.BundleStatus{Rollout: &trustapi.BundleRolloutStatus{
+			BundleHash: "hash",
+			StartTime:  metav1.NewTime(start),
+		}}}
+	}
+	soak := &trustapi.RolloutStrategy{
+		Type:   trustapi.RolloutStrategyImmediate,
+		Canary: &trustapi.CanaryRollout{Namespaces: []string{"a"}, SoakDuration: &metav1.Duration{Duration: 10 * time.Minute}},
+	}
+	approval := &trustapi.RolloutStrategy{
+		Type:        trustapi.RolloutStrategyProgressive,
+		Progressive: &trustapi.ProgressiveRollout{NamespacesPerMinute: 2, OrderLabel: "wave"},
+		Canary: &trustapi.CanaryRollout{NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"wave": "2"},
+		}},
+	}
+	approved := rollingOut(start)
+	approved.Annotations = map[string]string{trustapi.BundleApproveRolloutAnnotationKey: "hash"}
+	promotion := metav1.NewTime(start.Add(5 * time.Minute))
+	promoted := rollingOut(start)
+	promoted.Status.Rollout.PromotionTime = &promotion
+
+	tests := map[string]struct {
+		bundle   *trustapi.Bundle
+		strategy *trustapi.RolloutStrategy
+		now      time.Time
+
+		expDeferred     sets.Set[string]
+		expStatus       *trustapi.BundleRolloutStatus
+		expRequeueAfter time.Duration
+	}{
+		"targets without a rollout strategy are updated immediately": {
+			bundle: &trustapi.Bundle{},
+			now:    start,
+		},
+		"targets with the Immediate rollout strategy are updated immediately": {
+			bundle:   &trustapi.Bundle{},
+			strategy: &trustapi.RolloutStrategy{Type: trustapi.RolloutStrategyImmediate},
+			now:      start,
+		},
+		"a new bundle starts its rollout in the first Namespaces by order label": {
+			bundle:          &trustapi.Bundle{},
+			strategy:        progressive,
+			now:             start,
+			expDeferred:     sets.New("a", "b", "e"),
+			expStatus:       &trustapi.BundleRolloutStatus{BundleHash: "hash", StartTime: metav1.NewTime(start), UpdatedNamespaces: 2, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseProgressing},
+			expRequeueAfter: time.Minute,
+		},
+		"more Namespaces are updated each minute": {
+			bundle:          rollingOut(start),
+			strategy:        progressive,
+			now:             start.Add(90 * time.Second),
+			expDeferred:     sets.New("e"),
+			expStatus:       &trustapi.BundleRolloutStatus{BundleHash: "hash", StartTime: metav1.NewTime(start), UpdatedNamespaces: 4, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseProgressing},
+			expRequeueAfter: 30 * time.Second,
+		},
+		"the rollout completes once every Namespace is due": {
+			bundle:    rollingOut(start),
+			strategy:  progressive,
+			now:       start.Add(2 * time.Minute),
+			expStatus: &trustapi.BundleRolloutStatus{BundleHash: "hash", StartTime: metav1.NewTime(start), UpdatedNamespaces: 5, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseComplete},
+		},
+		"canary Namespaces are updated first": {
+			bundle:          &trustapi.Bundle{},
+			strategy:        soak,
+			now:             start.Add(time.Minute),
+			expDeferred:     sets.New("b", "c", "d", "e"),
+			expStatus:       &trustapi.BundleRolloutStatus{BundleHash: "hash", StartTime: metav1.NewTime(start.Add(time.Minute)), UpdatedNamespaces: 1, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseCanary},
+			expRequeueAfter: 10 * time.Minute,
+		},
+		"the bundle is held in canary Namespaces during the soak time": {
+			bundle:          rollingOut(start),
+			strategy:        soak,
+			now:             start.Add(9 * time.Minute),
+			expDeferred:     sets.New("b", "c", "d", "e"),
+			expStatus:       &trustapi.BundleRolloutStatus{BundleHash: "hash", StartTime: metav1.NewTime(start), UpdatedNamespaces: 1, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseCanary},
+			expRequeueAfter: time.Minute,
+		},
+		"the bundle is promoted after the soak time": {
+			bundle:   rollingOut(start),
+			strategy: soak,
+			now:      start.Add(10 * time.Minute),
+			expStatus: &trustapi.BundleRolloutStatus{
+				BundleHash: "hash", StartTime: metav1.NewTime(start), PromotionTime: &metav1.Time{Time: start.Add(10 * time.Minute)},
+				UpdatedNamespaces: 5, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseComplete,
+			},
+		},
+		"the bundle waits for approval without a soak time": {
+			bundle:      rollingOut(start),
+			strategy:    approval,
+			now:         start.Add(time.Hour),
+			expDeferred: sets.New("a", "c", "d", "e"),
+			expStatus:   &trustapi.BundleRolloutStatus{BundleHash: "hash", StartTime: metav1.NewTime(start), UpdatedNamespaces: 1, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseCanary},
+		},
+		"an approved bundle is rolled out progressively from its approval": {
+			bundle:      approved,
+			strategy:    approval,
+			now:         start.Add(time.Hour),
+			expDeferred: sets.New("a", "e"),
+			expStatus: &trustapi.BundleRolloutStatus{
+				BundleHash: "hash", StartTime: metav1.NewTime(start), PromotionTime: &metav1.Time{Time: start.Add(time.Hour)},
+				UpdatedNamespaces: 3, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseProgressing,
+			},
+			expRequeueAfter: time.Minute,
+		},
+		"a promoted bundle keeps its promotion time": {
+			bundle:   promoted,
+			strategy: approval,
+			now:      start.Add(7 * time.Minute),
+			expStatus: &trustapi.BundleRolloutStatus{
+				BundleHash: "hash", StartTime: metav1.NewTime(start), PromotionTime: &promotion,
+				UpdatedNamespaces: 5, TotalNamespaces: 5, Phase: trustapi.BundleRolloutPhaseComplete,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &bundle{clock: fakeclock.NewFakeClock(test.now)}
+
+			plan := b.newRolloutPlan(test.bundle, "hash")
+			deferred, err := plan.deferred(test.strategy, namespaces)
+			assert.NoError(t, err)
+
+			if test.expDeferred == nil {
+				assert.Empty(t, deferred)
+			} else {
+				assert.Equal(t, test.expDeferred, deferred)
+			}
+			assert.Equal(t, test.expStatus, plan.rolloutStatus())
+			assert.Equal(t, test.expRequeueAfter, plan.requeueAfter)
+		})
+	}
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/audit"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// remoteCluster is a cluster registered by a Secret in the trust Namespace,
+// which Bundles are propagated to.
+type remoteCluster struct {
+	name            string
+	resourceVersion string
+
+	client           client.Client
+	targetReconciler *target.Reconciler
+}
+
+// remoteClusters holds the clients of the registered remote clusters, which
+// are built again when the Secrets registering them change. A nil
+// remoteClusters has no clusters.
+type remoteClusters struct {
+	mu sync.Mutex
+
+	// newClient builds a client from the kubeconfig of a remote cluster.
+	newClient func(kubeconfig []byte) (client.Client, error)
+
+	// clusters maps the name of the Secret registering each remote cluster
+	// to the cluster.
+	clusters map[string]*remoteCluster
+
+	// audit, if set, records the changes made to remote targets.
+	audit *audit.Log
+}
+
+func newRemoteClusters() *remoteClusters {
+	return &remoteClusters{
+		newClient: newRemoteClient,
+		clusters:  make(map[string]*remoteCluster),
+	}
+}
+
+// newRemoteClient builds an uncached client from the kubeconfig of a remote
+// cluster. Remote targets are read directly, as they aren't watched.
+func newRemoteClient(kubeconfig []byte) (client.Client, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: trustapi.GlobalScheme})
+}
+
+// get returns the remote cluster registered by the Secret, building its client
+// if the Secret changed since it was last built.
+func (c *remoteClusters) get(secret *corev1.Secret) (*remoteCluster, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cluster, ok := c.clusters[secret.Name]; ok && cluster.resourceVersion == secret.ResourceVersion {
+		return cluster, nil
+	}
+
+	kubeconfig, ok := secret.Data[trustapi.RemoteClusterKubeconfigKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no %q key", secret.Name, trustapi.RemoteClusterKubeconfigKey)
+	}
+
+	cl, err := c.newClient(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client from the kubeconfig of Secret %q: %w", secret.Name, err)
+	}
+
+	cluster := &remoteCluster{
+		name:            secret.Name,
+		resourceVersion: secret.ResourceVersion,
+		client:          cl,
+		targetReconciler: &target.Reconciler{
+			Client:    cl,
+			Cache:     cl,
+			APIReader: cl,
+			Unowned:   true,
+			Audit:     c.audit.WithCluster(secret.Name),
+		},
+	}
+	c.clusters[secret.Name] = cluster
+	return cluster, nil
+}
+
+// retain drops the remote clusters which are no longer registered by one of
+// the given Secrets.
+func (c *remoteClusters) retain(secrets []corev1.Secret) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.clusters {
+		if !slices.ContainsFunc(secrets, func(secret corev1.Secret) bool { return secret.Name == name }) {
+			delete(c.clusters, name)
+		}
+	}
+}
+
+// listRemoteClusters returns the Secrets in the trust Namespace which register
+// remote clusters, sorted by name.
+func (b *bundle) listRemoteClusters(ctx context.Context) ([]corev1.Secret, error) {
+	var secretList corev1.SecretList
+	if err := b.client.List(ctx, &secretList,
+		client.InNamespace(b.Options.Namespace),
+		client.MatchingLabels{trustapi.RemoteClusterLabelKey: "true"},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list remote cluster Secrets: %w", err)
+	}
+
+	secrets := secretList.Items
+	slices.SortFunc(secrets, func(a, b corev1.Secret) int { return cmp.Compare(a.Name, b.Name) })
+	b.remoteClusters.retain(secrets)
+	return secrets, nil
+}
+
+// propagateToRemoteClusters syncs the targets of the Bundle to each registered
+// remote cluster, and returns the status of the Bundle in each of them.
+// Failing to sync to a remote cluster is reported in its status, and doesn't
+// fail the sync of the Bundle. Progressive rollouts only apply to the local
+// cluster; remote clusters receive the bundle once it's synced locally.
+func (b *bundle) propagateToRemoteClusters(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle, targets []*resolvedTarget, secretTargetName string, data target.Data) ([]trustapi.RemoteClusterStatus, error) {
+	secrets, err := b.listRemoteClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The finalizer is only added once there's a remote cluster to propagate
+	// to, so that Bundles which were never propagated aren't held up by it.
+	if len(secrets) > 0 {
+		if err := b.addRemoteTargetsFinalizer(ctx, bundle); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []trustapi.RemoteClusterStatus
+	for _, secret := range secrets {
+		clusterLog := log.WithValues("remoteCluster", secret.Name)
+
+		status := trustapi.RemoteClusterStatus{Name: secret.Name}
+		if previous := remoteClusterStatus(bundle, secret.Name); previous != nil {
+			status.LastSyncTime = previous.LastSyncTime
+		}
+
+		cluster, err := b.remoteClusters.get(&secret)
+		if err == nil {
+			var changed bool
+			status.TargetCount, changed, err = b.syncRemoteCluster(ctx, clusterLog, cluster, bundle, targets, secretTargetName, data)
+			if err == nil && (changed || status.LastSyncTime == nil) {
+				status.LastSyncTime = &metav1.Time{Time: b.clock.Now()}
+			}
+		}
+
+		if err != nil {
+			clusterLog.Error(err, "failed to sync bundle to remote cluster")
+			status.Message = err.Error()
+			if previous := remoteClusterStatus(bundle, secret.Name); previous == nil || previous.Message != status.Message {
+				b.recorder.Eventf(bundle, corev1.EventTypeWarning, "RemoteClusterSyncFailed", "Failed to sync bundle to remote cluster %s: %s", secret.Name, err)
+			}
+		}
+		status.Synced = err == nil
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// remoteClusterStatus returns the status of the Bundle in the named remote
+// cluster, or nil if it has none.
+func remoteClusterStatus(bundle *trustapi.Bundle, name string) *trustapi.RemoteClusterStatus {
+	for i, status := range bundle.Status.RemoteClusters {
+		if status.Name == name {
+			return &bundle.Status.RemoteClusters[i]
+		}
+	}
+	return nil
+}
+
+// syncRemoteCluster syncs the targets of the Bundle to the Namespaces of the
+// remote cluster which they select, and removes the remote targets which are
+// no longer desired. Returns the number of targets, and true if any target
+// was changed.
+func (b *bundle) syncRemoteCluster(ctx context.Context, log logr.Logger, cluster *remoteCluster, bundle *trustapi.Bundle, targets []*resolvedTarget, secretTargetName string, data target.Data) (int32, bool, error) {
+	targetResources := map[target.Resource]bool{}
+	resolvedTargets := map[target.Resource]*resolvedTarget{}
+	for _, t := range targets {
+		var namespaceList corev1.NamespaceList
+		if err := cluster.client.List(ctx, &namespaceList, &client.ListOptions{
+			LabelSelector: t.namespaceSelector,
+		}); err != nil {
+			return 0, false, fmt.Errorf("failed to list Namespaces: %w", err)
+		}
+
+		for _, namespace := range namespaceList.Items {
+			if namespace.Status.Phase == corev1.NamespaceTerminating || b.namespaceExcluded(&namespace) || namespaceOptedOut(&namespace) {
+				continue
+			}
+
+			namespaceTarget := t.forNamespace(&namespace)
+			for _, resource := range namespaceTargetResources(t.bundle.Spec.Target, bundle.Name, secretTargetName, namespace.Name) {
+				targetResources[resource] = true
+				resolvedTargets[resource] = namespaceTarget
+			}
+		}
+	}
+
+	stale, err := listRemoteTargets(ctx, cluster, bundle, b.Options.SecretTargetsEnabled)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, resource := range stale {
+		if _, ok := targetResources[resource]; ok {
+			continue
+		}
+		// Keep the immutable Secret which the Bundle still points consumers to.
+		if resource.Kind == target.KindSecret && resource.Name == bundle.GetAnnotations()[trustapi.BundleSecretTargetAnnotationKey] {
+			continue
+		}
+		targetResources[resource] = false
+	}
+
+	var (
+		mu          sync.Mutex
+		targetCount int32
+		changed     bool
+		failed      int
+		firstErr    error
+	)
+	syncTargets(b.tunables().TargetSyncConcurrency, targetResources, func(t target.Resource, shouldExist bool) {
+		syncBundle, syncData := bundle, data
+		if resolved, ok := resolvedTargets[t]; ok {
+			syncBundle, syncData = resolved.bundle, resolved.data
+		}
+
+		synced, err := cluster.targetReconciler.Sync(ctx, t, syncBundle, syncData, log.WithValues("target", t), shouldExist)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if shouldExist {
+			targetCount++
+		}
+		if synced {
+			changed = true
+		}
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	})
+
+	if firstErr != nil {
+		return targetCount, changed, fmt.Errorf("failed to sync %d of %d targets: %w", failed, len(targetResources), firstErr)
+	}
+	return targetCount, changed, nil
+}
+
+// listRemoteTargets returns the targets of the Bundle in the remote cluster,
+// other than those being deleted.
+func listRemoteTargets(ctx context.Context, cluster *remoteCluster, bundle *trustapi.Bundle, secretTargetsEnabled bool) ([]target.Resource, error) {
+	targetKinds := []target.Kind{target.KindConfigMap}
+	if secretTargetsEnabled {
+		targetKinds = append(targetKinds, target.KindSecret)
+	}
+
+	var resources []target.Resource
+	for _, kind := range targetKinds {
+		targetList := &metav1.PartialObjectMetadataList{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       string(kind),
+			},
+		}
+		if err := cluster.client.List(ctx, targetList, client.MatchingLabels{trustapi.BundleLabelKey: bundle.Name}); err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", kind, err)
+		}
+
+		for _, t := range targetList.Items {
+			if t.GetDeletionTimestamp() != nil {
+				continue
+			}
+			resources = append(resources, target.Resource{
+				Kind:           kind,
+				NamespacedName: types.NamespacedName{Name: t.Name, Namespace: t.Namespace},
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// addRemoteTargetsFinalizer adds the remote targets finalizer to the Bundle,
+// before it's propagated to remote clusters.
+func (b *bundle) addRemoteTargetsFinalizer(ctx context.Context, bundle *trustapi.Bundle) error {
+	if controllerutil.ContainsFinalizer(bundle, trustapi.BundleRemoteTargetsFinalizer) {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.AddFinalizer(bundle, trustapi.BundleRemoteTargetsFinalizer)
+	if err := b.client.Patch(ctx, bundle, patch); err != nil {
+		return fmt.Errorf("failed to add bundle finalizer: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileRemoteTargetsFinalizer removes the remote targets finalizer from
+// the Bundle if it's no longer propagated to remote clusters. The finalizer is
+// added by propagateToRemoteClusters. If the Bundle is being deleted, its
+// remote targets are removed, unless their deletion policy retains them,
+// before the finalizer is removed.
+func (b *bundle) reconcileRemoteTargetsFinalizer(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle) error {
+	propagated := b.remoteClusters != nil
+	hasFinalizer := controllerutil.ContainsFinalizer(bundle, trustapi.BundleRemoteTargetsFinalizer)
+
+	if bundle.GetDeletionTimestamp() == nil {
+		if propagated || !hasFinalizer {
+			return nil
+		}
+
+		patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.RemoveFinalizer(bundle, trustapi.BundleRemoteTargetsFinalizer)
+		if err := b.client.Patch(ctx, bundle, patch); err != nil {
+			return fmt.Errorf("failed to update bundle finalizers: %w", err)
+		}
+
+		return nil
+	}
+
+	if !hasFinalizer {
+		return nil
+	}
+
+	if propagated {
+		removed, err := b.removeRemoteTargets(ctx, log, bundle)
+		if err != nil {
+			b.recorder.Eventf(bundle, corev1.EventTypeWarning, "RemoveRemoteTargetsFailed", "Failed to remove remote targets: %s", err)
+			return err
+		}
+
+		if removed > 0 {
+			b.recorder.Eventf(bundle, corev1.EventTypeNormal, "RemoteTargetsRemoved", "Removed %d targets from remote clusters", removed)
+		}
+	}
+
+	patch := client.MergeFromWithOptions(bundle.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(bundle, trustapi.BundleRemoteTargetsFinalizer)
+	if err := b.client.Patch(ctx, bundle, patch); err != nil {
+		return fmt.Errorf("failed to remove bundle finalizer: %w", err)
+	}
+
+	return nil
+}
+
+// removeRemoteTargets removes the targets of the Bundle from all registered
+// remote clusters, other than those of kinds with the Retain deletion policy.
+// Returns the number of removed targets. Failing to remove the targets from a
+// remote cluster is reported in an Event, and doesn't fail the removal, so
+// that a single unreachable cluster can't block the deletion of Bundles.
+func (b *bundle) removeRemoteTargets(ctx context.Context, log logr.Logger, bundle *trustapi.Bundle) (int, error) {
+	secrets, err := b.listRemoteClusters(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	for _, secret := range secrets {
+		n, err := b.removeRemoteClusterTargets(ctx, log, &secret, bundle)
+		removed += n
+		if err != nil {
+			log.Error(err, "failed to remove remote targets", "remoteCluster", secret.Name)
+			b.recorder.Eventf(bundle, corev1.EventTypeWarning, "RemoveRemoteTargetsFailed", "Failed to remove targets from remote cluster %s: %s", secret.Name, err)
+		}
+	}
+
+	return removed, nil
+}
+
+// removeRemoteClusterTargets removes the targets of the Bundle from the remote
+// cluster registered by the Secret, and returns the number of removed targets.
+func (b *bundle) removeRemoteClusterTargets(ctx context.Context, log logr.Logger, secret *corev1.Secret, bundle *trustapi.Bundle) (int, error) {
+	cluster, err := b.remoteClusters.get(secret)
+	if err != nil {
+		return 0, err
+	}
+
+	resources, err := listRemoteTargets(ctx, cluster, bundle, b.Options.SecretTargetsEnabled)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	for _, resource := range resources {
+		if deletionPolicy(bundle, resource.Kind) == trustapi.DeletionPolicyRetain {
+			continue
+		}
+
+		if _, err := cluster.targetReconciler.Sync(ctx, resource, bundle, target.Data{}, log, false); err != nil {
+			return removed, err
+		}
+
+		log.V(2).Info("removed remote target", "remoteCluster", cluster.name, "target", resource)
+		removed++
+	}
+
+	return removed, nil
+}
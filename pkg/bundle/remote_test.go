@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	coreapplyconfig "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/test/dummy"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+const (
+	remoteTrustNamespace = "trust-namespace"
+	remoteBundleName     = "test-bundle"
+)
+
+// remoteClusterSecret returns a Secret registering a remote cluster.
+func remoteClusterSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       remoteTrustNamespace,
+			ResourceVersion: "1",
+			Labels:          map[string]string{trustapi.RemoteClusterLabelKey: "true"},
+		},
+		Data: data,
+	}
+}
+
+// fakeRemoteCluster returns a remote cluster backed by a fake client holding
+// the given objects, whose patches are recorded instead of applied.
+func fakeRemoteCluster(t *testing.T, name string, patched *[]string, objs ...client.Object) *remoteCluster {
+	cl := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).WithObjects(objs...).Build()
+
+	var mu sync.Mutex
+	return &remoteCluster{
+		name:            name,
+		resourceVersion: "1",
+		client:          cl,
+		targetReconciler: &target.Reconciler{
+			Client:  cl,
+			Cache:   cl,
+			Unowned: true,
+			PatchResourceOverwrite: func(_ context.Context, obj interface{}) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				patch := obj.(*coreapplyconfig.ConfigMapApplyConfiguration)
+				assert.Empty(t, patch.OwnerReferences, "remote targets must not be owned")
+				*patched = append(*patched, *patch.Namespace+"/"+*patch.Name)
+				return nil
+			},
+		},
+	}
+}
+
+func Test_propagateToRemoteClusters(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testBundle := gen.Bundle(remoteBundleName, func(b *trustapi.Bundle) {
+		b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}
+	})
+	targets := []*resolvedTarget{{
+		bundle:            testBundle,
+		data:              target.Data{Data: dummy.TestCertificate1},
+		namespaceSelector: labels.Everything(),
+	}}
+
+	var patched []string
+	remote := fakeRemoteCluster(t, "cluster-1", &patched,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-2", Annotations: map[string]string{trustapi.NamespaceExcludeAnnotationKey: "true"}}},
+		// A target left behind in a Namespace which is no longer selected.
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      remoteBundleName,
+			Namespace: "ns-3",
+			Labels:    map[string]string{trustapi.BundleLabelKey: remoteBundleName},
+		}},
+	)
+
+	localClient := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).WithObjects(
+		testBundle,
+		remoteClusterSecret("cluster-1", map[string][]byte{trustapi.RemoteClusterKubeconfigKey: []byte("kubeconfig")}),
+		remoteClusterSecret("cluster-2", nil),
+	).Build()
+
+	recorder := record.NewFakeRecorder(10)
+	b := &bundle{
+		client:         localClient,
+		recorder:       recorder,
+		clock:          fakeclock.NewFakeClock(now),
+		Options:        Options{Namespace: remoteTrustNamespace, TunableOptions: TunableOptions{TargetSyncConcurrency: 1}},
+		remoteClusters: newRemoteClusters(),
+	}
+	b.remoteClusters.clusters["cluster-1"] = remote
+	// A cluster which is no longer registered is forgotten.
+	b.remoteClusters.clusters["cluster-3"] = fakeRemoteCluster(t, "cluster-3", &patched)
+
+	log, ctx := ktesting.NewTestContext(t)
+	statuses, err := b.propagateToRemoteClusters(ctx, log, testBundle, targets, testBundle.Name, targets[0].data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []trustapi.RemoteClusterStatus{
+		{Name: "cluster-1", Synced: true, TargetCount: 1, LastSyncTime: &metav1.Time{Time: now}},
+		{Name: "cluster-2", Synced: false, Message: `secret "cluster-2" has no "kubeconfig" key`},
+	}, statuses)
+	assert.ElementsMatch(t, []string{"ns-1/test-bundle", "ns-3/test-bundle"}, patched)
+	assert.Equal(t, []string{`Warning RemoteClusterSyncFailed Failed to sync bundle to remote cluster cluster-2: secret "cluster-2" has no "kubeconfig" key`}, drainEvents(recorder))
+	assert.NotContains(t, b.remoteClusters.clusters, "cluster-3")
+
+	var propagated trustapi.Bundle
+	require.NoError(t, localClient.Get(ctx, client.ObjectKeyFromObject(testBundle), &propagated))
+	assert.True(t, controllerutil.ContainsFinalizer(&propagated, trustapi.BundleRemoteTargetsFinalizer), "expected a propagated Bundle to have the finalizer")
+
+	// A failure which was already reported isn't reported again.
+	testBundle.Status.RemoteClusters = statuses
+	_, err = b.propagateToRemoteClusters(ctx, log, testBundle, targets, testBundle.Name, targets[0].data)
+	require.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+}
+
+func Test_reconcileRemoteTargetsFinalizer(t *testing.T) {
+	log, ctx := ktesting.NewTestContext(t)
+
+	tests := map[string]struct {
+		bundle        *trustapi.Bundle
+		enabled       bool
+		brokenCluster bool
+
+		expFinalizer bool
+		expPatched   []string
+		expEvents    []string
+	}{
+		"if propagation is enabled, don't add the finalizer before the Bundle is propagated": {
+			bundle:  gen.Bundle(remoteBundleName),
+			enabled: true,
+		},
+		"if propagation is enabled, keep the finalizer": {
+			bundle: gen.Bundle(remoteBundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRemoteTargetsFinalizer}
+			}),
+			enabled:      true,
+			expFinalizer: true,
+		},
+		"if propagation is disabled, remove the finalizer": {
+			bundle: gen.Bundle(remoteBundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRemoteTargetsFinalizer}
+			}),
+		},
+		"if Bundle is deleted, remove its remote targets": {
+			bundle: gen.Bundle(remoteBundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRemoteTargetsFinalizer, "other"}
+				b.DeletionTimestamp = &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+			}),
+			enabled:    true,
+			expPatched: []string{"ns-1/test-bundle"},
+			expEvents:  []string{"Normal RemoteTargetsRemoved Removed 1 targets from remote clusters"},
+		},
+		"if Bundle is deleted and a remote cluster is broken, remove the finalizer": {
+			bundle: gen.Bundle(remoteBundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRemoteTargetsFinalizer, "other"}
+				b.DeletionTimestamp = &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+			}),
+			enabled:       true,
+			brokenCluster: true,
+			expPatched:    []string{"ns-1/test-bundle"},
+			expEvents: []string{
+				`Warning RemoveRemoteTargetsFailed Failed to remove targets from remote cluster cluster-2: secret "cluster-2" has no "kubeconfig" key`,
+				"Normal RemoteTargetsRemoved Removed 1 targets from remote clusters",
+			},
+		},
+		"if Bundle is deleted and retains its targets, leave its remote targets": {
+			bundle: gen.Bundle(remoteBundleName, func(b *trustapi.Bundle) {
+				b.Finalizers = []string{trustapi.BundleRemoteTargetsFinalizer, "other"}
+				b.DeletionTimestamp = &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+				b.Spec.Target.DeletionPolicy = trustapi.DeletionPolicyRetain
+			}),
+			enabled: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var patched []string
+			remote := fakeRemoteCluster(t, "cluster-1", &patched, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      remoteBundleName,
+				Namespace: "ns-1",
+				Labels:    map[string]string{trustapi.BundleLabelKey: remoteBundleName},
+			}})
+
+			objects := []client.Object{
+				test.bundle,
+				remoteClusterSecret("cluster-1", map[string][]byte{trustapi.RemoteClusterKubeconfigKey: []byte("kubeconfig")}),
+			}
+			if test.brokenCluster {
+				objects = append(objects, remoteClusterSecret("cluster-2", nil))
+			}
+			localClient := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).WithObjects(objects...).Build()
+
+			recorder := record.NewFakeRecorder(10)
+			b := &bundle{
+				client:   localClient,
+				recorder: recorder,
+				Options:  Options{Namespace: remoteTrustNamespace},
+			}
+			if test.enabled {
+				b.remoteClusters = newRemoteClusters()
+				b.remoteClusters.clusters["cluster-1"] = remote
+			}
+
+			var bundle trustapi.Bundle
+			require.NoError(t, localClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+			require.NoError(t, b.reconcileRemoteTargetsFinalizer(ctx, log, &bundle))
+
+			require.NoError(t, localClient.Get(ctx, client.ObjectKeyFromObject(test.bundle), &bundle))
+			assert.Equal(t, test.expFinalizer, controllerutil.ContainsFinalizer(&bundle, trustapi.BundleRemoteTargetsFinalizer))
+			assert.Equal(t, test.expPatched, patched)
+			assert.Equal(t, test.expEvents, drainEvents(recorder))
+		})
+	}
+}
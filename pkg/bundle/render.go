@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// Format is an encoding of a trust bundle which a Renderer can produce.
+type Format string
+
+const (
+	FormatPEM    Format = "pem"
+	FormatJKS    Format = "jks"
+	FormatPKCS12 Format = "p12"
+)
+
+// Renderer builds the PEM bundle for a Bundle from the current state of its
+// sources, without creating or updating any targets.
+type Renderer struct {
+	bundle *bundle
+}
+
+// NewRenderer returns a Renderer which reads sources using the given client.
+// The client must be able to read ConfigMaps and Secrets in the trust Namespace.
+func NewRenderer(c client.Client, opts Options) (*Renderer, error) {
+	b := &bundle{
+		client:  c,
+		clock:   clock.RealClock{},
+		Options: opts,
+	}
+
+	pkg, err := loadDefaultPackage(opts)
+	if err != nil {
+		return nil, err
+	}
+	b.defaultPackage = pkg
+
+	return &Renderer{bundle: b}, nil
+}
+
+// Render returns the PEM bundle which would be written to the targets of the
+// given Bundle.
+func (r *Renderer) Render(ctx context.Context, bundle *trustapi.Bundle) (string, error) {
+	resolvedBundle, err := r.bundle.buildSourceBundle(ctx, bundle.Spec.Sources, nil, r.bundle.requireCABasicConstraints(bundle), r.bundle.expiredCertificatePolicy(bundle), deduplicationStrategy(bundle), invalidCertificatePolicy(bundle))
+	if err != nil {
+		return "", err
+	}
+
+	return resolvedBundle.Data.Data, nil
+}
+
+// CertPool returns the certificates of the given Bundle's sources, after
+// deduplication and filtering, so that they can be encoded with the encoders
+// of the truststore package.
+func (r *Renderer) CertPool(ctx context.Context, bundle *trustapi.Bundle) (*util.CertPool, error) {
+	resolvedBundle, err := r.bundle.buildSourceBundle(ctx, bundle.Spec.Sources, nil, r.bundle.requireCABasicConstraints(bundle), r.bundle.expiredCertificatePolicy(bundle), deduplicationStrategy(bundle), invalidCertificatePolicy(bundle))
+	if err != nil {
+		return nil, err
+	}
+
+	return resolvedBundle.pool, nil
+}
+
+// RenderFormat returns the given Bundle's trust bundle in the requested format.
+// JKS and PKCS#12 truststores use the passwords configured in the additional
+// formats of the Bundle's targets, or the defaults if no target configures them,
+// and PKCS#12 truststores are encoded deterministically if that target's are.
+func (r *Renderer) RenderFormat(ctx context.Context, bundle *trustapi.Bundle, format Format) ([]byte, error) {
+	// Use the passwords of the first target which configures each format.
+	configured := &trustapi.AdditionalFormats{}
+	for _, t := range bundle.Spec.AllTargets() {
+		for _, targetFormats := range []*trustapi.AdditionalFormats{t.ConfigMapFormats(), t.SecretFormats()} {
+			if targetFormats == nil {
+				continue
+			}
+			if configured.JKS == nil {
+				configured.JKS = targetFormats.JKS
+			}
+			if configured.PKCS12 == nil {
+				configured.PKCS12 = targetFormats.PKCS12
+			}
+		}
+	}
+
+	formats := &trustapi.AdditionalFormats{}
+	switch format {
+	case FormatPEM:
+		formats = nil
+	case FormatJKS:
+		formats.JKS = &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: string(format)}, Password: ptr.To(trustapi.DefaultJKSPassword)}
+		if configured.JKS != nil && configured.JKS.Password != nil {
+			formats.JKS.Password = configured.JKS.Password
+		}
+	case FormatPKCS12:
+		formats.PKCS12 = &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: string(format)}, Password: ptr.To(trustapi.DefaultPKCS12Password)}
+		if configured.PKCS12 != nil && configured.PKCS12.Password != nil {
+			formats.PKCS12.Password = configured.PKCS12.Password
+		}
+		if configured.PKCS12 != nil {
+			formats.PKCS12.Deterministic = configured.PKCS12.Deterministic
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	resolvedBundle, err := r.bundle.buildSourceBundle(ctx, bundle.Spec.Sources, formats, r.bundle.requireCABasicConstraints(bundle), r.bundle.expiredCertificatePolicy(bundle), deduplicationStrategy(bundle), invalidCertificatePolicy(bundle))
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatPEM {
+		return []byte(resolvedBundle.Data.Data), nil
+	}
+	return resolvedBundle.Data.BinaryData[string(format)], nil
+}
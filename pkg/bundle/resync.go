@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// resyncMessage returns the message of the Resynced condition which records
+// that the resync request with the given value was handled.
+func resyncMessage(value string) string {
+	return fmt.Sprintf("Re-applied all targets for resync request %q", value)
+}
+
+// pendingResync returns the value of the resync annotation of the Bundle, and
+// true if the resync it requests hasn't been handled yet.
+func pendingResync(bundle *trustapi.Bundle) (string, bool) {
+	value := bundle.GetAnnotations()[trustapi.BundleResyncAnnotationKey]
+	if value == "" {
+		return "", false
+	}
+
+	for _, cond := range bundle.Status.Conditions {
+		if cond.Type == trustapi.BundleConditionResynced && cond.Message == resyncMessage(value) {
+			return "", false
+		}
+	}
+	return value, true
+}
+
+// retainResyncedCondition adds the Resynced condition of the Bundle to the
+// status patch if it records the current resync request, so that the request
+// isn't handled again. Returns true if the condition needs to be removed.
+func retainResyncedCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus) bool {
+	value := bundle.GetAnnotations()[trustapi.BundleResyncAnnotationKey]
+	for _, cond := range bundle.Status.Conditions {
+		if cond.Type != trustapi.BundleConditionResynced {
+			continue
+		}
+		if value != "" && cond.Message == resyncMessage(value) {
+			statusPatch.Conditions = append(statusPatch.Conditions, cond)
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// setResyncedCondition records in the status patch that all targets of the
+// Bundle were applied again for the resync request with the given value.
+func (b *bundle) setResyncedCondition(bundle *trustapi.Bundle, statusPatch *trustapi.BundleStatus, value string) {
+	message := resyncMessage(value)
+	b.setBundleCondition(bundle.Status.Conditions, &statusPatch.Conditions, trustapi.BundleCondition{
+		Type:               trustapi.BundleConditionResynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Resynced",
+		Message:            message,
+		ObservedGeneration: bundle.Generation,
+	})
+
+	b.recorder.Eventf(bundle, corev1.EventTypeNormal, "Resynced", message)
+}
+
+// checkPinnedHash returns an error if the Bundle is pinned to a hash other
+// than the given hash of its bundle.
+func checkPinnedHash(bundle *trustapi.Bundle, hash string) error {
+	pinned := bundle.GetAnnotations()[trustapi.BundlePinHashAnnotationKey]
+	if pinned == "" || pinned == hash {
+		return nil
+	}
+	return fmt.Errorf("bundle hash %s differs from the pinned hash %s", hash, pinned)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// rolloutWorkloads rolls out the Deployments and StatefulSets in the
+// Namespace of the target which mount it, by setting the rollout checksum
+// annotation on their pod templates to a checksum of the targets they mount.
+func (b *bundle) rolloutWorkloads(ctx context.Context, log logr.Logger, t target.Resource) error {
+	reader := b.apiReader
+	if reader == nil {
+		reader = b.client
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := reader.List(ctx, &deployments, client.InNamespace(t.Namespace)); err != nil {
+		return fmt.Errorf("failed to list Deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if err := b.rolloutWorkload(ctx, log, reader, t, "Deployment", deployment, &deployment.Spec.Template); err != nil {
+			return err
+		}
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := reader.List(ctx, &statefulSets, client.InNamespace(t.Namespace)); err != nil {
+		return fmt.Errorf("failed to list StatefulSets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		statefulSet := &statefulSets.Items[i]
+		if err := b.rolloutWorkload(ctx, log, reader, t, "StatefulSet", statefulSet, &statefulSet.Spec.Template); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rolloutWorkload patches the rollout checksum annotation of the pod template
+// of the workload, if it mounts the target and the checksum changed.
+func (b *bundle) rolloutWorkload(ctx context.Context, log logr.Logger, reader client.Reader, t target.Resource, kind string, workload client.Object, template *corev1.PodTemplateSpec) error {
+	mounted := mountedResources(t.Namespace, &template.Spec)
+	if !mounted.Has(t) {
+		return nil
+	}
+
+	checksum, err := targetsChecksum(ctx, reader, mounted)
+	if err != nil {
+		return err
+	}
+	if template.Annotations[trustapi.BundleRolloutChecksumAnnotationKey] == checksum {
+		return nil
+	}
+
+	patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+	metav1.SetMetaDataAnnotation(&template.ObjectMeta, trustapi.BundleRolloutChecksumAnnotationKey, checksum)
+	if err := b.client.Patch(ctx, workload, patch); err != nil {
+		return fmt.Errorf("failed to roll out %s %s: %w", kind, client.ObjectKeyFromObject(workload), err)
+	}
+
+	log.Info("rolled out workload mounting target", "kind", kind, "name", workload.GetName())
+	return nil
+}
+
+// mountedResources returns the ConfigMaps and Secrets in the Namespace which
+// the volumes of the pod spec mount, directly or as projected sources.
+func mountedResources(namespace string, spec *corev1.PodSpec) sets.Set[target.Resource] {
+	resource := func(kind target.Kind, name string) target.Resource {
+		return target.Resource{Kind: kind, NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	mounted := sets.New[target.Resource]()
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			mounted.Insert(resource(target.KindConfigMap, volume.ConfigMap.Name))
+		}
+		if volume.Secret != nil {
+			mounted.Insert(resource(target.KindSecret, volume.Secret.SecretName))
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil {
+				mounted.Insert(resource(target.KindConfigMap, source.ConfigMap.Name))
+			}
+			if source.Secret != nil {
+				mounted.Insert(resource(target.KindSecret, source.Secret.Name))
+			}
+		}
+	}
+	return mounted
+}
+
+// targetsChecksum returns a checksum of the bundle hashes of the given
+// resources which are targets of Bundles. Other resources are ignored.
+func targetsChecksum(ctx context.Context, reader client.Reader, resources sets.Set[target.Resource]) (string, error) {
+	hash := sha256.New()
+
+	sorted := resources.UnsortedList()
+	slices.SortFunc(sorted, func(a, b target.Resource) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+
+	for _, resource := range sorted {
+		obj := &metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{Kind: string(resource.Kind), APIVersion: "v1"},
+		}
+		err := reader.Get(ctx, resource.NamespacedName, obj)
+		// Resources which trust-manager can't read can't be its targets.
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get %s %s: %w", resource.Kind, resource.NamespacedName, err)
+		}
+
+		bundleHash, ok := obj.GetAnnotations()[trustapi.BundleHashAnnotationKey]
+		if !ok {
+			continue
+		}
+		_, _ = fmt.Fprintf(hash, "%s/%s=%s\n", resource.Kind, resource.Name, bundleHash)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
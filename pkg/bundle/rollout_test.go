@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+func Test_rolloutWorkloads(t *testing.T) {
+	podTemplate := func(volumes ...corev1.Volume) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: volumes}}
+	}
+	configMapVolume := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+		}}
+	}
+
+	targetConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "trust-bundle",
+			Namespace:   "ns-1",
+			Annotations: map[string]string{trustapi.BundleHashAnnotationKey: "hash-1"},
+		},
+	}
+	mounting := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mounting", Namespace: "ns-1"},
+		Spec: appsv1.DeploymentSpec{Template: podTemplate(corev1.Volume{Name: "trust", VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "trust-bundle"}},
+			}}},
+		}})},
+	}
+	notMounting := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-mounting", Namespace: "ns-1"},
+		Spec:       appsv1.DeploymentSpec{Template: podTemplate(configMapVolume("other"))},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "stateful", Namespace: "ns-1"},
+		Spec:       appsv1.StatefulSetSpec{Template: podTemplate(configMapVolume("trust-bundle"), configMapVolume("other"))},
+	}
+	otherNamespace := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mounting", Namespace: "ns-2"},
+		Spec:       appsv1.DeploymentSpec{Template: podTemplate(configMapVolume("trust-bundle"))},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(targetConfigMap, mounting, notMounting, statefulSet, otherNamespace).
+		Build()
+	b := &bundle{client: fakeClient}
+
+	log, ctx := ktesting.NewTestContext(t)
+	resource := target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "trust-bundle"}}
+
+	checksum := func(obj client.Object) string {
+		t.Helper()
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+		switch obj := obj.(type) {
+		case *appsv1.Deployment:
+			return obj.Spec.Template.Annotations[trustapi.BundleRolloutChecksumAnnotationKey]
+		case *appsv1.StatefulSet:
+			return obj.Spec.Template.Annotations[trustapi.BundleRolloutChecksumAnnotationKey]
+		}
+		return ""
+	}
+
+	require.NoError(t, b.rolloutWorkloads(ctx, log, resource))
+	first := checksum(mounting)
+	assert.NotEmpty(t, first)
+	// Other mounted ConfigMaps aren't targets, so don't change the checksum.
+	assert.Equal(t, first, checksum(statefulSet))
+	assert.Empty(t, checksum(notMounting))
+	assert.Empty(t, checksum(otherNamespace))
+
+	// Workloads are left alone while the bundle is unchanged.
+	resourceVersion := mounting.ResourceVersion
+	require.NoError(t, b.rolloutWorkloads(ctx, log, resource))
+	checksum(mounting)
+	assert.Equal(t, resourceVersion, mounting.ResourceVersion)
+
+	targetConfigMap.Annotations[trustapi.BundleHashAnnotationKey] = "hash-2"
+	require.NoError(t, fakeClient.Update(ctx, targetConfigMap))
+	require.NoError(t, b.rolloutWorkloads(ctx, log, resource))
+	assert.NotEqual(t, first, checksum(mounting))
+	assert.NotEqual(t, first, checksum(statefulSet))
+}
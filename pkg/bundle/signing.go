@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// sign sets the detached signature of the resolved bundle, using the Ed25519
+// key held in the signing key Secret in the trust Namespace.
+func (b *bundle) sign(ctx context.Context, resolvedBundle *target.Data) error {
+	if b.Options.SigningKeySecret == "" {
+		return errors.New("no signing key is configured")
+	}
+
+	var secret corev1.Secret
+	if err := b.client.Get(ctx, client.ObjectKey{Namespace: b.Namespace, Name: b.Options.SigningKeySecret}, &secret); err != nil {
+		return fmt.Errorf("failed to get signing key Secret %s/%s: %w", b.Namespace, b.Options.SigningKeySecret, err)
+	}
+
+	key, err := parseSigningKey(secret.Data[b.Options.SigningKeySecretKey])
+	if err != nil {
+		return fmt.Errorf("invalid signing key in Secret %s/%s at key %q: %w", b.Namespace, b.Options.SigningKeySecret, b.Options.SigningKeySecretKey, err)
+	}
+
+	resolvedBundle.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(resolvedBundle.Data)))
+
+	return nil
+}
+
+// parseSigningKey parses a PEM-encoded PKCS#8 Ed25519 private key.
+func parseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519Key, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 private key, got %T", key)
+	}
+
+	return ed25519Key, nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_sign(t *testing.T) {
+	const (
+		trustNamespace = "trust-namespace"
+		secretName     = "signing-key"
+	)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encode := func(key any) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	}
+
+	tests := map[string]struct {
+		secretName string
+		keyData    []byte
+		expErr     string
+	}{
+		"an Ed25519 key signs the bundle": {
+			secretName: secretName,
+			keyData:    encode(privateKey),
+		},
+		"no signing key configured": {
+			expErr: "no signing key is configured",
+		},
+		"a missing signing key Secret": {
+			secretName: "missing",
+			keyData:    encode(privateKey),
+			expErr:     `failed to get signing key Secret trust-namespace/missing: secrets "missing" not found`,
+		},
+		"a key which isn't PEM encoded": {
+			secretName: secretName,
+			keyData:    []byte("not a key"),
+			expErr:     `invalid signing key in Secret trust-namespace/signing-key at key "tls.key": no PEM data found`,
+		},
+		"a key which isn't Ed25519": {
+			secretName: secretName,
+			keyData:    encode(ecKey),
+			expErr:     `invalid signing key in Secret trust-namespace/signing-key at key "tls.key": expected an Ed25519 private key, got *ecdsa.PrivateKey`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: trustNamespace, Name: secretName},
+					Data:       map[string][]byte{"tls.key": test.keyData},
+				}).
+				Build()
+
+			b := &bundle{
+				client: fakeClient,
+				Options: Options{
+					Namespace:           trustNamespace,
+					SigningKeySecret:    test.secretName,
+					SigningKeySecretKey: "tls.key",
+				},
+			}
+
+			resolvedBundle := target.Data{Data: dummy.TestCertificate1}
+			err := b.sign(context.TODO(), &resolvedBundle)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				assert.Empty(t, resolvedBundle.Signature)
+				return
+			}
+			require.NoError(t, err)
+
+			signature, err := base64.StdEncoding.DecodeString(resolvedBundle.Signature)
+			require.NoError(t, err)
+			assert.True(t, ed25519.Verify(publicKey, []byte(dummy.TestCertificate1), signature))
+		})
+	}
+}
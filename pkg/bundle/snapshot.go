@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+	coreapplyconfig "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1applyconfig "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+const (
+	// snapshotBundleKey is the key of the PEM bundle in snapshot Secrets.
+	snapshotBundleKey = "bundle.pem"
+
+	// snapshotPackageVersionKey is the key of the version of the default CA
+	// package included in the bundle, if any, in snapshot Secrets.
+	snapshotPackageVersionKey = "defaultCAVersion"
+)
+
+// snapshotHash returns the hash which identifies the snapshot of a PEM bundle.
+func snapshotHash(bundlePEM string) string {
+	return target.TrustBundleHash([]byte(bundlePEM), nil)
+}
+
+// snapshotSecretName returns the name of the Secret in the trust Namespace
+// which holds the snapshot of the named Bundle with the given hash.
+func snapshotSecretName(bundleName, hash string) string {
+	return fmt.Sprintf("%s-snapshot-%s", bundleName, hash[:16])
+}
+
+// bundleRollbackTo returns the hash of the snapshot the Bundle is rolled back
+// to, set either through the spec or the rollback annotation, or an empty
+// string if it isn't rolled back.
+func bundleRollbackTo(bundle *trustapi.Bundle) string {
+	if bundle.Spec.RollbackTo != "" {
+		return bundle.Spec.RollbackTo
+	}
+	return bundle.GetAnnotations()[trustapi.BundleRollbackToAnnotationKey]
+}
+
+// buildSnapshotBundle builds the bundle from the snapshot of the Bundle with
+// the given hash, in place of its sources. The certificates of the snapshot
+// are filtered as those of the sources would be.
+func (b *bundle) buildSnapshotBundle(ctx context.Context, bundle *trustapi.Bundle, hash string) (bundleData, error) {
+	if len(hash) != 64 {
+		return bundleData{}, notFoundError{fmt.Errorf("snapshot %q is not a bundle hash", hash)}
+	}
+
+	var secret corev1.Secret
+	err := b.client.Get(ctx, client.ObjectKey{Namespace: b.Namespace, Name: snapshotSecretName(bundle.Name, hash)}, &secret)
+	if apierrors.IsNotFound(err) {
+		return bundleData{}, notFoundError{fmt.Errorf("snapshot %s of the bundle was not found", hash)}
+	}
+	if err != nil {
+		return bundleData{}, fmt.Errorf("failed to get snapshot %s of the bundle: %w", hash, err)
+	}
+
+	bundlePEM := string(secret.Data[snapshotBundleKey])
+	if snapshotHash(bundlePEM) != hash {
+		return bundleData{}, notFoundError{fmt.Errorf("snapshot %s of the bundle was not found; Secret %s/%s holds another bundle", hash, secret.Namespace, secret.Name)}
+	}
+
+	resolvedBundle, err := b.buildSourceBundle(ctx, []trustapi.BundleSource{{InLine: &bundlePEM}}, nil, b.requireCABasicConstraints(bundle), b.expiredCertificatePolicy(bundle), deduplicationStrategy(bundle), invalidCertificatePolicy(bundle))
+	if err != nil {
+		return bundleData{}, err
+	}
+	resolvedBundle.PackageVersion = string(secret.Data[snapshotPackageVersionKey])
+	resolvedBundle.provenance = nil
+	resolvedBundle.addProvenance(manifestSource{Kind: "Snapshot", Namespace: secret.Namespace, Name: secret.Name}, secret.Data[snapshotBundleKey])
+	return resolvedBundle, nil
+}
+
+// recordSnapshot keeps a snapshot of the bundle published to the targets of
+// the Bundle, and removes the snapshots beyond the limit of its history.
+// Returns the history of the Bundle, newest first.
+func (b *bundle) recordSnapshot(ctx context.Context, bundle *trustapi.Bundle, data target.Data) ([]trustapi.BundleSnapshot, error) {
+	var limit int
+	if bundle.Spec.History != nil {
+		limit = int(bundle.Spec.History.Limit)
+	}
+
+	history := bundle.Status.History
+	if hash := snapshotHash(data.Data); limit > 0 && (len(history) == 0 || history[0].Hash != hash) {
+		if err := b.applySnapshotSecret(ctx, bundle, hash, data); err != nil {
+			return nil, err
+		}
+
+		history = slices.DeleteFunc(slices.Clone(history), func(snapshot trustapi.BundleSnapshot) bool {
+			return snapshot.Hash == hash
+		})
+		history = slices.Insert(history, 0, trustapi.BundleSnapshot{Hash: hash, PublishTime: metav1.NewTime(b.clock.Now())})
+	}
+
+	if len(history) <= limit {
+		return history, nil
+	}
+
+	for _, snapshot := range history[limit:] {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: b.Namespace, Name: snapshotSecretName(bundle.Name, snapshot.Hash)}}
+		if err := b.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete snapshot Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	if limit == 0 {
+		return nil, nil
+	}
+	return history[:limit], nil
+}
+
+func (b *bundle) applySnapshotSecret(ctx context.Context, bundle *trustapi.Bundle, hash string, data target.Data) error {
+	secretData := map[string][]byte{snapshotBundleKey: []byte(data.Data)}
+	if data.PackageVersion != "" {
+		secretData[snapshotPackageVersionKey] = []byte(data.PackageVersion)
+	}
+
+	// Like the sync summary ConfigMap, snapshot Secrets are deliberately not
+	// labelled as targets, so they're never picked up by the target cache.
+	patch := coreapplyconfig.Secret(snapshotSecretName(bundle.Name, hash), b.Namespace).
+		WithAnnotations(map[string]string{trustapi.BundleHashAnnotationKey: hash}).
+		WithOwnerReferences(
+			metav1applyconfig.OwnerReference().
+				WithAPIVersion(trustapi.SchemeGroupVersion.String()).
+				WithKind(trustapi.BundleKind).
+				WithName(bundle.GetName()).
+				WithUID(bundle.GetUID()),
+		).
+		WithData(secretData)
+
+	encodedPatch, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	obj := &corev1.Secret{}
+	obj.SetName(*patch.Name)
+	obj.SetNamespace(*patch.Namespace)
+
+	if err := b.client.Patch(ctx, obj, ssa_client.ApplyPatch{Patch: encodedPatch}, ssa_client.FieldManager, client.ForceOwnership); err != nil {
+		return fmt.Errorf("failed to patch snapshot Secret %s/%s: %w", obj.Namespace, obj.Name, err)
+	}
+
+	return nil
+}
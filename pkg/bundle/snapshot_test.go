@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_buildSnapshotBundle(t *testing.T) {
+	const trustNamespace = "trust-namespace"
+
+	hash := snapshotHash(dummy.TestCertificate1)
+	bundleObj := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "test-bundle"}}
+	snapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: trustNamespace, Name: snapshotSecretName(bundleObj.Name, hash)},
+		Data: map[string][]byte{
+			snapshotBundleKey:         []byte(dummy.TestCertificate1),
+			snapshotPackageVersionKey: []byte("test-package"),
+		},
+	}
+	otherHash := snapshotHash(dummy.TestCertificate2)
+	otherSnapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: trustNamespace, Name: snapshotSecretName(bundleObj.Name, otherHash)},
+		Data:       map[string][]byte{snapshotBundleKey: []byte(dummy.TestCertificate3)},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(snapshot, otherSnapshot).
+		Build()
+
+	log, ctx := ktesting.NewTestContext(t)
+	b := &bundle{
+		client:  fakeClient,
+		Options: Options{Log: log, Namespace: trustNamespace},
+	}
+
+	resolvedBundle, err := b.buildSnapshotBundle(ctx, bundleObj, hash)
+	require.NoError(t, err)
+	assert.Equal(t, hash, snapshotHash(resolvedBundle.Data.Data))
+	assert.Equal(t, "test-package", resolvedBundle.PackageVersion)
+
+	_, err = b.buildSnapshotBundle(ctx, bundleObj, snapshotHash(dummy.TestCertificate4))
+	assert.True(t, errors.As(err, &notFoundError{}), "expected a notFoundError, got %v", err)
+
+	// A Secret holding another bundle than its name suggests isn't the snapshot.
+	_, err = b.buildSnapshotBundle(ctx, bundleObj, otherHash)
+	assert.True(t, errors.As(err, &notFoundError{}), "expected a notFoundError, got %v", err)
+
+	_, err = b.buildSnapshotBundle(ctx, bundleObj, "abc")
+	assert.True(t, errors.As(err, &notFoundError{}), "expected a notFoundError, got %v", err)
+}
+
+func Test_recordSnapshot(t *testing.T) {
+	const trustNamespace = "trust-namespace"
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	published := metav1.NewTime(now.Add(-time.Hour))
+
+	hash1 := snapshotHash(dummy.TestCertificate1)
+	hash2 := snapshotHash(dummy.TestCertificate2)
+	hash3 := snapshotHash(dummy.TestCertificate3)
+
+	newBundle := func(limit int32, history ...string) *trustapi.Bundle {
+		bundleObj := &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "test-bundle"}}
+		if limit > 0 {
+			bundleObj.Spec.History = &trustapi.BundleHistory{Limit: limit}
+		}
+		for _, hash := range history {
+			bundleObj.Status.History = append(bundleObj.Status.History, trustapi.BundleSnapshot{Hash: hash, PublishTime: published})
+		}
+		return bundleObj
+	}
+
+	tests := map[string]struct {
+		bundle *trustapi.Bundle
+		data   string
+
+		expHistory []trustapi.BundleSnapshot
+		expApplied []string
+		expDeleted []string
+	}{
+		"Bundles without a history keep no snapshots": {
+			bundle: newBundle(0),
+			data:   dummy.TestCertificate1,
+		},
+		"a new bundle is recorded": {
+			bundle:     newBundle(2, hash2),
+			data:       dummy.TestCertificate1,
+			expHistory: []trustapi.BundleSnapshot{{Hash: hash1, PublishTime: metav1.NewTime(now)}, {Hash: hash2, PublishTime: published}},
+			expApplied: []string{snapshotSecretName("test-bundle", hash1)},
+		},
+		"the latest bundle isn't recorded again": {
+			bundle:     newBundle(2, hash1, hash2),
+			data:       dummy.TestCertificate1,
+			expHistory: []trustapi.BundleSnapshot{{Hash: hash1, PublishTime: published}, {Hash: hash2, PublishTime: published}},
+		},
+		"a bundle published again moves to the front, and the oldest snapshots are removed": {
+			bundle:     newBundle(2, hash1, hash2, hash3),
+			data:       dummy.TestCertificate3,
+			expHistory: []trustapi.BundleSnapshot{{Hash: hash3, PublishTime: metav1.NewTime(now)}, {Hash: hash1, PublishTime: published}},
+			expApplied: []string{snapshotSecretName("test-bundle", hash3)},
+			expDeleted: []string{snapshotSecretName("test-bundle", hash2)},
+		},
+		"disabling the history removes all snapshots": {
+			bundle:     newBundle(0, hash1, hash2),
+			data:       dummy.TestCertificate1,
+			expDeleted: []string{snapshotSecretName("test-bundle", hash1), snapshotSecretName("test-bundle", hash2)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var applied, deleted []string
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						assert.Equal(t, trustNamespace, obj.GetNamespace())
+						applied = append(applied, obj.GetName())
+						return nil
+					},
+					Delete: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.DeleteOption) error {
+						assert.Equal(t, trustNamespace, obj.GetNamespace())
+						deleted = append(deleted, obj.GetName())
+						return apierrors.NewNotFound(corev1.Resource("secrets"), obj.GetName())
+					},
+				}).
+				Build()
+
+			log, ctx := ktesting.NewTestContext(t)
+			b := &bundle{
+				client:  fakeClient,
+				clock:   fakeclock.NewFakeClock(now),
+				Options: Options{Log: log, Namespace: trustNamespace},
+			}
+
+			history, err := b.recordSnapshot(ctx, test.bundle, target.Data{Data: test.data})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expHistory, history)
+			assert.Equal(t, test.expApplied, applied)
+			assert.Equal(t, test.expDeleted, deleted)
+		})
+	}
+}
@@ -17,49 +17,109 @@ limitations under the License.
 package bundle
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
 	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
 	"github.com/cert-manager/trust-manager/pkg/util"
 )
 
+const (
+	// kubeRootCAConfigMapName is the name of the ConfigMap which Kubernetes
+	// publishes the CA of the API server to in every Namespace.
+	kubeRootCAConfigMapName = "kube-root-ca.crt"
+
+	// kubeRootCAConfigMapKey is the key of the CA in the ConfigMap.
+	kubeRootCAConfigMapKey = "ca.crt"
+)
+
 type notFoundError struct{ error }
 
 type selectsNothingError struct{ error }
 
 type invalidSecretSourceError struct{ error }
 
+type expiredCertificateError struct{ error }
+
+type privateKeyError struct{ error }
+
+// certificateGVK is the kind of cert-manager Certificates, which Certificates
+// sources select.
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// issuerGVK is the kind of cert-manager Issuers, which IssuerRef sources may
+// refer to along with ClusterIssuers.
+var issuerGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}
+
+// errSourceNamespaceSelectorsDisabled is returned for sources with a namespace
+// selector if source namespace selectors aren't enabled.
+var errSourceNamespaceSelectorsDisabled = errors.New("namespace selectors on sources are not enabled; trust-manager must be started with --source-namespace-selectors-enabled")
+
 // bundleData holds the result of a call to buildSourceBundle. It contains the resulting PEM-encoded
 // certificate data from concatenating all the sources together, binary data for any additional formats and
 // any metadata from the sources which needs to be exposed on the Bundle resource's status field.
 type bundleData struct {
 	target.Data
 
-	defaultCAPackageStringID string
+	// skippedSources lists optional sources which were not found.
+	skippedSources []string
+
+	// cloudSourceFailures lists the failures of the APIs of cloud sources
+	// whose data fetched last was used instead.
+	cloudSourceFailures []string
+
+	// filtered lists the certificates which were removed from the sources.
+	filtered []util.FilteredCertificate
+
+	// invalidBlocks describes the malformed PEM blocks which were skipped in
+	// the sources.
+	invalidBlocks []string
+
+	// pool holds the certificates of the bundle, from which the additional
+	// formats of each target are encoded.
+	pool *util.CertPool
+
+	// provenance holds the sources which contributed each certificate, by
+	// SHA-256 fingerprint, for the manifest.
+	provenance map[string][]manifestSource
 }
 
 // buildSourceBundle retrieves and concatenates all source bundle data for this Bundle object.
 // Each source data is validated and pruned to ensure that all certificates within are valid, and
 // is each bundle is concatenated together with a new line character.
-func (b *bundle) buildSourceBundle(ctx context.Context, sources []trustapi.BundleSource, formats *trustapi.AdditionalFormats) (bundleData, error) {
+// If requireCA is true, certificates which aren't CA certificates are dropped.
+// Expired certificates and malformed PEM blocks are handled according to the
+// given policies.
+func (b *bundle) buildSourceBundle(ctx context.Context, sources []trustapi.BundleSource, formats *trustapi.AdditionalFormats, requireCA bool, expired trustapi.ExpiredCertificatePolicy, deduplication util.Deduplication, invalid trustapi.InvalidCertificatePolicy) (bundleData, error) {
 	var resolvedBundle bundleData
 	certPool := util.NewCertPool(
-		util.WithFilteredExpiredCerts(b.FilterExpiredCerts),
+		util.WithFilteredExpiredCerts(expired == trustapi.ExpiredCertificatePolicyRemove),
+		util.WithRejectedExpiredCerts(expired == trustapi.ExpiredCertificatePolicyFail),
+		util.WithRequiredCABasicConstraints(requireCA),
+		util.WithDeduplication(deduplication),
+		util.WithSkippedInvalidBlocks(invalid == trustapi.InvalidCertificatePolicySkip),
+		util.WithTrailingNewline(b.PEMTrailingNewline),
 		util.WithLogger(b.Log.WithName("cert-pool")),
 	)
 
-	for _, source := range sources {
+	for i, source := range sources {
 		var (
-			sourceData string
+			sourceData []byte
 			err        error
 		)
 
@@ -71,7 +131,35 @@ func (b *bundle) buildSourceBundle(ctx context.Context, sources []trustapi.Bundl
 			sourceData, err = b.secretBundle(ctx, source.Secret)
 
 		case source.InLine != nil:
-			sourceData = *source.InLine
+			sourceData = []byte(*source.InLine)
+
+		case source.IssuerRef != nil:
+			sourceData, err = b.issuerBundle(ctx, source.IssuerRef)
+
+		case source.Certificates != nil:
+			sourceData, err = b.certificateBundle(ctx, source.Certificates)
+
+		case source.SPIFFEFederation != nil:
+			sourceData, err = stringSource(b.spiffeFederationBundle(ctx, source.SPIFFEFederation))
+
+		case source.AWSPrivateCA != nil, source.GCPCASPool != nil, source.AzureKeyVault != nil, source.Plugin != nil:
+			cloud, _ := cloudSource(source)
+			sourceData, err = stringSource(b.cloudSourceBundle(ctx, cloud))
+
+			// The certificates fetched last are used while the API of the
+			// cloud provider fails.
+			if len(sourceData) > 0 && errors.As(err, &cloudSourceError{}) {
+				b.Log.Error(err, "failed to refresh cloud source, using the certificates fetched last", "provider", cloud.Provider, "ref", cloud.Ref)
+				resolvedBundle.cloudSourceFailures = append(resolvedBundle.cloudSourceFailures, err.Error())
+				err = nil
+			}
+
+		case source.UseInClusterCA != nil:
+			if !*source.UseInClusterCA {
+				continue
+			}
+
+			sourceData, err = b.configMapBundle(ctx, &trustapi.SourceObjectKeySelector{Name: kubeRootCAConfigMapName, Key: kubeRootCAConfigMapKey})
 
 		case source.UseDefaultCAs != nil:
 			if !*source.UseDefaultCAs {
@@ -81,8 +169,8 @@ func (b *bundle) buildSourceBundle(ctx context.Context, sources []trustapi.Bundl
 			if b.defaultPackage == nil {
 				err = notFoundError{fmt.Errorf("no default package was specified when trust-manager was started; default CAs not available")}
 			} else {
-				sourceData = b.defaultPackage.Bundle
-				resolvedBundle.defaultCAPackageStringID = b.defaultPackage.StringID()
+				sourceData = []byte(b.defaultPackage.Bundle)
+				resolvedBundle.PackageVersion = b.defaultPackage.StringID()
 			}
 		}
 
@@ -92,13 +180,36 @@ func (b *bundle) buildSourceBundle(ctx context.Context, sources []trustapi.Bundl
 			continue
 		}
 
+		// Optional sources may be missing, and are reported rather than failing the Bundle.
+		if errors.As(err, &notFoundError{}) {
+			if skipped, ok := b.optionalSourceName(source); ok {
+				b.Log.V(2).Info("skipping optional source which was not found", "source", skipped, "reason", err.Error())
+				resolvedBundle.skippedSources = append(resolvedBundle.skippedSources, skipped)
+				continue
+			}
+		}
+
 		if err != nil {
 			return bundleData{}, fmt.Errorf("failed to retrieve bundle from source: %w", err)
 		}
 
-		if err := certPool.AddCertsFromPEM([]byte(sourceData)); err != nil {
+		// Sources holding private keys, such as a tls.key copied by mistake,
+		// are never propagated, whatever else they hold.
+		if blockType, ok := util.PrivateKeyPEMType(sourceData); ok {
+			return bundleData{}, privateKeyError{fmt.Errorf("source %d (%s) holds a %q PEM block", i, b.describeSource(i, source), blockType)}
+		}
+
+		skipped := len(certPool.Invalid())
+		if err := certPool.AddCertsFromPEM(sourceData); errors.As(err, &util.ExpiredCertificateError{}) {
+			return bundleData{}, expiredCertificateError{fmt.Errorf("expired certificate in source: %w", err)}
+		} else if err != nil {
 			return bundleData{}, fmt.Errorf("invalid PEM data in source: %w", err)
 		}
+		for _, block := range certPool.Invalid()[skipped:] {
+			resolvedBundle.invalidBlocks = append(resolvedBundle.invalidBlocks, fmt.Sprintf("source %d (%s) %s", i, b.describeSource(i, source), block))
+		}
+
+		resolvedBundle.addProvenance(b.manifestSourceOf(i, source), sourceData)
 	}
 
 	// NB: empty bundles are not valid so check and return an error if one somehow snuck through.
@@ -109,12 +220,47 @@ func (b *bundle) buildSourceBundle(ctx context.Context, sources []trustapi.Bundl
 	if err := resolvedBundle.Data.Populate(certPool, formats); err != nil {
 		return bundleData{}, err
 	}
+	resolvedBundle.filtered = certPool.Filtered()
+	resolvedBundle.pool = certPool
 
 	return resolvedBundle, nil
 }
 
-// configMapBundle returns the data in the source ConfigMap within the trust Namespace.
-func (b *bundle) configMapBundle(ctx context.Context, ref *trustapi.SourceObjectKeySelector) (string, error) {
+// describeSource returns the kind and name of the source at the given index
+// of the sources of a Bundle, for messages.
+func (b *bundle) describeSource(index int, source trustapi.BundleSource) string {
+	ms := b.manifestSourceOf(index, source)
+	switch {
+	case ms.Name == "":
+		return ms.Kind
+	case ms.Namespace == "":
+		return ms.Kind + " " + ms.Name
+	default:
+		return ms.Kind + " " + ms.Namespace + "/" + ms.Name
+	}
+}
+
+// stringSource returns the data of a source which is fetched as a string.
+func stringSource(data string, err error) ([]byte, error) {
+	return []byte(data), err
+}
+
+// optionalSourceName returns a description of the given source, and true if
+// the source is optional.
+func (b *bundle) optionalSourceName(source trustapi.BundleSource) (string, bool) {
+	switch {
+	case source.ConfigMap != nil && source.ConfigMap.Optional:
+		return fmt.Sprintf("ConfigMap %s/%s", b.Namespace, source.ConfigMap.Name), true
+	case source.Secret != nil && source.Secret.Optional:
+		return fmt.Sprintf("Secret %s/%s", b.Namespace, source.Secret.Name), true
+	default:
+		return "", false
+	}
+}
+
+// configMapBundle returns the data in the source ConfigMap within the trust
+// Namespace, or in the ConfigMaps selected in other Namespaces.
+func (b *bundle) configMapBundle(ctx context.Context, ref *trustapi.SourceObjectKeySelector) ([]byte, error) {
 	// this slice will contain a single ConfigMap if we fetch by name
 	// or potentially multiple ConfigMaps if we fetch by label selector
 	var configMaps []corev1.ConfigMap
@@ -126,34 +272,50 @@ func (b *bundle) configMapBundle(ctx context.Context, ref *trustapi.SourceObject
 			Namespace: b.Namespace,
 			Name:      ref.Name,
 		}, &cm); apierrors.IsNotFound(err) {
-			return "", notFoundError{err}
+			return nil, notFoundError{err}
 		} else if err != nil {
-			return "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", b.Namespace, ref.Name, err)
+			return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", b.Namespace, ref.Name, err)
 		}
 
 		configMaps = []corev1.ConfigMap{cm}
 	} else {
-		// if Selector is set, we `List` by label selector
-		cml := corev1.ConfigMapList{}
+		// if Selector is set, we `List` by label selector, in the trust
+		// Namespace or in the Namespaces selected by the NamespaceSelector
 		selector, selectorErr := metav1.LabelSelectorAsSelector(ref.Selector)
 		if selectorErr != nil {
-			return "", fmt.Errorf("failed to parse label selector as Selector for ConfigMap in namespace %s: %w", b.Namespace, selectorErr)
+			return nil, fmt.Errorf("failed to parse label selector as Selector for ConfigMap in namespace %s: %w", b.Namespace, selectorErr)
 		}
-		if err := b.client.List(ctx, &cml, client.MatchingLabelsSelector{Selector: selector}); err != nil {
-			return "", fmt.Errorf("failed to get ConfigMapList: %w", err)
-		} else if len(cml.Items) == 0 {
-			return "", selectsNothingError{fmt.Errorf("label selector %s for ConfigMap didn't match any resources", selector.String())}
+		namespaces, err := b.sourceNamespaces(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range namespaces {
+			cml := corev1.ConfigMapList{}
+			if err := b.client.List(ctx, &cml, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil, fmt.Errorf("failed to get ConfigMapList: %w", err)
+			}
+			configMaps = append(configMaps, cml.Items...)
+		}
+		if len(configMaps) == 0 {
+			return nil, selectsNothingError{fmt.Errorf("label selector %s for ConfigMap didn't match any resources", selector.String())}
 		}
-
-		configMaps = cml.Items
 	}
 
-	var results strings.Builder
+	var results bytes.Buffer
 	for _, cm := range configMaps {
-		if len(ref.Key) > 0 {
+		if keyIsGlob(ref.Key) {
+			keys := matchingCertificateKeys(ref.Key, cm.Data)
+			if len(keys) == 0 {
+				return nil, notFoundError{fmt.Errorf("no certificates found in ConfigMap %s/%s at keys matching %q", cm.Namespace, cm.Name, ref.Key)}
+			}
+			for _, key := range keys {
+				results.WriteString(cm.Data[key])
+				results.WriteByte('\n')
+			}
+		} else if len(ref.Key) > 0 {
 			data, ok := cm.Data[ref.Key]
 			if !ok {
-				return "", notFoundError{fmt.Errorf("no data found in ConfigMap %s/%s at key %q", cm.Namespace, cm.Name, ref.Key)}
+				return nil, notFoundError{fmt.Errorf("no data found in ConfigMap %s/%s at key %q", cm.Namespace, cm.Name, ref.Key)}
 			}
 			results.WriteString(data)
 			results.WriteByte('\n')
@@ -164,11 +326,12 @@ func (b *bundle) configMapBundle(ctx context.Context, ref *trustapi.SourceObject
 			}
 		}
 	}
-	return results.String(), nil
+	return results.Bytes(), nil
 }
 
-// secretBundle returns the data in the source Secret within the trust Namespace.
-func (b *bundle) secretBundle(ctx context.Context, ref *trustapi.SourceObjectKeySelector) (string, error) {
+// secretBundle returns the data in the source Secret within the trust
+// Namespace, or in the Secrets selected in other Namespaces.
+func (b *bundle) secretBundle(ctx context.Context, ref *trustapi.SourceObjectKeySelector) ([]byte, error) {
 	// this slice will contain a single Secret if we fetch by name
 	// or potentially multiple Secrets if we fetch by label selector
 	var secrets []corev1.Secret
@@ -180,41 +343,57 @@ func (b *bundle) secretBundle(ctx context.Context, ref *trustapi.SourceObjectKey
 			Namespace: b.Namespace,
 			Name:      ref.Name,
 		}, &s); apierrors.IsNotFound(err) {
-			return "", notFoundError{err}
+			return nil, notFoundError{err}
 		} else if err != nil {
-			return "", fmt.Errorf("failed to get Secret %s/%s: %w", b.Namespace, ref.Name, err)
+			return nil, fmt.Errorf("failed to get Secret %s/%s: %w", b.Namespace, ref.Name, err)
 		}
 
 		secrets = []corev1.Secret{s}
 	} else {
-		// if Selector is set, we `List` by label selector
-		sl := corev1.SecretList{}
+		// if Selector is set, we `List` by label selector, in the trust
+		// Namespace or in the Namespaces selected by the NamespaceSelector
 		selector, selectorErr := metav1.LabelSelectorAsSelector(ref.Selector)
 		if selectorErr != nil {
-			return "", fmt.Errorf("failed to parse label selector as Selector for Secret in namespace %s: %w", b.Namespace, selectorErr)
+			return nil, fmt.Errorf("failed to parse label selector as Selector for Secret in namespace %s: %w", b.Namespace, selectorErr)
 		}
-		if err := b.client.List(ctx, &sl, client.MatchingLabelsSelector{Selector: selector}); err != nil {
-			return "", fmt.Errorf("failed to get SecretList: %w", err)
-		} else if len(sl.Items) == 0 {
-			return "", selectsNothingError{fmt.Errorf("label selector %s for Secret didn't match any resources", selector.String())}
+		namespaces, err := b.sourceNamespaces(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range namespaces {
+			sl := corev1.SecretList{}
+			if err := b.client.List(ctx, &sl, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil, fmt.Errorf("failed to get SecretList: %w", err)
+			}
+			secrets = append(secrets, sl.Items...)
+		}
+		if len(secrets) == 0 {
+			return nil, selectsNothingError{fmt.Errorf("label selector %s for Secret didn't match any resources", selector.String())}
 		}
-
-		secrets = sl.Items
 	}
 
-	var results strings.Builder
+	var results bytes.Buffer
 	for _, secret := range secrets {
-		if len(ref.Key) > 0 {
+		if keyIsGlob(ref.Key) {
+			keys := matchingCertificateKeys(ref.Key, secret.Data)
+			if len(keys) == 0 {
+				return nil, notFoundError{fmt.Errorf("no certificates found in Secret %s/%s at keys matching %q", secret.Namespace, secret.Name, ref.Key)}
+			}
+			for _, key := range keys {
+				results.Write(secret.Data[key])
+				results.WriteByte('\n')
+			}
+		} else if len(ref.Key) > 0 {
 			data, ok := secret.Data[ref.Key]
 			if !ok {
-				return "", notFoundError{fmt.Errorf("no data found in Secret %s/%s at key %q", secret.Namespace, secret.Name, ref.Key)}
+				return nil, notFoundError{fmt.Errorf("no data found in Secret %s/%s at key %q", secret.Namespace, secret.Name, ref.Key)}
 			}
 			results.Write(data)
 			results.WriteByte('\n')
 		} else if ref.IncludeAllKeys {
 			// This is done to prevent mistakes. All keys should never be included for a TLS secret, since that would include the private key.
 			if secret.Type == corev1.SecretTypeTLS {
-				return "", invalidSecretSourceError{fmt.Errorf("includeAllKeys is not supported for TLS Secrets such as %s/%s", secret.Namespace, secret.Name)}
+				return nil, invalidSecretSourceError{fmt.Errorf("includeAllKeys is not supported for TLS Secrets such as %s/%s", secret.Namespace, secret.Name)}
 			}
 
 			for _, data := range secret.Data {
@@ -223,5 +402,264 @@ func (b *bundle) secretBundle(ctx context.Context, ref *trustapi.SourceObjectKey
 			}
 		}
 	}
-	return results.String(), nil
+	return results.Bytes(), nil
+}
+
+// sourceNamespaces returns the Namespaces which the objects of a source are
+// listed in: the trust Namespace, or the Namespaces matching its namespace
+// selector, sorted by name. Namespaces which are being deleted, or aren't
+// watched in namespaced mode, are left out.
+func (b *bundle) sourceNamespaces(ctx context.Context, ref *trustapi.SourceObjectKeySelector) ([]string, error) {
+	if ref.NamespaceSelector == nil {
+		return []string{b.Namespace}, nil
+	}
+	if !b.Options.SourceNamespaceSelectorsEnabled {
+		return nil, errSourceNamespaceSelectorsDisabled
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ref.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse namespace selector of source: %w", err)
+	}
+
+	var namespaceList corev1.NamespaceList
+	if err := b.client.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list Namespaces: %w", err)
+	}
+
+	var namespaces []string
+	for _, namespace := range namespaceList.Items {
+		if namespaceIsGone(&namespace) || !b.namespaceWatched(namespace.Name) {
+			continue
+		}
+		namespaces = append(namespaces, namespace.Name)
+	}
+	slices.Sort(namespaces)
+
+	return namespaces, nil
+}
+
+// issuerNamespace returns the Namespace the Secrets of issuers of the kind
+// are read from: the trust Namespace for Issuers, and the cluster resource
+// Namespace for ClusterIssuers.
+func (b *bundle) issuerNamespace(kind string) string {
+	if kind == "ClusterIssuer" && b.ClusterResourceNamespace != "" {
+		return b.ClusterResourceNamespace
+	}
+	return b.Namespace
+}
+
+// issuerBundle returns the CA certificate of a cert-manager CA or SelfSigned
+// Issuer or ClusterIssuer. The Secret of a CA issuer is read from the trust
+// Namespace for an Issuer, and from the cluster resource Namespace for a
+// ClusterIssuer.
+func (b *bundle) issuerBundle(ctx context.Context, ref *trustapi.IssuerReference) ([]byte, error) {
+	kind := ref.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	namespace := b.issuerNamespace(kind)
+
+	issuer := &unstructured.Unstructured{}
+	issuer.SetGroupVersionKind(issuerGVK.GroupVersion().WithKind(kind))
+
+	key := client.ObjectKey{Name: ref.Name}
+	if kind == "Issuer" {
+		key.Namespace = namespace
+	}
+
+	if err := b.client.Get(ctx, key, issuer); apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, notFoundError{fmt.Errorf("%s %q not found: %w", kind, ref.Name, err)}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %s %q: %w", kind, ref.Name, err)
+	}
+
+	if _, ok, _ := unstructured.NestedMap(issuer.Object, "spec", "selfSigned"); ok {
+		return b.selfSignedIssuerBundle(ctx, kind, ref.Name, namespace)
+	}
+
+	secretName, ok, _ := unstructured.NestedString(issuer.Object, "spec", "ca", "secretName")
+	if !ok || secretName == "" {
+		return nil, fmt.Errorf("%s %q is not a CA or SelfSigned issuer; only CA and SelfSigned issuers are supported as sources", kind, ref.Name)
+	}
+
+	var secret corev1.Secret
+	if err := b.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); apierrors.IsNotFound(err) {
+		return nil, notFoundError{err}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s of %s %q: %w", namespace, secretName, kind, ref.Name, err)
+	}
+
+	if data := secretCACertificate(&secret); len(data) > 0 {
+		return data, nil
+	}
+
+	return nil, notFoundError{fmt.Errorf("no CA certificate found in Secret %s/%s of %s %q", namespace, secretName, kind, ref.Name)}
+}
+
+// selfSignedIssuerBundle returns the CA certificates of a SelfSigned issuer:
+// those of the CA Certificates it issued in the Namespace the issuer's Secrets
+// are read from. Certificates whose Secret doesn't exist yet are skipped.
+func (b *bundle) selfSignedIssuerBundle(ctx context.Context, kind, name, namespace string) ([]byte, error) {
+	// The CA Certificates of a SelfSigned issuer are found by listing
+	// Certificates, which is only permitted with Certificates sources.
+	if !b.CertificateSourcesEnabled {
+		return nil, cloudsource.UnavailableError{Provider: "Certificates"}
+	}
+
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(certificateGVK.GroupVersion().WithKind(certificateGVK.Kind + "List"))
+	if err := b.client.List(ctx, certificates, client.InNamespace(namespace)); meta.IsNoMatchError(err) {
+		return nil, notFoundError{fmt.Errorf("failed to list Certificates: %w", err)}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list Certificates: %w", err)
+	}
+
+	slices.SortFunc(certificates.Items, func(x, y unstructured.Unstructured) int {
+		return strings.Compare(x.GetName(), y.GetName())
+	})
+
+	var results bytes.Buffer
+	for _, certificate := range certificates.Items {
+		if !certificateIssuedBy(&certificate, kind, name) {
+			continue
+		}
+		secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+		if secretName == "" {
+			continue
+		}
+
+		var secret corev1.Secret
+		if err := b.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); apierrors.IsNotFound(err) {
+			b.Log.V(2).Info("skipping Certificate whose Secret doesn't exist yet", "certificate", certificate.GetName(), "secret", secretName)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s/%s of Certificate %q: %w", namespace, secretName, certificate.GetName(), err)
+		}
+
+		data := secretCACertificate(&secret)
+		if len(data) == 0 {
+			b.Log.V(2).Info("skipping Certificate whose Secret holds no CA certificate", "certificate", certificate.GetName(), "secret", secretName)
+			continue
+		}
+		results.Write(data)
+		results.WriteByte('\n')
+	}
+
+	if results.Len() == 0 {
+		return nil, notFoundError{fmt.Errorf("no CA Certificate issued by %s %q in Namespace %q holds a CA certificate yet", kind, name, namespace)}
+	}
+	return results.Bytes(), nil
+}
+
+// certificateIssuedBy returns true if the cert-manager Certificate is a CA
+// certificate issued by the issuer of the kind and name.
+func certificateIssuedBy(certificate *unstructured.Unstructured, kind, name string) bool {
+	if isCA, _, _ := unstructured.NestedBool(certificate.Object, "spec", "isCA"); !isCA {
+		return false
+	}
+
+	ref, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+	refKind := ref["kind"]
+	if refKind == "" {
+		refKind = "Issuer"
+	}
+	return ref["name"] == name && refKind == kind && (ref["group"] == "" || ref["group"] == issuerGVK.Group)
+}
+
+// secretCACertificate returns the CA certificate in the Secret of a
+// cert-manager issuer or Certificate. The root CA is preferred over the
+// issuing certificate, which may be an intermediate.
+func secretCACertificate(secret *corev1.Secret) []byte {
+	for _, key := range []string{"ca.crt", corev1.TLSCertKey} {
+		if data := secret.Data[key]; len(data) > 0 {
+			return data
+		}
+	}
+	return nil
+}
+
+// certificateBundle returns the CA certificates in the "ca.crt" key of the
+// Secrets of the cert-manager Certificates in the trust Namespace which the
+// source selects. Certificates whose Secret doesn't hold a CA certificate,
+// such as those which weren't issued yet, are skipped.
+func (b *bundle) certificateBundle(ctx context.Context, ref *trustapi.CertificateSource) ([]byte, error) {
+	if !b.CertificateSourcesEnabled {
+		return nil, cloudsource.UnavailableError{Provider: "Certificates"}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&ref.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse label selector of Certificates source: %w", err)
+	}
+
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(certificateGVK.GroupVersion().WithKind(certificateGVK.Kind + "List"))
+	if err := b.client.List(ctx, certificates, client.InNamespace(b.Namespace), client.MatchingLabelsSelector{Selector: selector}); meta.IsNoMatchError(err) {
+		return nil, notFoundError{fmt.Errorf("failed to list Certificates: %w", err)}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list Certificates: %w", err)
+	}
+	if len(certificates.Items) == 0 {
+		return nil, selectsNothingError{fmt.Errorf("label selector %s for Certificate didn't match any resources", selector.String())}
+	}
+
+	slices.SortFunc(certificates.Items, func(x, y unstructured.Unstructured) int {
+		return strings.Compare(x.GetName(), y.GetName())
+	})
+
+	var results bytes.Buffer
+	for _, certificate := range certificates.Items {
+		secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+		if secretName == "" {
+			continue
+		}
+
+		var secret corev1.Secret
+		if err := b.client.Get(ctx, client.ObjectKey{Namespace: b.Namespace, Name: secretName}, &secret); apierrors.IsNotFound(err) {
+			b.Log.V(2).Info("skipping Certificate whose Secret doesn't exist yet", "certificate", certificate.GetName(), "secret", secretName)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s/%s of Certificate %q: %w", b.Namespace, secretName, certificate.GetName(), err)
+		}
+
+		data := secret.Data["ca.crt"]
+		if len(data) == 0 {
+			b.Log.V(2).Info("skipping Certificate whose Secret holds no CA certificate", "certificate", certificate.GetName(), "secret", secretName)
+			continue
+		}
+		results.Write(data)
+		results.WriteByte('\n')
+	}
+
+	if results.Len() == 0 {
+		return nil, selectsNothingError{fmt.Errorf("none of the Certificates matching label selector %s hold a CA certificate yet", selector.String())}
+	}
+	return results.Bytes(), nil
+}
+
+// keyIsGlob returns true if a source key is a glob pattern, rather than the
+// name of a single key.
+func keyIsGlob(key string) bool {
+	return strings.ContainsAny(key, "*?[")
+}
+
+// matchingCertificateKeys returns the sorted keys of data which match the glob
+// pattern and whose values contain PEM-encoded certificates. Keys holding
+// anything else, such as private keys, are skipped.
+func matchingCertificateKeys[T string | []byte](pattern string, data map[string]T) []string {
+	var keys []string
+	for key, value := range data {
+		if matched, err := path.Match(pattern, key); err != nil || !matched {
+			continue
+		}
+		if !strings.Contains(string(value), "-----BEGIN CERTIFICATE-----") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
 }
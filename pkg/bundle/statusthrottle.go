@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"sync"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/clock"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// statusThrottle debounces the status updates of Bundles, which would
+// otherwise be written on every reconcile of a large sync or progressive
+// rollout as target counts and rollout progress change.
+// Updates which only change the progress fields of the status, its target
+// counts, rollout and last sync time, are applied at most once per interval;
+// the Bundle is requeued so that a deferred update is applied once the
+// interval has passed. Every other update is applied immediately, as not all
+// of the status can be recomputed by the next reconcile, such as the last
+// change to the content of the Bundle.
+type statusThrottle struct {
+	clock clock.PassiveClock
+
+	// interval is the minimum interval between progress updates of a
+	// Bundle. Zero disables throttling.
+	interval time.Duration
+
+	mu      sync.Mutex
+	applied map[string]appliedStatus
+}
+
+// appliedStatus is the last status update applied for a Bundle.
+type appliedStatus struct {
+	time   time.Time
+	status *trustapi.BundleStatus
+}
+
+func newStatusThrottle(clock clock.PassiveClock, interval time.Duration) *statusThrottle {
+	return &statusThrottle{
+		clock:    clock,
+		interval: interval,
+		applied:  make(map[string]appliedStatus),
+	}
+}
+
+// admit returns zero if the status update of the named Bundle should be
+// applied now, in which case the caller records it once it's applied.
+// Otherwise, it returns how long to wait before the update is due.
+func (t *statusThrottle) admit(name string, status *trustapi.BundleStatus) time.Duration {
+	if t == nil || t.interval <= 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	t.prune(now)
+
+	last, ok := t.applied[name]
+	if !ok || !onlyProgressChanged(last.status, status) {
+		return 0
+	}
+
+	return last.time.Add(t.interval).Sub(now)
+}
+
+// record records that the status update of the named Bundle was applied.
+func (t *statusThrottle) record(name string, status *trustapi.BundleStatus) {
+	if t == nil || t.interval <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.applied[name] = appliedStatus{
+		time:   t.clock.Now(),
+		status: status.DeepCopy(),
+	}
+}
+
+// forget drops the last status update of the named Bundle, such as when it's
+// deleted or its update failed.
+func (t *statusThrottle) forget(name string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.applied, name)
+}
+
+// prune forgets status updates which no longer hold back the next update.
+func (t *statusThrottle) prune(now time.Time) {
+	for name, last := range t.applied {
+		if !now.Before(last.time.Add(t.interval)) {
+			delete(t.applied, name)
+		}
+	}
+}
+
+// onlyProgressChanged returns true if the status differs from the previous
+// status in nothing but its progress fields, and the last transition times of
+// its conditions.
+func onlyProgressChanged(previous, current *trustapi.BundleStatus) bool {
+	if conditionsChanged(previous.Conditions, current.Conditions) {
+		return false
+	}
+
+	withoutProgress := func(status *trustapi.BundleStatus) *trustapi.BundleStatus {
+		status = status.DeepCopy()
+		status.Conditions = nil
+		status.TargetCount = 0
+		status.SyncedTargetCount = 0
+		status.Rollout = nil
+		status.LastSyncTime = nil
+		return status
+	}
+
+	return apiequality.Semantic.DeepEqual(withoutProgress(previous), withoutProgress(current))
+}
+
+// conditionsChanged returns true if the conditions differ in anything but
+// their last transition time.
+func conditionsChanged(previous, current []trustapi.BundleCondition) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+
+	for _, cond := range current {
+		if !bundleHasCondition(previous, cond) {
+			return true
+		}
+	}
+
+	return false
+}
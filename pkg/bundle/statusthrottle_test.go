@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+	"github.com/cert-manager/trust-manager/test/dummy"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+func Test_statusThrottle(t *testing.T) {
+	synced := trustapi.BundleCondition{Type: trustapi.BundleConditionSynced, Status: metav1.ConditionTrue, Reason: "Synced"}
+	failed := trustapi.BundleCondition{Type: trustapi.BundleConditionSynced, Status: metav1.ConditionFalse, Reason: "SyncConfigMapTargetFailed"}
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	throttle := newStatusThrottle(clock, 10*time.Second)
+
+	status := &trustapi.BundleStatus{ObservedGeneration: 1, TargetCount: 1, Conditions: []trustapi.BundleCondition{synced}}
+	require.Zero(t, throttle.admit("a", status), "first update is applied")
+	throttle.record("a", status)
+
+	// Progress is held back until the interval has passed.
+	clock.Step(4 * time.Second)
+	assert.Equal(t, 6*time.Second, throttle.admit("a", &trustapi.BundleStatus{ObservedGeneration: 1, TargetCount: 2, Conditions: []trustapi.BundleCondition{synced}}))
+
+	// Other Bundles aren't held back.
+	assert.Zero(t, throttle.admit("b", status))
+
+	// Changes to conditions and generation are applied immediately, even if
+	// only the transition time of the condition differs.
+	synced.LastTransitionTime = metav1.NewTime(clock.Now())
+	assert.Zero(t, throttle.admit("a", &trustapi.BundleStatus{ObservedGeneration: 1, Conditions: []trustapi.BundleCondition{failed}}))
+	assert.Zero(t, throttle.admit("a", &trustapi.BundleStatus{ObservedGeneration: 2, Conditions: []trustapi.BundleCondition{synced}}))
+	assert.NotZero(t, throttle.admit("a", &trustapi.BundleStatus{ObservedGeneration: 1, Conditions: []trustapi.BundleCondition{synced}}))
+
+	// Only progress fields are held back; other changes are applied
+	// immediately, as the next reconcile may not recompute them.
+	assert.NotZero(t, throttle.admit("a", &trustapi.BundleStatus{ObservedGeneration: 1, SyncedTargetCount: 1, LastSyncTime: &metav1.Time{Time: clock.Now()}, Rollout: &trustapi.BundleRolloutStatus{Phase: trustapi.BundleRolloutPhaseProgressing}, Conditions: []trustapi.BundleCondition{synced}}))
+	assert.Zero(t, throttle.admit("a", &trustapi.BundleStatus{ObservedGeneration: 1, TargetCount: 1, LastContentChange: &trustapi.BundleContentChange{Hash: "hash"}, Conditions: []trustapi.BundleCondition{synced}}))
+
+	clock.Step(6 * time.Second)
+	assert.Zero(t, throttle.admit("a", status))
+	assert.Empty(t, throttle.applied, "expired updates are pruned")
+
+	// A nil throttle, or one with a zero interval, never holds back.
+	assert.Zero(t, (*statusThrottle)(nil).admit("a", status))
+	disabled := newStatusThrottle(clock, 0)
+	disabled.record("a", status)
+	assert.Zero(t, disabled.admit("a", status))
+}
+
+func Test_Reconcile_statusUpdates(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	// The status patch is never persisted, so every reconcile of the paused
+	// Bundle produces the same status update.
+	var statusPatches int
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(gen.Bundle(bundleName, gen.SetBundlePaused(true))).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(context.Context, client.Client, string, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+				statusPatches++
+				return nil
+			},
+		}).
+		Build()
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	log, ctx := ktesting.NewTestContext(t)
+	b := &bundle{
+		client:   fakeClient,
+		recorder: record.NewFakeRecorder(10),
+		clock:    clock,
+		Options:  Options{Log: log},
+		targetReconciler: &target.Reconciler{
+			Client: fakeClient,
+			Cache:  fakeClient,
+		},
+		statusThrottle: newStatusThrottle(clock, 10*time.Second),
+	}
+
+	reconcile := func() ctrl.Result {
+		result, err := b.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: bundleName}})
+		require.NoError(t, err)
+		return result
+	}
+
+	for range 5 {
+		reconcile()
+	}
+	assert.Equal(t, 1, statusPatches, "repeated updates within the interval are applied once")
+
+	clock.Step(3 * time.Second)
+	assert.Equal(t, 7*time.Second, reconcile().RequeueAfter, "a deferred update is requeued until it's due")
+	assert.Equal(t, 1, statusPatches)
+
+	clock.Step(7 * time.Second)
+	reconcile()
+	assert.Equal(t, 2, statusPatches, "the deferred update is applied once due")
+
+	// A new generation of the Bundle is reported immediately.
+	var bundleObj trustapi.Bundle
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: bundleName}, &bundleObj))
+	bundleObj.Generation++
+	require.NoError(t, fakeClient.Update(ctx, &bundleObj))
+	reconcile()
+	assert.Equal(t, 3, statusPatches)
+}
+
+func Test_Reconcile_statusUpdatesContentChange(t *testing.T) {
+	const (
+		bundleName     = "test-bundle"
+		trustNamespace = "trust-namespace"
+	)
+
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: trustNamespace},
+		Data:       map[string]string{"ca.crt": dummy.TestCertificate1},
+	}
+	bundleObj := gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+		b.Spec.Sources = []trustapi.BundleSource{{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "source", Key: "ca.crt"}}}
+		b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}
+	})
+
+	// The status patch is never persisted, so every reconcile produces a
+	// status update.
+	var statuses []trustapi.BundleStatus
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(bundleObj, source, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+				data, err := patch.Data(obj)
+				require.NoError(t, err)
+				var patched trustapi.Bundle
+				require.NoError(t, json.Unmarshal(data, &patched))
+				statuses = append(statuses, patched.Status)
+				return nil
+			},
+		}).
+		Build()
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	log, ctx := ktesting.NewTestContext(t)
+	b := &bundle{
+		client:         fakeClient,
+		recorder:       record.NewFakeRecorder(100),
+		clock:          clock,
+		Options:        Options{Log: log, Namespace: trustNamespace},
+		contentTracker: newContentTracker(),
+		targetReconciler: &target.Reconciler{
+			Client:                 fakeClient,
+			Cache:                  fakeClient,
+			PatchResourceOverwrite: func(context.Context, interface{}) error { return nil },
+		},
+		statusThrottle: newStatusThrottle(clock, 10*time.Second),
+	}
+
+	reconcile := func() {
+		_, err := b.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: bundleName}})
+		require.NoError(t, err)
+	}
+
+	reconcile()
+	require.Len(t, statuses, 1)
+	assert.Nil(t, statuses[0].LastContentChange)
+
+	// A change to a source within the interval is reported immediately.
+	source.Data["ca.crt"] = dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)
+	require.NoError(t, fakeClient.Update(ctx, source))
+	clock.Step(time.Second)
+	reconcile()
+	require.Len(t, statuses, 2)
+	if assert.NotNil(t, statuses[1].LastContentChange) {
+		assert.Equal(t, int32(1), statuses[1].LastContentChange.AddedCount)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/json"
+	coreapplyconfig "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1applyconfig "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+// syncSummary is a compact record of a single fan-out of a Bundle to all of its
+// targets.
+type syncSummary struct {
+	completedAt time.Time
+	duration    time.Duration
+
+	namespacesTotal   int
+	namespacesChanged int
+	namespacesFailed  int
+
+	// digest is the hash of the distributed bundle and the additional formats
+	// of each of its targets.
+	digest string
+}
+
+// namespaceSyncResult tracks the outcome of syncing all targets in a single Namespace.
+type namespaceSyncResult struct {
+	changed bool
+	failed  bool
+}
+
+func newSyncSummary(start, end time.Time, digest string, namespaces map[string]namespaceSyncResult) syncSummary {
+	summary := syncSummary{
+		completedAt:     end,
+		duration:        end.Sub(start),
+		namespacesTotal: len(namespaces),
+		digest:          digest,
+	}
+
+	for _, result := range namespaces {
+		if result.changed {
+			summary.namespacesChanged++
+		}
+		if result.failed {
+			summary.namespacesFailed++
+		}
+	}
+
+	return summary
+}
+
+// String returns the summary as a single line, suitable for an Event message.
+func (s syncSummary) String() string {
+	return fmt.Sprintf("Synced bundle %s to %d namespaces (%d changed, %d failed) in %s",
+		s.digest, s.namespacesTotal, s.namespacesChanged, s.namespacesFailed, s.duration)
+}
+
+// data returns the summary as ConfigMap data.
+func (s syncSummary) data() map[string]string {
+	return map[string]string{
+		"completedAt":       s.completedAt.UTC().Format(time.RFC3339),
+		"duration":          s.duration.String(),
+		"namespacesTotal":   strconv.Itoa(s.namespacesTotal),
+		"namespacesChanged": strconv.Itoa(s.namespacesChanged),
+		"namespacesFailed":  strconv.Itoa(s.namespacesFailed),
+		"digest":            s.digest,
+	}
+}
+
+// syncSummaryConfigMapName returns the name of the ConfigMap in the trust
+// Namespace which holds the last sync summary of the named Bundle. The name
+// is prefixed rather than suffixed, so that it's unlikely to be the name of
+// the target of another Bundle, which is named after its Bundle.
+func syncSummaryConfigMapName(bundleName string) string {
+	return "trust-manager-sync-summary-" + bundleName
+}
+
+// publishSyncSummary records the summary as an Event on the Bundle and, if
+// enabled, in a ConfigMap in the trust Namespace.
+func (b *bundle) publishSyncSummary(ctx context.Context, bundle *trustapi.Bundle, summary syncSummary) error {
+	eventType := corev1.EventTypeNormal
+	if summary.namespacesFailed > 0 {
+		eventType = corev1.EventTypeWarning
+	}
+	b.recorder.Event(bundle, eventType, "SyncSummary", summary.String())
+
+	if !b.Options.SyncSummaryConfigMaps {
+		return nil
+	}
+
+	// A Bundle may still be named like the summary of another, and its
+	// target in the trust Namespace is then left alone.
+	name := syncSummaryConfigMapName(bundle.Name)
+	var existing corev1.ConfigMap
+	if err := b.client.Get(ctx, client.ObjectKey{Namespace: b.Namespace, Name: name}, &existing); err == nil {
+		if owner, ok := existing.Labels[trustapi.BundleLabelKey]; ok {
+			return fmt.Errorf("sync summary ConfigMap %s/%s is a target of Bundle %q", b.Namespace, name, owner)
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get sync summary ConfigMap %s/%s: %w", b.Namespace, name, err)
+	}
+
+	// The summary ConfigMap is deliberately not labelled as a target, so it's
+	// never picked up by the target cache.
+	patch := coreapplyconfig.ConfigMap(name, b.Namespace).
+		WithOwnerReferences(
+			metav1applyconfig.OwnerReference().
+				WithAPIVersion(trustapi.SchemeGroupVersion.String()).
+				WithKind(trustapi.BundleKind).
+				WithName(bundle.GetName()).
+				WithUID(bundle.GetUID()),
+		).
+		WithData(summary.data())
+
+	encodedPatch, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	obj := &corev1.ConfigMap{}
+	obj.SetName(*patch.Name)
+	obj.SetNamespace(*patch.Namespace)
+
+	if err := b.client.Patch(ctx, obj, ssa_client.ApplyPatch{Patch: encodedPatch}, ssa_client.SyncSummaryFieldManager, client.ForceOwnership); err != nil {
+		return fmt.Errorf("failed to patch sync summary ConfigMap %s/%s: %w", obj.Namespace, obj.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/ssa_client"
+)
+
+func Test_newSyncSummary(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(1500 * time.Millisecond)
+
+	summary := newSyncSummary(start, end, "abc123", map[string]namespaceSyncResult{
+		"ns-1": {changed: true},
+		"ns-2": {},
+		"ns-3": {failed: true},
+		"ns-4": {changed: true, failed: true},
+	})
+
+	assert.Equal(t, "Synced bundle abc123 to 4 namespaces (2 changed, 2 failed) in 1.5s", summary.String())
+	assert.Equal(t, map[string]string{
+		"completedAt":       "2026-01-02T03:04:06Z",
+		"duration":          "1.5s",
+		"namespacesTotal":   "4",
+		"namespacesChanged": "2",
+		"namespacesFailed":  "2",
+		"digest":            "abc123",
+	}, summary.data())
+}
+
+func Test_publishSyncSummary(t *testing.T) {
+	const trustNamespace = "trust-namespace"
+
+	summary := newSyncSummary(time.Now(), time.Now(), "abc123", map[string]namespaceSyncResult{"ns-1": {changed: true}})
+
+	tests := map[string]struct {
+		existing []client.Object
+
+		expErr     bool
+		expApplied []string
+	}{
+		"the summary is applied to a prefixed ConfigMap with its own field manager": {
+			expApplied: []string{"trust-manager-sync-summary-foo"},
+		},
+		"a target of another Bundle named like the summary is left alone": {
+			existing: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "trust-manager-sync-summary-foo",
+					Namespace: trustNamespace,
+					Labels:    map[string]string{trustapi.BundleLabelKey: "trust-manager-sync-summary-foo"},
+				},
+			}},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var applied []string
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(test.existing...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, opts ...client.PatchOption) error {
+						assert.Equal(t, trustNamespace, obj.GetNamespace())
+						assert.Contains(t, opts, ssa_client.SyncSummaryFieldManager)
+						applied = append(applied, obj.GetName())
+						return nil
+					},
+				}).
+				Build()
+
+			log, ctx := ktesting.NewTestContext(t)
+			b := &bundle{
+				client:   fakeClient,
+				recorder: record.NewFakeRecorder(1),
+				Options:  Options{Log: log, Namespace: trustNamespace, SyncSummaryConfigMaps: true},
+			}
+
+			err := b.publishSyncSummary(ctx, &trustapi.Bundle{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}, summary)
+			if test.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expApplied, applied)
+		})
+	}
+}
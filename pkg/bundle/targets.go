@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+// resolvedTarget is a single target of a Bundle, along with the data written
+// to it.
+type resolvedTarget struct {
+	// bundle is a copy of the Bundle with spec.target set to the target.
+	bundle *trustapi.Bundle
+
+	data              target.Data
+	namespaceSelector labels.Selector
+
+	// keyOverrides are copies of the target with the keys of its key
+	// overrides, in order.
+	keyOverrides []keyOverride
+}
+
+// targetFormats returns the additional formats of each target, which may be
+// set by spec.targets and by the configMap and secret of a target, rather
+// than only by spec.target.
+func targetFormats(targets []*resolvedTarget) []*trustapi.AdditionalFormats {
+	formats := make([]*trustapi.AdditionalFormats, 0, len(targets))
+	for _, t := range targets {
+		formats = append(formats, t.bundle.Spec.Target.AdditionalFormats)
+	}
+	return formats
+}
+
+// keyOverride is a copy of a target with an overridden PEM bundle key, which
+// is written to the Namespaces matching its selector.
+type keyOverride struct {
+	namespaceSelector labels.Selector
+	target            *resolvedTarget
+}
+
+// resolveKeyOverrides prepares a copy of the target for each of its key
+// overrides.
+func (t *resolvedTarget) resolveKeyOverrides() error {
+	for _, override := range t.bundle.Spec.Target.KeyOverrides {
+		selector, err := metav1.LabelSelectorAsSelector(&override.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("failed to parse key override namespace selector: %w", err)
+		}
+
+		bundle := t.bundle.DeepCopy()
+		if bundle.Spec.Target.ConfigMap != nil {
+			bundle.Spec.Target.ConfigMap.Key = override.Key
+		}
+		if bundle.Spec.Target.Secret != nil {
+			bundle.Spec.Target.Secret.Key = override.Key
+		}
+
+		t.keyOverrides = append(t.keyOverrides, keyOverride{
+			namespaceSelector: selector,
+			target:            &resolvedTarget{bundle: bundle, data: t.data, namespaceSelector: t.namespaceSelector},
+		})
+	}
+	return nil
+}
+
+// forNamespace returns the target as written to the Namespace: with the key of
+// the first key override selecting the Namespace, if any.
+func (t *resolvedTarget) forNamespace(namespace *corev1.Namespace) *resolvedTarget {
+	for _, override := range t.keyOverrides {
+		if override.namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+			return override.target
+		}
+	}
+	return t
+}
+
+// targetBundles returns a copy of the Bundle for each of its targets, with
+// spec.target set to that target and spec.targets cleared. The target
+// reconciler, and the checks which apply to a single target, only consider
+// spec.target.
+func targetBundles(bundle *trustapi.Bundle) []*trustapi.Bundle {
+	var targets []trustapi.BundleTarget
+	for _, t := range bundle.Spec.AllTargets() {
+		targets = append(targets, splitTarget(t)...)
+	}
+
+	bundles := make([]*trustapi.Bundle, 0, len(targets))
+	for _, t := range targets {
+		targetBundle := bundle.DeepCopy()
+		targetBundle.Spec.Target = t
+		targetBundle.Spec.Targets = nil
+		bundles = append(bundles, targetBundle)
+	}
+	return bundles
+}
+
+// splitTarget splits a target which overrides the additional formats of its
+// ConfigMap or Secret into a target for each kind, so that each is written
+// with its own formats. Other targets are returned unchanged.
+func splitTarget(t trustapi.BundleTarget) []trustapi.BundleTarget {
+	if (t.ConfigMap == nil || t.ConfigMap.AdditionalFormats == nil) && (t.Secret == nil || t.Secret.AdditionalFormats == nil) {
+		return []trustapi.BundleTarget{t}
+	}
+
+	var targets []trustapi.BundleTarget
+	if t.ConfigMap != nil {
+		configMapTarget := *t.DeepCopy()
+		configMapTarget.Secret = nil
+		configMapTarget.AdditionalFormats = t.ConfigMapFormats()
+		configMapTarget.ConfigMap.AdditionalFormats = nil
+		targets = append(targets, withoutEmptyFormats(configMapTarget))
+	}
+	if t.Secret != nil {
+		secretTarget := *t.DeepCopy()
+		secretTarget.ConfigMap = nil
+		secretTarget.AdditionalFormats = t.SecretFormats()
+		secretTarget.Secret.AdditionalFormats = nil
+		targets = append(targets, withoutEmptyFormats(secretTarget))
+	}
+	return targets
+}
+
+// withoutEmptyFormats clears the additional formats of a target, along with
+// the separate target they would be written to, if no format is requested.
+func withoutEmptyFormats(t trustapi.BundleTarget) trustapi.BundleTarget {
+	if formats := t.AdditionalFormats; formats != nil && formats.JKS == nil && formats.PKCS12 == nil && formats.SPIFFE == nil && formats.Certdata == nil {
+		t.AdditionalFormats = nil
+	}
+	if t.AdditionalFormats == nil {
+		t.AdditionalFormatsTarget = nil
+	}
+	return t
+}
+
+// targetFor returns the target of the Bundle which writes targets of the given
+// kind, or nil if there is none.
+func targetFor(bundle *trustapi.Bundle, kind target.Kind) *trustapi.BundleTarget {
+	for _, t := range bundle.Spec.AllTargets() {
+		if (kind == target.KindConfigMap && t.ConfigMap != nil) || (kind == target.KindSecret && t.Secret != nil) {
+			return &t
+		}
+	}
+	return nil
+}
+
+// targetOwnership returns the ownership of the targets of the given kind: the
+// ownership of the Bundle target which writes them, or of spec.target if none
+// does.
+func targetOwnership(bundle *trustapi.Bundle, kind target.Kind) trustapi.TargetOwnership {
+	if t := targetFor(bundle, kind); t != nil {
+		return t.Ownership
+	}
+	return bundle.Spec.Target.Ownership
+}
+
+// targetControlledBy returns true if the target object of the given kind is
+// controlled by the Bundle: owned by it or, if its targets are unowned,
+// labelled with it.
+func targetControlledBy(obj metav1.Object, bundle *trustapi.Bundle, kind target.Kind) bool {
+	if targetOwnership(bundle, kind) == trustapi.TargetOwnershipUnowned {
+		return obj.GetLabels()[trustapi.BundleLabelKey] == bundle.Name
+	}
+	return metav1.IsControlledBy(obj, bundle)
+}
+
+// anyTarget returns true if any target of the Bundle matches the predicate.
+func anyTarget(bundle *trustapi.Bundle, predicate func(trustapi.BundleTarget) bool) bool {
+	for _, t := range bundle.Spec.AllTargets() {
+		if predicate(t) {
+			return true
+		}
+	}
+	return false
+}
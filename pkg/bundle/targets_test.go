@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
+)
+
+func Test_splitTarget(t *testing.T) {
+	jks := &trustapi.AdditionalFormats{JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.jks"}, Password: ptr.To(trustapi.DefaultJKSPassword)}}
+	pkcs12 := &trustapi.AdditionalFormats{PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: "trust.p12"}, Password: ptr.To(trustapi.DefaultPKCS12Password)}}
+
+	tests := map[string]struct {
+		target   trustapi.BundleTarget
+		expSplit []trustapi.BundleTarget
+	}{
+		"a target without overridden formats is not split": {
+			target: trustapi.BundleTarget{
+				ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				AdditionalFormats: jks,
+			},
+			expSplit: []trustapi.BundleTarget{{
+				ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				AdditionalFormats: jks,
+			}},
+		},
+		"a Secret with its own formats is split from the ConfigMap": {
+			target: trustapi.BundleTarget{
+				ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}, AdditionalFormats: pkcs12},
+				AdditionalFormats: jks,
+			},
+			expSplit: []trustapi.BundleTarget{
+				{
+					ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					AdditionalFormats: jks,
+				},
+				{
+					Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}},
+					AdditionalFormats: pkcs12,
+				},
+			},
+		},
+		"empty overridden formats write only the PEM bundle": {
+			target: trustapi.BundleTarget{
+				ConfigMap:               &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}, AdditionalFormats: &trustapi.AdditionalFormats{}},
+				Secret:                  &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				AdditionalFormats:       jks,
+				AdditionalFormatsTarget: &trustapi.AdditionalFormatsTarget{Name: "formats"},
+			},
+			expSplit: []trustapi.BundleTarget{
+				{
+					ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+				},
+				{
+					Secret:                  &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					AdditionalFormats:       jks,
+					AdditionalFormatsTarget: &trustapi.AdditionalFormatsTarget{Name: "formats"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expSplit, splitTarget(test.target))
+		})
+	}
+}
+
+func Test_targetFormats(t *testing.T) {
+	resolve := func(bundle *trustapi.Bundle) []*resolvedTarget {
+		var targets []*resolvedTarget
+		for _, targetBundle := range targetBundles(bundle) {
+			targets = append(targets, &resolvedTarget{bundle: targetBundle})
+		}
+		return targets
+	}
+	bundleWithSecretPassword := func(password string) *trustapi.Bundle {
+		return &trustapi.Bundle{Spec: trustapi.BundleSpec{Targets: []trustapi.BundleTarget{
+			{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+			{Secret: &trustapi.SecretTarget{
+				KeySelector: trustapi.KeySelector{Key: "trust.pem"},
+				AdditionalFormats: &trustapi.AdditionalFormats{
+					PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: "trust.p12"}, Password: ptr.To(password)},
+				},
+			}},
+		}}}
+	}
+
+	data := []byte("data")
+	hash := target.TrustBundleHash(data, targetFormats(resolve(bundleWithSecretPassword("password")))...)
+
+	assert.Equal(t, hash, target.TrustBundleHash(data, targetFormats(resolve(bundleWithSecretPassword("password")))...))
+	assert.NotEqual(t, hash, target.TrustBundleHash(data, targetFormats(resolve(bundleWithSecretPassword("changed")))...),
+		"the hash must change when only the formats of a target of spec.targets change")
+	assert.NotEqual(t, hash, target.TrustBundleHash(data), "the hash must include the formats of spec.targets")
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"sync/atomic"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// Tunables holds the TunableOptions of a running Bundle controller, such as
+// those loaded from a config file which is reloaded. All Bundles are
+// reconciled when they change, so that the new options take effect.
+type Tunables struct {
+	current atomic.Pointer[TunableOptions]
+
+	// changed receives an event whenever the options change. It is buffered,
+	// so that changes made while Bundles are being enqueued are coalesced.
+	changed chan event.GenericEvent
+}
+
+// NewTunables returns Tunables holding the given options.
+func NewTunables(opts TunableOptions) *Tunables {
+	t := &Tunables{changed: make(chan event.GenericEvent, 1)}
+	t.current.Store(&opts)
+	return t
+}
+
+// Load returns the current options.
+func (t *Tunables) Load() TunableOptions {
+	return *t.current.Load()
+}
+
+// Store replaces the current options, and reconciles all Bundles.
+func (t *Tunables) Store(opts TunableOptions) {
+	t.current.Store(&opts)
+
+	select {
+	case t.changed <- event.GenericEvent{Object: &trustapi.Bundle{}}:
+	default:
+		// A reconcile of all Bundles is already pending.
+	}
+}
+
+// tunables returns the TunableOptions currently in effect.
+func (b *bundle) tunables() TunableOptions {
+	if b.Options.Tunables != nil {
+		return b.Options.Tunables.Load()
+	}
+	return b.Options.TunableOptions
+}
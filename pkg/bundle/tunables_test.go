@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tunables(t *testing.T) {
+	b := &bundle{Options: Options{TunableOptions: TunableOptions{TargetSyncConcurrency: 2}}}
+	assert.Equal(t, 2, b.tunables().TargetSyncConcurrency)
+
+	b.Options.Tunables = NewTunables(b.Options.TunableOptions)
+	b.Options.Tunables.Store(TunableOptions{TargetSyncConcurrency: 4})
+	b.Options.Tunables.Store(TunableOptions{TargetSyncConcurrency: 8, FilterExpiredCerts: true})
+	assert.Equal(t, TunableOptions{TargetSyncConcurrency: 8, FilterExpiredCerts: true}, b.tunables())
+
+	// Changes made before Bundles are enqueued are coalesced.
+	assert.Len(t, b.Options.Tunables.changed, 1)
+}
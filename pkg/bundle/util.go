@@ -17,9 +17,12 @@ limitations under the License.
 package bundle
 
 import (
+	"sync"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
 )
 
 // bundleHasCondition returns true if the bundle has an exact matching condition.
@@ -43,15 +46,52 @@ func bundleHasCondition(
 	return false
 }
 
+// bundleStatusObserved returns true if the status of the bundle reports its
+// current generation as observed, and mirrors its Synced condition to the
+// Ready condition. It doesn't for statuses written by older versions of
+// trust-manager.
+func bundleStatusObserved(bundle *trustapi.Bundle) bool {
+	if bundle.Status.ObservedGeneration != bundle.Generation {
+		return false
+	}
+
+	for _, cond := range bundle.Status.Conditions {
+		if cond.Type == trustapi.BundleConditionSynced {
+			cond.Type = trustapi.BundleConditionReady
+			return bundleHasCondition(bundle.Status.Conditions, cond)
+		}
+	}
+	return true
+}
+
 // setBundleCondition updates the bundle with the given condition.
 // Will overwrite any existing condition of the same type.
 // LastTransitionTime will not be updated if an existing condition of the same
-// Type and Status already exists.
+// Type and Status already exists. A Synced condition is mirrored to the Ready
+// condition.
 func (b *bundle) setBundleCondition(
 	existingConditions []trustapi.BundleCondition,
 	patchConditions *[]trustapi.BundleCondition,
 	newCondition trustapi.BundleCondition,
 ) trustapi.BundleCondition { // nolint:unparam
+	newCondition = b.putBundleCondition(existingConditions, patchConditions, newCondition)
+
+	if newCondition.Type == trustapi.BundleConditionSynced {
+		readyCondition := newCondition
+		readyCondition.Type = trustapi.BundleConditionReady
+		b.putBundleCondition(existingConditions, patchConditions, readyCondition)
+	}
+
+	return newCondition
+}
+
+// putBundleCondition adds the given condition to the patch conditions, or
+// overwrites the condition of the same type in them.
+func (b *bundle) putBundleCondition(
+	existingConditions []trustapi.BundleCondition,
+	patchConditions *[]trustapi.BundleCondition,
+	newCondition trustapi.BundleCondition,
+) trustapi.BundleCondition {
 	newCondition.LastTransitionTime = metav1.Time{Time: b.clock.Now()}
 
 	// Reset the LastTransitionTime if the status hasn't changed
@@ -116,3 +156,23 @@ func (b *bundle) setBundleStatusDefaultCAVersion(
 
 	return false
 }
+
+// syncTargets calls fn for each of the targets, with at most concurrency calls
+// running at once, and returns once all calls have returned.
+func syncTargets(concurrency int, targets map[target.Resource]bool, fn func(t target.Resource, shouldExist bool)) {
+	sem := make(chan struct{}, max(concurrency, 1))
+
+	var wg sync.WaitGroup
+	for t, shouldExist := range targets {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(t, shouldExist)
+		}()
+	}
+	wg.Wait()
+}
@@ -17,15 +17,19 @@ limitations under the License.
 package bundle
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/bundle/internal/target"
 )
 
 func Test_bundleHasCondition(t *testing.T) {
@@ -311,3 +315,40 @@ func Test_setBundleStatusDefaultCAVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_syncTargets(t *testing.T) {
+	targets := map[target.Resource]bool{}
+	for i := range 20 {
+		targets[target.Resource{Kind: target.KindConfigMap, NamespacedName: types.NamespacedName{Name: "bundle", Namespace: fmt.Sprintf("ns-%d", i)}}] = i%2 == 0
+	}
+
+	for _, concurrency := range []int{0, 1, 4} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			var (
+				mu               sync.Mutex
+				running, maxSeen int
+				seen             = map[target.Resource]bool{}
+			)
+			syncTargets(concurrency, targets, func(r target.Resource, shouldExist bool) {
+				mu.Lock()
+				running++
+				maxSeen = max(maxSeen, running)
+				seen[r] = shouldExist
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+			})
+
+			if !apiequality.Semantic.DeepEqual(targets, seen) {
+				t.Errorf("expected every target to be synced once, got %v", seen)
+			}
+			if maxSeen > max(concurrency, 1) {
+				t.Errorf("expected at most %d concurrent syncs, got %d", max(concurrency, 1), maxSeen)
+			}
+		})
+	}
+}
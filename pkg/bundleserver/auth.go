@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundleserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tokenCacheTTL is how long a successfully authenticated token is trusted for
+// before it is reviewed again.
+const tokenCacheTTL = time.Minute
+
+// authenticator authenticates the bearer token of a request.
+type authenticator interface {
+	Authenticate(ctx context.Context, token string) error
+}
+
+// tokenReviewAuthenticator authenticates tokens by creating a TokenReview.
+// Successful reviews are cached briefly, so that consumers polling for changes
+// don't cause a TokenReview for every request.
+type tokenReviewAuthenticator struct {
+	client client.Client
+	clock  clock.PassiveClock
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]time.Time
+}
+
+func newTokenReviewAuthenticator(c client.Client, clock clock.PassiveClock) *tokenReviewAuthenticator {
+	return &tokenReviewAuthenticator{
+		client: c,
+		clock:  clock,
+		cache:  make(map[[sha256.Size]byte]time.Time),
+	}
+}
+
+// Authenticate returns an error if the token is not authenticated by the API
+// server.
+func (a *tokenReviewAuthenticator) Authenticate(ctx context.Context, token string) error {
+	key := sha256.Sum256([]byte(token))
+	now := a.clock.Now()
+
+	a.mu.Lock()
+	for k, expiry := range a.cache {
+		if !now.Before(expiry) {
+			delete(a.cache, k)
+		}
+	}
+	_, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to create TokenReview: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return fmt.Errorf("token not authenticated: %s", review.Status.Error)
+		}
+		return errors.New("token not authenticated")
+	}
+
+	a.mu.Lock()
+	a.cache[key] = now.Add(tokenCacheTTL)
+	a.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundleserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func Test_tokenReviewAuthenticator(t *testing.T) {
+	reviews := 0
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				review := obj.(*authenticationv1.TokenReview)
+				reviews++
+				review.Status.Authenticated = review.Spec.Token == "valid"
+				if !review.Status.Authenticated {
+					review.Status.Error = "invalid bearer token"
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	authenticator := newTokenReviewAuthenticator(fakeClient, clock)
+	ctx := context.Background()
+
+	assert.NoError(t, authenticator.Authenticate(ctx, "valid"))
+	assert.NoError(t, authenticator.Authenticate(ctx, "valid"))
+	assert.Equal(t, 1, reviews, "expected a successful review to be cached")
+
+	assert.EqualError(t, authenticator.Authenticate(ctx, "invalid"), "token not authenticated: invalid bearer token")
+	assert.EqualError(t, authenticator.Authenticate(ctx, "invalid"), "token not authenticated: invalid bearer token")
+	assert.Equal(t, 3, reviews, "expected a failed review not to be cached")
+
+	clock.Step(tokenCacheTTL)
+	assert.NoError(t, authenticator.Authenticate(ctx, "valid"))
+	assert.Equal(t, 4, reviews, "expected an expired review to be repeated")
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundleserver serves the resolved content of Bundles over HTTP(S), so
+// that consumers such as a CSI driver, or workloads outside of the cluster, can
+// fetch trust bundles directly instead of from target ConfigMaps and Secrets.
+package bundleserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	trustbundle "github.com/cert-manager/trust-manager/pkg/bundle"
+	"github.com/cert-manager/trust-manager/pkg/hub"
+)
+
+// bundlesPath is the path prefix Bundles are served on, followed by the
+// Bundle name and an optional format extension.
+const bundlesPath = "/bundles/"
+
+// formats maps the extensions which may be requested to the format served,
+// and its content type.
+var formats = map[string]struct {
+	format      trustbundle.Format
+	contentType string
+}{
+	".pem": {trustbundle.FormatPEM, "application/x-pem-file"},
+	".jks": {trustbundle.FormatJKS, "application/x-java-keystore"},
+	".p12": {trustbundle.FormatPKCS12, "application/x-pkcs12"},
+}
+
+// Renderer builds a Bundle's trust bundle in the given format from its current
+// sources.
+type Renderer interface {
+	RenderFormat(ctx context.Context, bundle *trustapi.Bundle, format trustbundle.Format) ([]byte, error)
+}
+
+// Options hold options for the bundle server.
+type Options struct {
+	// Log is the bundle server logger.
+	Log logr.Logger
+
+	// Address is the address the bundle server listens on.
+	Address string
+
+	// Renderer builds the content served for each Bundle.
+	Renderer Renderer
+
+	// CertDir, if set, is the directory holding the 'tls.crt' and 'tls.key'
+	// which the server uses to serve HTTPS. HTTP is served if empty.
+	CertDir string
+
+	// Authenticate requires requests to carry a bearer token, which is
+	// authenticated using a Kubernetes TokenReview.
+	Authenticate bool
+	// Exports serves the Bundles annotated for export to trust-manager agents
+	// in spoke clusters at "/hub/bundles".
+	Exports bool
+}
+
+// server is a manager.Runnable which serves the current content of every
+// Bundle at "/bundles/<name>[.pem|.jks|.p12]".
+type server struct {
+	log           logr.Logger
+	address       string
+	certDir       string
+	client        client.Reader
+	cache         cache.Informers
+	renderer      Renderer
+	authenticator authenticator
+	exports       bool
+}
+
+// Register adds the bundle server to the controller-runtime Manager.
+func Register(mgr manager.Manager, opts Options) error {
+	s := &server{
+		log:      opts.Log,
+		address:  opts.Address,
+		certDir:  opts.CertDir,
+		client:   mgr.GetClient(),
+		cache:    mgr.GetCache(),
+		renderer: opts.Renderer,
+		exports:  opts.Exports,
+	}
+
+	if opts.Authenticate {
+		s.authenticator = newTokenReviewAuthenticator(mgr.GetClient(), clock.RealClock{})
+	}
+
+	return mgr.Add(s)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica
+// serves Bundles.
+func (s *server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *server) Start(ctx context.Context) error {
+	// Informers are otherwise only started by the Bundle controller, which
+	// only runs on the leader.
+	for _, obj := range []client.Object{&trustapi.Bundle{}, &corev1.ConfigMap{}, &corev1.Secret{}} {
+		if _, err := s.cache.GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("failed to start informer for bundle server: %w", err)
+		}
+	}
+
+	srv := &http.Server{
+		Addr:              s.address,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			s.log.Error(err, "failed to shut down bundle server")
+		}
+	}()
+
+	var err error
+	if s.certDir == "" {
+		s.log.Info("serving bundles over HTTP", "address", s.address)
+		err = srv.ListenAndServe()
+	} else {
+		watcher, werr := certwatcher.New(filepath.Join(s.certDir, "tls.crt"), filepath.Join(s.certDir, "tls.key"))
+		if werr != nil {
+			return fmt.Errorf("failed to load bundle server certificate: %w", werr)
+		}
+		go func() {
+			if err := watcher.Start(ctx); err != nil {
+				s.log.Error(err, "bundle server certificate watcher failed")
+			}
+		}()
+
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: watcher.GetCertificate,
+		}
+
+		s.log.Info("serving bundles over HTTPS", "address", s.address)
+		err = srv.ListenAndServeTLS("", "")
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("bundle server failed: %w", err)
+	}
+
+	return nil
+}
+
+// handler returns the handler of every path the server serves.
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(bundlesPath, s)
+	if s.exports {
+		mux.HandleFunc(hub.ExportsPath, s.serveExports)
+	}
+	return mux
+}
+
+// ServeHTTP responds with the current trust bundle of the named Bundle, in
+// the format given by the extension of the path, or PEM if there is none. A
+// weak ETag is returned, so that consumers polling for changes can make
+// conditional requests without the truststore being encoded. The ETag is
+// derived from the format and the certificates served: JKS encodings are
+// deterministic, but PKCS#12 encodings are only deterministic if the Bundle's
+// PKCS#12 target sets deterministic or has no password, so the bytes of a
+// PKCS#12 response may differ between requests with the same ETag.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowed(w, r) {
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, bundlesPath)
+	format := formats[".pem"]
+	if f, ok := formats[path.Ext(name)]; ok {
+		name = strings.TrimSuffix(name, path.Ext(name))
+		format = f
+	}
+	if name == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var bundle trustapi.Bundle
+	if err := s.client.Get(r.Context(), client.ObjectKey{Name: name}, &bundle); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		}
+		s.log.Error(err, "failed to get bundle", "bundle", name)
+		http.Error(w, "failed to get Bundle", http.StatusInternalServerError)
+		return
+	}
+
+	pem, err := s.renderer.RenderFormat(r.Context(), &bundle, trustbundle.FormatPEM)
+	if err != nil {
+		s.log.V(2).Info("failed to render bundle", "bundle", name, "error", err)
+		http.Error(w, fmt.Sprintf("failed to render Bundle: %s", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	hash := sha256.Sum256(append([]byte(format.format+"\x00"), pem...))
+	etag := `W/"` + hex.EncodeToString(hash[:]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	content := pem
+	if format.format != trustbundle.FormatPEM {
+		content, err = s.renderer.RenderFormat(r.Context(), &bundle, format.format)
+		if err != nil {
+			s.log.V(2).Info("failed to render bundle", "bundle", name, "format", format.format, "error", err)
+			http.Error(w, fmt.Sprintf("failed to render Bundle: %s", err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(content)
+	}
+}
+
+// serveExports responds with the Bundles annotated for export to agents in
+// spoke clusters, and their current trust bundles. If any exported Bundle
+// can't be rendered, no Bundles are served, so that agents keep their current
+// Bundles rather than deleting those missing from the response.
+func (s *server) serveExports(w http.ResponseWriter, r *http.Request) {
+	if !s.allowed(w, r) {
+		return
+	}
+
+	var bundles trustapi.BundleList
+	if err := s.client.List(r.Context(), &bundles); err != nil {
+		s.log.Error(err, "failed to list bundles")
+		http.Error(w, "failed to list Bundles", http.StatusInternalServerError)
+		return
+	}
+
+	exports := hub.Exports{Bundles: []hub.ExportedBundle{}}
+	for i := range bundles.Items {
+		bundle := &bundles.Items[i]
+		if bundle.Annotations[trustapi.BundleHubExportAnnotationKey] != "true" {
+			continue
+		}
+
+		pem, err := s.renderer.RenderFormat(r.Context(), bundle, trustbundle.FormatPEM)
+		if err != nil {
+			s.log.V(2).Info("failed to render exported bundle", "bundle", bundle.Name, "error", err)
+			http.Error(w, fmt.Sprintf("failed to render Bundle %q: %s", bundle.Name, err), http.StatusServiceUnavailable)
+			return
+		}
+
+		exports.Bundles = append(exports.Bundles, hub.ExportedBundle{
+			Name:    bundle.Name,
+			Bundle:  string(pem),
+			Target:  bundle.Spec.Target,
+			Targets: bundle.Spec.Targets,
+		})
+	}
+
+	slices.SortFunc(exports.Bundles, func(a, b hub.ExportedBundle) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_ = json.NewEncoder(w).Encode(exports)
+	}
+}
+
+// allowed writes an error response and returns false if the request isn't a
+// GET or HEAD, or doesn't carry an authenticated bearer token when
+// authentication is required.
+func (s *server) allowed(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "only GET and HEAD are supported", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	if s.authenticator == nil {
+		return true
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+		return false
+	}
+
+	if err := s.authenticator.Authenticate(r.Context(), token); err != nil {
+		s.log.V(2).Info("rejected bundle request", "error", err)
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	return true
+}
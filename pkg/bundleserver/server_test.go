@@ -0,0 +1,359 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundleserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	trustbundle "github.com/cert-manager/trust-manager/pkg/bundle"
+	"github.com/cert-manager/trust-manager/pkg/hub"
+	"github.com/cert-manager/trust-manager/test/dummy"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+type fakeRenderer func(bundle *trustapi.Bundle, format trustbundle.Format) ([]byte, error)
+
+func (f fakeRenderer) RenderFormat(_ context.Context, bundle *trustapi.Bundle, format trustbundle.Format) ([]byte, error) {
+	return f(bundle, format)
+}
+
+type fakeAuthenticator map[string]bool
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, token string) error {
+	if !f[token] {
+		return errors.New("token not authenticated")
+	}
+	return nil
+}
+
+func Test_server(t *testing.T) {
+	renderer := fakeRenderer(func(bundle *trustapi.Bundle, format trustbundle.Format) ([]byte, error) {
+		if bundle.Name == "broken" {
+			return nil, errors.New("source not found")
+		}
+		if format == trustbundle.FormatPEM {
+			return []byte(dummy.TestCertificate1), nil
+		}
+		return []byte("truststore " + format), nil
+	})
+
+	tests := map[string]struct {
+		method        string
+		path          string
+		conditional   bool
+		authenticate  bool
+		authorization string
+
+		expStatus      int
+		expContentType string
+		expBody        string
+	}{
+		"a Bundle which exists is served": {
+			method:         http.MethodGet,
+			path:           "/bundles/test-bundle",
+			expStatus:      http.StatusOK,
+			expContentType: "application/x-pem-file",
+			expBody:        dummy.TestCertificate1,
+		},
+		"a Bundle is served as PEM with an extension": {
+			method:         http.MethodGet,
+			path:           "/bundles/test-bundle.pem",
+			expStatus:      http.StatusOK,
+			expContentType: "application/x-pem-file",
+			expBody:        dummy.TestCertificate1,
+		},
+		"a Bundle is served as JKS": {
+			method:         http.MethodGet,
+			path:           "/bundles/test-bundle.jks",
+			expStatus:      http.StatusOK,
+			expContentType: "application/x-java-keystore",
+			expBody:        "truststore jks",
+		},
+		"a Bundle is served as PKCS#12": {
+			method:         http.MethodGet,
+			path:           "/bundles/test-bundle.p12",
+			expStatus:      http.StatusOK,
+			expContentType: "application/x-pkcs12",
+			expBody:        "truststore p12",
+		},
+		"a Bundle is served with a valid token": {
+			method:         http.MethodGet,
+			path:           "/bundles/test-bundle",
+			authenticate:   true,
+			authorization:  "Bearer valid",
+			expStatus:      http.StatusOK,
+			expContentType: "application/x-pem-file",
+			expBody:        dummy.TestCertificate1,
+		},
+		"a request without a token is unauthorized": {
+			method:       http.MethodGet,
+			path:         "/bundles/test-bundle",
+			authenticate: true,
+			expStatus:    http.StatusUnauthorized,
+			expBody:      "a bearer token is required\n",
+		},
+		"a request with an invalid token is unauthorized": {
+			method:        http.MethodGet,
+			path:          "/bundles/test-bundle",
+			authenticate:  true,
+			authorization: "Bearer invalid",
+			expStatus:     http.StatusUnauthorized,
+			expBody:       "unauthorized\n",
+		},
+		"a HEAD request returns no body": {
+			method:         http.MethodHead,
+			path:           "/bundles/test-bundle",
+			expStatus:      http.StatusOK,
+			expContentType: "application/x-pem-file",
+		},
+		"a request with a matching ETag is not modified": {
+			method:      http.MethodGet,
+			path:        "/bundles/test-bundle",
+			conditional: true,
+			expStatus:   http.StatusNotModified,
+		},
+		"a Bundle which doesn't exist is not found": {
+			method:    http.MethodGet,
+			path:      "/bundles/missing",
+			expStatus: http.StatusNotFound,
+			expBody:   "404 page not found\n",
+		},
+		"a Bundle which can't be rendered is unavailable": {
+			method:    http.MethodGet,
+			path:      "/bundles/broken",
+			expStatus: http.StatusServiceUnavailable,
+			expBody:   "failed to render Bundle: source not found\n",
+		},
+		"a POST is not allowed": {
+			method:    http.MethodPost,
+			path:      "/bundles/test-bundle",
+			expStatus: http.StatusMethodNotAllowed,
+			expBody:   "only GET and HEAD are supported\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(gen.Bundle("test-bundle"), gen.Bundle("broken")).
+				Build()
+
+			log, _ := ktesting.NewTestContext(t)
+			s := &server{
+				log:      log,
+				client:   fakeClient,
+				renderer: renderer,
+			}
+
+			// Find the ETag of the content first, so conditional requests can match it.
+			probe := httptest.NewRecorder()
+			s.ServeHTTP(probe, httptest.NewRequest(http.MethodGet, "/bundles/test-bundle", nil))
+			currentETag := probe.Header().Get("ETag")
+			assert.Regexp(t, `^W/"[0-9a-f]{64}"$`, currentETag)
+
+			req := httptest.NewRequest(test.method, test.path, nil)
+			if test.conditional {
+				req.Header.Set("If-None-Match", currentETag)
+			}
+			if test.authenticate {
+				s.authenticator = fakeAuthenticator{"valid": true}
+			}
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expStatus, w.Code)
+			if test.expContentType != "" {
+				assert.Equal(t, test.expContentType, w.Header().Get("Content-Type"))
+			}
+			assert.Equal(t, test.expBody, w.Body.String())
+		})
+	}
+}
+
+func Test_serveExports(t *testing.T) {
+	target := trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}}
+	exported := func(b *trustapi.Bundle) {
+		b.Annotations = map[string]string{trustapi.BundleHubExportAnnotationKey: "true"}
+		b.Spec.Target = target
+	}
+
+	renderer := fakeRenderer(func(bundle *trustapi.Bundle, _ trustbundle.Format) ([]byte, error) {
+		if bundle.Name == "broken" {
+			return nil, errors.New("source not found")
+		}
+		return []byte(dummy.TestCertificate1), nil
+	})
+
+	tests := map[string]struct {
+		objects       []client.Object
+		authorization string
+
+		expStatus int
+		expBody   string
+	}{
+		"only exported Bundles are served, ordered by name": {
+			objects:       []client.Object{gen.Bundle("b", exported), gen.Bundle("a", exported), gen.Bundle("not-exported")},
+			authorization: "Bearer valid",
+			expStatus:     http.StatusOK,
+			expBody: mustJSON(t, hub.Exports{Bundles: []hub.ExportedBundle{
+				{Name: "a", Bundle: dummy.TestCertificate1, Target: target},
+				{Name: "b", Bundle: dummy.TestCertificate1, Target: target},
+			}}),
+		},
+		"the targets of exported Bundles are served": {
+			objects: []client.Object{gen.Bundle("a", func(b *trustapi.Bundle) {
+				b.Annotations = map[string]string{trustapi.BundleHubExportAnnotationKey: "true"}
+				b.Spec.Targets = []trustapi.BundleTarget{target}
+			})},
+			authorization: "Bearer valid",
+			expStatus:     http.StatusOK,
+			expBody: mustJSON(t, hub.Exports{Bundles: []hub.ExportedBundle{
+				{Name: "a", Bundle: dummy.TestCertificate1, Targets: []trustapi.BundleTarget{target}},
+			}}),
+		},
+		"no exported Bundles are served as an empty list": {
+			objects:       []client.Object{gen.Bundle("not-exported")},
+			authorization: "Bearer valid",
+			expStatus:     http.StatusOK,
+			expBody:       "{\"bundles\":[]}\n",
+		},
+		"nothing is served if an exported Bundle can't be rendered": {
+			objects:       []client.Object{gen.Bundle("a", exported), gen.Bundle("broken", exported)},
+			authorization: "Bearer valid",
+			expStatus:     http.StatusServiceUnavailable,
+			expBody:       "failed to render Bundle \"broken\": source not found\n",
+		},
+		"a request with an invalid token is unauthorized": {
+			objects:       []client.Object{gen.Bundle("a", exported)},
+			authorization: "Bearer invalid",
+			expStatus:     http.StatusUnauthorized,
+			expBody:       "unauthorized\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(test.objects...).
+				Build()
+
+			log, _ := ktesting.NewTestContext(t)
+			s := &server{
+				log:           log,
+				client:        fakeClient,
+				renderer:      renderer,
+				authenticator: fakeAuthenticator{"valid": true},
+			}
+
+			req := httptest.NewRequest(http.MethodGet, hub.ExportsPath, nil)
+			req.Header.Set("Authorization", test.authorization)
+
+			w := httptest.NewRecorder()
+			s.serveExports(w, req)
+
+			assert.Equal(t, test.expStatus, w.Code)
+			assert.Equal(t, test.expBody, w.Body.String())
+		})
+	}
+}
+
+// fakeManager is a Manager which only provides a client, and records the
+// runnables added to it.
+type fakeManager struct {
+	manager.Manager
+
+	client    client.Client
+	runnables []manager.Runnable
+}
+
+func (m *fakeManager) GetClient() client.Client     { return m.client }
+func (m *fakeManager) GetCache() cache.Cache        { return nil }
+func (m *fakeManager) Add(r manager.Runnable) error { m.runnables = append(m.runnables, r); return nil }
+
+func Test_Register(t *testing.T) {
+	exported := func(b *trustapi.Bundle) {
+		b.Annotations = map[string]string{trustapi.BundleHubExportAnnotationKey: "true"}
+	}
+	renderer := fakeRenderer(func(_ *trustapi.Bundle, _ trustbundle.Format) ([]byte, error) {
+		return []byte(dummy.TestCertificate1), nil
+	})
+
+	tests := map[string]struct {
+		exports bool
+
+		expStatus int
+	}{
+		"exports are served if enabled": {
+			exports:   true,
+			expStatus: http.StatusOK,
+		},
+		"exports aren't served if disabled": {
+			exports:   false,
+			expStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mgr := &fakeManager{client: fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(gen.Bundle("a", exported)).
+				Build()}
+
+			log, _ := ktesting.NewTestContext(t)
+			require.NoError(t, Register(mgr, Options{Log: log, Renderer: renderer, Exports: test.exports}))
+			require.Len(t, mgr.runnables, 1)
+
+			w := httptest.NewRecorder()
+			mgr.runnables[0].(*server).handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, hub.ExportsPath, nil))
+
+			assert.Equal(t, test.expStatus, w.Code)
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data) + "\n"
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+// errNotReady is returned by TLS handshakes before a trust bundle was read.
+var errNotReady = errors.New("trust bundle has not been read yet")
+
+// ClientTLSConfig returns a copy of base, or of an empty config if nil, which
+// verifies servers against the current trust bundle on each handshake.
+//
+// As the roots of a tls.Config can't change, the standard verification is
+// disabled, and replaced with one which verifies the server's certificate
+// chain and name against the current pool, as the standard verification
+// would. base must not set InsecureSkipVerify or VerifyConnection.
+func (w *Watcher) ClientTLSConfig(base *tls.Config) *tls.Config {
+	config := cloneConfig(base)
+
+	config.InsecureSkipVerify = true // #nosec G402 -- Servers are verified by VerifyConnection.
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		pool := w.CertPool()
+		if pool == nil {
+			return errNotReady
+		}
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("server presented no certificates")
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, certificate := range state.PeerCertificates[1:] {
+			intermediates.AddCert(certificate)
+		}
+
+		_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+			DNSName:       state.ServerName,
+			Roots:         pool,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+
+	return config
+}
+
+// ServerTLSConfig returns a copy of base, or of an empty config if nil, which
+// verifies client certificates against the current trust bundle on each
+// handshake. Client certificates are required and verified unless base sets
+// another ClientAuth.
+func (w *Watcher) ServerTLSConfig(base *tls.Config) *tls.Config {
+	config := cloneConfig(base)
+	if config.ClientAuth == tls.NoClientCert {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	template := config.Clone()
+	template.GetConfigForClient = nil
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		pool := w.CertPool()
+		if pool == nil {
+			return nil, errNotReady
+		}
+
+		handshakeConfig := template.Clone()
+		handshakeConfig.ClientCAs = pool
+		return handshakeConfig, nil
+	}
+
+	return config
+}
+
+func cloneConfig(base *tls.Config) *tls.Config {
+	if base == nil {
+		return &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return base.Clone()
+}
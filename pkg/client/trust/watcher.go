@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trust lets Go services consume the trust bundles which
+// trust-manager writes to its targets. A Watcher watches a target ConfigMap,
+// Secret or ClusterTrustBundle, and keeps an x509.CertPool up to date with it,
+// which TLS configurations can verify peers against as the bundle rotates.
+package trust
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	certificatesv1alpha1 "k8s.io/api/certificates/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// Kind is the kind of object a trust bundle is read from.
+type Kind string
+
+const (
+	KindConfigMap          Kind = "ConfigMap"
+	KindSecret             Kind = "Secret"
+	KindClusterTrustBundle Kind = "ClusterTrustBundle"
+)
+
+// Source identifies the object which a Watcher reads the trust bundle from.
+type Source struct {
+	// Kind is the kind of the object.
+	Kind Kind
+
+	// Namespace is the namespace of the ConfigMap or Secret. It's ignored for
+	// ClusterTrustBundles, which are cluster-scoped.
+	Namespace string
+
+	// Name is the name of the object.
+	Name string
+
+	// Key is the key of the ConfigMap or Secret which holds the PEM bundle. It's
+	// ignored for ClusterTrustBundles.
+	Key string
+}
+
+// Option configures a Watcher.
+type Option func(*Watcher)
+
+// WithLogger sets the logger which the Watcher logs bundle updates and
+// invalid bundles to.
+func WithLogger(logger logr.Logger) Option {
+	return func(w *Watcher) {
+		w.log = logger
+	}
+}
+
+// WithOnUpdate registers a function which is called with the new pool each
+// time the trust bundle changes.
+func WithOnUpdate(fn func(pool *x509.CertPool)) Option {
+	return func(w *Watcher) {
+		w.onUpdate = append(w.onUpdate, fn)
+	}
+}
+
+// Watcher keeps an x509.CertPool up to date with the trust bundle held by a
+// target of trust-manager. Invalid bundles are logged and ignored, and the
+// last valid bundle is kept if the object is deleted, so that peers can still
+// be verified while the object is recreated.
+type Watcher struct {
+	client kubernetes.Interface
+	source Source
+
+	log      logr.Logger
+	onUpdate []func(pool *x509.CertPool)
+
+	mu    sync.RWMutex
+	pool  *x509.CertPool
+	pem   string
+	ready chan struct{}
+}
+
+// NewWatcher returns a Watcher of the trust bundle held by the source. The
+// Watcher must be started with Run.
+func NewWatcher(client kubernetes.Interface, source Source, options ...Option) (*Watcher, error) {
+	switch source.Kind {
+	case KindConfigMap, KindSecret:
+		if source.Namespace == "" || source.Name == "" || source.Key == "" {
+			return nil, fmt.Errorf("namespace, name and key of the %s must be set", source.Kind)
+		}
+	case KindClusterTrustBundle:
+		if source.Name == "" {
+			return nil, errors.New("name of the ClusterTrustBundle must be set")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported source kind %q", source.Kind)
+	}
+
+	w := &Watcher{
+		client: client,
+		source: source,
+		log:    logr.Discard(),
+		ready:  make(chan struct{}),
+	}
+	for _, option := range options {
+		option(w)
+	}
+
+	return w, nil
+}
+
+// Run watches the source until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) error {
+	lw, objType := w.listWatch(ctx)
+
+	_, informer := cache.NewInformerWithOptions(cache.InformerOptions{
+		ListerWatcher: lw,
+		ObjectType:    objType,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    w.update,
+			UpdateFunc: func(_, obj any) { w.update(obj) },
+		},
+	})
+
+	informer.Run(ctx.Done())
+	return ctx.Err()
+}
+
+// WaitForReady blocks until the Watcher has read a valid trust bundle, or the
+// context is cancelled.
+func (w *Watcher) WaitForReady(ctx context.Context) error {
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for trust bundle from %s %s: %w", w.source.Kind, w.objectName(), ctx.Err())
+	}
+}
+
+// CertPool returns the pool of the current trust bundle, or nil if no valid
+// bundle has been read yet. The returned pool must not be modified.
+func (w *Watcher) CertPool() *x509.CertPool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.pool
+}
+
+// listWatch returns the ListerWatcher and object type of the source, limited
+// to the named object.
+func (w *Watcher) listWatch(ctx context.Context) (cache.ListerWatcher, runtime.Object) {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", w.source.Name).String()
+
+	var (
+		list    func(metav1.ListOptions) (runtime.Object, error)
+		watchFn func(metav1.ListOptions) (watch.Interface, error)
+		objType runtime.Object
+	)
+	switch w.source.Kind {
+	case KindConfigMap:
+		configMaps := w.client.CoreV1().ConfigMaps(w.source.Namespace)
+		list = func(opts metav1.ListOptions) (runtime.Object, error) { return configMaps.List(ctx, opts) }
+		watchFn = func(opts metav1.ListOptions) (watch.Interface, error) { return configMaps.Watch(ctx, opts) }
+		objType = &corev1.ConfigMap{}
+	case KindSecret:
+		secrets := w.client.CoreV1().Secrets(w.source.Namespace)
+		list = func(opts metav1.ListOptions) (runtime.Object, error) { return secrets.List(ctx, opts) }
+		watchFn = func(opts metav1.ListOptions) (watch.Interface, error) { return secrets.Watch(ctx, opts) }
+		objType = &corev1.Secret{}
+	case KindClusterTrustBundle:
+		bundles := w.client.CertificatesV1alpha1().ClusterTrustBundles()
+		list = func(opts metav1.ListOptions) (runtime.Object, error) { return bundles.List(ctx, opts) }
+		watchFn = func(opts metav1.ListOptions) (watch.Interface, error) { return bundles.Watch(ctx, opts) }
+		objType = &certificatesv1alpha1.ClusterTrustBundle{}
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = fieldSelector
+			return list(opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = fieldSelector
+			return watchFn(opts)
+		},
+	}, objType
+}
+
+// update replaces the pool with the trust bundle of the object, if it's the
+// source and holds a valid bundle which differs from the current one.
+func (w *Watcher) update(obj any) {
+	bundlePEM, ok := w.bundlePEM(obj)
+	if !ok {
+		return
+	}
+
+	log := w.log.WithValues("kind", w.source.Kind, "name", w.objectName())
+
+	w.mu.RLock()
+	unchanged := w.pool != nil && bundlePEM == w.pem
+	w.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	certPool := util.NewCertPool()
+	if err := certPool.AddCertsFromPEM([]byte(bundlePEM)); err != nil {
+		log.Error(err, "ignoring invalid trust bundle")
+		return
+	}
+
+	pool := x509.NewCertPool()
+	for _, certificate := range certPool.Certificates() {
+		pool.AddCert(certificate)
+	}
+
+	w.mu.Lock()
+	w.pool, w.pem = pool, bundlePEM
+	w.mu.Unlock()
+
+	log.V(2).Info("updated trust bundle", "certificates", certPool.Size())
+
+	select {
+	case <-w.ready:
+	default:
+		close(w.ready)
+	}
+
+	for _, fn := range w.onUpdate {
+		fn(pool)
+	}
+}
+
+// bundlePEM returns the PEM bundle held by the object, and false if the object
+// isn't the source or doesn't hold the bundle.
+func (w *Watcher) bundlePEM(obj any) (string, bool) {
+	object, ok := obj.(metav1.Object)
+	if !ok || object.GetName() != w.source.Name {
+		return "", false
+	}
+
+	switch obj := obj.(type) {
+	case *corev1.ConfigMap:
+		data, ok := obj.Data[w.source.Key]
+		return data, ok
+	case *corev1.Secret:
+		data, ok := obj.Data[w.source.Key]
+		return string(data), ok
+	case *certificatesv1alpha1.ClusterTrustBundle:
+		return obj.Spec.TrustBundle, true
+	}
+	return "", false
+}
+
+func (w *Watcher) objectName() string {
+	if w.source.Kind == KindClusterTrustBundle {
+		return w.source.Name
+	}
+	return w.source.Namespace + "/" + w.source.Name
+}
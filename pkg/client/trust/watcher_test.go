@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1alpha1 "k8s.io/api/certificates/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func TestNewWatcher(t *testing.T) {
+	client := fake.NewClientset()
+
+	_, err := NewWatcher(client, Source{Kind: KindConfigMap, Namespace: "ns", Name: "bundle", Key: "trust.pem"})
+	assert.NoError(t, err)
+
+	_, err = NewWatcher(client, Source{Kind: KindClusterTrustBundle, Name: "bundle"})
+	assert.NoError(t, err)
+
+	_, err = NewWatcher(client, Source{Kind: KindSecret, Namespace: "ns", Name: "bundle"})
+	assert.EqualError(t, err, "namespace, name and key of the Secret must be set")
+
+	_, err = NewWatcher(client, Source{Kind: "Deployment", Name: "bundle"})
+	assert.EqualError(t, err, `unsupported source kind "Deployment"`)
+}
+
+func TestWatcher(t *testing.T) {
+	tests := map[string]struct {
+		source Source
+		object func(bundlePEM string) any
+	}{
+		"ConfigMap": {
+			source: Source{Kind: KindConfigMap, Namespace: "ns", Name: "bundle", Key: "trust.pem"},
+			object: func(bundlePEM string) any {
+				return &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bundle"},
+					Data:       map[string]string{"trust.pem": bundlePEM},
+				}
+			},
+		},
+		"Secret": {
+			source: Source{Kind: KindSecret, Namespace: "ns", Name: "bundle", Key: "ca.crt"},
+			object: func(bundlePEM string) any {
+				return &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bundle"},
+					Data:       map[string][]byte{"ca.crt": []byte(bundlePEM)},
+				}
+			},
+		},
+		"ClusterTrustBundle": {
+			source: Source{Kind: KindClusterTrustBundle, Name: "bundle"},
+			object: func(bundlePEM string) any {
+				return &certificatesv1alpha1.ClusterTrustBundle{
+					ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+					Spec:       certificatesv1alpha1.ClusterTrustBundleSpec{TrustBundle: bundlePEM},
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := fake.NewClientset()
+
+			updates := make(chan *x509.CertPool, 2)
+			w, err := NewWatcher(client, test.source, WithOnUpdate(func(pool *x509.CertPool) { updates <- pool }))
+			require.NoError(t, err)
+			assert.Nil(t, w.CertPool())
+
+			go func() { _ = w.Run(ctx) }()
+
+			create := func(obj any) {
+				var err error
+				switch obj := obj.(type) {
+				case *corev1.ConfigMap:
+					_, err = client.CoreV1().ConfigMaps(obj.Namespace).Create(ctx, obj, metav1.CreateOptions{})
+				case *corev1.Secret:
+					_, err = client.CoreV1().Secrets(obj.Namespace).Create(ctx, obj, metav1.CreateOptions{})
+				case *certificatesv1alpha1.ClusterTrustBundle:
+					_, err = client.CertificatesV1alpha1().ClusterTrustBundles().Create(ctx, obj, metav1.CreateOptions{})
+				}
+				require.NoError(t, err)
+			}
+			update := func(obj any) {
+				var err error
+				switch obj := obj.(type) {
+				case *corev1.ConfigMap:
+					_, err = client.CoreV1().ConfigMaps(obj.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+				case *corev1.Secret:
+					_, err = client.CoreV1().Secrets(obj.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+				case *certificatesv1alpha1.ClusterTrustBundle:
+					_, err = client.CertificatesV1alpha1().ClusterTrustBundles().Update(ctx, obj, metav1.UpdateOptions{})
+				}
+				require.NoError(t, err)
+			}
+
+			create(test.object(dummy.TestCertificate1))
+			require.NoError(t, w.WaitForReady(ctx))
+			first := <-updates
+			assert.Same(t, first, w.CertPool())
+
+			// Invalid bundles are ignored.
+			update(test.object("not a certificate"))
+
+			update(test.object(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)))
+			second := <-updates
+			assert.Same(t, second, w.CertPool())
+			assert.False(t, first.Equal(second))
+		})
+	}
+}
+
+func TestWatcherClientTLSConfig(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, &leafKey.PublicKey, caKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	w, err := NewWatcher(fake.NewClientset(), Source{Kind: KindConfigMap, Namespace: "ns", Name: "bundle", Key: "trust.pem"})
+	require.NoError(t, err)
+
+	config := w.ClientTLSConfig(nil)
+	state := tls.ConnectionState{ServerName: "example.com", PeerCertificates: []*x509.Certificate{leaf}}
+
+	assert.ErrorIs(t, config.VerifyConnection(state), errNotReady)
+
+	w.update(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bundle"},
+		Data:       map[string]string{"trust.pem": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))},
+	})
+	assert.NoError(t, config.VerifyConnection(state))
+
+	state.ServerName = "example.org"
+	assert.Error(t, config.VerifyConnection(state))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package awspca is the cloud source provider which fetches the certificate
+// chains of AWS Private CAs (ACM PCA), so that trust-manager can distribute
+// trust in the certificates they issue.
+// Requests are signed with AWS Signature Version 4, with the credentials of
+// IRSA or of the environment of trust-manager.
+package awspca
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+)
+
+const (
+	// maxResponseSize limits the size of the responses read from AWS.
+	maxResponseSize = 4 << 20
+
+	// serviceName is the name of the ACM PCA service, which requests are
+	// signed for.
+	serviceName = "acm-pca"
+)
+
+func init() {
+	cloudsource.Register(cloudsource.ProviderAWSPrivateCA, func(client *http.Client, clock clock.PassiveClock) (cloudsource.Provider, error) {
+		return NewProvider(client, clock), nil
+	})
+}
+
+// Provider fetches the certificate chains of AWS Private CAs, whose ARNs are
+// the references of its sources.
+type Provider struct {
+	client      *http.Client
+	clock       clock.PassiveClock
+	credentials credentialsProvider
+
+	// endpoint returns the base URL of the ACM PCA API in a region.
+	endpoint func(region string) string
+}
+
+// NewProvider returns a Provider which connects to AWS with the given client,
+// and with the credentials configured by the environment.
+func NewProvider(client *http.Client, clock clock.PassiveClock) *Provider {
+	return &Provider{
+		client:      client,
+		clock:       clock,
+		credentials: providerFromEnvironment(client, clock),
+		endpoint: func(region string) string {
+			return "https://" + serviceName + "." + region + "." + dnsSuffix(region)
+		},
+	}
+}
+
+// getCertificateAuthorityCertificateResponse is the response of the
+// GetCertificateAuthorityCertificate action.
+type getCertificateAuthorityCertificateResponse struct {
+	Certificate      string `json:"Certificate"`
+	CertificateChain string `json:"CertificateChain"`
+}
+
+// apiErrorResponse is the response of the ACM PCA API to a failed action.
+type apiErrorResponse struct {
+	Type         string `json:"__type"`
+	Message      string `json:"message"`
+	MessageUpper string `json:"Message"`
+}
+
+// Fetch returns the PEM certificate chain of the CA with the given ARN: its CA
+// certificate, followed by the certificates of the CAs above it. It calls the
+// GetCertificateAuthorityCertificate action of the ACM PCA API.
+func (p *Provider) Fetch(ctx context.Context, arn string) (string, error) {
+	region, err := arnRegion(arn)
+	if err != nil {
+		return "", err
+	}
+
+	creds, err := p.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{"CertificateAuthorityArn": arn})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint(region)+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "ACMPrivateCA.GetCertificateAuthorityCertificate")
+	signRequest(req, body, creds, serviceName, region, p.clock.Now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get certificate of AWS Private CA %q: %w", arn, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read certificate of AWS Private CA %q: %w", arn, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiErrorResponse
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Type != "" {
+			// The type may be qualified by its namespace, as in
+			// "com.amazonaws.acmpca#ResourceNotFoundException".
+			errType := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+			return "", fmt.Errorf("failed to get certificate of AWS Private CA %q: %s: %s", arn, errType, cmp.Or(apiErr.Message, apiErr.MessageUpper))
+		}
+		return "", fmt.Errorf("failed to get certificate of AWS Private CA %q: unexpected status %s", arn, resp.Status)
+	}
+
+	var certificate getCertificateAuthorityCertificateResponse
+	if err := json.Unmarshal(respBody, &certificate); err != nil {
+		return "", fmt.Errorf("invalid certificate of AWS Private CA %q: %w", arn, err)
+	}
+	if certificate.Certificate == "" {
+		return "", fmt.Errorf("AWS Private CA %q has no certificate", arn)
+	}
+
+	chain := strings.TrimSpace(certificate.Certificate) + "\n"
+	if c := strings.TrimSpace(certificate.CertificateChain); c != "" {
+		chain += c + "\n"
+	}
+	return chain, nil
+}
+
+// arnRegion returns the region of the ARN of an AWS Private CA.
+func arnRegion(arn string) (string, error) {
+	// arn:partition:acm-pca:region:account-id:certificate-authority/id
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != serviceName || parts[3] == "" || !strings.HasPrefix(parts[5], "certificate-authority/") {
+		return "", fmt.Errorf("%q is not the ARN of an AWS Private CA", arn)
+	}
+	return parts[3], nil
+}
+
+// dnsSuffix returns the DNS suffix of the endpoints of AWS services in the
+// region.
+func dnsSuffix(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return "amazonaws.com.cn"
+	}
+	return "amazonaws.com"
+}
+
+// ValidateARN returns an error if the ARN isn't the ARN of an AWS Private CA.
+func ValidateARN(arn string) error {
+	_, err := arnRegion(arn)
+	return err
+}
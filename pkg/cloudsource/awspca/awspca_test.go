@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awspca
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+const testARN = "arn:aws:acm-pca:eu-west-1:111122223333:certificate-authority/11223344-1234-1122-2233-112233445566"
+
+func Test_Provider(t *testing.T) {
+	var fail bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ACMPrivateCA.GetCertificateAuthorityCertificate", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session-token", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/20260101/eu-west-1/acm-pca/aws4_request, "))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"CertificateAuthorityArn": "`+testARN+`"}`, string(body))
+
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type": "com.amazonaws.acmpca#InvalidStateException", "message": "The certificate authority is not in a valid state."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(getCertificateAuthorityCertificateResponse{
+			Certificate:      dummy.TestCertificate1,
+			CertificateChain: dummy.TestCertificate2,
+		})
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		client:      server.Client(),
+		clock:       fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		credentials: staticProvider(credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session-token"}),
+		endpoint: func(region string) string {
+			assert.Equal(t, "eu-west-1", region)
+			return server.URL
+		},
+	}
+
+	chain, err := p.Fetch(context.Background(), testARN)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(dummy.TestCertificate1)+"\n"+strings.TrimSpace(dummy.TestCertificate2)+"\n", chain)
+
+	fail = true
+	_, err = p.Fetch(context.Background(), testARN)
+	assert.EqualError(t, err, `failed to get certificate of AWS Private CA "`+testARN+`": InvalidStateException: The certificate authority is not in a valid state.`)
+}
+
+func Test_Provider_invalidARN(t *testing.T) {
+	p := &Provider{
+		clock:       fakeclock.NewFakeClock(time.Now()),
+		credentials: staticProvider(credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}),
+	}
+
+	chain, err := p.Fetch(context.Background(), "arn:aws:acm:eu-west-1:111122223333:certificate/abc")
+	assert.EqualError(t, err, `"arn:aws:acm:eu-west-1:111122223333:certificate/abc" is not the ARN of an AWS Private CA`)
+	assert.Empty(t, chain)
+}
+
+func Test_webIdentityProvider(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::111122223333:role/trust-manager", r.Form.Get("RoleArn"))
+		assert.Equal(t, "trust-manager", r.Form.Get("RoleSessionName"))
+
+		if r.Form.Get("WebIdentityToken") != "web-identity-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>InvalidIdentityToken</Code><Message>Incorrect token audience</Message></Error></ErrorResponse>`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>ASIAEXAMPLE</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>session-token</SessionToken>
+      <Expiration>2026-01-01T01:00:00Z</Expiration>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("web-identity-token\n"), 0600))
+
+	clock := fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p := &webIdentityProvider{
+		client:      server.Client(),
+		clock:       clock,
+		endpoint:    server.URL,
+		roleARN:     "arn:aws:iam::111122223333:role/trust-manager",
+		tokenFile:   tokenFile,
+		sessionName: "trust-manager",
+	}
+
+	ctx := context.Background()
+	expCreds := credentials{
+		AccessKeyID:     "ASIAEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "session-token",
+		Expires:         time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC),
+	}
+
+	creds, err := p.Retrieve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expCreds, creds)
+
+	// Credentials are cached until shortly before they expire.
+	_, err = p.Retrieve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	clock.Step(time.Hour - credentialsExpiryWindow)
+	require.NoError(t, os.WriteFile(tokenFile, []byte("rotated-token"), 0600))
+	_, err = p.Retrieve(ctx)
+	assert.EqualError(t, err, `failed to assume role "arn:aws:iam::111122223333:role/trust-manager": InvalidIdentityToken: Incorrect token audience`)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awspca
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// credentialsExpiryWindow is how long before they expire credentials are
+// refreshed.
+const credentialsExpiryWindow = 5 * time.Minute
+
+// credentials are AWS security credentials.
+type credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Expires is when temporary credentials expire. It is zero for
+	// long-term credentials.
+	Expires time.Time
+}
+
+// credentialsProvider provides the credentials requests to AWS are signed
+// with.
+type credentialsProvider interface {
+	Retrieve(ctx context.Context) (credentials, error)
+}
+
+// providerFromEnvironment returns the credentials provider configured by the
+// environment of trust-manager, following the AWS SDKs: the web identity
+// token of IRSA, which the EKS Pod Identity webhook configures with
+// AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE, or else the static
+// credentials of AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+func providerFromEnvironment(client *http.Client, clock clock.PassiveClock) credentialsProvider {
+	if roleARN, tokenFile := os.Getenv("AWS_ROLE_ARN"), os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"); roleARN != "" && tokenFile != "" {
+		sessionName := os.Getenv("AWS_ROLE_SESSION_NAME")
+		if sessionName == "" {
+			sessionName = "trust-manager"
+		}
+
+		stsEndpoint := "https://sts.amazonaws.com"
+		if region := cmp.Or(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")); region != "" {
+			stsEndpoint = "https://sts." + region + "." + dnsSuffix(region)
+		}
+
+		return &webIdentityProvider{
+			client:      client,
+			clock:       clock,
+			endpoint:    stsEndpoint,
+			roleARN:     roleARN,
+			tokenFile:   tokenFile,
+			sessionName: sessionName,
+		}
+	}
+
+	if accessKeyID, secretAccessKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); accessKeyID != "" && secretAccessKey != "" {
+		return staticProvider(credentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		})
+	}
+
+	return noCredentialsProvider{}
+}
+
+// staticProvider provides long-term credentials.
+type staticProvider credentials
+
+func (p staticProvider) Retrieve(context.Context) (credentials, error) {
+	return credentials(p), nil
+}
+
+// noCredentialsProvider is used if no credentials are configured.
+type noCredentialsProvider struct{}
+
+func (noCredentialsProvider) Retrieve(context.Context) (credentials, error) {
+	return credentials{}, errors.New("no AWS credentials are configured; set up IRSA for the trust-manager ServiceAccount, or set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+}
+
+// webIdentityProvider provides temporary credentials for a role, which are
+// obtained from STS with a web identity token, such as the projected
+// ServiceAccount token of IRSA. The credentials are cached until shortly
+// before they expire. It is safe for concurrent use.
+type webIdentityProvider struct {
+	client *http.Client
+	clock  clock.PassiveClock
+
+	endpoint    string
+	roleARN     string
+	tokenFile   string
+	sessionName string
+
+	mu     sync.Mutex
+	cached credentials
+}
+
+// assumeRoleWithWebIdentityResponse is the response of the STS
+// AssumeRoleWithWebIdentity action.
+type assumeRoleWithWebIdentityResponse struct {
+	Credentials struct {
+		AccessKeyID     string    `xml:"AccessKeyId"`
+		SecretAccessKey string    `xml:"SecretAccessKey"`
+		SessionToken    string    `xml:"SessionToken"`
+		Expiration      time.Time `xml:"Expiration"`
+	} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+}
+
+// stsErrorResponse is the response of STS to a failed action.
+type stsErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+func (p *webIdentityProvider) Retrieve(ctx context.Context) (credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached.AccessKeyID != "" && p.clock.Now().Before(p.cached.Expires.Add(-credentialsExpiryWindow)) {
+		return p.cached, nil
+	}
+
+	// The token is read on each refresh, as the kubelet rotates it.
+	token, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return credentials{}, fmt.Errorf("failed to read web identity token: %w", err)
+	}
+
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {p.roleARN},
+		"RoleSessionName":  {p.sessionName},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/", strings.NewReader(form.Encode()))
+	if err != nil {
+		return credentials{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return credentials{}, fmt.Errorf("failed to assume role %q: %w", p.roleARN, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return credentials{}, fmt.Errorf("failed to read STS response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var stsErr stsErrorResponse
+		if xml.Unmarshal(body, &stsErr) == nil && stsErr.Code != "" {
+			return credentials{}, fmt.Errorf("failed to assume role %q: %s: %s", p.roleARN, stsErr.Code, stsErr.Message)
+		}
+		return credentials{}, fmt.Errorf("failed to assume role %q: unexpected status %s", p.roleARN, resp.Status)
+	}
+
+	var assumed assumeRoleWithWebIdentityResponse
+	if err := xml.Unmarshal(body, &assumed); err != nil {
+		return credentials{}, fmt.Errorf("invalid STS response: %w", err)
+	}
+
+	p.cached = credentials{
+		AccessKeyID:     assumed.Credentials.AccessKeyID,
+		SecretAccessKey: assumed.Credentials.SecretAccessKey,
+		SessionToken:    assumed.Credentials.SessionToken,
+		Expires:         assumed.Credentials.Expiration,
+	}
+	return p.cached, nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package azurekeyvault is the cloud source provider which fetches
+// certificates stored in Azure Key Vault, so that trust-manager can
+// distribute trust in CA certificates kept there. Requests are authorized
+// with the access tokens of Microsoft Entra Workload ID, which its webhook
+// configures with AZURE_CLIENT_ID, AZURE_TENANT_ID,
+// AZURE_FEDERATED_TOKEN_FILE and AZURE_AUTHORITY_HOST.
+//
+// The provider is only compiled into builds of trust-manager with the
+// "azurekeyvault" build tag.
+package azurekeyvault
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+)
+
+const (
+	// maxResponseSize limits the size of the responses read from Azure.
+	maxResponseSize = 4 << 20
+
+	// apiVersion is the version of the Key Vault API which is called.
+	apiVersion = "7.4"
+
+	// defaultAuthorityHost is the Microsoft Entra authority which issues
+	// access tokens, which AZURE_AUTHORITY_HOST overrides.
+	defaultAuthorityHost = "https://login.microsoftonline.com/"
+)
+
+func init() {
+	cloudsource.Register(cloudsource.ProviderAzureKeyVault, func(client *http.Client, clock clock.PassiveClock) (cloudsource.Provider, error) {
+		return NewProvider(client, clock), nil
+	})
+}
+
+// Provider fetches certificates from key vaults, whose certificate URLs, as
+// returned by cloudsource.AzureKeyVaultRef, are the references of its
+// sources.
+type Provider struct {
+	client *http.Client
+	clock  clock.PassiveClock
+
+	// fetchToken returns a new access token for the scope.
+	fetchToken func(ctx context.Context, scope string) (cloudsource.Token, error)
+
+	mu     sync.Mutex
+	tokens map[string]*cloudsource.TokenCache
+}
+
+// NewProvider returns a Provider which connects to Azure with the given
+// client, and with the workload identity configured by the environment.
+func NewProvider(client *http.Client, clock clock.PassiveClock) *Provider {
+	identity := workloadIdentity{
+		client:        client,
+		clock:         clock,
+		authorityHost: cmp.Or(os.Getenv("AZURE_AUTHORITY_HOST"), defaultAuthorityHost),
+		tenantID:      os.Getenv("AZURE_TENANT_ID"),
+		clientID:      os.Getenv("AZURE_CLIENT_ID"),
+		tokenFile:     os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
+	}
+
+	return &Provider{
+		client:     client,
+		clock:      clock,
+		fetchToken: identity.fetchToken,
+		tokens:     make(map[string]*cloudsource.TokenCache),
+	}
+}
+
+// certificateBundle is the certificate returned by the Key Vault API.
+type certificateBundle struct {
+	// CER is the base64 encoded DER certificate.
+	CER string `json:"cer"`
+}
+
+// apiErrorResponse is the response of the Key Vault API to a failed request.
+type apiErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Fetch returns the PEM certificate with the given URL, in its latest
+// version.
+func (p *Provider) Fetch(ctx context.Context, certificateURL string) (string, error) {
+	u, err := parseCertificateURL(certificateURL)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := p.tokenCache(scope(u)).Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u.RawQuery = url.Values{"api-version": {apiVersion}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Key Vault certificate %q: %w", certificateURL, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read Key Vault certificate %q: %w", certificateURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiErrorResponse
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Code != "" {
+			return "", fmt.Errorf("failed to get Key Vault certificate %q: %s: %s", certificateURL, apiErr.Error.Code, apiErr.Error.Message)
+		}
+		return "", fmt.Errorf("failed to get Key Vault certificate %q: unexpected status %s", certificateURL, resp.Status)
+	}
+
+	var certificate certificateBundle
+	if err := json.Unmarshal(respBody, &certificate); err != nil {
+		return "", fmt.Errorf("invalid Key Vault certificate %q: %w", certificateURL, err)
+	}
+	der, err := base64.StdEncoding.DecodeString(certificate.CER)
+	if err != nil {
+		return "", fmt.Errorf("invalid Key Vault certificate %q: %w", certificateURL, err)
+	}
+	if len(der) == 0 {
+		return "", fmt.Errorf("no certificate in Key Vault certificate %q", certificateURL)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+// tokenCache returns the cache of the access tokens for the scope.
+func (p *Provider) tokenCache(scope string) *cloudsource.TokenCache {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cache, ok := p.tokens[scope]
+	if !ok {
+		cache = &cloudsource.TokenCache{
+			Clock: p.clock,
+			Fetch: func(ctx context.Context) (cloudsource.Token, error) {
+				return p.fetchToken(ctx, scope)
+			},
+		}
+		p.tokens[scope] = cache
+	}
+	return cache
+}
+
+// scope returns the scope of the access tokens for the key vault at the URL,
+// which depends on its cloud, as in "https://vault.azure.net/.default".
+func scope(u *url.URL) string {
+	host := u.Hostname()
+	return "https://" + host[strings.Index(host, ".")+1:] + "/.default"
+}
+
+// parseCertificateURL parses the URL of a Key Vault certificate.
+func parseCertificateURL(certificateURL string) (*url.URL, error) {
+	u, err := url.Parse(certificateURL)
+	if err != nil || u.Scheme != "https" || !strings.Contains(u.Hostname(), ".") || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("%q is not the URL of a Key Vault certificate", certificateURL)
+	}
+
+	name, ok := strings.CutPrefix(u.Path, "/certificates/")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("%q is not the URL of a Key Vault certificate", certificateURL)
+	}
+	return u, nil
+}
+
+// workloadIdentity exchanges the federated token of Microsoft Entra Workload
+// ID for access tokens.
+type workloadIdentity struct {
+	client        *http.Client
+	clock         clock.PassiveClock
+	authorityHost string
+	tenantID      string
+	clientID      string
+	tokenFile     string
+}
+
+// tokenResponse is the response of the Microsoft identity platform to a
+// token request.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// fetchToken returns a new access token for the scope.
+func (w workloadIdentity) fetchToken(ctx context.Context, scope string) (cloudsource.Token, error) {
+	if w.tenantID == "" || w.clientID == "" || w.tokenFile == "" {
+		return cloudsource.Token{}, errors.New("no Azure workload identity configured: AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_FEDERATED_TOKEN_FILE must be set")
+	}
+
+	assertion, err := os.ReadFile(w.tokenFile)
+	if err != nil {
+		return cloudsource.Token{}, fmt.Errorf("failed to read federated token: %w", err)
+	}
+
+	form := url.Values{
+		"client_id":             {w.clientID},
+		"scope":                 {scope},
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {strings.TrimSpace(string(assertion))},
+	}
+	tokenURL := strings.TrimSuffix(w.authorityHost, "/") + "/" + url.PathEscape(w.tenantID) + "/oauth2/v2.0/token"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return cloudsource.Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return cloudsource.Token{}, fmt.Errorf("failed to get Azure access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&token); err != nil {
+		return cloudsource.Token{}, fmt.Errorf("failed to get Azure access token: unexpected status %s", resp.Status)
+	}
+	if token.Error != "" {
+		return cloudsource.Token{}, fmt.Errorf("failed to get Azure access token: %s: %s", token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return cloudsource.Token{}, fmt.Errorf("failed to get Azure access token: unexpected status %s", resp.Status)
+	}
+
+	return cloudsource.Token{
+		Value:   token.AccessToken,
+		Expires: w.clock.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
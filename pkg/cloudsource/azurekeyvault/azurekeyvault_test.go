@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurekeyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_Provider(t *testing.T) {
+	block, _ := pem.Decode([]byte(dummy.TestCertificate1))
+	require.NotNil(t, block)
+
+	var fail bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/certificates/my-ca", r.URL.Path)
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+
+		if fail {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "Forbidden", "message": "The user does not have certificates get permission"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "https://my-vault.vault.azure.net/certificates/my-ca/1", "cer": "` + base64.StdEncoding.EncodeToString(block.Bytes) + `"}`))
+	}))
+	defer server.Close()
+
+	var scopes []string
+	p := &Provider{
+		client: server.Client(),
+		clock:  fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		fetchToken: func(_ context.Context, scope string) (cloudsource.Token, error) {
+			scopes = append(scopes, scope)
+			return cloudsource.Token{Value: "access-token", Expires: time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)}, nil
+		},
+		tokens: make(map[string]*cloudsource.TokenCache),
+	}
+
+	certificateURL := cloudsource.AzureKeyVaultRef(server.URL+"/", "my-ca")
+	certificate, err := p.Fetch(context.Background(), certificateURL)
+	require.NoError(t, err)
+	assert.Equal(t, string(pem.EncodeToMemory(block)), certificate)
+
+	fail = true
+	_, err = p.Fetch(context.Background(), certificateURL)
+	assert.EqualError(t, err, `failed to get Key Vault certificate "`+certificateURL+`": Forbidden: The user does not have certificates get permission`)
+
+	// The access token is reused until it's about to expire.
+	assert.Len(t, scopes, 1)
+
+	_, err = p.Fetch(context.Background(), "https://my-vault.vault.azure.net/secrets/my-ca")
+	assert.EqualError(t, err, `"https://my-vault.vault.azure.net/secrets/my-ca" is not the URL of a Key Vault certificate`)
+}
+
+func Test_scope(t *testing.T) {
+	for vaultURL, expScope := range map[string]string{
+		"https://my-vault.vault.azure.net":          "https://vault.azure.net/.default",
+		"https://my-vault.vault.azure.cn/":          "https://vault.azure.cn/.default",
+		"https://my-vault.vault.usgovcloudapi.net/": "https://vault.usgovcloudapi.net/.default",
+	} {
+		u, err := parseCertificateURL(cloudsource.AzureKeyVaultRef(vaultURL, "my-ca"))
+		require.NoError(t, err)
+		assert.Equal(t, expScope, scope(u), vaultURL)
+	}
+}
+
+func Test_workloadIdentity(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("federated-token\n"), 0600))
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/my-tenant/oauth2/v2.0/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "my-client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "federated-token", r.PostForm.Get("client_assertion"))
+
+		if r.PostForm.Get("scope") != "https://vault.azure.net/.default" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_scope", "error_description": "AADSTS70011: The provided value for scope is not valid."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"token_type": "Bearer", "expires_in": 3599, "access_token": "access-token"}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	identity := workloadIdentity{
+		client:        server.Client(),
+		clock:         fakeclock.NewFakeClock(now),
+		authorityHost: server.URL + "/",
+		tenantID:      "my-tenant",
+		clientID:      "my-client",
+		tokenFile:     tokenFile,
+	}
+
+	token, err := identity.fetchToken(context.Background(), "https://vault.azure.net/.default")
+	require.NoError(t, err)
+	assert.Equal(t, cloudsource.Token{Value: "access-token", Expires: now.Add(3599 * time.Second)}, token)
+
+	_, err = identity.fetchToken(context.Background(), "https://vault.example.com/.default")
+	assert.EqualError(t, err, "failed to get Azure access token: invalid_scope: AADSTS70011: The provided value for scope is not valid.")
+
+	_, err = workloadIdentity{}.fetchToken(context.Background(), "https://vault.azure.net/.default")
+	assert.ErrorContains(t, err, "no Azure workload identity configured")
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudsource fetches the CA certificates of Bundle sources from the
+// APIs of cloud providers, and from source plugins, and caches them until
+// they are due to be refreshed. Each cloud provider registers itself with
+// Register, and is only available in builds of trust-manager which import it. The providers for
+// Google Cloud and Azure are only imported with the "gcpcas" and
+// "azurekeyvault" build tags, so that builds can leave them out.
+package cloudsource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+)
+
+// The names of the providers of the cloud sources of Bundles.
+const (
+	ProviderAWSPrivateCA  = "AWSPrivateCA"
+	ProviderGCPCASPool    = "GCPCASPool"
+	ProviderAzureKeyVault = "AzureKeyVault"
+	ProviderPlugin        = "Plugin"
+)
+
+// AzureKeyVaultRef returns the reference of the AzureKeyVault source of the
+// certificate with the given name in the key vault: the URL of the
+// certificate.
+func AzureKeyVaultRef(vaultURL, certificateName string) string {
+	return strings.TrimSuffix(vaultURL, "/") + "/certificates/" + certificateName
+}
+
+const (
+	// DefaultRefreshInterval is how often certificates are fetched if the
+	// source sets no refresh interval.
+	DefaultRefreshInterval = time.Hour
+
+	// initialBackoff and maxBackoff bound the time until a failed fetch is
+	// retried, which doubles with each consecutive failure.
+	initialBackoff = 10 * time.Second
+	maxBackoff     = 10 * time.Minute
+)
+
+// Provider fetches CA certificates from the API of a cloud provider.
+type Provider interface {
+	// Fetch returns the PEM CA certificates of the resource with the given
+	// reference, whose format depends on the provider.
+	Fetch(ctx context.Context, ref string) (string, error)
+}
+
+// Factory builds a Provider which connects to its API with the given client.
+type Factory func(client *http.Client, clock clock.PassiveClock) (Provider, error)
+
+var (
+	factoriesMu sync.Mutex
+	factories   = make(map[string]Factory)
+)
+
+// Register makes a provider available to the Fetchers created later. It is
+// meant to be called from the init function of the package of the provider,
+// and panics if a provider of the same name is already registered.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("cloud source provider %q is already registered", name))
+	}
+	factories[name] = factory
+}
+
+// Registered returns the names of the registered providers, in order.
+func Registered() []string {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Source is a cloud resource whose CA certificates are fetched.
+type Source struct {
+	// Provider is the name of the provider of the resource.
+	Provider string
+
+	// Ref is the reference to the resource, whose format depends on the
+	// provider.
+	Ref string
+
+	// RefreshInterval, if positive, is how often the certificates are
+	// fetched in place of DefaultRefreshInterval.
+	RefreshInterval time.Duration
+}
+
+// key identifies the cached certificates of the source.
+func (s Source) key() string {
+	return s.Provider + " " + s.Ref
+}
+
+// refreshInterval returns how often the certificates of the source are
+// fetched.
+func (s Source) refreshInterval() time.Duration {
+	if s.RefreshInterval > 0 {
+		return s.RefreshInterval
+	}
+	return DefaultRefreshInterval
+}
+
+// UnavailableError is returned for sources whose provider isn't compiled into
+// the running build of trust-manager, or isn't enabled.
+type UnavailableError struct {
+	Provider string
+}
+
+func (e UnavailableError) Error() string {
+	return fmt.Sprintf("the %s source provider is not enabled in this trust-manager", e.Provider)
+}
+
+// Fetcher fetches the certificates of cloud sources from their providers, and
+// caches them until they are due to be refreshed. It is safe for concurrent
+// use.
+type Fetcher struct {
+	providers map[string]Provider
+	clock     clock.PassiveClock
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	certificates string
+	nextFetch    time.Time
+
+	// failures is the number of consecutive failed fetches.
+	failures int
+}
+
+// NewFetcher returns a Fetcher using every registered provider, which connect
+// to their APIs with an HTTP client configured by opts.
+func NewFetcher(opts httpclient.Options, clock clock.PassiveClock) (*Fetcher, error) {
+	client, err := httpclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	providers := make(map[string]Provider, len(factories))
+	for name, factory := range factories {
+		provider, err := factory(client, clock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s source provider: %w", name, err)
+		}
+		providers[name] = provider
+	}
+
+	return newFetcher(providers, clock), nil
+}
+
+func newFetcher(providers map[string]Provider, clock clock.PassiveClock) *Fetcher {
+	return &Fetcher{
+		providers: providers,
+		clock:     clock,
+		entries:   make(map[string]entry),
+	}
+}
+
+// SetProvider makes the provider available under the given name, in addition
+// to the registered providers. It must be called before the Fetcher is used.
+func (f *Fetcher) SetProvider(name string, provider Provider) {
+	f.providers[name] = provider
+}
+
+// Fetch returns the PEM certificates of the source. Certificates are only
+// fetched again once they are due to be refreshed. If fetching fails, the
+// certificates fetched last are returned along with the error, and fetching
+// is retried with an exponential backoff.
+func (f *Fetcher) Fetch(ctx context.Context, source Source) (string, error) {
+	provider, ok := f.providers[source.Provider]
+	if !ok {
+		return "", UnavailableError{Provider: source.Provider}
+	}
+
+	now := f.clock.Now()
+
+	f.mu.Lock()
+	cached, ok := f.entries[source.key()]
+	f.mu.Unlock()
+
+	if ok && now.Before(cached.nextFetch) {
+		return cached.certificates, nil
+	}
+
+	certificates, err := provider.Fetch(ctx, source.Ref)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil {
+		failures := cached.failures + 1
+		backoff := min(initialBackoff<<min(failures-1, 10), maxBackoff, source.refreshInterval())
+		f.entries[source.key()] = entry{certificates: cached.certificates, nextFetch: now.Add(backoff), failures: failures}
+		return cached.certificates, err
+	}
+
+	f.entries[source.key()] = entry{certificates: certificates, nextFetch: now.Add(source.refreshInterval())}
+	return certificates, nil
+}
+
+// RefreshIn returns the time until the certificates of the source are due to
+// be fetched again, or zero if they are due already.
+func (f *Fetcher) RefreshIn(source Source) time.Duration {
+	f.mu.Lock()
+	cached, ok := f.entries[source.key()]
+	f.mu.Unlock()
+
+	if !ok {
+		return 0
+	}
+	return max(cached.nextFetch.Sub(f.clock.Now()), 0)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+// fakeProvider returns its certificates, or its error if set, and counts the
+// fetches.
+type fakeProvider struct {
+	certificates string
+	err          error
+	fetches      int
+}
+
+func (p *fakeProvider) Fetch(_ context.Context, ref string) (string, error) {
+	p.fetches++
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.certificates, nil
+}
+
+func Test_Fetcher(t *testing.T) {
+	provider := &fakeProvider{certificates: dummy.TestCertificate1}
+	clock := fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	f := newFetcher(map[string]Provider{"Fake": provider}, clock)
+
+	source := Source{Provider: "Fake", Ref: "ca-1", RefreshInterval: time.Hour}
+	ctx := context.Background()
+
+	certificates, err := f.Fetch(ctx, source)
+	require.NoError(t, err)
+	assert.Equal(t, dummy.TestCertificate1, certificates)
+	assert.Equal(t, time.Hour, f.RefreshIn(source))
+
+	// The certificates are cached until they're due to be refreshed.
+	_, err = f.Fetch(ctx, source)
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.fetches)
+
+	// Failures return the certificates fetched last, and are retried with a
+	// backoff.
+	provider.err = errors.New("API unavailable")
+	clock.Step(time.Hour)
+	certificates, err = f.Fetch(ctx, source)
+	assert.EqualError(t, err, "API unavailable")
+	assert.Equal(t, dummy.TestCertificate1, certificates)
+	assert.Equal(t, initialBackoff, f.RefreshIn(source))
+
+	clock.Step(initialBackoff)
+	_, err = f.Fetch(ctx, source)
+	assert.Error(t, err)
+	assert.Equal(t, 2*initialBackoff, f.RefreshIn(source))
+
+	// The backoff is reset once fetching succeeds again.
+	provider.err = nil
+	provider.certificates = dummy.TestCertificate2
+	clock.Step(2 * initialBackoff)
+	certificates, err = f.Fetch(ctx, source)
+	require.NoError(t, err)
+	assert.Equal(t, dummy.TestCertificate2, certificates)
+	assert.Equal(t, time.Hour, f.RefreshIn(source))
+	assert.Equal(t, 4, provider.fetches)
+
+	// Sources of other providers are cached separately.
+	assert.Equal(t, time.Duration(0), f.RefreshIn(Source{Provider: "Other", Ref: "ca-1"}))
+}
+
+func Test_Fetcher_unavailableProvider(t *testing.T) {
+	f := newFetcher(map[string]Provider{}, fakeclock.NewFakeClock(time.Now()))
+
+	_, err := f.Fetch(context.Background(), Source{Provider: ProviderGCPCASPool, Ref: "projects/p/locations/l/caPools/pool"})
+	assert.EqualError(t, err, "the GCPCASPool source provider is not enabled in this trust-manager")
+	assert.ErrorAs(t, err, &UnavailableError{})
+}
+
+func Test_TokenCache(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var fetches int
+	cache := &TokenCache{
+		Clock: clock,
+		Fetch: func(context.Context) (Token, error) {
+			fetches++
+			return Token{Value: "token", Expires: clock.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	for range 2 {
+		token, err := cache.Get(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token", token)
+	}
+	assert.Equal(t, 1, fetches)
+
+	// Tokens are refreshed shortly before they expire.
+	clock.Step(time.Hour - tokenExpiryWindow)
+	_, err := cache.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+}
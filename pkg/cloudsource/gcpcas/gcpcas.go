@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcpcas is the cloud source provider which fetches the CA
+// certificates of Google Cloud Certificate Authority Service CA pools, so
+// that trust-manager can distribute trust in the certificates they issue.
+// Requests are authorized with the access tokens of the service account of
+// the metadata server, which GKE Workload Identity Federation provides.
+//
+// The provider is only compiled into builds of trust-manager with the
+// "gcpcas" build tag.
+package gcpcas
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+)
+
+const (
+	// maxResponseSize limits the size of the responses read from Google
+	// Cloud.
+	maxResponseSize = 4 << 20
+
+	// defaultEndpoint is the base URL of the Certificate Authority Service
+	// API.
+	defaultEndpoint = "https://privateca.googleapis.com"
+
+	// defaultMetadataHost is the host of the metadata server, which
+	// GCE_METADATA_HOST overrides.
+	defaultMetadataHost = "metadata.google.internal"
+)
+
+// poolNameRegexp matches the resource names of CA pools.
+var poolNameRegexp = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/caPools/[^/]+$`)
+
+func init() {
+	cloudsource.Register(cloudsource.ProviderGCPCASPool, func(client *http.Client, clock clock.PassiveClock) (cloudsource.Provider, error) {
+		return NewProvider(client, clock), nil
+	})
+}
+
+// Provider fetches the CA certificates of CA pools, whose resource names are
+// the references of its sources.
+type Provider struct {
+	client   *http.Client
+	endpoint string
+	tokens   *cloudsource.TokenCache
+}
+
+// NewProvider returns a Provider which connects to Google Cloud with the given
+// client, and with the access tokens of the metadata server.
+func NewProvider(client *http.Client, clock clock.PassiveClock) *Provider {
+	metadataURL := "http://" + cmp.Or(os.Getenv("GCE_METADATA_HOST"), defaultMetadataHost)
+	return &Provider{
+		client:   client,
+		endpoint: defaultEndpoint,
+		tokens: &cloudsource.TokenCache{
+			Clock: clock,
+			Fetch: func(ctx context.Context) (cloudsource.Token, error) {
+				return fetchMetadataToken(ctx, client, clock, metadataURL)
+			},
+		},
+	}
+}
+
+// fetchCaCertsResponse is the response of the FetchCaCerts method.
+type fetchCaCertsResponse struct {
+	CACerts []struct {
+		Certificates []string `json:"certificates"`
+	} `json:"caCerts"`
+}
+
+// apiErrorResponse is the response of Google Cloud APIs to a failed request.
+type apiErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Fetch returns the PEM certificates of the CAs of the CA pool with the given
+// resource name, with the chain of each CA. It calls the FetchCaCerts method
+// of the Certificate Authority Service API.
+func (p *Provider) Fetch(ctx context.Context, pool string) (string, error) {
+	if err := validatePoolName(pool); err != nil {
+		return "", err
+	}
+
+	token, err := p.tokens.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/v1/"+pool+":fetchCaCerts", bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	respBody, err := do(p.client, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get CA certificates of CA pool %q: %w", pool, err)
+	}
+
+	var certs fetchCaCertsResponse
+	if err := json.Unmarshal(respBody, &certs); err != nil {
+		return "", fmt.Errorf("invalid CA certificates of CA pool %q: %w", pool, err)
+	}
+
+	var bundle strings.Builder
+	for _, chain := range certs.CACerts {
+		for _, cert := range chain.Certificates {
+			if cert = strings.TrimSpace(cert); cert != "" {
+				bundle.WriteString(cert + "\n")
+			}
+		}
+	}
+	if bundle.Len() == 0 {
+		return "", fmt.Errorf("CA pool %q has no CA certificates", pool)
+	}
+	return bundle.String(), nil
+}
+
+// metadataTokenResponse is the access token returned by the metadata server.
+type metadataTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// fetchMetadataToken returns an access token of the default service account
+// of the metadata server.
+func fetchMetadataToken(ctx context.Context, client *http.Client, clock clock.PassiveClock, metadataURL string) (cloudsource.Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL+"/computeMetadata/v1/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return cloudsource.Token{}, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	respBody, err := do(client, req)
+	if err != nil {
+		return cloudsource.Token{}, fmt.Errorf("failed to get access token from metadata server: %w", err)
+	}
+
+	var token metadataTokenResponse
+	if err := json.Unmarshal(respBody, &token); err != nil {
+		return cloudsource.Token{}, fmt.Errorf("invalid access token from metadata server: %w", err)
+	}
+	if token.AccessToken == "" {
+		return cloudsource.Token{}, errors.New("metadata server returned no access token")
+	}
+
+	return cloudsource.Token{
+		Value:   token.AccessToken,
+		Expires: clock.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// do sends the request, and returns the body of a successful response.
+func do(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiErrorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("%s: %s", cmp.Or(apiErr.Error.Status, resp.Status), apiErr.Error.Message)
+		}
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return body, nil
+}
+
+// validatePoolName returns an error if the name isn't the resource name of a
+// CA pool.
+func validatePoolName(name string) error {
+	if !poolNameRegexp.MatchString(name) {
+		return fmt.Errorf("%q is not the resource name of a CA pool", name)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcpcas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/trust-manager/pkg/cloudsource"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+const testPool = "projects/my-project/locations/europe-west1/caPools/my-pool"
+
+func Test_Provider(t *testing.T) {
+	var (
+		tokenRequests int
+		fail          bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/computeMetadata/v1/instance/service-accounts/default/token":
+			tokenRequests++
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			_, _ = w.Write([]byte(`{"access_token": "access-token", "expires_in": 3600, "token_type": "Bearer"}`))
+
+		case "/v1/" + testPool + ":fetchCaCerts":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+			if fail {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error": {"code": 403, "status": "PERMISSION_DENIED", "message": "Permission 'privateca.caPools.get' denied"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"caCerts": [{"certificates": [` + jsonString(dummy.TestCertificate1) + `]}, {"certificates": [` + jsonString(dummy.TestCertificate2) + `]}]}`))
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clock := fakeclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p := &Provider{
+		client:   server.Client(),
+		endpoint: server.URL,
+		tokens: &cloudsource.TokenCache{
+			Clock: clock,
+			Fetch: func(ctx context.Context) (cloudsource.Token, error) {
+				return fetchMetadataToken(ctx, server.Client(), clock, server.URL)
+			},
+		},
+	}
+
+	certificates, err := p.Fetch(context.Background(), testPool)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(dummy.TestCertificate1)+"\n"+strings.TrimSpace(dummy.TestCertificate2)+"\n", certificates)
+
+	fail = true
+	_, err = p.Fetch(context.Background(), testPool)
+	assert.EqualError(t, err, `failed to get CA certificates of CA pool "`+testPool+`": PERMISSION_DENIED: Permission 'privateca.caPools.get' denied`)
+
+	// The access token is reused until it's about to expire.
+	assert.Equal(t, 1, tokenRequests)
+
+	_, err = p.Fetch(context.Background(), "projects/my-project/caPools/my-pool")
+	assert.EqualError(t, err, `"projects/my-project/caPools/my-pool" is not the resource name of a CA pool`)
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsource
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// tokenExpiryWindow is how long before they expire cached tokens are
+// refreshed.
+const tokenExpiryWindow = 5 * time.Minute
+
+// Token is a bearer token for the API of a cloud provider.
+type Token struct {
+	Value   string
+	Expires time.Time
+}
+
+// TokenCache caches the token returned by its Fetch function until shortly
+// before the token expires. It is safe for concurrent use.
+type TokenCache struct {
+	Clock clock.PassiveClock
+	Fetch func(ctx context.Context) (Token, error)
+
+	mu    sync.Mutex
+	token Token
+}
+
+// Get returns the cached token, or a new token if the cached one is about to
+// expire.
+func (c *TokenCache) Get(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.Value != "" && c.Clock.Now().Before(c.token.Expires.Add(-tokenExpiryWindow)) {
+		return c.token.Value, nil
+	}
+
+	token, err := c.Fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = token
+	return token.Value, nil
+}
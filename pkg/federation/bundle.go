@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// x509SVIDUse is the "use" of the JWKs in a SPIFFE bundle which hold X.509
+// authorities. Other uses, such as "jwt-svid", are ignored.
+const x509SVIDUse = "x509-svid"
+
+// Bundle is the SPIFFE bundle of a trust domain.
+type Bundle struct {
+	// X509Authorities are the X.509 authorities of the trust domain.
+	X509Authorities []*x509.Certificate
+
+	// RefreshHint is how often the bundle should be fetched again, as
+	// suggested by the trust domain. Zero if the bundle has no hint.
+	RefreshHint time.Duration
+
+	// SequenceNumber is the sequence number of the bundle, if it has one.
+	SequenceNumber *uint64
+}
+
+// bundleDocument is the JWK Set encoding of a SPIFFE bundle.
+type bundleDocument struct {
+	Keys           []bundleKey `json:"keys"`
+	RefreshHint    *int64      `json:"spiffe_refresh_hint,omitempty"`
+	SequenceNumber *uint64     `json:"spiffe_sequence,omitempty"`
+}
+
+type bundleKey struct {
+	Use string `json:"use"`
+	// X5C is the certificate chain of the key, as base64 encoded DER.
+	X5C [][]byte `json:"x5c"`
+}
+
+// ParseBundle parses a SPIFFE bundle in its JWK Set encoding, as served by
+// bundle endpoints. Only the X.509 authorities of the bundle are kept.
+func ParseBundle(data []byte) (*Bundle, error) {
+	var doc bundleDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+	if doc.Keys == nil {
+		return nil, errors.New("bundle has no keys")
+	}
+
+	bundle := &Bundle{SequenceNumber: doc.SequenceNumber}
+	if doc.RefreshHint != nil {
+		if *doc.RefreshHint < 0 {
+			return nil, fmt.Errorf("bundle has a negative refresh hint: %d", *doc.RefreshHint)
+		}
+		bundle.RefreshHint = time.Duration(*doc.RefreshHint) * time.Second
+	}
+
+	for i, key := range doc.Keys {
+		if key.Use != x509SVIDUse {
+			continue
+		}
+		if len(key.X5C) != 1 {
+			return nil, fmt.Errorf("X.509 authority %d must hold exactly one certificate, but holds %d", i, len(key.X5C))
+		}
+		cert, err := x509.ParseCertificate(key.X5C[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse X.509 authority %d: %w", i, err)
+		}
+		bundle.X509Authorities = append(bundle.X509Authorities, cert)
+	}
+
+	if len(bundle.X509Authorities) == 0 {
+		return nil, errors.New("bundle has no X.509 authorities")
+	}
+
+	return bundle, nil
+}
+
+// PEM returns the X.509 authorities of the bundle as a PEM bundle.
+func (b *Bundle) PEM() string {
+	var buf bytes.Buffer
+	for _, cert := range b.X509Authorities {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.String()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBundle(t *testing.T) {
+	ca, _ := newCA(t, "example.org")
+	x5c := base64.StdEncoding.EncodeToString(ca.Raw)
+
+	tests := map[string]struct {
+		data    string
+		expErr  string
+		expHint time.Duration
+	}{
+		"bundle with X.509 and JWT authorities": {
+			data:    fmt.Sprintf(`{"keys": [{"use": "x509-svid", "kty": "EC", "x5c": [%q]}, {"use": "jwt-svid", "kty": "EC"}], "spiffe_refresh_hint": 60, "spiffe_sequence": 3}`, x5c),
+			expHint: time.Minute,
+		},
+		"not JSON": {
+			data:   "-----BEGIN CERTIFICATE-----",
+			expErr: "failed to decode bundle",
+		},
+		"no keys": {
+			data:   `{}`,
+			expErr: "bundle has no keys",
+		},
+		"only JWT authorities": {
+			data:   `{"keys": [{"use": "jwt-svid", "kty": "EC"}]}`,
+			expErr: "bundle has no X.509 authorities",
+		},
+		"X.509 authority with a chain": {
+			data:   fmt.Sprintf(`{"keys": [{"use": "x509-svid", "x5c": [%q, %q]}]}`, x5c, x5c),
+			expErr: "X.509 authority 0 must hold exactly one certificate, but holds 2",
+		},
+		"invalid certificate": {
+			data:   `{"keys": [{"use": "x509-svid", "x5c": ["AAAA"]}]}`,
+			expErr: "failed to parse X.509 authority 0",
+		},
+		"negative refresh hint": {
+			data:   fmt.Sprintf(`{"keys": [{"use": "x509-svid", "x5c": [%q]}], "spiffe_refresh_hint": -1}`, x5c),
+			expErr: "bundle has a negative refresh hint: -1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := ParseBundle([]byte(test.data))
+			if test.expErr != "" {
+				assert.ErrorContains(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, bundle.X509Authorities, 1)
+			assert.True(t, bundle.X509Authorities[0].Equal(ca))
+			assert.Equal(t, test.expHint, bundle.RefreshHint)
+			assert.Equal(t, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})), bundle.PEM())
+		})
+	}
+}
+
+// newCA returns a self-signed CA certificate of the trust domain, and its key.
+func newCA(t *testing.T, trustDomain string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{trustDomain}},
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: trustDomain}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+// bundleJSON returns the JWK Set encoding of a bundle of the authorities.
+func bundleJSON(refreshHint int, authorities ...*x509.Certificate) string {
+	keys := make([]string, 0, len(authorities))
+	for _, cert := range authorities {
+		keys = append(keys, fmt.Sprintf(`{"use": "x509-svid", "kty": "EC", "x5c": [%q]}`, base64.StdEncoding.EncodeToString(cert.Raw)))
+	}
+	return fmt.Sprintf(`{"keys": [%s], "spiffe_refresh_hint": %d}`, strings.Join(keys, ", "), refreshHint)
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package federation fetches the trust bundles of other SPIFFE trust domains
+// from their bundle endpoints, following the SPIFFE Trust Domain and Bundle
+// specification, so that trust-manager can act as the federation client of
+// the cluster.
+package federation
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+)
+
+const (
+	// DefaultRefreshInterval is how often bundles are fetched if neither the
+	// endpoint nor the bundle sets a refresh interval.
+	DefaultRefreshInterval = 5 * time.Minute
+
+	// retryInterval bounds the time until a failed fetch is retried.
+	retryInterval = time.Minute
+
+	// maxBundleSize limits the size of the bundles read from endpoints.
+	maxBundleSize = 4 << 20
+)
+
+// Profile is the profile of a bundle endpoint, which determines how the
+// endpoint is authenticated.
+type Profile string
+
+const (
+	// ProfileHTTPSWeb authenticates the endpoint with Web PKI.
+	ProfileHTTPSWeb Profile = "https_web"
+
+	// ProfileHTTPSSPIFFE authenticates the endpoint by its X.509-SVID.
+	ProfileHTTPSSPIFFE Profile = "https_spiffe"
+)
+
+// Endpoint is a bundle endpoint serving the bundle of a trust domain.
+type Endpoint struct {
+	// TrustDomain is the trust domain whose bundle is served.
+	TrustDomain string
+
+	// URL is the HTTPS URL of the endpoint.
+	URL string
+
+	// Profile is the profile of the endpoint. Defaults to ProfileHTTPSWeb.
+	Profile Profile
+
+	// SPIFFEID is the SPIFFE ID of the endpoint, for ProfileHTTPSSPIFFE.
+	SPIFFEID string
+
+	// BootstrapPEM holds the PEM X.509 authorities of the trust domain of
+	// SPIFFEID, which the endpoint is authenticated against, for
+	// ProfileHTTPSSPIFFE.
+	BootstrapPEM []byte
+
+	// RefreshInterval, if positive, is how often the bundle is fetched in
+	// place of the refresh hint of the bundle.
+	RefreshInterval time.Duration
+
+	// PresentToken, if true, presents the token of the Fetcher to the
+	// endpoint as a bearer token.
+	PresentToken bool
+}
+
+// key identifies the cached bundle of the endpoint.
+func (e Endpoint) key() string {
+	return e.TrustDomain + " " + e.URL
+}
+
+// refreshInterval returns how often the bundle of the endpoint is fetched.
+func (e Endpoint) refreshInterval(bundle *Bundle) time.Duration {
+	switch {
+	case e.RefreshInterval > 0:
+		return e.RefreshInterval
+	case bundle.RefreshHint > 0:
+		return bundle.RefreshHint
+	default:
+		return DefaultRefreshInterval
+	}
+}
+
+// Fetcher fetches bundles from bundle endpoints, and caches them until they
+// are due to be refreshed. It is safe for concurrent use.
+type Fetcher struct {
+	client *http.Client
+
+	// tokenFile is the path of the bearer token presented to endpoints, such
+	// as a projected ServiceAccount token. It is read on each fetch, as the
+	// kubelet rotates it.
+	tokenFile string
+
+	clock clock.PassiveClock
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	bundle    *Bundle
+	nextFetch time.Time
+}
+
+// NewFetcher returns a Fetcher which connects to endpoints with an HTTP client
+// configured by opts, and presents the token read from tokenFile to endpoints
+// which ask for it.
+func NewFetcher(opts httpclient.Options, tokenFile string, clock clock.PassiveClock) (*Fetcher, error) {
+	client, err := httpclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fetcher{
+		client:    client,
+		tokenFile: tokenFile,
+		clock:     clock,
+		entries:   make(map[string]entry),
+	}, nil
+}
+
+// Fetch returns the bundle served by the endpoint. A bundle is only fetched
+// again once it is due to be refreshed. If fetching fails, the bundle fetched
+// last is returned along with the error, and fetching is retried sooner.
+func (f *Fetcher) Fetch(ctx context.Context, endpoint Endpoint) (*Bundle, error) {
+	now := f.clock.Now()
+
+	f.mu.Lock()
+	cached, ok := f.entries[endpoint.key()]
+	f.mu.Unlock()
+
+	if ok && now.Before(cached.nextFetch) {
+		return cached.bundle, nil
+	}
+
+	bundle, err := f.fetch(ctx, endpoint, cached.bundle)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil {
+		if !ok {
+			return nil, err
+		}
+		f.entries[endpoint.key()] = entry{
+			bundle:    cached.bundle,
+			nextFetch: now.Add(min(retryInterval, endpoint.refreshInterval(cached.bundle))),
+		}
+		return cached.bundle, err
+	}
+
+	f.entries[endpoint.key()] = entry{bundle: bundle, nextFetch: now.Add(endpoint.refreshInterval(bundle))}
+	return bundle, nil
+}
+
+// RefreshIn returns the time until the bundle of the endpoint is due to be
+// fetched again, or zero if it is due already.
+func (f *Fetcher) RefreshIn(endpoint Endpoint) time.Duration {
+	f.mu.Lock()
+	cached, ok := f.entries[endpoint.key()]
+	f.mu.Unlock()
+
+	if !ok {
+		return 0
+	}
+	return max(cached.nextFetch.Sub(f.clock.Now()), 0)
+}
+
+// fetch fetches the bundle from the endpoint. previous is the bundle fetched
+// last, if any.
+func (f *Fetcher) fetch(ctx context.Context, endpoint Endpoint, previous *Bundle) (*Bundle, error) {
+	endpointURL, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint URL: %w", err)
+	}
+	if endpointURL.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint URL %q must use https", endpoint.URL)
+	}
+
+	client := f.client
+	if endpoint.Profile == ProfileHTTPSSPIFFE {
+		roots, err := endpointRoots(endpoint, previous)
+		if err != nil {
+			return nil, err
+		}
+
+		transport := f.client.Transport.(*http.Transport).Clone()
+		defer transport.CloseIdleConnections()
+
+		// The endpoint is authenticated by its SPIFFE ID rather than its
+		// DNS name, so the standard verification is replaced.
+		transport.TLSClientConfig.InsecureSkipVerify = true // #nosec G402
+		transport.TLSClientConfig.VerifyPeerCertificate = verifySVID(roots, endpoint.SPIFFEID)
+		client = &http.Client{Transport: transport, Timeout: f.client.Timeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.PresentToken {
+		token, err := f.token()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bundle of trust domain %q: %w", endpoint.TrustDomain, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch bundle of trust domain %q: unexpected status %s", endpoint.TrustDomain, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBundleSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle of trust domain %q: %w", endpoint.TrustDomain, err)
+	}
+	if len(data) > maxBundleSize {
+		return nil, fmt.Errorf("bundle of trust domain %q exceeds %d bytes", endpoint.TrustDomain, maxBundleSize)
+	}
+
+	bundle, err := ParseBundle(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle of trust domain %q: %w", endpoint.TrustDomain, err)
+	}
+
+	return bundle, nil
+}
+
+// token returns the bearer token presented to endpoints.
+func (f *Fetcher) token() (string, error) {
+	if f.tokenFile == "" {
+		return "", errors.New("no token was configured to present to bundle endpoints")
+	}
+
+	data, err := os.ReadFile(f.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// endpointRoots returns the X.509 authorities which an https_spiffe endpoint
+// is authenticated against: the bootstrap bundle and, if the endpoint belongs
+// to the trust domain whose bundle it serves, the bundle fetched last.
+func endpointRoots(endpoint Endpoint, previous *Bundle) (*x509.CertPool, error) {
+	endpointTrustDomain, err := trustDomainOf(endpoint.SPIFFEID)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	empty := !roots.AppendCertsFromPEM(endpoint.BootstrapPEM)
+	if previous != nil && endpointTrustDomain == endpoint.TrustDomain {
+		for _, cert := range previous.X509Authorities {
+			roots.AddCert(cert)
+			empty = false
+		}
+	}
+
+	if empty {
+		return nil, fmt.Errorf("no X.509 authorities of trust domain %q to authenticate the endpoint with", endpointTrustDomain)
+	}
+
+	return roots, nil
+}
+
+// verifySVID returns a function which verifies that the peer presents an
+// X.509-SVID for the SPIFFE ID, issued by one of the roots.
+func verifySVID(roots *x509.CertPool, spiffeID string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("endpoint presented no certificate")
+		}
+
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("failed to parse endpoint certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		leaf := certs[0]
+		if _, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return fmt.Errorf("failed to verify endpoint X.509-SVID: %w", err)
+		}
+
+		if len(leaf.URIs) != 1 || leaf.URIs[0].String() != spiffeID {
+			return fmt.Errorf("endpoint X.509-SVID is not for SPIFFE ID %q", spiffeID)
+		}
+
+		return nil
+	}
+}
+
+// trustDomainOf returns the trust domain of the SPIFFE ID.
+func trustDomainOf(spiffeID string) (string, error) {
+	id, err := url.Parse(spiffeID)
+	if err != nil || id.Scheme != "spiffe" || id.Host == "" {
+		return "", fmt.Errorf("invalid SPIFFE ID %q", spiffeID)
+	}
+	return id.Host, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/trust-manager/pkg/httpclient"
+)
+
+func TestFetcher_httpsWeb(t *testing.T) {
+	ca, _ := newCA(t, "example.org")
+
+	var (
+		requests atomic.Int32
+		fail     atomic.Bool
+	)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(bundleJSON(600, ca)))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	fetcher, err := NewFetcher(httpclient.Options{CAFile: caFile}, "", clock)
+	require.NoError(t, err)
+
+	endpoint := Endpoint{TrustDomain: "example.org", URL: server.URL}
+	assert.Zero(t, fetcher.RefreshIn(endpoint))
+
+	bundle, err := fetcher.Fetch(context.TODO(), endpoint)
+	require.NoError(t, err)
+	require.Len(t, bundle.X509Authorities, 1)
+	assert.True(t, bundle.X509Authorities[0].Equal(ca))
+	assert.Equal(t, int32(1), requests.Load())
+
+	// The bundle is cached until its refresh hint has passed.
+	clock.SetTime(clock.Now().Add(5 * time.Minute))
+	assert.Equal(t, 5*time.Minute, fetcher.RefreshIn(endpoint))
+	_, err = fetcher.Fetch(context.TODO(), endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Once due, a failed fetch returns the cached bundle and is retried
+	// sooner.
+	clock.SetTime(clock.Now().Add(5 * time.Minute))
+	fail.Store(true)
+	stale, err := fetcher.Fetch(context.TODO(), endpoint)
+	assert.ErrorContains(t, err, "unexpected status 503 Service Unavailable")
+	assert.Same(t, bundle, stale)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, retryInterval, fetcher.RefreshIn(endpoint))
+
+	// The refresh interval of the endpoint takes precedence over the hint.
+	endpoint.RefreshInterval = 30 * time.Second
+	fail.Store(false)
+	clock.SetTime(clock.Now().Add(retryInterval))
+	_, err = fetcher.Fetch(context.TODO(), endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, fetcher.RefreshIn(endpoint))
+
+	// Without a cached bundle, failures are returned alone.
+	_, err = fetcher.Fetch(context.TODO(), Endpoint{TrustDomain: "example.org", URL: "http://example.org"})
+	assert.EqualError(t, err, `endpoint URL "http://example.org" must use https`)
+}
+
+func TestFetcher_token(t *testing.T) {
+	ca, _ := newCA(t, "example.org")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer projected-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(bundleJSON(0, ca)))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("projected-token\n"), 0o600))
+
+	endpoint := Endpoint{TrustDomain: "example.org", URL: server.URL, PresentToken: true}
+
+	fetcher, err := NewFetcher(httpclient.Options{CAFile: caFile}, "", clocktesting.NewFakePassiveClock(time.Now()))
+	require.NoError(t, err)
+	_, err = fetcher.Fetch(context.TODO(), endpoint)
+	assert.EqualError(t, err, "no token was configured to present to bundle endpoints")
+
+	fetcher, err = NewFetcher(httpclient.Options{CAFile: caFile}, tokenFile, clocktesting.NewFakePassiveClock(time.Now()))
+	require.NoError(t, err)
+	bundle, err := fetcher.Fetch(context.TODO(), endpoint)
+	require.NoError(t, err)
+	assert.Len(t, bundle.X509Authorities, 1)
+	assert.Equal(t, DefaultRefreshInterval, fetcher.RefreshIn(endpoint))
+}
+
+func TestFetcher_httpsSPIFFE(t *testing.T) {
+	ca, caKey := newCA(t, "example.org")
+	rotatedCA, _ := newCA(t, "example.org")
+
+	svid := newSVID(t, ca, caKey, "spiffe://example.org/spire/server")
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(bundleJSON(0, ca, rotatedCA)))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{svid}, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	rotatedPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rotatedCA.Raw})
+
+	tests := map[string]struct {
+		endpoint Endpoint
+		expErr   string
+	}{
+		"endpoint with the expected SPIFFE ID": {
+			endpoint: Endpoint{SPIFFEID: "spiffe://example.org/spire/server", BootstrapPEM: caPEM},
+		},
+		"endpoint with another SPIFFE ID": {
+			endpoint: Endpoint{SPIFFEID: "spiffe://example.org/other", BootstrapPEM: caPEM},
+			expErr:   `endpoint X.509-SVID is not for SPIFFE ID "spiffe://example.org/other"`,
+		},
+		"endpoint not issued by the bootstrap bundle": {
+			endpoint: Endpoint{SPIFFEID: "spiffe://example.org/spire/server", BootstrapPEM: rotatedPEM},
+			expErr:   "failed to verify endpoint X.509-SVID",
+		},
+		"empty bootstrap bundle": {
+			endpoint: Endpoint{SPIFFEID: "spiffe://example.org/spire/server"},
+			expErr:   `no X.509 authorities of trust domain "example.org" to authenticate the endpoint with`,
+		},
+		"invalid SPIFFE ID": {
+			endpoint: Endpoint{SPIFFEID: "https://example.org", BootstrapPEM: caPEM},
+			expErr:   `invalid SPIFFE ID "https://example.org"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fetcher, err := NewFetcher(httpclient.Options{}, "", clocktesting.NewFakePassiveClock(time.Now()))
+			require.NoError(t, err)
+
+			endpoint := test.endpoint
+			endpoint.TrustDomain = "example.org"
+			endpoint.URL = server.URL
+			endpoint.Profile = ProfileHTTPSSPIFFE
+
+			bundle, err := fetcher.Fetch(context.TODO(), endpoint)
+			if test.expErr != "" {
+				assert.ErrorContains(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, bundle.X509Authorities, 2)
+		})
+	}
+}
+
+func Test_endpointRoots(t *testing.T) {
+	bootstrap, _ := newCA(t, "example.org")
+	fetched, _ := newCA(t, "example.org")
+	previous := &Bundle{X509Authorities: []*x509.Certificate{fetched}}
+	bootstrapPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: bootstrap.Raw})
+
+	// The bundle fetched last authenticates endpoints of its own trust domain.
+	roots, err := endpointRoots(Endpoint{TrustDomain: "example.org", SPIFFEID: "spiffe://example.org/server", BootstrapPEM: bootstrapPEM}, previous)
+	require.NoError(t, err)
+	assert.True(t, roots.Equal(poolOf(bootstrap, fetched)))
+
+	// It doesn't authenticate endpoints of other trust domains.
+	roots, err = endpointRoots(Endpoint{TrustDomain: "example.org", SPIFFEID: "spiffe://other.org/server", BootstrapPEM: bootstrapPEM}, previous)
+	require.NoError(t, err)
+	assert.True(t, roots.Equal(poolOf(bootstrap)))
+}
+
+// newSVID returns an X.509-SVID for the SPIFFE ID issued by the CA.
+func newSVID(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, spiffeID string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		URIs:         []*url.URL{id},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func poolOf(certs ...*x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, cert := range certs {
+		pool.AddCert(cert)
+	}
+	return pool
+}
@@ -17,13 +17,21 @@ limitations under the License.
 package fspkg
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/cert-manager/trust-manager/pkg/util"
 )
@@ -42,6 +50,14 @@ type Package struct {
 
 	// Version identifies the bundle's version, to distinguish updated bundles from older counterparts
 	Version string `json:"version"`
+
+	// Remove contains the hex-encoded SHA-256 fingerprints of certificates which this package
+	// removes from the packages layered below it. See LayerPackages.
+	Remove []string `json:"remove,omitempty"`
+
+	// BuildDate is the RFC 3339 time at which the package was built, which trust-manager uses to
+	// warn about outdated packages. It's optional.
+	BuildDate string `json:"buildDate,omitempty"`
 }
 
 // StringID returns a human-readable string ID which should allow one package to be easily distinguished from another.
@@ -57,7 +73,25 @@ func (p *Package) Clone() *Package {
 		Name:    p.Name,
 		Bundle:  p.Bundle,
 		Version: p.Version,
+		Remove:  slices.Clone(p.Remove),
+
+		BuildDate: p.BuildDate,
+	}
+}
+
+// BuildTime returns the time at which the package was built, and false if the package doesn't
+// specify it.
+func (p Package) BuildTime() (time.Time, bool) {
+	if p.BuildDate == "" {
+		return time.Time{}, false
 	}
+
+	buildTime, err := time.Parse(time.RFC3339, p.BuildDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return buildTime, true
 }
 
 // Validate checks that the given package is valid. All packages must successfully validate before being accepted for use.
@@ -65,10 +99,25 @@ func (p *Package) Validate() error {
 	// Ignore the sanitized bundle here and preserve the bundle as-is.
 	// We'll sanitize later, when building a bundle on a reconcile.
 
-	certPool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
+	// A package which only removes certificates from the packages below it may have an empty bundle.
+	if len(p.Bundle) > 0 || len(p.Remove) == 0 {
+		certPool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
 
-	if err := certPool.AddCertsFromPEM([]byte(p.Bundle)); err != nil {
-		return fmt.Errorf("package bundle failed validation: %w", err)
+		if err := certPool.AddCertsFromPEM([]byte(p.Bundle)); err != nil {
+			return fmt.Errorf("package bundle failed validation: %w", err)
+		}
+	}
+
+	if p.BuildDate != "" {
+		if _, err := time.Parse(time.RFC3339, p.BuildDate); err != nil {
+			return fmt.Errorf("package 'buildDate' must be an RFC 3339 time: %w", err)
+		}
+	}
+
+	for _, fingerprint := range p.Remove {
+		if !fingerprintRegexp.MatchString(fingerprint) {
+			return fmt.Errorf("package 'remove' entry %q is not a hex-encoded SHA-256 fingerprint", fingerprint)
+		}
 	}
 
 	if len(p.Name) == 0 {
@@ -82,6 +131,75 @@ func (p *Package) Validate() error {
 	return nil
 }
 
+var fingerprintRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// LayerPackages merges the given packages into one, layering each package on top of the ones
+// before it. Each layer first removes the certificates listed in its 'remove' field from the
+// layers below it, and then adds its own certificates which aren't already present. The merged
+// bundle lists certificates in the order of the layers which added them, so that the result is
+// deterministic. The name and version of the merged package join those of the layers with '+',
+// and its build date is that of the first layer, which is usually the base package of the
+// operating system.
+func LayerPackages(layers ...Package) (Package, error) {
+	if len(layers) == 0 {
+		return Package{}, errors.New("at least one package must be given")
+	}
+
+	var (
+		names        []string
+		versions     []string
+		certificates []*x509.Certificate
+	)
+
+	for i, layer := range layers {
+		if err := layer.Validate(); err != nil {
+			return Package{}, fmt.Errorf("package %d (%q) failed validation: %w", i, layer.Name, err)
+		}
+
+		names = append(names, layer.Name)
+		versions = append(versions, layer.Version)
+
+		certificates = slices.DeleteFunc(certificates, func(certificate *x509.Certificate) bool {
+			return slices.Contains(layer.Remove, fingerprint(certificate))
+		})
+
+		if len(layer.Bundle) == 0 {
+			continue
+		}
+
+		certPool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
+		if err := certPool.AddCertsFromPEM([]byte(layer.Bundle)); err != nil {
+			return Package{}, fmt.Errorf("package %d (%q) failed validation: %w", i, layer.Name, err)
+		}
+
+		for _, certificate := range certPool.Certificates() {
+			if !slices.ContainsFunc(certificates, certificate.Equal) {
+				certificates = append(certificates, certificate)
+			}
+		}
+	}
+
+	var bundle bytes.Buffer
+	for _, certificate := range certificates {
+		if err := pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}); err != nil {
+			return Package{}, fmt.Errorf("failed to encode merged package: %w", err)
+		}
+	}
+
+	return Package{
+		Name:    strings.Join(names, "+"),
+		Bundle:  bundle.String(),
+		Version: strings.Join(versions, "+"),
+
+		BuildDate: layers[0].BuildDate,
+	}, nil
+}
+
+func fingerprint(certificate *x509.Certificate) string {
+	hash := sha256.Sum256(certificate.Raw)
+	return hex.EncodeToString(hash[:])
+}
+
 // LoadPackage tries to read a package from the given reader, checking that it only contains valid certificates
 func LoadPackage(reader io.Reader) (Package, error) {
 	var pkg Package
@@ -98,26 +216,141 @@ func LoadPackage(reader io.Reader) (Package, error) {
 	return pkg, nil
 }
 
-const requiredExt = ".json"
+const (
+	jsonExt = ".json"
+
+	// metadataSuffix replaces the extension of a PEM package to give the path of its optional
+	// metadata file.
+	metadataSuffix = ".metadata.json"
+)
+
+// pemExts are the extensions of files which are loaded as PEM packages.
+var pemExts = []string{".pem", ".crt"}
+
+// LoadPEMPackage reads a package with the given name and version from a reader of a plain PEM
+// bundle, checking that it only contains valid certificates.
+func LoadPEMPackage(reader io.Reader, name, version string) (Package, error) {
+	bundle, err := io.ReadAll(reader)
+	if err != nil {
+		return Package{}, fmt.Errorf("failed to read PEM package: %w", err)
+	}
+
+	pkg := Package{
+		Name:    name,
+		Bundle:  string(bundle),
+		Version: version,
+	}
+	if err := pkg.Validate(); err != nil {
+		return Package{}, err
+	}
+
+	return pkg, nil
+}
+
+// LoadPackageFromFile reads a package from a file. Files ending in ".json" are read with
+// LoadPackage. Files ending in ".pem" or ".crt" are read as plain PEM bundles with
+// LoadPEMPackage: their name, version and build date are read from a metadata file next to them, with
+// the extension replaced by ".metadata.json", if it exists. Otherwise, the name is the
+// filename without its extension, and the version is derived from the bundle's contents.
+func LoadPackageFromFile(path string, options ...LoadOption) (Package, error) {
+	var opts loadOptions
+	for _, option := range options {
+		option(&opts)
+	}
 
-// LoadPackageFromFile uses LoadPackage to read a JSON file specifying a package
-func LoadPackageFromFile(path string) (Package, error) {
-	// Only try to read files ending in ".json"
-	if filepath.Ext(path) != requiredExt {
-		return Package{}, fmt.Errorf("can't load package at path %q since it doesn't have the required %q extension", path, requiredExt)
+	ext := filepath.Ext(path)
+	if ext != jsonExt && !slices.Contains(pemExts, ext) {
+		return Package{}, fmt.Errorf("can't load package at path %q since it doesn't have one of the supported extensions %q", path, append([]string{jsonExt}, pemExts...))
 	}
 
-	f, err := os.Open(path)
+	// Read the file once, so that the package loaded is the one which was verified.
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return Package{}, fmt.Errorf("failed to open package on filesystem %q: %w", path, err)
 	}
 
-	defer f.Close()
+	if opts.verificationKey != nil {
+		if err := verifyPackageSignature(path, data, opts.verificationKey); err != nil {
+			return Package{}, fmt.Errorf("failed to verify package %q: %w", path, err)
+		}
+	}
 
-	pkg, err := LoadPackage(f)
+	var pkg Package
+	if ext == jsonExt {
+		pkg, err = LoadPackage(bytes.NewReader(data))
+	} else {
+		pkg, err = loadPEMPackageFromFile(data, path)
+	}
 	if err != nil {
 		return Package{}, fmt.Errorf("failed to load package %q: %w", path, err)
 	}
 
 	return pkg, nil
 }
+
+// pemPackageMetadata is the metadata file of a PEM package.
+type pemPackageMetadata struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	BuildDate string `json:"buildDate,omitempty"`
+}
+
+func loadPEMPackageFromFile(bundle []byte, path string) (Package, error) {
+	bundleHash := sha256.Sum256(bundle)
+	metadata := pemPackageMetadata{
+		Name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Version: hex.EncodeToString(bundleHash[:8]),
+	}
+
+	metadataPath := strings.TrimSuffix(path, filepath.Ext(path)) + metadataSuffix
+	metadataJSON, err := os.ReadFile(metadataPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return Package{}, fmt.Errorf("failed to read package metadata %q: %w", metadataPath, err)
+	default:
+		var fileMetadata pemPackageMetadata
+		if err := json.Unmarshal(metadataJSON, &fileMetadata); err != nil {
+			return Package{}, fmt.Errorf("failed to parse package metadata %q: %w", metadataPath, err)
+		}
+		if fileMetadata.Name != "" {
+			metadata.Name = fileMetadata.Name
+		}
+		if fileMetadata.Version != "" {
+			metadata.Version = fileMetadata.Version
+		}
+		metadata.BuildDate = fileMetadata.BuildDate
+	}
+
+	pkg, err := LoadPEMPackage(bytes.NewReader(bundle), metadata.Name, metadata.Version)
+	if err != nil {
+		return Package{}, err
+	}
+
+	pkg.BuildDate = metadata.BuildDate
+	if err := pkg.Validate(); err != nil {
+		return Package{}, err
+	}
+
+	return pkg, nil
+}
+
+// LoadLayeredPackageFromFiles uses LoadPackageFromFile to read each of the given files, and
+// merges the packages with LayerPackages, in the given order.
+func LoadLayeredPackageFromFiles(paths []string, options ...LoadOption) (Package, error) {
+	layers := make([]Package, 0, len(paths))
+	for _, path := range paths {
+		pkg, err := LoadPackageFromFile(path, options...)
+		if err != nil {
+			return Package{}, err
+		}
+		layers = append(layers, pkg)
+	}
+
+	pkg, err := LayerPackages(layers...)
+	if err != nil {
+		return Package{}, fmt.Errorf("failed to layer packages %q: %w", paths, err)
+	}
+
+	return pkg, nil
+}
@@ -18,7 +18,13 @@ package fspkg
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/cert-manager/trust-manager/test/dummy"
@@ -68,6 +74,24 @@ func Test_LoadPackage(t *testing.T) {
 			}),
 			expError: true,
 		},
+		"package with invalid build date is rejected": {
+			testData: quickJSONFromPackage(Package{
+				Name:      "asd",
+				Version:   "123",
+				Bundle:    dummy.TestCertificate5,
+				BuildDate: "yesterday",
+			}),
+			expError: true,
+		},
+		"package with build date is loaded without error": {
+			testData: quickJSONFromPackage(Package{
+				Name:      "asd",
+				Version:   "123",
+				Bundle:    dummy.TestCertificate5,
+				BuildDate: "2026-01-01T00:00:00Z",
+			}),
+			expError: false,
+		},
 		"valid package is loaded without error": {
 			testData: quickJSONFromPackage(Package{
 				Name:    "asd",
@@ -91,3 +115,121 @@ func Test_LoadPackage(t *testing.T) {
 		})
 	}
 }
+
+func Test_LayerPackages(t *testing.T) {
+	base := Package{
+		Name:    "debian",
+		Version: "1",
+		Bundle:  dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2),
+	}
+	addendum := Package{
+		Name:    "corporate",
+		Version: "2",
+		Bundle:  dummy.TestCertificate3,
+		Remove:  []string{pemFingerprints(t, dummy.TestCertificate2)[0]},
+	}
+	duplicate := Package{
+		Name:    "duplicate",
+		Version: "3",
+		Bundle:  dummy.TestCertificate1,
+	}
+
+	pkg, err := LayerPackages(base, addendum, duplicate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pkg.Name != "debian+corporate+duplicate" || pkg.Version != "1+2+3" {
+		t.Errorf("unexpected name %q and version %q", pkg.Name, pkg.Version)
+	}
+
+	// The certificates of the base layer come first, the removed certificate is
+	// dropped, and the duplicate isn't added again.
+	expected := pemFingerprints(t, dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate3))
+	if got := pemFingerprints(t, pkg.Bundle); !slices.Equal(got, expected) {
+		t.Errorf("expected certificates %v, got %v", expected, got)
+	}
+
+	if err := pkg.Validate(); err != nil {
+		t.Errorf("expected layered package to be valid: %v", err)
+	}
+
+	if _, err := LayerPackages(); err == nil {
+		t.Error("expected an error when layering no packages")
+	}
+
+	invalid := addendum.Clone()
+	invalid.Remove = []string{"not-a-fingerprint"}
+	if _, err := LayerPackages(base, *invalid); err == nil {
+		t.Error("expected an error when a layer removes an invalid fingerprint")
+	}
+
+	removeOnly := Package{Name: "remove-only", Version: "1", Remove: addendum.Remove}
+	pkg, err = LayerPackages(base, removeOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, expected := pemFingerprints(t, pkg.Bundle), pemFingerprints(t, dummy.TestCertificate1); !slices.Equal(got, expected) {
+		t.Errorf("expected certificates %v, got %v", expected, got)
+	}
+}
+
+func pemFingerprints(t *testing.T, bundle string) []string {
+	t.Helper()
+
+	var fingerprints []string
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return fingerprints
+		}
+
+		hash := sha256.Sum256(block.Bytes)
+		fingerprints = append(fingerprints, hex.EncodeToString(hash[:]))
+	}
+}
+
+func Test_LoadPackageFromFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("failed to write %q: %v", path, err)
+		}
+		return path
+	}
+
+	jsonPath := writeFile("package.json", quickJSONFromPackage(Package{Name: "json", Version: "1", Bundle: dummy.TestCertificate1}).String())
+	pemPath := writeFile("corporate.pem", dummy.TestCertificate1)
+	crtPath := writeFile("internal.crt", dummy.TestCertificate2)
+	writeFile("internal.metadata.json", `{"name":"internal-cas","version":"2024.1"}`)
+	invalidPath := writeFile("invalid.pem", "not-a-certificate")
+	txtPath := writeFile("package.txt", dummy.TestCertificate1)
+
+	pkg, err := LoadPackageFromFile(jsonPath)
+	if err != nil || pkg.Name != "json" || pkg.Version != "1" {
+		t.Errorf("unexpected JSON package %+v, error: %v", pkg, err)
+	}
+
+	// Without a metadata file, the name is the filename and the version is
+	// derived from the bundle.
+	pkg, err = LoadPackageFromFile(pemPath)
+	if err != nil || pkg.Name != "corporate" || len(pkg.Version) != 16 || pkg.Bundle != dummy.TestCertificate1 {
+		t.Errorf("unexpected PEM package %+v, error: %v", pkg, err)
+	}
+
+	pkg, err = LoadPackageFromFile(crtPath)
+	if err != nil || pkg.Name != "internal-cas" || pkg.Version != "2024.1" {
+		t.Errorf("unexpected PEM package with metadata %+v, error: %v", pkg, err)
+	}
+
+	if _, err := LoadPackageFromFile(invalidPath); err == nil {
+		t.Error("expected an error loading an invalid PEM package")
+	}
+
+	if _, err := LoadPackageFromFile(txtPath); err == nil {
+		t.Error("expected an error loading a package with an unsupported extension")
+	}
+}
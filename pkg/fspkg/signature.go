@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fspkg
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SignatureExt is appended to the path of a package file to give the path of its detached
+// signature, which is the base64-encoded Ed25519 signature of the file's contents.
+const SignatureExt = ".sig"
+
+// LoadOption configures how packages are loaded from files.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	verificationKey ed25519.PublicKey
+}
+
+// WithVerificationKey requires each package file to have a detached signature, made by the
+// private key of the given public key, and refuses to load packages whose signature is
+// missing or invalid.
+func WithVerificationKey(key ed25519.PublicKey) LoadOption {
+	return func(o *loadOptions) {
+		o.verificationKey = key
+	}
+}
+
+// LoadVerificationKeyFromFile reads a PEM-encoded PKIX Ed25519 public key from a file.
+func LoadVerificationKeyFromFile(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package verification key %q: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in package verification key %q", path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse package verification key %q: %w", path, err)
+	}
+
+	ed25519Key, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 public key in %q, got %T", path, key)
+	}
+
+	return ed25519Key, nil
+}
+
+// verifyPackageSignature verifies the detached signature of the package file at the given
+// path, whose contents are data.
+func verifyPackageSignature(path string, data []byte, key ed25519.PublicKey) error {
+	encoded, err := os.ReadFile(path + SignatureExt)
+	if err != nil {
+		return fmt.Errorf("failed to read package signature: %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return fmt.Errorf("failed to decode package signature: %w", err)
+	}
+
+	if !ed25519.Verify(key, data, signature) {
+		return errors.New("package signature is invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fspkg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_LoadPackageFromFileWithVerificationKey(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	_, otherPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile := func(name string, contents []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, contents, 0600); err != nil {
+			t.Fatalf("failed to write %q: %v", path, err)
+		}
+		return path
+	}
+	writePackage := func(name string, key ed25519.PrivateKey) string {
+		data := quickJSONFromPackage(Package{Name: name, Version: "1", Bundle: dummy.TestCertificate1}).Bytes()
+		path := writeFile(name+".json", data)
+		if key != nil {
+			writeFile(name+".json"+SignatureExt, []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))+"\n"))
+		}
+		return path
+	}
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	keyPath := writeFile("key.pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}))
+
+	key, err := LoadVerificationKeyFromFile(keyPath)
+	if err != nil {
+		t.Fatalf("failed to load verification key: %v", err)
+	}
+
+	tampered := writePackage("tampered", privateKey)
+	writeFile("tampered.json", quickJSONFromPackage(Package{Name: "tampered", Version: "1", Bundle: dummy.TestCertificate2}).Bytes())
+
+	tests := map[string]struct {
+		path     string
+		expError bool
+	}{
+		"package signed with the key is loaded": {
+			path: writePackage("signed", privateKey),
+		},
+		"package without a signature is rejected": {
+			path:     writePackage("unsigned", nil),
+			expError: true,
+		},
+		"package signed with another key is rejected": {
+			path:     writePackage("other", otherPrivateKey),
+			expError: true,
+		},
+		"package changed after signing is rejected": {
+			path:     tampered,
+			expError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadPackageFromFile(test.path, WithVerificationKey(key))
+			if err != nil != test.expError {
+				t.Fatalf("expErr=%v, got=%v", test.expError, err)
+			}
+		})
+	}
+
+	if _, err := LoadVerificationKeyFromFile(writeFile("invalid.pub", []byte("not-a-key"))); err == nil {
+		t.Error("expected an error loading an invalid verification key")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpclient builds the HTTP clients trust-manager makes outbound
+// connections with, such as to fetch remote sources or to export telemetry.
+// Every client honours the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables, and the CAs and client certificate configured in Options.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Options configure the HTTP clients used for outbound connections.
+type Options struct {
+	// CAFile, if set, is the path of a PEM bundle of CAs which servers are
+	// verified against, in addition to the system roots. Use this for
+	// servers with private certificates, or proxies which intercept TLS.
+	CAFile string
+
+	// CertFile and KeyFile, if set, are the paths of the PEM certificate and
+	// private key presented to servers which require client authentication.
+	// Either both or neither must be set.
+	CertFile string
+	KeyFile  string
+
+	// Timeout limits the time taken by each request, including reading the
+	// response body. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// Validate returns an error if the options are inconsistent. Files are only
+// read by New.
+func (o Options) Validate() error {
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return errors.New("both or neither of the client certificate and key files must be set")
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, but is %s", o.Timeout)
+	}
+	return nil
+}
+
+// New returns an HTTP client configured by the options.
+func New(opts Options) (*http.Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CAFile != "" {
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+
+		caPEM, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("CA file %q holds no PEM certificates", opts.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if opts.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   opts.Timeout,
+	}, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// maxExportsSize is the maximum size of the exports served by a hub which an
+// agent reads.
+const maxExportsSize = 64 << 20
+
+// AgentOptions hold options for the agent pulling Bundles from a hub.
+type AgentOptions struct {
+	// Log is the agent logger.
+	Log logr.Logger
+
+	// URL is the base URL of the authenticated bundle server of the hub.
+	URL string
+
+	// TokenFile is the path of the bearer token presented to the hub, which
+	// must be authenticated by a TokenReview in the hub. The file is read on
+	// each sync, so that rotated tokens are used.
+	TokenFile string
+
+	// Interval is the interval at which Bundles are pulled from the hub.
+	Interval time.Duration
+
+	// HTTPClient is the client the hub is connected to with.
+	HTTPClient *http.Client
+}
+
+// agent is a manager.Runnable which periodically pulls the Bundles exported by
+// a hub, and applies them as local Bundles with an InLine source holding the
+// trust bundle resolved in the hub.
+type agent struct {
+	log        logr.Logger
+	url        string
+	tokenFile  string
+	interval   time.Duration
+	httpClient *http.Client
+	client     client.Client
+}
+
+// RegisterAgent adds the hub agent to the controller-runtime Manager.
+func RegisterAgent(mgr manager.Manager, opts AgentOptions) error {
+	return mgr.Add(&agent{
+		log:        opts.Log,
+		url:        strings.TrimSuffix(opts.URL, "/") + ExportsPath,
+		tokenFile:  opts.TokenFile,
+		interval:   opts.Interval,
+		httpClient: opts.HTTPClient,
+		client:     mgr.GetClient(),
+	})
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the
+// leader writes Bundles.
+func (a *agent) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (a *agent) Start(ctx context.Context) error {
+	a.log.Info("pulling bundles from hub", "url", a.url, "interval", a.interval)
+
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if err := a.sync(ctx); err != nil {
+			a.log.Error(err, "failed to sync bundles from hub")
+		}
+	}, a.interval, 0.1, true)
+	return nil
+}
+
+// sync applies the Bundles currently exported by the hub, and deletes the
+// managed Bundles it no longer exports. If the exports can't be fetched, the
+// local Bundles are left unchanged.
+func (a *agent) sync(ctx context.Context) error {
+	exports, err := a.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	var managed trustapi.BundleList
+	if err := a.client.List(ctx, &managed, client.MatchingLabels{trustapi.HubManagedLabelKey: "true"}); err != nil {
+		return fmt.Errorf("failed to list managed bundles: %w", err)
+	}
+
+	var errs []error
+	exported := make(map[string]struct{}, len(exports.Bundles))
+	for _, export := range exports.Bundles {
+		exported[export.Name] = struct{}{}
+		if err := a.apply(ctx, export); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply bundle %q: %w", export.Name, err))
+		}
+	}
+
+	for i := range managed.Items {
+		bundle := &managed.Items[i]
+		if _, ok := exported[bundle.Name]; ok {
+			continue
+		}
+
+		if err := a.client.Delete(ctx, bundle); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete bundle %q: %w", bundle.Name, err))
+			continue
+		}
+		a.log.Info("deleted bundle which is no longer exported by the hub", "bundle", bundle.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// apply creates or updates the local Bundle of an exported Bundle. A local
+// Bundle of the same name which isn't managed by the agent is left alone.
+func (a *agent) apply(ctx context.Context, export ExportedBundle) error {
+	spec := trustapi.BundleSpec{
+		Sources: []trustapi.BundleSource{{InLine: ptr.To(export.Bundle)}},
+		Target:  export.Target,
+		Targets: export.Targets,
+	}
+
+	var bundle trustapi.Bundle
+	err := a.client.Get(ctx, client.ObjectKey{Name: export.Name}, &bundle)
+	if apierrors.IsNotFound(err) {
+		bundle = trustapi.Bundle{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   export.Name,
+				Labels: map[string]string{trustapi.HubManagedLabelKey: "true"},
+			},
+			Spec: spec,
+		}
+		if err := a.client.Create(ctx, &bundle); err != nil {
+			return err
+		}
+		a.log.Info("created bundle exported by the hub", "bundle", export.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if bundle.Labels[trustapi.HubManagedLabelKey] != "true" {
+		a.log.Info("not applying bundle exported by the hub, as a Bundle of the same name isn't managed by the agent", "bundle", export.Name)
+		return nil
+	}
+
+	if apiequality.Semantic.DeepEqual(bundle.Spec, spec) {
+		return nil
+	}
+
+	bundle.Spec = spec
+	if err := a.client.Update(ctx, &bundle); err != nil {
+		return err
+	}
+	a.log.Info("updated bundle exported by the hub", "bundle", export.Name)
+	return nil
+}
+
+// fetch returns the Bundles currently exported by the hub.
+func (a *agent) fetch(ctx context.Context) (*Exports, error) {
+	token, err := os.ReadFile(a.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hub token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bundles from hub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch bundles from hub: unexpected status %s", resp.Status)
+	}
+
+	var exports Exports
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxExportsSize)).Decode(&exports); err != nil {
+		return nil, fmt.Errorf("invalid bundles from hub: %w", err)
+	}
+
+	return &exports, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/dummy"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+func managedBundle(name, pem string) *trustapi.Bundle {
+	return gen.Bundle(name, func(b *trustapi.Bundle) {
+		b.Labels = map[string]string{trustapi.HubManagedLabelKey: "true"}
+		b.Spec.Sources = []trustapi.BundleSource{{InLine: ptr.To(pem)}}
+		b.Spec.Target = trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}}
+	})
+}
+
+func Test_agent_sync(t *testing.T) {
+	target := trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}}
+	secretTarget := trustapi.BundleTarget{Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}}
+
+	exports := Exports{Bundles: []ExportedBundle{
+		{Name: "created", Bundle: dummy.TestCertificate1, Target: target},
+		{Name: "updated", Bundle: dummy.TestCertificate2, Target: target},
+		{Name: "unchanged", Bundle: dummy.TestCertificate1, Target: target},
+		{Name: "unmanaged", Bundle: dummy.TestCertificate1, Target: target},
+		{Name: "targets", Bundle: dummy.TestCertificate1, Targets: []trustapi.BundleTarget{secretTarget}},
+	}}
+
+	var authorization string
+	hubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ExportsPath, r.URL.Path)
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(exports)
+	}))
+	defer hubServer.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("hub-token\n"), 0600))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(
+			managedBundle("updated", dummy.TestCertificate1),
+			managedBundle("unchanged", dummy.TestCertificate1),
+			managedBundle("deleted", dummy.TestCertificate1),
+			gen.Bundle("unmanaged", func(b *trustapi.Bundle) {
+				b.Spec.Sources = []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate3)}}
+			}),
+		).
+		Build()
+
+	log, ctx := ktesting.NewTestContext(t)
+	a := &agent{
+		log:        log,
+		url:        hubServer.URL + ExportsPath,
+		tokenFile:  tokenFile,
+		httpClient: hubServer.Client(),
+		client:     fakeClient,
+	}
+	getBundle := func(name string) *trustapi.Bundle {
+		var bundle trustapi.Bundle
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name}, &bundle))
+		return &bundle
+	}
+	unchangedVersion := getBundle("unchanged").ResourceVersion
+
+	require.NoError(t, a.sync(ctx))
+	assert.Equal(t, "Bearer hub-token", authorization)
+
+	for _, name := range []string{"created", "updated", "unchanged"} {
+		bundle := getBundle(name)
+		assert.Equal(t, "true", bundle.Labels[trustapi.HubManagedLabelKey], name)
+		assert.Equal(t, managedBundle(name, exportedPEM(exports, name)).Spec, bundle.Spec, name)
+	}
+	assert.Equal(t, unchangedVersion, getBundle("unchanged").ResourceVersion, "expected an unchanged Bundle not to be updated")
+
+	targets := getBundle("targets")
+	assert.Equal(t, trustapi.BundleTarget{}, targets.Spec.Target)
+	assert.Equal(t, []trustapi.BundleTarget{secretTarget}, targets.Spec.Targets, "expected spec.targets of an exported Bundle to be applied")
+
+	unmanaged := getBundle("unmanaged")
+	assert.Empty(t, unmanaged.Labels[trustapi.HubManagedLabelKey])
+	assert.Equal(t, dummy.TestCertificate3, *unmanaged.Spec.Sources[0].InLine, "expected an unmanaged Bundle to be left alone")
+
+	err := fakeClient.Get(ctx, client.ObjectKey{Name: "deleted"}, &trustapi.Bundle{})
+	assert.True(t, apierrors.IsNotFound(err), "expected a Bundle no longer exported to be deleted")
+}
+
+func Test_agent_sync_hubUnavailable(t *testing.T) {
+	hubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "failed to render Bundle", http.StatusServiceUnavailable)
+	}))
+	defer hubServer.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("hub-token"), 0600))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithObjects(managedBundle("existing", dummy.TestCertificate1)).
+		Build()
+
+	log, ctx := ktesting.NewTestContext(t)
+	a := &agent{
+		log:        log,
+		url:        hubServer.URL + ExportsPath,
+		tokenFile:  tokenFile,
+		httpClient: hubServer.Client(),
+		client:     fakeClient,
+	}
+	assert.EqualError(t, a.sync(ctx), "failed to fetch bundles from hub: unexpected status 503 Service Unavailable")
+
+	assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "existing"}, &trustapi.Bundle{}),
+		"expected managed Bundles to be kept while the hub is unavailable")
+}
+
+// exportedPEM returns the trust bundle of the named exported Bundle.
+func exportedPEM(exports Exports, name string) string {
+	for _, export := range exports.Bundles {
+		if export.Name == name {
+			return export.Bundle
+		}
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hub lets trust-manager in spoke clusters pull Bundles from a hub
+// cluster, so that trust is managed centrally without distributing
+// kubeconfigs of every spoke to the hub. Bundles annotated for export in the
+// hub are served by its authenticated bundle server, and an agent in each
+// spoke creates, updates and deletes local copies of them.
+package hub
+
+import (
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// ExportsPath is the path the authenticated bundle server of a hub serves its
+// exported Bundles on.
+const ExportsPath = "/hub/bundles"
+
+// Exports is the list of Bundles exported by a hub.
+type Exports struct {
+	Bundles []ExportedBundle `json:"bundles"`
+}
+
+// ExportedBundle is a Bundle exported by a hub, with the trust bundle resolved
+// from its sources in the hub.
+type ExportedBundle struct {
+	// Name is the name of the Bundle.
+	Name string `json:"name"`
+
+	// Bundle is the PEM trust bundle of the Bundle.
+	Bundle string `json:"bundle"`
+
+	// Target and Targets are the targets of the Bundle, which spokes write
+	// the trust bundle to.
+	Target  trustapi.BundleTarget   `json:"target"`
+	Targets []trustapi.BundleTarget `json:"targets,omitempty"`
+}
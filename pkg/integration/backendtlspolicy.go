@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// backendTLSPolicyGVK is the kind of Gateway API BackendTLSPolicies. They're
+// read as unstructured objects, so that trust-manager doesn't depend on the
+// Gateway API module.
+var backendTLSPolicyGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "BackendTLSPolicy"}
+
+func newBackendTLSPolicy() *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(backendTLSPolicyGVK)
+	return policy
+}
+
+// backendTLSPolicyReconciler points the caCertificateRefs of BackendTLSPolicies
+// which opt in at the target ConfigMap of their Bundle. BackendTLSPolicies
+// read the ca.crt key of the ConfigMaps they reference, so the Bundle must
+// write to it.
+type backendTLSPolicyReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+func (r *backendTLSPolicyReconciler) setupWithManager(mgr manager.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("backendtlspolicies").
+		For(newBackendTLSPolicy(), builder.WithPredicates(optedInPredicate())).
+		Watches(&trustapi.Bundle{}, enqueueOptedIn(r.client, r.log,
+			func() client.ObjectList {
+				list := &unstructured.UnstructuredList{}
+				list.SetGroupVersionKind(backendTLSPolicyGVK.GroupVersion().WithKind(backendTLSPolicyGVK.Kind + "List"))
+				return list
+			},
+			func(list client.ObjectList) []client.Object {
+				var objs []client.Object
+				for i := range list.(*unstructured.UnstructuredList).Items {
+					objs = append(objs, &list.(*unstructured.UnstructuredList).Items[i])
+				}
+				return objs
+			},
+		)).
+		Complete(r)
+}
+
+// Reconcile points the caCertificateRefs of the BackendTLSPolicy at the target
+// ConfigMap of its Bundle. The wellKnownCACertificates of the policy are
+// removed, as they can't be set alongside caCertificateRefs.
+func (r *backendTLSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("backendtlspolicy", req.NamespacedName)
+
+	policy := newBackendTLSPolicy()
+	if err := r.client.Get(ctx, req.NamespacedName, policy); apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get BackendTLSPolicy: %w", err)
+	}
+
+	bundle, err := bundleOf(ctx, r.client, r.recorder, policy)
+	if err != nil || bundle == nil {
+		return ctrl.Result{}, err
+	}
+
+	if !writesCAKey(bundle, "ConfigMap") {
+		r.recorder.Eventf(policy, corev1.EventTypeWarning, "BundleNotUsable", "Bundle %s doesn't write to the %q key of a ConfigMap target, which BackendTLSPolicies read", bundle.Name, caCertKey)
+		return ctrl.Result{}, nil
+	}
+
+	desired := []any{map[string]any{"group": "", "kind": "ConfigMap", "name": bundle.Name}}
+	refs, _, _ := unstructured.NestedSlice(policy.Object, "spec", "validation", "caCertificateRefs")
+	_, wellKnown, _ := unstructured.NestedFieldNoCopy(policy.Object, "spec", "validation", "wellKnownCACertificates")
+	if equality.Semantic.DeepEqual(refs, desired) && !wellKnown {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(policy.DeepCopy())
+	if err := unstructured.SetNestedSlice(policy.Object, desired, "spec", "validation", "caCertificateRefs"); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set caCertificateRefs: %w", err)
+	}
+	unstructured.RemoveNestedField(policy.Object, "spec", "validation", "wellKnownCACertificates")
+	if err := r.client.Patch(ctx, policy, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch BackendTLSPolicy: %w", err)
+	}
+
+	log.Info("pointed caCertificateRefs at Bundle target", "bundle", bundle.Name)
+	r.recorder.Eventf(policy, corev1.EventTypeNormal, "CAReferenceUpdated", "Pointed caCertificateRefs at the target ConfigMap of Bundle %s", bundle.Name)
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// IngressNginxProxySSLSecretAnnotationKey is the annotation of Ingresses in
+// which ingress-nginx looks up the Secret holding the CA certificates used to
+// verify backends, as "<namespace>/<name>".
+const IngressNginxProxySSLSecretAnnotationKey = "nginx.ingress.kubernetes.io/proxy-ssl-secret"
+
+// ingressNginxReconciler points the proxy-ssl-secret annotation of Ingresses
+// which opt in at the target Secret of their Bundle in their Namespace.
+// ingress-nginx reads the ca.crt key of the Secret, so the Bundle must write
+// to it. Backends are only verified if the Ingress also enables
+// nginx.ingress.kubernetes.io/proxy-ssl-verify, which is left to its owner.
+type ingressNginxReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+func (r *ingressNginxReconciler) setupWithManager(mgr manager.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("ingresses").
+		For(&networkingv1.Ingress{}, builder.WithPredicates(optedInPredicate())).
+		Watches(&trustapi.Bundle{}, enqueueOptedIn(r.client, r.log,
+			func() client.ObjectList { return &networkingv1.IngressList{} },
+			func(list client.ObjectList) []client.Object {
+				var objs []client.Object
+				for i := range list.(*networkingv1.IngressList).Items {
+					objs = append(objs, &list.(*networkingv1.IngressList).Items[i])
+				}
+				return objs
+			},
+		)).
+		Complete(r)
+}
+
+// Reconcile points the proxy-ssl-secret annotation of the Ingress at the
+// target Secret of its Bundle.
+func (r *ingressNginxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("ingress", req.NamespacedName)
+
+	var ingress networkingv1.Ingress
+	if err := r.client.Get(ctx, req.NamespacedName, &ingress); apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get Ingress: %w", err)
+	}
+
+	bundle, err := bundleOf(ctx, r.client, r.recorder, &ingress)
+	if err != nil || bundle == nil {
+		return ctrl.Result{}, err
+	}
+
+	if !writesCAKey(bundle, "Secret") {
+		r.recorder.Eventf(&ingress, corev1.EventTypeWarning, "BundleNotUsable", "Bundle %s doesn't write to the %q key of a Secret target, which ingress-nginx reads", bundle.Name, caCertKey)
+		return ctrl.Result{}, nil
+	}
+
+	// Immutable target Secrets are named after their data, and the Bundle
+	// records the current name once they're written. The Ingress is
+	// reconciled again when it does.
+	secretName := bundle.Name
+	if immutableSecretTarget(bundle) {
+		secretName = bundle.Annotations[trustapi.BundleSecretTargetAnnotationKey]
+		if secretName == "" {
+			return ctrl.Result{}, nil
+		}
+	}
+
+	desired := ingress.Namespace + "/" + secretName
+	if ingress.Annotations[IngressNginxProxySSLSecretAnnotationKey] == desired {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(ingress.DeepCopy())
+	metav1.SetMetaDataAnnotation(&ingress.ObjectMeta, IngressNginxProxySSLSecretAnnotationKey, desired)
+	if err := r.client.Patch(ctx, &ingress, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch Ingress: %w", err)
+	}
+
+	log.Info("pointed proxy-ssl-secret at Bundle target", "bundle", bundle.Name, "secret", secretName)
+	r.recorder.Eventf(&ingress, corev1.EventTypeNormal, "CAReferenceUpdated", "Pointed %s at the target Secret %s of Bundle %s", IngressNginxProxySSLSecretAnnotationKey, secretName, bundle.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// immutableSecretTarget returns true if the Secret target of the Bundle is
+// immutable.
+func immutableSecretTarget(bundle *trustapi.Bundle) bool {
+	for _, t := range bundle.Spec.AllTargets() {
+		if t.Secret != nil && t.Secret.Immutable {
+			return true
+		}
+	}
+	return false
+}
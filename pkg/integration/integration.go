@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package integration keeps the CA certificate references of Gateway API
+// BackendTLSPolicies and ingress-nginx Ingresses pointed at the targets of
+// trust-manager Bundles, so that the trust of gateways and ingress
+// controllers follows a Bundle without being configured by hand. Objects opt
+// in by naming a Bundle in the trust.cert-manager.io/ca-bundle annotation.
+package integration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/permissions"
+)
+
+// caCertKey is the key which BackendTLSPolicies and ingress-nginx read CA
+// certificates from in the ConfigMaps and Secrets they reference.
+const caCertKey = "ca.crt"
+
+// Options configures the integrations.
+type Options struct {
+	Log logr.Logger
+
+	// BackendTLSPolicy, if true, points the caCertificateRefs of Gateway API
+	// BackendTLSPolicies which opt in at the target ConfigMap of their
+	// Bundle. Requires the Gateway API CRDs to be installed.
+	BackendTLSPolicy bool
+
+	// IngressNginx, if true, points the proxy-ssl-secret annotation of
+	// Ingresses which opt in at the target Secret of their Bundle. Requires
+	// Secret targets to be enabled.
+	IngressNginx bool
+}
+
+// Enabled returns true if any integration is enabled.
+func (o Options) Enabled() bool {
+	return o.BackendTLSPolicy || o.IngressNginx
+}
+
+// AddControllers registers a controller with the Manager for each enabled
+// integration.
+func AddControllers(mgr manager.Manager, opts Options) error {
+	recorder := mgr.GetEventRecorderFor("trust-manager-integration")
+
+	if opts.BackendTLSPolicy {
+		r := &backendTLSPolicyReconciler{
+			client:   mgr.GetClient(),
+			recorder: recorder,
+			log:      opts.Log.WithName("backendtlspolicy"),
+		}
+		if err := r.setupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed to create BackendTLSPolicy controller: %w", err)
+		}
+	}
+
+	if opts.IngressNginx {
+		r := &ingressNginxReconciler{
+			client:   mgr.GetClient(),
+			recorder: recorder,
+			log:      opts.Log.WithName("ingress-nginx"),
+		}
+		if err := r.setupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed to create ingress-nginx controller: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RequiredPermissions returns the RBAC permissions which the enabled
+// integrations need, matching those granted by the Helm chart. In namespaced
+// mode, objects are only reconciled in the watched Namespaces.
+func RequiredPermissions(opts Options, watchNamespaces []string) []permissions.Permission {
+	if len(watchNamespaces) == 0 {
+		watchNamespaces = []string{""}
+	}
+
+	var required []permissions.Permission
+	add := func(group, resource string) {
+		for _, namespace := range watchNamespaces {
+			for _, verb := range []string{"get", "list", "watch", "patch"} {
+				required = append(required, permissions.Permission{Namespace: namespace, Verb: verb, Group: group, Resource: resource})
+			}
+		}
+	}
+
+	if opts.BackendTLSPolicy {
+		add(backendTLSPolicyGVK.Group, "backendtlspolicies")
+	}
+	if opts.IngressNginx {
+		add("networking.k8s.io", "ingresses")
+	}
+
+	return required
+}
+
+// optedInPredicate filters events for objects which don't name a Bundle in
+// the CA bundle annotation.
+func optedInPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetAnnotations()[trustapi.CABundleAnnotationKey] != ""
+	})
+}
+
+// enqueueOptedIn returns an event handler which reconciles the objects naming
+// a Bundle in their CA bundle annotation when the Bundle changes. The objects
+// are listed with the given list, which must be of the kind of the objects.
+func enqueueOptedIn(c client.Client, log logr.Logger, newList func() client.ObjectList, items func(client.ObjectList) []client.Object) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, bundle client.Object) []reconcile.Request {
+		list := newList()
+		if err := c.List(ctx, list); err != nil {
+			log.Error(err, "failed to list objects referencing Bundle", "bundle", bundle.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, obj := range items(list) {
+			if obj.GetAnnotations()[trustapi.CABundleAnnotationKey] == bundle.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+			}
+		}
+		return requests
+	})
+}
+
+// bundleOf returns the Bundle named in the CA bundle annotation of the object,
+// or nil if the object names none. A Warning Event is recorded on the object
+// if the Bundle doesn't exist.
+func bundleOf(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object) (*trustapi.Bundle, error) {
+	name := obj.GetAnnotations()[trustapi.CABundleAnnotationKey]
+	if name == "" {
+		return nil, nil
+	}
+
+	var bundle trustapi.Bundle
+	err := c.Get(ctx, client.ObjectKey{Name: name}, &bundle)
+	if apierrors.IsNotFound(err) {
+		recorder.Eventf(obj, corev1.EventTypeWarning, "BundleNotFound", "Bundle %s was not found", name)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Bundle %s: %w", name, err)
+	}
+
+	return &bundle, nil
+}
+
+// writesCAKey returns true if a target of the Bundle writes the bundle to the
+// ca.crt key of its ConfigMap or Secret, depending on the kind.
+func writesCAKey(bundle *trustapi.Bundle, kind string) bool {
+	for _, t := range bundle.Spec.AllTargets() {
+		var (
+			key            string
+			additionalKeys []string
+		)
+		switch {
+		case kind == "ConfigMap" && t.ConfigMap != nil:
+			key, additionalKeys = t.ConfigMap.Key, t.ConfigMap.AdditionalKeys
+		case kind == "Secret" && t.Secret != nil:
+			key, additionalKeys = t.Secret.Key, t.Secret.AdditionalKeys
+		default:
+			continue
+		}
+
+		if key == caCertKey || slices.Contains(additionalKeys, caCertKey) {
+			return true
+		}
+	}
+	return false
+}
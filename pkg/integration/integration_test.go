@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/gen"
+)
+
+func Test_backendTLSPolicyReconciler(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	caKeyBundle := gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+		b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: caCertKey}}
+	})
+
+	policy := func(annotation string, validation map[string]any) *unstructured.Unstructured {
+		p := newBackendTLSPolicy()
+		p.SetName("test-policy")
+		p.SetNamespace("ns-1")
+		if annotation != "" {
+			p.SetAnnotations(map[string]string{trustapi.CABundleAnnotationKey: annotation})
+		}
+		p.Object["spec"] = map[string]any{"validation": validation}
+		return p
+	}
+
+	tests := map[string]struct {
+		bundle *trustapi.Bundle
+		policy *unstructured.Unstructured
+
+		expValidation map[string]any
+		expEvent      string
+	}{
+		"if policy doesn't name a Bundle, leave it alone": {
+			bundle:        caKeyBundle,
+			policy:        policy("", map[string]any{"hostname": "example.com"}),
+			expValidation: map[string]any{"hostname": "example.com"},
+		},
+		"if Bundle doesn't exist, record a Warning": {
+			policy:        policy(bundleName, map[string]any{"hostname": "example.com"}),
+			expValidation: map[string]any{"hostname": "example.com"},
+			expEvent:      "Warning BundleNotFound Bundle test-bundle was not found",
+		},
+		"if Bundle doesn't write to ca.crt, record a Warning": {
+			bundle: gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+				b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bundle.pem"}}
+			}),
+			policy:        policy(bundleName, map[string]any{"hostname": "example.com"}),
+			expValidation: map[string]any{"hostname": "example.com"},
+			expEvent:      `Warning BundleNotUsable Bundle test-bundle doesn't write to the "ca.crt" key of a ConfigMap target, which BackendTLSPolicies read`,
+		},
+		"if Bundle writes to ca.crt, point caCertificateRefs at it": {
+			bundle: caKeyBundle,
+			policy: policy(bundleName, map[string]any{"hostname": "example.com", "wellKnownCACertificates": "System"}),
+			expValidation: map[string]any{
+				"hostname":          "example.com",
+				"caCertificateRefs": []any{map[string]any{"group": "", "kind": "ConfigMap", "name": bundleName}},
+			},
+			expEvent: "Normal CAReferenceUpdated Pointed caCertificateRefs at the target ConfigMap of Bundle test-bundle",
+		},
+		"if Bundle is given as an additional key, point caCertificateRefs at it": {
+			bundle: gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+				b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bundle.pem"}, AdditionalKeys: []string{caCertKey}}
+			}),
+			policy: policy(bundleName, map[string]any{"hostname": "example.com"}),
+			expValidation: map[string]any{
+				"hostname":          "example.com",
+				"caCertificateRefs": []any{map[string]any{"group": "", "kind": "ConfigMap", "name": bundleName}},
+			},
+			expEvent: "Normal CAReferenceUpdated Pointed caCertificateRefs at the target ConfigMap of Bundle test-bundle",
+		},
+		"if caCertificateRefs already point at Bundle, do nothing": {
+			bundle: caKeyBundle,
+			policy: policy(bundleName, map[string]any{
+				"hostname":          "example.com",
+				"caCertificateRefs": []any{map[string]any{"group": "", "kind": "ConfigMap", "name": bundleName}},
+			}),
+			expValidation: map[string]any{
+				"hostname":          "example.com",
+				"caCertificateRefs": []any{map[string]any{"group": "", "kind": "ConfigMap", "name": bundleName}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).WithObjects(test.policy)
+			if test.bundle != nil {
+				builder = builder.WithObjects(test.bundle)
+			}
+			fakeClient := builder.Build()
+			recorder := record.NewFakeRecorder(1)
+
+			_, ctx := ktesting.NewTestContext(t)
+			r := &backendTLSPolicyReconciler{client: fakeClient, recorder: recorder, log: ktesting.NewLogger(t, ktesting.DefaultConfig)}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(test.policy)})
+			require.NoError(t, err)
+
+			got := newBackendTLSPolicy()
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(test.policy), got))
+			validation, _, err := unstructured.NestedMap(got.Object, "spec", "validation")
+			require.NoError(t, err)
+			assert.Equal(t, test.expValidation, validation)
+
+			assertEvent(t, recorder, test.expEvent)
+		})
+	}
+}
+
+func Test_ingressNginxReconciler(t *testing.T) {
+	const bundleName = "test-bundle"
+
+	secretBundle := func() *trustapi.Bundle {
+		return gen.Bundle(bundleName, gen.SetBundleTargetSecret(trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: caCertKey}}))
+	}
+
+	tests := map[string]struct {
+		bundle      *trustapi.Bundle
+		annotations map[string]string
+
+		expAnnotation string
+		expEvent      string
+	}{
+		"if Ingress doesn't name a Bundle, leave it alone": {
+			bundle: secretBundle(),
+		},
+		"if Bundle has no Secret target, record a Warning": {
+			bundle: gen.Bundle(bundleName, func(b *trustapi.Bundle) {
+				b.Spec.Target.ConfigMap = &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: caCertKey}}
+			}),
+			annotations: map[string]string{trustapi.CABundleAnnotationKey: bundleName},
+			expEvent:    `Warning BundleNotUsable Bundle test-bundle doesn't write to the "ca.crt" key of a Secret target, which ingress-nginx reads`,
+		},
+		"if Bundle writes a Secret target to ca.crt, point proxy-ssl-secret at it": {
+			bundle:        secretBundle(),
+			annotations:   map[string]string{trustapi.CABundleAnnotationKey: bundleName},
+			expAnnotation: "ns-1/test-bundle",
+			expEvent:      "Normal CAReferenceUpdated Pointed nginx.ingress.kubernetes.io/proxy-ssl-secret at the target Secret test-bundle of Bundle test-bundle",
+		},
+		"if proxy-ssl-secret points elsewhere, point it at Bundle": {
+			bundle: secretBundle(),
+			annotations: map[string]string{
+				trustapi.CABundleAnnotationKey:          bundleName,
+				IngressNginxProxySSLSecretAnnotationKey: "ns-1/other",
+			},
+			expAnnotation: "ns-1/test-bundle",
+			expEvent:      "Normal CAReferenceUpdated Pointed nginx.ingress.kubernetes.io/proxy-ssl-secret at the target Secret test-bundle of Bundle test-bundle",
+		},
+		"if immutable Secret target hasn't been written yet, wait for it": {
+			bundle:      gen.Bundle(bundleName, gen.SetBundleTargetSecret(trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: caCertKey}, Immutable: true})),
+			annotations: map[string]string{trustapi.CABundleAnnotationKey: bundleName},
+		},
+		"if immutable Secret target has been written, point proxy-ssl-secret at its current name": {
+			bundle: gen.Bundle(bundleName, gen.SetBundleTargetSecret(trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: caCertKey}, Immutable: true}), func(b *trustapi.Bundle) {
+				b.Annotations = map[string]string{trustapi.BundleSecretTargetAnnotationKey: "test-bundle-abc123"}
+			}),
+			annotations:   map[string]string{trustapi.CABundleAnnotationKey: bundleName},
+			expAnnotation: "ns-1/test-bundle-abc123",
+			expEvent:      "Normal CAReferenceUpdated Pointed nginx.ingress.kubernetes.io/proxy-ssl-secret at the target Secret test-bundle-abc123 of Bundle test-bundle",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: "ns-1", Annotations: test.annotations}}
+			fakeClient := fake.NewClientBuilder().WithScheme(trustapi.GlobalScheme).WithObjects(ingress, test.bundle).Build()
+			recorder := record.NewFakeRecorder(1)
+
+			_, ctx := ktesting.NewTestContext(t)
+			r := &ingressNginxReconciler{client: fakeClient, recorder: recorder, log: ktesting.NewLogger(t, ktesting.DefaultConfig)}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ingress)})
+			require.NoError(t, err)
+
+			var got networkingv1.Ingress
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(ingress), &got))
+			assert.Equal(t, test.expAnnotation, got.Annotations[IngressNginxProxySSLSecretAnnotationKey])
+
+			assertEvent(t, recorder, test.expEvent)
+		})
+	}
+}
+
+func Test_RequiredPermissions(t *testing.T) {
+	assert.Empty(t, RequiredPermissions(Options{}, nil))
+
+	required := RequiredPermissions(Options{BackendTLSPolicy: true, IngressNginx: true}, []string{"ns-1"})
+	assert.Len(t, required, 8)
+	for _, permission := range required {
+		assert.Equal(t, "ns-1", permission.Namespace)
+	}
+}
+
+func assertEvent(t *testing.T, recorder *record.FakeRecorder, expEvent string) {
+	t.Helper()
+
+	select {
+	case event := <-recorder.Events:
+		assert.Equal(t, expEvent, event)
+	default:
+		assert.Empty(t, expEvent, "expected an Event to be recorded")
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the Prometheus metrics exposed by trust-manager, in
+// addition to those of controller-runtime such as the work queue metrics.
+// Metrics are registered with the controller-runtime registry, and so are
+// served on the manager's metrics endpoint.
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "trust_manager"
+
+// Results of syncing a target.
+const (
+	PatchResultApplied = "applied"
+	PatchResultSkipped = "skipped"
+	PatchResultFailed  = "failed"
+)
+
+// Results of a lookup in the encoding cache.
+const (
+	CacheResultHit  = "hit"
+	CacheResultMiss = "miss"
+)
+
+var (
+	targetApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "target_apply_duration_seconds",
+		Help:      "Latency of server-side apply patches to Bundle targets, by target kind.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"kind"})
+
+	targetPatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "target_patches_total",
+		Help:      "Number of Bundle target syncs, by target kind and whether a patch was applied, skipped as the target was up to date, or failed.",
+	}, []string{"kind", "result"})
+
+	encodingCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "encoding_cache_lookups_total",
+		Help:      "Number of lookups of encoded additional formats in the encoding cache, by whether the formats were cached or had to be encoded.",
+	}, []string{"result"})
+
+	encodingCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "encoding_cache_entries",
+		Help:      "Number of encoded additional formats held in the encoding cache.",
+	})
+
+	webhookCertificateExpiry = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "webhook_certificate_expiration_timestamp_seconds",
+		Help:      "Time after which the serving certificate of the webhook is no longer valid, as seconds since the Unix epoch.",
+	})
+
+	missingPermissions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "missing_rbac_permissions",
+		Help:      "Number of the RBAC permissions which trust-manager needs but doesn't hold, as of the last check.",
+	})
+
+	bundleConsumerPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bundle_consumer_pods",
+		Help:      "Number of pods which mount a target of each Bundle, excluding completed pods, as of the last consumer report.",
+	}, []string{"bundle"})
+
+	defaultPackage = &defaultPackageCollector{now: time.Now}
+)
+
+var (
+	defaultPackageAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "default_package_age_days"),
+		"Days since the default CA package was built. Only exported if the package specifies its build date.",
+		nil, nil)
+
+	defaultPackageNewestExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "default_package_newest_certificate_expiration_timestamp_seconds"),
+		"Time after which the last of the certificates of the default CA package is no longer valid, as seconds since the Unix epoch.",
+		nil, nil)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(targetApplyDuration, targetPatches, encodingCacheLookups, encodingCacheEntries, webhookCertificateExpiry, missingPermissions, bundleConsumerPods, defaultPackage)
+}
+
+// ObserveTargetApply records the latency of a patch to a target of the given
+// kind.
+func ObserveTargetApply(kind string, duration time.Duration) {
+	targetApplyDuration.WithLabelValues(strings.ToLower(kind)).Observe(duration.Seconds())
+}
+
+// RecordTargetPatch counts the sync of a target of the given kind with the
+// given result.
+func RecordTargetPatch(kind, result string) {
+	targetPatches.WithLabelValues(strings.ToLower(kind), result).Inc()
+}
+
+// RecordEncodingCacheLookup counts a lookup in the encoding cache with the
+// given result.
+func RecordEncodingCacheLookup(result string) {
+	encodingCacheLookups.WithLabelValues(result).Inc()
+}
+
+// SetEncodingCacheEntries records the number of entries in the encoding cache.
+func SetEncodingCacheEntries(entries int) {
+	encodingCacheEntries.Set(float64(entries))
+}
+
+// SetWebhookCertificateExpiry records the expiry of the certificate currently
+// served by the webhook.
+func SetWebhookCertificateExpiry(notAfter time.Time) {
+	webhookCertificateExpiry.Set(float64(notAfter.Unix()))
+}
+
+// SetMissingPermissions records the number of RBAC permissions which
+// trust-manager is missing.
+func SetMissingPermissions(missing int) {
+	missingPermissions.Set(float64(missing))
+}
+
+// SetBundleConsumers records the number of pods which mount a target of each
+// Bundle, replacing the counts of Bundles which no longer exist.
+func SetBundleConsumers(pods map[string]int) {
+	bundleConsumerPods.Reset()
+	for bundle, count := range pods {
+		bundleConsumerPods.WithLabelValues(bundle).Set(float64(count))
+	}
+}
+
+// SetDefaultPackage records the build time of the default CA package, or the
+// zero time if it's unknown, and the latest expiry of its certificates.
+func SetDefaultPackage(buildTime, newestNotAfter time.Time) {
+	defaultPackage.mu.Lock()
+	defer defaultPackage.mu.Unlock()
+
+	defaultPackage.buildTime = buildTime
+	defaultPackage.newestNotAfter = newestNotAfter
+}
+
+// defaultPackageCollector exports the metrics of the default CA package. Its
+// age is computed when scraped, so that it grows while the package is loaded.
+type defaultPackageCollector struct {
+	now func() time.Time
+
+	mu             sync.RWMutex
+	buildTime      time.Time
+	newestNotAfter time.Time
+}
+
+func (c *defaultPackageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- defaultPackageAgeDesc
+	ch <- defaultPackageNewestExpiryDesc
+}
+
+func (c *defaultPackageCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.buildTime.IsZero() {
+		ch <- prometheus.MustNewConstMetric(defaultPackageAgeDesc, prometheus.GaugeValue, c.now().Sub(c.buildTime).Hours()/24)
+	}
+	if !c.newestNotAfter.IsZero() {
+		ch <- prometheus.MustNewConstMetric(defaultPackageNewestExpiryDesc, prometheus.GaugeValue, float64(c.newestNotAfter.Unix()))
+	}
+}
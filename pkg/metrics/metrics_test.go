@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_targetMetrics(t *testing.T) {
+	targetApplyDuration.Reset()
+	targetPatches.Reset()
+
+	ObserveTargetApply("ConfigMap", 20*time.Millisecond)
+	ObserveTargetApply("Secret", 40*time.Millisecond)
+	RecordTargetPatch("ConfigMap", PatchResultApplied)
+	RecordTargetPatch("ConfigMap", PatchResultSkipped)
+	RecordTargetPatch("ConfigMap", PatchResultSkipped)
+	RecordTargetPatch("Secret", PatchResultFailed)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(targetApplyDuration))
+
+	assert.NoError(t, testutil.CollectAndCompare(targetPatches, strings.NewReader(`
+# HELP trust_manager_target_patches_total Number of Bundle target syncs, by target kind and whether a patch was applied, skipped as the target was up to date, or failed.
+# TYPE trust_manager_target_patches_total counter
+trust_manager_target_patches_total{kind="configmap",result="applied"} 1
+trust_manager_target_patches_total{kind="configmap",result="skipped"} 2
+trust_manager_target_patches_total{kind="secret",result="failed"} 1
+`)))
+}
+
+func Test_encodingCacheMetrics(t *testing.T) {
+	encodingCacheLookups.Reset()
+
+	RecordEncodingCacheLookup(CacheResultMiss)
+	RecordEncodingCacheLookup(CacheResultHit)
+	RecordEncodingCacheLookup(CacheResultHit)
+	SetEncodingCacheEntries(3)
+
+	assert.NoError(t, testutil.CollectAndCompare(encodingCacheLookups, strings.NewReader(`
+# HELP trust_manager_encoding_cache_lookups_total Number of lookups of encoded additional formats in the encoding cache, by whether the formats were cached or had to be encoded.
+# TYPE trust_manager_encoding_cache_lookups_total counter
+trust_manager_encoding_cache_lookups_total{result="hit"} 2
+trust_manager_encoding_cache_lookups_total{result="miss"} 1
+`)))
+	assert.InDelta(t, 3, testutil.ToFloat64(encodingCacheEntries), 0)
+}
+
+func Test_webhookCertificateMetrics(t *testing.T) {
+	notAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	SetWebhookCertificateExpiry(notAfter)
+
+	assert.InDelta(t, float64(notAfter.Unix()), testutil.ToFloat64(webhookCertificateExpiry), 0)
+}
+
+func Test_bundleConsumerMetrics(t *testing.T) {
+	SetBundleConsumers(map[string]int{"a": 2, "b": 1})
+	SetBundleConsumers(map[string]int{"a": 3, "c": 0})
+
+	assert.NoError(t, testutil.CollectAndCompare(bundleConsumerPods, strings.NewReader(`
+# HELP trust_manager_bundle_consumer_pods Number of pods which mount a target of each Bundle, excluding completed pods, as of the last consumer report.
+# TYPE trust_manager_bundle_consumer_pods gauge
+trust_manager_bundle_consumer_pods{bundle="a"} 3
+trust_manager_bundle_consumer_pods{bundle="c"} 0
+`)))
+}
+
+func Test_defaultPackageMetrics(t *testing.T) {
+	built := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	defaultPackage.now = func() time.Time { return built.Add(36 * time.Hour) }
+	defer func() { defaultPackage.now = time.Now }()
+
+	SetDefaultPackage(built, notAfter)
+	assert.NoError(t, testutil.CollectAndCompare(defaultPackage, strings.NewReader(`
+# HELP trust_manager_default_package_age_days Days since the default CA package was built. Only exported if the package specifies its build date.
+# TYPE trust_manager_default_package_age_days gauge
+trust_manager_default_package_age_days 1.5
+# HELP trust_manager_default_package_newest_certificate_expiration_timestamp_seconds Time after which the last of the certificates of the default CA package is no longer valid, as seconds since the Unix epoch.
+# TYPE trust_manager_default_package_newest_certificate_expiration_timestamp_seconds gauge
+trust_manager_default_package_newest_certificate_expiration_timestamp_seconds 1.893456e+09
+`)))
+
+	// The age isn't exported if the build time is unknown.
+	SetDefaultPackage(time.Time{}, notAfter)
+	assert.Equal(t, 1, testutil.CollectAndCount(defaultPackage))
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package permissions checks that trust-manager holds the RBAC permissions
+// it needs, with SelfSubjectAccessReviews, so that a drifted ClusterRole is
+// reported as soon as trust-manager starts rather than as failures to sync
+// targets in some Namespaces later on.
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cert-manager/trust-manager/pkg/metrics"
+)
+
+// Permission is an RBAC permission to perform a verb on a resource.
+type Permission struct {
+	// Namespace is the Namespace the permission is needed in, or empty for
+	// cluster-scoped resources and for all Namespaces.
+	Namespace string
+
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+}
+
+// String returns the permission as, for example,
+// "patch bundles.trust.cert-manager.io/status" or
+// "get secrets in Namespace cert-manager".
+func (p Permission) String() string {
+	var b strings.Builder
+	b.WriteString(p.Verb + " " + p.Resource)
+	if p.Group != "" {
+		b.WriteString("." + p.Group)
+	}
+	if p.Subresource != "" {
+		b.WriteString("/" + p.Subresource)
+	}
+	if p.Namespace != "" {
+		b.WriteString(" in Namespace " + p.Namespace)
+	}
+	return b.String()
+}
+
+// Options configures a Checker.
+type Options struct {
+	Log logr.Logger
+
+	// Permissions are the permissions which trust-manager needs.
+	Permissions []Permission
+
+	// Interval is how often the permissions are checked again after they were
+	// first checked.
+	Interval time.Duration
+
+	// Recorder and Pod, if both set, record an Event on the trust-manager Pod
+	// whenever permissions go missing.
+	Recorder record.EventRecorder
+	Pod      *corev1.ObjectReference
+}
+
+// Checker checks that trust-manager holds the permissions it needs, when
+// started and then periodically. Missing permissions fail its readiness check,
+// are logged and exported as a metric, and recorded as an Event. It's run by
+// the Manager on every replica, as each one reports its own readiness.
+type Checker struct {
+	client client.Client
+	opts   Options
+
+	mu      sync.RWMutex
+	checked bool
+	missing []Permission
+}
+
+// NewChecker returns a Checker which reviews the permissions with the client.
+// The Checker must be added to the Manager to be started.
+func NewChecker(c client.Client, opts Options) *Checker {
+	return &Checker{client: c, opts: opts}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (*Checker) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable, and checks the permissions until the
+// context is cancelled.
+func (c *Checker) Start(ctx context.Context) error {
+	wait.JitterUntilWithContext(ctx, c.check, c.opts.Interval, 0.1, true)
+	return nil
+}
+
+// Check implements healthz.Checker, and fails until the permissions have been
+// checked, or while any of them are missing.
+func (c *Checker) Check(*http.Request) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.checked {
+		return errors.New("permissions have not been checked yet")
+	}
+	if len(c.missing) > 0 {
+		return fmt.Errorf("missing RBAC permissions: %s", joinPermissions(c.missing))
+	}
+	return nil
+}
+
+// check reviews the permissions and reports any which are missing. Failed
+// reviews are logged and leave the last result in place.
+func (c *Checker) check(ctx context.Context) {
+	missing, err := c.Missing(ctx)
+	if err != nil {
+		c.opts.Log.Error(err, "failed to check RBAC permissions")
+		return
+	}
+
+	c.mu.Lock()
+	changed := !c.checked || !slices.Equal(c.missing, missing)
+	c.checked = true
+	c.missing = missing
+	c.mu.Unlock()
+
+	metrics.SetMissingPermissions(len(missing))
+
+	if len(missing) == 0 {
+		if changed {
+			c.opts.Log.Info("trust-manager holds all the RBAC permissions it needs")
+		}
+		return
+	}
+
+	c.opts.Log.Error(nil, "trust-manager is missing RBAC permissions; check that its ClusterRole and Roles match the installed version", "missing", permissionStrings(missing))
+
+	if changed && c.opts.Recorder != nil && c.opts.Pod != nil {
+		c.opts.Recorder.Eventf(c.opts.Pod, corev1.EventTypeWarning, "MissingPermissions", "trust-manager is missing RBAC permissions: %s", joinPermissions(missing))
+	}
+}
+
+// Missing returns the permissions which trust-manager doesn't hold, in the
+// order of the configured permissions.
+func (c *Checker) Missing(ctx context.Context) ([]Permission, error) {
+	var missing []Permission
+	for _, permission := range c.opts.Permissions {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   permission.Namespace,
+					Verb:        permission.Verb,
+					Group:       permission.Group,
+					Resource:    permission.Resource,
+					Subresource: permission.Subresource,
+				},
+			},
+		}
+		if err := c.client.Create(ctx, review); err != nil {
+			return nil, fmt.Errorf("failed to review permission to %s: %w", permission, err)
+		}
+
+		if !review.Status.Allowed {
+			missing = append(missing, permission)
+		}
+	}
+
+	return missing, nil
+}
+
+// PodReference returns a reference to the named Pod, on which Events can be
+// recorded without getting the Pod, or nil if the name or Namespace is empty.
+func PodReference(namespace, name string) *corev1.ObjectReference {
+	if namespace == "" || name == "" {
+		return nil
+	}
+	return &corev1.ObjectReference{Kind: "Pod", APIVersion: "v1", Namespace: namespace, Name: name}
+}
+
+func permissionStrings(permissions []Permission) []string {
+	strs := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		strs = append(strs, permission.String())
+	}
+	return strs
+}
+
+func joinPermissions(permissions []Permission) string {
+	return strings.Join(permissionStrings(permissions), ", ")
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func TestPermission_String(t *testing.T) {
+	assert.Equal(t, "patch bundles.trust.cert-manager.io/status", Permission{Verb: "patch", Group: "trust.cert-manager.io", Resource: "bundles", Subresource: "status"}.String())
+	assert.Equal(t, "get secrets in Namespace cert-manager", Permission{Namespace: "cert-manager", Verb: "get", Resource: "secrets"}.String())
+}
+
+func TestChecker(t *testing.T) {
+	var (
+		patchStatus = Permission{Verb: "patch", Group: "trust.cert-manager.io", Resource: "bundles", Subresource: "status"}
+		getSecrets  = Permission{Namespace: "cert-manager", Verb: "get", Resource: "secrets"}
+		patchMaps   = Permission{Verb: "patch", Resource: "configmaps"}
+	)
+
+	// denied holds the permissions which the fake API server denies.
+	denied := map[Permission]bool{getSecrets: true}
+	var reviewErr error
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(trustapi.GlobalScheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				if reviewErr != nil {
+					return reviewErr
+				}
+				review := obj.(*authorizationv1.SelfSubjectAccessReview)
+				attrs := review.Spec.ResourceAttributes
+				review.Status.Allowed = !denied[Permission{
+					Namespace:   attrs.Namespace,
+					Verb:        attrs.Verb,
+					Group:       attrs.Group,
+					Resource:    attrs.Resource,
+					Subresource: attrs.Subresource,
+				}]
+				return nil
+			},
+		}).
+		Build()
+
+	log, ctx := ktesting.NewTestContext(t)
+	recorder := record.NewFakeRecorder(10)
+	checker := NewChecker(fakeClient, Options{
+		Log:         log,
+		Permissions: []Permission{patchStatus, getSecrets, patchMaps},
+		Recorder:    recorder,
+		Pod:         PodReference("cert-manager", "trust-manager-0"),
+	})
+
+	assert.EqualError(t, checker.Check(nil), "permissions have not been checked yet")
+
+	// Missing permissions fail the readiness check and are recorded once.
+	checker.check(ctx)
+	assert.EqualError(t, checker.Check(nil), "missing RBAC permissions: get secrets in Namespace cert-manager")
+	assert.Equal(t, "Warning MissingPermissions trust-manager is missing RBAC permissions: get secrets in Namespace cert-manager", <-recorder.Events)
+
+	checker.check(ctx)
+	assert.Empty(t, recorder.Events)
+
+	denied[patchMaps] = true
+	checker.check(ctx)
+	assert.EqualError(t, checker.Check(nil), "missing RBAC permissions: get secrets in Namespace cert-manager, patch configmaps")
+	assert.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// Failed reviews leave the last result in place.
+	reviewErr = errors.New("unavailable")
+	checker.check(ctx)
+	assert.Error(t, checker.Check(nil))
+	reviewErr = nil
+
+	denied = map[Permission]bool{}
+	checker.check(ctx)
+	assert.NoError(t, checker.Check(nil))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestPodReference(t *testing.T) {
+	assert.Nil(t, PodReference("", "trust-manager-0"))
+	assert.Nil(t, PodReference("cert-manager", ""))
+
+	ref := PodReference("cert-manager", "trust-manager-0")
+	assert.Equal(t, "Pod", ref.Kind)
+	assert.Equal(t, "cert-manager", ref.Namespace)
+	assert.Equal(t, "trust-manager-0", ref.Name)
+}
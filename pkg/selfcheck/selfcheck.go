@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selfcheck verifies that a running trust-manager syncs Bundles, by
+// creating a temporary Bundle and checking its target in a test Namespace.
+// It is used after installs and by operators checking a cluster.
+package selfcheck
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+const (
+	// generateName prefixes the names of the objects the check creates.
+	generateName = "trust-manager-selfcheck-"
+
+	// The keys of the target ConfigMap.
+	pemKey    = "ca.crt"
+	jksKey    = "truststore.jks"
+	pkcs12Key = "truststore.p12"
+
+	// cleanupTimeout bounds the time taken to delete the objects the check
+	// created, even once the check itself has timed out.
+	cleanupTimeout = 30 * time.Second
+)
+
+// Options configure a check.
+type Options struct {
+	Log logr.Logger
+
+	// Namespace is an existing Namespace which the Bundle is synced to. If
+	// empty, a temporary Namespace is created, which requires trust-manager
+	// to watch all Namespaces.
+	Namespace string
+
+	// Timeout bounds the time the Bundle may take to sync.
+	Timeout time.Duration
+
+	// PollInterval is how often the target is checked while waiting.
+	PollInterval time.Duration
+}
+
+// Run creates a temporary Bundle of a generated CA certificate, waits for its
+// PEM, JKS and PKCS#12 target to be synced to the test Namespace, checks that
+// the truststores decode to the certificate, and deletes the objects it
+// created. It returns an error if the Bundle didn't sync correctly in time.
+func Run(ctx context.Context, cl client.Client, opts Options) (err error) {
+	log := opts.Log
+
+	caPEM, err := generateCA()
+	if err != nil {
+		return fmt.Errorf("failed to generate test CA certificate: %w", err)
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: generateName}}
+		if err := cl.Create(ctx, ns); err != nil {
+			return fmt.Errorf("failed to create test Namespace: %w", err)
+		}
+		namespace = ns.Name
+		log.Info("created test Namespace", "namespace", namespace)
+		defer func() { err = errors.Join(err, cleanup(log, cl, "Namespace", ns)) }()
+	}
+
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: generateName},
+		Spec: trustapi.BundleSpec{
+			Sources: []trustapi.BundleSource{{InLine: ptr.To(caPEM)}},
+			Target: trustapi.BundleTarget{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: pemKey}},
+				AdditionalFormats: &trustapi.AdditionalFormats{
+					JKS:    &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: jksKey}},
+					PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: pkcs12Key}},
+				},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+				},
+			},
+		},
+	}
+	if err := cl.Create(ctx, bundle); err != nil {
+		return fmt.Errorf("failed to create test Bundle: %w", err)
+	}
+	log.Info("created test Bundle", "bundle", bundle.Name)
+	defer func() { err = errors.Join(err, cleanup(log, cl, "Bundle", bundle)) }()
+
+	var lastErr error
+	if err := wait.PollUntilContextTimeout(ctx, opts.PollInterval, opts.Timeout, true, func(ctx context.Context) (bool, error) {
+		lastErr = checkTarget(ctx, cl, bundle.Name, namespace, caPEM)
+		return lastErr == nil, nil
+	}); err != nil {
+		if lastErr != nil {
+			err = lastErr
+		}
+		return fmt.Errorf("test Bundle %q was not synced to Namespace %q: %w", bundle.Name, namespace, err)
+	}
+
+	log.Info("test Bundle was synced, and its truststores decode to the bundle", "bundle", bundle.Name, "namespace", namespace)
+	return nil
+}
+
+// checkTarget returns an error unless the target ConfigMap of the Bundle in
+// the Namespace holds the expected PEM bundle, and truststores of it.
+func checkTarget(ctx context.Context, cl client.Client, bundleName, namespace, expectedPEM string) error {
+	var configMap corev1.ConfigMap
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: bundleName}, &configMap); err != nil {
+		return fmt.Errorf("failed to get target ConfigMap: %w", err)
+	}
+
+	if got := configMap.Data[pemKey]; strings.TrimSpace(got) != strings.TrimSpace(expectedPEM) {
+		return fmt.Errorf("target ConfigMap key %q doesn't hold the expected bundle", pemKey)
+	}
+	if err := CheckJKS(configMap.BinaryData[jksKey], trustapi.DefaultJKSPassword, expectedPEM); err != nil {
+		return fmt.Errorf("target ConfigMap key %q: %w", jksKey, err)
+	}
+	if err := CheckPKCS12(configMap.BinaryData[pkcs12Key], trustapi.DefaultPKCS12Password, expectedPEM); err != nil {
+		return fmt.Errorf("target ConfigMap key %q: %w", pkcs12Key, err)
+	}
+
+	return nil
+}
+
+// cleanup deletes an object the check created. It uses a context of its own,
+// so that objects are deleted even if the check was cancelled.
+func cleanup(log logr.Logger, cl client.Client, kind string, obj client.Object) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	if err := cl.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete test %s %q: %w", kind, obj.GetName(), err)
+	}
+
+	log.Info("deleted test "+kind, "name", obj.GetName())
+	return nil
+}
+
+// generateCA returns a self-signed CA certificate, valid for a day, as PEM.
+// The key is discarded, as the certificate is only distributed.
+func generateCA() (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "trust-manager selfcheck"},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/truststore"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		namespace string
+		sync      func(bundle *trustapi.Bundle, configMap *corev1.ConfigMap)
+		expErr    string
+	}{
+		"Bundle synced to a temporary Namespace": {
+			sync: func(*trustapi.Bundle, *corev1.ConfigMap) {},
+		},
+		"Bundle synced to an existing Namespace": {
+			namespace: "selfcheck",
+			sync:      func(*trustapi.Bundle, *corev1.ConfigMap) {},
+		},
+		"Bundle not synced": {
+			expErr: "failed to get target ConfigMap",
+		},
+		"truststore not matching the bundle": {
+			sync: func(_ *trustapi.Bundle, configMap *corev1.ConfigMap) {
+				configMap.BinaryData[pkcs12Key] = configMap.BinaryData[jksKey]
+			},
+			expErr: `target ConfigMap key "truststore.p12": failed to decode PKCS#12 truststore`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "selfcheck"}}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					// Stand in for trust-manager, syncing Bundles as they are
+					// created.
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if err := c.Create(ctx, obj, opts...); err != nil {
+							return err
+						}
+						bundle, ok := obj.(*trustapi.Bundle)
+						if !ok || test.sync == nil {
+							return nil
+						}
+						configMap := syncedTarget(t, bundle)
+						test.sync(bundle, configMap)
+						return c.Create(ctx, configMap)
+					},
+				}).
+				Build()
+
+			err := Run(context.TODO(), cl, Options{
+				Log:          logr.Discard(),
+				Namespace:    test.namespace,
+				Timeout:      100 * time.Millisecond,
+				PollInterval: 10 * time.Millisecond,
+			})
+			if test.expErr != "" {
+				assert.ErrorContains(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// The objects created by the check are deleted.
+			var bundles trustapi.BundleList
+			require.NoError(t, cl.List(context.TODO(), &bundles))
+			assert.Empty(t, bundles.Items)
+
+			var namespaces corev1.NamespaceList
+			require.NoError(t, cl.List(context.TODO(), &namespaces))
+			require.Len(t, namespaces.Items, 1)
+			assert.Equal(t, "selfcheck", namespaces.Items[0].Name)
+		})
+	}
+}
+
+// syncedTarget returns the target ConfigMap which trust-manager would sync
+// for the Bundle.
+func syncedTarget(t *testing.T, bundle *trustapi.Bundle) *corev1.ConfigMap {
+	t.Helper()
+
+	pool := util.NewCertPool()
+	require.NoError(t, pool.AddCertsFromPEM([]byte(*bundle.Spec.Sources[0].InLine)))
+
+	jksData, err := truststore.NewJKSEncoder(trustapi.DefaultJKSPassword).Encode(pool)
+	require.NoError(t, err)
+	pkcs12Data, err := truststore.NewPKCS12Encoder(trustapi.DefaultPKCS12Password).Encode(pool)
+	require.NoError(t, err)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundle.Name,
+			Namespace: bundle.Spec.Target.NamespaceSelector.MatchLabels[corev1.LabelMetadataName],
+		},
+		Data:       map[string]string{pemKey: pool.PEM()},
+		BinaryData: map[string][]byte{jksKey: jksData, pkcs12Key: pkcs12Data},
+	}
+}
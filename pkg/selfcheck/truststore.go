@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfcheck
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// CheckJKS returns an error unless the JKS truststore opens with the password
+// and holds exactly the certificates of the expected PEM bundle.
+func CheckJKS(jksData []byte, password string, expectedPEM string) error {
+	ks := jks.New()
+	if err := ks.Load(bytes.NewReader(jksData), []byte(password)); err != nil {
+		return fmt.Errorf("failed to decode JKS truststore: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	for _, alias := range ks.Aliases() {
+		entry, err := ks.GetTrustedCertificateEntry(alias)
+		if err != nil {
+			return fmt.Errorf("JKS truststore entry %q is not a trusted certificate: %w", alias, err)
+		}
+		cert, err := x509.ParseCertificate(entry.Certificate.Content)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate of JKS truststore entry %q: %w", alias, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	return checkCertificates("JKS", certs, expectedPEM)
+}
+
+// CheckPKCS12 returns an error unless the PKCS#12 truststore opens with the
+// password and holds exactly the certificates of the expected PEM bundle.
+func CheckPKCS12(pkcs12Data []byte, password string, expectedPEM string) error {
+	certs, err := pkcs12.DecodeTrustStore(pkcs12Data, password)
+	if err != nil {
+		return fmt.Errorf("failed to decode PKCS#12 truststore: %w", err)
+	}
+
+	return checkCertificates("PKCS#12", certs, expectedPEM)
+}
+
+// checkCertificates returns an error unless certs are the certificates of the
+// expected PEM bundle, in any order.
+func checkCertificates(format string, certs []*x509.Certificate, expectedPEM string) error {
+	expected := util.NewCertPool(util.WithFilteredExpiredCerts(false))
+	if err := expected.AddCertsFromPEM([]byte(expectedPEM)); err != nil {
+		return fmt.Errorf("invalid expected PEM bundle: %w", err)
+	}
+
+	if len(certs) != expected.Size() {
+		return fmt.Errorf("expected %d certificates in %s truststore but found %d", expected.Size(), format, len(certs))
+	}
+
+	for _, want := range expected.Certificates() {
+		found := false
+		for _, cert := range certs {
+			if cert.Equal(want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s truststore is missing the certificate %q", format, want.Subject)
+		}
+	}
+
+	return nil
+}
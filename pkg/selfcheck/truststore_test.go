@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/trust-manager/pkg/truststore"
+	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func TestCheckTruststores(t *testing.T) {
+	bundlePEM := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)
+	otherPEM := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate3)
+
+	pool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
+	require.NoError(t, pool.AddCertsFromPEM([]byte(bundlePEM)))
+
+	jksData, err := truststore.NewJKSEncoder("changeit").Encode(pool)
+	require.NoError(t, err)
+	assert.NoError(t, CheckJKS(jksData, "changeit", bundlePEM))
+	assert.ErrorContains(t, CheckJKS(jksData, "wrong", bundlePEM), "failed to decode JKS truststore")
+	assert.ErrorContains(t, CheckJKS(jksData, "changeit", dummy.TestCertificate1), "expected 1 certificates in JKS truststore but found 2")
+	assert.ErrorContains(t, CheckJKS(jksData, "changeit", otherPEM), "JKS truststore is missing the certificate")
+
+	pkcs12Data, err := truststore.NewPKCS12Encoder("").Encode(pool)
+	require.NoError(t, err)
+	assert.NoError(t, CheckPKCS12(pkcs12Data, "", bundlePEM))
+	assert.ErrorContains(t, CheckPKCS12(pkcs12Data, "", otherPEM), "PKCS#12 truststore is missing the certificate")
+	assert.ErrorContains(t, CheckPKCS12(nil, "", bundlePEM), "failed to decode PKCS#12 truststore")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourceplugin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	sourcepluginv1 "github.com/cert-manager/trust-manager/pkg/sourceplugin/api/v1"
+)
+
+// callTimeout bounds the time taken by each call to a plugin.
+const callTimeout = 30 * time.Second
+
+// Provider is the cloudsource provider of plugin sources, whose references,
+// as returned by Ref, name the plugin and the parameters of the source. It
+// connects to the plugins in its plugin directory as they're first used. It
+// is safe for concurrent use.
+type Provider struct {
+	dir string
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewProvider returns a Provider for the plugins in the given directory.
+func NewProvider(dir string) (*Provider, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		dir:   dir,
+		conns: make(map[string]*grpc.ClientConn),
+	}, nil
+}
+
+// Fetch returns the certificates of the source with the given reference,
+// once the plugin reports that it's serving.
+func (p *Provider) Fetch(ctx context.Context, ref string) (string, error) {
+	name, parameters, err := ParseRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	conn, err := p.conn(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to source plugin %q: %w", name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+
+	health, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: ServiceName})
+	if err != nil {
+		return "", fmt.Errorf("failed to check health of source plugin %q: %s", name, status.Convert(err).Message())
+	}
+	if health.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return "", fmt.Errorf("source plugin %q is not serving: %s", name, health.Status)
+	}
+
+	resp, err := sourcepluginv1.NewSourcePluginClient(conn).FetchCertificates(ctx, &sourcepluginv1.FetchCertificatesRequest{Parameters: parameters})
+	if err != nil {
+		s := status.Convert(err)
+		return "", fmt.Errorf("source plugin %q failed to fetch certificates: %s: %s", name, s.Code(), s.Message())
+	}
+	if strings.TrimSpace(resp.Certificates) == "" {
+		return "", fmt.Errorf("source plugin %q returned no certificates", name)
+	}
+
+	return resp.Certificates, nil
+}
+
+// conn returns the connection to the plugin with the given name.
+func (p *Provider) conn(name string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn, ok := p.conns[name]; ok {
+		return conn, nil
+	}
+
+	conn, err := grpc.NewClient("unix://"+SocketPath(p.dir, name), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	p.conns[name] = conn
+	return conn, nil
+}
+
+// Close closes the connections to the plugins.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name, conn := range p.conns {
+		_ = conn.Close()
+		delete(p.conns, name)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourceplugin
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	sourcepluginv1 "github.com/cert-manager/trust-manager/pkg/sourceplugin/api/v1"
+)
+
+// Serve serves the plugin on the unix socket at the given path until the
+// context is done, replacing any socket left behind at the path. It also
+// serves the gRPC health checking protocol, reporting the plugin as serving
+// while healthy, if set, returns nil.
+func Serve(ctx context.Context, socketPath string, plugin sourcepluginv1.SourcePluginServer, healthy func(context.Context) error) error {
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	sourcepluginv1.RegisterSourcePluginServer(server, plugin)
+	grpc_health_v1.RegisterHealthServer(server, healthServer{healthy: healthy})
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.GracefulStop()
+		case <-stopped:
+		}
+	}()
+
+	return server.Serve(listener)
+}
+
+// healthServer reports the health of a plugin.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	healthy func(context.Context) error
+}
+
+func (h healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if req.Service != "" && req.Service != ServiceName {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+
+	if h.healthy != nil && h.healthy(ctx) != nil {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sourceplugin connects trust-manager to source plugins: processes
+// running alongside trust-manager, such as sidecars, which provide the
+// certificates of the plugin sources of Bundles. Plugins serve the
+// SourcePlugin gRPC API of the api/v1 package, and the gRPC health checking
+// protocol, on the unix socket named after the plugin in the plugin
+// directory of trust-manager. Third parties implement plugins with Serve, so
+// that sources such as the inventories of corporate PKIs need no changes to
+// trust-manager.
+package sourceplugin
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	sourcepluginv1 "github.com/cert-manager/trust-manager/pkg/sourceplugin/api/v1"
+)
+
+// ServiceName is the name of the SourcePlugin service, whose health plugins
+// report.
+var ServiceName = sourcepluginv1.SourcePlugin_ServiceDesc.ServiceName
+
+// SocketPath returns the path of the socket of the plugin with the given name
+// in the plugin directory.
+func SocketPath(dir, name string) string {
+	return filepath.Join(dir, name+".sock")
+}
+
+// Ref returns the reference of the source which fetches certificates from
+// the plugin with the given name, with the given parameters.
+func Ref(name string, parameters map[string]string) string {
+	if len(parameters) == 0 {
+		return name
+	}
+
+	values := make(url.Values, len(parameters))
+	for key, value := range parameters {
+		values.Set(key, value)
+	}
+	return name + "?" + values.Encode()
+}
+
+// ParseRef returns the name of the plugin and the parameters of the source
+// with the given reference.
+func ParseRef(ref string) (string, map[string]string, error) {
+	name, query, _ := strings.Cut(ref, "?")
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return "", nil, fmt.Errorf("invalid source plugin name %q: %s", name, strings.Join(errs, ", "))
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid parameters of source plugin %q: %w", name, err)
+	}
+
+	var parameters map[string]string
+	if len(values) > 0 {
+		parameters = make(map[string]string, len(values))
+		for key := range values {
+			parameters[key] = values.Get(key)
+		}
+	}
+	return name, parameters, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourceplugin
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sourcepluginv1 "github.com/cert-manager/trust-manager/pkg/sourceplugin/api/v1"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+// fakePlugin returns the certificates of the "ca" parameter.
+type fakePlugin struct {
+	sourcepluginv1.UnimplementedSourcePluginServer
+}
+
+func (fakePlugin) FetchCertificates(_ context.Context, req *sourcepluginv1.FetchCertificatesRequest) (*sourcepluginv1.FetchCertificatesResponse, error) {
+	switch req.Parameters["ca"] {
+	case "1":
+		return &sourcepluginv1.FetchCertificatesResponse{Certificates: dummy.TestCertificate1}, nil
+	case "empty":
+		return &sourcepluginv1.FetchCertificatesResponse{}, nil
+	default:
+		return nil, status.Errorf(codes.NotFound, "unknown CA %q", req.Parameters["ca"])
+	}
+}
+
+func Test_Ref(t *testing.T) {
+	for ref, expParameters := range map[string]map[string]string{
+		"inventory":               nil,
+		"inventory?ca=1":          {"ca": "1"},
+		"inventory?a=%26&ca=1%3D": {"a": "&", "ca": "1="},
+	} {
+		name, parameters, err := ParseRef(ref)
+		require.NoError(t, err)
+		assert.Equal(t, "inventory", name)
+		assert.Equal(t, expParameters, parameters)
+		assert.Equal(t, ref, Ref(name, parameters))
+	}
+
+	_, _, err := ParseRef("../inventory")
+	assert.ErrorContains(t, err, `invalid source plugin name "../inventory"`)
+}
+
+func Test_Provider(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var unhealthy atomic.Bool
+	served := make(chan error)
+	go func() {
+		served <- Serve(ctx, SocketPath(dir, "inventory"), fakePlugin{}, func(context.Context) error {
+			if unhealthy.Load() {
+				return errors.New("inventory unavailable")
+			}
+			return nil
+		})
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-served)
+	}()
+
+	p, err := NewProvider(dir)
+	require.NoError(t, err)
+	defer p.Close()
+
+	// The plugin may take a moment to listen.
+	var certificates string
+	require.Eventually(t, func() bool {
+		certificates, err = p.Fetch(ctx, Ref("inventory", map[string]string{"ca": "1"}))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, dummy.TestCertificate1, certificates)
+
+	_, err = p.Fetch(ctx, Ref("inventory", map[string]string{"ca": "2"}))
+	assert.EqualError(t, err, `source plugin "inventory" failed to fetch certificates: NotFound: unknown CA "2"`)
+
+	_, err = p.Fetch(ctx, Ref("inventory", map[string]string{"ca": "empty"}))
+	assert.EqualError(t, err, `source plugin "inventory" returned no certificates`)
+
+	unhealthy.Store(true)
+	_, err = p.Fetch(ctx, Ref("inventory", map[string]string{"ca": "1"}))
+	assert.EqualError(t, err, `source plugin "inventory" is not serving: NOT_SERVING`)
+
+	_, err = p.Fetch(ctx, Ref("missing", nil))
+	assert.ErrorContains(t, err, `failed to check health of source plugin "missing"`)
+}
@@ -0,0 +1,427 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package truststore encodes trust bundles into the truststore formats which
+// trust-manager can write to Bundle targets, for use by other controllers
+// which build their own trust bundles.
+package truststore
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/md5" //nolint:gosec // certdata.txt identifies certificates by their MD5 hash
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // certdata.txt identifies certificates by their SHA-1 hash
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand/v2" //nolint:gosec // the salts of deterministic PKCS#12 truststores are derived from their content on purpose
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+// Encoder encodes the certificates of a trust bundle into a truststore format.
+type Encoder interface {
+	Encode(trustBundle *util.CertPool) ([]byte, error)
+}
+
+// NewJKSEncoder returns an Encoder which writes a JKS truststore protected
+// by the given password.
+func NewJKSEncoder(password string) Encoder {
+	return &jksEncoder{password: password}
+}
+
+type jksEncoder struct {
+	password string
+}
+
+// Encode creates a binary JKS file from the given PEM-encoded trust bundle and Password.
+// Note that the Password is not treated securely; JKS files generally seem to expect a Password
+// to exist and so we have the option for one.
+func (e jksEncoder) Encode(trustBundle *util.CertPool) ([]byte, error) {
+	// The JKS file must be byte-for-byte identical for the same certificates, no matter
+	// when or in which order they're encoded, so that unchanged bundles don't cause
+	// spurious target updates or GitOps diffs. WithOrderedAliases ensures that trusted
+	// certs are written to the JKS file sorted by alias rather than in map order.
+	ks := keystore.New(keystore.WithOrderedAliases())
+
+	for _, c := range trustBundle.Certificates() {
+		alias := certAlias(c.Raw, c.Subject.String())
+
+		// Note on CreationTime:
+		// Debian's JKS trust store sets the creation time to match the time that certs are added to the
+		// trust store (i.e., it's effectively time.Now() at the instant the file is generated).
+		// Using that method would make our JKS files in trust-manager non-deterministic, leaving us with
+		// two options if we want to maintain determinism:
+		// - Using something from the cert being added (e.g. NotBefore / NotAfter)
+		// - Using a fixed time (i.e. unix epoch)
+		// We use NotBefore here, arbitrarily. Don't change this: it would change the
+		// encoded bytes of every existing JKS target.
+
+		if err := ks.SetTrustedCertificateEntry(alias, keystore.TrustedCertificateEntry{
+			CreationTime: c.NotBefore,
+			Certificate: keystore.Certificate{
+				Type:    "X509",
+				Content: c.Raw,
+			},
+		}); err != nil {
+			// this error should never happen if we set jks.Certificate correctly
+			return nil, fmt.Errorf("failed to add cert with alias %q to trust store: %w", alias, err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := ks.Store(buf, []byte(e.password)); err != nil {
+		return nil, fmt.Errorf("failed to create JKS file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewPKCS12Encoder returns an Encoder which writes a PKCS#12 truststore
+// protected by the given password. An empty password writes a passwordless
+// truststore.
+func NewPKCS12Encoder(password string) Encoder {
+	return &pkcs12Encoder{password: password}
+}
+
+// NewDeterministicPKCS12Encoder returns an Encoder which writes a PKCS#12
+// truststore like NewPKCS12Encoder, but derives its salts from the
+// certificates and password rather than generating them randomly, so that the
+// same certificates always encode to the same bytes.
+func NewDeterministicPKCS12Encoder(password string) Encoder {
+	return &pkcs12Encoder{password: password, deterministic: true}
+}
+
+type pkcs12Encoder struct {
+	password      string
+	deterministic bool
+}
+
+func (e pkcs12Encoder) Encode(trustBundle *util.CertPool) ([]byte, error) {
+	var entries []pkcs12.TrustStoreEntry
+	for _, c := range trustBundle.Certificates() {
+		entries = append(entries, pkcs12.TrustStoreEntry{
+			Cert:         c,
+			FriendlyName: certAlias(c.Raw, c.Subject.String()),
+		})
+	}
+
+	encoder := pkcs12.LegacyRC2
+
+	if e.password == "" {
+		encoder = pkcs12.Passwordless
+	} else if e.deterministic {
+		encoder = encoder.WithRand(pkcs12Rand(trustBundle, e.password))
+	}
+
+	return encoder.EncodeTrustStoreEntries(entries, e.password)
+}
+
+// pkcs12Rand returns the source of the salts of a deterministic PKCS#12
+// truststore: a stream seeded with the hash of the password and of the
+// certificates, in the order in which they're encoded.
+func pkcs12Rand(trustBundle *util.CertPool, password string) io.Reader {
+	// Every input is hashed on its own first, so that the boundaries between
+	// them can't be shifted.
+	hash := sha256.New()
+	passwordHash := sha256.Sum256([]byte(password))
+	_, _ = hash.Write(passwordHash[:])
+	for _, c := range trustBundle.Certificates() {
+		certHash := sha256.Sum256(c.Raw)
+		_, _ = hash.Write(certHash[:])
+	}
+
+	var seed [32]byte
+	hash.Sum(seed[:0])
+	return rand.NewChaCha8(seed)
+}
+
+// NewSPIFFEEncoder returns an Encoder which writes a SPIFFE trust bundle for
+// the given trust domain.
+func NewSPIFFEEncoder(trustDomain string) Encoder {
+	return &spiffeEncoder{trustDomain: trustDomain}
+}
+
+type spiffeEncoder struct {
+	trustDomain string
+}
+
+// spiffeJWK is a single X.509 authority entry in a SPIFFE trust bundle.
+// See https://github.com/spiffe/spiffe/blob/main/standards/SPIFFE_Trust_Domain_and_Bundle.md#4-spiffe-bundle-format
+type spiffeJWK struct {
+	Use string   `json:"use"`
+	Kty string   `json:"kty"`
+	Crv string   `json:"crv,omitempty"`
+	X   string   `json:"x,omitempty"`
+	Y   string   `json:"y,omitempty"`
+	N   string   `json:"n,omitempty"`
+	E   string   `json:"e,omitempty"`
+	X5C []string `json:"x5c"`
+}
+
+type spiffeBundle struct {
+	Keys []spiffeJWK `json:"keys"`
+}
+
+// errUnsupportedJWKKey is returned for certificates whose public key can't be
+// represented as a JWK.
+var errUnsupportedJWKKey = errors.New("public key can't be represented as a JWK")
+
+// Encode creates a SPIFFE trust bundle document, keyed by trust domain, containing
+// one "x509-svid" JWK for every certificate in the given trust bundle.
+// Certificates whose public key can't be represented as a JWK, such as ECDSA
+// keys on curves other than P-256, P-384 and P-521, are left out, as SPIFFE
+// implementations would reject the whole document.
+func (e spiffeEncoder) Encode(trustBundle *util.CertPool) ([]byte, error) {
+	bundle := spiffeBundle{Keys: []spiffeJWK{}}
+
+	for _, c := range trustBundle.Certificates() {
+		key, err := spiffeX509Authority(c)
+		if errors.Is(err, errUnsupportedJWKKey) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to add cert %q to SPIFFE bundle: %w", c.Subject.String(), err)
+		}
+
+		bundle.Keys = append(bundle.Keys, key)
+	}
+
+	return json.Marshal(map[string]spiffeBundle{e.trustDomain: bundle})
+}
+
+// spiffeX509Authority builds the JWK representation of the given CA certificate.
+// The public key parameters are included alongside the certificate, which is
+// what SPIRE and go-spiffe expect when parsing a bundle.
+func spiffeX509Authority(c *x509.Certificate) (spiffeJWK, error) {
+	key := spiffeJWK{
+		Use: "x509-svid",
+		X5C: []string{base64.StdEncoding.EncodeToString(c.Raw)},
+	}
+
+	switch pub := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		key.Kty = "RSA"
+		key.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+
+	case *ecdsa.PublicKey:
+		// crypto/ecdh only supports the curves which JWKs define.
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return spiffeJWK{}, fmt.Errorf("%w: %w", errUnsupportedJWKKey, err)
+		}
+
+		// The uncompressed point encoding is 0x04 || X || Y.
+		point := ecdhKey.Bytes()[1:]
+		key.Kty = "EC"
+		key.Crv = pub.Curve.Params().Name
+		key.X = base64.RawURLEncoding.EncodeToString(point[:len(point)/2])
+		key.Y = base64.RawURLEncoding.EncodeToString(point[len(point)/2:])
+
+	case ed25519.PublicKey:
+		key.Kty = "OKP"
+		key.Crv = "Ed25519"
+		key.X = base64.RawURLEncoding.EncodeToString(pub)
+
+	default:
+		return spiffeJWK{}, fmt.Errorf("%w: unsupported public key type %T", errUnsupportedJWKKey, c.PublicKey)
+	}
+
+	return key, nil
+}
+
+// NewCertdataEncoder returns an Encoder which writes a listing of the trust
+// bundle in the certdata.txt format of Mozilla's NSS, as consumed by the
+// scripts which build NSS databases and OS CA packages from it.
+func NewCertdataEncoder() Encoder {
+	return &certdataEncoder{}
+}
+
+type certdataEncoder struct{}
+
+// certdataHeader starts every certdata.txt file, listing the builtin root list
+// object which NSS expects before the certificates.
+const certdataHeader = `#
+# certdata.txt
+#
+# This file was generated by trust-manager from a trust Bundle, in the format
+# of the certdata.txt file of Mozilla's NSS. Every certificate is trusted as
+# a CA for server authentication and email protection.
+#
+BEGINDATA
+CKA_CLASS CK_OBJECT_CLASS CKO_NSS_BUILTIN_ROOT_LIST
+CKA_TOKEN CK_BBOOL CK_TRUE
+CKA_PRIVATE CK_BBOOL CK_FALSE
+CKA_MODIFIABLE CK_BBOOL CK_FALSE
+CKA_LABEL UTF8 "trust-manager Bundle"
+`
+
+// Encode creates a certdata.txt listing with a certificate object and a trust
+// object for every certificate in the given trust bundle, in the order of the
+// bundle.
+func (e certdataEncoder) Encode(trustBundle *util.CertPool) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(certdataHeader)
+
+	labels := make(map[string]int)
+	for _, c := range trustBundle.Certificates() {
+		serial, err := asn1.Marshal(c.SerialNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode serial number of cert %q: %w", c.Subject.String(), err)
+		}
+
+		// Labels must be unique, as the scripts consuming certdata.txt name
+		// the certificates they write after them.
+		label := certdataLabel(c)
+		labels[label]++
+		if n := labels[label]; n > 1 {
+			label += " " + strconv.Itoa(n)
+		}
+
+		sha1Hash := sha1.Sum(c.Raw) //nolint:gosec // see import
+		md5Hash := md5.Sum(c.Raw)   //nolint:gosec // see import
+		sha256Hash := sha256.Sum256(c.Raw)
+
+		comment := func() {
+			fmt.Fprintf(buf, "# Issuer: %s\n", certdataText(c.Issuer.String()))
+			fmt.Fprintf(buf, "# Serial Number:%s\n", colonHex(c.SerialNumber.Bytes(), false))
+			fmt.Fprintf(buf, "# Subject: %s\n", certdataText(c.Subject.String()))
+			fmt.Fprintf(buf, "# Not Valid Before: %s\n", c.NotBefore.UTC().Format(certdataTimeLayout))
+			fmt.Fprintf(buf, "# Not Valid After : %s\n", c.NotAfter.UTC().Format(certdataTimeLayout))
+			fmt.Fprintf(buf, "# Fingerprint (SHA-256): %s\n", colonHex(sha256Hash[:], true))
+			fmt.Fprintf(buf, "# Fingerprint (SHA1): %s\n", colonHex(sha1Hash[:], true))
+		}
+
+		fmt.Fprintf(buf, "\n#\n# Certificate \"%s\"\n#\n", label)
+		comment()
+		buf.WriteString("CKA_CLASS CK_OBJECT_CLASS CKO_CERTIFICATE\n")
+		buf.WriteString("CKA_TOKEN CK_BBOOL CK_TRUE\n")
+		buf.WriteString("CKA_PRIVATE CK_BBOOL CK_FALSE\n")
+		buf.WriteString("CKA_MODIFIABLE CK_BBOOL CK_FALSE\n")
+		fmt.Fprintf(buf, "CKA_LABEL UTF8 \"%s\"\n", label)
+		buf.WriteString("CKA_CERTIFICATE_TYPE CK_CERTIFICATE_TYPE CKC_X_509\n")
+		writeCertdataOctal(buf, "CKA_SUBJECT", c.RawSubject)
+		buf.WriteString("CKA_ID UTF8 \"0\"\n")
+		writeCertdataOctal(buf, "CKA_ISSUER", c.RawIssuer)
+		writeCertdataOctal(buf, "CKA_SERIAL_NUMBER", serial)
+		writeCertdataOctal(buf, "CKA_VALUE", c.Raw)
+		buf.WriteString("CKA_NSS_MOZILLA_CA_POLICY CK_BBOOL CK_TRUE\n")
+		buf.WriteString("CKA_NSS_SERVER_DISTRUST_AFTER CK_BBOOL CK_FALSE\n")
+		buf.WriteString("CKA_NSS_EMAIL_DISTRUST_AFTER CK_BBOOL CK_FALSE\n")
+
+		fmt.Fprintf(buf, "\n# Trust for \"%s\"\n", label)
+		comment()
+		buf.WriteString("CKA_CLASS CK_OBJECT_CLASS CKO_NSS_TRUST\n")
+		buf.WriteString("CKA_TOKEN CK_BBOOL CK_TRUE\n")
+		buf.WriteString("CKA_PRIVATE CK_BBOOL CK_FALSE\n")
+		buf.WriteString("CKA_MODIFIABLE CK_BBOOL CK_FALSE\n")
+		fmt.Fprintf(buf, "CKA_LABEL UTF8 \"%s\"\n", label)
+		writeCertdataOctal(buf, "CKA_CERT_SHA1_HASH", sha1Hash[:])
+		writeCertdataOctal(buf, "CKA_CERT_MD5_HASH", md5Hash[:])
+		writeCertdataOctal(buf, "CKA_ISSUER", c.RawIssuer)
+		writeCertdataOctal(buf, "CKA_SERIAL_NUMBER", serial)
+		buf.WriteString("CKA_TRUST_SERVER_AUTH CK_TRUST CKT_NSS_TRUSTED_DELEGATOR\n")
+		buf.WriteString("CKA_TRUST_EMAIL_PROTECTION CK_TRUST CKT_NSS_TRUSTED_DELEGATOR\n")
+		buf.WriteString("CKA_TRUST_CODE_SIGNING CK_TRUST CKT_NSS_MUST_VERIFY_TRUST\n")
+		buf.WriteString("CKA_TRUST_STEP_UP_APPROVED CK_BBOOL CK_FALSE\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// certdataTimeLayout is the layout of the validity dates in the comments of
+// certdata.txt.
+const certdataTimeLayout = "Mon Jan 02 15:04:05 2006"
+
+// certdataLabel returns the label of the certificate: its common name, or its
+// whole subject if it has none.
+func certdataLabel(c *x509.Certificate) string {
+	if c.Subject.CommonName != "" {
+		return certdataText(c.Subject.CommonName)
+	}
+	return certdataText(c.Subject.String())
+}
+
+// certdataText replaces the quotes, backslashes and control characters in the
+// text, which can't be written in certdata.txt strings and comments.
+func certdataText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '"' || r == '\\' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// writeCertdataOctal writes the attribute as a MULTILINE_OCTAL value, with 16
+// octal-escaped bytes per line as in Mozilla's certdata.txt.
+func writeCertdataOctal(buf *bytes.Buffer, attribute string, value []byte) {
+	buf.WriteString(attribute + " MULTILINE_OCTAL\n")
+	for i, b := range value {
+		fmt.Fprintf(buf, "\\%03o", b)
+		if i%16 == 15 || i == len(value)-1 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("END\n")
+}
+
+// colonHex formats the bytes as colon-separated hex, such as "0a:1b".
+func colonHex(value []byte, upper bool) string {
+	parts := make([]string, 0, len(value))
+	for _, b := range value {
+		parts = append(parts, fmt.Sprintf("%02x", b))
+	}
+	s := strings.Join(parts, ":")
+	if upper {
+		return strings.ToUpper(s)
+	}
+	return s
+}
+
+// certAlias creates a JKS-safe alias for the given DER-encoded certificate, such that
+// any two certificates will have a different aliases unless they're identical in every way.
+// This unique alias fixes an issue where we used the Issuer field as an alias, leading to
+// different certs being treated as identical.
+// The friendlyName is included in the alias as a UX feature when examining JKS files using a
+// tool like `keytool`.
+func certAlias(derData []byte, friendlyName string) string {
+	certHashBytes := sha256.Sum256(derData)
+	certHash := hex.EncodeToString(certHashBytes[:])
+
+	// Since certHash is the part which actually distinguishes between two
+	// certificates, put it first so that it won't be truncated if a cert
+	// with a really long subject is added. Not sure what the upper limit
+	// for length actually is, but it shouldn't matter here.
+
+	return certHash[:8] + "|" + friendlyName
+}
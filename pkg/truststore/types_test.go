@@ -0,0 +1,371 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package truststore
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/stretchr/testify/assert"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_Encoder_Deterministic(t *testing.T) {
+	tests := map[string]struct {
+		encoder             Encoder
+		expNonDeterministic bool
+	}{
+		"JKS default password": {
+			encoder: NewJKSEncoder(v1alpha1.DefaultJKSPassword),
+		},
+		"JKS custom password": {
+			encoder: NewJKSEncoder("my-password"),
+		},
+		"PKCS#12 default password": {
+			encoder: NewPKCS12Encoder(v1alpha1.DefaultPKCS12Password),
+		},
+		"PKCS#12 custom password": {
+			encoder: NewPKCS12Encoder("my-password"),
+			// Salts are random unless deterministic encoding is requested
+			expNonDeterministic: true,
+		},
+		"PKCS#12 custom password deterministic": {
+			encoder: NewDeterministicPKCS12Encoder("my-password"),
+		},
+		"SPIFFE": {
+			encoder: NewSPIFFEEncoder("example.org"),
+		},
+		"certdata": {
+			encoder: NewCertdataEncoder(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bundle := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2, dummy.TestCertificate3)
+
+			certPool := util.NewCertPool()
+			if err := certPool.AddCertsFromPEM([]byte(bundle)); err != nil {
+				t.Fatalf("didn't expect an error but got: %s", err)
+			}
+
+			store, err := test.encoder.Encode(certPool)
+			if err != nil {
+				t.Fatalf("didn't expect an error but got: %s", err)
+			}
+
+			store2, err := test.encoder.Encode(certPool)
+			if err != nil {
+				t.Fatalf("didn't expect an error but got: %s", err)
+			}
+
+			if test.expNonDeterministic {
+				assert.NotEqual(t, store, store2, "expected encoder to be non-deterministic")
+			} else {
+				assert.Equal(t, store, store2, "expected encoder to be deterministic")
+			}
+		})
+	}
+}
+
+func Test_encodeJKSAliases(t *testing.T) {
+	// IMPORTANT: We use TestCertificate1 and TestCertificate2 here because they're defined
+	// to be self-signed and to also use the same Subject, while being different certs.
+	// This test ensures that the aliases we create when adding to a JKS file is different under
+	// these conditions (where the issuer / subject is identical).
+	// Using different dummy certs would allow this test to pass but wouldn't actually test anything useful!
+	bundle := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)
+
+	certPool := util.NewCertPool()
+	if err := certPool.AddCertsFromPEM([]byte(bundle)); err != nil {
+		t.Fatal(err)
+	}
+
+	jksFile, err := jksEncoder{password: v1alpha1.DefaultJKSPassword}.Encode(certPool)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got: %s", err)
+	}
+
+	reader := bytes.NewReader(jksFile)
+
+	ks := keystore.New()
+
+	err = ks.Load(reader, []byte(v1alpha1.DefaultJKSPassword))
+	if err != nil {
+		t.Fatalf("failed to parse generated JKS file: %s", err)
+	}
+
+	entryNames := ks.Aliases()
+
+	if len(entryNames) != 2 {
+		t.Fatalf("expected two certs in JKS file but got %d", len(entryNames))
+	}
+}
+
+func Test_encodeJKSStable(t *testing.T) {
+	// The JKS output must not depend on the order in which certificates were
+	// added, or on when the file was encoded.
+	encode := func(certs ...string) []byte {
+		certPool := util.NewCertPool()
+		if err := certPool.AddCertsFromPEM([]byte(dummy.JoinCerts(certs...))); err != nil {
+			t.Fatal(err)
+		}
+
+		jksFile, err := NewJKSEncoder(v1alpha1.DefaultJKSPassword).Encode(certPool)
+		if err != nil {
+			t.Fatalf("didn't expect an error but got: %s", err)
+		}
+		return jksFile
+	}
+
+	jksFile := encode(dummy.TestCertificate1, dummy.TestCertificate2, dummy.TestCertificate3)
+	assert.Equal(t, jksFile, encode(dummy.TestCertificate3, dummy.TestCertificate1, dummy.TestCertificate2))
+
+	// Pin the encoded bytes so that a change of the keystore library or of the
+	// encoder which would rewrite every existing JKS target is noticed.
+	digest := sha256.Sum256(jksFile)
+	assert.Equal(t, "5824b691724afa139100578be87d41fe9e2407849ad822a370cef4556ebb3051", hex.EncodeToString(digest[:]))
+
+	ks := keystore.New()
+	if err := ks.Load(bytes.NewReader(jksFile), []byte(v1alpha1.DefaultJKSPassword)); err != nil {
+		t.Fatalf("failed to parse generated JKS file: %s", err)
+	}
+
+	aliases := ks.Aliases()
+	assert.Len(t, aliases, 3)
+	for _, alias := range aliases {
+		entry, err := ks.GetTrustedCertificateEntry(alias)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cert, err := x509.ParseCertificate(entry.Certificate.Content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, cert.NotBefore.Equal(entry.CreationTime), "expected creation time of %q to be its NotBefore", alias)
+	}
+}
+
+func Test_encodeDeterministicPKCS12(t *testing.T) {
+	encode := func(password string, certs ...string) []byte {
+		certPool := util.NewCertPool()
+		if err := certPool.AddCertsFromPEM([]byte(dummy.JoinCerts(certs...))); err != nil {
+			t.Fatal(err)
+		}
+
+		p12File, err := NewDeterministicPKCS12Encoder(password).Encode(certPool)
+		if err != nil {
+			t.Fatalf("didn't expect an error but got: %s", err)
+		}
+		return p12File
+	}
+
+	p12File := encode("my-password", dummy.TestCertificate1, dummy.TestCertificate2)
+	assert.Equal(t, p12File, encode("my-password", dummy.TestCertificate2, dummy.TestCertificate1))
+
+	// The salts differ for other content or passwords.
+	assert.NotEqual(t, p12File, encode("other-password", dummy.TestCertificate1, dummy.TestCertificate2))
+	assert.NotEqual(t, p12File, encode("my-password", dummy.TestCertificate1, dummy.TestCertificate3))
+
+	certs, err := pkcs12.DecodeTrustStore(p12File, "my-password")
+	if err != nil {
+		t.Fatalf("failed to parse generated PKCS#12 file: %s", err)
+	}
+	assert.Len(t, certs, 2)
+}
+
+func Test_encodeSPIFFE(t *testing.T) {
+	bundle := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)
+
+	certPool := util.NewCertPool()
+	if err := certPool.AddCertsFromPEM([]byte(bundle)); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := NewSPIFFEEncoder("example.org").Encode(certPool)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got: %s", err)
+	}
+
+	var doc map[string]spiffeBundle
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		t.Fatalf("failed to parse generated SPIFFE bundle: %s", err)
+	}
+
+	keys := doc["example.org"].Keys
+	if len(keys) != 2 {
+		t.Fatalf("expected two keys in SPIFFE bundle but got %d", len(keys))
+	}
+
+	for i, cert := range certPool.Certificates() {
+		assert.Equal(t, "x509-svid", keys[i].Use)
+		assert.Equal(t, []string{base64.StdEncoding.EncodeToString(cert.Raw)}, keys[i].X5C)
+		assert.NotEmpty(t, keys[i].Kty)
+	}
+}
+
+func Test_encodeSPIFFE_unsupportedCurve(t *testing.T) {
+	// crypto/ecdh, and the JWK format, don't support P-224 keys.
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "p224-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := dummy.JoinCerts(dummy.TestCertificate1, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	certPool := util.NewCertPool()
+	if err := certPool.AddCertsFromPEM([]byte(bundle)); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, certPool.Certificates(), 2)
+
+	encoded, err := NewSPIFFEEncoder("example.org").Encode(certPool)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got: %s", err)
+	}
+
+	var doc map[string]spiffeBundle
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		t.Fatalf("failed to parse generated SPIFFE bundle: %s", err)
+	}
+
+	// Only the certificate with a supported key is written.
+	keys := doc["example.org"].Keys
+	block, _ := pem.Decode([]byte(dummy.TestCertificate1))
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, []string{base64.StdEncoding.EncodeToString(block.Bytes)}, keys[0].X5C)
+	}
+}
+
+func Test_encodeCertdata(t *testing.T) {
+	// TestCertificate1 and TestCertificate2 have the same subject, so their
+	// labels must be made unique.
+	bundle := dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2)
+
+	certPool := util.NewCertPool()
+	if err := certPool.AddCertsFromPEM([]byte(bundle)); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := NewCertdataEncoder().Encode(certPool)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got: %s", err)
+	}
+
+	var (
+		values    [][]byte
+		labels    []string
+		attribute string
+		value     []byte
+	)
+	for _, line := range strings.Split(string(encoded), "\n") {
+		switch {
+		case strings.HasSuffix(line, " MULTILINE_OCTAL"):
+			attribute, value = strings.TrimSuffix(line, " MULTILINE_OCTAL"), nil
+		case line == "END":
+			if attribute == "CKA_VALUE" {
+				values = append(values, value)
+			}
+			attribute = ""
+		case attribute != "":
+			for _, octal := range strings.Split(line, "\\")[1:] {
+				b, err := strconv.ParseUint(octal, 8, 8)
+				if err != nil {
+					t.Fatalf("failed to parse octal value %q: %s", octal, err)
+				}
+				value = append(value, byte(b))
+			}
+		case strings.HasPrefix(line, "CKA_LABEL UTF8 "):
+			labels = append(labels, strings.TrimPrefix(line, "CKA_LABEL UTF8 "))
+		}
+	}
+
+	certs := certPool.Certificates()
+	if len(values) != len(certs) {
+		t.Fatalf("expected %d certs in certdata but got %d", len(certs), len(values))
+	}
+	for i, cert := range certs {
+		assert.Equal(t, cert.Raw, values[i])
+	}
+
+	// The builtin root list, then a certificate and a trust object per cert.
+	assert.Equal(t, []string{
+		`"trust-manager Bundle"`,
+		`"cmct-test-root"`, `"cmct-test-root"`,
+		`"cmct-test-root 2"`, `"cmct-test-root 2"`,
+	}, labels)
+}
+
+func Test_certAlias(t *testing.T) {
+	// We might not ever rely on aliases being stable, but this test seeks
+	// to enforce stability for now. It'll be easy to remove.
+
+	// If this test starts failing after TestCertificate1 is updated, it'll
+	// need to be updated with the new alias for the new cert.
+
+	block, _ := pem.Decode([]byte(dummy.TestCertificate1))
+	if block == nil {
+		t.Fatalf("couldn't parse a PEM block from TestCertificate1")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("Dummy certificate TestCertificate1 couldn't be parsed: %s", err)
+	}
+
+	alias := certAlias(cert.Raw, cert.Subject.String())
+
+	expectedAlias := "548b988f|CN=cmct-test-root,O=cert-manager"
+
+	if alias != expectedAlias {
+		t.Fatalf("expected alias to be %q but got %q", expectedAlias, alias)
+	}
+}
@@ -20,9 +20,13 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"iter"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -34,19 +38,159 @@ import (
 type CertPool struct {
 	certificates map[[32]byte]*x509.Certificate
 
+	// copies holds the number of times each certificate was added, for pools
+	// which don't deduplicate certificates.
+	copies map[[32]byte]int
+
+	// keys holds the deduplication keys of the certificates, for pools which
+	// deduplicate by something other than the whole certificate.
+	keys map[string]struct{}
+
 	filterExpired bool
+	rejectExpired bool
+	requireCA     bool
+	skipInvalid   bool
+	deduplication Deduplication
+
+	trailingNewline bool
+
+	// filtered lists the certificates which were removed from the input.
+	filtered []FilteredCertificate
+
+	// invalid lists the malformed PEM blocks which were skipped in the input.
+	invalid []InvalidBlock
 
 	logger logr.Logger
 }
 
+// Option configures a CertPool.
 type Option func(*CertPool)
 
+// WithFilteredExpiredCerts filters out expired certificates when added to
+// the CertPool.
 func WithFilteredExpiredCerts(filterExpired bool) Option {
 	return func(cp *CertPool) {
 		cp.filterExpired = filterExpired
 	}
 }
 
+// Reasons for which a certificate is removed from the input of a CertPool.
+const (
+	FilterReasonDuplicate = "Duplicate"
+	FilterReasonExpired   = "Expired"
+	FilterReasonNotCA     = "NotCA"
+)
+
+// FilteredCertificate describes a certificate which was removed from the input
+// of a CertPool.
+type FilteredCertificate struct {
+	Subject     string
+	Fingerprint string
+	Reason      string
+}
+
+// WithRejectedExpiredCerts makes AddCertsFromPEM return an
+// ExpiredCertificateError if the input contains an expired certificate.
+func WithRejectedExpiredCerts(rejectExpired bool) Option {
+	return func(cp *CertPool) {
+		cp.rejectExpired = rejectExpired
+	}
+}
+
+// ExpiredCertificateError is returned when an expired certificate is found in
+// a CertPool which rejects them.
+type ExpiredCertificateError struct {
+	Subject  string
+	NotAfter time.Time
+}
+
+func (e ExpiredCertificateError) Error() string {
+	return fmt.Sprintf("certificate %q expired at %s", e.Subject, e.NotAfter.UTC().Format(time.RFC3339))
+}
+
+// WithRequiredCABasicConstraints filters out certificates which aren't CA
+// certificates, i.e. which don't have a basic constraints extension with CA set
+// to true.
+func WithRequiredCABasicConstraints(requireCA bool) Option {
+	return func(cp *CertPool) {
+		cp.requireCA = requireCA
+	}
+}
+
+// WithSkippedInvalidBlocks skips malformed PEM blocks in the input, such as
+// blocks which aren't certificates or certificates which can't be parsed,
+// rather than making AddCertsFromPEM return an error. The skipped blocks are
+// listed by Invalid.
+func WithSkippedInvalidBlocks(skipInvalid bool) Option {
+	return func(cp *CertPool) {
+		cp.skipInvalid = skipInvalid
+	}
+}
+
+// InvalidBlock describes a malformed PEM block which was skipped in the input
+// of a CertPool.
+type InvalidBlock struct {
+	// Line is the line of the input on which the block begins.
+	Line int
+
+	// Type is the type of the PEM block.
+	Type string
+
+	// Subject is the subject of the certificate in the block, if it could be
+	// parsed.
+	Subject string
+
+	// Reason describes why the block is malformed.
+	Reason string
+}
+
+func (b InvalidBlock) String() string {
+	if b.Subject != "" {
+		return fmt.Sprintf("line %d (%q): %s", b.Line, b.Subject, b.Reason)
+	}
+	return fmt.Sprintf("line %d: %s", b.Line, b.Reason)
+}
+
+// Deduplication is the way in which a CertPool detects duplicate certificates.
+type Deduplication int
+
+const (
+	// DeduplicateFingerprint removes certificates which are byte-identical
+	// to one already in the pool.
+	DeduplicateFingerprint Deduplication = iota
+
+	// DeduplicateSPKI removes certificates with the same public key as one
+	// already in the pool, such as cross-signed variants of a CA.
+	DeduplicateSPKI
+
+	// DeduplicateSubjectSerial removes certificates with the same subject and
+	// serial number as one already in the pool.
+	DeduplicateSubjectSerial
+
+	// DeduplicateNone keeps every certificate, including byte-identical
+	// copies.
+	DeduplicateNone
+)
+
+// WithDeduplication sets the way in which duplicate certificates are detected.
+// The first of a set of duplicates to be added is kept. Defaults to
+// DeduplicateFingerprint.
+func WithDeduplication(deduplication Deduplication) Option {
+	return func(cp *CertPool) {
+		cp.deduplication = deduplication
+	}
+}
+
+// WithTrailingNewline ends the PEM bundle of the CertPool with a newline,
+// for consumers which concatenate bundles or expect POSIX text files. By
+// default, the PEM bundle has no trailing newline.
+func WithTrailingNewline(trailingNewline bool) Option {
+	return func(cp *CertPool) {
+		cp.trailingNewline = trailingNewline
+	}
+}
+
+// WithLogger sets the logger which the CertPool logs skipped certificates to.
 func WithLogger(logger logr.Logger) Option {
 	return func(cp *CertPool) {
 		cp.logger = logger
@@ -54,11 +198,14 @@ func WithLogger(logger logr.Logger) Option {
 }
 
 // NewCertPool returns a new, empty CertPool.
-// It will deduplicate certificates based on their SHA256 hash.
-// Optionally, it can filter out expired certificates.
+// By default, it will deduplicate certificates based on their SHA256 hash.
+// Optionally, it can filter out expired certificates and certificates which
+// aren't CA certificates.
 func NewCertPool(options ...Option) *CertPool {
 	certPool := &CertPool{
 		certificates: make(map[[32]byte]*x509.Certificate),
+		copies:       make(map[[32]byte]int),
+		keys:         make(map[string]struct{}),
 
 		logger: logr.Discard(),
 	}
@@ -96,6 +243,11 @@ func (cp *CertPool) AddCertsFromPEM(pemData []byte) error {
 		return fmt.Errorf("certificate data can't be nil")
 	}
 
+	// Normalizing preserves the number of lines, so lines of the normalized
+	// data are lines of the input.
+	normalized := NormalizePEM(pemData)
+	pemData = normalized
+
 	ok := false
 	for {
 		var block *pem.Block
@@ -106,11 +258,19 @@ func (cp *CertPool) AddCertsFromPEM(pemData []byte) error {
 		}
 
 		if block.Type != "CERTIFICATE" {
+			if cp.skip(normalized, pemData, block, fmt.Sprintf("PEM block of type %q is not a certificate", block.Type)) {
+				continue
+			}
+
 			// only certificates are allowed in a bundle
 			return fmt.Errorf("invalid PEM block in bundle: only CERTIFICATE blocks are permitted but found '%s'", block.Type)
 		}
 
 		if len(block.Headers) != 0 {
+			if cp.skip(normalized, pemData, block, "PEM block has headers") {
+				continue
+			}
+
 			return fmt.Errorf("invalid PEM block in bundle; blocks are not permitted to have PEM headers")
 		}
 
@@ -123,6 +283,10 @@ func (cp *CertPool) AddCertsFromPEM(pemData []byte) error {
 				continue
 			}
 
+			if cp.skip(normalized, pemData, block, fmt.Sprintf("invalid certificate: %s", err)) {
+				continue
+			}
+
 			// the presence of an invalid cert (including things which aren't certs)
 			// should cause the bundle to be rejected
 			return fmt.Errorf("invalid PEM block in bundle; invalid PEM certificate: %w", err)
@@ -132,14 +296,31 @@ func (cp *CertPool) AddCertsFromPEM(pemData []byte) error {
 			return fmt.Errorf("failed appending a certificate: certificate is nil")
 		}
 
-		if cp.filterExpired && time.Now().After(certificate.NotAfter) {
+		if time.Now().After(certificate.NotAfter) {
+			if cp.rejectExpired {
+				return ExpiredCertificateError{Subject: certificate.Subject.String(), NotAfter: certificate.NotAfter}
+			}
+			if cp.filterExpired {
+				cp.filter(certificate, FilterReasonExpired)
+				continue
+			}
+		}
+
+		if cp.requireCA && (!certificate.BasicConstraintsValid || !certificate.IsCA) {
+			cp.logger.Info("skipping a certificate in PEM bundle which is not a CA certificate", "subject", certificate.Subject.String())
+			cp.filter(certificate, FilterReasonNotCA)
 			continue
 		}
 
 		ok = true // at least one non-expired certificate was found in the input
 
-		hash := sha256.Sum256(certificate.Raw)
-		cp.certificates[hash] = certificate
+		if !cp.add(certificate) {
+			cp.filter(certificate, FilterReasonDuplicate)
+		}
+	}
+
+	if !ok && cp.requireCA {
+		return fmt.Errorf("no non-expired CA certificates found in input bundle")
 	}
 
 	if !ok {
@@ -149,9 +330,92 @@ func (cp *CertPool) AddCertsFromPEM(pemData []byte) error {
 	return nil
 }
 
+// add adds the certificate to the pool, unless it's a duplicate of one
+// already in the pool. Returns false if it's a duplicate.
+func (cp *CertPool) add(certificate *x509.Certificate) bool {
+	hash := sha256.Sum256(certificate.Raw)
+
+	var key string
+	switch cp.deduplication {
+	case DeduplicateNone:
+		cp.certificates[hash] = certificate
+		cp.copies[hash]++
+		return true
+	case DeduplicateSPKI:
+		key = string(certificate.RawSubjectPublicKeyInfo)
+	case DeduplicateSubjectSerial:
+		key = string(certificate.RawSubject) + "/" + certificate.SerialNumber.String()
+	}
+
+	if _, exists := cp.certificates[hash]; exists {
+		return false
+	}
+	if key != "" {
+		if _, exists := cp.keys[key]; exists {
+			return false
+		}
+		cp.keys[key] = struct{}{}
+	}
+
+	cp.certificates[hash] = certificate
+	cp.copies[hash] = 1
+	return true
+}
+
+func (cp *CertPool) filter(certificate *x509.Certificate, reason string) {
+	hash := sha256.Sum256(certificate.Raw)
+	cp.filtered = append(cp.filtered, FilteredCertificate{
+		Subject:     certificate.Subject.String(),
+		Fingerprint: hex.EncodeToString(hash[:]),
+		Reason:      reason,
+	})
+}
+
+// skip records the block as skipped and returns true if the pool skips
+// malformed blocks. The block is the last decoded from data, leaving rest.
+func (cp *CertPool) skip(data, rest []byte, block *pem.Block, reason string) bool {
+	if !cp.skipInvalid {
+		return false
+	}
+
+	// The block begins with the last BEGIN line before the rest of the data,
+	// as its headers and base64 data can't hold one.
+	begin := bytes.LastIndex(data[:len(data)-len(rest)], pemBeginPrefix)
+	invalid := InvalidBlock{
+		Line:   1 + bytes.Count(data[:max(begin, 0)], []byte("\n")),
+		Type:   block.Type,
+		Reason: reason,
+	}
+	if block.Type == "CERTIFICATE" {
+		if certificate, err := x509.ParseCertificate(block.Bytes); err == nil {
+			invalid.Subject = certificate.Subject.String()
+		}
+	}
+
+	cp.logger.Info("skipping a malformed PEM block in PEM bundle", "line", invalid.Line, "reason", reason)
+	cp.invalid = append(cp.invalid, invalid)
+	return true
+}
+
+// Invalid returns the malformed PEM blocks which were skipped in the input of
+// the pool.
+func (cp *CertPool) Invalid() []InvalidBlock {
+	return cp.invalid
+}
+
+// Filtered returns the certificates which were removed from the input of the
+// pool, as duplicates or by its filters.
+func (cp *CertPool) Filtered() []FilteredCertificate {
+	return cp.filtered
+}
+
 // Get certificates quantity in the certificates pool
 func (cp *CertPool) Size() int {
-	return len(cp.certificates)
+	size := 0
+	for _, copies := range cp.copies {
+		size += copies
+	}
+	return size
 }
 
 func (certPool *CertPool) PEM() string {
@@ -159,15 +423,55 @@ func (certPool *CertPool) PEM() string {
 		return ""
 	}
 
-	buffer := bytes.Buffer{}
+	certificates := certPool.Certificates()
 
-	for _, cert := range certPool.Certificates() {
-		if err := pem.Encode(&buffer, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
-			return ""
+	// The bundle is encoded straight into the string it's returned as, sized
+	// up front, so that a large bundle isn't copied as it grows or once done.
+	size := 0
+	for _, cert := range certificates {
+		size += pemEncodedLen(len(cert.Raw))
+	}
+
+	var (
+		builder strings.Builder
+		encoded []byte
+	)
+	builder.Grow(size)
+	for _, cert := range certificates {
+		// This is what pem.Encode writes, without its allocations for every
+		// block.
+		encoded = base64.StdEncoding.AppendEncode(encoded[:0], cert.Raw)
+
+		builder.WriteString(pemCertificateHeader)
+		for start := 0; start < len(encoded); start += pemLineLength {
+			builder.Write(encoded[start:min(start+pemLineLength, len(encoded))])
+			builder.WriteByte('\n')
 		}
+		builder.WriteString(pemCertificateFooter)
 	}
 
-	return string(bytes.TrimSpace(buffer.Bytes()))
+	// Every encoded block ends with a newline.
+	pemData := builder.String()
+	if !certPool.trailingNewline {
+		pemData = pemData[:len(pemData)-1]
+	}
+	return pemData
+}
+
+const (
+	pemCertificateHeader = "-----BEGIN CERTIFICATE-----\n"
+	pemCertificateFooter = "-----END CERTIFICATE-----\n"
+
+	// pemLineLength is the length at which PEM encoders wrap base64 data.
+	pemLineLength = 64
+)
+
+// pemEncodedLen returns the length of a CERTIFICATE PEM block holding DER
+// data of the given length.
+func pemEncodedLen(derLen int) int {
+	encodedLen := base64.StdEncoding.EncodedLen(derLen)
+	lines := (encodedLen + pemLineLength - 1) / pemLineLength
+	return len(pemCertificateHeader) + encodedLen + lines + len(pemCertificateFooter)
 }
 
 func (certPool *CertPool) PEMSplit() []string {
@@ -175,7 +479,7 @@ func (certPool *CertPool) PEMSplit() []string {
 		return nil
 	}
 
-	pems := make([]string, 0, len(certPool.certificates))
+	pems := make([]string, 0, certPool.Size())
 	for _, cert := range certPool.Certificates() {
 		pems = append(pems, string(bytes.TrimSpace(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))))
 	}
@@ -185,6 +489,67 @@ func (certPool *CertPool) PEMSplit() []string {
 
 // Get the list of all x509 Certificates in the certificates pool
 func (certPool *CertPool) Certificates() []*x509.Certificate {
+	hashes := certPool.sortedHashes()
+
+	orderedCertificates := make([]*x509.Certificate, 0, len(hashes))
+	for _, hash := range hashes {
+		for range certPool.copies[hash] {
+			orderedCertificates = append(orderedCertificates, certPool.certificates[hash])
+		}
+	}
+
+	return orderedCertificates
+}
+
+// CertificateInfo is a certificate in a CertPool along with its metadata.
+type CertificateInfo struct {
+	// Certificate is the parsed certificate.
+	Certificate *x509.Certificate
+
+	// Subject is the subject of the certificate.
+	Subject string
+
+	// NotAfter is the time after which the certificate expires.
+	NotAfter time.Time
+
+	// Fingerprint is the hex-encoded SHA-256 fingerprint of the certificate.
+	Fingerprint string
+
+	// IsCA is true if the certificate is a CA certificate with valid basic
+	// constraints.
+	IsCA bool
+}
+
+// All returns an iterator over the certificates in the pool with their
+// metadata, in the same order as Certificates. The certificates were parsed
+// when added to the pool, so iterating doesn't parse them again.
+func (certPool *CertPool) All() iter.Seq[CertificateInfo] {
+	return func(yield func(CertificateInfo) bool) {
+		if certPool == nil {
+			return
+		}
+
+		for _, hash := range certPool.sortedHashes() {
+			cert := certPool.certificates[hash]
+			info := CertificateInfo{
+				Certificate: cert,
+				Subject:     cert.Subject.String(),
+				NotAfter:    cert.NotAfter,
+				Fingerprint: hex.EncodeToString(hash[:]),
+				IsCA:        cert.BasicConstraintsValid && cert.IsCA,
+			}
+			for range certPool.copies[hash] {
+				if !yield(info) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// sortedHashes returns the hashes of the certificates in the pool in a stable
+// order.
+func (certPool *CertPool) sortedHashes() [][32]byte {
 	hashes := make([][32]byte, 0, len(certPool.certificates))
 	for hash := range certPool.certificates {
 		hashes = append(hashes, hash)
@@ -194,10 +559,5 @@ func (certPool *CertPool) Certificates() []*x509.Certificate {
 		return bytes.Compare(i[:], j[:])
 	})
 
-	orderedCertificates := make([]*x509.Certificate, 0, len(certPool.certificates))
-	for _, hash := range hashes {
-		orderedCertificates = append(orderedCertificates, certPool.certificates[hash])
-	}
-
-	return orderedCertificates
+	return hashes
 }
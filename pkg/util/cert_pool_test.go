@@ -17,7 +17,19 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -98,3 +110,222 @@ func TestAppendCertFromPEM(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendCertFromPEMRequireCA(t *testing.T) {
+	certPool := NewCertPool(WithRequiredCABasicConstraints(true))
+
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestLeafCertificate))))
+	require.Equal(t, dummy.TestCertificate1, certPool.PEM())
+
+	err := NewCertPool(WithRequiredCABasicConstraints(true)).AddCertsFromPEM([]byte(dummy.TestLeafCertificate))
+	require.EqualError(t, err, "no non-expired CA certificates found in input bundle")
+}
+
+func TestAppendCertFromPEMRejectExpired(t *testing.T) {
+	certPool := NewCertPool(WithRejectedExpiredCerts(true))
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.TestCertificate1)))
+
+	err := NewCertPool(WithRejectedExpiredCerts(true)).AddCertsFromPEM([]byte(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestExpiredCertificate)))
+	require.ErrorAs(t, err, &ExpiredCertificateError{})
+}
+
+func TestAppendCertFromPEMSkipInvalid(t *testing.T) {
+	withHeaders := strings.Replace(dummy.TestCertificate2, "-----BEGIN CERTIFICATE-----\n", "-----BEGIN CERTIFICATE-----\nProc-Type: 4,ENCRYPTED\n\n", 1)
+	input := dummy.JoinCerts(
+		"-----BEGIN X509 CRL-----\nAAAA\n-----END X509 CRL-----",
+		"-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----",
+		withHeaders,
+		dummy.TestCertificate1,
+	)
+
+	err := NewCertPool().AddCertsFromPEM([]byte(input))
+	require.EqualError(t, err, "invalid PEM block in bundle: only CERTIFICATE blocks are permitted but found 'X509 CRL'")
+
+	certPool := NewCertPool(WithSkippedInvalidBlocks(true))
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(input)))
+	require.Equal(t, dummy.TestCertificate1, certPool.PEM())
+
+	invalid := certPool.Invalid()
+	require.Len(t, invalid, 3)
+	require.Equal(t, InvalidBlock{Line: 1, Type: "X509 CRL", Reason: `PEM block of type "X509 CRL" is not a certificate`}, invalid[0])
+	require.Equal(t, 4, invalid[1].Line)
+	require.Contains(t, invalid[1].String(), "line 4: invalid certificate: ")
+	require.Equal(t, `line 7 ("CN=cmct-test-root,O=cert-manager"): PEM block has headers`, invalid[2].String())
+
+	// Inputs holding no valid certificate still fail.
+	err = NewCertPool(WithSkippedInvalidBlocks(true)).AddCertsFromPEM([]byte("-----BEGIN X509 CRL-----\nAAAA\n-----END X509 CRL-----"))
+	require.EqualError(t, err, "no non-expired certificates found in input bundle")
+}
+
+func TestCertPoolFiltered(t *testing.T) {
+	certPool := NewCertPool(WithFilteredExpiredCerts(true))
+
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestExpiredCertificate))))
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.TestCertificate1)))
+
+	filtered := certPool.Filtered()
+	require.Len(t, filtered, 2)
+	require.Equal(t, FilterReasonExpired, filtered[0].Reason)
+	require.Equal(t, FilterReasonDuplicate, filtered[1].Reason)
+	require.Len(t, filtered[1].Fingerprint, 64)
+}
+
+func TestCertPoolDeduplication(t *testing.T) {
+	newCertificate := func(serial int64, key, parentKey *ecdsa.PrivateKey) string {
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: "root"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), parentKey)
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	key, otherKey := mustGenerateKey(t), mustGenerateKey(t)
+	root := newCertificate(1, key, key)
+	crossSigned := newCertificate(2, key, otherKey)
+	reissued := newCertificate(1, otherKey, otherKey)
+	input := dummy.JoinCerts(root, crossSigned, reissued, root)
+
+	tests := map[string]struct {
+		deduplication Deduplication
+		expSize       int
+	}{
+		"by fingerprint, byte-identical certificates are removed":  {deduplication: DeduplicateFingerprint, expSize: 3},
+		"by SPKI, cross-signed variants are removed":               {deduplication: DeduplicateSPKI, expSize: 2},
+		"by subject and serial, reissued certificates are removed": {deduplication: DeduplicateSubjectSerial, expSize: 2},
+		"without deduplication, every certificate is kept":         {deduplication: DeduplicateNone, expSize: 4},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certPool := NewCertPool(WithDeduplication(test.deduplication))
+			require.NoError(t, certPool.AddCertsFromPEM([]byte(input)))
+
+			require.Equal(t, test.expSize, certPool.Size())
+			require.Len(t, certPool.Certificates(), test.expSize)
+			require.Len(t, certPool.PEMSplit(), test.expSize)
+			require.Len(t, certPool.Filtered(), 4-test.expSize)
+		})
+	}
+}
+
+func mustGenerateKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func TestCertPoolAll(t *testing.T) {
+	certPool := NewCertPool()
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+
+	certificates := certPool.Certificates()
+	var infos []CertificateInfo
+	for info := range certPool.All() {
+		infos = append(infos, info)
+	}
+	require.Len(t, infos, len(certificates))
+
+	for i, info := range infos {
+		hash := sha256.Sum256(certificates[i].Raw)
+		require.Same(t, certificates[i], info.Certificate)
+		require.Equal(t, certificates[i].Subject.String(), info.Subject)
+		require.Equal(t, certificates[i].NotAfter, info.NotAfter)
+		require.Equal(t, hex.EncodeToString(hash[:]), info.Fingerprint)
+		require.True(t, info.IsCA)
+	}
+
+	// Iteration stops when the loop breaks.
+	count := 0
+	for range certPool.All() {
+		count++
+		break
+	}
+	require.Equal(t, 1, count)
+}
+
+func TestCertPoolTrailingNewline(t *testing.T) {
+	certPool := NewCertPool()
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+
+	withNewline := NewCertPool(WithTrailingNewline(true))
+	require.NoError(t, withNewline.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+
+	require.Equal(t, certPool.PEM()+"\n", withNewline.PEM())
+	require.Empty(t, NewCertPool(WithTrailingNewline(true)).PEM())
+}
+
+func TestCertPoolPEMEncoding(t *testing.T) {
+	certPool := NewCertPool()
+	require.NoError(t, certPool.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+
+	var expected []byte
+	for _, cert := range certPool.Certificates() {
+		expected = append(expected, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	require.Equal(t, string(bytes.TrimSpace(expected)), certPool.PEM())
+
+	for _, derLen := range []int{1, 47, 48, 49, 96, 1000} {
+		encoded := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: make([]byte, derLen)})
+		require.Len(t, encoded, pemEncodedLen(derLen), "DER length %d", derLen)
+	}
+}
+
+// BenchmarkCertPoolPEM measures encoding a large pool, which is done for
+// every reconcile of a Bundle.
+func BenchmarkCertPoolPEM(b *testing.B) {
+	certPool := NewCertPool(WithDeduplication(DeduplicateNone))
+	for range 1000 {
+		require.NoError(b, certPool.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		_ = certPool.PEM()
+	}
+}
+
+// BenchmarkCertPoolAll measures reading certificate metadata from a CertPool,
+// which reuses the certificates parsed when they were added.
+func BenchmarkCertPoolAll(b *testing.B) {
+	certPool := NewCertPool()
+	require.NoError(b, certPool.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+
+	b.ResetTimer()
+	for range b.N {
+		for info := range certPool.All() {
+			_ = info.Fingerprint
+		}
+	}
+}
+
+// BenchmarkCertPoolReparse measures reading the same certificate metadata by
+// parsing the PEM bundle again, for comparison with BenchmarkCertPoolAll.
+func BenchmarkCertPoolReparse(b *testing.B) {
+	certPool := NewCertPool()
+	require.NoError(b, certPool.AddCertsFromPEM([]byte(dummy.DefaultJoinedCerts())))
+	bundlePEM := []byte(certPool.PEM())
+
+	b.ResetTimer()
+	for range b.N {
+		for rest := bundlePEM; ; {
+			var block *pem.Block
+			if block, rest = pem.Decode(rest); block == nil {
+				break
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				b.Fatal(err)
+			}
+			hash := sha256.Sum256(cert.Raw)
+			_ = hex.EncodeToString(hash[:])
+		}
+	}
+}
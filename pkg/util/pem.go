@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+)
+
+// utf8BOM is the byte order mark which some editors on Windows write at the
+// start of UTF-8 files.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// NormalizePEM returns the PEM data with a leading byte order mark removed,
+// CRLF and CR line endings replaced by LF, and the leading and trailing spaces
+// and tabs of each line removed.
+//
+// PEM data copied between systems or pasted into YAML often picks up such
+// differences, which encoding/pem doesn't tolerate everywhere: an indented
+// END line, for example, hides the certificate it ends. Normalized, equivalent
+// inputs parse to the same certificates.
+func NormalizePEM(pemData []byte) []byte {
+	pemData = bytes.TrimPrefix(pemData, utf8BOM)
+
+	// Bundles can be several megabytes, so they're normalized in a single
+	// pass into a single buffer rather than split into lines and joined.
+	normalized := make([]byte, 0, len(pemData))
+	for {
+		end := bytes.IndexAny(pemData, "\r\n")
+		if end < 0 {
+			return append(normalized, bytes.Trim(pemData, " \t")...)
+		}
+
+		normalized = append(normalized, bytes.Trim(pemData[:end], " \t")...)
+		normalized = append(normalized, '\n')
+
+		if pemData[end] == '\r' && end+1 < len(pemData) && pemData[end+1] == '\n' {
+			end++
+		}
+		pemData = pemData[end+1:]
+	}
+}
+
+var (
+	pemBeginPrefix = []byte("-----BEGIN ")
+	pemDashes      = []byte("-----")
+)
+
+// PrivateKeyPEMType returns the type of the first PEM block in the data which
+// holds a private key, such as "RSA PRIVATE KEY" or "ENCRYPTED PRIVATE KEY",
+// and true if there is one. Blocks are found by their BEGIN line alone, so
+// that keys in malformed blocks, which encoding/pem skips, are found too.
+func PrivateKeyPEMType(pemData []byte) (string, bool) {
+	for {
+		begin := bytes.Index(pemData, pemBeginPrefix)
+		if begin < 0 {
+			return "", false
+		}
+		pemData = pemData[begin+len(pemBeginPrefix):]
+
+		end := bytes.Index(pemData, pemDashes)
+		if end < 0 {
+			return "", false
+		}
+		blockType := pemData[:end]
+		if bytes.ContainsAny(blockType, "\r\n") {
+			continue
+		}
+		if bytes.Contains(blockType, []byte("PRIVATE KEY")) {
+			return string(blockType), true
+		}
+	}
+}
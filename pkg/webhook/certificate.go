@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+
+	"github.com/cert-manager/trust-manager/pkg/metrics"
+)
+
+// CertificateWatcher watches the serving certificate of the webhook. It's run
+// by the Manager on every replica, as each serves the webhook.
+type CertificateWatcher struct {
+	*certwatcher.CertWatcher
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (*CertificateWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// NewCertificateWatcher returns a watcher of the serving certificate and key
+// of the webhook, named 'tls.crt' and 'tls.key' in certDir. The files are
+// watched for changes, and re-read every interval in case a change is missed,
+// so that a rotated certificate is served without restarting trust-manager.
+// The watcher must be added to the Manager to be started, and its
+// GetCertificate set on the TLS config of the webhook server.
+func NewCertificateWatcher(log logr.Logger, certDir string, interval time.Duration) (*CertificateWatcher, error) {
+	watcher, err := certwatcher.New(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook certificate: %w", err)
+	}
+	if interval > 0 {
+		watcher = watcher.WithWatchInterval(interval)
+	}
+
+	watcher.RegisterCallback(func(cert tls.Certificate) {
+		leaf, err := certificateLeaf(cert)
+		if err != nil {
+			log.Error(err, "failed to parse webhook certificate")
+			return
+		}
+
+		log.Info("loaded webhook certificate", "serial", leaf.SerialNumber.String(), "notAfter", leaf.NotAfter)
+		metrics.SetWebhookCertificateExpiry(leaf.NotAfter)
+	})
+
+	return &CertificateWatcher{CertWatcher: watcher}, nil
+}
+
+// Check implements healthz.Checker, and fails unless the watcher holds a
+// certificate which is currently valid, as the API server can't call the
+// webhook otherwise.
+func (w *CertificateWatcher) Check(*http.Request) error {
+	return checkCertificateValid(w.GetCertificate, time.Now())
+}
+
+func checkCertificateValid(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), now time.Time) error {
+	cert, err := getCertificate(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook certificate: %w", err)
+	}
+	if cert == nil {
+		return errors.New("no webhook certificate has been loaded")
+	}
+
+	leaf, err := certificateLeaf(*cert)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook certificate: %w", err)
+	}
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return fmt.Errorf("webhook certificate is only valid from %s to %s", leaf.NotBefore, leaf.NotAfter)
+	}
+
+	return nil
+}
+
+// certificateLeaf returns the parsed leaf of the certificate chain.
+func certificateLeaf(cert tls.Certificate) (*x509.Certificate, error) {
+	if cert.Leaf != nil {
+		return cert.Leaf, nil
+	}
+	if len(cert.Certificate) == 0 {
+		return nil, errors.New("certificate chain is empty")
+	}
+	return x509.ParseCertificate(cert.Certificate[0])
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewCertificateWatcher(t *testing.T) {
+	certDir := t.TempDir()
+
+	_, err := NewCertificateWatcher(logr.Discard(), certDir, 0)
+	assert.ErrorContains(t, err, "failed to load webhook certificate")
+
+	writeServingCertificate(t, certDir, 1)
+	watcher, err := NewCertificateWatcher(logr.Discard(), certDir, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, watcher.NeedLeaderElection())
+
+	cert, err := watcher.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := certificateLeaf(*cert)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), leaf.SerialNumber.Int64())
+
+	// A rotated certificate is served once it's read.
+	writeServingCertificate(t, certDir, 2)
+	require.NoError(t, watcher.ReadCertificate())
+
+	cert, err = watcher.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err = certificateLeaf(*cert)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), leaf.SerialNumber.Int64())
+}
+
+func Test_CertificateWatcher_Check(t *testing.T) {
+	certDir := t.TempDir()
+	writeServingCertificate(t, certDir, 1)
+	watcher, err := NewCertificateWatcher(logr.Discard(), certDir, 0)
+	require.NoError(t, err)
+
+	assert.NoError(t, watcher.Check(nil))
+	assert.ErrorContains(t, checkCertificateValid(watcher.GetCertificate, time.Now().Add(2*time.Hour)), "webhook certificate is only valid")
+	assert.ErrorContains(t, checkCertificateValid(watcher.GetCertificate, time.Now().Add(-2*time.Hour)), "webhook certificate is only valid")
+}
+
+func writeServingCertificate(t *testing.T, certDir string, serial int64) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "trust-manager.cert-manager.svc"},
+		DNSNames:     []string{"trust-manager.cert-manager.svc"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "tls.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "tls.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
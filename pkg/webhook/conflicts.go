@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+// writtenKey is a key of a target resource written by a Bundle.
+type writtenKey struct {
+	kind string
+	name string
+	key  string
+}
+
+// writtenKeys returns the keys of the target resources written by the Bundle,
+// along with the path of the field each is defined by.
+func writtenKeys(bundle *trustapi.Bundle) map[writtenKey]*field.Path {
+	keys := map[writtenKey]*field.Path{}
+
+	add := func(kind, name string, formats *trustapi.AdditionalFormats, formatsPath *field.Path) {
+		if formats == nil {
+			return
+		}
+		if formats.JKS != nil {
+			keys[writtenKey{kind, name, formats.JKS.Key}] = formatsPath.Child("jks", "key")
+		}
+		if formats.PKCS12 != nil {
+			keys[writtenKey{kind, name, formats.PKCS12.Key}] = formatsPath.Child("pkcs12", "key")
+		}
+		if formats.SPIFFE != nil {
+			keys[writtenKey{kind, name, formats.SPIFFE.Key}] = formatsPath.Child("spiffe", "key")
+		}
+		if formats.Certdata != nil {
+			keys[writtenKey{kind, name, formats.Certdata.Key}] = formatsPath.Child("certdata", "key")
+		}
+	}
+
+	visit := func(bundleTarget trustapi.BundleTarget, path *field.Path) {
+		formatsName := bundle.Name
+		if bundleTarget.AdditionalFormatsTarget != nil {
+			formatsName = bundleTarget.AdditionalFormatsTarget.Name
+		}
+
+		if configMap := bundleTarget.ConfigMap; configMap != nil {
+			keys[writtenKey{"ConfigMap", bundle.Name, configMap.Key}] = path.Child("configMap", "key")
+
+			formatsPath := path.Child("additionalFormats")
+			if configMap.AdditionalFormats != nil {
+				formatsPath = path.Child("configMap", "additionalFormats")
+			}
+			add("ConfigMap", formatsName, bundleTarget.ConfigMapFormats(), formatsPath)
+		}
+
+		if secret := bundleTarget.Secret; secret != nil {
+			keys[writtenKey{"Secret", bundle.Name, secret.Key}] = path.Child("secret", "key")
+
+			formatsPath := path.Child("additionalFormats")
+			if secret.AdditionalFormats != nil {
+				formatsPath = path.Child("secret", "additionalFormats")
+			}
+			add("Secret", formatsName, bundleTarget.SecretFormats(), formatsPath)
+		}
+	}
+
+	path := field.NewPath("spec")
+	visit(bundle.Spec.Target, path.Child("target"))
+	for i, bundleTarget := range bundle.Spec.Targets {
+		visit(bundleTarget, path.Child("targets").Index(i))
+	}
+
+	return keys
+}
+
+// validateTargetConflicts rejects a Bundle which writes a key to a target
+// resource another Bundle already writes the same key to, as the Bundles
+// would otherwise keep overwriting each other's targets. Namespace selectors
+// are not considered, as the labels of Namespaces may change at any time.
+// Only keys the old version of the Bundle, if any, didn't write are checked,
+// so that existing conflicts don't block unrelated updates.
+func (v *validator) validateTargetConflicts(ctx context.Context, bundle, oldBundle *trustapi.Bundle) (field.ErrorList, error) {
+	if v.client == nil {
+		return nil, nil
+	}
+
+	keys := writtenKeys(bundle)
+	if oldBundle != nil {
+		for key := range writtenKeys(oldBundle) {
+			delete(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	var bundleList trustapi.BundleList
+	if err := v.client.List(ctx, &bundleList); err != nil {
+		return nil, fmt.Errorf("failed to list Bundles: %w", err)
+	}
+	slices.SortFunc(bundleList.Items, func(a, b trustapi.Bundle) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var el field.ErrorList
+	for _, other := range bundleList.Items {
+		if other.Name == bundle.Name {
+			continue
+		}
+
+		for key := range writtenKeys(&other) {
+			path, ok := keys[key]
+			if !ok {
+				continue
+			}
+			el = append(el, field.Invalid(path, key.key, fmt.Sprintf("key of target %s %q is already written by Bundle %q", key.kind, key.name, other.Name)))
+			delete(keys, key)
+		}
+	}
+
+	slices.SortFunc(el, func(a, b *field.Error) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+
+	return el, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+func Test_validateTargetConflicts(t *testing.T) {
+	configMapBundle := func(name, key string, formats *trustapi.AdditionalFormats, formatsTarget string) *trustapi.Bundle {
+		bundle := &trustapi.Bundle{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: trustapi.BundleSpec{
+				Sources: []trustapi.BundleSource{{InLine: ptr.To("foo")}},
+				Target: trustapi.BundleTarget{
+					ConfigMap:         &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: key}},
+					AdditionalFormats: formats,
+				},
+			},
+		}
+		if formatsTarget != "" {
+			bundle.Spec.Target.AdditionalFormatsTarget = &trustapi.AdditionalFormatsTarget{Name: formatsTarget}
+		}
+		return bundle
+	}
+	jks := &trustapi.AdditionalFormats{JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.jks"}}}
+
+	tests := map[string]struct {
+		existing  []client.Object
+		bundle    *trustapi.Bundle
+		oldBundle *trustapi.Bundle
+		expErr    *string
+	}{
+		"no other Bundles": {
+			bundle: configMapBundle("a", "trust.pem", jks, "formats"),
+		},
+		"other Bundles writing to other targets": {
+			existing: []client.Object{configMapBundle("b", "trust.pem", jks, "other-formats")},
+			bundle:   configMapBundle("a", "trust.pem", jks, "formats"),
+		},
+		"additional formats target shared with another Bundle": {
+			existing: []client.Object{configMapBundle("b", "trust.pem", jks, "formats")},
+			bundle:   configMapBundle("a", "trust.pem", jks, "formats"),
+			expErr:   ptr.To(`spec.target.additionalFormats.jks.key: Invalid value: "trust.jks": key of target ConfigMap "formats" is already written by Bundle "b"`),
+		},
+		"additional formats target with the name of another Bundle": {
+			existing: []client.Object{configMapBundle("b", "trust.jks", nil, "")},
+			bundle:   configMapBundle("a", "trust.pem", jks, "b"),
+			expErr:   ptr.To(`spec.target.additionalFormats.jks.key: Invalid value: "trust.jks": key of target ConfigMap "b" is already written by Bundle "b"`),
+		},
+		"additional formats target with the name of another Bundle using other keys": {
+			existing: []client.Object{configMapBundle("b", "trust.pem", nil, "")},
+			bundle:   configMapBundle("a", "trust.pem", jks, "b"),
+		},
+		"existing conflict which the update doesn't change": {
+			existing:  []client.Object{configMapBundle("b", "trust.pem", jks, "formats")},
+			bundle:    configMapBundle("a", "ca.pem", jks, "formats"),
+			oldBundle: configMapBundle("a", "trust.pem", jks, "formats"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, ctx := ktesting.NewTestContext(t)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(trustapi.GlobalScheme).
+				WithObjects(test.existing...).
+				Build()
+
+			v := &validator{client: fakeClient}
+			el, err := v.validateTargetConflicts(ctx, test.bundle, test.oldBundle)
+			assert.NoError(t, err)
+
+			if test.expErr == nil {
+				assert.Empty(t, el)
+			} else {
+				assert.EqualError(t, el.ToAggregate(), *test.expErr)
+			}
+		})
+	}
+}
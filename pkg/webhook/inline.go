@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/compat"
+	"github.com/cert-manager/trust-manager/pkg/util"
+)
+
+var (
+	pemBegin = []byte("-----BEGIN ")
+	pemEnd   = []byte("-----END ")
+)
+
+// validateInLinePEM parses the PEM data of an InLine source as the controller
+// would, and rejects data it would fail to sync: malformed PEM blocks, blocks
+// other than certificates, such as private keys, invalid certificates, and
+// data without any unexpired certificate. Errors give the line of the
+// offending block. Expired certificates alongside unexpired ones are only
+// warned about, as are malformed blocks and invalid certificates if the
+// Bundle skips them. Private keys are always rejected.
+// Data without any unexpired certificate is permitted if the Bundle keeps
+// expired certificates, and only warned about if unchanged is true: the
+// source was admitted before, and the Bundle mustn't be stuck once its last
+// certificate expires.
+func validateInLinePEM(inLine string, path *field.Path, now time.Time, filters *trustapi.BundleFilters, unchanged bool) (field.ErrorList, admission.Warnings) {
+	// Normalizing preserves the number of lines, so lines of the normalized
+	// data are lines of the source.
+	data := util.NormalizePEM([]byte(inLine))
+
+	var (
+		el       field.ErrorList
+		warnings admission.Warnings
+
+		certificates, expired int
+		offset                int
+	)
+
+	skipInvalid := filters != nil && filters.Invalid == trustapi.InvalidCertificatePolicySkip
+	// invalid rejects an invalid block, or only warns about it if the Bundle
+	// skips invalid blocks, as the controller drops it from the bundle.
+	invalid := func(line, detail string) {
+		if skipInvalid {
+			warnings = append(warnings, fmt.Sprintf("%s: %s: %s", path, line, detail))
+			return
+		}
+		el = append(el, field.Invalid(path, line, detail))
+	}
+	for {
+		begin := bytes.Index(data[offset:], pemBegin)
+		if begin < 0 {
+			break
+		}
+		begin += offset
+		line := fmt.Sprintf("line %d", 1+bytes.Count(data[:begin], []byte("\n")))
+
+		// The block ends with the first END line. encoding/pem skips a
+		// malformed block and decodes the next, so each block is decoded on
+		// its own to tell which one is malformed.
+		end := bytes.Index(data[begin:], pemEnd)
+		if end < 0 {
+			invalid(line, "PEM block has no END line")
+			break
+		}
+		end += begin
+		if lineEnd := bytes.IndexByte(data[end:], '\n'); lineEnd < 0 {
+			end = len(data)
+		} else {
+			end += lineEnd + 1
+		}
+		offset = end
+
+		block, _ := pem.Decode(data[begin:end])
+		if block == nil || bytes.Contains(data[begin+len(pemBegin):end], pemBegin) {
+			invalid(line, "malformed PEM block")
+			continue
+		}
+
+		if block.Type != "CERTIFICATE" {
+			detail := fmt.Sprintf("PEM block of type %q is not permitted; only CERTIFICATE blocks are", block.Type)
+			if strings.Contains(block.Type, "PRIVATE KEY") {
+				el = append(el, field.Invalid(path, line, detail+". This private key must be considered compromised"))
+				continue
+			}
+			invalid(line, detail)
+			continue
+		}
+
+		if len(block.Headers) != 0 {
+			invalid(line, "PEM block must not have headers")
+			continue
+		}
+
+		cert, err := compat.ParseCertificate(block.Bytes)
+		if err != nil {
+			if compat.IsSkipError(err) {
+				// The controller skips such certificates, as they're only
+				// unsupported by this version of Go.
+				continue
+			}
+			invalid(line, fmt.Sprintf("invalid certificate: %s", err))
+			continue
+		}
+
+		certificates++
+		if now.After(cert.NotAfter) {
+			expired++
+			warnings = append(warnings, fmt.Sprintf("%s: %s: certificate %q expired at %s", path, line, cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	if len(el) > 0 {
+		return el, nil
+	}
+
+	switch {
+	case certificates == 0:
+		return field.ErrorList{field.Invalid(path, field.OmitValueType{}, "must contain at least one PEM-encoded certificate")}, nil
+	case certificates > expired, filters != nil && filters.Expired == trustapi.ExpiredCertificatePolicyKeep:
+		return nil, warnings
+	case unchanged:
+		return nil, append(warnings, fmt.Sprintf("%s: all certificates are expired", path))
+	default:
+		return field.ErrorList{field.Invalid(path, field.OmitValueType{}, "all certificates are expired")}, nil
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+func Test_validateInLinePEM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	privateKey := strings.TrimSpace(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+
+	certLines := strings.Count(dummy.TestCertificate1, "\n") + 1
+	path := field.NewPath("spec", "sources", "[0]", "inLine")
+
+	tests := map[string]struct {
+		inLine    string
+		filters   *trustapi.BundleFilters
+		unchanged bool
+
+		expErrs     field.ErrorList
+		expWarnings admission.Warnings
+	}{
+		"valid certificates": {
+			inLine: dummy.JoinCerts(dummy.TestCertificate1, dummy.TestCertificate2),
+		},
+		"certificates with CRLF line endings, indentation and comments": {
+			inLine: "# root CA\r\n  " + strings.ReplaceAll(dummy.TestCertificate1, "\n", "\r\n  ") + "\r\n",
+		},
+		"no certificates": {
+			inLine:  "test",
+			expErrs: field.ErrorList{field.Invalid(path, field.OmitValueType{}, "must contain at least one PEM-encoded certificate")},
+		},
+		"a private key": {
+			inLine: dummy.TestCertificate1 + "\n" + privateKey,
+			expErrs: field.ErrorList{field.Invalid(path, "line "+strconv.Itoa(certLines+1),
+				`PEM block of type "EC PRIVATE KEY" is not permitted; only CERTIFICATE blocks are. This private key must be considered compromised`)},
+		},
+		"a block without an END line": {
+			inLine:  dummy.TestCertificate1 + "\n-----BEGIN CERTIFICATE-----\nMIIB\n",
+			expErrs: field.ErrorList{field.Invalid(path, "line "+strconv.Itoa(certLines+1), "PEM block has no END line")},
+		},
+		"a block without an END line followed by a valid block": {
+			inLine:  "-----BEGIN CERTIFICATE-----\nMIIB\n" + dummy.TestCertificate1,
+			expErrs: field.ErrorList{field.Invalid(path, "line 1", "malformed PEM block")},
+		},
+		"a block with invalid base64": {
+			inLine:  dummy.TestCertificate1 + "\n-----BEGIN CERTIFICATE-----\n!!!!\n-----END CERTIFICATE-----",
+			expErrs: field.ErrorList{field.Invalid(path, "line "+strconv.Itoa(certLines+1), "malformed PEM block")},
+		},
+		"a block with invalid base64, skipped": {
+			inLine:      dummy.TestCertificate1 + "\n-----BEGIN CERTIFICATE-----\n!!!!\n-----END CERTIFICATE-----",
+			filters:     &trustapi.BundleFilters{Invalid: trustapi.InvalidCertificatePolicySkip},
+			expWarnings: admission.Warnings{`spec.sources.[0].inLine: line ` + strconv.Itoa(certLines+1) + `: malformed PEM block`},
+		},
+		"a private key, skipping invalid blocks": {
+			inLine:  dummy.TestCertificate1 + "\n" + privateKey,
+			filters: &trustapi.BundleFilters{Invalid: trustapi.InvalidCertificatePolicySkip},
+			expErrs: field.ErrorList{field.Invalid(path, "line "+strconv.Itoa(certLines+1),
+				`PEM block of type "EC PRIVATE KEY" is not permitted; only CERTIFICATE blocks are. This private key must be considered compromised`)},
+		},
+		"a block with headers": {
+			inLine:  "-----BEGIN CERTIFICATE-----\nProc-Type: 4,ENCRYPTED\n\n" + strings.Join(strings.Split(dummy.TestCertificate1, "\n")[1:], "\n"),
+			expErrs: field.ErrorList{field.Invalid(path, "line 1", "PEM block must not have headers")},
+		},
+		"only expired certificates": {
+			inLine:  dummy.TestExpiredCertificate,
+			expErrs: field.ErrorList{field.Invalid(path, field.OmitValueType{}, "all certificates are expired")},
+		},
+		"only expired certificates, unchanged": {
+			inLine:    dummy.TestExpiredCertificate,
+			unchanged: true,
+			expWarnings: admission.Warnings{
+				`spec.sources.[0].inLine: line 1: certificate "O=Internet Widgits Pty Ltd,ST=Some-State,C=AU" expired at 2024-01-12T11:09:24Z`,
+				`spec.sources.[0].inLine: all certificates are expired`,
+			},
+		},
+		"only expired certificates, kept": {
+			inLine:      dummy.TestExpiredCertificate,
+			filters:     &trustapi.BundleFilters{Expired: trustapi.ExpiredCertificatePolicyKeep},
+			expWarnings: admission.Warnings{`spec.sources.[0].inLine: line 1: certificate "O=Internet Widgits Pty Ltd,ST=Some-State,C=AU" expired at 2024-01-12T11:09:24Z`},
+		},
+		"an expired certificate alongside a valid one": {
+			inLine: dummy.JoinCerts(dummy.TestCertificate1, dummy.TestExpiredCertificate),
+			expWarnings: admission.Warnings{
+				`spec.sources.[0].inLine: line ` + strconv.Itoa(certLines+1) + `: certificate "O=Internet Widgits Pty Ltd,ST=Some-State,C=AU" expired at 2024-01-12T11:09:24Z`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs, warnings := validateInLinePEM(test.inLine, path, dummy.DummyInstant(), test.filters, test.unchanged)
+			assert.Equal(t, test.expErrs, errs)
+			assert.Equal(t, test.expWarnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+const (
+	// validatePath is the path controller-runtime registers the Bundle
+	// validating webhook on.
+	validatePath = "/validate-trust-cert-manager-io-v1alpha1-bundle"
+
+	readinessInitialBackoff = 100 * time.Millisecond
+	readinessMaxBackoff     = 10 * time.Second
+)
+
+// errShuttingDown is returned by the readiness check once trust-manager has
+// started to shut down.
+var errShuttingDown = errors.New("webhook is shutting down")
+
+// readinessChecker reports the webhook as ready only once its prerequisites
+// pass, such as the webhook server having started with a valid certificate
+// and the caches having synced, and a validation request sent to the webhook
+// itself has succeeded.
+// Failed self-calls are retried with exponential backoff; between attempts the
+// last error is returned. Once a self-call has succeeded the result is cached,
+// so subsequent probes only check the prerequisites. Once shuttingDown is
+// closed, the webhook is reported as unready for good.
+type readinessChecker struct {
+	prerequisites []healthz.Checker
+	shuttingDown  <-chan struct{}
+	url           string
+	client        *http.Client
+	clock         clock.Clock
+
+	mu          sync.Mutex
+	ready       bool
+	lastErr     error
+	backoff     time.Duration
+	nextAttempt time.Time
+}
+
+func newReadinessChecker(host string, port int, shuttingDown <-chan struct{}, prerequisites ...healthz.Checker) *readinessChecker {
+	// The webhook usually listens on all interfaces, in which case we call it
+	// over loopback.
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+
+	return &readinessChecker{
+		prerequisites: prerequisites,
+		shuttingDown:  shuttingDown,
+		url:           "https://" + net.JoinHostPort(host, strconv.Itoa(port)) + validatePath,
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				// The serving certificate is issued for the webhook Service, not
+				// for loopback, so we only care that the handshake succeeds.
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- self-call to our own server
+			},
+		},
+		clock: clock.RealClock{},
+	}
+}
+
+// Check implements healthz.Checker.
+func (c *readinessChecker) Check(req *http.Request) error {
+	select {
+	case <-c.shuttingDown:
+		return errShuttingDown
+	default:
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ready {
+		return c.checkPrerequisites(req)
+	}
+
+	now := c.clock.Now()
+	if now.Before(c.nextAttempt) {
+		return c.lastErr
+	}
+
+	if err := c.attempt(req); err != nil {
+		if c.backoff == 0 {
+			c.backoff = readinessInitialBackoff
+		} else {
+			c.backoff = min(c.backoff*2, readinessMaxBackoff)
+		}
+		c.nextAttempt = now.Add(c.backoff)
+		c.lastErr = err
+		return err
+	}
+
+	c.ready = true
+	c.lastErr = nil
+	return nil
+}
+
+func (c *readinessChecker) checkPrerequisites(req *http.Request) error {
+	for _, check := range c.prerequisites {
+		if err := check(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *readinessChecker) attempt(req *http.Request) error {
+	if err := c.checkPrerequisites(req); err != nil {
+		return err
+	}
+
+	review, err := selfCallReview()
+	if err != nil {
+		return fmt.Errorf("failed to build webhook self-call: %w", err)
+	}
+
+	body, err := json.Marshal(review)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook self-call: %w", err)
+	}
+
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook self-call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook self-call returned unexpected status: %s", resp.Status)
+	}
+
+	var response admissionv1.AdmissionReview
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode webhook self-call response: %w", err)
+	}
+
+	if response.Response == nil {
+		return fmt.Errorf("webhook self-call returned no admission response")
+	}
+
+	return nil
+}
+
+// selfCallReview returns a minimal AdmissionReview for a Bundle. The Bundle
+// is not expected to be valid; any admission response proves the webhook is
+// serving.
+func selfCallReview() (*admissionv1.AdmissionReview, error) {
+	bundle := &trustapi.Bundle{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: trustapi.SchemeGroupVersion.String(),
+			Kind:       trustapi.BundleKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "trust-manager-readiness-check"},
+	}
+
+	raw, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionv1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "trust-manager-readiness-check",
+			Kind:      metav1.GroupVersionKind(trustapi.SchemeGroupVersion.WithKind(trustapi.BundleKind)),
+			Resource:  metav1.GroupVersionResource(trustapi.SchemeGroupVersion.WithResource("bundles")),
+			Name:      bundle.Name,
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+			DryRun:    ptr.To(true),
+		},
+	}, nil
+}
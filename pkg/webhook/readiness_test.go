@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func Test_readinessChecker(t *testing.T) {
+	var (
+		calls   int
+		healthy bool
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, validatePath, r.URL.Path)
+
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var review admissionv1.AdmissionReview
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			t.Errorf("failed to decode self-call: %s", err)
+		}
+		review.Response = &admissionv1.AdmissionResponse{UID: review.Request.UID}
+		_ = json.NewEncoder(w).Encode(review)
+	}))
+	defer server.Close()
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	checker := newReadinessChecker("", 0, nil, func(*http.Request) error { return nil })
+	checker.url = server.URL + validatePath
+	checker.client = server.Client()
+	checker.clock = clock
+
+	// First attempt fails and schedules a retry.
+	assert.Error(t, checker.Check(nil))
+	assert.Equal(t, 1, calls)
+
+	// Within the backoff window the cached error is returned without calling the webhook.
+	healthy = true
+	assert.Error(t, checker.Check(nil))
+	assert.Equal(t, 1, calls)
+
+	// Once the backoff has elapsed the webhook is called again.
+	clock.Step(readinessInitialBackoff)
+	assert.NoError(t, checker.Check(nil))
+	assert.Equal(t, 2, calls)
+
+	// Success is cached.
+	healthy = false
+	assert.NoError(t, checker.Check(nil))
+	assert.Equal(t, 2, calls)
+}
+
+func Test_readinessChecker_notStarted(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	checker := newReadinessChecker("", 0, nil, func(*http.Request) error { return errors.New("not started") })
+	checker.clock = clock
+
+	assert.EqualError(t, checker.Check(nil), "not started")
+
+	// Backoff doubles after each failure, up to the maximum.
+	for range 10 {
+		clock.Step(checker.backoff)
+		assert.Error(t, checker.Check(nil))
+	}
+	assert.Equal(t, readinessMaxBackoff, checker.backoff)
+}
+
+func Test_readinessChecker_prerequisitesAndShutdown(t *testing.T) {
+	var prerequisiteErr error
+	shuttingDown := make(chan struct{})
+	checker := newReadinessChecker("", 0, shuttingDown, func(*http.Request) error { return prerequisiteErr })
+	checker.ready = true
+
+	assert.NoError(t, checker.Check(nil))
+
+	// Prerequisites are checked even once the self-call has succeeded.
+	prerequisiteErr = errors.New("certificate expired")
+	assert.EqualError(t, checker.Check(nil), "certificate expired")
+
+	prerequisiteErr = nil
+	assert.NoError(t, checker.Check(nil))
+
+	close(shuttingDown)
+	assert.ErrorIs(t, checker.Check(nil), errShuttingDown)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+)
+
+const (
+	// renderPath is the path the Bundle render endpoint is served on.
+	renderPath = "/render"
+
+	// maxRenderRequestBytes limits the size of Bundle manifests accepted by
+	// the render endpoint.
+	maxRenderRequestBytes = 1 << 20
+)
+
+// Renderer builds the PEM bundle for a Bundle from its current sources.
+type Renderer interface {
+	Render(ctx context.Context, bundle *trustapi.Bundle) (string, error)
+}
+
+// renderHandler accepts a Bundle manifest, as YAML or JSON, and responds with
+// the PEM bundle that would be written to its targets. Nothing is created or
+// updated in the cluster.
+type renderHandler struct {
+	log       logr.Logger
+	validator *validator
+	renderer  Renderer
+}
+
+func (h *renderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var bundle trustapi.Bundle
+	decoder := yaml.NewYAMLOrJSONDecoder(io.LimitReader(r.Body, maxRenderRequestBytes), 4096)
+	if err := decoder.Decode(&bundle); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode Bundle: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.validator.validate(&bundle, nil); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	pem, err := h.renderer.Render(r.Context(), &bundle)
+	if err != nil {
+		h.log.V(2).Info("failed to render bundle", "bundle", bundle.Name, "error", err)
+		http.Error(w, fmt.Sprintf("failed to render Bundle: %s", err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	_, _ = io.WriteString(w, pem)
+}
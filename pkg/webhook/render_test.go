@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/klog/v2/ktesting"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/dummy"
+)
+
+type fakeRenderer func(ctx context.Context, bundle *trustapi.Bundle) (string, error)
+
+func (f fakeRenderer) Render(ctx context.Context, bundle *trustapi.Bundle) (string, error) {
+	return f(ctx, bundle)
+}
+
+func Test_renderHandler(t *testing.T) {
+	const validBundle = `
+apiVersion: trust.cert-manager.io/v1alpha1
+kind: Bundle
+metadata:
+  name: test-bundle
+spec:
+  sources:
+  - configMap:
+      name: source
+      key: ca.crt
+  target:
+    configMap:
+      key: ca.crt
+`
+
+	tests := map[string]struct {
+		method    string
+		body      string
+		renderErr error
+
+		expStatus int
+		expBody   string
+	}{
+		"a GET request should be rejected": {
+			method:    http.MethodGet,
+			expStatus: http.StatusMethodNotAllowed,
+			expBody:   "only POST is supported\n",
+		},
+		"a body which isn't a Bundle should be rejected": {
+			method:    http.MethodPost,
+			body:      "{",
+			expStatus: http.StatusBadRequest,
+		},
+		"an invalid Bundle should be rejected": {
+			method:    http.MethodPost,
+			body:      `{"metadata":{"name":"test-bundle"},"spec":{"sources":[{"inLine":"foo"}],"target":{}}}`,
+			expStatus: http.StatusUnprocessableEntity,
+		},
+		"if the Bundle fails to render, the error should be returned": {
+			method:    http.MethodPost,
+			body:      validBundle,
+			renderErr: errors.New("source not found"),
+			expStatus: http.StatusUnprocessableEntity,
+			expBody:   "failed to render Bundle: source not found\n",
+		},
+		"a valid Bundle should be rendered": {
+			method:    http.MethodPost,
+			body:      validBundle,
+			expStatus: http.StatusOK,
+			expBody:   dummy.TestCertificate1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			log, _ := ktesting.NewTestContext(t)
+			handler := &renderHandler{
+				log:       log,
+				validator: &validator{log: log},
+				renderer: fakeRenderer(func(_ context.Context, bundle *trustapi.Bundle) (string, error) {
+					assert.Equal(t, "test-bundle", bundle.Name)
+					if test.renderErr != nil {
+						return "", test.renderErr
+					}
+					return dummy.TestCertificate1, nil
+				}),
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(test.method, renderPath, strings.NewReader(test.body)))
+
+			assert.Equal(t, test.expStatus, recorder.Code)
+			if test.expBody != "" {
+				assert.Equal(t, test.expBody, recorder.Body.String())
+			}
+		})
+	}
+}
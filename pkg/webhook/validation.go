@@ -19,26 +19,118 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/url"
+	gopath "path"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/pkg/cloudsource/awspca"
+	"github.com/cert-manager/trust-manager/pkg/util"
 )
 
+// spiffeTrustDomainRegexp matches the characters permitted in a SPIFFE trust domain name.
+// See https://github.com/spiffe/spiffe/blob/main/standards/SPIFFE-ID.md#21-trust-domain
+var spiffeTrustDomainRegexp = regexp.MustCompile(`^[a-z0-9._-]+$`)
+
+// gcpCASPoolRegexp matches the resource names of Google Cloud CA pools.
+var gcpCASPoolRegexp = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/caPools/[^/]+$`)
+
+// azureKeyVaultCertificateNameRegexp matches the names of Azure Key Vault
+// certificates.
+var azureKeyVaultCertificateNameRegexp = regexp.MustCompile(`^[0-9a-zA-Z-]{1,127}$`)
+
+// snapshotHashRegexp matches the hashes which identify snapshots of Bundles.
+var snapshotHashRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
 // validator validates against trust.cert-manager.io resources.
 type validator struct {
 	log logr.Logger
+
+	// requireCABasicConstraints is the default for Bundles which don't set
+	// spec.requireCABasicConstraints.
+	requireCABasicConstraints bool
+
+	// signingEnabled is true if Bundles may request a signature.
+	signingEnabled bool
+
+	// sourceNamespaceSelectorsEnabled is true if sources may select objects
+	// in other Namespaces.
+	sourceNamespaceSelectorsEnabled bool
+
+	// certificateSourcesEnabled is true if sources may select cert-manager
+	// Certificates.
+	certificateSourcesEnabled bool
+
+	// maxBundleSizeBytes and maxCertificates, if positive, limit the size and
+	// number of certificates of the InLine sources of a Bundle, which are the
+	// only sources known at admission.
+	maxBundleSizeBytes int
+	maxCertificates    int
+
+	// client, if set, is used to reject Bundles whose targets conflict with
+	// those of other Bundles.
+	client client.Reader
 }
 
 var _ admission.CustomValidator = &validator{}
 
+// newValidator returns a validator configured by the options, which checks
+// for target conflicts with the Bundles read from client.
+func newValidator(opts Options, client client.Reader) *validator {
+	return &validator{
+		log:                       opts.Log.WithName("validation"),
+		requireCABasicConstraints: opts.RequireCABasicConstraints,
+		signingEnabled:            opts.SigningEnabled,
+		maxBundleSizeBytes:        opts.MaxBundleSizeBytes,
+		maxCertificates:           opts.MaxCertificates,
+		client:                    client,
+
+		sourceNamespaceSelectorsEnabled: opts.SourceNamespaceSelectorsEnabled,
+		certificateSourcesEnabled:       opts.CertificateSourcesEnabled,
+	}
+}
+
+// Validate validates the Bundle as the webhook would on admission: as a new
+// Bundle, or as an update of oldBundle if it's set, submitted in the given API
+// version. Target conflicts with the Bundles read from client are checked if
+// client is set. It lets Bundles be validated before they're applied, such as
+// in previews of changes.
+func Validate(ctx context.Context, opts Options, client client.Reader, version string, bundle, oldBundle *trustapi.Bundle) (admission.Warnings, error) {
+	ctx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Kind: metav1.GroupVersionKind{Group: trustapi.SchemeGroupVersion.Group, Version: version, Kind: "Bundle"},
+	}})
+
+	v := newValidator(opts, client)
+	if oldBundle == nil {
+		return v.ValidateCreate(ctx, bundle)
+	}
+	return v.ValidateUpdate(ctx, oldBundle, bundle)
+}
+
 func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return v.validate(obj)
+	warnings, err := v.validate(obj, nil)
+	if err != nil {
+		return warnings, err
+	}
+	bundle := obj.(*trustapi.Bundle)
+	warnings = append(warnings, deprecationWarnings(ctx, bundle)...)
+	return warnings, v.validateConflicts(ctx, bundle, nil)
 }
 
 func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -55,17 +147,46 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 		el   field.ErrorList
 		path = field.NewPath("spec")
 	)
-	// Target removal are not allowed.
-	if oldBundle.Spec.Target.ConfigMap != nil && newBundle.Spec.Target.ConfigMap == nil {
+	// Target removal are not allowed. Targets may move between target and
+	// targets, as long as a target of the same kind remains.
+	hasConfigMap := func(t trustapi.BundleTarget) bool { return t.ConfigMap != nil }
+	hasSecret := func(t trustapi.BundleTarget) bool { return t.Secret != nil }
+	if slices.ContainsFunc(oldBundle.Spec.AllTargets(), hasConfigMap) && !slices.ContainsFunc(newBundle.Spec.AllTargets(), hasConfigMap) {
 		el = append(el, field.Invalid(path.Child("target", "configmap"), "", "target configMap removal is not allowed"))
 		return nil, el.ToAggregate()
 	}
 	// Target removal are not allowed.
-	if oldBundle.Spec.Target.Secret != nil && newBundle.Spec.Target.Secret == nil {
+	if slices.ContainsFunc(oldBundle.Spec.AllTargets(), hasSecret) && !slices.ContainsFunc(newBundle.Spec.AllTargets(), hasSecret) {
 		el = append(el, field.Invalid(path.Child("target", "secret"), "", "target secret removal is not allowed"))
 		return nil, el.ToAggregate()
 	}
-	return v.validate(newObj)
+
+	warnings, err := v.validate(newObj, oldBundle)
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, deprecationWarnings(ctx, newBundle)...)
+	return warnings, v.validateConflicts(ctx, newBundle, oldBundle)
+}
+
+// deprecationWarnings returns warnings for the deprecated fields and values
+// used by the Bundle, in the API version it was submitted in.
+func deprecationWarnings(ctx context.Context, bundle *trustapi.Bundle) admission.Warnings {
+	var version string
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		version = req.Kind.Version
+	}
+	return trustapi.DeprecationWarnings(bundle, version)
+}
+
+// validateConflicts returns an error if the Bundle writes to the targets of
+// another Bundle.
+func (v *validator) validateConflicts(ctx context.Context, bundle, oldBundle *trustapi.Bundle) error {
+	el, err := v.validateTargetConflicts(ctx, bundle, oldBundle)
+	if err != nil {
+		return err
+	}
+	return el.ToAggregate()
 }
 
 func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -73,7 +194,8 @@ func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (adm
 	return nil, nil
 }
 
-func (v *validator) validate(obj runtime.Object) (admission.Warnings, error) {
+// validate validates the Bundle, as an update of oldBundle if it's set.
+func (v *validator) validate(obj runtime.Object, oldBundle *trustapi.Bundle) (admission.Warnings, error) {
 	bundle, ok := obj.(*trustapi.Bundle)
 	if !ok {
 		return nil, fmt.Errorf("expected a Bundle, but got a %T", obj)
@@ -88,6 +210,8 @@ func (v *validator) validate(obj runtime.Object) (admission.Warnings, error) {
 
 	sourceCount := 0
 	defaultCAsCount := 0
+	inClusterCACount := 0
+	inLineSize, inLineCertificates := 0, 0
 
 	for i, source := range bundle.Spec.Sources {
 		path := path.Child("sources").Child("[" + strconv.Itoa(i) + "]")
@@ -111,9 +235,13 @@ func (v *validator) validate(obj runtime.Object) (admission.Warnings, error) {
 			if len(configMap.Key) > 0 && configMap.IncludeAllKeys {
 				el = append(el, field.Invalid(path, fmt.Sprintf("key: %s, includeAllKeys: %t", configMap.Key, configMap.IncludeAllKeys), "source configMap key cannot be defined when includeAllKeys is true"))
 			}
+			if _, err := gopath.Match(configMap.Key, ""); err != nil {
+				el = append(el, field.Invalid(path.Child("key"), configMap.Key, "source configMap key must be a valid glob pattern"))
+			}
 
 			errs := validation.ValidateLabelSelector(configMap.Selector, validation.LabelSelectorValidationOptions{}, path.Child("selector"))
 			el = append(el, errs...)
+			el = append(el, v.validateSourceNamespaceSelector(configMap, path)...)
 		}
 
 		if secret := source.Secret; secret != nil {
@@ -133,14 +261,97 @@ func (v *validator) validate(obj runtime.Object) (admission.Warnings, error) {
 			if len(secret.Key) > 0 && secret.IncludeAllKeys {
 				el = append(el, field.Invalid(path, fmt.Sprintf("key: %s, includeAllKeys: %t", secret.Key, secret.IncludeAllKeys), "source secret key cannot be defined when includeAllKeys is true"))
 			}
+			if _, err := gopath.Match(secret.Key, ""); err != nil {
+				el = append(el, field.Invalid(path.Child("key"), secret.Key, "source secret key must be a valid glob pattern"))
+			}
 
 			errs := validation.ValidateLabelSelector(secret.Selector, validation.LabelSelectorValidationOptions{}, path.Child("selector"))
 			el = append(el, errs...)
+			el = append(el, v.validateSourceNamespaceSelector(secret, path)...)
 		}
 
 		if source.InLine != nil {
 			sourceCount++
 			unionCount++
+
+			// An inLine source which was admitted before isn't rejected for
+			// its certificates expiring since, as that would reject every
+			// update of the Bundle, including of its status and finalizers.
+			unchanged := oldBundle != nil && slices.ContainsFunc(oldBundle.Spec.Sources, func(old trustapi.BundleSource) bool {
+				return old.InLine != nil && *old.InLine == *source.InLine
+			})
+			pemErrs, pemWarnings := validateInLinePEM(*source.InLine, path.Child("inLine"), time.Now(), bundle.Spec.Filters, unchanged)
+			el = append(el, pemErrs...)
+			warnings = append(warnings, pemWarnings...)
+
+			if len(pemErrs) == 0 && v.bundleRequiresCA(bundle) {
+				skipInvalid := bundle.Spec.Filters != nil && bundle.Spec.Filters.Invalid == trustapi.InvalidCertificatePolicySkip
+				el = append(el, validateInLineCACertificates(*source.InLine, path.Child("inLine"), skipInvalid)...)
+			}
+
+			inLineSize += len(*source.InLine)
+			inLineCertificates += countCertificates(*source.InLine)
+		}
+
+		if source.IssuerRef != nil {
+			sourceCount++
+			unionCount++
+		}
+
+		if source.Certificates != nil {
+			path := path.Child("certificates")
+			sourceCount++
+			unionCount++
+
+			if !v.certificateSourcesEnabled {
+				el = append(el, field.Forbidden(path, "cert-manager Certificate sources are not enabled; trust-manager must be started with --cert-manager-certificate-integration"))
+			}
+			el = append(el, validation.ValidateLabelSelector(&source.Certificates.Selector, validation.LabelSelectorValidationOptions{}, path.Child("selector"))...)
+		}
+
+		if source.SPIFFEFederation != nil {
+			sourceCount++
+			unionCount++
+
+			el = append(el, validateSPIFFEFederation(source.SPIFFEFederation, path.Child("spiffeFederation"))...)
+		}
+
+		if source.AWSPrivateCA != nil {
+			sourceCount++
+			unionCount++
+
+			if err := awspca.ValidateARN(source.AWSPrivateCA.ARN); err != nil {
+				el = append(el, field.Invalid(path.Child("awsPrivateCA", "arn"), source.AWSPrivateCA.ARN, "must be the ARN of an AWS Private CA, such as arn:aws:acm-pca:<region>:<account>:certificate-authority/<id>"))
+			}
+			if interval := source.AWSPrivateCA.RefreshInterval; interval != nil && interval.Duration <= 0 {
+				el = append(el, field.Invalid(path.Child("awsPrivateCA", "refreshInterval"), interval.Duration.String(), "must be positive"))
+			}
+		}
+
+		if source.GCPCASPool != nil {
+			sourceCount++
+			unionCount++
+
+			if !gcpCASPoolRegexp.MatchString(source.GCPCASPool.Pool) {
+				el = append(el, field.Invalid(path.Child("gcpCASPool", "pool"), source.GCPCASPool.Pool, "must be the resource name of a CA pool, such as projects/<project>/locations/<location>/caPools/<pool>"))
+			}
+			if interval := source.GCPCASPool.RefreshInterval; interval != nil && interval.Duration <= 0 {
+				el = append(el, field.Invalid(path.Child("gcpCASPool", "refreshInterval"), interval.Duration.String(), "must be positive"))
+			}
+		}
+
+		if source.AzureKeyVault != nil {
+			sourceCount++
+			unionCount++
+
+			el = append(el, validateAzureKeyVault(source.AzureKeyVault, path.Child("azureKeyVault"))...)
+		}
+
+		if source.Plugin != nil {
+			sourceCount++
+			unionCount++
+
+			el = append(el, validatePlugin(source.Plugin, path.Child("plugin"))...)
 		}
 
 		if source.UseDefaultCAs != nil {
@@ -152,6 +363,15 @@ func (v *validator) validate(obj runtime.Object) (admission.Warnings, error) {
 			}
 		}
 
+		if source.UseInClusterCA != nil {
+			inClusterCACount++
+			unionCount++
+
+			if *source.UseInClusterCA {
+				sourceCount++
+			}
+		}
+
 		if unionCount != 1 {
 			el = append(el, field.Forbidden(
 				path, fmt.Sprintf("must define exactly one source type for each item but found %d defined types", unionCount),
@@ -170,72 +390,837 @@ func (v *validator) validate(obj runtime.Object) (admission.Warnings, error) {
 		))
 	}
 
-	if target := bundle.Spec.Target.ConfigMap; target != nil {
-		path := path.Child("sources")
+	if v.maxBundleSizeBytes > 0 && inLineSize > v.maxBundleSizeBytes {
+		el = append(el, field.Forbidden(
+			path.Child("sources"),
+			fmt.Sprintf("inLine sources are %d bytes, which exceeds the maximum bundle size of %d bytes", inLineSize, v.maxBundleSizeBytes),
+		))
+	}
+
+	if v.maxCertificates > 0 && inLineCertificates > v.maxCertificates {
+		el = append(el, field.Forbidden(
+			path.Child("sources"),
+			fmt.Sprintf("inLine sources hold %d certificates, which exceeds the maximum of %d", inLineCertificates, v.maxCertificates),
+		))
+	}
+
+	if inClusterCACount > 1 {
+		el = append(el, field.Forbidden(
+			path.Child("sources"),
+			fmt.Sprintf("must request the in-cluster CA either once or not at all but got %d requests", inClusterCACount),
+		))
+	}
+
+	// spec.target may be left empty if spec.targets is used.
+	if len(bundle.Spec.Targets) == 0 || bundle.Spec.Target.ConfigMap != nil || bundle.Spec.Target.Secret != nil {
+		el = append(el, v.validateTarget(bundle, bundle.Spec.Target, path, path.Child("target"))...)
+	}
+	for i, bundleTarget := range bundle.Spec.Targets {
+		el = append(el, v.validateTarget(bundle, bundleTarget, path, path.Child("targets").Index(i))...)
+	}
+
+	kinds := map[string]int{}
+	for _, bundleTarget := range bundle.Spec.AllTargets() {
+		if bundleTarget.ConfigMap != nil {
+			kinds["configMap"]++
+		}
+		if bundleTarget.Secret != nil {
+			kinds["secret"]++
+		}
+	}
+	for _, kind := range []string{"configMap", "secret"} {
+		if kinds[kind] > 1 {
+			el = append(el, field.Forbidden(path.Child("targets"), fmt.Sprintf("%s targets may only be defined once across target and targets", kind)))
+		}
+	}
+
+	if interval := bundle.Spec.RefreshInterval; interval != nil && interval.Duration < 0 {
+		el = append(el, field.Invalid(path.Child("refreshInterval"), interval.Duration.String(), "refresh interval must not be negative"))
+	}
+
+	if history := bundle.Spec.History; history != nil && history.Limit < 1 {
+		el = append(el, field.Invalid(path.Child("history", "limit"), history.Limit, "must be at least 1"))
+	}
+	if rollbackTo := bundle.Spec.RollbackTo; rollbackTo != "" && !snapshotHashRegexp.MatchString(rollbackTo) {
+		el = append(el, field.Invalid(path.Child("rollbackTo"), rollbackTo, "must be the SHA-256 hash of a snapshot in the history of the Bundle"))
+	}
+	if rollbackTo, ok := bundle.GetAnnotations()[trustapi.BundleRollbackToAnnotationKey]; ok && !snapshotHashRegexp.MatchString(rollbackTo) {
+		el = append(el, field.Invalid(field.NewPath("metadata", "annotations").Key(trustapi.BundleRollbackToAnnotationKey), rollbackTo, "must be the SHA-256 hash of a snapshot in the history of the Bundle"))
+	}
+
+	return warnings, el.ToAggregate()
+
+}
+
+// validateAdditionalFormats validates the additional formats found at the
+// given path, which are written alongside the given target keys. targetKeys
+// maps each target key to a description of its use in error messages.
+func validateAdditionalFormats(additionalFormats *trustapi.AdditionalFormats, targetKeys map[string]string, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	var formats = make(map[string]*trustapi.KeySelector)
+	usedKeys := make(map[string]string, len(targetKeys))
+	for key, use := range targetKeys {
+		usedKeys[key] = use
+	}
+
+	// Checks for nil to avoid nil point dereference error
+	if additionalFormats.JKS != nil {
+		formats["jks"] = &additionalFormats.JKS.KeySelector
+	}
+
+	// Checks for nil to avoid nil point dereference error
+	if additionalFormats.PKCS12 != nil {
+		formats["pkcs12"] = &additionalFormats.PKCS12.KeySelector
+	}
+
+	// Checks for nil to avoid nil point dereference error
+	if spiffe := additionalFormats.SPIFFE; spiffe != nil {
+		formats["spiffe"] = &spiffe.KeySelector
+
+		if !spiffeTrustDomainRegexp.MatchString(spiffe.TrustDomain) {
+			el = append(el, field.Invalid(path.Child("spiffe", "trustDomain"), spiffe.TrustDomain, "trust domain must only contain lowercase letters, numbers, dots, dashes and underscores"))
+		}
+	}
+
+	// Checks for nil to avoid nil point dereference error
+	if additionalFormats.Certdata != nil {
+		formats["certdata"] = &additionalFormats.Certdata.KeySelector
+	}
+
+	// Formats are checked in a fixed order, so that a collision is always
+	// reported against the same key.
+	for _, f := range []string{"jks", "pkcs12", "spiffe", "certdata"} {
+		if selector := formats[f]; selector != nil {
+			if use, ok := usedKeys[selector.Key]; ok {
+				el = append(el, field.Invalid(path.Child(f, "key"), selector.Key, fmt.Sprintf("key must be unique in target, but is also used by the %s", use)))
+				continue
+			}
+			usedKeys[selector.Key] = f + " format"
+		}
+	}
+
+	return el
+}
+
+// validateTarget validates a single target of the Bundle, found at the given
+// path.
+func (v *validator) validateTarget(bundle *trustapi.Bundle, bundleTarget trustapi.BundleTarget, specPath, targetPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if target := bundleTarget.ConfigMap; target != nil {
+		path := specPath.Child("sources")
 		for i, source := range bundle.Spec.Sources {
-			if source.ConfigMap != nil && source.ConfigMap.Name == bundle.Name && source.ConfigMap.Key == target.Key {
+			if source.ConfigMap != nil && source.ConfigMap.Name == bundle.Name && sourceKeyMatches(source.ConfigMap.Key, target.Key) {
 				el = append(el, field.Forbidden(path.Child(fmt.Sprintf("[%d]", i), "configMap", source.ConfigMap.Name, source.ConfigMap.Key), "cannot define the same source as target"))
 			}
 		}
 	}
 
-	if target := bundle.Spec.Target.Secret; target != nil {
-		path := path.Child("sources")
+	if target := bundleTarget.Secret; target != nil {
+		path := specPath.Child("sources")
 		for i, source := range bundle.Spec.Sources {
-			if source.Secret != nil && source.Secret.Name == bundle.Name && source.Secret.Key == target.Key {
+			if source.Secret != nil && source.Secret.Name == bundle.Name && sourceKeyMatches(source.Secret.Key, target.Key) {
 				el = append(el, field.Forbidden(path.Child(fmt.Sprintf("[%d]", i), "secret", source.Secret.Name, source.Secret.Key), "cannot define the same source as target"))
 			}
 		}
 	}
 
-	configMap := bundle.Spec.Target.ConfigMap
-	secret := bundle.Spec.Target.Secret
+	configMap := bundleTarget.ConfigMap
+	secret := bundleTarget.Secret
 
 	if configMap == nil && secret == nil {
-		el = append(el, field.Invalid(path.Child("target"), bundle.Spec.Target, "must define at least one target"))
+		el = append(el, field.Invalid(targetPath, bundleTarget, "must define at least one target"))
 	}
 
 	if configMap != nil && len(configMap.Key) == 0 {
-		el = append(el, field.Invalid(path.Child("target", "configMap", "key"), configMap.Key, "target configMap key must be defined"))
+		el = append(el, field.Invalid(targetPath.Child("configMap", "key"), configMap.Key, "target configMap key must be defined"))
 	}
 
 	if secret != nil && len(secret.Key) == 0 {
-		el = append(el, field.Invalid(path.Child("target", "secret", "key"), secret.Key, "target secret key must be defined"))
+		el = append(el, field.Invalid(targetPath.Child("secret", "key"), secret.Key, "target secret key must be defined"))
+	}
+
+	if transition := bundleTarget.KeyTransition; transition != nil && transition.Duration != nil && transition.Duration.Duration <= 0 {
+		el = append(el, field.Invalid(targetPath.Child("keyTransition", "duration"), transition.Duration.Duration.String(), "must be positive"))
+	}
+
+	// Additional formats written to a separate target can't collide with
+	// additional keys.
+	configMapFormats, secretFormats := bundleTarget.ConfigMapFormats(), bundleTarget.SecretFormats()
+	if bundleTarget.AdditionalFormatsTarget != nil {
+		configMapFormats, secretFormats = nil, nil
+	}
+
+	if configMap != nil && len(configMap.AdditionalKeys) > 0 {
+		el = append(el, validateAdditionalKeys(configMap.Key, configMap.AdditionalKeys, configMapFormats, targetPath.Child("configMap", "additionalKeys"))...)
+	}
+
+	if secret != nil && len(secret.AdditionalKeys) > 0 {
+		el = append(el, validateAdditionalKeys(secret.Key, secret.AdditionalKeys, secretFormats, targetPath.Child("secret", "additionalKeys"))...)
+	}
+
+	if bundleTarget.AdditionalFormats != nil {
+		targetKeys := map[string]string{}
+		if secret != nil {
+			targetKeys[secret.Key] = "target secret key"
+		}
+		if configMap != nil {
+			targetKeys[configMap.Key] = "target configMap key"
+		}
+		el = append(el, validateAdditionalFormats(bundleTarget.AdditionalFormats, targetKeys, targetPath.Child("additionalFormats"))...)
+	}
+
+	if configMap != nil && configMap.AdditionalFormats != nil {
+		el = append(el, validateAdditionalFormats(configMap.AdditionalFormats, map[string]string{configMap.Key: "target configMap key"}, targetPath.Child("configMap", "additionalFormats"))...)
+	}
+
+	if secret != nil && secret.AdditionalFormats != nil {
+		el = append(el, validateAdditionalFormats(secret.AdditionalFormats, map[string]string{secret.Key: "target secret key"}, targetPath.Child("secret", "additionalFormats"))...)
+	}
+
+	if formatsTarget := bundleTarget.AdditionalFormatsTarget; formatsTarget != nil {
+		path := targetPath.Child("additionalFormatsTarget")
+
+		if bundleTarget.AdditionalFormats == nil && (configMap == nil || configMap.AdditionalFormats == nil) && (secret == nil || secret.AdditionalFormats == nil) {
+			el = append(el, field.Invalid(path, formatsTarget.Name, "additionalFormats must be defined when additionalFormatsTarget is set"))
+		}
+
+		if formatsTarget.Name == bundle.Name {
+			el = append(el, field.Invalid(path.Child("name"), formatsTarget.Name, "name must differ from the Bundle name"))
+		}
+
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(formatsTarget.Name) {
+			el = append(el, field.Invalid(path.Child("name"), formatsTarget.Name, msg))
+		}
+
+		if secret != nil && secret.Immutable {
+			el = append(el, field.Forbidden(path, "additionalFormatsTarget is not supported with immutable Secret targets"))
+		}
 	}
 
-	if bundle.Spec.Target.AdditionalFormats != nil {
-		var formats = make(map[string]*trustapi.KeySelector)
-		targetKeys := map[string]struct{}{}
+	if signature := bundleTarget.Signature; signature != nil {
+		path := targetPath.Child("signature")
+
+		if !v.signingEnabled {
+			el = append(el, field.Forbidden(path, "signatures are not enabled; trust-manager must be started with a signing key"))
+		}
+
+		if signature.Key != "" {
+			for _, msg := range utilvalidation.IsConfigMapKey(signature.Key) {
+				el = append(el, field.Invalid(path.Child("key"), signature.Key, msg))
+			}
+		}
+
+		usedKeys := map[string]struct{}{}
+		var bundleKeys []string
 		if configMap != nil {
-			targetKeys[configMap.Key] = struct{}{}
+			bundleKeys = append(bundleKeys, configMap.Key)
 		}
 		if secret != nil {
-			targetKeys[secret.Key] = struct{}{}
+			bundleKeys = append(bundleKeys, secret.Key)
+		}
+		for _, key := range bundleKeys {
+			usedKeys[key] = struct{}{}
+		}
+		if bundleTarget.AdditionalFormatsTarget == nil {
+			for _, formats := range []*trustapi.AdditionalFormats{bundleTarget.ConfigMapFormats(), bundleTarget.SecretFormats()} {
+				if formats == nil {
+					continue
+				}
+				if formats.JKS != nil {
+					usedKeys[formats.JKS.Key] = struct{}{}
+				}
+				if formats.PKCS12 != nil {
+					usedKeys[formats.PKCS12.Key] = struct{}{}
+				}
+				if formats.SPIFFE != nil {
+					usedKeys[formats.SPIFFE.Key] = struct{}{}
+				}
+				if formats.Certdata != nil {
+					usedKeys[formats.Certdata.Key] = struct{}{}
+				}
+			}
 		}
 
-		// Checks for nil to avoid nil point dereference error
-		if bundle.Spec.Target.AdditionalFormats.JKS != nil {
-			formats["jks"] = &bundle.Spec.Target.AdditionalFormats.JKS.KeySelector
+		for _, bundleKey := range bundleKeys {
+			key := signatureKey(signature, bundleKey)
+			if _, ok := usedKeys[key]; ok {
+				el = append(el, field.Invalid(path.Child("key"), key, "signature key must be unique in target"))
+				break
+			}
 		}
+	}
+
+	if manifest := bundleTarget.Manifest; manifest != nil {
+		path := targetPath.Child("manifest", "key")
 
-		// Checks for nil to avoid nil point dereference error
-		if bundle.Spec.Target.AdditionalFormats.PKCS12 != nil {
-			formats["pkcs12"] = &bundle.Spec.Target.AdditionalFormats.PKCS12.KeySelector
+		key := manifest.Key
+		if key == "" {
+			key = trustapi.DefaultManifestKey
+		}
+		for _, msg := range utilvalidation.IsConfigMapKey(key) {
+			el = append(el, field.Invalid(path, key, msg))
 		}
 
-		for f, selector := range formats {
-			if selector != nil {
-				if _, ok := targetKeys[selector.Key]; ok {
-					el = append(el, field.Invalid(path.Child("target", "additionalFormats", f, "key"), selector.Key, "key must be unique in target configMap"))
+		usedKeys := map[string]struct{}{}
+		if configMap != nil {
+			usedKeys[configMap.Key] = struct{}{}
+			for _, additionalKey := range configMap.AdditionalKeys {
+				usedKeys[additionalKey] = struct{}{}
+			}
+			if signature := bundleTarget.Signature; signature != nil {
+				usedKeys[signatureKey(signature, configMap.Key)] = struct{}{}
+			}
+		}
+		if secret != nil {
+			usedKeys[secret.Key] = struct{}{}
+			for _, additionalKey := range secret.AdditionalKeys {
+				usedKeys[additionalKey] = struct{}{}
+			}
+			if signature := bundleTarget.Signature; signature != nil {
+				usedKeys[signatureKey(signature, secret.Key)] = struct{}{}
+			}
+		}
+		if bundleTarget.AdditionalFormatsTarget == nil {
+			for _, formats := range []*trustapi.AdditionalFormats{bundleTarget.ConfigMapFormats(), bundleTarget.SecretFormats()} {
+				if formats == nil {
+					continue
+				}
+				if formats.JKS != nil {
+					usedKeys[formats.JKS.Key] = struct{}{}
+				}
+				if formats.PKCS12 != nil {
+					usedKeys[formats.PKCS12.Key] = struct{}{}
+				}
+				if formats.SPIFFE != nil {
+					usedKeys[formats.SPIFFE.Key] = struct{}{}
+				}
+				if formats.Certdata != nil {
+					usedKeys[formats.Certdata.Key] = struct{}{}
 				}
-				targetKeys[selector.Key] = struct{}{}
 			}
 		}
+		if _, ok := usedKeys[key]; ok {
+			el = append(el, field.Invalid(path, key, "manifest key must be unique in target"))
+		}
 	}
 
-	errs := validation.ValidateLabelSelector(bundle.Spec.Target.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("target", "namespaceSelector"))
+	if len(bundleTarget.UsageKeys) > 0 {
+		usedKeys := map[string]struct{}{}
+		if configMap != nil {
+			usedKeys[configMap.Key] = struct{}{}
+			for _, additionalKey := range configMap.AdditionalKeys {
+				usedKeys[additionalKey] = struct{}{}
+			}
+			if signature := bundleTarget.Signature; signature != nil {
+				usedKeys[signatureKey(signature, configMap.Key)] = struct{}{}
+			}
+		}
+		if secret != nil {
+			usedKeys[secret.Key] = struct{}{}
+			for _, additionalKey := range secret.AdditionalKeys {
+				usedKeys[additionalKey] = struct{}{}
+			}
+			if signature := bundleTarget.Signature; signature != nil {
+				usedKeys[signatureKey(signature, secret.Key)] = struct{}{}
+			}
+		}
+		if manifest := bundleTarget.Manifest; manifest != nil {
+			if manifest.Key != "" {
+				usedKeys[manifest.Key] = struct{}{}
+			} else {
+				usedKeys[trustapi.DefaultManifestKey] = struct{}{}
+			}
+		}
+		if bundleTarget.AdditionalFormatsTarget == nil {
+			for _, formats := range []*trustapi.AdditionalFormats{bundleTarget.ConfigMapFormats(), bundleTarget.SecretFormats()} {
+				if formats == nil {
+					continue
+				}
+				if formats.JKS != nil {
+					usedKeys[formats.JKS.Key] = struct{}{}
+				}
+				if formats.PKCS12 != nil {
+					usedKeys[formats.PKCS12.Key] = struct{}{}
+				}
+				if formats.SPIFFE != nil {
+					usedKeys[formats.SPIFFE.Key] = struct{}{}
+				}
+				if formats.Certdata != nil {
+					usedKeys[formats.Certdata.Key] = struct{}{}
+				}
+			}
+		}
+
+		usages := map[trustapi.CertificateUsage]struct{}{}
+		for i, usageKey := range bundleTarget.UsageKeys {
+			path := targetPath.Child("usageKeys").Index(i)
+
+			if _, ok := usages[usageKey.Usage]; ok {
+				el = append(el, field.Duplicate(path.Child("usage"), usageKey.Usage))
+			}
+			usages[usageKey.Usage] = struct{}{}
+
+			for _, msg := range utilvalidation.IsConfigMapKey(usageKey.Key) {
+				el = append(el, field.Invalid(path.Child("key"), usageKey.Key, msg))
+			}
+			if _, ok := usedKeys[usageKey.Key]; ok {
+				el = append(el, field.Invalid(path.Child("key"), usageKey.Key, "usage key must be unique in target"))
+			}
+			usedKeys[usageKey.Key] = struct{}{}
+		}
+	}
+
+	if bundleTarget.MergeStrategy == trustapi.MergeStrategyUnion {
+		path := targetPath.Child("mergeStrategy")
+
+		if secret != nil && secret.Immutable {
+			el = append(el, field.Forbidden(path, "the Union merge strategy is not supported with immutable Secret targets"))
+		}
+
+		if bundleTarget.Signature != nil {
+			el = append(el, field.Forbidden(path, "the Union merge strategy is not supported with signatures, as merged certificates aren't signed"))
+		}
+
+		if bundleTarget.Manifest != nil {
+			el = append(el, field.Forbidden(path, "the Union merge strategy is not supported with manifests, as merged certificates aren't described"))
+		}
+
+		if len(bundleTarget.UsageKeys) > 0 {
+			el = append(el, field.Forbidden(path, "the Union merge strategy is not supported with usage keys, as merged certificates have no usages"))
+		}
+	}
+
+	errs := validation.ValidateLabelSelector(bundleTarget.NamespaceSelector, validation.LabelSelectorValidationOptions{}, targetPath.Child("namespaceSelector"))
 	el = append(el, errs...)
 
-	return warnings, el.ToAggregate()
+	el = append(el, validateKeyOverrides(bundleTarget, targetPath.Child("keyOverrides"))...)
+
+	if strategy := bundleTarget.RolloutStrategy; strategy != nil {
+		path := targetPath.Child("rolloutStrategy")
+
+		switch {
+		case strategy.Type == trustapi.RolloutStrategyProgressive && strategy.Progressive == nil:
+			el = append(el, field.Required(path.Child("progressive"), "progressive must be set for the Progressive rollout strategy"))
+		case strategy.Type != trustapi.RolloutStrategyProgressive && strategy.Progressive != nil:
+			el = append(el, field.Forbidden(path.Child("progressive"), "progressive may only be set for the Progressive rollout strategy"))
+		}
+
+		if progressive := strategy.Progressive; progressive != nil {
+			if progressive.NamespacesPerMinute < 1 {
+				el = append(el, field.Invalid(path.Child("progressive", "namespacesPerMinute"), progressive.NamespacesPerMinute, "must be at least 1"))
+			}
+			if progressive.OrderLabel != "" {
+				el = append(el, validation.ValidateLabelName(progressive.OrderLabel, path.Child("progressive", "orderLabel"))...)
+			}
+		}
+
+		if canary := strategy.Canary; canary != nil {
+			canaryPath := path.Child("canary")
+			if canary.NamespaceSelector == nil && len(canary.Namespaces) == 0 {
+				el = append(el, field.Required(canaryPath, "one of namespaceSelector or namespaces must be set"))
+			}
+			if canary.NamespaceSelector != nil {
+				el = append(el, validation.ValidateLabelSelector(canary.NamespaceSelector, validation.LabelSelectorValidationOptions{}, canaryPath.Child("namespaceSelector"))...)
+			}
+			for i, namespace := range canary.Namespaces {
+				for _, msg := range utilvalidation.IsDNS1123Label(namespace) {
+					el = append(el, field.Invalid(canaryPath.Child("namespaces").Index(i), namespace, msg))
+				}
+			}
+			if canary.SoakDuration != nil && canary.SoakDuration.Duration < 0 {
+				el = append(el, field.Invalid(canaryPath.Child("soakDuration"), canary.SoakDuration.Duration.String(), "must not be negative"))
+			}
+		}
+	}
+
+	if bundleTarget.Metadata != nil {
+		el = append(el, validateTargetMetadata(bundle, bundleTarget.Metadata, targetPath.Child("metadata"))...)
+	}
+
+	if secret != nil {
+		el = append(el, validateSecretType(bundleTarget, targetPath)...)
+	}
+
+	return el
+}
+
+// validateSPIFFEFederation validates a SPIFFE federation source: the trust
+// domain, the endpoint URL, and the fields required by the endpoint profile.
+// validateAzureKeyVault validates an AzureKeyVault source.
+func validateAzureKeyVault(source *trustapi.AzureKeyVaultSource, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if vaultURL, err := url.Parse(source.VaultURL); err != nil || vaultURL.Scheme != "https" || !strings.Contains(vaultURL.Hostname(), ".") || strings.Trim(vaultURL.Path, "/") != "" || vaultURL.RawQuery != "" || vaultURL.Fragment != "" {
+		el = append(el, field.Invalid(path.Child("vaultURL"), source.VaultURL, "must be the https URL of a key vault, such as https://<vault>.vault.azure.net"))
+	}
+
+	if !azureKeyVaultCertificateNameRegexp.MatchString(source.CertificateName) {
+		el = append(el, field.Invalid(path.Child("certificateName"), source.CertificateName, "must be a Key Vault certificate name, containing only letters, digits and dashes"))
+	}
+
+	if interval := source.RefreshInterval; interval != nil && interval.Duration <= 0 {
+		el = append(el, field.Invalid(path.Child("refreshInterval"), interval.Duration.String(), "must be positive"))
+	}
+
+	return el
+}
+
+// validateSourceNamespaceSelector validates the namespace selector of a
+// ConfigMap or Secret source, which may only be set along with a label
+// selector, and if enabled.
+func (v *validator) validateSourceNamespaceSelector(ref *trustapi.SourceObjectKeySelector, path *field.Path) field.ErrorList {
+	if ref.NamespaceSelector == nil {
+		return nil
+	}
+
+	path = path.Child("namespaceSelector")
+	var el field.ErrorList
+	if !v.sourceNamespaceSelectorsEnabled {
+		el = append(el, field.Forbidden(path, "namespace selectors on sources are not enabled; trust-manager must be started with --source-namespace-selectors-enabled"))
+	}
+	if ref.Selector == nil {
+		el = append(el, field.Forbidden(path, "namespaceSelector may only be set along with selector"))
+	}
+	el = append(el, validation.ValidateLabelSelector(ref.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path)...)
+
+	return el
+}
+
+// validatePlugin validates a Plugin source.
+func validatePlugin(source *trustapi.PluginSource, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	for _, msg := range utilvalidation.IsDNS1123Label(source.Name) {
+		el = append(el, field.Invalid(path.Child("name"), source.Name, msg))
+	}
+
+	if len(source.Parameters) > 32 {
+		el = append(el, field.TooMany(path.Child("parameters"), len(source.Parameters), 32))
+	}
+	for _, key := range slices.Sorted(maps.Keys(source.Parameters)) {
+		if key == "" {
+			el = append(el, field.Invalid(path.Child("parameters"), key, "parameter names must not be empty"))
+		}
+	}
+
+	if interval := source.RefreshInterval; interval != nil && interval.Duration <= 0 {
+		el = append(el, field.Invalid(path.Child("refreshInterval"), interval.Duration.String(), "must be positive"))
+	}
+
+	return el
+}
+
+func validateSPIFFEFederation(source *trustapi.SPIFFEFederationSource, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if !spiffeTrustDomainRegexp.MatchString(source.TrustDomain) {
+		el = append(el, field.Invalid(path.Child("trustDomain"), source.TrustDomain, "must be a valid SPIFFE trust domain name, containing only lowercase letters, digits, dots, dashes and underscores"))
+	}
+
+	if endpointURL, err := url.Parse(source.EndpointURL); err != nil || endpointURL.Scheme != "https" || endpointURL.Host == "" {
+		el = append(el, field.Invalid(path.Child("endpointURL"), source.EndpointURL, "must be an absolute https URL"))
+	}
+
+	switch source.Profile {
+	case "", trustapi.SPIFFEBundleEndpointProfileHTTPSWeb:
+		if source.EndpointSPIFFEID != "" {
+			el = append(el, field.Forbidden(path.Child("endpointSPIFFEID"), "may only be set for the https_spiffe profile"))
+		}
+		if source.BootstrapBundle != nil {
+			el = append(el, field.Forbidden(path.Child("bootstrapBundle"), "may only be set for the https_spiffe profile"))
+		}
+	case trustapi.SPIFFEBundleEndpointProfileHTTPSSPIFFE:
+		if source.EndpointSPIFFEID == "" {
+			el = append(el, field.Required(path.Child("endpointSPIFFEID"), "required for the https_spiffe profile"))
+		} else if id, err := url.Parse(source.EndpointSPIFFEID); err != nil || id.Scheme != "spiffe" || !spiffeTrustDomainRegexp.MatchString(id.Host) || id.RawQuery != "" || id.Fragment != "" {
+			el = append(el, field.Invalid(path.Child("endpointSPIFFEID"), source.EndpointSPIFFEID, "must be a valid SPIFFE ID, such as spiffe://example.org/spire/server"))
+		}
+		if source.BootstrapBundle == nil {
+			el = append(el, field.Required(path.Child("bootstrapBundle"), "required for the https_spiffe profile"))
+		}
+	default:
+		el = append(el, field.NotSupported(path.Child("profile"), source.Profile, []trustapi.SPIFFEBundleEndpointProfile{trustapi.SPIFFEBundleEndpointProfileHTTPSWeb, trustapi.SPIFFEBundleEndpointProfileHTTPSSPIFFE}))
+	}
+
+	if source.BootstrapBundle != nil {
+		if source.BootstrapBundle.Name == "" {
+			el = append(el, field.Required(path.Child("bootstrapBundle", "name"), ""))
+		}
+		if source.BootstrapBundle.Key == "" {
+			el = append(el, field.Required(path.Child("bootstrapBundle", "key"), ""))
+		}
+	}
+
+	if source.RefreshInterval != nil && source.RefreshInterval.Duration <= 0 {
+		el = append(el, field.Invalid(path.Child("refreshInterval"), source.RefreshInterval.Duration.String(), "must be positive"))
+	}
+
+	return el
+}
+
+// validateSecretType validates the type of the Secret target, and rejects
+// options which can't be written to kubernetes.io/tls Secrets: keys other
+// than ca.crt for the bundle, keys clashing with tls.crt and tls.key, and
+// keys or Secrets which differ between Namespaces.
+func validateSecretType(bundleTarget trustapi.BundleTarget, targetPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	secret := bundleTarget.Secret
+	path := targetPath.Child("secret")
+
+	switch secret.Type {
+	case "", corev1.SecretTypeOpaque:
+		return nil
+	case corev1.SecretTypeTLS:
+	default:
+		return append(el, field.NotSupported(path.Child("type"), secret.Type, []corev1.SecretType{corev1.SecretTypeOpaque, corev1.SecretTypeTLS}))
+	}
+
+	if secret.Key != trustapi.TLSSecretTargetKey {
+		el = append(el, field.Invalid(path.Child("key"), secret.Key, fmt.Sprintf("kubernetes.io/tls Secret targets must write the bundle to the %s key", trustapi.TLSSecretTargetKey)))
+	}
+
+	reservedKeys := []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}
+	for i, additionalKey := range secret.AdditionalKeys {
+		if slices.Contains(reservedKeys, additionalKey) {
+			el = append(el, field.Invalid(path.Child("additionalKeys").Index(i), additionalKey, "key is reserved in kubernetes.io/tls Secrets"))
+		}
+	}
+	if formats := bundleTarget.SecretFormats(); formats != nil && bundleTarget.AdditionalFormatsTarget == nil {
+		var formatKeys []string
+		if formats.JKS != nil {
+			formatKeys = append(formatKeys, formats.JKS.Key)
+		}
+		if formats.PKCS12 != nil {
+			formatKeys = append(formatKeys, formats.PKCS12.Key)
+		}
+		if formats.SPIFFE != nil {
+			formatKeys = append(formatKeys, formats.SPIFFE.Key)
+		}
+		if formats.Certdata != nil {
+			formatKeys = append(formatKeys, formats.Certdata.Key)
+		}
+		for _, key := range formatKeys {
+			if slices.Contains(reservedKeys, key) {
+				el = append(el, field.Invalid(targetPath.Child("additionalFormats"), key, "key is reserved in kubernetes.io/tls Secrets"))
+			}
+		}
+	}
+	if signature := bundleTarget.Signature; signature != nil && slices.Contains(reservedKeys, signatureKey(signature, secret.Key)) {
+		el = append(el, field.Invalid(targetPath.Child("signature", "key"), signature.Key, "key is reserved in kubernetes.io/tls Secrets"))
+	}
+	if manifest := bundleTarget.Manifest; manifest != nil && slices.Contains(reservedKeys, manifest.Key) {
+		el = append(el, field.Invalid(targetPath.Child("manifest", "key"), manifest.Key, "key is reserved in kubernetes.io/tls Secrets"))
+	}
+	for i, usageKey := range bundleTarget.UsageKeys {
+		if slices.Contains(reservedKeys, usageKey.Key) {
+			el = append(el, field.Invalid(targetPath.Child("usageKeys").Index(i).Child("key"), usageKey.Key, "key is reserved in kubernetes.io/tls Secrets"))
+		}
+	}
+
+	if len(bundleTarget.KeyOverrides) > 0 {
+		el = append(el, field.Forbidden(targetPath.Child("keyOverrides"), "key overrides are not supported with kubernetes.io/tls Secret targets"))
+	}
+	if bundleTarget.AdditionalFormatsTarget != nil {
+		el = append(el, field.Forbidden(targetPath.Child("additionalFormatsTarget"), "additionalFormatsTarget is not supported with kubernetes.io/tls Secret targets"))
+	}
+
+	return el
+}
+
+// validateTargetMetadata validates the labels and annotations of the target
+// metadata, found at the given path. Templates are rendered with example
+// variables, so that labels whose values can never be valid are rejected.
+func validateTargetMetadata(bundle *trustapi.Bundle, metadata *trustapi.TargetMetadata, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	vars := trustapi.TargetMetadataVariables{
+		BundleName:     bundle.Name,
+		BundleHash:     strings.Repeat("0", 64),
+		PackageVersion: "cert-manager-package-0-0000000000000000",
+		SyncTimestamp:  time.Time{}.Format(time.RFC3339),
+	}
+
+	for key, value := range metadata.Labels {
+		path := path.Child("labels").Key(key)
+
+		el = append(el, validation.ValidateLabelName(key, path)...)
+		if key == trustapi.BundleLabelKey {
+			el = append(el, field.Forbidden(path, "label is managed by trust-manager"))
+		}
+
+		rendered, err := trustapi.RenderTargetMetadataValue(value, vars)
+		if err != nil {
+			el = append(el, field.Invalid(path, value, err.Error()))
+			continue
+		}
+		for _, msg := range utilvalidation.IsValidLabelValue(rendered) {
+			el = append(el, field.Invalid(path, value, fmt.Sprintf("rendered value %q is invalid: %s", rendered, msg)))
+		}
+	}
+
+	for key, value := range metadata.Annotations {
+		path := path.Child("annotations").Key(key)
+
+		for _, msg := range utilvalidation.IsQualifiedName(strings.ToLower(key)) {
+			el = append(el, field.Invalid(path, key, msg))
+		}
+		switch key {
+		case trustapi.BundleHashAnnotationKey, trustapi.BundleMetadataHashAnnotationKey, trustapi.BundleMergedCertificatesAnnotationKey:
+			el = append(el, field.Forbidden(path, "annotation is managed by trust-manager"))
+		}
+
+		if _, err := trustapi.RenderTargetMetadataValue(value, vars); err != nil {
+			el = append(el, field.Invalid(path, value, err.Error()))
+		}
+	}
+
+	return el
+}
+
+// signatureKey returns the key the signature is written to in a target where
+// the PEM bundle is written to bundleKey.
+func signatureKey(signature *trustapi.BundleSignature, bundleKey string) string {
+	if signature.Key != "" {
+		return signature.Key
+	}
+	return bundleKey + ".sig"
+}
+
+// validateAdditionalKeys validates the additional keys of a ConfigMap or
+// Secret target, found at the given path, which must be unique in the target.
+func validateAdditionalKeys(key string, additionalKeys []string, formats *trustapi.AdditionalFormats, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	usedKeys := map[string]string{key: "target key"}
+	if formats != nil {
+		if formats.JKS != nil {
+			usedKeys[formats.JKS.Key] = "jks format"
+		}
+		if formats.PKCS12 != nil {
+			usedKeys[formats.PKCS12.Key] = "pkcs12 format"
+		}
+		if formats.SPIFFE != nil {
+			usedKeys[formats.SPIFFE.Key] = "spiffe format"
+		}
+		if formats.Certdata != nil {
+			usedKeys[formats.Certdata.Key] = "certdata format"
+		}
+	}
+
+	for i, additionalKey := range additionalKeys {
+		path := path.Index(i)
+
+		for _, msg := range utilvalidation.IsConfigMapKey(additionalKey) {
+			el = append(el, field.Invalid(path, additionalKey, msg))
+		}
+		if use, ok := usedKeys[additionalKey]; ok {
+			el = append(el, field.Invalid(path, additionalKey, fmt.Sprintf("key must be unique in target, but is also used by the %s", use)))
+			continue
+		}
+		usedKeys[additionalKey] = "additional key"
+	}
+
+	return el
+}
+
+// validateKeyOverrides validates the key overrides of the target, found at
+// the given path.
+func validateKeyOverrides(bundleTarget trustapi.BundleTarget, path *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	// Additional formats written to the target itself can't share the key.
+	formatKeys := map[string]string{}
+	if bundleTarget.AdditionalFormatsTarget == nil {
+		for _, formats := range []*trustapi.AdditionalFormats{bundleTarget.ConfigMapFormats(), bundleTarget.SecretFormats()} {
+			if formats == nil {
+				continue
+			}
+			if formats.JKS != nil {
+				formatKeys[formats.JKS.Key] = "jks"
+			}
+			if formats.PKCS12 != nil {
+				formatKeys[formats.PKCS12.Key] = "pkcs12"
+			}
+			if formats.SPIFFE != nil {
+				formatKeys[formats.SPIFFE.Key] = "spiffe"
+			}
+			if formats.Certdata != nil {
+				formatKeys[formats.Certdata.Key] = "certdata"
+			}
+		}
+	}
+
+	for i, override := range bundleTarget.KeyOverrides {
+		path := path.Index(i)
+
+		if len(override.Key) == 0 {
+			el = append(el, field.Invalid(path.Child("key"), override.Key, "key override key must be defined"))
+		} else {
+			for _, msg := range utilvalidation.IsConfigMapKey(override.Key) {
+				el = append(el, field.Invalid(path.Child("key"), override.Key, msg))
+			}
+		}
+		if format, ok := formatKeys[override.Key]; ok {
+			el = append(el, field.Invalid(path.Child("key"), override.Key, fmt.Sprintf("key must be unique in target, but is also used by the %s format", format)))
+		}
+
+		if secret := bundleTarget.Secret; secret != nil && secret.Immutable {
+			el = append(el, field.Forbidden(path, "key overrides are not supported with immutable Secret targets"))
+		}
+
+		el = append(el, validation.ValidateLabelSelector(&override.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+	}
+
+	return el
+}
+
+// bundleRequiresCA returns true if the Bundle may only contain CA certificates.
+func (v *validator) bundleRequiresCA(bundle *trustapi.Bundle) bool {
+	if bundle.Spec.RequireCABasicConstraints != nil {
+		return *bundle.Spec.RequireCABasicConstraints
+	}
+
+	return v.requireCABasicConstraints
+}
+
+// countCertificates returns the number of valid certificates in a PEM bundle.
+func countCertificates(pemData string) int {
+	certPool := util.NewCertPool()
+	if err := certPool.AddCertsFromPEM([]byte(pemData)); err != nil {
+		return 0
+	}
+	return certPool.Size()
+}
+
+// validateInLineCACertificates rejects certificates in an InLine source which
+// aren't CA certificates. Invalid PEM data is reported by validateInLinePEM,
+// and skipped if skipInvalid is true.
+func validateInLineCACertificates(inLine string, path *field.Path, skipInvalid bool) field.ErrorList {
+	certPool := util.NewCertPool(util.WithSkippedInvalidBlocks(skipInvalid))
+	if err := certPool.AddCertsFromPEM([]byte(inLine)); err != nil {
+		return nil
+	}
+
+	var el field.ErrorList
+	for cert := range certPool.All() {
+		if !cert.IsCA {
+			el = append(el, field.Invalid(path, cert.Subject, "certificate is not a CA certificate; only CA certificates are permitted when requireCABasicConstraints is enabled"))
+		}
+	}
+
+	return el
+}
+
+// sourceKeyMatches returns true if the source key, which may be a glob
+// pattern, selects the given key.
+func sourceKeyMatches(sourceKey, key string) bool {
+	if sourceKey == key {
+		return true
+	}
 
+	matched, _ := gopath.Match(sourceKey, key)
+	return matched
 }
@@ -17,9 +17,13 @@ limitations under the License.
 package webhook
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,11 +33,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	"github.com/cert-manager/trust-manager/test/dummy"
 )
 
 func Test_validate(t *testing.T) {
 	tests := map[string]struct {
-		bundle      runtime.Object
+		bundle             runtime.Object
+		requireCA          bool
+		signingEnabled     bool
+		maxBundleSizeBytes int
+		maxCertificates    int
+
+		sourceNamespaceSelectorsEnabled bool
+		certificateSourcesEnabled       bool
+
 		expErr      *string
 		expWarnings admission.Warnings
 	}{
@@ -56,15 +69,15 @@ func Test_validate(t *testing.T) {
 					Sources: []trustapi.BundleSource{
 						{
 							ConfigMap: &trustapi.SourceObjectKeySelector{Name: "test", Key: "test"},
-							InLine:    ptr.To("test"),
+							InLine:    ptr.To(dummy.TestCertificate1),
 						},
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 						{
 							ConfigMap: &trustapi.SourceObjectKeySelector{Name: "test", Key: "test"},
 							Secret:    &trustapi.SourceObjectKeySelector{Name: "test", Key: "test"},
 						},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -78,7 +91,7 @@ func Test_validate(t *testing.T) {
 					Sources: []trustapi.BundleSource{
 						{},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -94,7 +107,7 @@ func Test_validate(t *testing.T) {
 							UseDefaultCAs: ptr.To(false),
 						},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -112,7 +125,7 @@ func Test_validate(t *testing.T) {
 							UseDefaultCAs: ptr.To(true),
 						},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -133,22 +146,97 @@ func Test_validate(t *testing.T) {
 							UseDefaultCAs: ptr.To(true),
 						},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
 				field.Forbidden(field.NewPath("spec", "sources"), "must request default CAs either once or not at all but got 3 requests"),
 			}.ToAggregate().Error()),
 		},
+		"useInClusterCA requested twice": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{
+							UseInClusterCA: ptr.To(true),
+						},
+						{
+							UseInClusterCA: ptr.To(true),
+						},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "sources"), "must request the in-cluster CA either once or not at all but got 2 requests"),
+			}.ToAggregate().Error()),
+		},
+		"inLine sources exceeding the maximum bundle size and certificate count": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+						{InLine: ptr.To(dummy.TestCertificate2)},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+				},
+			},
+			maxBundleSizeBytes: 100,
+			maxCertificates:    1,
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "sources"), fmt.Sprintf("inLine sources are %d bytes, which exceeds the maximum bundle size of 100 bytes", len(dummy.TestCertificate1)+len(dummy.TestCertificate2))),
+				field.Forbidden(field.NewPath("spec", "sources"), "inLine sources hold 2 certificates, which exceeds the maximum of 1"),
+			}.ToAggregate().Error()),
+		},
+		"inLine sources within the maximum bundle size and certificate count": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+				},
+			},
+			maxBundleSizeBytes: len(dummy.TestCertificate1),
+			maxCertificates:    1,
+		},
+		"inLine source holding only expired certificates": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+						{InLine: ptr.To(dummy.TestExpiredCertificate)},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[1]", "inLine"), field.OmitValueType{}, "all certificates are expired"),
+			}.ToAggregate().Error()),
+		},
+		"inLine source holding a malformed PEM block, skipped": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1 + "\n-----BEGIN CERTIFICATE-----\n!!!!\n-----END CERTIFICATE-----")},
+					},
+					Target:  trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+					Filters: &trustapi.BundleFilters{Invalid: trustapi.InvalidCertificatePolicySkip},
+				},
+			},
+			expWarnings: admission.Warnings{
+				fmt.Sprintf("spec.sources.[0].inLine: line %d: malformed PEM block", strings.Count(dummy.TestCertificate1, "\n")+2),
+			},
+		},
 		"sources names, selectors and keys are empty": {
 			bundle: &trustapi.Bundle{
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
 						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "", Key: ""}},
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 						{Secret: &trustapi.SourceObjectKeySelector{Name: "", Key: ""}},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -163,10 +251,10 @@ func Test_validate(t *testing.T) {
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
 						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", Selector: &metav1.LabelSelector{}, Key: "test"}},
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 						{Secret: &trustapi.SourceObjectKeySelector{Name: "some-secret", Selector: &metav1.LabelSelector{}, Key: "test"}},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -179,10 +267,10 @@ func Test_validate(t *testing.T) {
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
 						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", Key: "test", IncludeAllKeys: true}},
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 						{Secret: &trustapi.SourceObjectKeySelector{Name: "some-secret", Key: "test", IncludeAllKeys: true}},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -195,25 +283,53 @@ func Test_validate(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "test-bundle", Key: "test"}},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
 				field.Forbidden(field.NewPath("spec", "sources", "[1]", "configMap", "test-bundle", "test"), "cannot define the same source as target"),
 			}.ToAggregate().Error()),
 		},
+		"sources defines a configMap key pattern which matches the target": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "test-bundle", Key: "*.pem"}},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "sources", "[1]", "configMap", "test-bundle", "*.pem"), "cannot define the same source as target"),
+			}.ToAggregate().Error()),
+		},
+		"sources defines an invalid configMap key pattern": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "test", Key: "[ca"}},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "configMap", "key"), "[ca", "source configMap key must be a valid glob pattern"),
+			}.ToAggregate().Error()),
+		},
 		"sources defines the same secret target": {
 			bundle: &trustapi.Bundle{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 						{Secret: &trustapi.SourceObjectKeySelector{Name: "test-bundle", Key: "test"}},
 					},
-					Target: trustapi.BundleTarget{Secret: &trustapi.KeySelector{Key: "test"}},
+					Target: trustapi.BundleTarget{Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -224,9 +340,9 @@ func Test_validate(t *testing.T) {
 			bundle: &trustapi.Bundle{
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
-					Target: trustapi.BundleTarget{ConfigMap: &trustapi.KeySelector{Key: ""}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: ""}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -237,9 +353,9 @@ func Test_validate(t *testing.T) {
 			bundle: &trustapi.Bundle{
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("test")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
-					Target: trustapi.BundleTarget{Secret: &trustapi.KeySelector{Key: ""}},
+					Target: trustapi.BundleTarget{Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: ""}}},
 				},
 			},
 			expErr: ptr.To(field.ErrorList{
@@ -251,10 +367,10 @@ func Test_validate(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle-1"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("test-1")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
-						ConfigMap: &trustapi.KeySelector{Key: "test-1"},
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test-1"}},
 						NamespaceSelector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{"@@@@": ""},
 						},
@@ -278,7 +394,7 @@ func Test_validate(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("foo")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
 						AdditionalFormats: &trustapi.AdditionalFormats{
@@ -288,23 +404,23 @@ func Test_validate(t *testing.T) {
 								},
 							},
 						},
-						ConfigMap: &trustapi.KeySelector{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
 							Key: "bar",
-						},
+						}},
 						NamespaceSelector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{"foo": "bar"},
 						},
 					},
 				},
 			},
-			expErr: ptr.To("spec.target.additionalFormats.jks.key: Invalid value: \"bar\": key must be unique in target configMap"),
+			expErr: ptr.To("spec.target.additionalFormats.jks.key: Invalid value: \"bar\": key must be unique in target, but is also used by the target configMap key"),
 		},
 		"a Bundle with a duplicate target PKCS12 key should fail validation and return a denied response": {
 			bundle: &trustapi.Bundle{
 				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("foo")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
 						AdditionalFormats: &trustapi.AdditionalFormats{
@@ -314,170 +430,1159 @@ func Test_validate(t *testing.T) {
 								},
 							},
 						},
-						ConfigMap: &trustapi.KeySelector{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
 							Key: "bar",
-						},
+						}},
 						NamespaceSelector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{"foo": "bar"},
 						},
 					},
 				},
 			},
-			expErr: ptr.To("spec.target.additionalFormats.pkcs12.key: Invalid value: \"bar\": key must be unique in target configMap"),
+			expErr: ptr.To("spec.target.additionalFormats.pkcs12.key: Invalid value: \"bar\": key must be unique in target, but is also used by the target configMap key"),
 		},
-		"valid Bundle": {
+		"a Bundle with JKS and PKCS12 formats sharing a key should fail validation and return a denied response": {
 			bundle: &trustapi.Bundle{
-				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle-1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("test-1")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
-						ConfigMap: &trustapi.KeySelector{Key: "test-1"},
-						NamespaceSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{"foo": "bar"},
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							JKS:    &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "truststore"}},
+							PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: "truststore"}},
 						},
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
 					},
 				},
-				Status: trustapi.BundleStatus{
-					Conditions: []trustapi.BundleCondition{
-						{
-							Type:   "A",
-							Reason: "B",
+			},
+			expErr: ptr.To("spec.target.additionalFormats.pkcs12.key: Invalid value: \"truststore\": key must be unique in target, but is also used by the jks format"),
+		},
+		"a Bundle with key overrides should pass validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						KeyOverrides: []trustapi.KeyOverride{{
+							NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"istio-injection": "enabled"}},
+							Key:               "ca.crt",
+						}},
+					},
+				},
+			},
+		},
+		"a Bundle with a key override colliding with an additional format should fail validation and return a denied response": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: "truststore"}},
 						},
-						{
-							Type:   "B",
-							Reason: "C",
+						KeyOverrides: []trustapi.KeyOverride{
+							{Key: "ca.crt"},
+							{Key: "truststore"},
 						},
 					},
 				},
 			},
-			expErr: nil,
+			expErr: ptr.To("spec.target.keyOverrides[1].key: Invalid value: \"truststore\": key must be unique in target, but is also used by the pkcs12 format"),
 		},
-		"valid Bundle with JKS": {
+		"a Bundle with additional target keys should pass validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}, AdditionalKeys: []string{"root-cert.pem"}},
+					},
+				},
+			},
+		},
+		"a Bundle with an additional target key colliding with another key should fail validation and return a denied response": {
 			bundle: &trustapi.Bundle{
 				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("foo")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}, AdditionalKeys: []string{"ca.crt", "truststore.jks"}},
 						AdditionalFormats: &trustapi.AdditionalFormats{
-							JKS: &trustapi.JKS{
-								KeySelector: trustapi.KeySelector{
-									Key: "bar.jks",
-								},
-							},
+							JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "truststore.jks"}},
 						},
-						ConfigMap: &trustapi.KeySelector{
-							Key: "bar",
+					},
+				},
+			},
+			expErr: ptr.To("spec.target.configMap.additionalKeys[1]: Invalid value: \"truststore.jks\": key must be unique in target, but is also used by the jks format"),
+		},
+		"a Bundle with a progressive rollout strategy should pass validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						RolloutStrategy: &trustapi.RolloutStrategy{
+							Type:        trustapi.RolloutStrategyProgressive,
+							Progressive: &trustapi.ProgressiveRollout{NamespacesPerMinute: 10, OrderLabel: "example.com/rollout-wave"},
 						},
-						NamespaceSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{"foo": "bar"},
+					},
+				},
+			},
+		},
+		"a Bundle with a progressive rollout strategy without its configuration should fail validation and return a denied response": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap:       &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						RolloutStrategy: &trustapi.RolloutStrategy{Type: trustapi.RolloutStrategyProgressive},
+					},
+				},
+			},
+			expErr: ptr.To("spec.target.rolloutStrategy.progressive: Required value: progressive must be set for the Progressive rollout strategy"),
+		},
+		"a Bundle with canary Namespaces without a selector or names should fail validation and return a denied response": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						RolloutStrategy: &trustapi.RolloutStrategy{
+							Type:   trustapi.RolloutStrategyImmediate,
+							Canary: &trustapi.CanaryRollout{SoakDuration: &metav1.Duration{Duration: time.Hour}},
 						},
 					},
 				},
 			},
-			expErr: nil,
+			expErr: ptr.To("spec.target.rolloutStrategy.canary: Required value: one of namespaceSelector or namespaces must be set"),
 		},
-		"valid Bundle with PKCS12": {
+		"a Bundle rolled back to a snapshot which isn't a bundle hash should fail validation and return a denied response": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+					History:    &trustapi.BundleHistory{Limit: 5},
+					RollbackTo: "previous",
+				},
+			},
+			expErr: ptr.To(`spec.rollbackTo: Invalid value: "previous": must be the SHA-256 hash of a snapshot in the history of the Bundle`),
+		},
+		"a Bundle with templated target metadata should pass validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Metadata: &trustapi.TargetMetadata{
+							Labels:      map[string]string{"bundle": "{{ .BundleName }}"},
+							Annotations: map[string]string{"example.com/hash": "{{ .BundleHash }}", "example.com/synced": "{{ .SyncTimestamp }}"},
+						},
+					},
+				},
+			},
+		},
+		"a Bundle with a target label which can never be valid should fail validation and return a denied response": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Metadata: &trustapi.TargetMetadata{
+							Labels: map[string]string{"synced": "{{ .SyncTimestamp }}"},
+						},
+					},
+				},
+			},
+			expErr: ptr.To("spec.target.metadata.labels[synced]: Invalid value: \"{{ .SyncTimestamp }}\": rendered value \"0001-01-01T00:00:00Z\" is invalid: a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
+		},
+		"a Bundle with target metadata using an unknown variable or a managed key should fail validation and return a denied response": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Metadata: &trustapi.TargetMetadata{
+							Annotations: map[string]string{trustapi.BundleHashAnnotationKey: "{{ .Hash }}"},
+						},
+					},
+				},
+			},
+			expErr: ptr.To("[spec.target.metadata.annotations[trust.cert-manager.io/hash]: Forbidden: annotation is managed by trust-manager, spec.target.metadata.annotations[trust.cert-manager.io/hash]: Invalid value: \"{{ .Hash }}\": template: :1:3: executing \"\" at <.Hash>: can't evaluate field Hash in type v1alpha1.TargetMetadataVariables]"),
+		},
+		"a Bundle with an invalid SPIFFE trust domain should fail validation and return a denied response": {
 			bundle: &trustapi.Bundle{
 				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{InLine: ptr.To("foo")},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
 						AdditionalFormats: &trustapi.AdditionalFormats{
-							PKCS12: &trustapi.PKCS12{
+							SPIFFE: &trustapi.SPIFFE{
 								KeySelector: trustapi.KeySelector{
-									Key: "bar.p12",
+									Key: "bundle.spiffe",
 								},
+								TrustDomain: "Example.org",
 							},
 						},
-						ConfigMap: &trustapi.KeySelector{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
 							Key: "bar",
-						},
-						NamespaceSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{"foo": "bar"},
-						},
+						}},
 					},
 				},
 			},
-			expErr: nil,
+			expErr: ptr.To("spec.target.additionalFormats.spiffe.trustDomain: Invalid value: \"Example.org\": trust domain must only contain lowercase letters, numbers, dots, dashes and underscores"),
 		},
-		"valid Bundle including all keys": {
+		"a Bundle with an additional formats target named after the Bundle should fail validation": {
 			bundle: &trustapi.Bundle{
-				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle-1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
 					Sources: []trustapi.BundleSource{
-						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", IncludeAllKeys: true}},
-						{Secret: &trustapi.SourceObjectKeySelector{Name: "some-secret", IncludeAllKeys: true}},
+						{InLine: ptr.To(dummy.TestCertificate1)},
 					},
 					Target: trustapi.BundleTarget{
-						ConfigMap: &trustapi.KeySelector{Key: "test-1"},
-						NamespaceSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{"foo": "bar"},
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "bundle.jks"}},
 						},
+						AdditionalFormatsTarget: &trustapi.AdditionalFormatsTarget{Name: "testing"},
+						ConfigMap:               &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}},
 					},
 				},
 			},
-			expErr: nil,
+			expErr: ptr.To("spec.target.additionalFormatsTarget.name: Invalid value: \"testing\": name must differ from the Bundle name"),
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			log, _ := ktesting.NewTestContext(t)
-			v := &validator{log: log}
-			gotWarnings, gotErr := v.validate(test.bundle)
-			if test.expErr == nil && gotErr != nil {
-				t.Errorf("got an unexpected error: %v", gotErr)
-			} else if test.expErr != nil && (gotErr == nil || *test.expErr != gotErr.Error()) {
-				t.Errorf("wants error: %v got: %v", *test.expErr, gotErr)
-			}
-			assert.Equal(t, test.expWarnings, gotWarnings)
-
-		})
-	}
-}
-
-func Test_validate_update(t *testing.T) {
-	tests := map[string]struct {
-		oldBundle   runtime.Object
-		newBundle   runtime.Object
-		expErr      *string
-		expWarnings admission.Warnings
-	}{
-		"if the target configmap is removed during update": {
-			oldBundle: &trustapi.Bundle{
+		"a Bundle with an additional formats target but no additional formats should fail validation": {
+			bundle: &trustapi.Bundle{
 				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
 					Target: trustapi.BundleTarget{
-						ConfigMap: &trustapi.KeySelector{
-							Key: "bar",
-						},
+						AdditionalFormatsTarget: &trustapi.AdditionalFormatsTarget{Name: "testing-formats"},
+						ConfigMap:               &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}},
 					},
 				},
 			},
-			newBundle: &trustapi.Bundle{},
-			expErr:    ptr.To("spec.target.configmap: Invalid value: \"\": target configMap removal is not allowed"),
+			expErr: ptr.To("spec.target.additionalFormatsTarget: Invalid value: \"testing-formats\": additionalFormats must be defined when additionalFormatsTarget is set"),
 		},
-		"if the target secret is removed during update": {
-			oldBundle: &trustapi.Bundle{
+		"a Bundle with additional formats overridden for its Secret target should pass validation": {
+			bundle: &trustapi.Bundle{
 				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
 				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
 					Target: trustapi.BundleTarget{
-						Secret: &trustapi.KeySelector{
-							Key: "bar",
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Secret: &trustapi.SecretTarget{
+							KeySelector: trustapi.KeySelector{Key: "ca.crt"},
+							AdditionalFormats: &trustapi.AdditionalFormats{
+								JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.pem"}, Password: ptr.To(trustapi.DefaultJKSPassword)},
+							},
 						},
 					},
 				},
 			},
-			newBundle: &trustapi.Bundle{},
-			expErr:    ptr.To("spec.target.secret: Invalid value: \"\": target secret removal is not allowed"),
 		},
-	}
+		"a Bundle with overridden additional formats reusing the target key should fail validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						Secret: &trustapi.SecretTarget{
+							KeySelector: trustapi.KeySelector{Key: "ca.crt"},
+							AdditionalFormats: &trustapi.AdditionalFormats{
+								JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "ca.crt"}, Password: ptr.To(trustapi.DefaultJKSPassword)},
+							},
+						},
+					},
+				},
+			},
+			expErr: ptr.To("spec.target.secret.additionalFormats.jks.key: Invalid value: \"ca.crt\": key must be unique in target, but is also used by the target secret key"),
+		},
+		"a Bundle with a ConfigMap and a Secret in separate targets should pass validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+						{
+							Secret:            &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ingress": "true"}},
+						},
+					},
+				},
+			},
+		},
+		"a Bundle with an invalid target in targets should fail validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+					Targets: []trustapi.BundleTarget{
+						{Secret: &trustapi.SecretTarget{}},
+					},
+				},
+			},
+			expErr: ptr.To("spec.targets[0].secret.key: Invalid value: \"\": target secret key must be defined"),
+		},
+		"a Bundle defining a kind of target twice should fail validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}},
+					},
+				},
+			},
+			expErr: ptr.To("spec.targets: Forbidden: configMap targets may only be defined once across target and targets"),
+		},
+		"a Bundle with the Union merge strategy and an immutable Secret target should fail validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						Secret:        &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "bar"}, Immutable: true},
+						MergeStrategy: trustapi.MergeStrategyUnion,
+					},
+				},
+			},
+			expErr: ptr.To("spec.target.mergeStrategy: Forbidden: the Union merge strategy is not supported with immutable Secret targets"),
+		},
+		"a Bundle with an additional formats target and an immutable Secret target should fail validation": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "bundle.jks"}},
+						},
+						AdditionalFormatsTarget: &trustapi.AdditionalFormatsTarget{Name: "testing-formats"},
+						Secret:                  &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "bar"}, Immutable: true},
+					},
+				},
+			},
+			expErr: ptr.To("spec.target.additionalFormatsTarget: Forbidden: additionalFormatsTarget is not supported with immutable Secret targets"),
+		},
+		"valid Bundle": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle-1"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test-1"}},
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+				Status: trustapi.BundleStatus{
+					Conditions: []trustapi.BundleCondition{
+						{
+							Type:   "A",
+							Reason: "B",
+						},
+						{
+							Type:   "B",
+							Reason: "C",
+						},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"valid Bundle with JKS": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							JKS: &trustapi.JKS{
+								KeySelector: trustapi.KeySelector{
+									Key: "bar.jks",
+								},
+							},
+						},
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
+							Key: "bar",
+						}},
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"valid Bundle with PKCS12": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							PKCS12: &trustapi.PKCS12{
+								KeySelector: trustapi.KeySelector{
+									Key: "bar.p12",
+								},
+							},
+						},
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
+							Key: "bar",
+						}},
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"if CA certificates are required, an InLine source containing a leaf certificate should fail validation": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestLeafCertificate))},
+					},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+				},
+			},
+			requireCA: true,
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "inLine"), "CN=cmct-test-leaf,O=cert-manager", "certificate is not a CA certificate; only CA certificates are permitted when requireCABasicConstraints is enabled"),
+			}.ToAggregate().Error()),
+		},
+		"if the Bundle opts out of requiring CA certificates, an InLine source containing a leaf certificate should pass validation": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.JoinCerts(dummy.TestCertificate1, dummy.TestLeafCertificate))},
+					},
+					Target:                    trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+					RequireCABasicConstraints: ptr.To(false),
+				},
+			},
+			requireCA: true,
+		},
+		"if the Bundle requires CA certificates, an InLine source containing a leaf certificate should fail validation": {
+			bundle: &trustapi.Bundle{
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestLeafCertificate)},
+					},
+					Target:                    trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test"}}},
+					RequireCABasicConstraints: ptr.To(true),
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "inLine"), "CN=cmct-test-leaf,O=cert-manager", "certificate is not a CA certificate; only CA certificates are permitted when requireCABasicConstraints is enabled"),
+			}.ToAggregate().Error()),
+		},
+		"valid Bundle with SPIFFE": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							SPIFFE: &trustapi.SPIFFE{
+								KeySelector: trustapi.KeySelector{
+									Key: "bundle.spiffe",
+								},
+								TrustDomain: "example.org",
+							},
+						},
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
+							Key: "bar",
+						}},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"certdata format colliding with SPIFFE format": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							SPIFFE: &trustapi.SPIFFE{
+								KeySelector: trustapi.KeySelector{
+									Key: "bundle.out",
+								},
+								TrustDomain: "example.org",
+							},
+							Certdata: &trustapi.Certdata{
+								KeySelector: trustapi.KeySelector{
+									Key: "bundle.out",
+								},
+							},
+						},
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
+							Key: "bar",
+						}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "additionalFormats", "certdata", "key"), "bundle.out", "key must be unique in target, but is also used by the spiffe format"),
+			}.ToAggregate().Error()),
+		},
+		"issuerRef source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{IssuerRef: &trustapi.IssuerReference{Name: "ca-issuer"}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"spiffeFederation source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{SPIFFEFederation: &trustapi.SPIFFEFederationSource{
+						TrustDomain:      "example.org",
+						EndpointURL:      "https://spire.example.org/bundle",
+						Profile:          trustapi.SPIFFEBundleEndpointProfileHTTPSSPIFFE,
+						EndpointSPIFFEID: "spiffe://example.org/spire/server",
+						BootstrapBundle:  &trustapi.ConfigMapKeyReference{Name: "example-org", Key: "bundle.pem"},
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"awsPrivateCA source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{AWSPrivateCA: &trustapi.AWSPrivateCASource{
+						ARN: "arn:aws:acm-pca:us-east-1:111122223333:certificate-authority/11223344-1234-1122-2233-112233445566",
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"invalid awsPrivateCA source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{AWSPrivateCA: &trustapi.AWSPrivateCASource{
+						ARN:             "arn:aws:acm:us-east-1:111122223333:certificate/abc",
+						RefreshInterval: &metav1.Duration{},
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "awsPrivateCA", "arn"), "arn:aws:acm:us-east-1:111122223333:certificate/abc", "must be the ARN of an AWS Private CA, such as arn:aws:acm-pca:<region>:<account>:certificate-authority/<id>"),
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "awsPrivateCA", "refreshInterval"), "0s", "must be positive"),
+			}.ToAggregate().Error()),
+		},
+		"gcpCASPool and azureKeyVault sources": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{GCPCASPool: &trustapi.GCPCASPoolSource{Pool: "projects/my-project/locations/us-central1/caPools/my-pool"}},
+						{AzureKeyVault: &trustapi.AzureKeyVaultSource{VaultURL: "https://my-vault.vault.azure.net/", CertificateName: "my-ca"}},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"invalid gcpCASPool and azureKeyVault sources": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{GCPCASPool: &trustapi.GCPCASPoolSource{Pool: "projects/my-project/caPools/my-pool", RefreshInterval: &metav1.Duration{}}},
+						{AzureKeyVault: &trustapi.AzureKeyVaultSource{VaultURL: "https://my-vault.vault.azure.net/certificates", CertificateName: "my_ca"}},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "gcpCASPool", "pool"), "projects/my-project/caPools/my-pool", "must be the resource name of a CA pool, such as projects/<project>/locations/<location>/caPools/<pool>"),
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "gcpCASPool", "refreshInterval"), "0s", "must be positive"),
+				field.Invalid(field.NewPath("spec", "sources", "[1]", "azureKeyVault", "vaultURL"), "https://my-vault.vault.azure.net/certificates", "must be the https URL of a key vault, such as https://<vault>.vault.azure.net"),
+				field.Invalid(field.NewPath("spec", "sources", "[1]", "azureKeyVault", "certificateName"), "my_ca", "must be a Key Vault certificate name, containing only letters, digits and dashes"),
+			}.ToAggregate().Error()),
+		},
+		"plugin source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{Plugin: &trustapi.PluginSource{
+						Name:       "inventory",
+						Parameters: map[string]string{"ca": "issuing-ca"},
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"invalid plugin source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{Plugin: &trustapi.PluginSource{
+						Name:            "../inventory",
+						Parameters:      map[string]string{"": "issuing-ca"},
+						RefreshInterval: &metav1.Duration{},
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "plugin", "name"), "../inventory", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "plugin", "parameters"), "", "parameter names must not be empty"),
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "plugin", "refreshInterval"), "0s", "must be positive"),
+			}.ToAggregate().Error()),
+		},
+		"invalid spiffeFederation source": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{SPIFFEFederation: &trustapi.SPIFFEFederationSource{
+						TrustDomain:      "Example.org",
+						EndpointURL:      "http://spire.example.org/bundle",
+						EndpointSPIFFEID: "spiffe://example.org/spire/server",
+						RefreshInterval:  &metav1.Duration{},
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "spiffeFederation", "trustDomain"), "Example.org", "must be a valid SPIFFE trust domain name, containing only lowercase letters, digits, dots, dashes and underscores"),
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "spiffeFederation", "endpointURL"), "http://spire.example.org/bundle", "must be an absolute https URL"),
+				field.Forbidden(field.NewPath("spec", "sources", "[0]", "spiffeFederation", "endpointSPIFFEID"), "may only be set for the https_spiffe profile"),
+				field.Invalid(field.NewPath("spec", "sources", "[0]", "spiffeFederation", "refreshInterval"), "0s", "must be positive"),
+			}.ToAggregate().Error()),
+		},
+		"https_spiffe spiffeFederation source without endpoint": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{SPIFFEFederation: &trustapi.SPIFFEFederationSource{
+						TrustDomain: "example.org",
+						EndpointURL: "https://spire.example.org/bundle",
+						Profile:     trustapi.SPIFFEBundleEndpointProfileHTTPSSPIFFE,
+					}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Required(field.NewPath("spec", "sources", "[0]", "spiffeFederation", "endpointSPIFFEID"), "required for the https_spiffe profile"),
+				field.Required(field.NewPath("spec", "sources", "[0]", "spiffeFederation", "bootstrapBundle"), "required for the https_spiffe profile"),
+			}.ToAggregate().Error()),
+		},
+		"negative refreshInterval": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+					},
+					RefreshInterval: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "refreshInterval"), "-1m0s", "refresh interval must not be negative"),
+			}.ToAggregate().Error()),
+		},
+		"zero keyTransition duration": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap:     &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						KeyTransition: &trustapi.KeyTransition{Duration: &metav1.Duration{}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "keyTransition", "duration"), "0s", "must be positive"),
+			}.ToAggregate().Error()),
+		},
+		"signature with signing enabled": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Signature: &trustapi.BundleSignature{},
+					},
+				},
+			},
+			signingEnabled: true,
+			expErr:         nil,
+		},
+		"signature with signing disabled": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Signature: &trustapi.BundleSignature{},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "target", "signature"), "signatures are not enabled; trust-manager must be started with a signing key"),
+			}.ToAggregate().Error()),
+		},
+		"Certificates source with the integration enabled": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{Certificates: &trustapi.CertificateSource{
+						Selector: metav1.LabelSelector{MatchLabels: map[string]string{"trust": "ca"}},
+					}}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+				},
+			},
+			certificateSourcesEnabled: true,
+			expErr:                    nil,
+		},
+		"Certificates source with the integration disabled": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{Certificates: &trustapi.CertificateSource{
+						Selector: metav1.LabelSelector{MatchLabels: map[string]string{"trust": "ca"}},
+					}}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "sources", "[0]", "certificates"), "cert-manager Certificate sources are not enabled; trust-manager must be started with --cert-manager-certificate-integration"),
+			}.ToAggregate().Error()),
+		},
+		"source namespace selector with the feature enabled": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{Secret: &trustapi.SourceObjectKeySelector{
+						Key:               "ca.crt",
+						Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "ca"}},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust-sources": "true"}},
+					}}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+				},
+			},
+			sourceNamespaceSelectorsEnabled: true,
+			expErr:                          nil,
+		},
+		"source namespace selector with the feature disabled, and without a selector": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{ConfigMap: &trustapi.SourceObjectKeySelector{
+						Name:              "ca",
+						Key:               "ca.crt",
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust-sources": "true"}},
+					}}},
+					Target: trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}}},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "sources", "[0]", "configMap", "namespaceSelector"), "namespace selectors on sources are not enabled; trust-manager must be started with --source-namespace-selectors-enabled"),
+				field.Forbidden(field.NewPath("spec", "sources", "[0]", "configMap", "namespaceSelector"), "namespaceSelector may only be set along with selector"),
+			}.ToAggregate().Error()),
+		},
+		"signature key which clashes with an additional format key": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						AdditionalFormats: &trustapi.AdditionalFormats{
+							JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "trust.pem.sig"}},
+						},
+						Signature: &trustapi.BundleSignature{},
+					},
+				},
+			},
+			signingEnabled: true,
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "signature", "key"), "trust.pem.sig", "signature key must be unique in target"),
+			}.ToAggregate().Error()),
+		},
+		"signature key which is invalid": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Signature: &trustapi.BundleSignature{Key: "trust/sig"},
+					},
+				},
+			},
+			signingEnabled: true,
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "signature", "key"), "trust/sig", "a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')"),
+			}.ToAggregate().Error()),
+		},
+		"manifest key which clashes with the target key": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Manifest:  &trustapi.BundleManifest{Key: "trust.pem"},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "manifest", "key"), "trust.pem", "manifest key must be unique in target"),
+			}.ToAggregate().Error()),
+		},
+		"usage key which clashes with the manifest key": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1), Usages: []trustapi.CertificateUsage{trustapi.CertificateUsageServerAuth}}},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Manifest:  &trustapi.BundleManifest{},
+						UsageKeys: []trustapi.UsageKey{
+							{Usage: trustapi.CertificateUsageServerAuth, Key: "server-roots.pem"},
+							{Usage: trustapi.CertificateUsageClientAuth, Key: trustapi.DefaultManifestKey},
+						},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "usageKeys").Index(1).Child("key"), trustapi.DefaultManifestKey, "usage key must be unique in target"),
+			}.ToAggregate().Error()),
+		},
+		"manifest with the Union merge strategy": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+					Target: trustapi.BundleTarget{
+						ConfigMap:     &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+						Manifest:      &trustapi.BundleManifest{},
+						MergeStrategy: trustapi.MergeStrategyUnion,
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "target", "mergeStrategy"), "the Union merge strategy is not supported with manifests, as merged certificates aren't described"),
+			}.ToAggregate().Error()),
+		},
+		"a Bundle with a kubernetes.io/tls Secret target": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", Key: "ca.crt"}}},
+					Target: trustapi.BundleTarget{
+						Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}, Type: corev1.SecretTypeTLS},
+					},
+				},
+			},
+			expErr: nil,
+		},
+		"a Bundle with a Secret target of an unsupported type": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", Key: "ca.crt"}}},
+					Target: trustapi.BundleTarget{
+						Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}, Type: corev1.SecretTypeDockerConfigJson},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "target", "secret", "type"), corev1.SecretTypeDockerConfigJson, []corev1.SecretType{corev1.SecretTypeOpaque, corev1.SecretTypeTLS}),
+			}.ToAggregate().Error()),
+		},
+		"a Bundle with a kubernetes.io/tls Secret target using other keys": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", Key: "ca.crt"}}},
+					Target: trustapi.BundleTarget{
+						Secret: &trustapi.SecretTarget{
+							KeySelector:    trustapi.KeySelector{Key: "trust.pem"},
+							AdditionalKeys: []string{"tls.crt"},
+							Type:           corev1.SecretTypeTLS,
+						},
+						KeyOverrides: []trustapi.KeyOverride{{
+							NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"legacy": "true"}},
+							Key:               "legacy.pem",
+						}},
+					},
+				},
+			},
+			expErr: ptr.To(field.ErrorList{
+				field.Invalid(field.NewPath("spec", "target", "secret", "key"), "trust.pem", "kubernetes.io/tls Secret targets must write the bundle to the ca.crt key"),
+				field.Invalid(field.NewPath("spec", "target", "secret", "additionalKeys").Index(0), "tls.crt", "key is reserved in kubernetes.io/tls Secrets"),
+				field.Forbidden(field.NewPath("spec", "target", "keyOverrides"), "key overrides are not supported with kubernetes.io/tls Secret targets"),
+			}.ToAggregate().Error()),
+		},
+		"valid Bundle including all keys": {
+			bundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle-1"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{ConfigMap: &trustapi.SourceObjectKeySelector{Name: "some-config-map", IncludeAllKeys: true}},
+						{Secret: &trustapi.SourceObjectKeySelector{Name: "some-secret", IncludeAllKeys: true}},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "test-1"}},
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+			},
+			expErr: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			log, _ := ktesting.NewTestContext(t)
+			v := &validator{
+				log:                       log,
+				requireCABasicConstraints: test.requireCA,
+				signingEnabled:            test.signingEnabled,
+				maxBundleSizeBytes:        test.maxBundleSizeBytes,
+				maxCertificates:           test.maxCertificates,
+
+				sourceNamespaceSelectorsEnabled: test.sourceNamespaceSelectorsEnabled,
+				certificateSourcesEnabled:       test.certificateSourcesEnabled,
+			}
+			gotWarnings, gotErr := v.validate(test.bundle, nil)
+			if test.expErr == nil && gotErr != nil {
+				t.Errorf("got an unexpected error: %v", gotErr)
+			} else if test.expErr != nil && (gotErr == nil || *test.expErr != gotErr.Error()) {
+				t.Errorf("wants error: %v got: %v", *test.expErr, gotErr)
+			}
+			assert.Equal(t, test.expWarnings, gotWarnings)
+
+		})
+	}
+}
+
+func Test_validate_update(t *testing.T) {
+	tests := map[string]struct {
+		oldBundle   runtime.Object
+		newBundle   runtime.Object
+		expErr      *string
+		expWarnings admission.Warnings
+	}{
+		"if the target configmap is removed during update": {
+			oldBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{
+							Key: "bar",
+						}},
+					},
+				},
+			},
+			newBundle: &trustapi.Bundle{},
+			expErr:    ptr.To("spec.target.configmap: Invalid value: \"\": target configMap removal is not allowed"),
+		},
+		"if the target configmap is moved to targets during update": {
+			oldBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Target: trustapi.BundleTarget{
+						ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}},
+					},
+				},
+			},
+			newBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}}},
+					},
+				},
+			},
+		},
+		"if an inLine source holding only expired certificates is unchanged during update": {
+			oldBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestExpiredCertificate)},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}}},
+					},
+				},
+			},
+			newBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing", Finalizers: []string{trustapi.BundleRetainTargetsFinalizer}},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestExpiredCertificate)},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}}},
+					},
+				},
+			},
+			expWarnings: admission.Warnings{
+				`spec.sources.[0].inLine: line 1: certificate "O=Internet Widgits Pty Ltd,ST=Some-State,C=AU" expired at 2024-01-12T11:09:24Z`,
+				`spec.sources.[0].inLine: all certificates are expired`,
+			},
+		},
+		"if an inLine source is changed to hold only expired certificates during update": {
+			oldBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestCertificate1)},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}}},
+					},
+				},
+			},
+			newBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Sources: []trustapi.BundleSource{
+						{InLine: ptr.To(dummy.TestExpiredCertificate)},
+					},
+					Targets: []trustapi.BundleTarget{
+						{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "bar"}}},
+					},
+				},
+			},
+			expErr: ptr.To("spec.sources.[0].inLine: Invalid value: all certificates are expired"),
+		},
+		"if the target secret is removed during update": {
+			oldBundle: &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+				Spec: trustapi.BundleSpec{
+					Target: trustapi.BundleTarget{
+						Secret: &trustapi.SecretTarget{
+							KeySelector: trustapi.KeySelector{Key: "bar"},
+						},
+					},
+				},
+			},
+			newBundle: &trustapi.Bundle{},
+			expErr:    ptr.To("spec.target.secret: Invalid value: \"\": target secret removal is not allowed"),
+		},
+	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -494,3 +1599,34 @@ func Test_validate_update(t *testing.T) {
 		})
 	}
 }
+
+func Test_validate_create_deprecations(t *testing.T) {
+	bundle := &trustapi.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "testing"},
+		Spec: trustapi.BundleSpec{
+			Sources: []trustapi.BundleSource{{InLine: ptr.To(dummy.TestCertificate1)}},
+			Target: trustapi.BundleTarget{
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}},
+			},
+		},
+	}
+
+	log, ctx := ktesting.NewTestContext(t)
+	v := &validator{log: log}
+
+	request := func(version string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind: metav1.GroupVersionKind{Group: "trust.cert-manager.io", Version: version, Kind: "Bundle"},
+		}}
+	}
+
+	warnings, err := v.ValidateCreate(admission.NewContextWithRequest(ctx, request("v1alpha1")), bundle)
+	assert.NoError(t, err)
+	assert.Equal(t, admission.Warnings{
+		"spec.target: spec.target is deprecated and not served in trust.cert-manager.io/v1beta1; move the target to spec.targets",
+	}, warnings)
+
+	warnings, err = v.ValidateCreate(admission.NewContextWithRequest(ctx, request("v1beta1")), bundle)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
@@ -17,31 +17,104 @@ limitations under the License.
 package webhook
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+	trustv1beta1 "github.com/cert-manager/trust-manager/pkg/apis/trust/v1beta1"
 )
 
 // Options are options for running the wehook.
 type Options struct {
 	Log logr.Logger
+
+	// Host and Port are the address the webhook server listens on. They are
+	// used by the readiness check to call the webhook.
+	Host string
+	Port int
+
+	// RequireCABasicConstraints rejects InLine sources containing certificates
+	// which aren't CA certificates, unless overridden by the Bundle.
+	RequireCABasicConstraints bool
+
+	// SigningEnabled is true if the controller is configured with a key to
+	// sign Bundles with.
+	SigningEnabled bool
+
+	// SourceNamespaceSelectorsEnabled is true if ConfigMap and Secret sources
+	// may select objects in other Namespaces.
+	SourceNamespaceSelectorsEnabled bool
+
+	// CertificateSourcesEnabled is true if sources may select cert-manager
+	// Certificates.
+	CertificateSourcesEnabled bool
+
+	// MaxBundleSizeBytes and MaxCertificates, if positive, are the maximum
+	// size and number of certificates of a bundle.
+	MaxBundleSizeBytes int
+	MaxCertificates    int
+
+	// Renderer, if set, is used to serve the Bundle render endpoint.
+	Renderer Renderer
+
+	// CertificateWatcher, if set, is the watcher of the serving certificate.
+	// The webhook is only reported as ready while it holds a valid
+	// certificate.
+	CertificateWatcher *CertificateWatcher
+
+	// ShuttingDown, if set, is closed once trust-manager starts to shut down.
+	// The webhook is reported as unready from then on, so that the API server
+	// stops calling this replica before its webhook server stops.
+	ShuttingDown <-chan struct{}
 }
 
 // Register the webhook endpoints against the Manager.
 func Register(mgr manager.Manager, opts Options) error {
 	opts.Log.Info("registering webhook endpoints")
-	validator := &validator{log: opts.Log.WithName("validation")}
+	validator := newValidator(opts, mgr.GetClient())
+	// Bundles are converted between API versions at "/convert", which is
+	// registered along with the validator as long as the scheme knows every
+	// version. v1alpha1 is the hub, and the version Bundles are validated in.
+	if err := trustv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		return fmt.Errorf("error adding v1beta1 to scheme: %v", err)
+	}
 	if err := builder.WebhookManagedBy(mgr).
 		For(&trustapi.Bundle{}).
 		WithValidator(validator).
 		Complete(); err != nil {
 		return fmt.Errorf("error registering webhook: %v", err)
 	}
-	if err := mgr.AddReadyzCheck("validator", mgr.GetWebhookServer().StartedChecker()); err != nil {
+	if opts.Renderer != nil {
+		opts.Log.Info("registering bundle render endpoint", "path", renderPath)
+		mgr.GetWebhookServer().Register(renderPath, &renderHandler{
+			log:       opts.Log.WithName("render"),
+			validator: validator,
+			renderer:  opts.Renderer,
+		})
+	}
+	// The "webhook" check is also served on its own at /readyz/webhook, so it
+	// can be probed independently of the controller checks. Validation reads
+	// Bundles from the cache, so the webhook isn't ready before it has synced.
+	prerequisites := []healthz.Checker{
+		mgr.GetWebhookServer().StartedChecker(),
+		func(req *http.Request) error {
+			if !mgr.GetCache().WaitForCacheSync(req.Context()) {
+				return errors.New("informers not synced")
+			}
+			return nil
+		},
+	}
+	if opts.CertificateWatcher != nil {
+		prerequisites = append(prerequisites, opts.CertificateWatcher.Check)
+	}
+	checker := newReadinessChecker(opts.Host, opts.Port, opts.ShuttingDown, prerequisites...)
+	if err := mgr.AddReadyzCheck("webhook", checker.Check); err != nil {
 		return fmt.Errorf("error adding ready check: %v", err)
 	}
 	return nil
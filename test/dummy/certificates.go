@@ -477,6 +477,41 @@ PQ5qEvRB3rGmtpvWu/p8z4AlMSWFb9C+Qp4NiU2jiPgw0t1DL/vdrvLcYb/ExyJx
 /+ZA+ONCt347Do/oMXy8iT4cmNOe28pHLYHkhkbP5d2ajpjSwqH2Q8Gr8AiMM5OO
 HYjDRRens0uEsJFTfFBq0YbGiIAHZ1ESs/ipdisdgmLkIDjF8UKRNoBacodAsghV
 z40l74JcR+GvcFZWz7/jmJq95YMZ7LawLAr1CaAXxCwsoLbJpbgg4lVo6odACzY=
+-----END CERTIFICATE-----`
+
+	// TestLeafCertificate is a self-signed certificate which is not a CA; its
+	// basic constraints extension has CA set to false.
+	// Certificate:
+	//     Data:
+	//         Version: 3 (0x2)
+	//         Serial Number:
+	//             18:f2:df:c3:31:f7:11:c0:5c:43:bd:17:35:4a:a3:f0
+	//         Signature Algorithm: ecdsa-with-SHA256
+	//         Issuer: O = cert-manager, CN = cmct-test-leaf
+	//         Validity
+	//             Not Before: Nov 25 13:03:54 2022 GMT
+	//             Not After : Nov 25 13:03:54 2052 GMT
+	//         Subject: O = cert-manager, CN = cmct-test-leaf
+	//         X509v3 extensions:
+	//             X509v3 Key Usage: critical
+	//                 Digital Signature
+	//             X509v3 Extended Key Usage:
+	//                 TLS Web Server Authentication
+	//             X509v3 Basic Constraints: critical
+	//                 CA:FALSE
+	//             X509v3 Subject Alternative Name:
+	//                 DNS:leaf.example.com
+	TestLeafCertificate = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVigAwIBAgIQGPLfwzH3EcBcQ70XNUqj8DAKBggqhkjOPQQDAjAwMRUw
+EwYDVQQKEwxjZXJ0LW1hbmFnZXIxFzAVBgNVBAMTDmNtY3QtdGVzdC1sZWFmMCAX
+DTIyMTEyNTEzMDM1NFoYDzIwNTIxMTI1MTMwMzU0WjAwMRUwEwYDVQQKEwxjZXJ0
+LW1hbmFnZXIxFzAVBgNVBAMTDmNtY3QtdGVzdC1sZWFmMFkwEwYHKoZIzj0CAQYI
+KoZIzj0DAQcDQgAEoXMiAZyRzHT7ymRxNKVxM2zm5jVNKcyYAjWW67hN2WPR7X3x
+Y4nudulbKvvpKY1RibU56s+9znIRNfqLoKbSgaNSMFAwDgYDVR0PAQH/BAQDAgeA
+MBMGA1UdJQQMMAoGCCsGAQUFBwMBMAwGA1UdEwEB/wQCMAAwGwYDVR0RBBQwEoIQ
+bGVhZi5leGFtcGxlLmNvbTAKBggqhkjOPQQDAgNJADBGAiEAhNruSQgBqOtbDpnF
+areHWcHesA1xF/lOeLUCMQowV2ECIQDyVxa7jHotUQTkc3PXMqfhptoBJeWsgSx0
+t4zREqh8sg==
 -----END CERTIFICATE-----`
 )
 
@@ -78,6 +78,8 @@ func Test_DummyCertificateSanity(t *testing.T) {
 		"TestCertificate3": TestCertificate3,
 		"TestCertificate4": TestCertificate4,
 		"TestCertificate5": TestCertificate5,
+
+		"TestLeafCertificate": TestLeafCertificate,
 	}
 
 	equalityMap := make(map[string]struct{})
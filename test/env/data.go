@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"strings"
 
-	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -31,7 +30,7 @@ import (
 
 	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
 	bundlectrl "github.com/cert-manager/trust-manager/pkg/bundle"
-	"github.com/cert-manager/trust-manager/pkg/util"
+	"github.com/cert-manager/trust-manager/pkg/selfcheck"
 	"github.com/cert-manager/trust-manager/test/dummy"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -128,17 +127,17 @@ func newTestBundle(ctx context.Context, cl client.Client, opts bundlectrl.Option
 				},
 			},
 			Target: trustapi.BundleTarget{
-				ConfigMap: &td.Target,
+				ConfigMap: &trustapi.ConfigMapTarget{KeySelector: td.Target},
 			},
 		},
 	}
 	if targetType == "ConfigMap" {
 		bundle.Spec.Target = trustapi.BundleTarget{
-			ConfigMap: &td.Target,
+			ConfigMap: &trustapi.ConfigMapTarget{KeySelector: td.Target},
 		}
 	} else if targetType == "Secret" {
 		bundle.Spec.Target = trustapi.BundleTarget{
-			Secret: &td.Target,
+			Secret: &trustapi.SecretTarget{KeySelector: td.Target},
 		}
 	}
 	Expect(cl.Create(ctx, &bundle)).NotTo(HaveOccurred())
@@ -343,32 +342,8 @@ func EventuallyBundleHasSyncedAllNamespacesContains(ctx context.Context, cl clie
 	).Should(Succeed(), fmt.Sprintf("checking bundle %s has synced to all namespaces with correct starting data", bundleName))
 }
 
-// CheckJKSFileSynced ensures that the given JKS data
+// CheckJKSFileSynced ensures that the given JKS data opens with the password
+// and holds exactly the certificates of the expected PEM data.
 func CheckJKSFileSynced(jksData []byte, expectedPassword string, expectedCertPEMData string) error {
-	reader := bytes.NewReader(jksData)
-	certPool := util.NewCertPool(util.WithFilteredExpiredCerts(false))
-
-	ks := jks.New()
-
-	err := ks.Load(reader, []byte(expectedPassword))
-	if err != nil {
-		return err
-	}
-
-	err = certPool.AddCertsFromPEM([]byte(expectedCertPEMData))
-	if err != nil {
-		return fmt.Errorf("invalid PEM data passed to CheckJKSFileSynced: %s", err)
-	}
-
-	// TODO: check that the cert content matches expectedCertPEMData exactly, not just
-	// that the count is the same
-
-	aliasCount := len(ks.Aliases())
-	expectedPEMCount := certPool.Size()
-
-	if aliasCount != expectedPEMCount {
-		return fmt.Errorf("expected %d certificates in JKS but found %d", expectedPEMCount, aliasCount)
-	}
-
-	return nil
+	return selfcheck.CheckJKS(jksData, expectedPassword, expectedCertPEMData)
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package gen
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -67,6 +69,38 @@ func SetBundleTargetAdditionalFormats(formats trustapi.AdditionalFormats) Bundle
 	}
 }
 
+// SetBundlePaused sets the Bundle object's spec paused field as a
+// BundleModifier.
+func SetBundlePaused(paused bool) BundleModifier {
+	return func(bundle *trustapi.Bundle) {
+		bundle.Spec.Paused = paused
+	}
+}
+
+// SetBundleRefreshInterval sets the Bundle object's spec refresh interval as a
+// BundleModifier.
+func SetBundleRefreshInterval(interval time.Duration) BundleModifier {
+	return func(bundle *trustapi.Bundle) {
+		bundle.Spec.RefreshInterval = &metav1.Duration{Duration: interval}
+	}
+}
+
+// SetBundleTargetDeletionPolicy sets the Bundle object's spec target deletion
+// policy as a BundleModifier.
+func SetBundleTargetDeletionPolicy(policy trustapi.DeletionPolicy) BundleModifier {
+	return func(bundle *trustapi.Bundle) {
+		bundle.Spec.Target.DeletionPolicy = policy
+	}
+}
+
+// SetBundleTargetSecret sets the Bundle object's spec target Secret as a
+// BundleModifier.
+func SetBundleTargetSecret(secret trustapi.SecretTarget) BundleModifier {
+	return func(bundle *trustapi.Bundle) {
+		bundle.Spec.Target.Secret = &secret
+	}
+}
+
 // SetResourceVersion sets the Bundle object's resource version as a
 // BundleModifier.
 func SetBundleResourceVersion(resourceVersion string) BundleModifier {
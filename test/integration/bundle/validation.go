@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	trustapi "github.com/cert-manager/trust-manager/pkg/apis/trust/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// The CEL rules of the CRD validate Bundles when the webhook is disabled, so
+// they are tested against the API server without the webhook.
+var _ = Describe("CRD validation", func() {
+	var cl client.Client
+
+	BeforeEach(func() {
+		var err error
+		cl, err = client.New(env.Config, client.Options{
+			Scheme: trustapi.GlobalScheme,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	validSpec := func() trustapi.BundleSpec {
+		return trustapi.BundleSpec{
+			Sources: []trustapi.BundleSource{{InLine: ptr.To("")}},
+			Target:  trustapi.BundleTarget{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}},
+		}
+	}
+
+	DescribeTable("Bundles",
+		func(mutate func(*trustapi.BundleSpec), valid bool) {
+			spec := validSpec()
+			mutate(&spec)
+			bundle := &trustapi.Bundle{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "cel-validation-"},
+				Spec:       spec,
+			}
+
+			err := cl.Create(context.TODO(), bundle)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl.Delete(context.TODO(), bundle)).To(Succeed())
+				return
+			}
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an invalid error, got %v", err)
+		},
+		Entry("should accept a valid Bundle", func(spec *trustapi.BundleSpec) {}, true),
+		Entry("should reject a source with several types", func(spec *trustapi.BundleSpec) {
+			spec.Sources[0].UseDefaultCAs = ptr.To(true)
+		}, false),
+		Entry("should reject Bundles with only disabled sources", func(spec *trustapi.BundleSpec) {
+			spec.Sources = []trustapi.BundleSource{{UseDefaultCAs: ptr.To(false)}}
+		}, false),
+		Entry("should reject a source with both a name and a selector", func(spec *trustapi.BundleSpec) {
+			spec.Sources[0] = trustapi.BundleSource{ConfigMap: &trustapi.SourceObjectKeySelector{
+				Name: "source", Selector: &metav1.LabelSelector{}, Key: "ca.crt",
+			}}
+		}, false),
+		Entry("should reject a source with both a key and includeAllKeys", func(spec *trustapi.BundleSpec) {
+			spec.Sources[0] = trustapi.BundleSource{Secret: &trustapi.SourceObjectKeySelector{
+				Name: "source", Key: "ca.crt", IncludeAllKeys: true,
+			}}
+		}, false),
+		Entry("should reject Bundles without targets", func(spec *trustapi.BundleSpec) {
+			spec.Target = trustapi.BundleTarget{}
+		}, false),
+		Entry("should reject a ConfigMap target in both target and targets", func(spec *trustapi.BundleSpec) {
+			spec.Targets = []trustapi.BundleTarget{{ConfigMap: &trustapi.ConfigMapTarget{KeySelector: trustapi.KeySelector{Key: "other.crt"}}}}
+		}, false),
+		Entry("should reject an additional format written to the target key", func(spec *trustapi.BundleSpec) {
+			spec.Target.AdditionalFormats = &trustapi.AdditionalFormats{JKS: &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "ca.crt"}}}
+		}, false),
+		Entry("should reject additional formats written to the same key", func(spec *trustapi.BundleSpec) {
+			spec.Target.AdditionalFormats = &trustapi.AdditionalFormats{
+				JKS:    &trustapi.JKS{KeySelector: trustapi.KeySelector{Key: "ca.jks"}},
+				PKCS12: &trustapi.PKCS12{KeySelector: trustapi.KeySelector{Key: "ca.jks"}},
+			}
+		}, false),
+		Entry("should reject an additional key equal to the target key", func(spec *trustapi.BundleSpec) {
+			spec.Target.ConfigMap.AdditionalKeys = []string{"ca.crt"}
+		}, false),
+		Entry("should reject a Progressive rollout strategy without progressive", func(spec *trustapi.BundleSpec) {
+			spec.Target.RolloutStrategy = &trustapi.RolloutStrategy{Type: trustapi.RolloutStrategyProgressive}
+		}, false),
+		Entry("should accept a kubernetes.io/tls Secret target", func(spec *trustapi.BundleSpec) {
+			spec.Target = trustapi.BundleTarget{Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "ca.crt"}, Type: corev1.SecretTypeTLS}}
+		}, true),
+		Entry("should reject a kubernetes.io/tls Secret target with another key", func(spec *trustapi.BundleSpec) {
+			spec.Target = trustapi.BundleTarget{Secret: &trustapi.SecretTarget{KeySelector: trustapi.KeySelector{Key: "trust.pem"}, Type: corev1.SecretTypeTLS}}
+		}, false),
+		Entry("should accept an https_web SPIFFE federation source", func(spec *trustapi.BundleSpec) {
+			spec.Sources[0] = trustapi.BundleSource{SPIFFEFederation: &trustapi.SPIFFEFederationSource{
+				TrustDomain: "example.org", EndpointURL: "https://example.org/bundle",
+			}}
+		}, true),
+		Entry("should reject an https_spiffe SPIFFE federation source without an endpoint SPIFFE ID", func(spec *trustapi.BundleSpec) {
+			spec.Sources[0] = trustapi.BundleSource{SPIFFEFederation: &trustapi.SPIFFEFederationSource{
+				TrustDomain: "example.org", EndpointURL: "https://example.org/bundle",
+				Profile: trustapi.SPIFFEBundleEndpointProfileHTTPSSPIFFE,
+			}}
+		}, false),
+	)
+})
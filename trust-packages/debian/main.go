@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
-	"io"
 	"io/fs"
 	"log"
 	"os"
@@ -28,9 +27,13 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 )
 
-var waitFlag = flag.Bool("wait", false, "if true, wait for a signal before exiting\nif false, exit with a status code after copying")
+var (
+	waitFlag          = flag.Bool("wait", false, "if true, wait for a signal before exiting\nif false, exit with a status code after copying")
+	watchIntervalFlag = flag.Duration("watch-interval", 0, "if set, copy changed packages again at this interval and on SIGHUP\nuntil a termination signal, for running as a sidecar; implies -wait")
+)
 
 // usage ensures that printing arg defaults from the flag package goes through the logger
 func usage(logger *log.Logger) func() {
@@ -84,7 +87,65 @@ func main() {
 		stderrLogger.Fatalf("couldn't confirm that output path is a directory that exists: %s", err.Error())
 	}
 
-	walkErr := filepath.Walk(inputDir, func(path string, info fs.FileInfo, err error) error {
+	if err := copyPackages(stderrLogger, inputDir, destinationDir); err != nil {
+		stderrLogger.Fatalf("failed to walk input dir %q: %s", inputDir, err.Error())
+	}
+
+	switch {
+	case *watchIntervalFlag > 0:
+		stderrLogger.Printf("finished copying, copying changed packages every %s or on SIGHUP until a termination signal", *watchIntervalFlag)
+
+		watch(stderrLogger, inputDir, destinationDir, *watchIntervalFlag)
+
+		stderrLogger.Println("received interrupt, closing")
+
+	case *waitFlag:
+		stderrLogger.Printf("finished copying, waiting for termination signal")
+
+		// TODO: if we add the ability to reap zombie processes, this could function as a full init
+
+		sigs := make(chan os.Signal, 1)
+
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		<-sigs
+
+		stderrLogger.Println("received interrupt, closing")
+	}
+}
+
+// watch copies changed packages from inputDir to destinationDir every interval
+// and whenever a SIGHUP is received, until a SIGINT or SIGTERM is received.
+// Failures to copy are logged and retried on the next refresh, so that a
+// sidecar keeps serving the last good packages.
+func watch(logger *log.Logger, inputDir string, destinationDir string, interval time.Duration) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case sig := <-sigs:
+			if sig != syscall.SIGHUP {
+				return
+			}
+			logger.Printf("received SIGHUP, copying changed packages")
+		case <-ticker.C:
+		}
+
+		if err := copyPackages(logger, inputDir, destinationDir); err != nil {
+			logger.Printf("failed to walk input dir %q: %s", inputDir, err.Error())
+		}
+	}
+}
+
+// copyPackages copies each JSON package in inputDir to destinationDir, unless
+// the destination already holds the same package. Packages are written to a
+// temporary file which is renamed into place, so that trust-manager never
+// reads a partially written package when it reloads them.
+func copyPackages(logger *log.Logger, inputDir string, destinationDir string) error {
+	return filepath.Walk(inputDir, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -93,60 +154,53 @@ func main() {
 			return nil
 		}
 
-		var input *os.File
-		var target *os.File
-
-		input, err = os.Open(path)
+		contents, err := os.ReadFile(path) // #nosec G304 -- the input dir is chosen by the operator
 		if err != nil {
-			return fmt.Errorf("failed to open file %q for reading: %w", path, err)
+			return fmt.Errorf("failed to read file %q: %w", path, err)
 		}
 
-		defer func() {
-			err := input.Close()
-			if err != nil {
-				stderrLogger.Printf("failed to close input file: %s", err.Error())
-			}
-		}()
-
 		destinationFile := filepath.Join(destinationDir, filepath.Base(path))
 
-		target, err = os.OpenFile(destinationFile, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0o664)
-		if err != nil {
-			return fmt.Errorf("failed to open file %q for writing: %w", destinationFile, err)
+		existing, err := os.ReadFile(destinationFile) // #nosec G304 -- the output dir is chosen by the operator
+		if err == nil && bytes.Equal(existing, contents) {
+			return nil
 		}
 
-		defer func() {
-			err := target.Close()
-			if err != nil {
-				stderrLogger.Printf("failed to close output file: %s", err.Error())
-			}
-		}()
-
-		if _, err := io.Copy(target, input); err != nil {
+		if err := writeFileAtomically(destinationFile, contents); err != nil {
 			return fmt.Errorf("failed to copy source %q to destination %q: %w", path, destinationFile, err)
 		}
 
-		stderrLogger.Printf("successfully copied %s to %s", path, destinationFile)
+		logger.Printf("successfully copied %s to %s", path, destinationFile)
 
 		return nil
 	})
+}
 
-	if walkErr != nil {
-		stderrLogger.Fatalf("failed to walk input dir %q: %s", inputDir, walkErr.Error())
+// writeFileAtomically writes the contents to a temporary file alongside name,
+// and renames it to name.
+func writeFileAtomically(name string, contents []byte) error {
+	target, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
 	}
+	// The temporary file is already gone once renamed.
+	defer func() { _ = os.Remove(target.Name()) }()
 
-	if *waitFlag {
-		stderrLogger.Printf("finished copying, waiting for termination signal")
-
-		// TODO: if we add the ability to reap zombie processes, this could function as a full init
-
-		sigs := make(chan os.Signal, 1)
+	if _, err := target.Write(contents); err != nil {
+		_ = target.Close()
+		return err
+	}
 
-		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-		<-sigs
+	if err := target.Chmod(0o664); err != nil {
+		_ = target.Close()
+		return err
+	}
 
-		stderrLogger.Println("received interrupt, closing")
+	if err := target.Close(); err != nil {
+		return err
 	}
+
+	return os.Rename(target.Name(), name)
 }
 
 func dirOrError(name string) error {